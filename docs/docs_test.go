@@ -0,0 +1,31 @@
+package docs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSwaggerDocIncludesPaths(t *testing.T) {
+	var doc struct {
+		Paths map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(SwaggerInfo.ReadDoc()), &doc); err != nil {
+		t.Fatalf("failed to parse generated doc: %v", err)
+	}
+
+	for _, path := range []string{
+		"/systems",
+		"/incidents",
+		"/incidents/{id}/updates",
+		"/incident-templates",
+		"/maintenances",
+		"/webhooks",
+		"/webhooks/{id}/test",
+		"/sla/reports",
+		"/sla/breaches",
+	} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("expected path %s in generated doc", path)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"sort"
 	"status-incident/internal/domain"
 	"time"
 )
@@ -11,6 +12,7 @@ import (
 type AnalyticsService struct {
 	analyticsRepo domain.AnalyticsRepository
 	logRepo       domain.StatusLogRepository
+	logRetention  time.Duration
 }
 
 // NewAnalyticsService creates a new AnalyticsService
@@ -57,6 +59,100 @@ func (s *AnalyticsService) GetOverallAnalytics(ctx context.Context, period strin
 	return analytics, nil
 }
 
+// LeaderboardEntry is a system's position in the reliability leaderboard
+type LeaderboardEntry struct {
+	Rank                int     `json:"rank"`
+	SystemID            int64   `json:"system_id"`
+	SystemName          string  `json:"system_name"`
+	UptimePercent       float64 `json:"uptime_percent"`
+	AvailabilityPercent float64 `json:"availability_percent"`
+	TotalIncidents      int     `json:"total_incidents"`
+}
+
+// GetSystemLeaderboard ranks systems by uptime over the period, lowest
+// first when worstFirst is set. Ties are broken by incident count.
+func (s *AnalyticsService) GetSystemLeaderboard(ctx context.Context, systems []*domain.System, period string, worstFirst bool) ([]LeaderboardEntry, error) {
+	perSystem, err := s.uptimeBySystem(ctx, systems, period)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(systems))
+	for i, system := range systems {
+		analytics := perSystem[i]
+		entries = append(entries, LeaderboardEntry{
+			SystemID:            system.ID,
+			SystemName:          system.Name,
+			UptimePercent:       analytics.UptimePercent,
+			AvailabilityPercent: analytics.AvailabilityPercent,
+			TotalIncidents:      analytics.TotalIncidents,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.UptimePercent != b.UptimePercent {
+			if worstFirst {
+				return a.UptimePercent < b.UptimePercent
+			}
+			return a.UptimePercent > b.UptimePercent
+		}
+		if worstFirst {
+			return a.TotalIncidents > b.TotalIncidents
+		}
+		return a.TotalIncidents < b.TotalIncidents
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	return entries, nil
+}
+
+// SystemUptime is a system's uptime over a period, as returned in bulk
+type SystemUptime struct {
+	SystemName          string  `json:"system_name"`
+	UptimePercent       float64 `json:"uptime_percent"`
+	AvailabilityPercent float64 `json:"availability_percent"`
+}
+
+// GetUptimeForSystems computes uptime for all given systems over the same
+// period, keyed by system ID. Each system is still one repository query.
+func (s *AnalyticsService) GetUptimeForSystems(ctx context.Context, systems []*domain.System, period string) (map[int64]SystemUptime, error) {
+	perSystem, err := s.uptimeBySystem(ctx, systems, period)
+	if err != nil {
+		return nil, err
+	}
+
+	uptimes := make(map[int64]SystemUptime, len(systems))
+	for i, system := range systems {
+		analytics := perSystem[i]
+		uptimes[system.ID] = SystemUptime{
+			SystemName:          system.Name,
+			UptimePercent:       analytics.UptimePercent,
+			AvailabilityPercent: analytics.AvailabilityPercent,
+		}
+	}
+
+	return uptimes, nil
+}
+
+// uptimeBySystem fetches analytics for each system over the period, in the
+// same order as systems
+func (s *AnalyticsService) uptimeBySystem(ctx context.Context, systems []*domain.System, period string) ([]*domain.Analytics, error) {
+	start, end := s.parsePeriod(period)
+
+	results := make([]*domain.Analytics, 0, len(systems))
+	for _, system := range systems {
+		analytics, err := s.analyticsRepo.GetUptimeBySystemID(ctx, system.ID, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system analytics: %w", err)
+		}
+		results = append(results, analytics)
+	}
+	return results, nil
+}
+
 // GetSystemIncidents retrieves incident periods for a system
 func (s *AnalyticsService) GetSystemIncidents(ctx context.Context, systemID int64, period string) ([]domain.IncidentPeriod, error) {
 	start, end := s.parsePeriod(period)
@@ -95,6 +191,26 @@ func (s *AnalyticsService) GetAllLogs(ctx context.Context, limit int) ([]*domain
 	return logs, nil
 }
 
+// GetLogsPage retrieves up to limit status logs, newest first, starting at
+// offset, together with the total number of logs
+func (s *AnalyticsService) GetLogsPage(ctx context.Context, limit, offset int) ([]*domain.StatusLog, int, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	logs, err := s.logRepo.GetPage(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	total, err := s.logRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count logs: %w", err)
+	}
+
+	return logs, total, nil
+}
+
 // CreateLog creates a new status log entry (used for import)
 func (s *AnalyticsService) CreateLog(ctx context.Context, log *domain.StatusLog) error {
 	if err := s.logRepo.Create(ctx, log); err != nil {
@@ -103,6 +219,35 @@ func (s *AnalyticsService) CreateLog(ctx context.Context, log *domain.StatusLog)
 	return nil
 }
 
+// SetLogRetention sets how long status logs are kept. Days older than that
+// are no longer rolled up: their logs are gone, so recomputing them would
+// overwrite the existing rollups with 100% uptime.
+func (s *AnalyticsService) SetLogRetention(retention time.Duration) {
+	s.logRetention = retention
+}
+
+// RollupUptime precomputes daily uptime rollups for the last days complete days.
+// Rollups are idempotent, so already rolled days are simply recomputed.
+// Days that started before the log retention cutoff are skipped.
+func (s *AnalyticsService) RollupUptime(ctx context.Context, days int) error {
+	today := time.Now()
+	for i := days; i >= 1; i-- {
+		day := today.AddDate(0, 0, -i)
+		if s.logRetention > 0 && startOfDay(day).Before(today.Add(-s.logRetention)) {
+			continue
+		}
+		if err := s.analyticsRepo.RollupDailyUptime(ctx, day); err != nil {
+			return fmt.Errorf("failed to rollup uptime: %w", err)
+		}
+	}
+	return nil
+}
+
+// startOfDay returns local midnight of t's day, the day a rollup covers
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
 // parsePeriod converts period string to time range
 func (s *AnalyticsService) parsePeriod(period string) (start, end time.Time) {
 	end = time.Now()
@@ -116,7 +261,7 @@ func (s *AnalyticsService) parsePeriod(period string) (start, end time.Time) {
 		start = end.Add(-7 * 24 * time.Hour)
 	case "30d":
 		start = end.Add(-30 * 24 * time.Hour)
-	case "90d":
+	case "90d", "rolling90":
 		start = end.Add(-90 * 24 * time.Hour)
 	default:
 		// Default to 24h
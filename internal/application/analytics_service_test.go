@@ -39,6 +39,49 @@ func TestAnalyticsService_GetSystemAnalytics(t *testing.T) {
 	}
 }
 
+func TestAnalyticsService_GetSystemLeaderboard(t *testing.T) {
+	analyticsRepo := NewMockAnalyticsRepository()
+	uptime := map[int64]float64{1: 99.95, 2: 97.5, 3: 99.5, 4: 97.5}
+	incidents := map[int64]int{1: 0, 2: 2, 3: 1, 4: 5}
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: uptime[systemID], TotalIncidents: incidents[systemID]}, nil
+	}
+	service := NewAnalyticsService(analyticsRepo, NewMockStatusLogRepository())
+
+	var systems []*domain.System
+	for i, name := range []string{"API", "Billing", "Search", "Email"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		system.ID = int64(i + 1)
+		systems = append(systems, system)
+	}
+
+	worst, err := service.GetSystemLeaderboard(context.Background(), systems, "30d", true)
+	if err != nil {
+		t.Fatalf("GetSystemLeaderboard() error = %v", err)
+	}
+	// Equal uptime is broken by incident count
+	want := []string{"Email", "Billing", "Search", "API"}
+	if len(worst) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(worst))
+	}
+	for i, name := range want {
+		if worst[i].SystemName != name || worst[i].Rank != i+1 {
+			t.Errorf("entry %d = %s (rank %d), want %s (rank %d)", i, worst[i].SystemName, worst[i].Rank, name, i+1)
+		}
+	}
+	if worst[0].TotalIncidents != 5 {
+		t.Errorf("expected incident count 5 for Email, got %d", worst[0].TotalIncidents)
+	}
+
+	best, err := service.GetSystemLeaderboard(context.Background(), systems, "30d", false)
+	if err != nil {
+		t.Fatalf("GetSystemLeaderboard() error = %v", err)
+	}
+	if best[0].SystemName != "API" {
+		t.Errorf("expected API first when ordered best, got %s", best[0].SystemName)
+	}
+}
+
 func TestAnalyticsService_GetDependencyAnalytics(t *testing.T) {
 	analyticsRepo := NewMockAnalyticsRepository()
 	logRepo := NewMockStatusLogRepository()
@@ -131,6 +174,43 @@ func TestAnalyticsService_GetAllLogs(t *testing.T) {
 	}
 }
 
+func TestAnalyticsService_GetLogsPage(t *testing.T) {
+	analyticsRepo := NewMockAnalyticsRepository()
+	logRepo := NewMockStatusLogRepository()
+
+	systemID := int64(1)
+	for i := 1; i <= 5; i++ {
+		logRepo.Logs = append(logRepo.Logs, &domain.StatusLog{
+			ID:        int64(i),
+			SystemID:  &systemID,
+			OldStatus: domain.StatusGreen,
+			NewStatus: domain.StatusYellow,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	service := NewAnalyticsService(analyticsRepo, logRepo)
+
+	logs, total, err := service.GetLogsPage(context.Background(), 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected total 5, got %d", total)
+	}
+	if len(logs) != 2 || logs[0].ID != 3 || logs[1].ID != 4 {
+		t.Errorf("expected logs 3 and 4, got %v", logs)
+	}
+
+	logs, total, err = service.GetLogsPage(context.Background(), 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logs) != 0 || total != 5 {
+		t.Errorf("expected empty page with total 5, got %d logs and total %d", len(logs), total)
+	}
+}
+
 func TestAnalyticsService_CreateLog(t *testing.T) {
 	analyticsRepo := NewMockAnalyticsRepository()
 	logRepo := NewMockStatusLogRepository()
@@ -169,6 +249,7 @@ func TestAnalyticsService_parsePeriod(t *testing.T) {
 		{"7d", "7d", 7 * 24 * time.Hour},
 		{"30d", "30d", 30 * 24 * time.Hour},
 		{"90d", "90d", 90 * 24 * time.Hour},
+		{"rolling90", "rolling90", 90 * 24 * time.Hour},
 		{"unknown_defaults_24h", "unknown", 24 * time.Hour},
 		{"365d_defaults_24h", "365d", 24 * time.Hour},
 		{"empty_defaults_24h", "", 24 * time.Hour},
@@ -433,3 +514,30 @@ func TestAnalyticsService_ZeroRecords(t *testing.T) {
 		t.Errorf("expected 0 incidents, got %d", len(incidents))
 	}
 }
+
+func TestAnalyticsService_RollupUptime(t *testing.T) {
+	analyticsRepo := NewMockAnalyticsRepository()
+	var days []time.Time
+	analyticsRepo.RollupDailyUptimeFunc = func(ctx context.Context, day time.Time) error {
+		days = append(days, day)
+		return nil
+	}
+
+	service := NewAnalyticsService(analyticsRepo, NewMockStatusLogRepository())
+	if err := service.RollupUptime(context.Background(), 3); err != nil {
+		t.Fatalf("RollupUptime() error = %v", err)
+	}
+
+	if len(days) != 3 {
+		t.Fatalf("expected 3 days rolled up, got %d", len(days))
+	}
+	today := time.Now().Format("2006-01-02")
+	for i, day := range days {
+		if day.Format("2006-01-02") == today {
+			t.Errorf("day %d: today must not be rolled up", i)
+		}
+		if i > 0 && !day.After(days[i-1]) {
+			t.Errorf("days should be rolled up oldest first")
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package application
+
+import "time"
+
+// Clock is the time source services use for scheduling decisions, so tests
+// can pin the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real Clock, the default of every service
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ClockFunc adapts a function to a Clock
+type ClockFunc func() time.Time
+
+// Now returns f()
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
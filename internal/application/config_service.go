@@ -0,0 +1,913 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// ConfigVersion is the format version of configuration exports
+const ConfigVersion = "2.0"
+
+// Import modes
+const (
+	// ImportModeMerge updates entities that already exist by name and adds the rest
+	ImportModeMerge = "merge"
+	// ImportModeReplace deletes the current configuration before importing
+	ImportModeReplace = "replace"
+)
+
+// ErrInvalidImportMode is returned for an unknown import mode
+var ErrInvalidImportMode = errors.New("import mode must be merge or replace")
+
+// ConfigValidationError lists every problem found in an import document.
+// Nothing is written when an import fails validation.
+type ConfigValidationError struct {
+	Problems []string
+}
+
+func (e *ConfigValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// ConfigDocument is a snapshot of the whole configuration: systems, their
+// dependencies and links, webhooks and maintenance windows. Status, logs and
+// latency history are not part of it. IDs only tie the entries of one
+// document together; imported entities get new IDs.
+type ConfigDocument struct {
+	Version            string              `json:"version"`
+	ExportedAt         time.Time           `json:"exported_at"`
+	Systems            []ConfigSystem      `json:"systems"`
+	SystemDependencies []ConfigSystemLink  `json:"system_dependencies,omitempty"`
+	Dependencies       []ConfigDependency  `json:"dependencies"`
+	Webhooks           []ConfigWebhook     `json:"webhooks,omitempty"`
+	Maintenances       []ConfigMaintenance `json:"maintenances,omitempty"`
+}
+
+// ConfigSystem is the definition of a system
+type ConfigSystem struct {
+	ID                   int64    `json:"id"`
+	Name                 string   `json:"name"`
+	Description          string   `json:"description"`
+	URL                  string   `json:"url"`
+	Owner                string   `json:"owner"`
+	SLATarget            float64  `json:"sla_target,omitempty"` // only when set on the system
+	ResponseTimeTargetMs int64    `json:"response_time_target_ms,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	DisplayOrder         int      `json:"display_order,omitempty"`
+	Slug                 string   `json:"slug,omitempty"`
+}
+
+// ConfigSystemLink is an edge of the system graph
+type ConfigSystemLink struct {
+	SystemID    int64 `json:"system_id"`
+	DependsOnID int64 `json:"depends_on_id"`
+}
+
+// ConfigDependency is the definition of a dependency
+type ConfigDependency struct {
+	ID          int64                   `json:"id"`
+	SystemID    int64                   `json:"system_id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Heartbeat   *domain.HeartbeatConfig `json:"heartbeat,omitempty"`
+	// HeartbeatURL and HeartbeatInterval are read from version 1.0 exports,
+	// which had no full heartbeat configuration
+	HeartbeatURL      string   `json:"heartbeat_url,omitempty"`
+	HeartbeatInterval int      `json:"heartbeat_interval,omitempty"`
+	RecordLatency     *bool    `json:"record_latency,omitempty"`
+	Weight            *float64 `json:"weight,omitempty"`
+	Criticality       string   `json:"criticality,omitempty"`
+}
+
+// ConfigWebhook is the definition of a webhook, including its signing secret
+type ConfigWebhook struct {
+	Name           string   `json:"name"`
+	URL            string   `json:"url"`
+	Type           string   `json:"type"`
+	Events         []string `json:"events,omitempty"`
+	SystemIDs      []int64  `json:"system_ids,omitempty"`
+	PayloadVersion int      `json:"payload_version,omitempty"`
+	IncludeEntity  bool     `json:"include_entity,omitempty"`
+	SlackBlocks    bool     `json:"slack_blocks,omitempty"`
+	Secret         string   `json:"secret,omitempty"`
+	Enabled        *bool    `json:"enabled,omitempty"` // nil = enabled
+
+	PayloadTemplate string                  `json:"payload_template,omitempty"`
+	MinSeverity     domain.IncidentSeverity `json:"min_severity,omitempty"`
+	TriggerStatuses []domain.Status         `json:"trigger_statuses,omitempty"`
+}
+
+// ConfigMaintenance is a scheduled or running maintenance window
+type ConfigMaintenance struct {
+	Title              string     `json:"title"`
+	Description        string     `json:"description,omitempty"`
+	StartTime          time.Time  `json:"start_time"`
+	EndTime            time.Time  `json:"end_time"`
+	SystemIDs          []int64    `json:"system_ids,omitempty"`
+	Timezone           string     `json:"timezone,omitempty"`
+	NotifySubscribers  bool       `json:"notify_subscribers,omitempty"`
+	RecurrenceType     string     `json:"recurrence_type,omitempty"`
+	RecurrenceInterval int        `json:"recurrence_interval,omitempty"`
+	RecurrenceUntil    *time.Time `json:"recurrence_until,omitempty"`
+}
+
+// ConfigImportResult counts what an import created and updated. SystemIDs
+// and DependencyIDs map document IDs to the IDs of the stored entities.
+type ConfigImportResult struct {
+	SystemsCreated      int             `json:"systems_created"`
+	SystemsUpdated      int             `json:"systems_updated"`
+	DependenciesCreated int             `json:"dependencies_created"`
+	DependenciesUpdated int             `json:"dependencies_updated"`
+	SystemLinksCreated  int             `json:"system_dependencies_created"`
+	WebhooksCreated     int             `json:"webhooks_created"`
+	WebhooksUpdated     int             `json:"webhooks_updated"`
+	MaintenancesCreated int             `json:"maintenances_created"`
+	MaintenancesSkipped int             `json:"maintenances_skipped"`
+	SystemIDs           map[int64]int64 `json:"-"`
+	DependencyIDs       map[int64]int64 `json:"-"`
+}
+
+// ConfigService exports and imports the service configuration
+type ConfigService struct {
+	systemRepo      domain.SystemRepository
+	depRepo         domain.DependencyRepository
+	webhookRepo     domain.WebhookRepository
+	maintenanceRepo domain.MaintenanceRepository
+	systemDepRepo   domain.SystemDependencyRepository
+	clock           Clock
+}
+
+// NewConfigService creates a new ConfigService
+func NewConfigService(
+	systemRepo domain.SystemRepository,
+	depRepo domain.DependencyRepository,
+	webhookRepo domain.WebhookRepository,
+	maintenanceRepo domain.MaintenanceRepository,
+) *ConfigService {
+	return &ConfigService{
+		systemRepo:      systemRepo,
+		depRepo:         depRepo,
+		webhookRepo:     webhookRepo,
+		maintenanceRepo: maintenanceRepo,
+		clock:           SystemClock{},
+	}
+}
+
+// SetSystemDependencyRepo includes the system graph in exports and imports
+func (s *ConfigService) SetSystemDependencyRepo(repo domain.SystemDependencyRepository) {
+	s.systemDepRepo = repo
+}
+
+// SetClock sets the clock used to pick current maintenance windows
+func (s *ConfigService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Export returns the current configuration. Maintenance windows that have
+// ended or were cancelled are left out.
+func (s *ConfigService) Export(ctx context.Context) (*ConfigDocument, error) {
+	ctx, span := startSpan(ctx, "ConfigService.Export")
+	defer span.End()
+
+	now := s.clock.Now()
+	doc := &ConfigDocument{
+		Version:      ConfigVersion,
+		ExportedAt:   now,
+		Systems:      make([]ConfigSystem, 0),
+		Dependencies: make([]ConfigDependency, 0),
+	}
+
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	sort.Slice(systems, func(i, j int) bool { return systems[i].ID < systems[j].ID })
+
+	for _, sys := range systems {
+		cs := ConfigSystem{
+			ID:                   sys.ID,
+			Name:                 sys.Name,
+			Description:          sys.Description,
+			URL:                  sys.URL,
+			Owner:                sys.Owner,
+			ResponseTimeTargetMs: sys.ResponseTimeTargetMs,
+			Tags:                 sys.Tags,
+			DisplayOrder:         sys.DisplayOrder,
+			Slug:                 sys.Slug,
+		}
+		if sys.SLATargetExplicit {
+			cs.SLATarget = sys.SLATarget
+		}
+		doc.Systems = append(doc.Systems, cs)
+
+		deps, err := s.depRepo.GetBySystemID(ctx, sys.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of system %d: %w", sys.ID, err)
+		}
+		sort.Slice(deps, func(i, j int) bool { return deps[i].ID < deps[j].ID })
+		for _, dep := range deps {
+			doc.Dependencies = append(doc.Dependencies, exportDependency(dep))
+		}
+	}
+
+	if s.systemDepRepo != nil {
+		edges, err := s.systemDepRepo.GetAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system dependencies: %w", err)
+		}
+		for _, edge := range edges {
+			doc.SystemDependencies = append(doc.SystemDependencies, ConfigSystemLink{
+				SystemID:    edge.SystemID,
+				DependsOnID: edge.DependsOnID,
+			})
+		}
+	}
+
+	webhooks, err := s.webhookRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	for _, w := range webhooks {
+		events := make([]string, len(w.Events))
+		for i, event := range w.Events {
+			events[i] = string(event)
+		}
+		enabled := w.Enabled
+		doc.Webhooks = append(doc.Webhooks, ConfigWebhook{
+			Name:           w.Name,
+			URL:            w.URL,
+			Type:           string(w.Type),
+			Events:         events,
+			SystemIDs:      w.SystemIDs,
+			PayloadVersion: w.PayloadVersion,
+			IncludeEntity:  w.IncludeEntity,
+			SlackBlocks:    w.SlackBlocks,
+			Secret:         w.Secret,
+			Enabled:        &enabled,
+
+			PayloadTemplate: w.PayloadTemplate,
+			MinSeverity:     w.MinSeverity,
+			TriggerStatuses: w.TriggerStatuses,
+		})
+	}
+
+	maintenances, err := s.maintenanceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenances: %w", err)
+	}
+	sort.Slice(maintenances, func(i, j int) bool { return maintenances[i].ID < maintenances[j].ID })
+	for _, m := range maintenances {
+		if m.Status == domain.MaintenanceCancelled || !m.EndTime.After(now) {
+			continue
+		}
+		cm := ConfigMaintenance{
+			Title:             m.Title,
+			Description:       m.Description,
+			StartTime:         m.StartTime,
+			EndTime:           m.EndTime,
+			SystemIDs:         m.SystemIDs,
+			Timezone:          m.Timezone,
+			NotifySubscribers: m.NotifySubscribers,
+		}
+		if m.Recurrence.IsRecurring() {
+			cm.RecurrenceType = string(m.Recurrence.Type)
+			cm.RecurrenceInterval = m.Recurrence.Interval
+			cm.RecurrenceUntil = m.Recurrence.Until
+		}
+		doc.Maintenances = append(doc.Maintenances, cm)
+	}
+
+	return doc, nil
+}
+
+// exportDependency converts a dependency to its definition
+func exportDependency(dep *domain.Dependency) ConfigDependency {
+	cd := ConfigDependency{
+		ID:          dep.ID,
+		SystemID:    dep.SystemID,
+		Name:        dep.Name,
+		Description: dep.Description,
+		Criticality: string(dep.Criticality),
+	}
+	if dep.HasHeartbeat() {
+		config := dep.GetHeartbeatConfig()
+		cd.Heartbeat = &config
+	}
+	recordLatency, weight := dep.RecordLatency, dep.Weight
+	cd.RecordLatency = &recordLatency
+	cd.Weight = &weight
+	return cd
+}
+
+// Import applies doc in the given mode (empty means merge). The whole
+// document is validated first: every system reference must point to a
+// system in the document or, when merging, to an existing system. If
+// anything is wrong a *ConfigValidationError is returned and nothing is
+// written. Maintenance windows are stored without notifying subscribers.
+func (s *ConfigService) Import(ctx context.Context, doc *ConfigDocument, mode string) (*ConfigImportResult, error) {
+	ctx, span := startSpan(ctx, "ConfigService.Import")
+	defer span.End()
+
+	if mode == "" {
+		mode = ImportModeMerge
+	}
+	if mode != ImportModeMerge && mode != ImportModeReplace {
+		return nil, ErrInvalidImportMode
+	}
+
+	existing, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	if mode == ImportModeReplace {
+		existing = nil
+	}
+
+	if problems := s.validate(doc, existing); len(problems) > 0 {
+		return nil, &ConfigValidationError{Problems: problems}
+	}
+
+	if mode == ImportModeReplace {
+		if err := s.wipe(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ConfigImportResult{
+		SystemIDs:     make(map[int64]int64),
+		DependencyIDs: make(map[int64]int64),
+	}
+	if err := s.importSystems(ctx, doc, existing, result); err != nil {
+		return nil, err
+	}
+
+	// References may also point to systems that were already there
+	systemIDs := make(map[int64]int64, len(existing)+len(result.SystemIDs))
+	for _, sys := range existing {
+		systemIDs[sys.ID] = sys.ID
+	}
+	for docID, id := range result.SystemIDs {
+		systemIDs[docID] = id
+	}
+
+	if err := s.importDependencies(ctx, doc, systemIDs, result); err != nil {
+		return nil, err
+	}
+	if err := s.importSystemLinks(ctx, doc, systemIDs, result); err != nil {
+		return nil, err
+	}
+	if err := s.importWebhooks(ctx, doc, mode, systemIDs, result); err != nil {
+		return nil, err
+	}
+	if err := s.importMaintenances(ctx, doc, mode, systemIDs, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validate checks the document against itself and the existing systems
+// by building every entity without storing it
+func (s *ConfigService) validate(doc *ConfigDocument, existing []*domain.System) []string {
+	var problems []string
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if doc.Version != "" && !strings.HasPrefix(doc.Version, "1.") && !strings.HasPrefix(doc.Version, "2.") {
+		addf("unsupported version %q", doc.Version)
+	}
+
+	// known holds every system ID a reference may point to
+	known := make(map[int64]bool)
+	for _, sys := range existing {
+		known[sys.ID] = true
+	}
+
+	docIDs := make(map[int64]bool)
+	names := make(map[string]bool)
+	slugs := make(map[string]bool)
+	for _, cs := range doc.Systems {
+		if docIDs[cs.ID] {
+			addf("system %q: duplicate id %d", cs.Name, cs.ID)
+		}
+		docIDs[cs.ID] = true
+		known[cs.ID] = true
+
+		key := strings.ToLower(strings.TrimSpace(cs.Name))
+		if names[key] {
+			addf("system %q: duplicate name", cs.Name)
+		}
+		names[key] = true
+
+		sys, err := buildSystem(cs)
+		if err != nil {
+			addf("system %q: %v", cs.Name, err)
+			continue
+		}
+		if sys.Slug != "" {
+			if slugs[sys.Slug] {
+				addf("system %q: duplicate slug %q", cs.Name, sys.Slug)
+			}
+			slugs[sys.Slug] = true
+		}
+	}
+
+	depIDs := make(map[int64]bool)
+	depNames := make(map[string]bool)
+	for _, cd := range doc.Dependencies {
+		if cd.ID != 0 && depIDs[cd.ID] {
+			addf("dependency %q: duplicate id %d", cd.Name, cd.ID)
+		}
+		depIDs[cd.ID] = true
+
+		if !known[cd.SystemID] {
+			addf("dependency %q: system %d not found", cd.Name, cd.SystemID)
+			continue
+		}
+		key := fmt.Sprintf("%d/%s", cd.SystemID, strings.ToLower(strings.TrimSpace(cd.Name)))
+		if depNames[key] {
+			addf("dependency %q: duplicate name in system %d", cd.Name, cd.SystemID)
+		}
+		depNames[key] = true
+
+		dep, err := domain.NewDependency(cd.SystemID, cd.Name, cd.Description)
+		if err == nil {
+			err = applyDependencyConfig(dep, cd)
+		}
+		if err != nil {
+			addf("dependency %q: %v", cd.Name, err)
+		}
+	}
+
+	var edges []*domain.SystemDependency
+	for _, link := range doc.SystemDependencies {
+		if !known[link.SystemID] || !known[link.DependsOnID] {
+			addf("system dependency %d -> %d: system not found", link.SystemID, link.DependsOnID)
+			continue
+		}
+		edge, err := domain.NewSystemDependency(link.SystemID, link.DependsOnID)
+		if err != nil {
+			addf("system dependency %d -> %d: %v", link.SystemID, link.DependsOnID, err)
+			continue
+		}
+		if domain.CreatesCycle(edges, link.SystemID, link.DependsOnID) {
+			addf("system dependency %d -> %d: %v", link.SystemID, link.DependsOnID, domain.ErrDependencyCycle)
+			continue
+		}
+		edges = append(edges, edge)
+	}
+
+	webhookNames := make(map[string]bool)
+	for _, cw := range doc.Webhooks {
+		key := strings.ToLower(strings.TrimSpace(cw.Name))
+		if webhookNames[key] {
+			addf("webhook %q: duplicate name", cw.Name)
+		}
+		webhookNames[key] = true
+
+		for _, id := range cw.SystemIDs {
+			if !known[id] {
+				addf("webhook %q: system %d not found", cw.Name, id)
+			}
+		}
+		if _, err := buildWebhook(cw, nil); err != nil {
+			addf("webhook %q: %v", cw.Name, err)
+		}
+	}
+
+	for _, cm := range doc.Maintenances {
+		for _, id := range cm.SystemIDs {
+			if !known[id] {
+				addf("maintenance %q: system %d not found", cm.Title, id)
+			}
+		}
+		if _, err := buildMaintenance(cm, nil); err != nil {
+			addf("maintenance %q: %v", cm.Title, err)
+		}
+	}
+
+	return problems
+}
+
+// wipe deletes the current configuration for a replace import
+func (s *ConfigService) wipe(ctx context.Context) error {
+	if s.systemDepRepo != nil {
+		edges, err := s.systemDepRepo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get system dependencies: %w", err)
+		}
+		for _, edge := range edges {
+			if err := s.systemDepRepo.Delete(ctx, edge.SystemID, edge.DependsOnID); err != nil {
+				return fmt.Errorf("failed to delete system dependency: %w", err)
+			}
+		}
+	}
+
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get systems: %w", err)
+	}
+	for _, sys := range systems {
+		deps, err := s.depRepo.GetBySystemID(ctx, sys.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get dependencies: %w", err)
+		}
+		for _, dep := range deps {
+			if err := s.depRepo.Delete(ctx, dep.ID); err != nil {
+				return fmt.Errorf("failed to delete dependency: %w", err)
+			}
+		}
+		if err := s.systemRepo.Delete(ctx, sys.ID); err != nil {
+			return fmt.Errorf("failed to delete system: %w", err)
+		}
+	}
+
+	webhooks, err := s.webhookRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	for _, w := range webhooks {
+		if err := s.webhookRepo.Delete(ctx, w.ID); err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+	}
+
+	maintenances, err := s.maintenanceRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenances: %w", err)
+	}
+	for _, m := range maintenances {
+		if err := s.maintenanceRepo.Delete(ctx, m.ID); err != nil {
+			return fmt.Errorf("failed to delete maintenance: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// importSystems creates the document's systems, or updates the existing
+// system of the same name
+func (s *ConfigService) importSystems(ctx context.Context, doc *ConfigDocument, existing []*domain.System, result *ConfigImportResult) error {
+	byName := make(map[string]*domain.System, len(existing))
+	for _, sys := range existing {
+		byName[strings.ToLower(sys.Name)] = sys
+	}
+
+	for _, cs := range doc.Systems {
+		if sys, ok := byName[strings.ToLower(strings.TrimSpace(cs.Name))]; ok {
+			if err := sys.Update(cs.Name, cs.Description, cs.URL, cs.Owner); err != nil {
+				return fmt.Errorf("system %q: %w", cs.Name, err)
+			}
+			if err := applySystemConfig(sys, cs); err != nil {
+				return fmt.Errorf("system %q: %w", cs.Name, err)
+			}
+			if err := s.systemRepo.Update(ctx, sys); err != nil {
+				return fmt.Errorf("failed to update system %q: %w", cs.Name, err)
+			}
+			result.SystemIDs[cs.ID] = sys.ID
+			result.SystemsUpdated++
+			continue
+		}
+
+		sys, err := buildSystem(cs)
+		if err != nil {
+			return fmt.Errorf("system %q: %w", cs.Name, err)
+		}
+		if err := s.systemRepo.Create(ctx, sys); err != nil {
+			return fmt.Errorf("failed to create system %q: %w", cs.Name, err)
+		}
+		result.SystemIDs[cs.ID] = sys.ID
+		result.SystemsCreated++
+	}
+	return nil
+}
+
+// importDependencies creates the document's dependencies, or updates the
+// existing dependency of the same name in the system, keeping its status
+func (s *ConfigService) importDependencies(ctx context.Context, doc *ConfigDocument, systemIDs map[int64]int64, result *ConfigImportResult) error {
+	current := make(map[int64][]*domain.Dependency)
+
+	for _, cd := range doc.Dependencies {
+		systemID := systemIDs[cd.SystemID]
+
+		deps, ok := current[systemID]
+		if !ok {
+			var err error
+			if deps, err = s.depRepo.GetBySystemID(ctx, systemID); err != nil {
+				return fmt.Errorf("failed to get dependencies: %w", err)
+			}
+			current[systemID] = deps
+		}
+
+		var dep *domain.Dependency
+		for _, d := range deps {
+			if strings.EqualFold(d.Name, strings.TrimSpace(cd.Name)) {
+				dep = d
+				break
+			}
+		}
+
+		if dep != nil {
+			if err := dep.Update(cd.Name, cd.Description); err != nil {
+				return fmt.Errorf("dependency %q: %w", cd.Name, err)
+			}
+			if err := applyDependencyConfig(dep, cd); err != nil {
+				return fmt.Errorf("dependency %q: %w", cd.Name, err)
+			}
+			if err := s.depRepo.Update(ctx, dep); err != nil {
+				return fmt.Errorf("failed to update dependency %q: %w", cd.Name, err)
+			}
+			result.DependenciesUpdated++
+		} else {
+			var err error
+			if dep, err = domain.NewDependency(systemID, cd.Name, cd.Description); err != nil {
+				return fmt.Errorf("dependency %q: %w", cd.Name, err)
+			}
+			if err := applyDependencyConfig(dep, cd); err != nil {
+				return fmt.Errorf("dependency %q: %w", cd.Name, err)
+			}
+			if err := s.depRepo.Create(ctx, dep); err != nil {
+				return fmt.Errorf("failed to create dependency %q: %w", cd.Name, err)
+			}
+			current[systemID] = append(deps, dep)
+			result.DependenciesCreated++
+		}
+
+		if cd.ID != 0 {
+			result.DependencyIDs[cd.ID] = dep.ID
+		}
+	}
+	return nil
+}
+
+// importSystemLinks creates the edges of the system graph that are missing
+func (s *ConfigService) importSystemLinks(ctx context.Context, doc *ConfigDocument, systemIDs map[int64]int64, result *ConfigImportResult) error {
+	if s.systemDepRepo == nil || len(doc.SystemDependencies) == 0 {
+		return nil
+	}
+
+	edges, err := s.systemDepRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get system dependencies: %w", err)
+	}
+	for _, link := range doc.SystemDependencies {
+		systemID, dependsOnID := systemIDs[link.SystemID], systemIDs[link.DependsOnID]
+
+		exists := false
+		for _, edge := range edges {
+			if edge.SystemID == systemID && edge.DependsOnID == dependsOnID {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		edge, err := domain.NewSystemDependency(systemID, dependsOnID)
+		if err != nil {
+			return fmt.Errorf("system dependency %d -> %d: %w", link.SystemID, link.DependsOnID, err)
+		}
+		if domain.CreatesCycle(edges, systemID, dependsOnID) {
+			return fmt.Errorf("system dependency %d -> %d: %w", link.SystemID, link.DependsOnID, domain.ErrDependencyCycle)
+		}
+		if err := s.systemDepRepo.Create(ctx, edge); err != nil {
+			return fmt.Errorf("failed to create system dependency: %w", err)
+		}
+		edges = append(edges, edge)
+		result.SystemLinksCreated++
+	}
+	return nil
+}
+
+// importWebhooks creates the document's webhooks; when merging, a webhook
+// with the same name is updated instead
+func (s *ConfigService) importWebhooks(ctx context.Context, doc *ConfigDocument, mode string, systemIDs map[int64]int64, result *ConfigImportResult) error {
+	var existing []*domain.Webhook
+	if mode == ImportModeMerge && len(doc.Webhooks) > 0 {
+		var err error
+		if existing, err = s.webhookRepo.GetAll(ctx); err != nil {
+			return fmt.Errorf("failed to get webhooks: %w", err)
+		}
+	}
+
+	for _, cw := range doc.Webhooks {
+		cw.SystemIDs = mapSystemIDs(cw.SystemIDs, systemIDs)
+
+		var current *domain.Webhook
+		for _, w := range existing {
+			if strings.EqualFold(w.Name, strings.TrimSpace(cw.Name)) {
+				current = w
+				break
+			}
+		}
+
+		w, err := buildWebhook(cw, current)
+		if err != nil {
+			return fmt.Errorf("webhook %q: %w", cw.Name, err)
+		}
+		if current != nil {
+			if err := s.webhookRepo.Update(ctx, w); err != nil {
+				return fmt.Errorf("failed to update webhook %q: %w", cw.Name, err)
+			}
+			result.WebhooksUpdated++
+			continue
+		}
+		if err := s.webhookRepo.Create(ctx, w); err != nil {
+			return fmt.Errorf("failed to create webhook %q: %w", cw.Name, err)
+		}
+		result.WebhooksCreated++
+	}
+	return nil
+}
+
+// importMaintenances stores the document's maintenance windows; when
+// merging, a window with the same title and start time is skipped
+func (s *ConfigService) importMaintenances(ctx context.Context, doc *ConfigDocument, mode string, systemIDs map[int64]int64, result *ConfigImportResult) error {
+	var existing []*domain.Maintenance
+	if mode == ImportModeMerge && len(doc.Maintenances) > 0 {
+		var err error
+		if existing, err = s.maintenanceRepo.GetAll(ctx); err != nil {
+			return fmt.Errorf("failed to get maintenances: %w", err)
+		}
+	}
+
+	for _, cm := range doc.Maintenances {
+		duplicate := false
+		for _, m := range existing {
+			if m.Title == cm.Title && m.StartTime.Equal(cm.StartTime) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			result.MaintenancesSkipped++
+			continue
+		}
+
+		cm.SystemIDs = mapSystemIDs(cm.SystemIDs, systemIDs)
+		m, err := buildMaintenance(cm, s.clock)
+		if err != nil {
+			return fmt.Errorf("maintenance %q: %w", cm.Title, err)
+		}
+		if err := s.maintenanceRepo.Create(ctx, m); err != nil {
+			return fmt.Errorf("failed to create maintenance %q: %w", cm.Title, err)
+		}
+		result.MaintenancesCreated++
+	}
+	return nil
+}
+
+// buildSystem creates a system from its definition
+func buildSystem(cs ConfigSystem) (*domain.System, error) {
+	sys, err := domain.NewSystem(cs.Name, cs.Description, cs.URL, cs.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySystemConfig(sys, cs); err != nil {
+		return nil, err
+	}
+	return sys, nil
+}
+
+// applySystemConfig sets the optional settings of a system definition
+func applySystemConfig(sys *domain.System, cs ConfigSystem) error {
+	sys.SetSLATarget(cs.SLATarget)
+	sys.SetResponseTimeTarget(cs.ResponseTimeTargetMs)
+	sys.SetTags(cs.Tags)
+	sys.SetDisplayOrder(cs.DisplayOrder)
+	return sys.SetSlug(cs.Slug)
+}
+
+// applyDependencyConfig sets the heartbeat and SLA settings of a
+// dependency definition; settings missing from the definition keep
+// their current value
+func applyDependencyConfig(dep *domain.Dependency, cd ConfigDependency) error {
+	switch {
+	case cd.Heartbeat != nil:
+		if err := dep.SetHeartbeatConfig(*cd.Heartbeat); err != nil {
+			return err
+		}
+	case cd.HeartbeatURL != "":
+		if err := dep.SetHeartbeat(cd.HeartbeatURL, cd.HeartbeatInterval); err != nil {
+			return err
+		}
+	default:
+		dep.ClearHeartbeat()
+	}
+
+	if cd.RecordLatency != nil {
+		dep.SetRecordLatency(*cd.RecordLatency)
+	}
+	if cd.Weight != nil {
+		if err := dep.SetWeight(*cd.Weight); err != nil {
+			return err
+		}
+	}
+	if cd.Criticality != "" {
+		if err := dep.SetCriticality(domain.Criticality(cd.Criticality)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildWebhook applies a webhook definition to current, or to a new
+// webhook when current is nil
+func buildWebhook(cw ConfigWebhook, current *domain.Webhook) (*domain.Webhook, error) {
+	webhookType := domain.WebhookType(cw.Type)
+
+	w := current
+	if w == nil {
+		var err error
+		if w, err = domain.NewWebhook(cw.Name, cw.URL, webhookType); err != nil {
+			return nil, err
+		}
+	} else if err := w.Update(cw.Name, cw.URL, webhookType); err != nil {
+		return nil, err
+	}
+
+	if len(cw.Events) > 0 {
+		events := make([]domain.WebhookEvent, len(cw.Events))
+		for i, name := range cw.Events {
+			events[i] = domain.WebhookEvent(name)
+			if !domain.IsValidWebhookEvent(events[i]) {
+				return nil, fmt.Errorf("unknown webhook event: %s", name)
+			}
+		}
+		w.SetEvents(events)
+	}
+	w.SetSystemIDs(cw.SystemIDs)
+	if err := w.SetPayloadVersion(cw.PayloadVersion); err != nil {
+		return nil, err
+	}
+	w.SetIncludeEntity(cw.IncludeEntity)
+	w.SetSlackBlocks(cw.SlackBlocks)
+	if err := w.SetPayloadTemplate(cw.PayloadTemplate); err != nil {
+		return nil, err
+	}
+	w.SetSecret(cw.Secret)
+	if err := w.SetMinSeverity(cw.MinSeverity); err != nil {
+		return nil, err
+	}
+	if err := w.SetTriggerStatuses(cw.TriggerStatuses); err != nil {
+		return nil, err
+	}
+	if enabled := cw.Enabled == nil || *cw.Enabled; enabled != w.Enabled {
+		if enabled {
+			w.Enable()
+		} else {
+			w.Disable()
+		}
+	}
+	return w, nil
+}
+
+// buildMaintenance creates a maintenance window from its definition. With
+// a clock its status is refreshed to the current time.
+func buildMaintenance(cm ConfigMaintenance, clock Clock) (*domain.Maintenance, error) {
+	m, err := domain.NewMaintenance(cm.Title, cm.Description, cm.StartTime, cm.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	m.SetSystemIDs(cm.SystemIDs)
+	if cm.Timezone != "" {
+		if err := m.SetTimezone(cm.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	m.SetNotifySubscribers(cm.NotifySubscribers)
+	if cm.RecurrenceType != "" {
+		if err := m.SetRecurrence(domain.Recurrence{
+			Type:     domain.RecurrenceType(cm.RecurrenceType),
+			Interval: cm.RecurrenceInterval,
+			Until:    cm.RecurrenceUntil,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if clock != nil {
+		m.RefreshStatusAt(clock.Now())
+	}
+	return m, nil
+}
+
+// mapSystemIDs translates document system IDs to stored ones
+func mapSystemIDs(ids []int64, mapping map[int64]int64) []int64 {
+	if len(ids) == 0 {
+		return ids
+	}
+	mapped := make([]int64, len(ids))
+	for i, id := range ids {
+		mapped[i] = mapping[id]
+	}
+	return mapped
+}
@@ -0,0 +1,325 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+type configFixture struct {
+	systems      *MockSystemRepository
+	deps         *MockDependencyRepository
+	webhooks     *MockWebhookRepository
+	maintenances *MockMaintenanceRepository
+	edges        *MockSystemDependencyRepository
+	service      *ConfigService
+}
+
+func newConfigFixture(now time.Time) *configFixture {
+	f := &configFixture{
+		systems:      NewMockSystemRepository(),
+		deps:         NewMockDependencyRepository(),
+		webhooks:     NewMockWebhookRepository(),
+		maintenances: NewMockMaintenanceRepository(),
+		edges:        NewMockSystemDependencyRepository(),
+	}
+	f.service = NewConfigService(f.systems, f.deps, f.webhooks, f.maintenances)
+	f.service.SetSystemDependencyRepo(f.edges)
+	f.service.SetClock(ClockFunc(func() time.Time { return now }))
+	return f
+}
+
+// seed stores two linked systems with dependencies, a webhook and a
+// recurring maintenance window
+func (f *configFixture) seed(t *testing.T, now time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	api, _ := domain.NewSystem("API", "Public API", "https://api.example.com", "Backend")
+	api.SetSLATarget(99.95)
+	api.SetResponseTimeTarget(300)
+	api.SetTags([]string{"production"})
+	api.SetDisplayOrder(1)
+	f.systems.Create(ctx, api)
+
+	db, _ := domain.NewSystem("Database", "Primary cluster", "", "DBA")
+	f.systems.Create(ctx, db)
+
+	edge, _ := domain.NewSystemDependency(api.ID, db.ID)
+	f.edges.Create(ctx, edge)
+
+	gateway, _ := domain.NewDependency(api.ID, "Gateway", "Edge proxy")
+	if err := gateway.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:          "https://gw.example.com/health",
+		Interval:     30,
+		Method:       "POST",
+		Headers:      map[string]string{"Authorization": "Bearer secret"},
+		ExpectStatus: "2xx",
+		Retries:      2,
+	}); err != nil {
+		t.Fatalf("failed to set heartbeat: %v", err)
+	}
+	gateway.SetWeight(2)
+	gateway.SetCriticality(domain.CriticalityMajor)
+	gateway.SetRecordLatency(false)
+	f.deps.Create(ctx, gateway)
+
+	replica, _ := domain.NewDependency(db.ID, "Replica", "Read replica")
+	f.deps.Create(ctx, replica)
+
+	hook, _ := domain.NewWebhook("Ops", "https://hooks.example.com/ops", domain.WebhookTypeGeneric)
+	hook.SetEvents([]domain.WebhookEvent{domain.EventStatusChange})
+	hook.SetSystemIDs([]int64{db.ID})
+	hook.SetSecret("s3cret")
+	hook.SetIncludeEntity(true)
+	hook.SetTriggerStatuses([]domain.Status{domain.StatusRed})
+	f.webhooks.Create(ctx, hook)
+
+	start := now.Add(24 * time.Hour).Truncate(time.Second)
+	window, _ := domain.NewMaintenance("DB patching", "Monthly patches", start, start.Add(time.Hour))
+	window.SetSystemIDs([]int64{db.ID})
+	window.SetTimezone("Europe/Riga")
+	window.SetRecurrence(domain.Recurrence{Type: domain.RecurrenceMonthly, Interval: 1})
+	f.maintenances.Create(ctx, window)
+
+	// Past windows are history, not configuration
+	past, _ := domain.NewMaintenance("Old upgrade", "", now.Add(-48*time.Hour), now.Add(-47*time.Hour))
+	f.maintenances.Create(ctx, past)
+}
+
+func TestConfigService_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newConfigFixture(now)
+	f.seed(t, now)
+
+	exported, err := f.service.Export(ctx)
+	if err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	if len(exported.Systems) != 2 || len(exported.Dependencies) != 2 || len(exported.SystemDependencies) != 1 ||
+		len(exported.Webhooks) != 1 || len(exported.Maintenances) != 1 {
+		t.Fatalf("unexpected export contents: %+v", exported)
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("failed to marshal export: %v", err)
+	}
+
+	// Wipe everything, including the runtime state of a dependency
+	f.deps.Dependencies[1].RecordCheckFailure(500)
+	if _, err := f.service.Import(ctx, &ConfigDocument{}, ImportModeReplace); err != nil {
+		t.Fatalf("unexpected wipe error: %v", err)
+	}
+	if len(f.systems.Systems) != 0 || len(f.deps.Dependencies) != 0 || len(f.edges.Edges) != 0 ||
+		len(f.webhooks.Webhooks) != 0 || len(f.maintenances.Maintenances) != 0 {
+		t.Fatal("expected replace with an empty document to remove everything")
+	}
+
+	var doc ConfigDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to unmarshal export: %v", err)
+	}
+	result, err := f.service.Import(ctx, &doc, ImportModeReplace)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if result.SystemsCreated != 2 || result.DependenciesCreated != 2 || result.SystemLinksCreated != 1 ||
+		result.WebhooksCreated != 1 || result.MaintenancesCreated != 1 {
+		t.Errorf("unexpected import counts: %+v", result)
+	}
+
+	reexported, err := f.service.Export(ctx)
+	if err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	if !reflect.DeepEqual(exported, reexported) {
+		first, _ := json.Marshal(exported)
+		second, _ := json.Marshal(reexported)
+		t.Errorf("configuration changed in the round trip\nbefore: %s\nafter:  %s", first, second)
+	}
+
+	for _, dep := range f.deps.Dependencies {
+		if dep.Status != domain.StatusGreen || dep.ConsecutiveFailures != 0 {
+			t.Errorf("expected %s to start with fresh runtime state, got %s with %d failures", dep.Name, dep.Status, dep.ConsecutiveFailures)
+		}
+	}
+	for _, m := range f.maintenances.Maintenances {
+		if m.Status != domain.MaintenanceScheduled {
+			t.Errorf("expected imported window to be scheduled, got %s", m.Status)
+		}
+	}
+}
+
+func TestConfigService_ImportMerge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newConfigFixture(now)
+
+	// An unrelated system keeps ID 1, so imported IDs must be remapped
+	other, _ := domain.NewSystem("Billing", "", "", "")
+	f.systems.Create(ctx, other)
+	api, _ := domain.NewSystem("api", "Old description", "", "")
+	f.systems.Create(ctx, api)
+	gateway, _ := domain.NewDependency(api.ID, "Gateway", "")
+	gateway.RecordCheckFailure(100)
+	f.deps.Create(ctx, gateway)
+
+	doc := &ConfigDocument{
+		Systems: []ConfigSystem{
+			{ID: 10, Name: "API", Description: "Public API"},
+			{ID: 11, Name: "Search"},
+		},
+		SystemDependencies: []ConfigSystemLink{{SystemID: 10, DependsOnID: 11}},
+		Dependencies: []ConfigDependency{
+			{ID: 20, SystemID: 10, Name: "gateway", Description: "Edge proxy", HeartbeatURL: "https://gw.example.com/health", HeartbeatInterval: 60},
+			{ID: 21, SystemID: 11, Name: "Index"},
+			// References to existing systems are allowed when merging
+			{ID: 22, SystemID: other.ID, Name: "Ledger"},
+		},
+		Webhooks: []ConfigWebhook{{Name: "Search alerts", URL: "https://hooks.example.com/search", Type: "generic", SystemIDs: []int64{11}}},
+	}
+
+	result, err := f.service.Import(ctx, doc, ImportModeMerge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SystemsCreated != 1 || result.SystemsUpdated != 1 || result.DependenciesCreated != 2 || result.DependenciesUpdated != 1 {
+		t.Errorf("unexpected import counts: %+v", result)
+	}
+	if len(f.systems.Systems) != 3 {
+		t.Fatalf("expected 3 systems, got %d", len(f.systems.Systems))
+	}
+
+	if api.Name != "API" || api.Description != "Public API" {
+		t.Errorf("expected existing system updated, got %q %q", api.Name, api.Description)
+	}
+	if gateway.HeartbeatURL != "https://gw.example.com/health" || gateway.Description != "Edge proxy" {
+		t.Errorf("expected existing dependency updated, got %+v", gateway)
+	}
+	if gateway.ConsecutiveFailures != 1 {
+		t.Errorf("expected merge to keep runtime state, got %d failures", gateway.ConsecutiveFailures)
+	}
+
+	searchID := result.SystemIDs[11]
+	if searchID == 0 || searchID == 11 || f.systems.Systems[searchID].Name != "Search" {
+		t.Fatalf("expected Search mapped to its new ID, got %d", searchID)
+	}
+	if result.SystemIDs[10] != api.ID {
+		t.Errorf("expected API mapped to existing ID %d, got %d", api.ID, result.SystemIDs[10])
+	}
+	index := f.deps.Dependencies[result.DependencyIDs[21]]
+	if index == nil || index.SystemID != searchID {
+		t.Errorf("expected Index on system %d, got %+v", searchID, index)
+	}
+	if ledger := f.deps.Dependencies[result.DependencyIDs[22]]; ledger == nil || ledger.SystemID != other.ID {
+		t.Errorf("expected Ledger on existing system %d, got %+v", other.ID, ledger)
+	}
+	if len(f.edges.Edges) != 1 || f.edges.Edges[0].SystemID != api.ID || f.edges.Edges[0].DependsOnID != searchID {
+		t.Errorf("expected edge API -> Search, got %+v", f.edges.Edges)
+	}
+	for _, w := range f.webhooks.Webhooks {
+		if !reflect.DeepEqual(w.SystemIDs, []int64{searchID}) {
+			t.Errorf("expected webhook scoped to %d, got %v", searchID, w.SystemIDs)
+		}
+	}
+
+	// Importing the same document again changes nothing new
+	again, err := f.service.Import(ctx, doc, ImportModeMerge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.SystemsCreated != 0 || again.DependenciesCreated != 0 || again.SystemLinksCreated != 0 || again.WebhooksCreated != 0 {
+		t.Errorf("expected repeated merge to create nothing, got %+v", again)
+	}
+}
+
+func TestConfigService_ImportValidation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		mode string
+		doc  ConfigDocument
+	}{
+		{
+			name: "dependency of unknown system",
+			doc: ConfigDocument{
+				Systems:      []ConfigSystem{{ID: 1, Name: "API"}},
+				Dependencies: []ConfigDependency{{SystemID: 2, Name: "Redis"}},
+			},
+		},
+		{
+			name: "existing system is gone after replace",
+			mode: ImportModeReplace,
+			doc: ConfigDocument{
+				Dependencies: []ConfigDependency{{SystemID: 1, Name: "Redis"}},
+			},
+		},
+		{
+			name: "duplicate system names",
+			doc: ConfigDocument{
+				Systems: []ConfigSystem{{ID: 1, Name: "API"}, {ID: 2, Name: "api"}},
+			},
+		},
+		{
+			name: "invalid heartbeat",
+			doc: ConfigDocument{
+				Systems:      []ConfigSystem{{ID: 1, Name: "API"}},
+				Dependencies: []ConfigDependency{{SystemID: 1, Name: "Redis", Heartbeat: &domain.HeartbeatConfig{URL: "not a url", Interval: 30}}},
+			},
+		},
+		{
+			name: "system dependency cycle",
+			doc: ConfigDocument{
+				Systems:            []ConfigSystem{{ID: 1, Name: "API"}, {ID: 2, Name: "DB"}},
+				SystemDependencies: []ConfigSystemLink{{SystemID: 1, DependsOnID: 2}, {SystemID: 2, DependsOnID: 1}},
+			},
+		},
+		{
+			name: "webhook scoped to unknown system",
+			doc: ConfigDocument{
+				Webhooks: []ConfigWebhook{{Name: "Ops", URL: "https://hooks.example.com", Type: "generic", SystemIDs: []int64{5}}},
+			},
+		},
+		{
+			name: "maintenance ending before it starts",
+			doc: ConfigDocument{
+				Maintenances: []ConfigMaintenance{{Title: "Upgrade", StartTime: now, EndTime: now.Add(-time.Hour)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConfigFixture(now)
+			existing, _ := domain.NewSystem("Legacy", "", "", "")
+			f.systems.Create(ctx, existing)
+
+			_, err := f.service.Import(ctx, &tt.doc, tt.mode)
+			var invalid *ConfigValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected ConfigValidationError, got %v", err)
+			}
+			if len(f.systems.Systems) != 1 || f.systems.Systems[existing.ID] == nil || len(f.deps.Dependencies) != 0 || len(f.webhooks.Webhooks) != 0 {
+				t.Error("expected nothing to change on a failed import")
+			}
+		})
+	}
+}
+
+func TestConfigService_ImportInvalidMode(t *testing.T) {
+	f := newConfigFixture(time.Now())
+
+	_, err := f.service.Import(context.Background(), &ConfigDocument{}, "overwrite")
+	if !errors.Is(err, ErrInvalidImportMode) {
+		t.Errorf("expected ErrInvalidImportMode, got %v", err)
+	}
+}
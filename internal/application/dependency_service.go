@@ -2,16 +2,23 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"status-incident/internal/domain"
+	"strings"
 )
 
+// ErrCloneIntoSameSystem is returned when dependencies would be cloned into
+// the system they come from
+var ErrCloneIntoSameSystem = errors.New("cannot clone dependencies into the same system")
+
 // DependencyService handles dependency-related use cases
 type DependencyService struct {
 	depRepo             domain.DependencyRepository
 	logRepo             domain.StatusLogRepository
 	notificationService *NotificationService
 	propagationService  *StatusPropagationService
+	eventBroker         *StatusEventBroker
 }
 
 // NewDependencyService creates a new DependencyService
@@ -27,6 +34,11 @@ func (s *DependencyService) SetNotificationService(ns *NotificationService) {
 	s.notificationService = ns
 }
 
+// SetEventBroker publishes status changes to live subscribers
+func (s *DependencyService) SetEventBroker(b *StatusEventBroker) {
+	s.eventBroker = b
+}
+
 // SetPropagationService sets the propagation service for propagating status to parent systems
 func (s *DependencyService) SetPropagationService(ps *StatusPropagationService) {
 	s.propagationService = ps
@@ -34,6 +46,9 @@ func (s *DependencyService) SetPropagationService(ps *StatusPropagationService)
 
 // CreateDependency creates a new dependency for a system
 func (s *DependencyService) CreateDependency(ctx context.Context, systemID int64, name, description string) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.CreateDependency")
+	defer span.End()
+
 	dep, err := domain.NewDependency(systemID, name, description)
 	if err != nil {
 		return nil, fmt.Errorf("invalid dependency data: %w", err)
@@ -46,8 +61,64 @@ func (s *DependencyService) CreateDependency(ctx context.Context, systemID int64
 	return dep, nil
 }
 
+// CloneResult lists the dependencies created by CloneDependencies and the
+// names skipped because the target system already had them
+type CloneResult struct {
+	Created []*domain.Dependency `json:"created"`
+	Skipped []string             `json:"skipped"`
+}
+
+// CloneDependencies copies the dependency definitions of the source system
+// to the target system. Dependencies whose name already exists on the target
+// (ignoring case) are skipped. Copies start with fresh runtime state.
+func (s *DependencyService) CloneDependencies(ctx context.Context, targetSystemID, sourceSystemID int64) (*CloneResult, error) {
+	ctx, span := startSpan(ctx, "DependencyService.CloneDependencies")
+	defer span.End()
+
+	if targetSystemID == sourceSystemID {
+		return nil, ErrCloneIntoSameSystem
+	}
+
+	sources, err := s.depRepo.GetBySystemID(ctx, sourceSystemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source dependencies: %w", err)
+	}
+	existing, err := s.depRepo.GetBySystemID(ctx, targetSystemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target dependencies: %w", err)
+	}
+
+	names := make(map[string]bool, len(existing))
+	for _, dep := range existing {
+		names[strings.ToLower(dep.Name)] = true
+	}
+
+	result := &CloneResult{Created: []*domain.Dependency{}, Skipped: []string{}}
+	for _, source := range sources {
+		if names[strings.ToLower(source.Name)] {
+			result.Skipped = append(result.Skipped, source.Name)
+			continue
+		}
+
+		clone, err := source.CloneTo(targetSystemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dependency data: %w", err)
+		}
+		if err := s.depRepo.Create(ctx, clone); err != nil {
+			return nil, fmt.Errorf("failed to create dependency: %w", err)
+		}
+		names[strings.ToLower(clone.Name)] = true
+		result.Created = append(result.Created, clone)
+	}
+
+	return result, nil
+}
+
 // GetDependency retrieves a dependency by ID
 func (s *DependencyService) GetDependency(ctx context.Context, id int64) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.GetDependency")
+	defer span.End()
+
 	dep, err := s.depRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -57,6 +128,9 @@ func (s *DependencyService) GetDependency(ctx context.Context, id int64) (*domai
 
 // GetDependenciesBySystem retrieves all dependencies for a system
 func (s *DependencyService) GetDependenciesBySystem(ctx context.Context, systemID int64) ([]*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.GetDependenciesBySystem")
+	defer span.End()
+
 	deps, err := s.depRepo.GetBySystemID(ctx, systemID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependencies: %w", err)
@@ -66,6 +140,9 @@ func (s *DependencyService) GetDependenciesBySystem(ctx context.Context, systemI
 
 // UpdateDependency updates dependency name and description
 func (s *DependencyService) UpdateDependency(ctx context.Context, id int64, name, description string) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.UpdateDependency")
+	defer span.End()
+
 	dep, err := s.depRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -85,8 +162,88 @@ func (s *DependencyService) UpdateDependency(ctx context.Context, id int64, name
 	return dep, nil
 }
 
+// SetRecordLatency toggles latency history persistence for a dependency
+func (s *DependencyService) SetRecordLatency(ctx context.Context, id int64, record bool) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.SetRecordLatency")
+	defer span.End()
+
+	dep, err := s.depRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency: %w", err)
+	}
+	if dep == nil {
+		return nil, fmt.Errorf("dependency not found: %d", id)
+	}
+
+	dep.SetRecordLatency(record)
+
+	if err := s.depRepo.Update(ctx, dep); err != nil {
+		return nil, fmt.Errorf("failed to update dependency: %w", err)
+	}
+
+	return dep, nil
+}
+
+// SetWeight sets the dependency's weight in its system's weighted SLA
+func (s *DependencyService) SetWeight(ctx context.Context, id int64, weight float64) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.SetWeight")
+	defer span.End()
+
+	dep, err := s.depRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency: %w", err)
+	}
+	if dep == nil {
+		return nil, fmt.Errorf("dependency not found: %d", id)
+	}
+
+	if err := dep.SetWeight(weight); err != nil {
+		return nil, err
+	}
+
+	if err := s.depRepo.Update(ctx, dep); err != nil {
+		return nil, fmt.Errorf("failed to update dependency: %w", err)
+	}
+
+	return dep, nil
+}
+
+// SetCriticality sets how the dependency's status propagates to its system
+// and re-evaluates the system status
+func (s *DependencyService) SetCriticality(ctx context.Context, id int64, criticality domain.Criticality) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.SetCriticality")
+	defer span.End()
+
+	dep, err := s.depRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency: %w", err)
+	}
+	if dep == nil {
+		return nil, fmt.Errorf("dependency not found: %d", id)
+	}
+
+	if err := dep.SetCriticality(criticality); err != nil {
+		return nil, err
+	}
+
+	if err := s.depRepo.Update(ctx, dep); err != nil {
+		return nil, fmt.Errorf("failed to update dependency: %w", err)
+	}
+
+	if s.propagationService != nil {
+		if _, err := s.propagationService.PropagateStatusToSystem(ctx, dep.SystemID); err != nil {
+			fmt.Printf("failed to propagate status to system %d: %v\n", dep.SystemID, err)
+		}
+	}
+
+	return dep, nil
+}
+
 // SetHeartbeat configures heartbeat checking for a dependency (legacy method)
 func (s *DependencyService) SetHeartbeat(ctx context.Context, id int64, url string, interval int) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.SetHeartbeat")
+	defer span.End()
+
 	return s.SetHeartbeatConfig(ctx, id, domain.HeartbeatConfig{
 		URL:      url,
 		Interval: interval,
@@ -95,6 +252,9 @@ func (s *DependencyService) SetHeartbeat(ctx context.Context, id int64, url stri
 
 // SetHeartbeatConfig configures heartbeat checking with advanced options
 func (s *DependencyService) SetHeartbeatConfig(ctx context.Context, id int64, config domain.HeartbeatConfig) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.SetHeartbeatConfig")
+	defer span.End()
+
 	dep, err := s.depRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -116,6 +276,9 @@ func (s *DependencyService) SetHeartbeatConfig(ctx context.Context, id int64, co
 
 // ClearHeartbeat removes heartbeat checking for a dependency
 func (s *DependencyService) ClearHeartbeat(ctx context.Context, id int64) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.ClearHeartbeat")
+	defer span.End()
+
 	dep, err := s.depRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -135,6 +298,9 @@ func (s *DependencyService) ClearHeartbeat(ctx context.Context, id int64) (*doma
 
 // UpdateDependencyStatus changes dependency status with logging
 func (s *DependencyService) UpdateDependencyStatus(ctx context.Context, id int64, statusStr, message string) (*domain.Dependency, error) {
+	ctx, span := startSpan(ctx, "DependencyService.UpdateDependencyStatus")
+	defer span.End()
+
 	dep, err := s.depRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -160,6 +326,7 @@ func (s *DependencyService) UpdateDependencyStatus(ctx context.Context, id int64
 
 	// Log the status change
 	log := domain.NewStatusLog(nil, &id, oldStatus, newStatus, message, domain.SourceManual)
+	log.Actor = domain.ActorFromContext(ctx)
 	if err := s.logRepo.Create(ctx, log); err != nil {
 		fmt.Printf("failed to log status change: %v\n", err)
 	}
@@ -168,6 +335,9 @@ func (s *DependencyService) UpdateDependencyStatus(ctx context.Context, id int64
 	if s.notificationService != nil && oldStatus != newStatus {
 		go s.notificationService.NotifyStatusChange(ctx, log)
 	}
+	if s.eventBroker != nil && oldStatus != newStatus {
+		s.eventBroker.PublishStatusChange(log, dep.SystemID)
+	}
 
 	// Propagate status change to parent system
 	if s.propagationService != nil && oldStatus != newStatus {
@@ -181,6 +351,9 @@ func (s *DependencyService) UpdateDependencyStatus(ctx context.Context, id int64
 
 // DeleteDependency removes a dependency
 func (s *DependencyService) DeleteDependency(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DependencyService.DeleteDependency")
+	defer span.End()
+
 	// Get the dependency first to know the system ID for propagation
 	var systemID int64
 	if s.propagationService != nil {
@@ -206,6 +379,9 @@ func (s *DependencyService) DeleteDependency(ctx context.Context, id int64) erro
 
 // GetDependencyLogs retrieves status logs for a dependency
 func (s *DependencyService) GetDependencyLogs(ctx context.Context, id int64, limit int) ([]*domain.StatusLog, error) {
+	ctx, span := startSpan(ctx, "DependencyService.GetDependencyLogs")
+	defer span.End()
+
 	logs, err := s.logRepo.GetByDependencyID(ctx, id, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logs: %w", err)
@@ -489,3 +489,80 @@ func TestDependencyService_UpdateDependencyStatus_NoChangeNoPropagation(t *testi
 		t.Errorf("expected system status to remain green, got %q", system.Status)
 	}
 }
+
+func TestDependencyService_CloneDependencies(t *testing.T) {
+	ctx := context.Background()
+	depRepo := NewMockDependencyRepository()
+
+	gateway, _ := domain.NewDependency(1, "Gateway", "Edge proxy")
+	disabled := false
+	gateway.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:             "https://gw.example.com/health",
+		Interval:        30,
+		Method:          "POST",
+		Headers:         map[string]string{"Authorization": "Bearer secret"},
+		ExpectStatus:    "2xx",
+		FollowRedirects: &disabled,
+	})
+	gateway.SetCriticality(domain.CriticalityMajor)
+	gateway.SetWeight(2)
+	gateway.RecordCheckFailure(900)
+	gateway.RecordCheckFailure(900)
+	gateway.RecordCheckFailure(900)
+	gateway.LastStatusCode = 503
+	depRepo.Create(ctx, gateway)
+
+	redis, _ := domain.NewDependency(1, "Redis", "Cache")
+	depRepo.Create(ctx, redis)
+
+	// The target already has a Redis dependency
+	existing, _ := domain.NewDependency(2, "redis", "Own cache")
+	depRepo.Create(ctx, existing)
+
+	service := NewDependencyService(depRepo, NewMockStatusLogRepository())
+	result, err := service.CloneDependencies(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Created) != 1 || len(result.Skipped) != 1 || result.Skipped[0] != "Redis" {
+		t.Fatalf("expected Gateway created and Redis skipped, got %d created, skipped %v", len(result.Created), result.Skipped)
+	}
+
+	clone := result.Created[0]
+	if clone.ID == gateway.ID || clone.SystemID != 2 {
+		t.Errorf("expected a new dependency on system 2, got ID %d on system %d", clone.ID, clone.SystemID)
+	}
+	if clone.Name != "Gateway" || clone.Description != "Edge proxy" {
+		t.Errorf("expected name and description copied, got %q %q", clone.Name, clone.Description)
+	}
+	config := clone.GetHeartbeatConfig()
+	if config.URL != "https://gw.example.com/health" || config.Interval != 30 || config.Method != "POST" || config.ExpectStatus != "2xx" {
+		t.Errorf("expected heartbeat config copied, got %+v", config)
+	}
+	if config.Headers["Authorization"] != "Bearer secret" || clone.HeartbeatFollowRedirects {
+		t.Errorf("expected headers and redirect option copied, got %+v", config)
+	}
+	if clone.Criticality != domain.CriticalityMajor || clone.Weight != 2 {
+		t.Errorf("expected criticality and weight copied, got %s %v", clone.Criticality, clone.Weight)
+	}
+
+	if clone.Status != domain.StatusGreen || clone.ConsecutiveFailures != 0 || clone.LastLatency != 0 || clone.LastStatusCode != 0 || !clone.LastCheck.IsZero() {
+		t.Errorf("expected runtime fields reset, got status %s, %d failures, latency %d, code %d", clone.Status, clone.ConsecutiveFailures, clone.LastLatency, clone.LastStatusCode)
+	}
+
+	// Headers are copied, not shared
+	clone.HeartbeatHeaders["Authorization"] = "changed"
+	if gateway.HeartbeatHeaders["Authorization"] != "Bearer secret" {
+		t.Error("expected the source headers to be unaffected by the clone")
+	}
+}
+
+func TestDependencyService_CloneDependencies_SameSystem(t *testing.T) {
+	service := NewDependencyService(NewMockDependencyRepository(), NewMockStatusLogRepository())
+
+	_, err := service.CloneDependencies(context.Background(), 1, 1)
+	if !errors.Is(err, ErrCloneIntoSameSystem) {
+		t.Errorf("expected ErrCloneIntoSameSystem, got %v", err)
+	}
+}
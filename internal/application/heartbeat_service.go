@@ -3,9 +3,16 @@ package application
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"status-incident/internal/domain"
+	"sync"
+	"time"
 )
 
+// DefaultHeartbeatConcurrency is the number of heartbeat checks run at once
+// when no concurrency is configured
+const DefaultHeartbeatConcurrency = 20
+
 // HeartbeatService handles heartbeat checking
 type HeartbeatService struct {
 	depRepo             domain.DependencyRepository
@@ -14,6 +21,24 @@ type HeartbeatService struct {
 	checker             domain.HealthChecker
 	notificationService *NotificationService
 	propagationService  *StatusPropagationService
+	eventBroker         *StatusEventBroker
+	monitor             *MonitoringHealthService
+	defaultInterval     time.Duration
+	concurrency         int
+	failureThreshold    int
+	recoveryThreshold   int
+	flapWindow          time.Duration
+	flapThreshold       int
+	clock               Clock
+	logger              Logger
+
+	// flaps holds the recent status changes of each dependency, guarded by
+	// recordMu
+	flaps map[int64]*flapState
+
+	// recordMu serializes writing check results so concurrent checks do not
+	// race on the repositories or on status propagation
+	recordMu sync.Mutex
 }
 
 // NewHeartbeatService creates a new HeartbeatService
@@ -26,9 +51,21 @@ func NewHeartbeatService(
 		depRepo: depRepo,
 		logRepo: logRepo,
 		checker: checker,
+		clock:   SystemClock{},
+		logger:  slog.Default(),
+		flaps:   make(map[int64]*flapState),
 	}
 }
 
+// flapState tracks the recent status changes of one dependency
+type flapState struct {
+	changes  []time.Time
+	flapping bool
+	// notified is the last status subscribers heard about before the
+	// dependency started flapping
+	notified domain.Status
+}
+
 // SetLatencyRepo sets the latency repository for recording history
 func (s *HeartbeatService) SetLatencyRepo(repo domain.LatencyRepository) {
 	s.latencyRepo = repo
@@ -39,54 +76,248 @@ func (s *HeartbeatService) SetNotificationService(ns *NotificationService) {
 	s.notificationService = ns
 }
 
+// SetEventBroker publishes status changes to live subscribers
+func (s *HeartbeatService) SetEventBroker(b *StatusEventBroker) {
+	s.eventBroker = b
+}
+
 // SetPropagationService sets the propagation service for propagating status to parent systems
 func (s *HeartbeatService) SetPropagationService(ps *StatusPropagationService) {
 	s.propagationService = ps
 }
 
+// SetDefaultInterval sets the check interval for dependencies without one
+// of their own
+func (s *HeartbeatService) SetDefaultInterval(interval time.Duration) {
+	s.defaultInterval = interval
+}
+
+// SetConcurrency sets how many heartbeat checks run at once
+func (s *HeartbeatService) SetConcurrency(n int) {
+	s.concurrency = n
+}
+
+// SetFailureThreshold sets how many consecutive failed checks turn a
+// dependency red; earlier failures only mark it yellow. Values below 1 use
+// domain.DefaultFailureThreshold
+func (s *HeartbeatService) SetFailureThreshold(n int) {
+	s.failureThreshold = n
+}
+
+// SetRecoveryThreshold sets how many consecutive successful checks turn an
+// unhealthy dependency green again; it stays yellow until then. Values below
+// 1 use domain.DefaultRecoveryThreshold
+func (s *HeartbeatService) SetRecoveryThreshold(n int) {
+	s.recoveryThreshold = n
+}
+
+// SetFlapDetection treats a dependency as flapping once its status changed
+// more than threshold times within window. While flapping, status changes
+// are still logged but neither notified nor propagated to the system. A
+// threshold below 1 disables flap detection
+func (s *HeartbeatService) SetFlapDetection(window time.Duration, threshold int) {
+	s.flapWindow = window
+	s.flapThreshold = threshold
+}
+
+// SetClock sets the clock that decides which checks are due and when
+// a dependency is flapping
+func (s *HeartbeatService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// SetLogger replaces the logger used for check failures and flapping
+func (s *HeartbeatService) SetLogger(logger Logger) {
+	s.logger = logger
+}
+
+// IsFlapping reports whether the dependency is currently flapping
+func (s *HeartbeatService) IsFlapping(depID int64) bool {
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	state, ok := s.flaps[depID]
+	return ok && state.flapping
+}
+
+// updateFlapping records a check outcome for flap detection and reports
+// whether the dependency is flapping now and whether it was before, along
+// with the last status notified before it started flapping. Callers must
+// hold recordMu
+func (s *HeartbeatService) updateFlapping(dep *domain.Dependency, oldStatus domain.Status, changed bool) (flapping, wasFlapping bool, notified domain.Status) {
+	if s.flapThreshold < 1 {
+		return false, false, oldStatus
+	}
+
+	now := s.clock.Now()
+	state, ok := s.flaps[dep.ID]
+	if !ok {
+		state = &flapState{}
+		s.flaps[dep.ID] = state
+	}
+	if changed {
+		state.changes = append(state.changes, now)
+	}
+
+	cutoff := now.Add(-s.flapWindow)
+	expired := 0
+	for expired < len(state.changes) && !state.changes[expired].After(cutoff) {
+		expired++
+	}
+	state.changes = state.changes[expired:]
+
+	wasFlapping = state.flapping
+	state.flapping = len(state.changes) > s.flapThreshold
+	if state.flapping && !wasFlapping {
+		state.notified = oldStatus
+	}
+	if !state.flapping && len(state.changes) == 0 {
+		delete(s.flaps, dep.ID)
+	}
+	return state.flapping, wasFlapping, state.notified
+}
+
+// thresholds returns the failure and recovery thresholds in effect
+func (s *HeartbeatService) thresholds() (failures, recoveries int) {
+	failures, recoveries = s.failureThreshold, s.recoveryThreshold
+	if failures < 1 {
+		failures = domain.DefaultFailureThreshold
+	}
+	if recoveries < 1 {
+		recoveries = domain.DefaultRecoveryThreshold
+	}
+	return failures, recoveries
+}
+
+// SetMonitor sets the self-monitor that tracks sweeps and repository errors
+func (s *HeartbeatService) SetMonitor(m *MonitoringHealthService) {
+	s.monitor = m
+}
+
+// recordRepoError reports a repository error to the self-monitor
+func (s *HeartbeatService) recordRepoError(err error) {
+	if s.monitor != nil {
+		s.monitor.RecordError(err)
+	}
+}
+
 // CheckAllDependencies checks all dependencies with heartbeat configured
+// whose interval has elapsed
 func (s *HeartbeatService) CheckAllDependencies(ctx context.Context) error {
+	_, err := s.CheckDueDependencies(ctx)
+	return err
+}
+
+// CheckDueDependencies checks each dependency whose own interval has elapsed
+// and returns when the next one is due. The zero time means no dependency has
+// a heartbeat configured.
+func (s *HeartbeatService) CheckDueDependencies(ctx context.Context) (time.Time, error) {
 	deps, err := s.depRepo.GetAllWithHeartbeat(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get dependencies: %w", err)
+		s.recordRepoError(err)
+		return time.Time{}, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	if s.monitor != nil {
+		defer s.monitor.RecordSweep()
 	}
 
+	now := s.clock.Now()
+	var next time.Time
+	var due []*domain.Dependency
 	for _, dep := range deps {
-		if dep.NeedsCheck() {
-			if err := s.checkDependency(ctx, dep); err != nil {
-				// Log error but continue checking other dependencies
-				fmt.Printf("heartbeat check failed for dependency %d: %v\n", dep.ID, err)
-			}
+		at := dep.NextCheckAt(s.defaultInterval)
+		if !at.After(now) {
+			due = append(due, dep)
+			// Failed checks wait a full interval too instead of spinning
+			at = now.Add(dep.CheckInterval(s.defaultInterval))
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
 		}
 	}
 
-	return nil
+	s.runChecks(ctx, due)
+
+	return next, nil
+}
+
+// runChecks checks the given dependencies through a bounded pool of workers
+// so a few slow endpoints cannot hold up the rest of the sweep
+func (s *HeartbeatService) runChecks(ctx context.Context, deps []*domain.Dependency) {
+	workers := s.concurrency
+	if workers <= 0 {
+		workers = DefaultHeartbeatConcurrency
+	}
+	if workers > len(deps) {
+		workers = len(deps)
+	}
+
+	jobs := make(chan *domain.Dependency)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dep := range jobs {
+				if err := s.checkDependency(ctx, dep); err != nil {
+					// Log error but continue checking other dependencies
+					s.logger.Error("heartbeat check failed", "dependency_id", dep.ID, "system_id", dep.SystemID, "error", err)
+				}
+			}
+		}()
+	}
+
+	for _, dep := range deps {
+		jobs <- dep
+	}
+	close(jobs)
+	wg.Wait()
 }
 
-// checkDependency performs health check on a single dependency
+// checkDependency performs health check on a single dependency. The probe
+// runs under its own deadline, sized by the dependency's timeout and retries
 func (s *HeartbeatService) checkDependency(ctx context.Context, dep *domain.Dependency) error {
 	// Use advanced config if available
 	config := dep.GetHeartbeatConfig()
-	result := s.checker.CheckWithConfig(ctx, config)
+	checkCtx, cancel := context.WithTimeout(ctx, config.CheckBudget())
+	result := s.checker.CheckWithConfig(checkCtx, config)
+	cancel()
 
 	if result.Error != nil {
 		return fmt.Errorf("check error: %w", result.Error)
 	}
 
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+
 	oldStatus := dep.Status
 	var statusChanged bool
 
 	// Update last status code
 	dep.LastStatusCode = result.StatusCode
+	if !result.CertExpiresAt.IsZero() {
+		dep.CertExpiresAt = result.CertExpiresAt
+	}
 
-	if result.Healthy {
-		statusChanged = dep.RecordCheckSuccess(result.LatencyMs)
-	} else {
-		statusChanged = dep.RecordCheckFailure(result.LatencyMs)
+	failureThreshold, recoveryThreshold := s.thresholds()
+	now := s.clock.Now()
+	switch {
+	case result.Healthy:
+		statusChanged = dep.RecordCheckSuccessWithThreshold(result.LatencyMs, recoveryThreshold, now)
+	case result.CertExpiring:
+		s.logger.Warn("heartbeat certificate expiring", "dependency_id", dep.ID, "system_id", dep.SystemID, "days_remaining", result.CertDaysRemaining)
+		statusChanged = dep.RecordCheckDegraded(result.LatencyMs, now)
+	case result.Degraded && dep.HeartbeatCheckType == domain.CheckTypeDNS:
+		s.logger.Warn("heartbeat DNS answer mismatch", "dependency_id", dep.ID, "system_id", dep.SystemID, "answer", result.BodySnippet)
+		statusChanged = dep.RecordCheckDegraded(result.LatencyMs, now)
+	case result.Degraded:
+		s.logger.Warn("heartbeat body mismatch", "dependency_id", dep.ID, "system_id", dep.SystemID, "status_code", result.StatusCode, "body", result.BodySnippet)
+		statusChanged = dep.RecordCheckDegraded(result.LatencyMs, now)
+	default:
+		statusChanged = dep.RecordCheckFailureWithThreshold(result.LatencyMs, failureThreshold, now)
 	}
 
-	// Record latency history
-	if s.latencyRepo != nil {
+	// Record latency history unless disabled for this dependency
+	if s.latencyRepo != nil && dep.RecordLatency {
 		record := &domain.LatencyRecord{
 			DependencyID: dep.ID,
 			LatencyMs:    result.LatencyMs,
@@ -94,45 +325,88 @@ func (s *HeartbeatService) checkDependency(ctx context.Context, dep *domain.Depe
 			StatusCode:   result.StatusCode,
 		}
 		if err := s.latencyRepo.Record(ctx, record); err != nil {
-			fmt.Printf("failed to record latency history: %v\n", err)
+			s.recordRepoError(err)
+			s.logger.Error("failed to record latency history", "dependency_id", dep.ID, "system_id", dep.SystemID, "error", err)
 		}
 	}
 
 	// Always update to save LastCheck and LastLatency
 	if err := s.depRepo.Update(ctx, dep); err != nil {
+		s.recordRepoError(err)
 		return fmt.Errorf("failed to update dependency: %w", err)
 	}
 
+	flapping, wasFlapping, notified := s.updateFlapping(dep, oldStatus, statusChanged)
+	if flapping && !wasFlapping {
+		s.logger.Warn("dependency is flapping, holding notifications", "dependency_id", dep.ID, "system_id", dep.SystemID, "flap_threshold", s.flapThreshold, "flap_window", s.flapWindow)
+	}
+
 	// Log status change if happened
 	if statusChanged {
 		var message string
-		if result.Healthy {
+		if result.Healthy && dep.Status != domain.StatusGreen {
+			message = fmt.Sprintf("Heartbeat check succeeded, recovering (%d of %d consecutive successes, latency: %dms, status: %d)", dep.ConsecutiveSuccesses, recoveryThreshold, result.LatencyMs, result.StatusCode)
+		} else if result.Healthy {
 			message = fmt.Sprintf("Heartbeat check succeeded, service recovered (latency: %dms, status: %d)", result.LatencyMs, result.StatusCode)
+		} else if result.CertExpiring {
+			message = fmt.Sprintf("Heartbeat check degraded, TLS certificate expires in %d days on %s (latency: %dms, status: %d)", result.CertDaysRemaining, result.CertExpiresAt.Format("2006-01-02"), result.LatencyMs, result.StatusCode)
+		} else if result.Degraded && dep.HeartbeatCheckType == domain.CheckTypeDNS {
+			message = fmt.Sprintf("Heartbeat check degraded, unexpected DNS answer (latency: %dms, answer: %q)", result.LatencyMs, result.BodySnippet)
+		} else if result.Degraded {
+			message = fmt.Sprintf("Heartbeat check degraded, unexpected response body (latency: %dms, status: %d, body: %q)", result.LatencyMs, result.StatusCode, result.BodySnippet)
 		} else {
 			message = fmt.Sprintf("Heartbeat check failed (%d consecutive failures, latency: %dms, status: %d)", dep.ConsecutiveFailures, result.LatencyMs, result.StatusCode)
+			if result.BodySnippet != "" {
+				message = fmt.Sprintf("Heartbeat check failed (%d consecutive failures, latency: %dms, reason: %q)", dep.ConsecutiveFailures, result.LatencyMs, result.BodySnippet)
+			}
 		}
-
-		log := domain.NewStatusLog(nil, &dep.ID, oldStatus, dep.Status, message, domain.SourceHeartbeat)
-		if err := s.logRepo.Create(ctx, log); err != nil {
-			fmt.Printf("failed to log heartbeat status change: %v\n", err)
+		if flapping {
+			message += " [flapping, notifications suppressed]"
 		}
 
-		// Send notifications
-		if s.notificationService != nil {
-			go s.notificationService.NotifyStatusChange(ctx, log)
-		}
+		s.logStatusChange(ctx, dep, oldStatus, message, !flapping && !wasFlapping)
+	}
 
-		// Propagate status change to parent system
-		if s.propagationService != nil {
-			if _, err := s.propagationService.PropagateStatusToSystem(ctx, dep.SystemID); err != nil {
-				fmt.Printf("failed to propagate status to system %d: %v\n", dep.SystemID, err)
-			}
+	// Once it settles, tell subscribers where the dependency ended up
+	if wasFlapping && !flapping {
+		s.logger.Info("dependency stopped flapping", "dependency_id", dep.ID, "system_id", dep.SystemID)
+		if notified != dep.Status {
+			s.logStatusChange(ctx, dep, notified, "Heartbeat status settled after flapping", true)
 		}
 	}
 
 	return nil
 }
 
+// logStatusChange records a dependency status change and, when notify is
+// set, notifies subscribers and propagates the status to the parent system
+func (s *HeartbeatService) logStatusChange(ctx context.Context, dep *domain.Dependency, oldStatus domain.Status, message string, notify bool) {
+	log := domain.NewStatusLog(nil, &dep.ID, oldStatus, dep.Status, message, domain.SourceHeartbeat)
+	if err := s.logRepo.Create(ctx, log); err != nil {
+		s.recordRepoError(err)
+		s.logger.Error("failed to log heartbeat status change", "dependency_id", dep.ID, "system_id", dep.SystemID, "error", err)
+	}
+	// Live subscribers see every change, even while notifications are held
+	if s.eventBroker != nil {
+		s.eventBroker.PublishStatusChange(log, dep.SystemID)
+	}
+	if !notify {
+		return
+	}
+
+	// Send notifications
+	if s.notificationService != nil {
+		go s.notificationService.NotifyStatusChange(ctx, log)
+	}
+
+	// Propagate status change to parent system
+	if s.propagationService != nil {
+		if _, err := s.propagationService.PropagateStatusToSystem(ctx, dep.SystemID); err != nil {
+			s.logger.Error("failed to propagate status to system", "dependency_id", dep.ID, "system_id", dep.SystemID, "error", err)
+		}
+	}
+}
+
 // ForceCheck forces immediate health check for a specific dependency
 func (s *HeartbeatService) ForceCheck(ctx context.Context, depID int64) (*domain.Dependency, error) {
 	dep, err := s.depRepo.GetByID(ctx, depID)
@@ -1,9 +1,16 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"status-incident/internal/domain"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNewHeartbeatService(t *testing.T) {
@@ -92,6 +99,98 @@ func TestHeartbeatService_CheckAllDependencies_WithDeps(t *testing.T) {
 	}
 }
 
+func TestHeartbeatService_CheckDeadlinePerDependency(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	slow, _ := domain.NewDependency(1, "Reports", "")
+	slow.ID = 1
+	slow.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://reports.example.com/health", Interval: 60, TimeoutMs: 60000, Retries: 5})
+	depRepo.Dependencies[1] = slow
+	fast, _ := domain.NewDependency(1, "Cache", "")
+	fast.ID = 2
+	fast.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://cache.example.com/health", Interval: 60, TimeoutMs: 2000})
+	depRepo.Dependencies[2] = fast
+
+	var mu sync.Mutex
+	budgets := make(map[string]time.Duration)
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Errorf("expected a deadline for %s", config.URL)
+		}
+		mu.Lock()
+		budgets[config.URL] = time.Until(deadline)
+		mu.Unlock()
+		return domain.HealthCheckResult{Healthy: true, LatencyMs: 5, StatusCode: 200}
+	}
+
+	service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+	if err := service.CheckAllDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Six attempts of 60s each must fit, well beyond any fixed sweep deadline
+	if got := budgets["https://reports.example.com/health"]; got < 6*time.Minute {
+		t.Errorf("expected the slow check to get at least 6m, got %v", got)
+	}
+	if got := budgets["https://cache.example.com/health"]; got > 5*time.Second {
+		t.Errorf("expected the fast check to be bounded by its own timeout, got %v", got)
+	}
+}
+
+func TestHeartbeatService_CheckDueDependencies_UsesClock(t *testing.T) {
+	lastCheck := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+	dep.LastCheck = lastCheck
+	depRepo.Dependencies[1] = dep
+
+	var checks int32
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		atomic.AddInt32(&checks, 1)
+		return domain.HealthCheckResult{Healthy: true, LatencyMs: 5, StatusCode: 200}
+	}
+
+	now := lastCheck.Add(30 * time.Second)
+	service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+
+	next, err := service.CheckDueDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checks != 0 || !next.Equal(lastCheck.Add(time.Minute)) {
+		t.Errorf("expected no check and the next one due at %v, got %d checks and %v", lastCheck.Add(time.Minute), checks, next)
+	}
+
+	// Exactly one interval after the last check the dependency is due
+	now = lastCheck.Add(time.Minute)
+	if _, err := service.CheckDueDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checks != 1 {
+		t.Errorf("expected the dependency to be checked once due, got %d checks", checks)
+	}
+
+	// The check is recorded at clock time, so the next one is due a full
+	// interval later on the same clock
+	checked, _ := depRepo.GetByID(context.Background(), 1)
+	if !checked.LastCheck.Equal(now) {
+		t.Errorf("expected last check at clock time %v, got %v", now, checked.LastCheck)
+	}
+	now = now.Add(30 * time.Second)
+	next, err = service.CheckDueDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checks != 1 || !next.Equal(lastCheck.Add(2*time.Minute)) {
+		t.Errorf("expected no check and the next one due at %v, got %d checks and %v", lastCheck.Add(2*time.Minute), checks, next)
+	}
+}
+
 func TestHeartbeatService_CheckAllDependencies_WithLatencyRepo(t *testing.T) {
 	depRepo := NewMockDependencyRepository()
 	dep, _ := domain.NewDependency(1, "Redis", "Cache")
@@ -125,6 +224,48 @@ func TestHeartbeatService_CheckAllDependencies_WithLatencyRepo(t *testing.T) {
 	}
 }
 
+func TestHeartbeatService_CheckAllDependencies_RecordLatencyOff(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+	dep.SetRecordLatency(false)
+	depRepo.Dependencies[1] = dep
+
+	logRepo := NewMockStatusLogRepository()
+	latencyRepo := NewMockLatencyRepository()
+
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{
+			Healthy:    false,
+			LatencyMs:  100,
+			StatusCode: 500,
+		}
+	}
+
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+	service.SetLatencyRepo(latencyRepo)
+
+	err := service.CheckAllDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Status still follows the check result
+	if dep.Status != domain.StatusYellow {
+		t.Errorf("expected status yellow after failed check, got %s", dep.Status)
+	}
+	if dep.LastLatency != 100 {
+		t.Errorf("expected last latency 100, got %d", dep.LastLatency)
+	}
+
+	// But no latency history is written
+	if len(latencyRepo.Records) != 0 {
+		t.Errorf("expected no latency records, got %d", len(latencyRepo.Records))
+	}
+}
+
 func TestHeartbeatService_CheckAllDependencies_FailingCheck(t *testing.T) {
 	depRepo := NewMockDependencyRepository()
 	dep, _ := domain.NewDependency(1, "Redis", "Cache")
@@ -156,6 +297,176 @@ func TestHeartbeatService_CheckAllDependencies_FailingCheck(t *testing.T) {
 	}
 }
 
+func TestHeartbeatService_CheckAllDependencies_DegradedBody(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Search", "")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:                 "https://search.example.com/health",
+		Interval:            60,
+		ExpectBodySubstring: `"status":"ok"`,
+	})
+	depRepo.Dependencies[1] = dep
+
+	logRepo := NewMockStatusLogRepository()
+
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{
+			LatencyMs:   40,
+			StatusCode:  200,
+			Degraded:    true,
+			BodySnippet: `{"status":"degraded"}`,
+		}
+	}
+
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+
+	// Repeated degraded checks stay yellow instead of escalating to red
+	for i := 0; i < 4; i++ {
+		dep.LastCheck = time.Time{}
+		if err := service.CheckAllDependencies(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if dep.Status != domain.StatusYellow {
+		t.Errorf("expected yellow for degraded body, got %s", dep.Status)
+	}
+	if dep.ConsecutiveFailures != 0 {
+		t.Errorf("expected degraded checks not to count as failures, got %d", dep.ConsecutiveFailures)
+	}
+	if len(logRepo.Logs) != 1 {
+		t.Fatalf("expected 1 status change log, got %d", len(logRepo.Logs))
+	}
+	if !strings.Contains(logRepo.Logs[0].Message, `{\"status\":\"degraded\"}`) {
+		t.Errorf("expected body snippet in log message, got %q", logRepo.Logs[0].Message)
+	}
+}
+
+func TestHeartbeatService_BodyMismatchLogsFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := NewLogger(LogFormatJSON, &buf)
+
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(7, "Search", "")
+	dep.ID = 3
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:                 "https://search.example.com/health",
+		Interval:            60,
+		ExpectBodySubstring: `"status":"ok"`,
+	})
+	depRepo.Dependencies[3] = dep
+
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{StatusCode: 200, Degraded: true, BodySnippet: "down"}
+	}
+
+	service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+	service.SetLogger(logger)
+	if err := service.CheckAllDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", buf.String(), err)
+	}
+	want := map[string]any{
+		"level":         "WARN",
+		"msg":           "heartbeat body mismatch",
+		"dependency_id": float64(3),
+		"system_id":     float64(7),
+		"status_code":   float64(200),
+		"body":          "down",
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("%s = %v, want %v", key, entry[key], value)
+		}
+	}
+}
+
+func TestHeartbeatService_FailureReasonInStatusLog(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Orders gRPC", "")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeGRPC,
+		URL:       "orders.internal:50051",
+		Interval:  60,
+	})
+	depRepo.Dependencies[1] = dep
+
+	logRepo := NewMockStatusLogRepository()
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{LatencyMs: 5, BodySnippet: "NOT_SERVING"}
+	}
+
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+	service.SetFailureThreshold(1)
+	if err := service.CheckAllDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(logRepo.Logs) != 1 {
+		t.Fatalf("expected 1 status change log, got %d", len(logRepo.Logs))
+	}
+	if !strings.Contains(logRepo.Logs[0].Message, `reason: "NOT_SERVING"`) {
+		t.Errorf("expected failure reason in log message, got %q", logRepo.Logs[0].Message)
+	}
+}
+
+func TestHeartbeatService_CheckAllDependencies_CertExpiring(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Billing", "")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:                   "https://billing.example.com/health",
+		Interval:              60,
+		CertExpiryWarningDays: 14,
+	})
+	depRepo.Dependencies[1] = dep
+
+	logRepo := NewMockStatusLogRepository()
+
+	expiresAt := time.Now().Add(5*24*time.Hour + time.Hour)
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		if config.CertExpiryWarningDays != 14 {
+			t.Errorf("expected warning window passed to checker, got %d", config.CertExpiryWarningDays)
+		}
+		return domain.HealthCheckResult{
+			LatencyMs:         30,
+			StatusCode:        200,
+			Degraded:          true,
+			CertExpiring:      true,
+			CertExpiresAt:     expiresAt,
+			CertDaysRemaining: 5,
+		}
+	}
+
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+	if err := service.CheckAllDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dep.Status != domain.StatusYellow {
+		t.Errorf("expected yellow for expiring certificate, got %s", dep.Status)
+	}
+	if !dep.CertExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected cert expiry %v recorded, got %v", expiresAt, dep.CertExpiresAt)
+	}
+	if len(logRepo.Logs) != 1 {
+		t.Fatalf("expected 1 status change log, got %d", len(logRepo.Logs))
+	}
+	if !strings.Contains(logRepo.Logs[0].Message, "certificate expires in 5 days") {
+		t.Errorf("expected certificate expiry in log message, got %q", logRepo.Logs[0].Message)
+	}
+}
+
 func TestHeartbeatService_ForceCheck(t *testing.T) {
 	depRepo := NewMockDependencyRepository()
 	dep, _ := domain.NewDependency(1, "Redis", "Cache")
@@ -321,3 +632,277 @@ func TestHeartbeatService_CheckWithStatusChange_PropagatesStatus(t *testing.T) {
 		t.Error("expected a log with source 'propagation'")
 	}
 }
+
+func TestHeartbeatService_CheckDueDependencies_PerDependencyInterval(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+
+	edge, _ := domain.NewDependency(1, "CDN edge", "")
+	edge.ID = 1
+	edge.SetHeartbeat("https://edge.example.com/health", 10)
+	edge.LastCheck = time.Now().Add(-15 * time.Second)
+	depRepo.Dependencies[1] = edge
+
+	batch, _ := domain.NewDependency(1, "Batch job", "")
+	batch.ID = 2
+	batch.SetHeartbeat("https://batch.example.com/health", 300)
+	batch.LastCheck = time.Now().Add(-15 * time.Second)
+	depRepo.Dependencies[2] = batch
+
+	var checked []string
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		checked = append(checked, config.URL)
+		return domain.HealthCheckResult{Healthy: true, LatencyMs: 5, StatusCode: 200}
+	}
+
+	service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+	service.SetDefaultInterval(time.Minute)
+
+	before := time.Now()
+	next, err := service.CheckDueDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(checked) != 1 || checked[0] != "https://edge.example.com/health" {
+		t.Fatalf("expected only the 10s dependency to be checked, got %v", checked)
+	}
+
+	// The edge is due again in 10s, well before the batch job
+	if next.Before(before.Add(9*time.Second)) || next.After(time.Now().Add(10*time.Second)) {
+		t.Errorf("expected next check ~10s from now, got %v", next.Sub(before))
+	}
+}
+
+func TestHeartbeatService_CheckDueDependencies_None(t *testing.T) {
+	service := NewHeartbeatService(NewMockDependencyRepository(), NewMockStatusLogRepository(), NewMockHealthChecker())
+
+	next, err := service.CheckDueDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next.IsZero() {
+		t.Errorf("expected zero next check without heartbeats, got %v", next)
+	}
+}
+
+func TestHeartbeatService_CheckDueDependencies_Concurrent(t *testing.T) {
+	const (
+		numDeps     = 100
+		concurrency = 20
+		delay       = 50 * time.Millisecond
+	)
+
+	depRepo := NewMockDependencyRepository()
+	for i := int64(1); i <= numDeps; i++ {
+		dep, _ := domain.NewDependency(1, fmt.Sprintf("Service %d", i), "")
+		dep.ID = i
+		dep.SetHeartbeat(fmt.Sprintf("https://svc%d.example.com/health", i), 60)
+		depRepo.Dependencies[i] = dep
+	}
+
+	var inFlight, maxInFlight int32
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		atomic.AddInt32(&inFlight, -1)
+		return domain.HealthCheckResult{Healthy: true, LatencyMs: 42, StatusCode: 200}
+	}
+
+	latencyRepo := NewMockLatencyRepository()
+	service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+	service.SetLatencyRepo(latencyRepo)
+	service.SetConcurrency(concurrency)
+
+	start := time.Now()
+	if _, err := service.CheckDueDependencies(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	// Sequential checks would take numDeps*delay = 5s
+	if sequential := numDeps * delay; elapsed > sequential/4 {
+		t.Errorf("expected pass well under sequential time %v, took %v", sequential, elapsed)
+	}
+	if maxInFlight > concurrency {
+		t.Errorf("expected at most %d concurrent checks, got %d", concurrency, maxInFlight)
+	}
+
+	if len(latencyRepo.Records) != numDeps {
+		t.Errorf("expected %d latency records, got %d", numDeps, len(latencyRepo.Records))
+	}
+	for id, dep := range depRepo.Dependencies {
+		if dep.LastCheck.IsZero() || dep.LastLatency != 42 {
+			t.Errorf("dependency %d: expected recorded check, got last_check=%v latency=%d", id, dep.LastCheck, dep.LastLatency)
+		}
+	}
+}
+
+func TestHeartbeatService_Thresholds(t *testing.T) {
+	g, y, r := domain.StatusGreen, domain.StatusYellow, domain.StatusRed
+	tests := []struct {
+		name       string
+		failures   int
+		recoveries int
+		results    string // one check per character: '+' healthy, '-' failed
+		expected   []domain.Status
+	}{
+		{"defaults", 0, 0, "---+", []domain.Status{y, y, r, g}},
+		{"red on first failure", 1, 0, "-+", []domain.Status{r, g}},
+		{"red after five failures", 5, 0, "-----", []domain.Status{y, y, y, y, r}},
+		{"streak broken by a success", 3, 0, "--+--", []domain.Status{y, y, g, y, y}},
+		{"recovery after three successes", 2, 3, "--+++", []domain.Status{y, r, y, y, g}},
+		{"recovery streak broken by a failure", 2, 2, "--+-++", []domain.Status{y, r, y, y, y, g}},
+		{"healthy dependency stays green", 3, 3, "++", []domain.Status{g, g}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depRepo := NewMockDependencyRepository()
+			dep, _ := domain.NewDependency(1, "Redis", "Cache")
+			dep.ID = 1
+			dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+			depRepo.Dependencies[1] = dep
+
+			var healthy bool
+			checker := NewMockHealthChecker()
+			checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+				return domain.HealthCheckResult{Healthy: healthy, LatencyMs: 10, StatusCode: 200}
+			}
+
+			service := NewHeartbeatService(depRepo, NewMockStatusLogRepository(), checker)
+			service.SetFailureThreshold(tt.failures)
+			service.SetRecoveryThreshold(tt.recoveries)
+
+			for i, c := range tt.results {
+				healthy = c == '+'
+				if _, err := service.ForceCheck(context.Background(), 1); err != nil {
+					t.Fatalf("check %d: unexpected error: %v", i+1, err)
+				}
+				if dep.Status != tt.expected[i] {
+					t.Errorf("check %d: expected status %s, got %s", i+1, tt.expected[i], dep.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestHeartbeatService_Thresholds_LogsRecoveryProgress(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+	dep.Status = domain.StatusRed
+	depRepo.Dependencies[1] = dep
+
+	logRepo := NewMockStatusLogRepository()
+	service := NewHeartbeatService(depRepo, logRepo, NewMockHealthChecker())
+	service.SetRecoveryThreshold(2)
+
+	service.ForceCheck(context.Background(), 1)
+	service.ForceCheck(context.Background(), 1)
+
+	if len(logRepo.Logs) != 2 {
+		t.Fatalf("expected 2 status logs, got %d", len(logRepo.Logs))
+	}
+	if !strings.Contains(logRepo.Logs[0].Message, "recovering (1 of 2") {
+		t.Errorf("unexpected first message: %q", logRepo.Logs[0].Message)
+	}
+	if !strings.Contains(logRepo.Logs[1].Message, "service recovered") {
+		t.Errorf("unexpected second message: %q", logRepo.Logs[1].Message)
+	}
+}
+
+func TestHeartbeatService_FlapDetection(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+	depRepo.Dependencies[1] = dep
+
+	var healthy bool
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{Healthy: healthy, LatencyMs: 10, StatusCode: 200}
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	logRepo := NewMockStatusLogRepository()
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	service.SetFailureThreshold(1)
+	service.SetFlapDetection(10*time.Minute, 3)
+
+	// Alternate red and green once a minute; the fourth change within the
+	// window marks the dependency as flapping
+	for i := 0; i < 6; i++ {
+		healthy = i%2 == 1
+		if _, err := service.ForceCheck(context.Background(), 1); err != nil {
+			t.Fatalf("check %d: unexpected error: %v", i+1, err)
+		}
+		wantFlapping := i >= 3
+		if got := service.IsFlapping(1); got != wantFlapping {
+			t.Errorf("check %d: expected flapping=%v, got %v", i+1, wantFlapping, got)
+		}
+		suppressed := strings.Contains(logRepo.Logs[i].Message, "notifications suppressed")
+		if suppressed != wantFlapping {
+			t.Errorf("check %d: expected suppressed=%v, got message %q", i+1, wantFlapping, logRepo.Logs[i].Message)
+		}
+		now = now.Add(time.Minute)
+	}
+	if dep.Status != domain.StatusGreen {
+		t.Fatalf("expected dependency green after last check, got %s", dep.Status)
+	}
+
+	// Staying green until the changes age out of the window settles the
+	// dependency; subscribers last heard red, so the settled green is reported
+	for i := 0; i < 10 && service.IsFlapping(1); i++ {
+		now = now.Add(time.Minute)
+		service.ForceCheck(context.Background(), 1)
+	}
+	if service.IsFlapping(1) {
+		t.Fatal("expected dependency to stop flapping")
+	}
+	last := logRepo.Logs[len(logRepo.Logs)-1]
+	if last.Message != "Heartbeat status settled after flapping" || last.OldStatus != domain.StatusRed || last.NewStatus != domain.StatusGreen {
+		t.Errorf("unexpected settle log: %q %s -> %s", last.Message, last.OldStatus, last.NewStatus)
+	}
+}
+
+func TestHeartbeatService_FlapDetection_Disabled(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{URL: "https://redis.example.com/health", Interval: 60})
+	depRepo.Dependencies[1] = dep
+
+	var healthy bool
+	checker := NewMockHealthChecker()
+	checker.CheckWithConfigFunc = func(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+		return domain.HealthCheckResult{Healthy: healthy, LatencyMs: 10, StatusCode: 200}
+	}
+
+	logRepo := NewMockStatusLogRepository()
+	service := NewHeartbeatService(depRepo, logRepo, checker)
+	service.SetFailureThreshold(1)
+
+	for i := 0; i < 10; i++ {
+		healthy = i%2 == 1
+		service.ForceCheck(context.Background(), 1)
+	}
+	if service.IsFlapping(1) {
+		t.Error("expected flap detection disabled by default")
+	}
+	for _, log := range logRepo.Logs {
+		if strings.Contains(log.Message, "suppressed") {
+			t.Errorf("unexpected suppressed change: %q", log.Message)
+		}
+	}
+}
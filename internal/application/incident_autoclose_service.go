@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// IncidentAutoCloseService resolves incidents that were forgotten: no activity
+// for longer than maxAge while every affected system is green
+type IncidentAutoCloseService struct {
+	incidentRepo        domain.IncidentRepository
+	systemRepo          domain.SystemRepository
+	notificationService *NotificationService
+	maxAge              time.Duration
+	clock               Clock
+}
+
+// NewIncidentAutoCloseService creates a new IncidentAutoCloseService
+func NewIncidentAutoCloseService(incidentRepo domain.IncidentRepository, systemRepo domain.SystemRepository, maxAge time.Duration) *IncidentAutoCloseService {
+	return &IncidentAutoCloseService{
+		incidentRepo: incidentRepo,
+		systemRepo:   systemRepo,
+		maxAge:       maxAge,
+		clock:        SystemClock{},
+	}
+}
+
+// SetNotificationService enables the resolve-time incident digest
+func (s *IncidentAutoCloseService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetClock sets the clock that decides when a quiet incident is closed
+func (s *IncidentAutoCloseService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// CloseStale resolves stale incidents and returns them
+func (s *IncidentAutoCloseService) CloseStale(ctx context.Context) ([]*domain.Incident, error) {
+	incidents, err := s.incidentRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+	if len(incidents) == 0 {
+		return nil, nil
+	}
+
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+
+	now := s.clock.Now()
+	var closed []*domain.Incident
+	for _, incident := range incidents {
+		lastActivity, err := s.lastActivity(ctx, incident)
+		if err != nil {
+			return closed, err
+		}
+		if now.Sub(lastActivity) < s.maxAge {
+			continue
+		}
+		if !affectedSystemsGreen(incident, systems) {
+			continue
+		}
+
+		if err := incident.Resolve(""); err != nil {
+			continue
+		}
+		if err := s.incidentRepo.Update(ctx, incident); err != nil {
+			return closed, fmt.Errorf("failed to update incident: %w", err)
+		}
+
+		message := fmt.Sprintf("Automatically resolved: no activity for %s and all affected systems operational", s.maxAge)
+		update, _ := domain.NewIncidentUpdate(incident.ID, domain.IncidentResolved, message, "auto-close")
+		if update != nil {
+			s.incidentRepo.CreateUpdate(ctx, update)
+		}
+
+		if s.notificationService != nil {
+			if updates, err := s.incidentRepo.GetUpdates(ctx, incident.ID); err == nil {
+				s.notificationService.NotifyIncidentResolved(ctx, incident, updates)
+			}
+		}
+
+		closed = append(closed, incident)
+	}
+
+	return closed, nil
+}
+
+// lastActivity returns the time of the latest change to the incident or its timeline
+func (s *IncidentAutoCloseService) lastActivity(ctx context.Context, incident *domain.Incident) (time.Time, error) {
+	last := incident.UpdatedAt
+	updates, err := s.incidentRepo.GetUpdates(ctx, incident.ID)
+	if err != nil {
+		return last, fmt.Errorf("failed to get incident updates: %w", err)
+	}
+	for _, u := range updates {
+		if u.CreatedAt.After(last) {
+			last = u.CreatedAt
+		}
+	}
+	return last, nil
+}
+
+func affectedSystemsGreen(incident *domain.Incident, systems []*domain.System) bool {
+	for _, system := range systems {
+		if incident.AffectsSystem(system.ID) && system.Status != domain.StatusGreen {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func TestIncidentAutoCloseService_CloseStale(t *testing.T) {
+	ctx := context.Background()
+	incidentRepo := NewMockIncidentRepository()
+	systemRepo := NewMockSystemRepository()
+
+	green, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(ctx, green)
+	red, _ := domain.NewSystem("Billing", "", "", "")
+	red.UpdateStatus(domain.StatusRed)
+	systemRepo.Create(ctx, red)
+
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	newIncident := func(title string, systemIDs []int64) *domain.Incident {
+		incident, _ := domain.NewIncident(title, "Test", domain.SeverityMinor)
+		incident.SystemIDs = systemIDs
+		incident.CreatedAt = start
+		incident.UpdatedAt = start
+		incidentRepo.Create(ctx, incident)
+		return incident
+	}
+
+	stale := newIncident("Forgotten test", []int64{green.ID})
+	recent := newIncident("Still worked on", []int64{green.ID})
+	update, _ := domain.NewIncidentUpdate(recent.ID, recent.Status, "Investigating", "alice")
+	update.CreatedAt = start.Add(40 * time.Hour)
+	incidentRepo.CreateUpdate(ctx, update)
+	stillDown := newIncident("Billing outage", []int64{red.ID})
+
+	service := NewIncidentAutoCloseService(incidentRepo, systemRepo, 24*time.Hour)
+	service.SetClock(ClockFunc(func() time.Time { return start.Add(48 * time.Hour) }))
+
+	closed, err := service.CloseStale(ctx)
+	if err != nil {
+		t.Fatalf("CloseStale() error = %v", err)
+	}
+	if len(closed) != 1 || closed[0].ID != stale.ID {
+		t.Fatalf("expected only the stale incident to close, got %d", len(closed))
+	}
+
+	if !incidentRepo.Incidents[stale.ID].IsResolved() {
+		t.Error("expected stale incident to be resolved")
+	}
+	if incidentRepo.Incidents[recent.ID].IsResolved() {
+		t.Error("expected recently updated incident to stay open")
+	}
+	if incidentRepo.Incidents[stillDown.ID].IsResolved() {
+		t.Error("expected incident with a red system to stay open")
+	}
+
+	updates, _ := incidentRepo.GetUpdates(ctx, stale.ID)
+	if len(updates) != 1 || updates[0].CreatedBy != "auto-close" || updates[0].Status != domain.IncidentResolved {
+		t.Errorf("expected an auto-close update, got %+v", updates)
+	}
+}
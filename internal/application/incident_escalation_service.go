@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// DefaultMaxAckEscalations caps how often one unacknowledged incident is escalated
+const DefaultMaxAckEscalations = 3
+
+// IncidentEscalationService re-notifies about active incidents nobody has
+// acknowledged. The first escalation fires once an incident is unacknowledged
+// for the threshold; each further one waits twice as long as the previous
+// gap, up to maxEscalations per incident.
+type IncidentEscalationService struct {
+	incidentRepo        domain.IncidentRepository
+	notificationService *NotificationService
+	threshold           time.Duration
+	maxEscalations      int
+	clock               Clock
+
+	mu        sync.Mutex
+	escalated map[int64]ackEscalation // incident ID -> escalations sent so far
+}
+
+// ackEscalation tracks the escalations sent for one incident
+type ackEscalation struct {
+	count int
+	last  time.Time
+}
+
+// NewIncidentEscalationService creates a new IncidentEscalationService
+func NewIncidentEscalationService(incidentRepo domain.IncidentRepository, threshold time.Duration, maxEscalations int) *IncidentEscalationService {
+	if maxEscalations <= 0 {
+		maxEscalations = DefaultMaxAckEscalations
+	}
+	return &IncidentEscalationService{
+		incidentRepo:   incidentRepo,
+		threshold:      threshold,
+		maxEscalations: maxEscalations,
+		clock:          SystemClock{},
+		escalated:      make(map[int64]ackEscalation),
+	}
+}
+
+// SetNotificationService sets the notification service for escalations
+func (s *IncidentEscalationService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetClock sets the clock that decides when an unacknowledged incident
+// is escalated again
+func (s *IncidentEscalationService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Sweep escalates every active, unacknowledged incident that is due and
+// returns the incidents escalated in this sweep
+func (s *IncidentEscalationService) Sweep(ctx context.Context) ([]*domain.Incident, error) {
+	incidents, err := s.incidentRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active incidents: %w", err)
+	}
+
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := make(map[int64]bool, len(incidents))
+	var escalated []*domain.Incident
+	for _, incident := range incidents {
+		if incident.IsAcknowledged() {
+			continue
+		}
+		pending[incident.ID] = true
+
+		state := s.escalated[incident.ID]
+		if state.count >= s.maxEscalations || !s.due(incident, state, now) {
+			continue
+		}
+
+		state.count++
+		state.last = now
+		s.escalated[incident.ID] = state
+		escalated = append(escalated, incident)
+
+		if s.notificationService != nil {
+			go s.notificationService.NotifyIncidentEscalated(context.Background(), incident, state.count, incident.DurationAt(now))
+		}
+	}
+
+	// Forget incidents that were acknowledged or resolved
+	for id := range s.escalated {
+		if !pending[id] {
+			delete(s.escalated, id)
+		}
+	}
+
+	return escalated, nil
+}
+
+// due reports whether the next escalation of the incident should fire at now
+func (s *IncidentEscalationService) due(incident *domain.Incident, state ackEscalation, now time.Time) bool {
+	if state.count == 0 {
+		return incident.DurationAt(now) >= s.threshold
+	}
+	return now.Sub(state.last) >= s.threshold<<state.count
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func TestIncidentEscalationService_BacksOffToMax(t *testing.T) {
+	ctx := context.Background()
+	incidentRepo := NewMockIncidentRepository()
+
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	incident, _ := domain.NewIncident("Checkout down", "Payments failing", domain.SeverityCritical)
+	incident.CreatedAt = clock
+	incidentRepo.Create(ctx, incident)
+
+	service := NewIncidentEscalationService(incidentRepo, 10*time.Minute, 3)
+	service.SetClock(ClockFunc(func() time.Time { return clock }))
+
+	sweep := func() int {
+		t.Helper()
+		escalated, err := service.Sweep(ctx)
+		if err != nil {
+			t.Fatalf("Sweep() error = %v", err)
+		}
+		return len(escalated)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		want    int
+	}{
+		{9 * time.Minute, 0},  // before the threshold
+		{2 * time.Minute, 1},  // first escalation at 10m
+		{19 * time.Minute, 0}, // second waits 20m
+		{time.Minute, 1},
+		{39 * time.Minute, 0}, // third waits 40m
+		{time.Minute, 1},
+		{24 * time.Hour, 0}, // capped at the maximum
+	}
+	for i, step := range steps {
+		clock = clock.Add(step.advance)
+		if n := sweep(); n != step.want {
+			t.Errorf("step %d: expected %d escalations, got %d", i, step.want, n)
+		}
+	}
+}
+
+func TestIncidentEscalationService_SkipsAcknowledgedAndResolved(t *testing.T) {
+	ctx := context.Background()
+	incidentRepo := NewMockIncidentRepository()
+
+	clock := time.Now().Add(time.Hour)
+	acked, _ := domain.NewIncident("Acked", "Being handled", domain.SeverityMajor)
+	acked.Acknowledge("alice")
+	incidentRepo.Create(ctx, acked)
+	resolved, _ := domain.NewIncident("Resolved", "Fixed", domain.SeverityMajor)
+	resolved.Resolve("")
+	incidentRepo.Create(ctx, resolved)
+	open, _ := domain.NewIncident("Open", "Nobody looked", domain.SeverityMinor)
+	incidentRepo.Create(ctx, open)
+
+	service := NewIncidentEscalationService(incidentRepo, 10*time.Minute, 0)
+	service.SetClock(ClockFunc(func() time.Time { return clock }))
+
+	escalated, err := service.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if len(escalated) != 1 || escalated[0].ID != open.ID {
+		t.Fatalf("expected only the unacknowledged incident to escalate, got %v", escalated)
+	}
+
+	// Acknowledging stops further escalations
+	open.Acknowledge("bob")
+	clock = clock.Add(24 * time.Hour)
+	if escalated, _ := service.Sweep(ctx); len(escalated) != 0 {
+		t.Errorf("expected no escalation after acknowledgement, got %d", len(escalated))
+	}
+}
+
+func TestIncidentEscalationService_NotifiesEscalatedEvent(t *testing.T) {
+	events := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		events <- fmt.Sprintf("%v:%v:%v", body["event"], body["escalated"], body["escalation"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	webhook, _ := domain.NewWebhook("On-call", server.URL, domain.WebhookTypeGeneric)
+	webhook.SetEvents([]domain.WebhookEvent{domain.EventIncidentEscalated})
+	webhookRepo.Create(ctx, webhook)
+
+	incidentRepo := NewMockIncidentRepository()
+	incident, _ := domain.NewIncident("Checkout down", "Payments failing", domain.SeverityCritical)
+	incidentRepo.Create(ctx, incident)
+
+	service := NewIncidentEscalationService(incidentRepo, 5*time.Minute, 1)
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+	service.SetClock(ClockFunc(func() time.Time { return incident.CreatedAt.Add(6 * time.Minute) }))
+
+	if _, err := service.Sweep(ctx); err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+
+	select {
+	case got := <-events:
+		if got != "incident_escalated:true:1" {
+			t.Errorf("unexpected notification %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an escalation notification")
+	}
+}
@@ -2,25 +2,168 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"status-incident/internal/domain"
 )
 
+// DefaultIdempotencyWindow is how long an idempotency key keeps returning
+// the incident it created
+const DefaultIdempotencyWindow = 24 * time.Hour
+
+// Idempotency key reservation timing
+const (
+	// idempotencyPendingTTL bounds how long a reserved key waits for its
+	// incident, so a request that died mid-create does not hold the key
+	idempotencyPendingTTL = time.Minute
+	// A request finding the key reserved by a concurrent one polls for its
+	// incident for idempotencyWaitAttempts * idempotencyPollInterval
+	idempotencyPollInterval = 50 * time.Millisecond
+	idempotencyWaitAttempts = 100
+)
+
+// ErrIdempotencyKeyInUse is returned when a concurrent request holding the
+// same idempotency key did not create its incident in time
+var ErrIdempotencyKeyInUse = errors.New("another request with this idempotency key is still in progress")
+
 // IncidentService handles incident-related use cases
 type IncidentService struct {
-	incidentRepo domain.IncidentRepository
+	incidentRepo        domain.IncidentRepository
+	notificationService *NotificationService
+	subscriptionService *SubscriptionService
+	eventBroker         *StatusEventBroker
+	templateRepo        domain.IncidentTemplateRepository
+	requireAck          bool
+	idempotencyWindow   time.Duration
+	clock               Clock
 }
 
 // NewIncidentService creates a new IncidentService
 func NewIncidentService(incidentRepo domain.IncidentRepository) *IncidentService {
 	return &IncidentService{
-		incidentRepo: incidentRepo,
+		incidentRepo:      incidentRepo,
+		idempotencyWindow: DefaultIdempotencyWindow,
+		clock:             SystemClock{},
 	}
 }
 
+// SetNotificationService enables the resolve-time incident digest
+func (s *IncidentService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetEventBroker publishes incident changes to live subscribers
+func (s *IncidentService) SetEventBroker(b *StatusEventBroker) {
+	s.eventBroker = b
+}
+
+// SetSubscriptionService emails confirmed subscribers when incidents are
+// opened and resolved
+func (s *IncidentService) SetSubscriptionService(ss *SubscriptionService) {
+	s.subscriptionService = ss
+}
+
+// SetTemplateRepository enables incident templates
+func (s *IncidentService) SetTemplateRepository(repo domain.IncidentTemplateRepository) {
+	s.templateRepo = repo
+}
+
+// SetRequireAcknowledgement makes UpdateIncidentStatus reject moving an
+// unacknowledged incident to identified or monitoring
+func (s *IncidentService) SetRequireAcknowledgement(require bool) {
+	s.requireAck = require
+}
+
+// SetIdempotencyWindow sets how long an idempotency key maps to the
+// incident it created
+func (s *IncidentService) SetIdempotencyWindow(window time.Duration) {
+	s.idempotencyWindow = window
+}
+
+// SetClock sets the clock that starts scheduled incidents and times
+// incident updates
+func (s *IncidentService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
 // CreateIncident creates a new incident
 func (s *IncidentService) CreateIncident(ctx context.Context, title, message string, severity domain.IncidentSeverity, systemIDs []int64) (*domain.Incident, error) {
+	return s.CreateIncidentWithComponents(ctx, title, message, severity, systemIDs, nil)
+}
+
+// CreateIncidentWithComponents creates a new incident listing the components it affects
+func (s *IncidentService) CreateIncidentWithComponents(ctx context.Context, title, message string, severity domain.IncidentSeverity, systemIDs []int64, components []domain.AffectedComponent) (*domain.Incident, error) {
+	return s.createIncident(ctx, title, message, severity, systemIDs, components, nil)
+}
+
+// CreateIncidentIdempotent runs create unless an incident was already
+// created with the idempotency key in scope (the caller, e.g. an API key)
+// within the idempotency window, in which case that incident is returned
+// with created set to false. The key is reserved in the repository before
+// create runs, so concurrent requests, also on other instances, create at
+// most one incident; the others wait for it.
+func (s *IncidentService) CreateIncidentIdempotent(ctx context.Context, scope, key string, create func() (*domain.Incident, error)) (incident *domain.Incident, created bool, err error) {
+	for attempt := 0; ; attempt++ {
+		now := s.clock.Now()
+		reserved, err := s.incidentRepo.ReserveIdempotencyKey(ctx, scope, key, now, now.Add(idempotencyPendingTTL))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if reserved {
+			break
+		}
+
+		existing, err := s.incidentRepo.GetByIdempotencyKey(ctx, scope, key, now)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to look up idempotency key: %w", err)
+		}
+		if existing != nil {
+			return existing, false, nil
+		}
+
+		// Reserved by a request that is still creating its incident
+		if attempt >= idempotencyWaitAttempts {
+			return nil, false, ErrIdempotencyKeyInUse
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+
+	incident, err = create()
+	if err != nil {
+		// Free the key so a retry can create the incident
+		if err := s.incidentRepo.ReleaseIdempotencyKey(ctx, scope, key); err != nil {
+			slog.Error("failed to release incident idempotency key", "error", err)
+		}
+		return nil, false, err
+	}
+
+	// The incident exists either way; a lost mapping only weakens
+	// deduplication of later retries
+	if err := s.incidentRepo.SetIdempotencyKeyIncident(ctx, scope, key, incident.ID, s.clock.Now().Add(s.idempotencyWindow)); err != nil {
+		slog.Error("failed to save incident idempotency key", "incident_id", incident.ID, "error", err)
+	}
+
+	return incident, true, nil
+}
+
+// ScheduleIncident announces an incident that starts at the given future time.
+// It stays scheduled, and out of the active incidents, until StartDueIncidents
+// starts it; notifications are sent then.
+func (s *IncidentService) ScheduleIncident(ctx context.Context, title, message string, severity domain.IncidentSeverity, systemIDs []int64, components []domain.AffectedComponent, at time.Time) (*domain.Incident, error) {
+	if !at.After(s.clock.Now()) {
+		return nil, fmt.Errorf("invalid incident data: scheduled time must be in the future")
+	}
+	return s.createIncident(ctx, title, message, severity, systemIDs, components, &at)
+}
+
+func (s *IncidentService) createIncident(ctx context.Context, title, message string, severity domain.IncidentSeverity, systemIDs []int64, components []domain.AffectedComponent, scheduledFor *time.Time) (*domain.Incident, error) {
 	incident, err := domain.NewIncident(title, message, severity)
 	if err != nil {
 		return nil, fmt.Errorf("invalid incident data: %w", err)
@@ -29,6 +172,14 @@ func (s *IncidentService) CreateIncident(ctx context.Context, title, message str
 	if len(systemIDs) > 0 {
 		incident.SetSystemIDs(systemIDs)
 	}
+	if err := incident.SetComponents(components); err != nil {
+		return nil, fmt.Errorf("invalid incident data: %w", err)
+	}
+	if scheduledFor != nil {
+		if err := incident.Schedule(*scheduledFor); err != nil {
+			return nil, fmt.Errorf("invalid incident data: %w", err)
+		}
+	}
 
 	if err := s.incidentRepo.Create(ctx, incident); err != nil {
 		return nil, fmt.Errorf("failed to create incident: %w", err)
@@ -40,9 +191,108 @@ func (s *IncidentService) CreateIncident(ctx context.Context, title, message str
 		s.incidentRepo.CreateUpdate(ctx, update)
 	}
 
+	if incident.IsScheduled() {
+		return incident, nil
+	}
+
+	if s.notificationService != nil {
+		s.notificationService.NotifyIncident(ctx, incident, update, domain.EventIncidentStart)
+	}
+	if s.eventBroker != nil {
+		s.eventBroker.PublishIncident(incident, update, domain.EventIncidentStart)
+	}
+	if s.subscriptionService != nil {
+		s.subscriptionService.NotifyIncident(ctx, incident, domain.EventIncidentStart)
+	}
+
 	return incident, nil
 }
 
+// ImportedIncident describes a historical incident to import
+type ImportedIncident struct {
+	Title      string
+	Message    string
+	Severity   domain.IncidentSeverity
+	Status     domain.IncidentStatus
+	SystemIDs  []int64
+	Postmortem string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+	Updates    []ImportedIncidentUpdate
+}
+
+// ImportedIncidentUpdate describes a timeline entry of an imported incident
+type ImportedIncidentUpdate struct {
+	Status    domain.IncidentStatus
+	Message   string
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// ImportIncidents creates incidents with their original timing and timeline.
+// All entries are validated before anything is stored.
+func (s *IncidentService) ImportIncidents(ctx context.Context, imports []ImportedIncident) ([]*domain.Incident, error) {
+	incidents := make([]*domain.Incident, len(imports))
+	timelines := make([][]*domain.IncidentUpdate, len(imports))
+
+	for idx, imp := range imports {
+		incident, err := domain.NewHistoricalIncident(imp.Title, imp.Message, imp.Severity, imp.Status, imp.CreatedAt, imp.ResolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid incident #%d: %w", idx+1, err)
+		}
+		incident.SystemIDs = imp.SystemIDs
+		incident.Postmortem = imp.Postmortem
+
+		var updates []*domain.IncidentUpdate
+		for _, u := range imp.Updates {
+			status := u.Status
+			if status == "" {
+				status = incident.Status
+			}
+			createdBy := u.CreatedBy
+			if createdBy == "" {
+				createdBy = "import"
+			}
+			update, err := domain.NewIncidentUpdate(0, status, u.Message, createdBy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid update for incident #%d: %w", idx+1, err)
+			}
+			if u.CreatedAt.IsZero() {
+				return nil, fmt.Errorf("invalid update for incident #%d: created_at is required", idx+1)
+			}
+			update.CreatedAt = u.CreatedAt
+			if update.CreatedAt.After(incident.UpdatedAt) {
+				incident.UpdatedAt = update.CreatedAt
+			}
+			updates = append(updates, update)
+		}
+
+		// Mirror CreateIncident: a timeline always starts with the initial message
+		if len(updates) == 0 {
+			update, _ := domain.NewIncidentUpdate(0, incident.Status, incident.Message, "import")
+			update.CreatedAt = incident.CreatedAt
+			updates = append(updates, update)
+		}
+
+		incidents[idx] = incident
+		timelines[idx] = updates
+	}
+
+	for idx, incident := range incidents {
+		if err := s.incidentRepo.Create(ctx, incident); err != nil {
+			return nil, fmt.Errorf("failed to import incident: %w", err)
+		}
+		for _, update := range timelines[idx] {
+			update.IncidentID = incident.ID
+			if err := s.incidentRepo.CreateUpdate(ctx, update); err != nil {
+				return nil, fmt.Errorf("failed to import incident update: %w", err)
+			}
+		}
+	}
+
+	return incidents, nil
+}
+
 // GetIncident retrieves an incident by ID
 func (s *IncidentService) GetIncident(ctx context.Context, id int64) (*domain.Incident, error) {
 	incident, err := s.incidentRepo.GetByID(ctx, id)
@@ -61,6 +311,22 @@ func (s *IncidentService) GetAllIncidents(ctx context.Context, limit int) ([]*do
 	return incidents, nil
 }
 
+// GetIncidentsPage retrieves up to limit incidents matching filter, starting
+// at offset, together with the number of matching incidents
+func (s *IncidentService) GetIncidentsPage(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.Incident, int, error) {
+	incidents, err := s.incidentRepo.GetPage(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get incidents: %w", err)
+	}
+
+	total, err := s.incidentRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count incidents: %w", err)
+	}
+
+	return incidents, total, nil
+}
+
 // GetActiveIncidents retrieves all unresolved incidents
 func (s *IncidentService) GetActiveIncidents(ctx context.Context) ([]*domain.Incident, error) {
 	incidents, err := s.incidentRepo.GetActive(ctx)
@@ -70,6 +336,57 @@ func (s *IncidentService) GetActiveIncidents(ctx context.Context) ([]*domain.Inc
 	return incidents, nil
 }
 
+// GetScheduledIncidents retrieves announced incidents that have not started yet
+func (s *IncidentService) GetScheduledIncidents(ctx context.Context) ([]*domain.Incident, error) {
+	incidents, err := s.incidentRepo.GetScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled incidents: %w", err)
+	}
+	return incidents, nil
+}
+
+// StartDueIncidents moves scheduled incidents whose time has come to
+// investigating, notifying as if they had just been opened, and returns them
+func (s *IncidentService) StartDueIncidents(ctx context.Context) ([]*domain.Incident, error) {
+	scheduled, err := s.incidentRepo.GetScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled incidents: %w", err)
+	}
+
+	now := s.clock.Now()
+	var started []*domain.Incident
+	for _, incident := range scheduled {
+		if !incident.IsDue(now) {
+			continue
+		}
+		if err := incident.Start(); err != nil {
+			continue
+		}
+		if err := s.incidentRepo.Update(ctx, incident); err != nil {
+			return started, fmt.Errorf("failed to update incident: %w", err)
+		}
+
+		update, _ := domain.NewIncidentUpdate(incident.ID, incident.Status, "Scheduled incident started", "scheduler")
+		if update != nil {
+			s.incidentRepo.CreateUpdate(ctx, update)
+		}
+
+		if s.notificationService != nil {
+			s.notificationService.NotifyIncident(ctx, incident, update, domain.EventIncidentStart)
+		}
+		if s.eventBroker != nil {
+			s.eventBroker.PublishIncident(incident, update, domain.EventIncidentStart)
+		}
+		if s.subscriptionService != nil {
+			s.subscriptionService.NotifyIncident(ctx, incident, domain.EventIncidentStart)
+		}
+
+		started = append(started, incident)
+	}
+
+	return started, nil
+}
+
 // GetRecentIncidents retrieves recently resolved incidents
 func (s *IncidentService) GetRecentIncidents(ctx context.Context, days int) ([]*domain.Incident, error) {
 	if days <= 0 {
@@ -112,8 +429,9 @@ func (s *IncidentService) AcknowledgeIncident(ctx context.Context, id int64, by
 	return incident, nil
 }
 
-// UpdateIncidentStatus updates the status of an incident
-func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id int64, status domain.IncidentStatus, message, updatedBy string) (*domain.Incident, error) {
+// UpdateIncidentStatus updates the status of an incident. Webhooks are told
+// about the change unless notify is false.
+func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id int64, status domain.IncidentStatus, message, updatedBy string, notify bool) (*domain.Incident, error) {
 	incident, err := s.incidentRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get incident: %w", err)
@@ -122,6 +440,11 @@ func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id int64, st
 		return nil, fmt.Errorf("incident not found: %d", id)
 	}
 
+	if s.requireAck && !incident.IsAcknowledged() &&
+		(status == domain.IncidentIdentified || status == domain.IncidentMonitoring) {
+		return nil, domain.ErrIncidentNotAcknowledged
+	}
+
 	if err := incident.UpdateStatus(status); err != nil {
 		return nil, fmt.Errorf("failed to update status: %w", err)
 	}
@@ -136,11 +459,20 @@ func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id int64, st
 		s.incidentRepo.CreateUpdate(ctx, update)
 	}
 
+	if notify && s.notificationService != nil {
+		s.notificationService.NotifyIncident(ctx, incident, update, domain.EventIncidentUpdated)
+	}
+	// Live views follow silent updates too
+	if s.eventBroker != nil {
+		s.eventBroker.PublishIncident(incident, update, domain.EventIncidentUpdated)
+	}
+
 	return incident, nil
 }
 
-// AddIncidentUpdate adds a timeline entry without changing status
-func (s *IncidentService) AddIncidentUpdate(ctx context.Context, incidentID int64, message, createdBy string) (*domain.IncidentUpdate, error) {
+// AddIncidentUpdate adds a timeline entry without changing status. With
+// notify false the entry is silent, e.g. for fixing a typo in the last one.
+func (s *IncidentService) AddIncidentUpdate(ctx context.Context, incidentID int64, message, createdBy string, notify bool) (*domain.IncidentUpdate, error) {
 	incident, err := s.incidentRepo.GetByID(ctx, incidentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get incident: %w", err)
@@ -158,6 +490,13 @@ func (s *IncidentService) AddIncidentUpdate(ctx context.Context, incidentID int6
 		return nil, fmt.Errorf("failed to create update: %w", err)
 	}
 
+	if notify && s.notificationService != nil {
+		s.notificationService.NotifyIncident(ctx, incident, update, domain.EventIncidentUpdated)
+	}
+	if s.eventBroker != nil {
+		s.eventBroker.PublishIncident(incident, update, domain.EventIncidentUpdated)
+	}
+
 	return update, nil
 }
 
@@ -189,6 +528,43 @@ func (s *IncidentService) ResolveIncident(ctx context.Context, id int64, postmor
 		s.incidentRepo.CreateUpdate(ctx, update)
 	}
 
+	// Send the final summary including the resolve update
+	if s.notificationService != nil {
+		updates, err := s.incidentRepo.GetUpdates(ctx, id)
+		if err != nil {
+			slog.Error("failed to get updates for incident digest", "incident_id", id, "error", err)
+		} else {
+			s.notificationService.NotifyIncidentResolved(ctx, incident, updates)
+		}
+	}
+	if s.subscriptionService != nil {
+		s.subscriptionService.NotifyIncident(ctx, incident, domain.EventIncidentEnd)
+	}
+	if s.eventBroker != nil {
+		s.eventBroker.PublishIncident(incident, update, domain.EventIncidentEnd)
+	}
+
+	return incident, nil
+}
+
+// UpdateIncidentComponents replaces the list of components affected by an incident
+func (s *IncidentService) UpdateIncidentComponents(ctx context.Context, id int64, components []domain.AffectedComponent) (*domain.Incident, error) {
+	incident, err := s.incidentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident == nil {
+		return nil, fmt.Errorf("incident not found: %d", id)
+	}
+
+	if err := incident.SetComponents(components); err != nil {
+		return nil, fmt.Errorf("invalid components: %w", err)
+	}
+
+	if err := s.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
 	return incident, nil
 }
 
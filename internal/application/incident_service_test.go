@@ -2,8 +2,15 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"status-incident/internal/domain"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewIncidentService(t *testing.T) {
@@ -65,6 +72,256 @@ func TestIncidentService_CreateIncident_NoSystems(t *testing.T) {
 	}
 }
 
+func TestIncidentService_CreateIncidentWithComponents(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	ctx := context.Background()
+
+	incident, err := service.CreateIncidentWithComponents(ctx, "Checkout errors", "Payments failing",
+		domain.SeverityMajor, nil, []domain.AffectedComponent{{Name: "Checkout"}, {Name: "Search"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := incident.ComponentNames(); len(names) != 2 || names[0] != "Checkout" {
+		t.Errorf("expected components [Checkout Search], got %v", names)
+	}
+
+	_, err = service.CreateIncidentWithComponents(ctx, "Broken", "Something broke", domain.SeverityMinor, nil,
+		[]domain.AffectedComponent{{Name: ""}})
+	if err == nil {
+		t.Error("expected error for a component without a name")
+	}
+}
+
+func TestIncidentService_UpdateIncidentComponents(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	ctx := context.Background()
+
+	incident, _ := service.CreateIncident(ctx, "Checkout errors", "Payments failing", domain.SeverityMajor, nil)
+
+	updated, err := service.UpdateIncidentComponents(ctx, incident.ID, []domain.AffectedComponent{{Name: "Checkout"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updated.Components) != 1 || updated.Components[0].Name != "Checkout" {
+		t.Errorf("expected components [Checkout], got %+v", updated.Components)
+	}
+
+	if _, err := service.UpdateIncidentComponents(ctx, incident.ID, []domain.AffectedComponent{{Name: "A"}, {Name: "a"}}); err == nil {
+		t.Error("expected error for duplicate components")
+	}
+	if _, err := service.UpdateIncidentComponents(ctx, 999, nil); err == nil {
+		t.Error("expected error for an unknown incident")
+	}
+}
+
+func TestIncidentService_ScheduleIncident(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	if _, err := service.ScheduleIncident(ctx, "Database upgrade", "Read-only mode", domain.SeverityMinor, nil, nil, now); err == nil {
+		t.Error("expected error for a start time that is not in the future")
+	}
+
+	incident, err := service.ScheduleIncident(ctx, "Database upgrade", "Read-only mode", domain.SeverityMinor, nil, nil, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !incident.IsScheduled() {
+		t.Errorf("expected status scheduled, got %q", incident.Status)
+	}
+
+	active, _ := service.GetActiveIncidents(ctx)
+	if len(active) != 0 {
+		t.Errorf("expected no active incidents, got %d", len(active))
+	}
+	scheduled, _ := service.GetScheduledIncidents(ctx)
+	if len(scheduled) != 1 || scheduled[0].ID != incident.ID {
+		t.Errorf("expected the incident among scheduled ones, got %v", scheduled)
+	}
+}
+
+func TestIncidentService_CreateIncidentIdempotent(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	service.SetIdempotencyWindow(time.Hour)
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	create := func(key string) (*domain.Incident, bool) {
+		t.Helper()
+		incident, created, err := service.CreateIncidentIdempotent(ctx, "api_key:1", key, func() (*domain.Incident, error) {
+			return service.CreateIncident(ctx, "Checkout down", "Alert fired", domain.SeverityMajor, nil)
+		})
+		if err != nil {
+			t.Fatalf("CreateIncidentIdempotent() error = %v", err)
+		}
+		return incident, created
+	}
+
+	first, created := create("alert-1")
+	if !created {
+		t.Fatal("expected the first request to create an incident")
+	}
+
+	// Duplicate key returns the existing incident
+	dup, created := create("alert-1")
+	if created || dup.ID != first.ID {
+		t.Errorf("expected duplicate key to return incident %d, got %d (created=%v)", first.ID, dup.ID, created)
+	}
+
+	// Distinct key creates a new incident
+	other, created := create("alert-2")
+	if !created || other.ID == first.ID {
+		t.Errorf("expected a distinct key to create a new incident, got %d (created=%v)", other.ID, created)
+	}
+
+	// Keys expire after the window
+	now = now.Add(time.Hour)
+	again, created := create("alert-1")
+	if !created || again.ID == first.ID {
+		t.Errorf("expected an expired key to create a new incident, got %d (created=%v)", again.ID, created)
+	}
+
+	if len(incidentRepo.Incidents) != 3 {
+		t.Errorf("expected 3 incidents, got %d", len(incidentRepo.Incidents))
+	}
+}
+
+func TestIncidentService_CreateIncidentIdempotent_Concurrent(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	ctx := context.Background()
+
+	// Two services sharing a repository stand in for two instances sharing a
+	// database: only the repository can keep them from both creating
+	var wg sync.WaitGroup
+	ids := make(chan int64, 2)
+	for i := 0; i < 2; i++ {
+		service := NewIncidentService(incidentRepo)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			incident, _, err := service.CreateIncidentIdempotent(ctx, "", "alert-1", func() (*domain.Incident, error) {
+				// Keep the first create in flight while the other request arrives
+				time.Sleep(100 * time.Millisecond)
+				return service.CreateIncident(ctx, "Checkout down", "Alert fired", domain.SeverityMajor, nil)
+			})
+			if err != nil {
+				t.Errorf("CreateIncidentIdempotent() error = %v", err)
+				return
+			}
+			ids <- incident.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	first, second := <-ids, <-ids
+	if first != second {
+		t.Errorf("expected both requests to get the same incident, got %d and %d", first, second)
+	}
+	if len(incidentRepo.Incidents) != 1 {
+		t.Errorf("expected a single incident, got %d", len(incidentRepo.Incidents))
+	}
+}
+
+func TestIncidentService_CreateIncidentIdempotent_ScopedPerCaller(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	ctx := context.Background()
+
+	create := func(scope string) *domain.Incident {
+		t.Helper()
+		incident, created, err := service.CreateIncidentIdempotent(ctx, scope, "alert-1", func() (*domain.Incident, error) {
+			return service.CreateIncident(ctx, "Checkout down", "Alert fired", domain.SeverityMajor, nil)
+		})
+		if err != nil || !created {
+			t.Fatalf("expected a new incident for scope %q, got created=%v err=%v", scope, created, err)
+		}
+		return incident
+	}
+
+	// Two integrations sending the same key string each get their own incident
+	if first, second := create("api_key:1"), create("api_key:2"); first.ID == second.ID {
+		t.Errorf("expected separate incidents per scope, both got %d", first.ID)
+	}
+}
+
+func TestIncidentService_CreateIncidentIdempotent_FailedCreateReleasesKey(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	ctx := context.Background()
+
+	_, _, err := service.CreateIncidentIdempotent(ctx, "", "alert-1", func() (*domain.Incident, error) {
+		return nil, errors.New("invalid incident data")
+	})
+	if err == nil {
+		t.Fatal("expected the create error to be returned")
+	}
+
+	// The retry is not blocked by the failed request's reservation
+	incident, created, err := service.CreateIncidentIdempotent(ctx, "", "alert-1", func() (*domain.Incident, error) {
+		return service.CreateIncident(ctx, "Checkout down", "Alert fired", domain.SeverityMajor, nil)
+	})
+	if err != nil || !created || incident == nil {
+		t.Errorf("expected the retry to create an incident, got created=%v err=%v", created, err)
+	}
+}
+
+func TestIncidentService_StartDueIncidents(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	soon, _ := service.ScheduleIncident(ctx, "Database upgrade", "Read-only mode", domain.SeverityMinor, nil, nil, now.Add(30*time.Minute))
+	later, _ := service.ScheduleIncident(ctx, "Storage migration", "Uploads paused", domain.SeverityMinor, nil, nil, now.Add(2*time.Hour))
+
+	started, err := service.StartDueIncidents(ctx)
+	if err != nil {
+		t.Fatalf("StartDueIncidents() error = %v", err)
+	}
+	if len(started) != 0 {
+		t.Fatalf("expected nothing to start yet, got %d", len(started))
+	}
+
+	now = now.Add(time.Hour)
+	started, err = service.StartDueIncidents(ctx)
+	if err != nil {
+		t.Fatalf("StartDueIncidents() error = %v", err)
+	}
+	if len(started) != 1 || started[0].ID != soon.ID {
+		t.Fatalf("expected only the due incident to start, got %v", started)
+	}
+	if incidentRepo.Incidents[soon.ID].Status != domain.IncidentInvestigating {
+		t.Errorf("expected started incident to be investigating, got %q", incidentRepo.Incidents[soon.ID].Status)
+	}
+	if !incidentRepo.Incidents[later.ID].IsScheduled() {
+		t.Error("expected the later incident to stay scheduled")
+	}
+
+	updates, _ := incidentRepo.GetUpdates(ctx, soon.ID)
+	if len(updates) != 2 || updates[1].CreatedBy != "scheduler" || updates[1].Status != domain.IncidentInvestigating {
+		t.Errorf("expected a scheduler update after the announcement, got %+v", updates)
+	}
+
+	active, _ := service.GetActiveIncidents(ctx)
+	if len(active) != 1 || active[0].ID != soon.ID {
+		t.Errorf("expected the started incident to be active, got %v", active)
+	}
+
+	// Starting again is a no-op
+	if started, _ := service.StartDueIncidents(ctx); len(started) != 0 {
+		t.Errorf("expected no incidents to start twice, got %d", len(started))
+	}
+}
+
 func TestIncidentService_CreateIncident_EmptyTitle(t *testing.T) {
 	incidentRepo := NewMockIncidentRepository()
 	service := NewIncidentService(incidentRepo)
@@ -123,6 +380,29 @@ func TestIncidentService_GetAllIncidents(t *testing.T) {
 	}
 }
 
+func TestIncidentService_GetIncidentsPage(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	for i := int64(1); i <= 5; i++ {
+		incident, _ := domain.NewIncident("Incident "+string(rune('A'+i-1)), "Message", domain.SeverityMinor)
+		incident.ID = i
+		incidentRepo.Incidents[i] = incident
+	}
+
+	service := NewIncidentService(incidentRepo)
+
+	incidents, total, err := service.GetIncidentsPage(context.Background(), domain.IncidentFilter{}, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected total 5, got %d", total)
+	}
+	// Newest first, so offset 1 skips incident 5
+	if len(incidents) != 2 || incidents[0].ID != 4 || incidents[1].ID != 3 {
+		t.Errorf("expected incidents 4 and 3, got %d incidents", len(incidents))
+	}
+}
+
 func TestIncidentService_GetActiveIncidents(t *testing.T) {
 	incidentRepo := NewMockIncidentRepository()
 	incident1, _ := domain.NewIncident("Active", "Message", domain.SeverityMinor)
@@ -224,6 +504,7 @@ func TestIncidentService_UpdateIncidentStatus(t *testing.T) {
 		domain.IncidentIdentified,
 		"Root cause identified",
 		"admin",
+		true,
 	)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -249,6 +530,7 @@ func TestIncidentService_UpdateIncidentStatus_NotFound(t *testing.T) {
 		domain.IncidentIdentified,
 		"Test",
 		"admin",
+		true,
 	)
 	if err == nil {
 		t.Error("expected error for non-existent incident")
@@ -268,6 +550,7 @@ func TestIncidentService_AddIncidentUpdate(t *testing.T) {
 		1,
 		"Still investigating",
 		"admin",
+		true,
 	)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -285,12 +568,62 @@ func TestIncidentService_AddIncidentUpdate_NotFound(t *testing.T) {
 	incidentRepo := NewMockIncidentRepository()
 	service := NewIncidentService(incidentRepo)
 
-	_, err := service.AddIncidentUpdate(context.Background(), 999, "Test", "admin")
+	_, err := service.AddIncidentUpdate(context.Background(), 999, "Test", "admin", true)
 	if err == nil {
 		t.Error("expected error for non-existent incident")
 	}
 }
 
+func TestIncidentService_UpdateIncidentStatus_RequireAcknowledgement(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name       string
+		requireAck bool
+		wantErr    bool
+	}{
+		{"rule on", true, true},
+		{"rule off", false, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			incidentRepo := NewMockIncidentRepository()
+			incident, _ := domain.NewIncident("Test", "Message", domain.SeverityMajor)
+			incident.ID = 1
+			incidentRepo.Incidents[1] = incident
+
+			service := NewIncidentService(incidentRepo)
+			service.SetRequireAcknowledgement(tt.requireAck)
+
+			_, err := service.UpdateIncidentStatus(ctx, 1, domain.IncidentIdentified, "Root cause found", "ops", true)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrIncidentNotAcknowledged) {
+					t.Fatalf("expected ErrIncidentNotAcknowledged, got %v", err)
+				}
+				if incident.Status != domain.IncidentInvestigating {
+					t.Errorf("status changed to %s despite missing acknowledgement", incident.Status)
+				}
+				if len(incidentRepo.Updates) != 0 {
+					t.Errorf("expected no timeline update, got %d", len(incidentRepo.Updates))
+				}
+
+				// Once acknowledged the incident can advance
+				if _, err := service.AcknowledgeIncident(ctx, 1, "ops"); err != nil {
+					t.Fatalf("AcknowledgeIncident() error = %v", err)
+				}
+				if _, err := service.UpdateIncidentStatus(ctx, 1, domain.IncidentIdentified, "Root cause found", "ops", true); err != nil {
+					t.Fatalf("UpdateIncidentStatus() after acknowledgement error = %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("UpdateIncidentStatus() error = %v", err)
+			}
+
+			if incident.Status != domain.IncidentIdentified {
+				t.Errorf("Status = %s, want identified", incident.Status)
+			}
+		})
+	}
+}
+
 func TestIncidentService_ResolveIncident(t *testing.T) {
 	incidentRepo := NewMockIncidentRepository()
 	incident, _ := domain.NewIncident("Test", "Message", domain.SeverityMinor)
@@ -340,6 +673,104 @@ func TestIncidentService_ResolveIncident_NotFound(t *testing.T) {
 	}
 }
 
+func TestIncidentService_ResolveIncident_SendsDigest(t *testing.T) {
+	genericBodies := make(chan domain.IncidentDigestPayload, 4)
+	generic := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload domain.IncidentDigestPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		genericBodies <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer generic.Close()
+
+	slackTexts := make(chan string, 4)
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		slackTexts <- body["text"]
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slack.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	for _, target := range []struct {
+		url  string
+		kind domain.WebhookType
+	}{{generic.URL, domain.WebhookTypeGeneric}, {slack.URL, domain.WebhookTypeSlack}} {
+		webhook, _ := domain.NewWebhook("Stakeholders", target.url, target.kind)
+		webhook.SetEvents([]domain.WebhookEvent{domain.EventIncidentEnd})
+		webhookRepo.Create(ctx, webhook)
+	}
+
+	systemRepo := NewMockSystemRepository()
+	system, _ := domain.NewSystem("Payments", "", "", "")
+	systemRepo.Create(ctx, system)
+
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	service.SetNotificationService(NewNotificationService(webhookRepo, systemRepo, NewMockDependencyRepository()))
+
+	incident, err := service.CreateIncident(ctx, "Card payments failing", "Investigating errors", domain.SeverityMajor, []int64{system.ID})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := service.UpdateIncidentStatus(ctx, incident.ID, domain.IncidentIdentified, "Provider outage identified", "ops", true); err != nil {
+		t.Fatalf("UpdateIncidentStatus() error = %v", err)
+	}
+	if _, err := service.AddIncidentUpdate(ctx, incident.ID, "Failover in progress", "ops", true); err != nil {
+		t.Fatalf("AddIncidentUpdate() error = %v", err)
+	}
+	if _, err := service.ResolveIncident(ctx, incident.ID, "Switched provider", "ops"); err != nil {
+		t.Fatalf("ResolveIncident() error = %v", err)
+	}
+
+	messages := []string{"Investigating errors", "Provider outage identified", "Failover in progress", "Incident resolved: Switched provider"}
+
+	select {
+	case payload := <-genericBodies:
+		if payload.Event != domain.EventIncidentEnd {
+			t.Errorf("expected event %q, got %q", domain.EventIncidentEnd, payload.Event)
+		}
+		if len(payload.Incident.Timeline) != len(messages) {
+			t.Fatalf("expected %d timeline entries, got %d", len(messages), len(payload.Incident.Timeline))
+		}
+		for i, want := range messages {
+			if payload.Incident.Timeline[i].Message != want {
+				t.Errorf("timeline[%d] = %q, want %q", i, payload.Incident.Timeline[i].Message, want)
+			}
+		}
+		if len(payload.Incident.AffectedSystems) != 1 || payload.Incident.AffectedSystems[0].Name != "Payments" {
+			t.Errorf("expected Payments as affected system, got %+v", payload.Incident.AffectedSystems)
+		}
+		if payload.Incident.ResolvedAt == nil {
+			t.Error("expected resolved_at to be set")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a digest on the generic webhook")
+	}
+
+	select {
+	case text := <-slackTexts:
+		for _, want := range append(messages, "Card payments failing", "Duration:", "Payments") {
+			if !strings.Contains(text, want) {
+				t.Errorf("expected digest text to contain %q, got:\n%s", want, text)
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a digest on the slack webhook")
+	}
+
+	// One message per channel
+	select {
+	case <-genericBodies:
+		t.Error("unexpected extra generic notification")
+	case <-slackTexts:
+		t.Error("unexpected extra slack notification")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
 func TestIncidentService_DeleteIncident(t *testing.T) {
 	incidentRepo := NewMockIncidentRepository()
 	incident, _ := domain.NewIncident("Test", "Message", domain.SeverityMinor)
@@ -567,3 +998,248 @@ func TestIncidentService_GetIncidentUpdates_ContentValidation(t *testing.T) {
 		t.Errorf("update[1]: expected status Identified, got %q", updates[1].Status)
 	}
 }
+
+func TestIncidentService_ImportIncidents_PreservesTiming(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+
+	created := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	identified := created.Add(20 * time.Minute)
+	resolved := created.Add(2 * time.Hour)
+
+	incidents, err := service.ImportIncidents(context.Background(), []ImportedIncident{
+		{
+			Title:      "Payments outage",
+			Message:    "Card payments failing",
+			Severity:   domain.SeverityCritical,
+			SystemIDs:  []int64{3},
+			Postmortem: "Expired certificate",
+			CreatedAt:  created,
+			ResolvedAt: &resolved,
+			Updates: []ImportedIncidentUpdate{
+				{Status: domain.IncidentInvestigating, Message: "Looking into it", CreatedAt: created, CreatedBy: "alice"},
+				{Status: domain.IncidentIdentified, Message: "Certificate expired", CreatedAt: identified},
+				{Status: domain.IncidentResolved, Message: "Certificate renewed", CreatedAt: resolved, CreatedBy: "bob"},
+			},
+		},
+		{
+			Title:     "Slow search",
+			Message:   "Search latency elevated",
+			Severity:  domain.SeverityMinor,
+			CreatedAt: created.Add(24 * time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %d", len(incidents))
+	}
+
+	stored := incidentRepo.Incidents[incidents[0].ID]
+	if !stored.CreatedAt.Equal(created) {
+		t.Errorf("expected created_at %v, got %v", created, stored.CreatedAt)
+	}
+	if stored.ResolvedAt == nil || !stored.ResolvedAt.Equal(resolved) {
+		t.Errorf("expected resolved_at %v, got %v", resolved, stored.ResolvedAt)
+	}
+	if !stored.UpdatedAt.Equal(resolved) {
+		t.Errorf("expected updated_at %v, got %v", resolved, stored.UpdatedAt)
+	}
+	if stored.Status != domain.IncidentResolved {
+		t.Errorf("expected status resolved, got %q", stored.Status)
+	}
+	if stored.Postmortem != "Expired certificate" {
+		t.Errorf("expected postmortem to be kept, got %q", stored.Postmortem)
+	}
+
+	updates, _ := service.GetIncidentUpdates(context.Background(), stored.ID)
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(updates))
+	}
+	wantTimes := []time.Time{created, identified, resolved}
+	wantBy := []string{"alice", "import", "bob"}
+	for i, u := range updates {
+		if !u.CreatedAt.Equal(wantTimes[i]) {
+			t.Errorf("update[%d]: expected created_at %v, got %v", i, wantTimes[i], u.CreatedAt)
+		}
+		if u.CreatedBy != wantBy[i] {
+			t.Errorf("update[%d]: expected created_by %q, got %q", i, wantBy[i], u.CreatedBy)
+		}
+	}
+	if updates[1].Status != domain.IncidentIdentified {
+		t.Errorf("expected update[1] status identified, got %q", updates[1].Status)
+	}
+
+	// Without updates the timeline starts with the initial message at created_at
+	open := incidentRepo.Incidents[incidents[1].ID]
+	if open.Status != domain.IncidentInvestigating {
+		t.Errorf("expected status investigating, got %q", open.Status)
+	}
+	initial, _ := service.GetIncidentUpdates(context.Background(), open.ID)
+	if len(initial) != 1 || !initial[0].CreatedAt.Equal(open.CreatedAt) {
+		t.Errorf("expected one initial update at created_at, got %+v", initial)
+	}
+}
+
+func TestIncidentService_ImportIncidents_InvalidRejectsAll(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+
+	created := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	_, err := service.ImportIncidents(context.Background(), []ImportedIncident{
+		{Title: "Valid", Message: "ok", CreatedAt: created},
+		{Title: "Missing created_at", Message: "bad"},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid incident")
+	}
+	if len(incidentRepo.Incidents) != 0 {
+		t.Errorf("expected nothing to be stored, got %d incidents", len(incidentRepo.Incidents))
+	}
+}
+
+func TestIncidentService_UpdateNotificationsOptIn(t *testing.T) {
+	type received struct {
+		hook  string
+		event domain.WebhookEvent
+	}
+	events := make(chan received, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Event domain.WebhookEvent `json:"event"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		events <- received{hook: r.URL.Path, event: body.Event}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+
+	openResolve, _ := domain.NewWebhook("Stakeholders", server.URL+"/open-resolve", domain.WebhookTypeGeneric)
+	openResolve.SetEvents([]domain.WebhookEvent{domain.EventIncidentStart, domain.EventIncidentEnd})
+	webhookRepo.Create(ctx, openResolve)
+
+	chatty, _ := domain.NewWebhook("Responders", server.URL+"/all", domain.WebhookTypeGeneric)
+	chatty.SetEvents([]domain.WebhookEvent{domain.EventIncidentStart, domain.EventIncidentUpdated, domain.EventIncidentEnd})
+	webhookRepo.Create(ctx, chatty)
+
+	service := NewIncidentService(NewMockIncidentRepository())
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+
+	incident, err := service.CreateIncident(ctx, "Search degraded", "Investigating", domain.SeverityMinor, nil)
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := service.UpdateIncidentStatus(ctx, incident.ID, domain.IncidentIdentified, "Index rebuild stuck", "ops", true); err != nil {
+		t.Fatalf("UpdateIncidentStatus() error = %v", err)
+	}
+	if _, err := service.AddIncidentUpdate(ctx, incident.ID, "Rebuild restarted", "ops", true); err != nil {
+		t.Fatalf("AddIncidentUpdate() error = %v", err)
+	}
+	if _, err := service.ResolveIncident(ctx, incident.ID, "", "ops"); err != nil {
+		t.Fatalf("ResolveIncident() error = %v", err)
+	}
+
+	// 2 for the open/resolve webhook, 4 for the chatty one
+	counts := map[string]map[domain.WebhookEvent]int{"/open-resolve": {}, "/all": {}}
+	for i := 0; i < 6; i++ {
+		select {
+		case r := <-events:
+			counts[r.hook][r.event]++
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %d notifications", i)
+		}
+	}
+
+	select {
+	case r := <-events:
+		t.Errorf("unexpected extra notification %v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	got := counts["/open-resolve"]
+	if got[domain.EventIncidentStart] != 1 || got[domain.EventIncidentEnd] != 1 {
+		t.Errorf("open/resolve webhook should receive open and resolve, got %v", got)
+	}
+	if got[domain.EventIncidentUpdated] != 0 {
+		t.Errorf("open/resolve webhook should skip updates, got %d", got[domain.EventIncidentUpdated])
+	}
+	if counts["/all"][domain.EventIncidentUpdated] != 2 {
+		t.Errorf("subscribed webhook should receive 2 updates, got %v", counts["/all"])
+	}
+}
+
+func TestIncidentService_SilentUpdates(t *testing.T) {
+	type received struct {
+		Event  domain.WebhookEvent `json:"event"`
+		Update *struct {
+			Message string `json:"message"`
+		} `json:"update"`
+	}
+	events := make(chan received, 8)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body received
+		json.NewDecoder(r.Body).Decode(&body)
+		events <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	webhook, _ := domain.NewWebhook("Stakeholders", server.URL, domain.WebhookTypeGeneric)
+	webhook.SetEvents([]domain.WebhookEvent{domain.EventIncidentUpdated, domain.EventIncidentEnd})
+	webhookRepo.Create(ctx, webhook)
+
+	service := NewIncidentService(NewMockIncidentRepository())
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+
+	incident, err := service.CreateIncident(ctx, "Login failing", "Investigating", domain.SeverityMajor, nil)
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if _, err := service.AddIncidentUpdate(ctx, incident.ID, "Investigating, typo fixed", "ops", false); err != nil {
+		t.Fatalf("AddIncidentUpdate() error = %v", err)
+	}
+	if _, err := service.UpdateIncidentStatus(ctx, incident.ID, domain.IncidentMonitoring, "Quiet status tweak", "ops", false); err != nil {
+		t.Fatalf("UpdateIncidentStatus() error = %v", err)
+	}
+	if _, err := service.AddIncidentUpdate(ctx, incident.ID, "Fix deployed", "ops", true); err != nil {
+		t.Fatalf("AddIncidentUpdate() error = %v", err)
+	}
+	if _, err := service.ResolveIncident(ctx, incident.ID, "", "ops"); err != nil {
+		t.Fatalf("ResolveIncident() error = %v", err)
+	}
+
+	var got []received
+	for len(got) < 2 {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %d notifications", len(got))
+		}
+	}
+	select {
+	case e := <-events:
+		got = append(got, e)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	counts := map[domain.WebhookEvent]int{}
+	for _, e := range got {
+		counts[e.Event]++
+		if e.Event == domain.EventIncidentUpdated && (e.Update == nil || e.Update.Message != "Fix deployed") {
+			t.Errorf("expected only the notifying update to be sent, got %+v", e.Update)
+		}
+	}
+	if counts[domain.EventIncidentUpdated] != 1 {
+		t.Errorf("expected silent updates to skip webhooks, got %d update notifications", counts[domain.EventIncidentUpdated])
+	}
+	if counts[domain.EventIncidentEnd] != 1 {
+		t.Errorf("expected the resolve to notify, got %d", counts[domain.EventIncidentEnd])
+	}
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"status-incident/internal/domain"
+)
+
+// ErrIncidentTemplateNotFound is returned for operations on a missing template
+var ErrIncidentTemplateNotFound = errors.New("incident template not found")
+
+// IncidentOverrides replaces template defaults when an incident is created
+// from a template. Empty fields keep the template's values.
+type IncidentOverrides struct {
+	Title     string
+	Message   string
+	Severity  domain.IncidentSeverity
+	SystemIDs []int64
+	// Variables fill the {name} placeholders of the template's title pattern
+	Variables map[string]string
+}
+
+// CreateTemplate creates a new incident template
+func (s *IncidentService) CreateTemplate(ctx context.Context, name, titlePattern string, severity domain.IncidentSeverity, message string, systemIDs []int64) (*domain.IncidentTemplate, error) {
+	t, err := domain.NewIncidentTemplate(name, titlePattern, severity, message, systemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template data: %w", err)
+	}
+
+	if err := s.templateRepo.Create(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to create incident template: %w", err)
+	}
+
+	return t, nil
+}
+
+// GetTemplate retrieves an incident template by ID; nil if it doesn't exist
+func (s *IncidentService) GetTemplate(ctx context.Context, id int64) (*domain.IncidentTemplate, error) {
+	t, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident template: %w", err)
+	}
+	return t, nil
+}
+
+// GetAllTemplates retrieves all incident templates
+func (s *IncidentService) GetAllTemplates(ctx context.Context) ([]*domain.IncidentTemplate, error) {
+	templates, err := s.templateRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident templates: %w", err)
+	}
+	return templates, nil
+}
+
+// UpdateTemplate replaces the fields of an incident template
+func (s *IncidentService) UpdateTemplate(ctx context.Context, id int64, name, titlePattern string, severity domain.IncidentSeverity, message string, systemIDs []int64) (*domain.IncidentTemplate, error) {
+	t, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrIncidentTemplateNotFound
+	}
+
+	if err := t.Update(name, titlePattern, severity, message, systemIDs); err != nil {
+		return nil, fmt.Errorf("invalid template data: %w", err)
+	}
+
+	if err := s.templateRepo.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update incident template: %w", err)
+	}
+
+	return t, nil
+}
+
+// DeleteTemplate removes an incident template; incidents created from it are kept
+func (s *IncidentService) DeleteTemplate(ctx context.Context, id int64) error {
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete incident template: %w", err)
+	}
+	return nil
+}
+
+// CreateIncidentFromTemplate opens an incident pre-filled from a template,
+// applying any overrides
+func (s *IncidentService) CreateIncidentFromTemplate(ctx context.Context, templateID int64, overrides IncidentOverrides) (*domain.Incident, error) {
+	t, err := s.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrIncidentTemplateNotFound
+	}
+
+	title := t.RenderTitle(overrides.Variables)
+	if overrides.Title != "" {
+		title = overrides.Title
+	}
+	message := t.DefaultMessage
+	if overrides.Message != "" {
+		message = overrides.Message
+	}
+	severity := t.DefaultSeverity
+	if overrides.Severity != "" {
+		if severity, err = domain.ParseIncidentSeverity(string(overrides.Severity)); err != nil {
+			return nil, fmt.Errorf("invalid incident data: %w", err)
+		}
+	}
+	systemIDs := t.DefaultSystemIDs
+	if overrides.SystemIDs != nil {
+		systemIDs = overrides.SystemIDs
+	}
+
+	return s.CreateIncident(ctx, title, message, severity, systemIDs)
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func newTemplateTestService() (*IncidentService, *MockIncidentRepository) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewIncidentService(incidentRepo)
+	service.SetTemplateRepository(NewMockIncidentTemplateRepository())
+	return service, incidentRepo
+}
+
+func TestIncidentService_CreateTemplate(t *testing.T) {
+	service, _ := newTemplateTestService()
+	ctx := context.Background()
+
+	tmpl, err := service.CreateTemplate(ctx, "DB failover", "Database failover on {cluster}", domain.SeverityMajor, "We are failing over the primary database.", []int64{3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.ID == 0 {
+		t.Error("expected template ID to be set")
+	}
+
+	if _, err := service.CreateTemplate(ctx, "Broken", "Title", domain.IncidentSeverity("catastrophic"), "", nil); err == nil {
+		t.Error("expected invalid default severity to be rejected")
+	}
+
+	templates, _ := service.GetAllTemplates(ctx)
+	if len(templates) != 1 {
+		t.Errorf("expected 1 stored template, got %d", len(templates))
+	}
+}
+
+func TestIncidentService_UpdateTemplate(t *testing.T) {
+	service, _ := newTemplateTestService()
+	ctx := context.Background()
+
+	tmpl, _ := service.CreateTemplate(ctx, "CDN", "CDN degradation", domain.SeverityMinor, "Assets load slowly.", nil)
+
+	updated, err := service.UpdateTemplate(ctx, tmpl.ID, "CDN", "CDN outage", domain.SeverityCritical, "Assets are not loading.", []int64{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.TitlePattern != "CDN outage" || updated.DefaultSeverity != domain.SeverityCritical {
+		t.Errorf("unexpected template after update: %+v", updated)
+	}
+
+	if _, err := service.UpdateTemplate(ctx, tmpl.ID, "CDN", "CDN outage", domain.IncidentSeverity("huge"), "", nil); err == nil {
+		t.Error("expected invalid default severity to be rejected on update")
+	}
+	if _, err := service.UpdateTemplate(ctx, 99, "X", "Y", domain.SeverityMinor, "", nil); !errors.Is(err, ErrIncidentTemplateNotFound) {
+		t.Errorf("expected ErrIncidentTemplateNotFound, got %v", err)
+	}
+}
+
+func TestIncidentService_CreateIncidentFromTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		overrides    IncidentOverrides
+		wantTitle    string
+		wantMessage  string
+		wantSeverity domain.IncidentSeverity
+		wantSystems  []int64
+	}{
+		{
+			name:         "template defaults",
+			overrides:    IncidentOverrides{Variables: map[string]string{"cluster": "eu-1"}},
+			wantTitle:    "Database failover on eu-1",
+			wantMessage:  "We are failing over the primary database.",
+			wantSeverity: domain.SeverityMajor,
+			wantSystems:  []int64{3, 4},
+		},
+		{
+			name: "overrides",
+			overrides: IncidentOverrides{
+				Title:     "Emergency failover",
+				Message:   "Primary is down, failing over now.",
+				Severity:  domain.SeverityCritical,
+				SystemIDs: []int64{7},
+			},
+			wantTitle:    "Emergency failover",
+			wantMessage:  "Primary is down, failing over now.",
+			wantSeverity: domain.SeverityCritical,
+			wantSystems:  []int64{7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, incidentRepo := newTemplateTestService()
+			tmpl, _ := service.CreateTemplate(ctx, "DB failover", "Database failover on {cluster}", domain.SeverityMajor, "We are failing over the primary database.", []int64{3, 4})
+
+			incident, err := service.CreateIncidentFromTemplate(ctx, tmpl.ID, tt.overrides)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if incident.Title != tt.wantTitle || incident.Message != tt.wantMessage || incident.Severity != tt.wantSeverity {
+				t.Errorf("got %q/%q/%s, want %q/%q/%s", incident.Title, incident.Message, incident.Severity,
+					tt.wantTitle, tt.wantMessage, tt.wantSeverity)
+			}
+			if !reflect.DeepEqual(incident.SystemIDs, tt.wantSystems) {
+				t.Errorf("expected systems %v, got %v", tt.wantSystems, incident.SystemIDs)
+			}
+			if incident.Status != domain.IncidentInvestigating {
+				t.Errorf("expected investigating status, got %s", incident.Status)
+			}
+			if incidentRepo.Incidents[incident.ID] == nil {
+				t.Error("expected incident to be stored")
+			}
+			if len(incidentRepo.Updates) != 1 || incidentRepo.Updates[0].Message != tt.wantMessage {
+				t.Errorf("expected the initial timeline entry to use the message, got %+v", incidentRepo.Updates)
+			}
+		})
+	}
+}
+
+func TestIncidentService_CreateIncidentFromTemplate_Errors(t *testing.T) {
+	service, incidentRepo := newTemplateTestService()
+	ctx := context.Background()
+
+	if _, err := service.CreateIncidentFromTemplate(ctx, 42, IncidentOverrides{}); !errors.Is(err, ErrIncidentTemplateNotFound) {
+		t.Errorf("expected ErrIncidentTemplateNotFound, got %v", err)
+	}
+
+	tmpl, _ := service.CreateTemplate(ctx, "Blank", "Something broke", domain.SeverityMinor, "", nil)
+	if _, err := service.CreateIncidentFromTemplate(ctx, tmpl.ID, IncidentOverrides{}); err == nil {
+		t.Error("expected an error when neither the template nor the request has a message")
+	}
+	if _, err := service.CreateIncidentFromTemplate(ctx, tmpl.ID, IncidentOverrides{Message: "Details", Severity: "severe"}); err == nil {
+		t.Error("expected an invalid severity override to be rejected")
+	}
+
+	if len(incidentRepo.Incidents) != 0 {
+		t.Errorf("expected no incidents to be created, got %d", len(incidentRepo.Incidents))
+	}
+}
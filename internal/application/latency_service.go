@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"status-incident/internal/domain"
 	"time"
@@ -11,6 +12,9 @@ import (
 type LatencyService struct {
 	latencyRepo domain.LatencyRepository
 	depRepo     domain.DependencyRepository
+	// analyticsRepo supplies the incident periods shown on latency charts
+	// (nil leaves them out)
+	analyticsRepo domain.AnalyticsRepository
 }
 
 // NewLatencyService creates a new LatencyService
@@ -21,8 +25,35 @@ func NewLatencyService(latencyRepo domain.LatencyRepository, depRepo domain.Depe
 	}
 }
 
-// GetDependencyLatencyStats retrieves latency statistics for a dependency
-func (s *LatencyService) GetDependencyLatencyStats(ctx context.Context, dependencyID int64, period string) (*domain.LatencyStats, error) {
+// SetAnalyticsRepository includes the dependency's incident periods in
+// latency stats
+func (s *LatencyService) SetAnalyticsRepository(repo domain.AnalyticsRepository) {
+	s.analyticsRepo = repo
+}
+
+// ErrInvalidLatencyInterval is returned for a chart interval that is not a
+// whole number of minutes or splits the period into too many buckets
+var ErrInvalidLatencyInterval = errors.New("invalid latency interval")
+
+// maxLatencyBuckets caps the chart points of one period
+const maxLatencyBuckets = 2880
+
+// GetDependencyLatencyStats retrieves latency statistics for a dependency.
+// Chart points are bucketed by interval, or by the period's default interval
+// when it is zero, with an empty point for every bucket without checks.
+func (s *LatencyService) GetDependencyLatencyStats(ctx context.Context, dependencyID int64, period string, interval time.Duration) (*domain.LatencyStats, error) {
+	start, end := parsePeriod(period)
+	intervalMinutes := getIntervalMinutes(period)
+	if interval != 0 {
+		if interval < time.Minute || interval%time.Minute != 0 {
+			return nil, fmt.Errorf("%w: %s is not a whole number of minutes", ErrInvalidLatencyInterval, interval)
+		}
+		if end.Sub(start)/interval > maxLatencyBuckets {
+			return nil, fmt.Errorf("%w: %s splits the period into more than %d buckets", ErrInvalidLatencyInterval, interval, maxLatencyBuckets)
+		}
+		intervalMinutes = int(interval / time.Minute)
+	}
+
 	dep, err := s.depRepo.GetByID(ctx, dependencyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency: %w", err)
@@ -31,9 +62,6 @@ func (s *LatencyService) GetDependencyLatencyStats(ctx context.Context, dependen
 		return nil, fmt.Errorf("dependency not found")
 	}
 
-	start, end := parsePeriod(period)
-	intervalMinutes := getIntervalMinutes(period)
-
 	stats, err := s.latencyRepo.GetStats(ctx, dependencyID, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
@@ -45,12 +73,57 @@ func (s *LatencyService) GetDependencyLatencyStats(ctx context.Context, dependen
 	// Get data points for chart
 	dataPoints, err := s.latencyRepo.GetAggregated(ctx, dependencyID, start, end, intervalMinutes)
 	if err == nil {
-		stats.DataPoints = dataPoints
+		stats.DataPoints = fillLatencyGaps(dataPoints, start, end, intervalMinutes)
+	}
+
+	// Get incident periods for chart annotations
+	if s.analyticsRepo != nil {
+		incidents, err := s.analyticsRepo.GetIncidentsByDependencyID(ctx, dependencyID, start, end)
+		if err == nil {
+			stats.Incidents = latencyIncidents(incidents)
+		}
 	}
 
 	return stats, nil
 }
 
+// latencyIncidents converts incident periods to chart annotations
+func latencyIncidents(periods []domain.IncidentPeriod) []domain.LatencyIncident {
+	if len(periods) == 0 {
+		return nil
+	}
+	incidents := make([]domain.LatencyIncident, 0, len(periods))
+	for _, p := range periods {
+		incidents = append(incidents, domain.LatencyIncident{
+			StartedAt: p.StartedAt,
+			EndedAt:   p.EndedAt,
+			Severity:  p.MaxSeverity,
+		})
+	}
+	return incidents
+}
+
+// fillLatencyGaps returns a point for every bucket between start and end,
+// adding an empty point for buckets missing from points
+func fillLatencyGaps(points []domain.LatencyPoint, start, end time.Time, intervalMinutes int) []domain.LatencyPoint {
+	step := int64(intervalMinutes) * 60
+	byBucket := make(map[int64]domain.LatencyPoint, len(points))
+	for _, p := range points {
+		byBucket[p.Timestamp.Unix()] = p
+	}
+
+	first := start.Unix() / step * step
+	filled := make([]domain.LatencyPoint, 0, (end.Unix()-first)/step+1)
+	for bucket := first; bucket <= end.Unix(); bucket += step {
+		if p, ok := byBucket[bucket]; ok {
+			filled = append(filled, p)
+			continue
+		}
+		filled = append(filled, domain.LatencyPoint{Timestamp: time.Unix(bucket, 0).UTC()})
+	}
+	return filled
+}
+
 // GetDependencyUptimeHeatmap retrieves uptime heatmap for a dependency
 func (s *LatencyService) GetDependencyUptimeHeatmap(ctx context.Context, dependencyID int64, days int) ([]domain.UptimePoint, error) {
 	if days <= 0 {
@@ -66,6 +139,23 @@ func (s *LatencyService) GetDependencyLatencyChart(ctx context.Context, dependen
 	return s.latencyRepo.GetAggregated(ctx, dependencyID, start, end, intervalMinutes)
 }
 
+// LatencyHistogramWindow is how far back GetDependencyLatencyHistogram looks
+const LatencyHistogramWindow = time.Hour
+
+// maxHistogramRecords caps the records read per dependency for a histogram
+const maxHistogramRecords = 10000
+
+// GetDependencyLatencyHistogram buckets a dependency's successful check
+// latencies over the last LatencyHistogramWindow
+func (s *LatencyService) GetDependencyLatencyHistogram(ctx context.Context, dependencyID int64) (domain.LatencyHistogram, error) {
+	end := time.Now()
+	records, err := s.latencyRepo.GetByDependency(ctx, dependencyID, end.Add(-LatencyHistogramWindow), end, maxHistogramRecords)
+	if err != nil {
+		return domain.LatencyHistogram{}, fmt.Errorf("failed to get latency records: %w", err)
+	}
+	return domain.NewLatencyHistogram(records), nil
+}
+
 // CleanupOldRecords removes records older than specified days
 func (s *LatencyService) CleanupOldRecords(ctx context.Context, retentionDays int) error {
 	if retentionDays <= 0 {
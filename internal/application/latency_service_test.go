@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"status-incident/internal/domain"
 	"testing"
 	"time"
@@ -29,7 +30,7 @@ func TestLatencyService_GetDependencyLatencyStats(t *testing.T) {
 
 	service := NewLatencyService(latencyRepo, depRepo)
 
-	stats, err := service.GetDependencyLatencyStats(context.Background(), 1, "7d")
+	stats, err := service.GetDependencyLatencyStats(context.Background(), 1, "7d", 0)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -51,7 +52,7 @@ func TestLatencyService_GetDependencyLatencyStats_NotFound(t *testing.T) {
 
 	service := NewLatencyService(latencyRepo, depRepo)
 
-	_, err := service.GetDependencyLatencyStats(context.Background(), 999, "7d")
+	_, err := service.GetDependencyLatencyStats(context.Background(), 999, "7d", 0)
 	if err == nil {
 		t.Error("expected error for non-existent dependency")
 	}
@@ -332,7 +333,7 @@ func TestLatencyService_GetDependencyLatencyStats_VerifyIntervalPassed(t *testin
 
 			service := NewLatencyService(latencyRepo, depRepo)
 
-			_, err := service.GetDependencyLatencyStats(context.Background(), 1, tt.period)
+			_, err := service.GetDependencyLatencyStats(context.Background(), 1, tt.period, 0)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -343,3 +344,157 @@ func TestLatencyService_GetDependencyLatencyStats_VerifyIntervalPassed(t *testin
 		})
 	}
 }
+
+func TestLatencyService_GetDependencyLatencyStats_FillsGaps(t *testing.T) {
+	latencyRepo := NewMockLatencyRepository()
+	var capturedInterval int
+	var firstBucket time.Time
+	latencyRepo.GetAggregatedFunc = func(ctx context.Context, dependencyID int64, start, end time.Time, intervalMinutes int) ([]domain.LatencyPoint, error) {
+		capturedInterval = intervalMinutes
+		firstBucket = start.UTC().Truncate(30 * time.Minute)
+		return []domain.LatencyPoint{
+			{Timestamp: firstBucket.Add(time.Hour), AvgMs: 50, P50Ms: 45, P95Ms: 90, P99Ms: 99, Count: 4},
+		}, nil
+	}
+
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	depRepo.Dependencies[1] = dep
+
+	service := NewLatencyService(latencyRepo, depRepo)
+
+	stats, err := service.GetDependencyLatencyStats(context.Background(), 1, "6h", 30*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if capturedInterval != 30 {
+		t.Errorf("expected interval 30 minutes, got %d", capturedInterval)
+	}
+
+	// 6h in 30 minute buckets, plus the partial bucket at the start
+	if len(stats.DataPoints) != 13 {
+		t.Fatalf("expected 13 points, got %d", len(stats.DataPoints))
+	}
+	for i, p := range stats.DataPoints {
+		if want := firstBucket.Add(time.Duration(i) * 30 * time.Minute); !p.Timestamp.Equal(want) {
+			t.Errorf("point %d: expected timestamp %v, got %v", i, want, p.Timestamp)
+		}
+		if i == 2 {
+			if p.Count != 4 || p.P95Ms != 90 {
+				t.Errorf("expected the repository point at index 2, got %+v", p)
+			}
+			continue
+		}
+		if p.Count != 0 || p.AvgMs != 0 || p.P99Ms != 0 {
+			t.Errorf("point %d: expected an empty bucket, got %+v", i, p)
+		}
+	}
+}
+
+func TestLatencyService_GetDependencyLatencyStats_InvalidInterval(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	depRepo.Dependencies[1] = dep
+
+	service := NewLatencyService(NewMockLatencyRepository(), depRepo)
+
+	tests := []struct {
+		period   string
+		interval time.Duration
+	}{
+		{"24h", 90 * time.Second},
+		{"24h", 30 * time.Second},
+		{"24h", -time.Hour},
+		{"90d", time.Minute},
+	}
+	for _, tt := range tests {
+		_, err := service.GetDependencyLatencyStats(context.Background(), 1, tt.period, tt.interval)
+		if !errors.Is(err, ErrInvalidLatencyInterval) {
+			t.Errorf("period %s, interval %s: expected ErrInvalidLatencyInterval, got %v", tt.period, tt.interval, err)
+		}
+	}
+}
+
+func TestLatencyService_GetDependencyLatencyStats_Incidents(t *testing.T) {
+	latencyRepo := NewMockLatencyRepository()
+	latencyRepo.GetAggregatedFunc = func(ctx context.Context, dependencyID int64, start, end time.Time, intervalMinutes int) ([]domain.LatencyPoint, error) {
+		bucket := start.UTC().Truncate(15 * time.Minute).Add(time.Hour)
+		return []domain.LatencyPoint{{Timestamp: bucket, AvgMs: 900, P95Ms: 1500, Count: 10, Failures: 3}}, nil
+	}
+
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	depRepo.Dependencies[1] = dep
+
+	var windowStart, windowEnd time.Time
+	var incidentStart, incidentEnd time.Time
+	analyticsRepo := NewMockAnalyticsRepository()
+	analyticsRepo.GetIncidentsByDependencyIDFunc = func(ctx context.Context, dependencyID int64, start, end time.Time) ([]domain.IncidentPeriod, error) {
+		if dependencyID != 1 {
+			t.Errorf("expected incidents of dependency 1, got %d", dependencyID)
+		}
+		windowStart, windowEnd = start, end
+		incidentStart = start.Add(time.Hour)
+		incidentEnd = incidentStart.Add(20 * time.Minute)
+		return []domain.IncidentPeriod{{
+			DependencyID: &dependencyID,
+			StartedAt:    incidentStart,
+			EndedAt:      &incidentEnd,
+			Duration:     20 * time.Minute,
+			MaxSeverity:  domain.StatusRed,
+			LogCount:     2,
+		}}, nil
+	}
+
+	service := NewLatencyService(latencyRepo, depRepo)
+	service.SetAnalyticsRepository(analyticsRepo)
+
+	stats, err := service.GetDependencyLatencyStats(context.Background(), 1, "24h", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if windowEnd.Sub(windowStart) != 24*time.Hour {
+		t.Errorf("expected incidents for the 24h window, got %v to %v", windowStart, windowEnd)
+	}
+	if len(stats.Incidents) != 1 {
+		t.Fatalf("expected 1 incident, got %d", len(stats.Incidents))
+	}
+	incident := stats.Incidents[0]
+	if !incident.StartedAt.Equal(incidentStart) || incident.EndedAt == nil || !incident.EndedAt.Equal(incidentEnd) {
+		t.Errorf("expected incident from %v to %v, got %+v", incidentStart, incidentEnd, incident)
+	}
+	if incident.Severity != domain.StatusRed {
+		t.Errorf("expected severity red, got %s", incident.Severity)
+	}
+
+	var withChecks int
+	for _, p := range stats.DataPoints {
+		if p.Count > 0 {
+			withChecks++
+		}
+	}
+	if withChecks != 1 {
+		t.Errorf("expected the latency point alongside the incident, got %d points with checks", withChecks)
+	}
+}
+
+func TestLatencyService_GetDependencyLatencyStats_NoAnalyticsRepository(t *testing.T) {
+	depRepo := NewMockDependencyRepository()
+	dep, _ := domain.NewDependency(1, "Redis", "Cache")
+	dep.ID = 1
+	depRepo.Dependencies[1] = dep
+
+	service := NewLatencyService(NewMockLatencyRepository(), depRepo)
+
+	stats, err := service.GetDependencyLatencyStats(context.Background(), 1, "24h", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Incidents != nil {
+		t.Errorf("expected no incidents without an analytics repository, got %+v", stats.Incidents)
+	}
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Logger records events as a message plus alternating key-value fields,
+// e.g. logger.Error("webhook delivery failed", "webhook_id", 3, "attempt", 2).
+// *slog.Logger satisfies it.
+type Logger interface {
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// Log output formats accepted by NewLogger
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// NewLogger returns a logger writing JSON lines or key=value text to w
+func NewLogger(format string, w io.Writer) (*slog.Logger, error) {
+	switch format {
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, nil)), nil
+	case LogFormatText:
+		return slog.New(slog.NewTextHandler(w, nil)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (want %s or %s)", format, LogFormatJSON, LogFormatText)
+	}
+}
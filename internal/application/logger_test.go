@@ -0,0 +1,49 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(LogFormatJSON, &buf)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	logger.Warn("check slow", "dependency_id", 7, "latency_ms", 1500)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "check slow" {
+		t.Errorf("level/msg = %v/%v", entry["level"], entry["msg"])
+	}
+	if entry["dependency_id"] != float64(7) || entry["latency_ms"] != float64(1500) {
+		t.Errorf("expected fields in entry, got %v", entry)
+	}
+}
+
+func TestNewLogger_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(LogFormatText, &buf)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	logger.Info("started", "version", "1.2.3")
+
+	if out := buf.String(); !strings.Contains(out, "msg=started") || !strings.Contains(out, "version=1.2.3") {
+		t.Errorf("unexpected text output %q", out)
+	}
+}
+
+func TestNewLogger_UnknownFormat(t *testing.T) {
+	if _, err := NewLogger("xml", &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -3,41 +3,228 @@ package application
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"status-incident/internal/domain"
 )
 
+// MaintenanceConflictError is returned when a window would double-book a
+// system that another window already covers at the same time
+type MaintenanceConflictError struct {
+	Conflicts []*domain.Maintenance
+}
+
+func (e *MaintenanceConflictError) Error() string {
+	windows := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		windows[i] = fmt.Sprintf("#%d %q (%s - %s)", c.ID, c.Title,
+			c.StartTime.UTC().Format(time.RFC3339), c.EndTime.UTC().Format(time.RFC3339))
+	}
+	return "maintenance overlaps with " + strings.Join(windows, ", ")
+}
+
+// DefaultMaintenanceReminderLead is how long before a maintenance window
+// starts the first reminder is sent by default
+const DefaultMaintenanceReminderLead = 30 * time.Minute
+
 // MaintenanceService handles maintenance-related use cases
 type MaintenanceService struct {
-	maintenanceRepo domain.MaintenanceRepository
+	maintenanceRepo     domain.MaintenanceRepository
+	notificationService *NotificationService
+	clock               Clock
+	// reminderLead is how long before a window starts the first reminder is
+	// sent (0 disables reminders)
+	reminderLead time.Duration
 }
 
 // NewMaintenanceService creates a new MaintenanceService
 func NewMaintenanceService(maintenanceRepo domain.MaintenanceRepository) *MaintenanceService {
 	return &MaintenanceService{
 		maintenanceRepo: maintenanceRepo,
+		clock:           SystemClock{},
 	}
 }
 
-// CreateMaintenance creates a new maintenance window
+// SetClock sets the clock that decides when maintenance windows start
+// and end
+func (s *MaintenanceService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// SetNotificationService sets the notification service for subscriber notifications
+func (s *MaintenanceService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetReminderLeadTime sets how long before a window starts webhooks get a
+// maintenance_reminder; they are reminded again when it starts and ends.
+// Zero disables reminders.
+func (s *MaintenanceService) SetReminderLeadTime(lead time.Duration) {
+	s.reminderLead = lead
+}
+
+// CreateMaintenance creates a new maintenance window without notifying
+// subscribers. Overlapping windows on a shared system are rejected.
 func (s *MaintenanceService) CreateMaintenance(ctx context.Context, title, description string, startTime, endTime time.Time, systemIDs []int64) (*domain.Maintenance, error) {
+	return s.ScheduleMaintenance(ctx, title, description, startTime, endTime, systemIDs, false)
+}
+
+// ScheduleMaintenance creates a new maintenance window; when notifySubscribers
+// is set, webhooks are told about the window now and again when it starts
+func (s *MaintenanceService) ScheduleMaintenance(ctx context.Context, title, description string, startTime, endTime time.Time, systemIDs []int64, notifySubscribers bool) (*domain.Maintenance, error) {
+	return s.ScheduleRecurringMaintenance(ctx, title, description, startTime, endTime, systemIDs, notifySubscribers, domain.Recurrence{}, "", false)
+}
+
+// ScheduleRecurringMaintenance creates a maintenance window that repeats
+// according to recurrence; each occurrence is created once the previous one
+// ends. timezone is the IANA zone the window is shown in (empty = UTC).
+// Unless allowOverlap is set, a window that overlaps another one on a shared
+// system is rejected with a *MaintenanceConflictError.
+func (s *MaintenanceService) ScheduleRecurringMaintenance(ctx context.Context, title, description string, startTime, endTime time.Time, systemIDs []int64, notifySubscribers bool, recurrence domain.Recurrence, timezone string, allowOverlap bool) (*domain.Maintenance, error) {
 	m, err := domain.NewMaintenance(title, description, startTime, endTime)
 	if err != nil {
 		return nil, fmt.Errorf("invalid maintenance data: %w", err)
 	}
+	if err := m.SetTimezone(timezone); err != nil {
+		return nil, fmt.Errorf("invalid maintenance data: %w", err)
+	}
+	if err := m.SetRecurrence(recurrence); err != nil {
+		return nil, fmt.Errorf("invalid maintenance data: %w", err)
+	}
 
 	if len(systemIDs) > 0 {
 		m.SetSystemIDs(systemIDs)
 	}
+	m.SetNotifySubscribers(notifySubscribers)
+	m.RefreshStatusAt(s.clock.Now())
+
+	if !allowOverlap {
+		if err := s.checkConflicts(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	// A window that is already running is announced as started
+	startsNow := m.NeedsStartNotification()
+	if startsNow {
+		m.StartNotified = true
+	}
 
 	if err := s.maintenanceRepo.Create(ctx, m); err != nil {
 		return nil, fmt.Errorf("failed to create maintenance: %w", err)
 	}
 
+	if m.NotifySubscribers && s.notificationService != nil {
+		event := domain.EventMaintenanceScheduled
+		if startsNow {
+			event = domain.EventMaintenanceStarted
+		}
+		go s.notificationService.NotifyMaintenance(context.Background(), m, event)
+	}
+
 	return m, nil
 }
 
+// NotifyStartedMaintenances announces flagged windows that have started since
+// the last call and returns them
+func (s *MaintenanceService) NotifyStartedMaintenances(ctx context.Context) ([]*domain.Maintenance, error) {
+	actives, err := s.maintenanceRepo.GetActive(ctx, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active maintenances: %w", err)
+	}
+
+	var started []*domain.Maintenance
+	for _, m := range actives {
+		if !m.NeedsStartNotification() {
+			continue
+		}
+
+		m.StartNotified = true
+		if err := s.maintenanceRepo.Update(ctx, m); err != nil {
+			return started, fmt.Errorf("failed to update maintenance: %w", err)
+		}
+		started = append(started, m)
+
+		if s.notificationService != nil {
+			go s.notificationService.NotifyMaintenance(context.Background(), m, domain.EventMaintenanceStarted)
+		}
+	}
+
+	return started, nil
+}
+
+// SendReminders sends the maintenance reminders that are due and returns the
+// reminded windows; each window's LastReminder is the stage just sent
+func (s *MaintenanceService) SendReminders(ctx context.Context) ([]*domain.Maintenance, error) {
+	if s.reminderLead <= 0 {
+		return nil, nil
+	}
+
+	maintenances, err := s.maintenanceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenances: %w", err)
+	}
+
+	now := s.clock.Now()
+	var reminded []*domain.Maintenance
+	for _, m := range maintenances {
+		m.RefreshStatusAt(now)
+		reminder := m.DueReminder(now, s.reminderLead)
+		if reminder == domain.ReminderNone {
+			continue
+		}
+
+		m.LastReminder = reminder
+		if err := s.maintenanceRepo.Update(ctx, m); err != nil {
+			return reminded, fmt.Errorf("failed to update maintenance: %w", err)
+		}
+		reminded = append(reminded, m)
+
+		if s.notificationService != nil {
+			go s.notificationService.NotifyMaintenanceReminder(context.Background(), m, reminder)
+		}
+	}
+
+	return reminded, nil
+}
+
+// CreateNextOccurrences creates the next occurrence of every recurring window
+// that has ended, and returns the created windows
+func (s *MaintenanceService) CreateNextOccurrences(ctx context.Context) ([]*domain.Maintenance, error) {
+	maintenances, err := s.maintenanceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenances: %w", err)
+	}
+
+	now := s.clock.Now()
+	var created []*domain.Maintenance
+	for _, m := range maintenances {
+		if !m.NeedsNextOccurrence(now) {
+			continue
+		}
+
+		if next := m.NextOccurrence(now); next != nil {
+			if err := s.maintenanceRepo.Create(ctx, next); err != nil {
+				return created, fmt.Errorf("failed to create maintenance: %w", err)
+			}
+			created = append(created, next)
+
+			if next.NotifySubscribers && s.notificationService != nil {
+				go s.notificationService.NotifyMaintenance(context.Background(), next, domain.EventMaintenanceScheduled)
+			}
+		}
+
+		m.NextCreated = true
+		if err := s.maintenanceRepo.Update(ctx, m); err != nil {
+			return created, fmt.Errorf("failed to update maintenance: %w", err)
+		}
+	}
+
+	return created, nil
+}
+
 // GetMaintenance retrieves a maintenance window by ID
 func (s *MaintenanceService) GetMaintenance(ctx context.Context, id int64) (*domain.Maintenance, error) {
 	m, err := s.maintenanceRepo.GetByID(ctx, id)
@@ -58,24 +245,52 @@ func (s *MaintenanceService) GetAllMaintenances(ctx context.Context) ([]*domain.
 
 // GetActiveMaintenances retrieves currently active maintenance windows
 func (s *MaintenanceService) GetActiveMaintenances(ctx context.Context) ([]*domain.Maintenance, error) {
-	maintenances, err := s.maintenanceRepo.GetActive(ctx)
+	maintenances, err := s.maintenanceRepo.GetActive(ctx, s.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active maintenances: %w", err)
 	}
 	return maintenances, nil
 }
 
-// GetUpcomingMaintenances retrieves scheduled maintenance windows
+// GetUpcomingMaintenances retrieves scheduled maintenance windows. For
+// recurring windows in progress, the next occurrence is included before it
+// is created; such computed windows have no ID.
 func (s *MaintenanceService) GetUpcomingMaintenances(ctx context.Context) ([]*domain.Maintenance, error) {
-	maintenances, err := s.maintenanceRepo.GetUpcoming(ctx)
+	now := s.clock.Now()
+	maintenances, err := s.maintenanceRepo.GetUpcoming(ctx, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming maintenances: %w", err)
 	}
+
+	actives, err := s.maintenanceRepo.GetActive(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active maintenances: %w", err)
+	}
+
+	computed := false
+	for _, m := range actives {
+		if m.NextCreated {
+			continue
+		}
+		if next := m.NextOccurrence(now); next != nil && next.StartTime.After(now) {
+			maintenances = append(maintenances, next)
+			computed = true
+		}
+	}
+
+	if computed {
+		sort.SliceStable(maintenances, func(i, j int) bool {
+			return maintenances[i].StartTime.Before(maintenances[j].StartTime)
+		})
+	}
+
 	return maintenances, nil
 }
 
-// UpdateMaintenance updates a maintenance window
-func (s *MaintenanceService) UpdateMaintenance(ctx context.Context, id int64, title, description string, startTime, endTime time.Time, systemIDs []int64) (*domain.Maintenance, error) {
+// UpdateMaintenance updates a maintenance window. Unless allowOverlap is set,
+// changes that make it overlap another window on a shared system are
+// rejected with a *MaintenanceConflictError.
+func (s *MaintenanceService) UpdateMaintenance(ctx context.Context, id int64, title, description string, startTime, endTime time.Time, systemIDs []int64, recurrence domain.Recurrence, timezone string, allowOverlap bool) (*domain.Maintenance, error) {
 	m, err := s.maintenanceRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get maintenance: %w", err)
@@ -84,11 +299,29 @@ func (s *MaintenanceService) UpdateMaintenance(ctx context.Context, id int64, ti
 		return nil, fmt.Errorf("maintenance not found: %d", id)
 	}
 
+	previousStart := m.StartTime
 	if err := m.Update(title, description, startTime, endTime); err != nil {
 		return nil, fmt.Errorf("invalid update data: %w", err)
 	}
+	// A window moved to a later start is reminded about again
+	if !m.StartTime.Equal(previousStart) && s.clock.Now().Before(m.StartTime) {
+		m.LastReminder = domain.ReminderNone
+	}
+	if err := m.SetRecurrence(recurrence); err != nil {
+		return nil, fmt.Errorf("invalid update data: %w", err)
+	}
+	if err := m.SetTimezone(timezone); err != nil {
+		return nil, fmt.Errorf("invalid update data: %w", err)
+	}
 
 	m.SetSystemIDs(systemIDs)
+	m.RefreshStatusAt(s.clock.Now())
+
+	if !allowOverlap {
+		if err := s.checkConflicts(ctx, m); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.maintenanceRepo.Update(ctx, m); err != nil {
 		return nil, fmt.Errorf("failed to update maintenance: %w", err)
@@ -97,6 +330,29 @@ func (s *MaintenanceService) UpdateMaintenance(ctx context.Context, id int64, ti
 	return m, nil
 }
 
+// checkConflicts returns a *MaintenanceConflictError listing the other
+// windows that cover one of m's systems while m is running
+func (s *MaintenanceService) checkConflicts(ctx context.Context, m *domain.Maintenance) error {
+	candidates, err := s.maintenanceRepo.GetByTimeRange(ctx, m.StartTime, m.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check maintenance conflicts: %w", err)
+	}
+
+	var conflicts []*domain.Maintenance
+	for _, other := range candidates {
+		if other.ID == m.ID && m.ID != 0 {
+			continue
+		}
+		if m.ConflictsWith(other) {
+			conflicts = append(conflicts, other)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &MaintenanceConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
 // CancelMaintenance cancels a maintenance window
 func (s *MaintenanceService) CancelMaintenance(ctx context.Context, id int64) (*domain.Maintenance, error) {
 	m, err := s.maintenanceRepo.GetByID(ctx, id)
@@ -124,9 +380,32 @@ func (s *MaintenanceService) DeleteMaintenance(ctx context.Context, id int64) er
 	return nil
 }
 
+// GetSystemsUnderMaintenance returns which of the given systems are inside an
+// active maintenance window. Cancelled windows never count.
+func (s *MaintenanceService) GetSystemsUnderMaintenance(ctx context.Context, systemIDs []int64) (map[int64]bool, error) {
+	actives, err := s.maintenanceRepo.GetActive(ctx, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check maintenance: %w", err)
+	}
+
+	under := make(map[int64]bool)
+	for _, m := range actives {
+		if m.Status == domain.MaintenanceCancelled {
+			continue
+		}
+		for _, id := range systemIDs {
+			if m.AffectsSystem(id) {
+				under[id] = true
+			}
+		}
+	}
+
+	return under, nil
+}
+
 // IsSystemUnderMaintenance checks if a system is currently under maintenance
 func (s *MaintenanceService) IsSystemUnderMaintenance(ctx context.Context, systemID int64) (bool, *domain.Maintenance, error) {
-	actives, err := s.maintenanceRepo.GetActive(ctx)
+	actives, err := s.maintenanceRepo.GetActive(ctx, s.clock.Now())
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to check maintenance: %w", err)
 	}
@@ -2,7 +2,13 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"status-incident/internal/domain"
+	"strings"
 	"testing"
 	"time"
 )
@@ -207,6 +213,9 @@ func TestMaintenanceService_UpdateMaintenance(t *testing.T) {
 		newStart,
 		newEnd,
 		[]int64{1, 2, 3},
+		domain.Recurrence{},
+		"",
+		false,
 	)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -235,6 +244,9 @@ func TestMaintenanceService_UpdateMaintenance_NotFound(t *testing.T) {
 		start,
 		end,
 		nil,
+		domain.Recurrence{},
+		"",
+		false,
 	)
 	if err == nil {
 		t.Error("expected error for non-existent maintenance")
@@ -329,3 +341,447 @@ func TestMaintenanceService_IsSystemUnderMaintenance(t *testing.T) {
 		t.Error("expected nil maintenance for system not under maintenance")
 	}
 }
+
+func TestMaintenanceService_NotifySubscribersToggle(t *testing.T) {
+	events := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		maintenance, _ := body["maintenance"].(map[string]interface{})
+		events <- fmt.Sprintf("%v:%v", body["event"], maintenance["title"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	webhook, _ := domain.NewWebhook("Subscribers", server.URL, domain.WebhookTypeGeneric)
+	webhook.SetEvents([]domain.WebhookEvent{domain.EventMaintenanceScheduled, domain.EventMaintenanceStarted})
+	webhookRepo.Create(ctx, webhook)
+
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+
+	if _, err := service.ScheduleMaintenance(ctx, "Quiet", "", start, end, []int64{1}, false); err != nil {
+		t.Fatalf("ScheduleMaintenance() error = %v", err)
+	}
+	announced, err := service.ScheduleMaintenance(ctx, "Announced", "", start, end, []int64{2}, true)
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance() error = %v", err)
+	}
+
+	select {
+	case got := <-events:
+		if got != "maintenance_scheduled:Announced" {
+			t.Errorf("unexpected notification %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a notification for the flagged window")
+	}
+
+	// Both windows start; only the flagged one is announced, and only once
+	for _, m := range maintenanceRepo.Maintenances {
+		m.StartTime = time.Now().Add(-time.Minute)
+	}
+	for i := 0; i < 2; i++ {
+		started, err := service.NotifyStartedMaintenances(ctx)
+		if err != nil {
+			t.Fatalf("NotifyStartedMaintenances() error = %v", err)
+		}
+		if i == 0 && (len(started) != 1 || started[0].ID != announced.ID) {
+			t.Errorf("expected only the flagged window to start, got %d", len(started))
+		}
+		if i == 1 && len(started) != 0 {
+			t.Errorf("expected start to be announced once, got %d", len(started))
+		}
+	}
+
+	select {
+	case got := <-events:
+		if got != "maintenance_started:Announced" {
+			t.Errorf("unexpected notification %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a start notification for the flagged window")
+	}
+
+	select {
+	case got := <-events:
+		t.Errorf("unexpected extra notification %q", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestMaintenanceService_CreateNextOccurrences_Weekly(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+
+	// Last week's window has ended
+	start := time.Now().Add(-7*24*time.Hour + time.Hour)
+	m, err := service.ScheduleRecurringMaintenance(ctx, "DB maintenance", "", start, start.Add(2*time.Hour), []int64{1}, false,
+		domain.Recurrence{Type: domain.RecurrenceWeekly}, "", false)
+	if err != nil {
+		t.Fatalf("ScheduleRecurringMaintenance() error = %v", err)
+	}
+	m.EndTime = start.Add(30 * time.Minute)
+
+	created, err := service.CreateNextOccurrences(ctx)
+	if err != nil {
+		t.Fatalf("CreateNextOccurrences() error = %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected 1 created occurrence, got %d", len(created))
+	}
+
+	next := created[0]
+	if next.ID == 0 || !next.StartTime.Equal(start.AddDate(0, 0, 7)) {
+		t.Errorf("expected saved occurrence a week later at %v, got id %d at %v", start.AddDate(0, 0, 7), next.ID, next.StartTime)
+	}
+	if next.Status != domain.MaintenanceScheduled || next.Recurrence.Type != domain.RecurrenceWeekly {
+		t.Errorf("expected scheduled weekly occurrence, got %s %+v", next.Status, next.Recurrence)
+	}
+	if !maintenanceRepo.Maintenances[m.ID].NextCreated {
+		t.Error("expected ended window to be marked as having its next occurrence")
+	}
+
+	// Running again must not duplicate the occurrence
+	created, _ = service.CreateNextOccurrences(ctx)
+	if len(created) != 0 || len(maintenanceRepo.Maintenances) != 2 {
+		t.Errorf("expected no further occurrences, got %d created and %d stored", len(created), len(maintenanceRepo.Maintenances))
+	}
+}
+
+func TestMaintenanceService_CreateNextOccurrences_StopsAfterEndDate(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+
+	start := time.Now().Add(-3 * time.Hour)
+	until := time.Now().Add(24 * time.Hour)
+	m, err := service.ScheduleRecurringMaintenance(ctx, "DB maintenance", "", start, start.Add(time.Hour), nil, false,
+		domain.Recurrence{Type: domain.RecurrenceWeekly, Until: &until}, "", false)
+	if err != nil {
+		t.Fatalf("ScheduleRecurringMaintenance() error = %v", err)
+	}
+
+	created, err := service.CreateNextOccurrences(ctx)
+	if err != nil {
+		t.Fatalf("CreateNextOccurrences() error = %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("expected no occurrence after the end date, got %d", len(created))
+	}
+	if !maintenanceRepo.Maintenances[m.ID].NextCreated {
+		t.Error("expected finished series not to be checked again")
+	}
+}
+
+func TestMaintenanceService_GetUpcomingMaintenances_Recurring(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+
+	// Daily window in progress, plus a one-off window in two days
+	start := time.Now().Add(-30 * time.Minute)
+	if _, err := service.ScheduleRecurringMaintenance(ctx, "Daily backup", "", start, start.Add(time.Hour), nil, false,
+		domain.Recurrence{Type: domain.RecurrenceDaily}, "", false); err != nil {
+		t.Fatalf("ScheduleRecurringMaintenance() error = %v", err)
+	}
+	later := time.Now().Add(48 * time.Hour)
+	service.CreateMaintenance(ctx, "Upgrade", "", later, later.Add(time.Hour), nil)
+
+	upcoming, err := service.GetUpcomingMaintenances(ctx)
+	if err != nil {
+		t.Fatalf("GetUpcomingMaintenances() error = %v", err)
+	}
+	if len(upcoming) != 2 {
+		t.Fatalf("expected 2 upcoming maintenances, got %d", len(upcoming))
+	}
+	if upcoming[0].Title != "Daily backup" || upcoming[0].ID != 0 || !upcoming[0].StartTime.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("expected computed next backup first, got %q (id %d) at %v", upcoming[0].Title, upcoming[0].ID, upcoming[0].StartTime)
+	}
+	if upcoming[1].Title != "Upgrade" {
+		t.Errorf("expected one-off upgrade second, got %q", upcoming[1].Title)
+	}
+}
+
+func TestMaintenanceService_Timezone(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+
+	if _, err := service.ScheduleRecurringMaintenance(ctx, "Bad", "", start, end, nil, false, domain.Recurrence{}, "Moon/Tranquility", false); !errors.Is(err, domain.ErrInvalidTimezone) {
+		t.Errorf("expected ErrInvalidTimezone, got %v", err)
+	}
+	if len(maintenanceRepo.Maintenances) != 0 {
+		t.Error("expected invalid maintenance not to be stored")
+	}
+
+	m, err := service.ScheduleRecurringMaintenance(ctx, "Good", "", start, end, nil, false, domain.Recurrence{}, "America/New_York", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Timezone != "America/New_York" {
+		t.Errorf("expected America/New_York, got %q", m.Timezone)
+	}
+
+	if _, err := service.UpdateMaintenance(ctx, m.ID, "Good", "", start, end, nil, domain.Recurrence{}, "GMT+25", false); !errors.Is(err, domain.ErrInvalidTimezone) {
+		t.Errorf("expected ErrInvalidTimezone on update, got %v", err)
+	}
+}
+
+func TestMaintenanceService_ActiveExactlyAtStart(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+
+	start := time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Minute)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+
+	m, err := service.ScheduleMaintenance(ctx, "DB upgrade", "", start, start.Add(time.Hour), []int64{1}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Status != domain.MaintenanceScheduled {
+		t.Fatalf("expected status scheduled before the start, got %q", m.Status)
+	}
+
+	upcoming, _ := service.GetUpcomingMaintenances(ctx)
+	if len(upcoming) != 1 {
+		t.Errorf("expected the window to be upcoming, got %d", len(upcoming))
+	}
+	if started, _ := service.NotifyStartedMaintenances(ctx); len(started) != 0 {
+		t.Errorf("expected no start announcement before the start, got %d", len(started))
+	}
+
+	now = start
+	active, _ := service.GetActiveMaintenances(ctx)
+	if len(active) != 1 || active[0].Status != domain.MaintenanceInProgress {
+		t.Fatalf("expected the window to be active exactly at its start, got %v", active)
+	}
+	under, _ := service.GetSystemsUnderMaintenance(ctx, []int64{1, 2})
+	if !under[1] || under[2] {
+		t.Errorf("expected only system 1 under maintenance, got %v", under)
+	}
+	upcoming, _ = service.GetUpcomingMaintenances(ctx)
+	if len(upcoming) != 0 {
+		t.Errorf("expected no upcoming windows once started, got %d", len(upcoming))
+	}
+	started, _ := service.NotifyStartedMaintenances(ctx)
+	if len(started) != 1 || started[0].ID != m.ID {
+		t.Errorf("expected the start to be announced, got %v", started)
+	}
+}
+
+func TestMaintenanceService_GetSystemsUnderMaintenance(t *testing.T) {
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+	ctx := context.Background()
+
+	start := time.Now().Add(-1 * time.Hour)
+	end := time.Now().Add(1 * time.Hour)
+
+	if _, err := service.CreateMaintenance(ctx, "Scoped", "", start, end, []int64{1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancelled, err := service.CreateMaintenance(ctx, "Cancelled", "", start, end, []int64{2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.CancelMaintenance(ctx, cancelled.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	under, err := service.GetSystemsUnderMaintenance(ctx, []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !under[1] {
+		t.Error("expected system 1 to be under maintenance")
+	}
+	if under[2] {
+		t.Error("expected cancelled window to be ignored for system 2")
+	}
+	if under[3] {
+		t.Error("expected system 3 not to be under maintenance")
+	}
+
+	// A window without systems affects all of them
+	if _, err := service.ScheduleRecurringMaintenance(ctx, "Everything", "", start, end, nil, false, domain.Recurrence{}, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	under, err = service.GetSystemsUnderMaintenance(ctx, []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(under) != 3 {
+		t.Errorf("expected all 3 systems under maintenance, got %v", under)
+	}
+}
+
+func TestMaintenanceService_Conflicts(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	end := start.Add(2 * time.Hour)
+
+	tests := []struct {
+		name         string
+		start, end   time.Time
+		systemIDs    []int64
+		wantConflict bool
+	}{
+		{"overlapping on shared system", start.Add(time.Hour), end.Add(time.Hour), []int64{2, 3}, true},
+		{"inside the window", start.Add(30 * time.Minute), start.Add(time.Hour), []int64{1}, true},
+		{"all systems", start, end, nil, true},
+		{"adjacent after", end, end.Add(time.Hour), []int64{1}, false},
+		{"adjacent before", start.Add(-time.Hour), start, []int64{1}, false},
+		{"disjoint systems", start, end, []int64{4}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMaintenanceService(NewMockMaintenanceRepository())
+			existing, err := service.CreateMaintenance(ctx, "DB upgrade", "", start, end, []int64{1, 2})
+			if err != nil {
+				t.Fatalf("CreateMaintenance() error = %v", err)
+			}
+
+			_, err = service.CreateMaintenance(ctx, "Network work", "", tt.start, tt.end, tt.systemIDs)
+			var conflict *MaintenanceConflictError
+			if got := errors.As(err, &conflict); got != tt.wantConflict {
+				t.Fatalf("expected conflict = %v, got error %v", tt.wantConflict, err)
+			}
+			if tt.wantConflict {
+				if len(conflict.Conflicts) != 1 || conflict.Conflicts[0].ID != existing.ID {
+					t.Errorf("expected conflict with window %d, got %v", existing.ID, conflict.Conflicts)
+				}
+				if !strings.Contains(err.Error(), `"DB upgrade"`) {
+					t.Errorf("expected error to name the conflicting window, got %q", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceService_Conflicts_AllowOverlapAndCancelled(t *testing.T) {
+	ctx := context.Background()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+
+	first, err := service.CreateMaintenance(ctx, "First", "", start, end, []int64{1})
+	if err != nil {
+		t.Fatalf("CreateMaintenance() error = %v", err)
+	}
+
+	second, err := service.ScheduleRecurringMaintenance(ctx, "Second", "", start, end, []int64{1}, false, domain.Recurrence{}, "", true)
+	if err != nil {
+		t.Fatalf("expected allow_overlap to skip the check, got %v", err)
+	}
+
+	// Updating a window does not conflict with itself, only with others
+	if _, err := service.UpdateMaintenance(ctx, second.ID, "Second", "", start, end, []int64{2}, domain.Recurrence{}, "", false); err != nil {
+		t.Fatalf("UpdateMaintenance() error = %v", err)
+	}
+	_, err = service.UpdateMaintenance(ctx, second.ID, "Second", "", start, end, []int64{1}, domain.Recurrence{}, "", false)
+	var conflict *MaintenanceConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected conflict on update, got %v", err)
+	}
+
+	// Cancelled windows free their slot
+	if _, err := service.CancelMaintenance(ctx, first.ID); err != nil {
+		t.Fatalf("CancelMaintenance() error = %v", err)
+	}
+	if _, err := service.UpdateMaintenance(ctx, second.ID, "Second", "", start, end, []int64{1}, domain.Recurrence{}, "", false); err != nil {
+		t.Errorf("expected cancelled window to be ignored, got %v", err)
+	}
+	if _, err := service.CreateMaintenance(ctx, "Third", "", start, end, []int64{3}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMaintenanceService_SendReminders(t *testing.T) {
+	events := make(chan string, 8)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		events <- fmt.Sprintf("%v:%v", body["event"], body["reminder"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	webhook, _ := domain.NewWebhook("Reminders", server.URL, domain.WebhookTypeGeneric)
+	webhook.SetEvents([]domain.WebhookEvent{domain.EventMaintenanceReminder})
+	webhookRepo.Create(ctx, webhook)
+
+	maintenanceRepo := NewMockMaintenanceRepository()
+	service := NewMaintenanceService(maintenanceRepo)
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+	service.SetReminderLeadTime(30 * time.Minute)
+
+	start := time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+
+	if _, err := service.ScheduleMaintenance(ctx, "DB upgrade", "", start, start.Add(time.Hour), []int64{1}, false); err != nil {
+		t.Fatalf("ScheduleMaintenance() error = %v", err)
+	}
+
+	steps := []struct {
+		now  time.Time
+		want domain.MaintenanceReminder
+	}{
+		{start.Add(-time.Hour), domain.ReminderNone},
+		{start.Add(-20 * time.Minute), domain.ReminderUpcoming},
+		{start.Add(-10 * time.Minute), domain.ReminderNone},
+		{start, domain.ReminderStarted},
+		{start.Add(30 * time.Minute), domain.ReminderNone},
+		{start.Add(61 * time.Minute), domain.ReminderEnded},
+		{start.Add(2 * time.Hour), domain.ReminderNone},
+	}
+	for _, step := range steps {
+		now = step.now
+		reminded, err := service.SendReminders(ctx)
+		if err != nil {
+			t.Fatalf("SendReminders() error = %v", err)
+		}
+		if step.want == domain.ReminderNone {
+			if len(reminded) != 0 {
+				t.Errorf("at %v: expected no reminder, got %d", step.now, len(reminded))
+			}
+			continue
+		}
+		if len(reminded) != 1 || reminded[0].LastReminder != step.want {
+			t.Fatalf("at %v: expected %s reminder, got %v", step.now, step.want, reminded)
+		}
+
+		select {
+		case got := <-events:
+			if want := "maintenance_reminder:" + string(step.want); got != want {
+				t.Errorf("unexpected notification %q, want %q", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected a %s notification", step.want)
+		}
+	}
+
+	select {
+	case got := <-events:
+		t.Errorf("unexpected extra notification %q", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"sort"
 	"status-incident/internal/domain"
+	"sync"
 	"time"
 )
 
@@ -38,6 +40,15 @@ func (m *MockSystemRepository) GetByID(ctx context.Context, id int64) (*domain.S
 	return m.Systems[id], nil
 }
 
+func (m *MockSystemRepository) GetBySlug(ctx context.Context, slug string) (*domain.System, error) {
+	for _, s := range m.Systems {
+		if slug != "" && s.Slug == slug {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *MockSystemRepository) GetAll(ctx context.Context) ([]*domain.System, error) {
 	if m.GetAllFunc != nil {
 		return m.GetAllFunc(ctx)
@@ -212,6 +223,21 @@ func (m *MockStatusLogRepository) GetAll(ctx context.Context, limit int) ([]*dom
 	return m.Logs, nil
 }
 
+func (m *MockStatusLogRepository) GetPage(ctx context.Context, limit, offset int) ([]*domain.StatusLog, error) {
+	if offset >= len(m.Logs) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(m.Logs) {
+		end = len(m.Logs)
+	}
+	return m.Logs[offset:end], nil
+}
+
+func (m *MockStatusLogRepository) Count(ctx context.Context) (int, error) {
+	return len(m.Logs), nil
+}
+
 func (m *MockStatusLogRepository) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.StatusLog, error) {
 	var result []*domain.StatusLog
 	for _, log := range m.Logs {
@@ -242,6 +268,17 @@ func (m *MockStatusLogRepository) GetDependencyLogsByTimeRange(ctx context.Conte
 	return result, nil
 }
 
+func (m *MockStatusLogRepository) Cleanup(ctx context.Context, olderThan time.Time) error {
+	kept := m.Logs[:0]
+	for _, log := range m.Logs {
+		if !log.CreatedAt.Before(olderThan) {
+			kept = append(kept, log)
+		}
+	}
+	m.Logs = kept
+	return nil
+}
+
 // MockAnalyticsRepository is a mock implementation of domain.AnalyticsRepository
 type MockAnalyticsRepository struct {
 	GetUptimeBySystemIDFunc     func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error)
@@ -249,6 +286,7 @@ type MockAnalyticsRepository struct {
 	GetOverallAnalyticsFunc     func(ctx context.Context, start, end time.Time) (*domain.Analytics, error)
 	GetIncidentsBySystemIDFunc  func(ctx context.Context, systemID int64, start, end time.Time) ([]domain.IncidentPeriod, error)
 	GetIncidentsByDependencyIDFunc func(ctx context.Context, dependencyID int64, start, end time.Time) ([]domain.IncidentPeriod, error)
+	RollupDailyUptimeFunc       func(ctx context.Context, day time.Time) error
 }
 
 func NewMockAnalyticsRepository() *MockAnalyticsRepository {
@@ -301,6 +339,13 @@ func (m *MockAnalyticsRepository) GetIncidentsByDependencyID(ctx context.Context
 	return []domain.IncidentPeriod{}, nil
 }
 
+func (m *MockAnalyticsRepository) RollupDailyUptime(ctx context.Context, day time.Time) error {
+	if m.RollupDailyUptimeFunc != nil {
+		return m.RollupDailyUptimeFunc(ctx, day)
+	}
+	return nil
+}
+
 // MockLatencyRepository is a mock implementation of domain.LatencyRepository
 type MockLatencyRepository struct {
 	Records      []*domain.LatencyRecord
@@ -381,12 +426,28 @@ type MockIncidentRepository struct {
 	CreateFunc   func(ctx context.Context, i *domain.Incident) error
 	GetByIDFunc  func(ctx context.Context, id int64) (*domain.Incident, error)
 	GetRecentFunc func(ctx context.Context, days int) ([]*domain.Incident, error)
+
+	IdempotencyKeys map[string]mockIdempotencyKey
+	// mu guards Incidents and IdempotencyKeys for concurrent keyed creates
+	mu sync.Mutex
+}
+
+// mockIdempotencyKey is a reserved key; incidentID stays 0 until the
+// incident is recorded
+type mockIdempotencyKey struct {
+	incidentID int64
+	expiresAt  time.Time
+}
+
+func mockIdempotencyMapKey(scope, key string) string {
+	return scope + "\x00" + key
 }
 
 func NewMockIncidentRepository() *MockIncidentRepository {
 	return &MockIncidentRepository{
-		Incidents: make(map[int64]*domain.Incident),
-		Updates:   make([]*domain.IncidentUpdate, 0),
+		Incidents:       make(map[int64]*domain.Incident),
+		Updates:         make([]*domain.IncidentUpdate, 0),
+		IdempotencyKeys: make(map[string]mockIdempotencyKey),
 	}
 }
 
@@ -394,6 +455,8 @@ func (m *MockIncidentRepository) Create(ctx context.Context, i *domain.Incident)
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, i)
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	i.ID = int64(len(m.Incidents) + 1)
 	m.Incidents[i.ID] = i
 	return nil
@@ -414,6 +477,33 @@ func (m *MockIncidentRepository) GetAll(ctx context.Context, limit int) ([]*doma
 	return result, nil
 }
 
+func (m *MockIncidentRepository) GetPage(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	// Newest first, like the SQLite repository's default order
+	var result []*domain.Incident
+	for id := int64(len(m.Incidents)); id > 0; id-- {
+		if i, ok := m.Incidents[id]; ok && filter.Matches(i) {
+			result = append(result, i)
+		}
+	}
+	if offset >= len(result) {
+		return nil, nil
+	}
+	if offset+limit < len(result) {
+		return result[offset : offset+limit], nil
+	}
+	return result[offset:], nil
+}
+
+func (m *MockIncidentRepository) Count(ctx context.Context, filter domain.IncidentFilter) (int, error) {
+	count := 0
+	for _, i := range m.Incidents {
+		if filter.Matches(i) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *MockIncidentRepository) GetActive(ctx context.Context) ([]*domain.Incident, error) {
 	var result []*domain.Incident
 	for _, i := range m.Incidents {
@@ -424,6 +514,17 @@ func (m *MockIncidentRepository) GetActive(ctx context.Context) ([]*domain.Incid
 	return result, nil
 }
 
+func (m *MockIncidentRepository) GetScheduled(ctx context.Context) ([]*domain.Incident, error) {
+	var result []*domain.Incident
+	for _, i := range m.Incidents {
+		if i.IsScheduled() {
+			result = append(result, i)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].ScheduledFor.Before(*result[b].ScheduledFor) })
+	return result, nil
+}
+
 func (m *MockIncidentRepository) GetRecent(ctx context.Context, days int) ([]*domain.Incident, error) {
 	if m.GetRecentFunc != nil {
 		return m.GetRecentFunc(ctx, days)
@@ -461,6 +562,49 @@ func (m *MockIncidentRepository) GetUpdates(ctx context.Context, incidentID int6
 	return result, nil
 }
 
+func (m *MockIncidentRepository) GetByIdempotencyKey(ctx context.Context, scope, key string, now time.Time) (*domain.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.IdempotencyKeys[mockIdempotencyMapKey(scope, key)]
+	if !ok || !entry.expiresAt.After(now) || entry.incidentID == 0 {
+		return nil, nil
+	}
+	return m.Incidents[entry.incidentID], nil
+}
+
+func (m *MockIncidentRepository) ReserveIdempotencyKey(ctx context.Context, scope, key string, now, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, entry := range m.IdempotencyKeys {
+		if !entry.expiresAt.After(now) {
+			delete(m.IdempotencyKeys, k)
+		}
+	}
+	mapKey := mockIdempotencyMapKey(scope, key)
+	if _, ok := m.IdempotencyKeys[mapKey]; ok {
+		return false, nil
+	}
+	m.IdempotencyKeys[mapKey] = mockIdempotencyKey{expiresAt: expiresAt}
+	return true, nil
+}
+
+func (m *MockIncidentRepository) SetIdempotencyKeyIncident(ctx context.Context, scope, key string, incidentID int64, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.IdempotencyKeys[mockIdempotencyMapKey(scope, key)] = mockIdempotencyKey{incidentID: incidentID, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *MockIncidentRepository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mapKey := mockIdempotencyMapKey(scope, key)
+	if m.IdempotencyKeys[mapKey].incidentID == 0 {
+		delete(m.IdempotencyKeys, mapKey)
+	}
+	return nil
+}
+
 // MockIncidentUpdateRepository is a mock implementation
 type MockIncidentUpdateRepository struct {
 	Updates []*domain.IncidentUpdate
@@ -527,20 +671,20 @@ func (m *MockMaintenanceRepository) GetByTimeRange(ctx context.Context, start, e
 	return result, nil
 }
 
-func (m *MockMaintenanceRepository) GetActive(ctx context.Context) ([]*domain.Maintenance, error) {
+func (m *MockMaintenanceRepository) GetActive(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
 	var result []*domain.Maintenance
 	for _, maint := range m.Maintenances {
-		if maint.IsActive() {
+		if maint.IsActiveAt(now) {
 			result = append(result, maint)
 		}
 	}
 	return result, nil
 }
 
-func (m *MockMaintenanceRepository) GetUpcoming(ctx context.Context) ([]*domain.Maintenance, error) {
+func (m *MockMaintenanceRepository) GetUpcoming(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
 	var result []*domain.Maintenance
 	for _, maint := range m.Maintenances {
-		if maint.IsUpcoming() {
+		if maint.IsUpcomingAt(now) {
 			result = append(result, maint)
 		}
 	}
@@ -567,9 +711,53 @@ func (m *MockMaintenanceRepository) GetBySystemID(ctx context.Context, systemID
 	return result, nil
 }
 
+// MockIncidentTemplateRepository is a mock implementation of domain.IncidentTemplateRepository
+type MockIncidentTemplateRepository struct {
+	Templates map[int64]*domain.IncidentTemplate
+	nextID    int64
+}
+
+func NewMockIncidentTemplateRepository() *MockIncidentTemplateRepository {
+	return &MockIncidentTemplateRepository{
+		Templates: make(map[int64]*domain.IncidentTemplate),
+	}
+}
+
+func (m *MockIncidentTemplateRepository) Create(ctx context.Context, t *domain.IncidentTemplate) error {
+	m.nextID++
+	t.ID = m.nextID
+	m.Templates[t.ID] = t
+	return nil
+}
+
+func (m *MockIncidentTemplateRepository) GetByID(ctx context.Context, id int64) (*domain.IncidentTemplate, error) {
+	return m.Templates[id], nil
+}
+
+func (m *MockIncidentTemplateRepository) GetAll(ctx context.Context) ([]*domain.IncidentTemplate, error) {
+	var result []*domain.IncidentTemplate
+	for id := int64(1); id <= m.nextID; id++ {
+		if t, ok := m.Templates[id]; ok {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockIncidentTemplateRepository) Update(ctx context.Context, t *domain.IncidentTemplate) error {
+	m.Templates[t.ID] = t
+	return nil
+}
+
+func (m *MockIncidentTemplateRepository) Delete(ctx context.Context, id int64) error {
+	delete(m.Templates, id)
+	return nil
+}
+
 // MockWebhookRepository is a mock implementation
 type MockWebhookRepository struct {
 	Webhooks map[int64]*domain.Webhook
+	mu       sync.Mutex // guards delivery fields written from send goroutines
 }
 
 func NewMockWebhookRepository() *MockWebhookRepository {
@@ -616,6 +804,163 @@ func (m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (m *MockWebhookRepository) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.Webhooks[d.WebhookID]
+	if !ok {
+		return 0, nil
+	}
+	at := d.CreatedAt
+	w.LastDeliveryAt = &at
+	w.LastDeliveryStatus = d.Status
+	w.LastDeliveryCode = d.StatusCode
+	if d.Succeeded() {
+		w.ConsecutiveFailures = 0
+	} else {
+		w.ConsecutiveFailures++
+	}
+	return w.ConsecutiveFailures, nil
+}
+
+// MockWebhookDeliveryRepository is a mock implementation of domain.WebhookDeliveryRepository
+type MockWebhookDeliveryRepository struct {
+	mu         sync.Mutex
+	Deliveries []*domain.WebhookDelivery
+}
+
+func NewMockWebhookDeliveryRepository() *MockWebhookDeliveryRepository {
+	return &MockWebhookDeliveryRepository{}
+}
+
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d.ID = int64(len(m.Deliveries) + 1)
+	m.Deliveries = append(m.Deliveries, d)
+	return nil
+}
+
+func (m *MockWebhookDeliveryRepository) GetByWebhook(ctx context.Context, webhookID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.WebhookDelivery
+	for i := len(m.Deliveries) - 1; i >= 0 && len(result) < limit; i-- {
+		if m.Deliveries[i].WebhookID == webhookID {
+			result = append(result, m.Deliveries[i])
+		}
+	}
+	return result, nil
+}
+
+// MockSubscriberRepository is a mock implementation of domain.SubscriberRepository
+type MockSubscriberRepository struct {
+	Subscribers map[int64]*domain.Subscriber
+	nextID      int64
+}
+
+func NewMockSubscriberRepository() *MockSubscriberRepository {
+	return &MockSubscriberRepository{
+		Subscribers: make(map[int64]*domain.Subscriber),
+	}
+}
+
+func (m *MockSubscriberRepository) Create(ctx context.Context, s *domain.Subscriber) error {
+	m.nextID++
+	s.ID = m.nextID
+	m.Subscribers[s.ID] = s
+	return nil
+}
+
+func (m *MockSubscriberRepository) GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error) {
+	for _, s := range m.Subscribers {
+		if s.Email == email {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockSubscriberRepository) GetByToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	for _, s := range m.Subscribers {
+		if s.Token == token {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockSubscriberRepository) GetConfirmed(ctx context.Context) ([]*domain.Subscriber, error) {
+	var result []*domain.Subscriber
+	for id := int64(1); id <= m.nextID; id++ {
+		if s, ok := m.Subscribers[id]; ok && s.Confirmed {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockSubscriberRepository) Update(ctx context.Context, s *domain.Subscriber) error {
+	m.Subscribers[s.ID] = s
+	return nil
+}
+
+func (m *MockSubscriberRepository) Delete(ctx context.Context, id int64) error {
+	delete(m.Subscribers, id)
+	return nil
+}
+
+// MockSystemDependencyRepository is a mock implementation of domain.SystemDependencyRepository
+type MockSystemDependencyRepository struct {
+	Edges  []*domain.SystemDependency
+	nextID int64
+}
+
+func NewMockSystemDependencyRepository() *MockSystemDependencyRepository {
+	return &MockSystemDependencyRepository{}
+}
+
+func (m *MockSystemDependencyRepository) Create(ctx context.Context, dep *domain.SystemDependency) error {
+	m.nextID++
+	dep.ID = m.nextID
+	m.Edges = append(m.Edges, dep)
+	return nil
+}
+
+func (m *MockSystemDependencyRepository) GetAll(ctx context.Context) ([]*domain.SystemDependency, error) {
+	return m.Edges, nil
+}
+
+func (m *MockSystemDependencyRepository) GetBySystemID(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	var result []*domain.SystemDependency
+	for _, e := range m.Edges {
+		if e.SystemID == systemID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockSystemDependencyRepository) GetDependents(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	var result []*domain.SystemDependency
+	for _, e := range m.Edges {
+		if e.DependsOnID == systemID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockSystemDependencyRepository) Delete(ctx context.Context, systemID, dependsOnID int64) error {
+	for i, e := range m.Edges {
+		if e.SystemID == systemID && e.DependsOnID == dependsOnID {
+			m.Edges = append(m.Edges[:i], m.Edges[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
 // MockHealthChecker is a mock implementation of domain.HealthChecker
 type MockHealthChecker struct {
 	CheckFunc           func(ctx context.Context, url string) (healthy bool, latencyMs int64, err error)
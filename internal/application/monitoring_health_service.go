@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MonitoringHealthService watches the monitoring pipeline itself. It alerts
+// once when heartbeat sweeps stop completing or repository errors spike, and
+// re-arms when the condition clears.
+type MonitoringHealthService struct {
+	notificationService *NotificationService
+	sweepInterval       time.Duration
+	staleSweeps         int // alert after this many missed sweep intervals
+	errorThreshold      int // alert at this many repository errors per window
+	clock               Clock
+
+	mu           sync.Mutex
+	started      time.Time
+	lastSweep    time.Time
+	errors       []time.Time
+	staleAlerted bool
+	errorAlerted bool
+}
+
+// NewMonitoringHealthService creates a new MonitoringHealthService.
+// A zero staleSweeps or errorThreshold disables that check.
+func NewMonitoringHealthService(sweepInterval time.Duration, staleSweeps, errorThreshold int) *MonitoringHealthService {
+	return &MonitoringHealthService{
+		sweepInterval:  sweepInterval,
+		staleSweeps:    staleSweeps,
+		errorThreshold: errorThreshold,
+		clock:          SystemClock{},
+		started:        time.Now(),
+	}
+}
+
+// SetNotificationService sets the notification service for alerts
+func (s *MonitoringHealthService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetClock sets the clock that ages sweeps and repository errors; the
+// worker counts as started at the clock's current time
+func (s *MonitoringHealthService) SetClock(clock Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clock
+	s.started = clock.Now()
+}
+
+// RecordSweep marks a completed heartbeat sweep
+func (s *MonitoringHealthService) RecordSweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSweep = s.clock.Now()
+}
+
+// RecordError counts a repository error towards the error spike check
+func (s *MonitoringHealthService) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, s.clock.Now())
+}
+
+// window is the period over which sweeps must complete and errors are counted
+func (s *MonitoringHealthService) window() time.Duration {
+	sweeps := s.staleSweeps
+	if sweeps <= 0 {
+		sweeps = 1
+	}
+	return time.Duration(sweeps) * s.sweepInterval
+}
+
+// Check evaluates the monitoring health and sends an alert for each newly
+// detected problem. It returns the alerts sent in this check.
+func (s *MonitoringHealthService) Check(ctx context.Context) []string {
+	s.mu.Lock()
+	now := s.clock.Now()
+	window := s.window()
+	var alerts []string
+
+	if s.staleSweeps > 0 {
+		last := s.lastSweep
+		if last.IsZero() {
+			last = s.started
+		}
+		if now.Sub(last) > window {
+			if !s.staleAlerted {
+				s.staleAlerted = true
+				if s.lastSweep.IsZero() {
+					alerts = append(alerts, fmt.Sprintf("Heartbeat worker has not completed a sweep since startup (%s ago)", now.Sub(last).Round(time.Second)))
+				} else {
+					alerts = append(alerts, fmt.Sprintf("Heartbeat worker has not completed a sweep for %s (expected every %s)", now.Sub(last).Round(time.Second), s.sweepInterval))
+				}
+			}
+		} else {
+			s.staleAlerted = false
+		}
+	}
+
+	if s.errorThreshold > 0 {
+		// Drop errors that fell out of the window
+		recent := s.errors[:0]
+		for _, t := range s.errors {
+			if now.Sub(t) <= window {
+				recent = append(recent, t)
+			}
+		}
+		s.errors = recent
+
+		if len(s.errors) >= s.errorThreshold {
+			if !s.errorAlerted {
+				s.errorAlerted = true
+				alerts = append(alerts, fmt.Sprintf("%d repository errors in the last %s", len(s.errors), window))
+			}
+		} else {
+			s.errorAlerted = false
+		}
+	}
+	s.mu.Unlock()
+
+	if s.notificationService != nil {
+		for _, alert := range alerts {
+			s.notificationService.NotifyMonitoringDegraded(ctx, alert)
+		}
+	}
+
+	return alerts
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func TestMonitoringHealthService_StalledWorkerAlertsOnce(t *testing.T) {
+	bodies := make(chan domain.MonitoringAlertPayload, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload domain.MonitoringAlertPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		bodies <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	webhookRepo := NewMockWebhookRepository()
+	webhook, _ := domain.NewWebhook("Ops", server.URL, domain.WebhookTypeGeneric)
+	webhook.SetEvents([]domain.WebhookEvent{domain.EventMonitoringDegraded})
+	webhookRepo.Create(ctx, webhook)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewMonitoringHealthService(time.Minute, 3, 0)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	service.SetNotificationService(NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository()))
+
+	service.RecordSweep()
+
+	// Within three intervals the worker is considered healthy
+	now = now.Add(2 * time.Minute)
+	if alerts := service.Check(ctx); len(alerts) != 0 {
+		t.Fatalf("expected no alerts while sweeps are recent, got %v", alerts)
+	}
+
+	// Stale last run: alert exactly once
+	now = now.Add(2 * time.Minute)
+	if alerts := service.Check(ctx); len(alerts) != 1 {
+		t.Fatalf("expected 1 alert for stalled worker, got %v", alerts)
+	}
+	now = now.Add(time.Minute)
+	if alerts := service.Check(ctx); len(alerts) != 0 {
+		t.Errorf("expected stalled worker to alert only once, got %v", alerts)
+	}
+
+	select {
+	case payload := <-bodies:
+		if payload.Event != domain.EventMonitoringDegraded {
+			t.Errorf("expected event %q, got %q", domain.EventMonitoringDegraded, payload.Event)
+		}
+		if !strings.Contains(payload.Message, "has not completed a sweep") {
+			t.Errorf("unexpected message %q", payload.Message)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for monitoring alert")
+	}
+
+	select {
+	case payload := <-bodies:
+		t.Errorf("unexpected second notification: %+v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A completed sweep re-arms the alert
+	service.RecordSweep()
+	if alerts := service.Check(ctx); len(alerts) != 0 {
+		t.Errorf("expected no alerts after a sweep, got %v", alerts)
+	}
+	now = now.Add(4 * time.Minute)
+	if alerts := service.Check(ctx); len(alerts) != 1 {
+		t.Errorf("expected alert after worker stalls again, got %v", alerts)
+	}
+}
+
+func TestMonitoringHealthService_ErrorSpike(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewMonitoringHealthService(time.Minute, 3, 3)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+	service.RecordSweep()
+
+	repoErr := errors.New("database is locked")
+	service.RecordError(repoErr)
+	service.RecordError(repoErr)
+	if alerts := service.Check(context.Background()); len(alerts) != 0 {
+		t.Fatalf("expected no alerts below threshold, got %v", alerts)
+	}
+
+	service.RecordError(repoErr)
+	alerts := service.Check(context.Background())
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "3 repository errors") {
+		t.Fatalf("expected error spike alert, got %v", alerts)
+	}
+
+	// Errors age out of the window
+	now = now.Add(4 * time.Minute)
+	service.RecordSweep()
+	if alerts := service.Check(context.Background()); len(alerts) != 0 {
+		t.Errorf("expected no alerts once errors aged out, got %v", alerts)
+	}
+}
+
+func TestHeartbeatService_RecordsSweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	monitor := NewMonitoringHealthService(time.Minute, 3, 0)
+	monitor.SetClock(ClockFunc(func() time.Time { return now }))
+
+	service := NewHeartbeatService(NewMockDependencyRepository(), NewMockStatusLogRepository(), &MockHealthChecker{})
+	service.SetMonitor(monitor)
+
+	now = now.Add(10 * time.Minute)
+	if err := service.CheckAllDependencies(context.Background()); err != nil {
+		t.Fatalf("CheckAllDependencies() error = %v", err)
+	}
+
+	if alerts := monitor.Check(context.Background()); len(alerts) != 0 {
+		t.Errorf("expected sweep to be recorded, got alerts %v", alerts)
+	}
+}
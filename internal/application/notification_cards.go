@@ -0,0 +1,305 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// Card colors (hex RGB) for incident and maintenance notifications
+const (
+	cardColorRed    = "ED4245"
+	cardColorOrange = "E67E22"
+	cardColorYellow = "FEE75C"
+	cardColorGreen  = "57F287"
+	cardColorBlue   = "3B82F6"
+)
+
+// eventCard is a chat message for incident and maintenance events. Text is
+// the full plain message (its first line is the title); Fields are rendered
+// as Slack attachment fields, Discord embed fields or Teams facts. Actions
+// are only rendered as Slack Block Kit buttons.
+type eventCard struct {
+	Text    string
+	Color   string
+	Fields  []cardField
+	Actions []cardAction
+}
+
+type cardField struct {
+	Name  string
+	Value string
+}
+
+// cardAction is a link button; ID and Value identify the action to Slack
+// apps with interactivity enabled
+type cardAction struct {
+	ID    string
+	Text  string
+	URL   string
+	Value string
+}
+
+func (c *eventCard) title() string {
+	title, _, _ := strings.Cut(c.Text, "\n")
+	return title
+}
+
+func (c *eventCard) body() string {
+	_, body, _ := strings.Cut(c.Text, "\n")
+	return body
+}
+
+// sendEventNotification sends a formatted card to chat webhooks and the
+// structured payload to generic webhooks
+func (s *NotificationService) sendEventNotification(webhook *domain.Webhook, event domain.WebhookEvent, card *eventCard, payload interface{}) {
+	var body []byte
+	var err error
+
+	switch webhook.Type {
+	case domain.WebhookTypeSlack:
+		if webhook.SlackBlocks {
+			body, err = s.formatSlackBlocksCard(card)
+		} else {
+			body, err = s.formatSlackCard(card)
+		}
+	case domain.WebhookTypeDiscord:
+		body, err = s.formatDiscordCard(card)
+	case domain.WebhookTypeTeams:
+		body, err = s.formatTeamsCard(card)
+	case domain.WebhookTypeTelegram:
+		body, err = s.formatTelegramCard(webhook.URL, card)
+	case domain.WebhookTypePagerDuty:
+		body, err = s.formatPagerDutyText(webhook.URL, card.title())
+	case domain.WebhookTypeEmail:
+		fields := make([]emailField, len(card.Fields))
+		for i, f := range card.Fields {
+			fields[i] = emailField(f)
+		}
+		fields = append(fields, emailField{Name: "Details", Value: card.body()})
+		text, htmlBody := formatEmailBodies(card.title(), card.Color, fields)
+		s.sendEmail(webhook, event, card.title(), text, htmlBody)
+		return
+	default:
+		body, err = formatGenericPayload(webhook, payload)
+	}
+
+	if err != nil {
+		s.logger.Error("failed to format webhook payload", append(webhookLogFields(webhook), "error", err)...)
+		return
+	}
+
+	s.postWebhook(webhook, event, body)
+}
+
+func (s *NotificationService) formatSlackCard(card *eventCard) ([]byte, error) {
+	fields := make([]map[string]interface{}, 0, len(card.Fields))
+	for _, f := range card.Fields {
+		fields = append(fields, map[string]interface{}{"title": f.Name, "value": f.Value, "short": true})
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"text": card.Text,
+		"attachments": []map[string]interface{}{
+			{
+				"color":  "#" + card.Color,
+				"fields": fields,
+			},
+		},
+	})
+}
+
+// formatSlackBlocksCard renders a card as Slack Block Kit blocks. Text stays
+// set as the fallback shown in notifications.
+func (s *NotificationService) formatSlackBlocksCard(card *eventCard) ([]byte, error) {
+	blocks := []map[string]interface{}{
+		{"type": "section", "text": slackMarkdown(card.title())},
+	}
+
+	if len(card.Fields) > 0 {
+		fields := make([]map[string]interface{}, 0, len(card.Fields))
+		for _, f := range card.Fields {
+			fields = append(fields, slackMarkdown(fmt.Sprintf("*%s*\n%s", f.Name, f.Value)))
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	if body := card.body(); body != "" {
+		blocks = append(blocks, map[string]interface{}{"type": "section", "text": slackMarkdown(body)})
+	}
+
+	if len(card.Actions) > 0 {
+		elements := make([]map[string]interface{}, 0, len(card.Actions))
+		for _, a := range card.Actions {
+			elements = append(elements, map[string]interface{}{
+				"type":      "button",
+				"text":      map[string]interface{}{"type": "plain_text", "text": a.Text},
+				"action_id": a.ID,
+				"url":       a.URL,
+				"value":     a.Value,
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "actions", "elements": elements})
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"text":   card.Text,
+		"blocks": blocks,
+	})
+}
+
+func slackMarkdown(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "mrkdwn", "text": text}
+}
+
+// statusChangeCard turns a status change into a card, for formats that
+// render every event as one
+func statusChangeCard(payload *domain.NotificationPayload) *eventCard {
+	entityName := ""
+	if payload.System != nil {
+		entityName = payload.System.Name
+	}
+	if payload.Dependency != nil {
+		if entityName != "" {
+			entityName += " / " + payload.Dependency.Name
+		} else {
+			entityName = payload.Dependency.Name
+		}
+	}
+
+	statusText := domain.StatusText(payload.NewStatus)
+	text := fmt.Sprintf("%s *%s* is now *%s*", domain.StatusEmoji(payload.NewStatus), entityName, statusText)
+	if payload.Message != "" {
+		text += "\n" + payload.Message
+	}
+
+	color := cardColorGreen
+	switch payload.NewStatus {
+	case domain.StatusYellow:
+		color = cardColorYellow
+	case domain.StatusPartial:
+		color = cardColorOrange
+	case domain.StatusRed:
+		color = cardColorRed
+	}
+
+	return &eventCard{
+		Text:  text,
+		Color: color,
+		Fields: []cardField{
+			{Name: "Status", Value: statusText},
+			{Name: "Source", Value: payload.Source},
+		},
+	}
+}
+
+// IncidentAcknowledgePath is the web UI page where a signed-in user confirms
+// acknowledging an incident. Chat buttons open it in a browser, so it must
+// be a GET route rather than the POST-only API endpoint
+func IncidentAcknowledgePath(id int64) string {
+	return fmt.Sprintf("/incidents/%d/acknowledge", id)
+}
+
+// incidentActions returns the acknowledge button for an incident that still
+// needs one, if a public URL is configured
+func (s *NotificationService) incidentActions(incident *domain.Incident) []cardAction {
+	if s.publicURL == "" || incident.IsAcknowledged() || !incident.IsActive() {
+		return nil
+	}
+	return []cardAction{{
+		ID:    "acknowledge_incident",
+		Text:  "Acknowledge",
+		URL:   s.publicURL + IncidentAcknowledgePath(incident.ID),
+		Value: strconv.FormatInt(incident.ID, 10),
+	}}
+}
+
+func (s *NotificationService) formatDiscordCard(card *eventCard) ([]byte, error) {
+	fields := make([]map[string]interface{}, 0, len(card.Fields))
+	for _, f := range card.Fields {
+		fields = append(fields, map[string]interface{}{"name": f.Name, "value": f.Value, "inline": true})
+	}
+
+	// Discord expects a decimal color
+	color, _ := strconv.ParseInt(card.Color, 16, 64)
+
+	return json.Marshal(map[string]interface{}{
+		"content": card.Text,
+		"embeds": []map[string]interface{}{
+			{
+				"color":  color,
+				"fields": fields,
+			},
+		},
+	})
+}
+
+func (s *NotificationService) formatTeamsCard(card *eventCard) ([]byte, error) {
+	facts := make([]map[string]interface{}, 0, len(card.Fields)+1)
+	for _, f := range card.Fields {
+		facts = append(facts, map[string]interface{}{"name": f.Name, "value": f.Value})
+	}
+	facts = append(facts, map[string]interface{}{"name": "Time", "value": time.Now().Format("2006-01-02 15:04:05")})
+
+	section := map[string]interface{}{
+		"activityTitle": card.title(),
+		"facts":         facts,
+		"markdown":      true,
+	}
+	if body := card.body(); body != "" {
+		section["text"] = strings.ReplaceAll(body, "\n", "<br>")
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"themeColor": card.Color,
+		"summary":    card.title(),
+		"text":       card.Text,
+		"sections":   []map[string]interface{}{section},
+	})
+}
+
+func (s *NotificationService) formatTelegramCard(webhookURL string, card *eventCard) ([]byte, error) {
+	var text strings.Builder
+	fmt.Fprintf(&text, "<b>%s</b>", html.EscapeString(card.title()))
+	for _, f := range card.Fields {
+		fmt.Fprintf(&text, "\n%s: %s", html.EscapeString(f.Name), html.EscapeString(f.Value))
+	}
+	if body := card.body(); body != "" {
+		text.WriteString("\n" + html.EscapeString(body))
+	}
+
+	telegramPayload := map[string]interface{}{
+		"text":       text.String(),
+		"parse_mode": "HTML",
+	}
+
+	// Extract chat_id from URL if present (format: token:chatid)
+	if !strings.Contains(webhookURL, "api.telegram.org") {
+		parts := strings.SplitN(webhookURL, ":", 2)
+		if len(parts) == 2 {
+			telegramPayload["chat_id"] = parts[1]
+		}
+	}
+
+	return json.Marshal(telegramPayload)
+}
+
+// incidentCardColor maps incident severity and status to a card color
+func incidentCardColor(incident *domain.Incident) string {
+	if incident.Status == domain.IncidentResolved {
+		return cardColorGreen
+	}
+	switch incident.Severity {
+	case domain.SeverityMinor:
+		return cardColorYellow
+	default:
+		return cardColorRed
+	}
+}
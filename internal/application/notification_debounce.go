@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// statusChangeBuffer coalesces status changes per system or dependency
+// during the debounce window
+type statusChangeBuffer struct {
+	mu      sync.Mutex
+	pending map[string]*pendingStatusChange
+}
+
+// pendingStatusChange is the coalesced change waiting to be sent
+type pendingStatusChange struct {
+	ctx   context.Context
+	log   *domain.StatusLog
+	timer *time.Timer
+}
+
+// SetDebounce coalesces status changes of the same system or dependency
+// that arrive within window into a single notification carrying the latest
+// state. 0 sends every change immediately.
+func (s *NotificationService) SetDebounce(window time.Duration) {
+	s.debounce = window
+}
+
+// statusChangeKey identifies the entity a status change belongs to
+func statusChangeKey(statusLog *domain.StatusLog) string {
+	if statusLog.DependencyID != nil {
+		return fmt.Sprintf("dependency/%d", *statusLog.DependencyID)
+	}
+	if statusLog.SystemID != nil {
+		return fmt.Sprintf("system/%d", *statusLog.SystemID)
+	}
+	return ""
+}
+
+// bufferStatusChange holds a status change until the entity's debounce
+// window closes. A later change replaces the pending one but keeps its old
+// status, so the notification spans the whole window.
+func (s *NotificationService) bufferStatusChange(ctx context.Context, statusLog *domain.StatusLog) {
+	key := statusChangeKey(statusLog)
+	coalesced := *statusLog
+
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+
+	if s.buffer.pending == nil {
+		s.buffer.pending = make(map[string]*pendingStatusChange)
+	}
+	if pending, ok := s.buffer.pending[key]; ok {
+		coalesced.OldStatus = pending.log.OldStatus
+		pending.log = &coalesced
+		return
+	}
+
+	// The caller's context may end before the window closes
+	pending := &pendingStatusChange{ctx: context.WithoutCancel(ctx), log: &coalesced}
+	pending.timer = time.AfterFunc(s.debounce, func() { s.flushStatusChange(key, nil) })
+	s.buffer.pending[key] = pending
+}
+
+// flushStatusChange sends the pending change for key, if it is still there
+func (s *NotificationService) flushStatusChange(key string, wg *sync.WaitGroup) {
+	s.buffer.mu.Lock()
+	pending, ok := s.buffer.pending[key]
+	delete(s.buffer.pending, key)
+	s.buffer.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	// A change that was undone within the window is not worth a message
+	if pending.log.OldStatus == pending.log.NewStatus {
+		return
+	}
+	s.sendStatusChange(pending.ctx, pending.log, wg)
+}
+
+// Flush sends all buffered status changes right away and waits until they
+// are delivered or ctx is done. Call it on shutdown so that changes inside
+// an open debounce window are not lost.
+func (s *NotificationService) Flush(ctx context.Context) {
+	s.buffer.mu.Lock()
+	keys := make([]string, 0, len(s.buffer.pending))
+	for key, pending := range s.buffer.pending {
+		pending.timer.Stop()
+		keys = append(keys, key)
+	}
+	s.buffer.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		s.flushStatusChange(key, &wg)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
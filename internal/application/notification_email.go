@@ -0,0 +1,171 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// MailSender delivers a composed message; it matches smtp.SendMail so tests
+// can capture messages without a real server
+type MailSender func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// SetMailSender replaces the SMTP client used for email webhooks
+func (s *NotificationService) SetMailSender(sender MailSender) {
+	s.sendMail = sender
+}
+
+// emailField is a labelled row in an email body
+type emailField struct {
+	Name  string
+	Value string
+}
+
+// sendEmail composes a plain-text (and optionally HTML) message and sends it
+// to the SMTP target encoded in the webhook URL
+func (s *NotificationService) sendEmail(webhook *domain.Webhook, event domain.WebhookEvent, subject, text, htmlBody string) {
+	config, err := domain.ParseEmailConfig(webhook.URL)
+	if err != nil {
+		s.logger.Error("invalid email webhook config", append(webhookLogFields(webhook), "error", err)...)
+		return
+	}
+
+	msg, err := composeEmail(config.From, config.To, subject, text, htmlBody)
+	if err != nil {
+		s.logger.Error("failed to compose webhook email", append(webhookLogFields(webhook), "error", err)...)
+		return
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	sendMail := s.sendMail
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+	err = sendMail(config.Addr(), auth, config.From, config.To, msg)
+	if err != nil {
+		s.logger.Error("webhook email delivery failed", append(webhookLogFields(webhook), "error", err)...)
+	}
+	s.recordDelivery(webhook, domain.NewWebhookDelivery(webhook.ID, event, 0, 1, err))
+}
+
+// composeEmail builds an RFC 5322 message. With an HTML body the message is
+// multipart/alternative so clients can pick either part.
+func composeEmail(from string, to []string, subject, text, htmlBody string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if htmlBody == "" {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+		buf.WriteString(strings.ReplaceAll(text, "\n", "\r\n"))
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", strings.ReplaceAll(text, "\n", "\r\n")},
+		{"text/html; charset=utf-8", htmlBody},
+	}
+	for _, p := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(p.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatEmailBodies renders the title and fields as plain text and HTML
+func formatEmailBodies(title, color string, fields []emailField) (string, string) {
+	var text, body strings.Builder
+
+	text.WriteString(title + "\n")
+	fmt.Fprintf(&body, `<h2 style="color:#%s">%s</h2><table>`, color, html.EscapeString(title))
+	for _, f := range fields {
+		if f.Value == "" {
+			continue
+		}
+		fmt.Fprintf(&text, "\n%s: %s", f.Name, f.Value)
+		fmt.Fprintf(&body, `<tr><th align="left">%s</th><td>%s</td></tr>`, html.EscapeString(f.Name), strings.ReplaceAll(html.EscapeString(f.Value), "\n", "<br>"))
+	}
+	body.WriteString("</table>")
+
+	return text.String(), body.String()
+}
+
+// formatEmailStatusChange returns subject, text and HTML for a status change
+func (s *NotificationService) formatEmailStatusChange(payload *domain.NotificationPayload) (string, string, string) {
+	entityName := ""
+	if payload.System != nil {
+		entityName = payload.System.Name
+	}
+	if payload.Dependency != nil {
+		if entityName != "" {
+			entityName += " / " + payload.Dependency.Name
+		} else {
+			entityName = payload.Dependency.Name
+		}
+	}
+
+	color := cardColorGreen
+	switch payload.NewStatus {
+	case domain.StatusYellow:
+		color = cardColorYellow
+	case domain.StatusPartial:
+		color = cardColorOrange
+	case domain.StatusRed:
+		color = cardColorRed
+	}
+
+	title := fmt.Sprintf("%s is now %s", entityName, domain.StatusText(payload.NewStatus))
+	text, htmlBody := formatEmailBodies(title, color, []emailField{
+		{"Previous status", domain.StatusText(payload.OldStatus)},
+		{"Status", domain.StatusText(payload.NewStatus)},
+		{"Source", payload.Source},
+		{"Message", payload.Message},
+		{"Time", payload.Timestamp.Format("2006-01-02 15:04:05 MST")},
+	})
+
+	return "[Status] " + title, text, htmlBody
+}
+
+// formatEmailSLABreach returns subject, text and HTML for an SLA breach
+func (s *NotificationService) formatEmailSLABreach(payload *domain.SLABreachPayload) (string, string, string) {
+	title := fmt.Sprintf("SLA Breach - %s", payload.System.Name)
+	text, htmlBody := formatEmailBodies(title, cardColorRed, []emailField{
+		{"System", payload.System.Name},
+		{"Period", payload.Period},
+		{"Target", fmt.Sprintf("%.2f%%", payload.SLATarget)},
+		{"Actual", fmt.Sprintf("%.2f%%", payload.ActualValue)},
+		{"Message", payload.Message},
+	})
+
+	return "[SLA] " + title, text, htmlBody
+}
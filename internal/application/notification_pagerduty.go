@@ -0,0 +1,127 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutySource identifies this service in PagerDuty events that are not
+// tied to a single system
+const pagerDutySource = "status-incident"
+
+// pagerDutySeverity maps a status to a PagerDuty event severity
+func pagerDutySeverity(status domain.Status) string {
+	switch status {
+	case domain.StatusRed:
+		return "critical"
+	case domain.StatusPartial:
+		return "error"
+	case domain.StatusYellow:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// pagerDutyDedupKey returns a stable key per system or dependency so that a
+// recovery resolves the alert opened by the outage
+func pagerDutyDedupKey(payload *domain.NotificationPayload) string {
+	if payload.Dependency != nil {
+		return fmt.Sprintf("%s/dependency/%d", pagerDutySource, payload.Dependency.ID)
+	}
+	if payload.System != nil {
+		return fmt.Sprintf("%s/system/%d", pagerDutySource, payload.System.ID)
+	}
+	return pagerDutySource
+}
+
+// formatPagerDutyPayload renders a status change as an Events API v2 event.
+// Red, partial and yellow trigger an alert; green resolves it.
+func (s *NotificationService) formatPagerDutyPayload(routingKey string, payload *domain.NotificationPayload) ([]byte, error) {
+	dedupKey := pagerDutyDedupKey(payload)
+
+	if payload.NewStatus == domain.StatusGreen {
+		return json.Marshal(map[string]interface{}{
+			"routing_key":  routingKey,
+			"event_action": "resolve",
+			"dedup_key":    dedupKey,
+		})
+	}
+
+	entityName := pagerDutySource
+	if payload.System != nil {
+		entityName = payload.System.Name
+	}
+	if payload.Dependency != nil {
+		if payload.System != nil {
+			entityName += " / " + payload.Dependency.Name
+		} else {
+			entityName = payload.Dependency.Name
+		}
+	}
+
+	summary := fmt.Sprintf("%s is now %s", entityName, domain.StatusText(payload.NewStatus))
+	if payload.Message != "" {
+		summary += ": " + payload.Message
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]interface{}{
+			"summary":   summary,
+			"severity":  pagerDutySeverity(payload.NewStatus),
+			"source":    entityName,
+			"timestamp": payload.Timestamp.Format(time.RFC3339),
+			"custom_details": map[string]interface{}{
+				"old_status": payload.OldStatus,
+				"new_status": payload.NewStatus,
+				"source":     payload.Source,
+			},
+		},
+	})
+}
+
+// formatPagerDutySLABreach renders an SLA breach as an Events API v2 trigger.
+// Repeated breaches for the same system and period share one alert.
+func (s *NotificationService) formatPagerDutySLABreach(routingKey string, payload *domain.SLABreachPayload) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    fmt.Sprintf("%s/sla/%d/%s", pagerDutySource, payload.System.ID, payload.Period),
+		"payload": map[string]interface{}{
+			"summary":   fmt.Sprintf("SLA Breach - %s: %s", payload.System.Name, payload.Message),
+			"severity":  "error",
+			"source":    payload.System.Name,
+			"timestamp": payload.Timestamp.Format(time.RFC3339),
+			"custom_details": map[string]interface{}{
+				"period":       payload.Period,
+				"sla_target":   payload.SLATarget,
+				"actual_value": payload.ActualValue,
+				"breach_type":  payload.BreachType,
+			},
+		},
+	})
+}
+
+// formatPagerDutyText renders other events (incidents, maintenance, alerts)
+// as an informational trigger
+func (s *NotificationService) formatPagerDutyText(routingKey, summary string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":   summary,
+			"severity":  "info",
+			"source":    pagerDutySource,
+			"timestamp": time.Now().Format(time.RFC3339),
+		},
+	})
+}
@@ -3,11 +3,18 @@ package application
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/smtp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"status-incident/internal/domain"
@@ -19,8 +26,39 @@ type NotificationService struct {
 	systemRepo  domain.SystemRepository
 	depRepo     domain.DependencyRepository
 	httpClient  *http.Client
+	// pagerDutyURL overrides the Events API endpoint (used in tests)
+	pagerDutyURL string
+	sendMail     MailSender
+	// maxRetries is how many times a failed delivery is retried after the
+	// first attempt; retryBaseDelay is the first backoff step
+	maxRetries     int
+	retryBaseDelay time.Duration
+	logger         Logger
+	// deliveryRepo keeps the per-webhook delivery log (nil disables it)
+	deliveryRepo domain.WebhookDeliveryRepository
+	// autoDisableAfter disables a webhook after this many consecutive
+	// failed deliveries (0 never disables)
+	autoDisableAfter int
+	// debounce coalesces rapid status changes per entity (0 disables)
+	debounce time.Duration
+	buffer   statusChangeBuffer
+	// maintenanceService mutes status changes of systems in an active
+	// maintenance window (nil never mutes)
+	maintenanceService *MaintenanceService
+	// sends tracks background deliveries for Shutdown
+	sends sendTracker
+	// publicURL is linked from Block Kit action buttons (empty omits them)
+	publicURL string
 }
 
+// Webhook delivery retry defaults
+const (
+	DefaultWebhookRetries    = 3
+	DefaultWebhookRetryDelay = time.Second
+	// maxWebhookRetryDelay caps backoff and Retry-After waits
+	maxWebhookRetryDelay = 30 * time.Second
+)
+
 // NewNotificationService creates a new NotificationService
 func NewNotificationService(
 	webhookRepo domain.WebhookRepository,
@@ -34,14 +72,68 @@ func NewNotificationService(
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		sendMail:       smtp.SendMail,
+		maxRetries:     DefaultWebhookRetries,
+		retryBaseDelay: DefaultWebhookRetryDelay,
+		logger:         slog.Default(),
 	}
 }
 
-// NotifyStatusChange sends notifications for a status change
+// SetLogger replaces the logger used for delivery failures
+func (s *NotificationService) SetLogger(logger Logger) {
+	s.logger = logger
+}
+
+// SetRetryPolicy configures how failed webhook deliveries are retried.
+// maxRetries of 0 disables retries.
+func (s *NotificationService) SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	s.maxRetries = maxRetries
+	s.retryBaseDelay = baseDelay
+}
+
+// SetDeliveryRepository enables the per-webhook delivery log
+func (s *NotificationService) SetDeliveryRepository(repo domain.WebhookDeliveryRepository) {
+	s.deliveryRepo = repo
+}
+
+// SetAutoDisableThreshold disables webhooks after n consecutive failed
+// deliveries. 0 keeps failing webhooks enabled.
+func (s *NotificationService) SetAutoDisableThreshold(n int) {
+	s.autoDisableAfter = n
+}
+
+// SetPublicURL sets the base URL that Slack Block Kit buttons link back to
+func (s *NotificationService) SetPublicURL(publicURL string) {
+	s.publicURL = strings.TrimRight(publicURL, "/")
+}
+
+// SetMaintenanceService mutes status change notifications for systems, and
+// their dependencies, that are in an active maintenance window
+func (s *NotificationService) SetMaintenanceService(ms *MaintenanceService) {
+	s.maintenanceService = ms
+}
+
+// NotifyStatusChange sends notifications for a status change. Changes of
+// systems under maintenance are expected and not sent. With a debounce
+// window set, the change is held and coalesced with later changes of the
+// same system or dependency.
 func (s *NotificationService) NotifyStatusChange(ctx context.Context, statusLog *domain.StatusLog) {
+	if s.underMaintenance(ctx, statusLog) {
+		return
+	}
+	if s.debounce > 0 && statusChangeKey(statusLog) != "" {
+		s.bufferStatusChange(ctx, statusLog)
+		return
+	}
+	s.sendStatusChange(ctx, statusLog, nil)
+}
+
+// sendStatusChange delivers a status change to the matching webhooks. When
+// wg is set, it tracks the deliveries.
+func (s *NotificationService) sendStatusChange(ctx context.Context, statusLog *domain.StatusLog, wg *sync.WaitGroup) {
 	webhooks, err := s.webhookRepo.GetEnabled(ctx)
 	if err != nil {
-		logError("Failed to get webhooks: %v", err)
+		s.logger.Error("failed to get webhooks", "error", err)
 		return
 	}
 
@@ -55,24 +147,63 @@ func (s *NotificationService) NotifyStatusChange(ctx context.Context, statusLog
 		return
 	}
 
-	// Determine system ID for filtering
-	var systemID int64
+	systemID := s.statusChangeSystemID(ctx, statusLog)
+
+	// Send to matching webhooks
+	for _, webhook := range webhooks {
+		if !webhook.ShouldTrigger(domain.EventStatusChange, systemID, domain.TriggerAttrs{Status: statusLog.NewStatus}) {
+			continue
+		}
+		if wg == nil {
+			s.goSend(func() { s.sendNotification(webhook, payload) })
+			continue
+		}
+		wg.Add(1)
+		started := s.goSend(func() {
+			defer wg.Done()
+			s.sendNotification(webhook, payload)
+		})
+		if !started {
+			wg.Done()
+		}
+	}
+}
+
+// statusChangeSystemID returns the system a status change belongs to, or 0
+// if it cannot be determined
+func (s *NotificationService) statusChangeSystemID(ctx context.Context, statusLog *domain.StatusLog) int64 {
 	if statusLog.SystemID != nil {
-		systemID = *statusLog.SystemID
-	} else if statusLog.DependencyID != nil {
+		return *statusLog.SystemID
+	}
+	if statusLog.DependencyID != nil {
 		// Get system ID from dependency
 		dep, err := s.depRepo.GetByID(ctx, *statusLog.DependencyID)
 		if err == nil && dep != nil {
-			systemID = dep.SystemID
+			return dep.SystemID
 		}
 	}
+	return 0
+}
 
-	// Send to matching webhooks
-	for _, webhook := range webhooks {
-		if webhook.ShouldTrigger(domain.EventStatusChange, systemID) {
-			go s.sendNotification(webhook, payload)
-		}
+// underMaintenance reports whether a status change belongs to a system in an
+// active maintenance window. Lookup errors do not mute the change.
+func (s *NotificationService) underMaintenance(ctx context.Context, statusLog *domain.StatusLog) bool {
+	if s.maintenanceService == nil {
+		return false
+	}
+	systemID := s.statusChangeSystemID(ctx, statusLog)
+	if systemID == 0 {
+		return false
+	}
+	under, m, err := s.maintenanceService.IsSystemUnderMaintenance(ctx, systemID)
+	if err != nil {
+		s.logger.Warn("failed to check maintenance, sending status change", "system_id", systemID, "error", err)
+		return false
 	}
+	if under {
+		s.logger.Info("status change muted during maintenance", "system_id", systemID, "maintenance_id", m.ID, "new_status", string(statusLog.NewStatus))
+	}
+	return under
 }
 
 func (s *NotificationService) buildPayload(ctx context.Context, statusLog *domain.StatusLog) *domain.NotificationPayload {
@@ -85,10 +216,14 @@ func (s *NotificationService) buildPayload(ctx context.Context, statusLog *domai
 		Source:    string(statusLog.Source),
 	}
 
+	var system *domain.System
+	var dep *domain.Dependency
+
 	// Add system info
 	if statusLog.SystemID != nil {
-		system, err := s.systemRepo.GetByID(ctx, *statusLog.SystemID)
-		if err == nil && system != nil {
+		sys, err := s.systemRepo.GetByID(ctx, *statusLog.SystemID)
+		if err == nil && sys != nil {
+			system = sys
 			payload.System = &domain.SystemInfo{
 				ID:   system.ID,
 				Name: system.Name,
@@ -98,16 +233,18 @@ func (s *NotificationService) buildPayload(ctx context.Context, statusLog *domai
 
 	// Add dependency info
 	if statusLog.DependencyID != nil {
-		dep, err := s.depRepo.GetByID(ctx, *statusLog.DependencyID)
-		if err == nil && dep != nil {
+		d, err := s.depRepo.GetByID(ctx, *statusLog.DependencyID)
+		if err == nil && d != nil {
+			dep = d
 			payload.Dependency = &domain.DepInfo{
 				ID:   dep.ID,
 				Name: dep.Name,
 			}
 			// Also get system if not already set
 			if payload.System == nil {
-				system, err := s.systemRepo.GetByID(ctx, dep.SystemID)
-				if err == nil && system != nil {
+				sys, err := s.systemRepo.GetByID(ctx, dep.SystemID)
+				if err == nil && sys != nil {
+					system = sys
 					payload.System = &domain.SystemInfo{
 						ID:   system.ID,
 						Name: system.Name,
@@ -117,6 +254,11 @@ func (s *NotificationService) buildPayload(ctx context.Context, statusLog *domai
 		}
 	}
 
+	// Full entities are stripped again for webhooks that did not opt in
+	if system != nil || dep != nil {
+		payload.Entities = domain.NewPayloadEntities(system, dep)
+	}
+
 	return payload
 }
 
@@ -126,23 +268,58 @@ func (s *NotificationService) sendNotification(webhook *domain.Webhook, payload
 
 	switch webhook.Type {
 	case domain.WebhookTypeSlack:
-		body, err = s.formatSlackPayload(payload)
+		if webhook.SlackBlocks {
+			body, err = s.formatSlackBlocksCard(statusChangeCard(payload))
+		} else {
+			body, err = s.formatSlackPayload(payload)
+		}
 	case domain.WebhookTypeTelegram:
 		body, err = s.formatTelegramPayload(webhook.URL, payload)
 	case domain.WebhookTypeDiscord:
 		body, err = s.formatDiscordPayload(payload)
 	case domain.WebhookTypeTeams:
 		body, err = s.formatTeamsPayload(payload)
+	case domain.WebhookTypePagerDuty:
+		body, err = s.formatPagerDutyPayload(webhook.URL, payload)
+	case domain.WebhookTypeEmail:
+		subject, text, htmlBody := s.formatEmailStatusChange(payload)
+		s.sendEmail(webhook, payload.Event, subject, text, htmlBody)
+		return
 	default:
-		body, err = json.Marshal(payload)
+		if !webhook.IncludeEntity {
+			payload = payload.WithoutEntities()
+		}
+		body, err = formatGenericPayload(webhook, payload.ForVersion(webhook.EffectivePayloadVersion()))
 	}
 
 	if err != nil {
-		logError("Failed to format payload for webhook %s: %v", webhook.Name, err)
+		s.logger.Error("failed to format webhook payload", append(webhookLogFields(webhook), "error", err)...)
 		return
 	}
 
+	s.postWebhook(webhook, payload.Event, body)
+}
+
+// formatGenericPayload renders a generic webhook body with the webhook's
+// payload template, or as plain JSON when it has none
+func formatGenericPayload(webhook *domain.Webhook, payload interface{}) ([]byte, error) {
+	if webhook.PayloadTemplate != "" {
+		return webhook.RenderPayload(payload)
+	}
+	return json.Marshal(payload)
+}
+
+// postWebhook delivers an already formatted body to the webhook, retrying
+// transient failures with exponential backoff, and records the outcome
+func (s *NotificationService) postWebhook(webhook *domain.Webhook, event domain.WebhookEvent, body []byte) {
 	url := webhook.URL
+	// PagerDuty webhooks hold a routing key; events go to the Events API
+	if webhook.Type == domain.WebhookTypePagerDuty {
+		url = pagerDutyEventsURL
+		if s.pagerDutyURL != "" {
+			url = s.pagerDutyURL
+		}
+	}
 	// For Telegram, we need to modify the URL
 	if webhook.Type == domain.WebhookTypeTelegram {
 		// URL format: https://api.telegram.org/bot{token}/sendMessage
@@ -156,25 +333,188 @@ func (s *NotificationService) sendNotification(webhook *domain.Webhook, payload
 		}
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", "StatusIncident-Webhook/1.0")
+	// Generic webhooks with a secret are signed so receivers can verify them
+	if webhook.Type == domain.WebhookTypeGeneric && webhook.Secret != "" {
+		header.Set(SignatureHeader, signPayload(webhook.Secret, body))
+	}
+
+	for attempt := 0; ; attempt++ {
+		statusCode, retryable, retryAfter, err := s.deliver(url, header, body)
+		if err == nil {
+			s.recordDelivery(webhook, domain.NewWebhookDelivery(webhook.ID, event, statusCode, attempt+1, nil))
+			return
+		}
+		if !retryable || attempt >= s.maxRetries {
+			s.logger.Error("webhook delivery failed", append(webhookLogFields(webhook),
+				"status_code", statusCode,
+				"attempt", attempt+1,
+				"error", err,
+			)...)
+			s.recordDelivery(webhook, domain.NewWebhookDelivery(webhook.ID, event, statusCode, attempt+1, err))
+			return
+		}
+
+		wait := s.retryDelay(attempt)
+		if retryAfter > 0 {
+			wait = min(retryAfter, maxWebhookRetryDelay)
+		}
+		select {
+		case <-time.After(wait):
+		case <-s.sendContext().Done():
+			s.logger.Error("webhook delivery cancelled", append(webhookLogFields(webhook), "attempt", attempt+1)...)
+			s.recordDelivery(webhook, domain.NewWebhookDelivery(webhook.ID, event, statusCode, attempt+1, err))
+			return
+		}
+	}
+}
+
+// recordDelivery logs the delivery, updates the webhook's last delivery
+// status and disables it once it reaches the consecutive failure threshold
+func (s *NotificationService) recordDelivery(webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	if webhook.ID == 0 || s.webhookRepo == nil {
+		return
+	}
+	ctx := context.Background()
+
+	if s.deliveryRepo != nil {
+		if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+			s.logger.Error("failed to save webhook delivery", append(webhookLogFields(webhook), "error", err)...)
+		}
+	}
+
+	failures, err := s.webhookRepo.RecordDelivery(ctx, delivery)
 	if err != nil {
-		logError("Failed to create request for webhook %s: %v", webhook.Name, err)
+		s.logger.Error("failed to record webhook delivery", append(webhookLogFields(webhook), "error", err)...)
+		return
+	}
+
+	if delivery.Succeeded() || s.autoDisableAfter <= 0 || failures < s.autoDisableAfter {
+		return
+	}
+
+	// Re-read so the update doesn't overwrite concurrent edits with a stale copy
+	current, err := s.webhookRepo.GetByID(ctx, webhook.ID)
+	if err != nil || current == nil || !current.Enabled {
+		return
+	}
+	current.Disable()
+	if err := s.webhookRepo.Update(ctx, current); err != nil {
+		s.logger.Error("failed to auto-disable webhook", append(webhookLogFields(webhook), "error", err)...)
 		return
 	}
+	s.logger.Warn("webhook auto-disabled after consecutive failures", append(webhookLogFields(webhook),
+		"consecutive_failures", failures,
+	)...)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "StatusIncident-Webhook/1.0")
+// deliver makes a single POST. Network errors, 5xx and 429 responses are
+// retryable; retryAfter is taken from the Retry-After header when present.
+// statusCode is 0 when no response was received.
+func (s *NotificationService) deliver(url string, header http.Header, body []byte) (statusCode int, retryable bool, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(s.sendContext(), "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = header.Clone()
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		logError("Failed to send webhook %s: %v", webhook.Name, err)
-		return
+		return 0, true, 0, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		logError("Webhook %s returned status %d", webhook.Name, resp.StatusCode)
+	if resp.StatusCode < 400 {
+		return resp.StatusCode, false, 0, nil
+	}
+
+	err = fmt.Errorf("returned status %d", resp.StatusCode)
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return resp.StatusCode, true, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), err
+	}
+	return resp.StatusCode, false, 0, err
+}
+
+// SignatureHeader carries the HMAC-SHA256 of the request body for generic
+// webhooks with a secret, formatted as "sha256=<hex>" (GitHub-style)
+const SignatureHeader = "X-Signature-256"
+
+// signPayload returns the signature header value for body
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the exponential backoff before the given retry, with
+// jitter in the upper half of the step so concurrent senders spread out
+func (s *NotificationService) retryDelay(attempt int) time.Duration {
+	if s.retryBaseDelay <= 0 {
+		return 0
+	}
+	delay := s.retryBaseDelay << attempt
+	if delay <= 0 || delay > maxWebhookRetryDelay {
+		delay = maxWebhookRetryDelay
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// sendTextNotification sends a plain text message to chat webhooks and the
+// structured payload to generic webhooks
+func (s *NotificationService) sendTextNotification(webhook *domain.Webhook, event domain.WebhookEvent, text string, payload interface{}) {
+	var body []byte
+	var err error
+
+	switch webhook.Type {
+	case domain.WebhookTypeSlack, domain.WebhookTypeTeams:
+		body, err = json.Marshal(map[string]interface{}{"text": text})
+	case domain.WebhookTypeDiscord:
+		body, err = json.Marshal(map[string]interface{}{"content": text})
+	case domain.WebhookTypeTelegram:
+		telegramPayload := map[string]interface{}{"text": text}
+		if !strings.Contains(webhook.URL, "api.telegram.org") {
+			parts := strings.SplitN(webhook.URL, ":", 2)
+			if len(parts) == 2 {
+				telegramPayload["chat_id"] = parts[1]
+			}
+		}
+		body, err = json.Marshal(telegramPayload)
+	case domain.WebhookTypePagerDuty:
+		body, err = s.formatPagerDutyText(webhook.URL, text)
+	case domain.WebhookTypeEmail:
+		subject, _, _ := strings.Cut(text, "\n")
+		s.sendEmail(webhook, event, subject, text, "")
+		return
+	default:
+		body, err = formatGenericPayload(webhook, payload)
+	}
+
+	if err != nil {
+		s.logger.Error("failed to format webhook payload", append(webhookLogFields(webhook), "error", err)...)
+		return
 	}
+
+	s.postWebhook(webhook, event, body)
 }
 
 func (s *NotificationService) formatSlackPayload(payload *domain.NotificationPayload) ([]byte, error) {
@@ -199,6 +539,8 @@ func (s *NotificationService) formatSlackPayload(payload *domain.NotificationPay
 	switch payload.NewStatus {
 	case domain.StatusYellow:
 		color = "warning"
+	case domain.StatusPartial:
+		color = "#E67E22" // orange, between warning and danger
 	case domain.StatusRed:
 		color = "danger"
 	}
@@ -290,6 +632,8 @@ func (s *NotificationService) formatDiscordPayload(payload *domain.NotificationP
 	switch payload.NewStatus {
 	case domain.StatusYellow:
 		color = 16776960 // yellow
+	case domain.StatusPartial:
+		color = 15105570 // orange
 	case domain.StatusRed:
 		color = 15548997 // red
 	}
@@ -339,6 +683,8 @@ func (s *NotificationService) formatTeamsPayload(payload *domain.NotificationPay
 	switch payload.NewStatus {
 	case domain.StatusYellow:
 		themeColor = "FFFF00" // yellow
+	case domain.StatusPartial:
+		themeColor = "E67E22" // orange
 	case domain.StatusRed:
 		themeColor = "FF0000" // red
 	}
@@ -401,11 +747,384 @@ func (s *NotificationService) SendTestNotification(ctx context.Context, webhookI
 	return nil
 }
 
+// SendTestSLABreach sends a sample SLA breach to a webhook so its breach
+// format can be previewed. Like SendTestNotification it ignores the
+// webhook's event subscriptions and filters.
+func (s *NotificationService) SendTestSLABreach(ctx context.Context, webhookID int64) error {
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if webhook == nil {
+		return fmt.Errorf("webhook not found")
+	}
+
+	breach := &domain.SLABreachEvent{
+		SystemName:  "Test System",
+		BreachType:  "availability",
+		SLATarget:   99.9,
+		ActualValue: 99.5,
+		Period:      "monthly",
+		DetectedAt:  time.Now(),
+	}
+	payload := buildSLABreachPayload(breach)
+	payload.Message = "This is a test SLA breach notification from Status Incident. " + payload.Message
+
+	s.sendSLABreachNotification(webhook, payload)
+	return nil
+}
+
+// NotifyDependencyProlongedOutage sends an escalation for a dependency that
+// has been down since downSince
+func (s *NotificationService) NotifyDependencyProlongedOutage(ctx context.Context, dep *domain.Dependency, downSince time.Time, now time.Time) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload := &domain.NotificationPayload{
+		Event:      domain.EventDependencyProlongedOutage,
+		Timestamp:  now,
+		Dependency: &domain.DepInfo{ID: dep.ID, Name: dep.Name},
+		OldStatus:  domain.StatusRed,
+		NewStatus:  domain.StatusRed,
+		Message:    fmt.Sprintf("Down for %s (since %s)", now.Sub(downSince).Round(time.Minute), downSince.Format(time.RFC3339)),
+		Source:     "escalation",
+	}
+
+	system, err := s.systemRepo.GetByID(ctx, dep.SystemID)
+	if err == nil && system != nil {
+		payload.System = &domain.SystemInfo{ID: system.ID, Name: system.Name}
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTrigger(domain.EventDependencyProlongedOutage, dep.SystemID, domain.TriggerAttrs{Status: domain.StatusRed}) {
+			s.goSend(func() { s.sendNotification(webhook, payload) })
+		}
+	}
+}
+
+// NotifyMaintenance sends a maintenance scheduled/started notification
+func (s *NotificationService) NotifyMaintenance(ctx context.Context, m *domain.Maintenance, event domain.WebhookEvent) {
+	var message string
+	switch event {
+	case domain.EventMaintenanceStarted:
+		message = fmt.Sprintf("🔧 Maintenance started: %s (until %s)", m.Title, m.EndTime.Format("Jan 2, 15:04 MST"))
+	default:
+		message = fmt.Sprintf("🗓️ Maintenance scheduled: %s (%s – %s)", m.Title,
+			m.StartTime.Format("Jan 2, 15:04 MST"), m.EndTime.Format("Jan 2, 15:04 MST"))
+	}
+
+	s.notifyMaintenance(ctx, m, event, domain.ReminderNone, message)
+}
+
+// NotifyMaintenanceReminder sends a maintenance_reminder notification for
+// the given reminder stage
+func (s *NotificationService) NotifyMaintenanceReminder(ctx context.Context, m *domain.Maintenance, reminder domain.MaintenanceReminder) {
+	var message string
+	switch reminder {
+	case domain.ReminderStarted:
+		message = fmt.Sprintf("🔧 Maintenance starting now: %s (until %s)", m.Title, m.EndTime.Format("Jan 2, 15:04 MST"))
+	case domain.ReminderEnded:
+		message = fmt.Sprintf("✅ Maintenance ended: %s", m.Title)
+	default:
+		message = fmt.Sprintf("⏰ Maintenance starting soon: %s (%s – %s)", m.Title,
+			m.StartTime.Format("Jan 2, 15:04 MST"), m.EndTime.Format("Jan 2, 15:04 MST"))
+	}
+
+	s.notifyMaintenance(ctx, m, domain.EventMaintenanceReminder, reminder, message)
+}
+
+// notifyMaintenance sends a maintenance event to the webhooks subscribed to
+// it for one of the window's systems
+func (s *NotificationService) notifyMaintenance(ctx context.Context, m *domain.Maintenance, event domain.WebhookEvent, reminder domain.MaintenanceReminder, message string) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload := &domain.MaintenancePayload{
+		PayloadVersion: domain.CurrentPayloadVersion,
+		Event:          event,
+		Timestamp:      time.Now(),
+		Maintenance: domain.MaintenanceInfo{
+			ID:          m.ID,
+			Title:       m.Title,
+			Description: m.Description,
+			StartTime:   m.StartTime,
+			EndTime:     m.EndTime,
+			SystemIDs:   m.SystemIDs,
+		},
+		Reminder: reminder,
+		Message:  message,
+	}
+
+	color := cardColorBlue
+	if reminder == domain.ReminderEnded {
+		color = cardColorGreen
+	}
+	card := &eventCard{
+		Text:  message,
+		Color: color,
+		Fields: []cardField{
+			{Name: "Start", Value: m.StartTime.Format("Jan 2, 15:04 MST")},
+			{Name: "End", Value: m.EndTime.Format("Jan 2, 15:04 MST")},
+		},
+	}
+	if m.Description != "" {
+		card.Text += "\n" + m.Description
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTriggerAny(event, m.SystemIDs, domain.TriggerAttrs{}) {
+			s.goSend(func() { s.sendEventNotification(webhook, event, card, payload) })
+		}
+	}
+}
+
+// NotifyIncident sends an incident opened (EventIncidentStart) or timeline
+// update (EventIncidentUpdated) notification
+func (s *NotificationService) NotifyIncident(ctx context.Context, incident *domain.Incident, update *domain.IncidentUpdate, event domain.WebhookEvent) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload := &domain.IncidentPayload{
+		PayloadVersion: domain.CurrentPayloadVersion,
+		Event:          event,
+		Timestamp:      time.Now(),
+		Incident: domain.IncidentInfo{
+			ID:        incident.ID,
+			Title:     incident.Title,
+			Severity:  incident.Severity,
+			Status:    incident.Status,
+			SystemIDs: incident.SystemIDs,
+		},
+	}
+
+	var message string
+	switch event {
+	case domain.EventIncidentStart:
+		message = fmt.Sprintf("🚨 Incident opened: %s (%s)", incident.Title, incident.Severity)
+	default:
+		message = fmt.Sprintf("📝 Incident update: %s [%s]", incident.Title, incident.Status)
+	}
+	if update != nil {
+		payload.Update = &domain.IncidentDigestEntry{
+			Status:    update.Status,
+			Message:   update.Message,
+			CreatedBy: update.CreatedBy,
+			CreatedAt: update.CreatedAt,
+		}
+		if update.Message != "" {
+			message += "\n" + update.Message
+		}
+	}
+	payload.Message = message
+
+	_, affected := s.affectedSystems(ctx, incident.SystemIDs)
+	card := &eventCard{
+		Text:  message,
+		Color: incidentCardColor(incident),
+		Fields: []cardField{
+			{Name: "Severity", Value: string(incident.Severity)},
+			{Name: "Status", Value: string(incident.Status)},
+			{Name: "Affected", Value: strings.Join(affected, ", ")},
+		},
+		Actions: s.incidentActions(incident),
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTriggerAny(event, incident.SystemIDs, domain.TriggerAttrs{Severity: incident.Severity}) {
+			s.goSend(func() { s.sendEventNotification(webhook, event, card, payload) })
+		}
+	}
+}
+
+// NotifyIncidentEscalated re-sends an active incident that nobody has
+// acknowledged for unacked; escalation counts the escalations so far
+func (s *NotificationService) NotifyIncidentEscalated(ctx context.Context, incident *domain.Incident, escalation int, unacked time.Duration) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("📣 Incident not acknowledged for %s: %s (%s, escalation %d)",
+		unacked.Round(time.Minute), incident.Title, incident.Severity, escalation)
+	payload := &domain.IncidentPayload{
+		PayloadVersion: domain.CurrentPayloadVersion,
+		Event:          domain.EventIncidentEscalated,
+		Timestamp:      time.Now(),
+		Incident: domain.IncidentInfo{
+			ID:        incident.ID,
+			Title:     incident.Title,
+			Severity:  incident.Severity,
+			Status:    incident.Status,
+			SystemIDs: incident.SystemIDs,
+		},
+		Message:    message,
+		Escalated:  true,
+		Escalation: escalation,
+	}
+
+	_, affected := s.affectedSystems(ctx, incident.SystemIDs)
+	card := &eventCard{
+		Text:  message,
+		Color: incidentCardColor(incident),
+		Fields: []cardField{
+			{Name: "Severity", Value: string(incident.Severity)},
+			{Name: "Status", Value: string(incident.Status)},
+			{Name: "Affected", Value: strings.Join(affected, ", ")},
+			{Name: "Escalation", Value: strconv.Itoa(escalation)},
+		},
+		Actions: s.incidentActions(incident),
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTriggerAny(domain.EventIncidentEscalated, incident.SystemIDs, domain.TriggerAttrs{Severity: incident.Severity}) {
+			s.goSend(func() { s.sendEventNotification(webhook, domain.EventIncidentEscalated, card, payload) })
+		}
+	}
+}
+
+// affectedSystems resolves incident system IDs to infos and display names;
+// no IDs means all systems
+func (s *NotificationService) affectedSystems(ctx context.Context, ids []int64) ([]domain.SystemInfo, []string) {
+	var infos []domain.SystemInfo
+	var names []string
+	for _, id := range ids {
+		info := domain.SystemInfo{ID: id}
+		if system, err := s.systemRepo.GetByID(ctx, id); err == nil && system != nil {
+			info.Name = system.Name
+		}
+		infos = append(infos, info)
+		names = append(names, info.Name)
+	}
+	if len(names) == 0 {
+		names = []string{"all systems"}
+	}
+	return infos, names
+}
+
+// NotifyIncidentResolved sends a single digest of a resolved incident,
+// covering its timeline, duration and affected systems
+func (s *NotificationService) NotifyIncidentResolved(ctx context.Context, incident *domain.Incident, updates []*domain.IncidentUpdate) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	duration := incident.Duration().Round(time.Second)
+	digest := domain.IncidentDigest{
+		ID:              incident.ID,
+		Title:           incident.Title,
+		Severity:        incident.Severity,
+		CreatedAt:       incident.CreatedAt,
+		ResolvedAt:      incident.ResolvedAt,
+		DurationSeconds: int64(duration.Seconds()),
+		Postmortem:      incident.Postmortem,
+	}
+
+	var affected []string
+	digest.AffectedSystems, affected = s.affectedSystems(ctx, incident.SystemIDs)
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "✅ Incident resolved: %s (%s)\n", incident.Title, incident.Severity)
+	fmt.Fprintf(&text, "Duration: %s\n", duration)
+	fmt.Fprintf(&text, "Affected: %s\n", strings.Join(affected, ", "))
+	text.WriteString("Timeline:\n")
+	for _, u := range updates {
+		digest.Timeline = append(digest.Timeline, domain.IncidentDigestEntry{
+			Status:    u.Status,
+			Message:   u.Message,
+			CreatedBy: u.CreatedBy,
+			CreatedAt: u.CreatedAt,
+		})
+		fmt.Fprintf(&text, "• %s [%s] %s\n", u.CreatedAt.Format("Jan 2, 15:04 MST"), u.Status, u.Message)
+	}
+	if incident.Postmortem != "" {
+		fmt.Fprintf(&text, "Postmortem: %s\n", incident.Postmortem)
+	}
+	message := strings.TrimRight(text.String(), "\n")
+
+	payload := &domain.IncidentDigestPayload{
+		PayloadVersion: domain.CurrentPayloadVersion,
+		Event:          domain.EventIncidentEnd,
+		Timestamp:      time.Now(),
+		Incident:       digest,
+		Message:        message,
+	}
+
+	card := &eventCard{
+		Text:  message,
+		Color: cardColorGreen,
+		Fields: []cardField{
+			{Name: "Severity", Value: string(incident.Severity)},
+			{Name: "Duration", Value: duration.String()},
+		},
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTriggerAny(domain.EventIncidentEnd, incident.SystemIDs, domain.TriggerAttrs{Severity: incident.Severity}) {
+			s.goSend(func() { s.sendEventNotification(webhook, domain.EventIncidentEnd, card, payload) })
+		}
+	}
+}
+
+// NotifyMonitoringDegraded alerts that the monitoring pipeline itself is unhealthy
+func (s *NotificationService) NotifyMonitoringDegraded(ctx context.Context, reason string) {
+	webhooks, err := s.webhookRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("failed to get webhooks", "error", err)
+		return
+	}
+
+	message := "⚠️ Monitoring degraded: " + reason
+	payload := &domain.MonitoringAlertPayload{
+		PayloadVersion: domain.CurrentPayloadVersion,
+		Event:          domain.EventMonitoringDegraded,
+		Timestamp:      time.Now(),
+		Message:        message,
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ShouldTriggerAny(domain.EventMonitoringDegraded, nil, domain.TriggerAttrs{}) {
+			s.goSend(func() { s.sendTextNotification(webhook, domain.EventMonitoringDegraded, message, payload) })
+		}
+	}
+}
+
 // NotifySLABreach sends notifications for an SLA breach
 func (s *NotificationService) NotifySLABreach(ctx context.Context, breach *domain.SLABreachEvent) {
 	webhooks, err := s.webhookRepo.GetEnabled(ctx)
 	if err != nil {
-		logError("Failed to get webhooks: %v", err)
+		s.logger.Error("failed to get webhooks", "error", err)
 		return
 	}
 
@@ -413,8 +1132,18 @@ func (s *NotificationService) NotifySLABreach(ctx context.Context, breach *domai
 		return
 	}
 
-	// Build payload
-	payload := &domain.SLABreachPayload{
+	payload := buildSLABreachPayload(breach)
+
+	// Send to matching webhooks
+	for _, webhook := range webhooks {
+		if webhook.ShouldTrigger(domain.EventSLABreach, breach.SystemID, domain.TriggerAttrs{}) {
+			s.goSend(func() { s.sendSLABreachNotification(webhook, payload) })
+		}
+	}
+}
+
+func buildSLABreachPayload(breach *domain.SLABreachEvent) *domain.SLABreachPayload {
+	return &domain.SLABreachPayload{
 		Event:     domain.EventSLABreach,
 		Timestamp: breach.DetectedAt,
 		System: &domain.SystemInfo{
@@ -427,13 +1156,6 @@ func (s *NotificationService) NotifySLABreach(ctx context.Context, breach *domai
 		Period:      breach.Period,
 		Message:     fmt.Sprintf("SLA target %.2f%% not met (actual: %.2f%%)", breach.SLATarget, breach.ActualValue),
 	}
-
-	// Send to matching webhooks
-	for _, webhook := range webhooks {
-		if webhook.ShouldTrigger(domain.EventSLABreach, breach.SystemID) {
-			go s.sendSLABreachNotification(webhook, payload)
-		}
-	}
 }
 
 func (s *NotificationService) sendSLABreachNotification(webhook *domain.Webhook, payload *domain.SLABreachPayload) {
@@ -449,44 +1171,24 @@ func (s *NotificationService) sendSLABreachNotification(webhook *domain.Webhook,
 		body, err = s.formatDiscordSLABreach(payload)
 	case domain.WebhookTypeTeams:
 		body, err = s.formatTeamsSLABreach(payload)
-	default:
-		body, err = json.Marshal(payload)
-	}
-
-	if err != nil {
-		logError("Failed to format SLA breach payload for webhook %s: %v", webhook.Name, err)
-		return
-	}
-
-	url := webhook.URL
-	if webhook.Type == domain.WebhookTypeTelegram {
-		if !strings.Contains(url, "api.telegram.org") {
-			parts := strings.SplitN(url, ":", 2)
-			if len(parts) == 2 {
-				url = fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", parts[0])
-			}
-		}
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		logError("Failed to create request for webhook %s: %v", webhook.Name, err)
+	case domain.WebhookTypePagerDuty:
+		body, err = s.formatPagerDutySLABreach(webhook.URL, payload)
+	case domain.WebhookTypeEmail:
+		subject, text, htmlBody := s.formatEmailSLABreach(payload)
+		s.sendEmail(webhook, payload.Event, subject, text, htmlBody)
 		return
+	default:
+		versioned := *payload
+		versioned.PayloadVersion = webhook.EffectivePayloadVersion()
+		body, err = formatGenericPayload(webhook, versioned)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "StatusIncident-Webhook/1.0")
-
-	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		logError("Failed to send webhook %s: %v", webhook.Name, err)
+		s.logger.Error("failed to format SLA breach payload", append(webhookLogFields(webhook), "error", err)...)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		logError("Webhook %s returned status %d", webhook.Name, resp.StatusCode)
-	}
+	s.postWebhook(webhook, payload.Event, body)
 }
 
 func (s *NotificationService) formatSlackSLABreach(payload *domain.SLABreachPayload) ([]byte, error) {
@@ -577,6 +1279,11 @@ func (s *NotificationService) formatTeamsSLABreach(payload *domain.SLABreachPayl
 	return json.Marshal(teamsPayload)
 }
 
-func logError(format string, args ...interface{}) {
-	log.Printf("[WEBHOOK ERROR] "+format, args...)
+// webhookLogFields identifies a webhook in structured log fields
+func webhookLogFields(webhook *domain.Webhook) []any {
+	return []any{
+		"webhook_id", webhook.ID,
+		"webhook_name", webhook.Name,
+		"webhook_type", string(webhook.Type),
+	}
 }
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"sync"
+)
+
+// sendTracker tracks background webhook sends so they can be drained on
+// shutdown
+type sendTracker struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	closing bool
+	// ctx bounds the HTTP requests and retry waits of all sends; it is
+	// cancelled when Shutdown gives up waiting
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// sendContext returns the context background sends run under
+func (s *NotificationService) sendContext() context.Context {
+	s.sends.mu.Lock()
+	defer s.sends.mu.Unlock()
+	if s.sends.ctx == nil {
+		s.sends.ctx, s.sends.cancel = context.WithCancel(context.Background())
+	}
+	return s.sends.ctx
+}
+
+// goSend runs send in the background and tracks it until it returns. Sends
+// started after Shutdown are dropped and false is returned.
+func (s *NotificationService) goSend(send func()) bool {
+	s.sends.mu.Lock()
+	if s.sends.closing {
+		s.sends.mu.Unlock()
+		s.logger.Warn("notification dropped during shutdown")
+		return false
+	}
+	s.sends.wg.Add(1)
+	s.sends.mu.Unlock()
+
+	go func() {
+		defer s.sends.wg.Done()
+		send()
+	}()
+	return true
+}
+
+// Shutdown flushes buffered status changes and waits for in-flight sends
+// to finish. When ctx is done first, in-flight requests and retries are
+// cancelled and ctx's error is returned. No new sends are started afterwards.
+func (s *NotificationService) Shutdown(ctx context.Context) error {
+	s.Flush(ctx)
+
+	s.sendContext() // make sure there is a context to cancel
+	s.sends.mu.Lock()
+	s.sends.closing = true
+	cancel := s.sends.cancel
+	s.sends.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.sends.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
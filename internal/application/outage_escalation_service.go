@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// DefaultOutageEscalationThreshold is how long a dependency may stay red before escalating
+const DefaultOutageEscalationThreshold = 15 * time.Minute
+
+// OutageEscalationService escalates dependencies that stay down too long
+type OutageEscalationService struct {
+	systemRepo          domain.SystemRepository
+	depRepo             domain.DependencyRepository
+	logRepo             domain.StatusLogRepository
+	notificationService *NotificationService
+	threshold           time.Duration
+	clock               Clock
+
+	mu        sync.Mutex
+	escalated map[int64]time.Time // dependency ID -> start of the escalated outage
+}
+
+// NewOutageEscalationService creates a new OutageEscalationService
+func NewOutageEscalationService(systemRepo domain.SystemRepository, depRepo domain.DependencyRepository, logRepo domain.StatusLogRepository, threshold time.Duration) *OutageEscalationService {
+	if threshold <= 0 {
+		threshold = DefaultOutageEscalationThreshold
+	}
+	return &OutageEscalationService{
+		systemRepo: systemRepo,
+		depRepo:    depRepo,
+		logRepo:    logRepo,
+		threshold:  threshold,
+		clock:      SystemClock{},
+		escalated:  make(map[int64]time.Time),
+	}
+}
+
+// SetNotificationService sets the notification service for escalations
+func (s *OutageEscalationService) SetNotificationService(ns *NotificationService) {
+	s.notificationService = ns
+}
+
+// SetClock sets the clock that measures how long a dependency has been red
+func (s *OutageEscalationService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Sweep checks all dependencies and escalates each prolonged outage once.
+// It returns the dependencies escalated in this sweep.
+func (s *OutageEscalationService) Sweep(ctx context.Context) ([]*domain.Dependency, error) {
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+
+	var deps []*domain.Dependency
+	for _, system := range systems {
+		systemDeps, err := s.depRepo.GetBySystemID(ctx, system.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies: %w", err)
+		}
+		deps = append(deps, systemDeps...)
+	}
+
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var escalated []*domain.Dependency
+	for _, dep := range deps {
+		if dep.Status != domain.StatusRed {
+			// Recovered: the next outage escalates again
+			delete(s.escalated, dep.ID)
+			continue
+		}
+
+		downSince := s.downSince(ctx, dep)
+		if now.Sub(downSince) < s.threshold {
+			continue
+		}
+		if start, ok := s.escalated[dep.ID]; ok && start.Equal(downSince) {
+			continue
+		}
+
+		s.escalated[dep.ID] = downSince
+		escalated = append(escalated, dep)
+
+		if s.notificationService != nil {
+			go s.notificationService.NotifyDependencyProlongedOutage(context.Background(), dep, downSince, now)
+		}
+	}
+
+	return escalated, nil
+}
+
+// downSince returns when the dependency last turned red
+func (s *OutageEscalationService) downSince(ctx context.Context, dep *domain.Dependency) time.Time {
+	logs, err := s.logRepo.GetByDependencyID(ctx, dep.ID, 1)
+	if err == nil && len(logs) > 0 && logs[0].NewStatus == domain.StatusRed {
+		return logs[0].CreatedAt
+	}
+	return dep.UpdatedAt
+}
@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func TestOutageEscalationService_EscalatesOncePerOutage(t *testing.T) {
+	ctx := context.Background()
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	logRepo := NewMockStatusLogRepository()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(ctx, system)
+	dep, _ := domain.NewDependency(system.ID, "PostgreSQL", "")
+	depRepo.Create(ctx, dep)
+
+	// Latest log first, like the sqlite repository
+	logRepo.GetByDependencyIDFunc = func(ctx context.Context, dependencyID int64, limit int) ([]*domain.StatusLog, error) {
+		if len(logRepo.Logs) == 0 {
+			return nil, nil
+		}
+		return []*domain.StatusLog{logRepo.Logs[len(logRepo.Logs)-1]}, nil
+	}
+
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOutageEscalationService(systemRepo, depRepo, logRepo, 10*time.Minute)
+	service.SetClock(ClockFunc(func() time.Time { return clock }))
+
+	setStatus := func(status domain.Status) {
+		old := dep.Status
+		dep.Status = status
+		log := domain.NewStatusLog(nil, &dep.ID, old, status, "", domain.SourceHeartbeat)
+		log.CreatedAt = clock
+		logRepo.Logs = append(logRepo.Logs, log)
+	}
+	sweep := func() int {
+		t.Helper()
+		escalated, err := service.Sweep(ctx)
+		if err != nil {
+			t.Fatalf("Sweep() error = %v", err)
+		}
+		return len(escalated)
+	}
+
+	setStatus(domain.StatusRed)
+
+	clock = clock.Add(5 * time.Minute)
+	if n := sweep(); n != 0 {
+		t.Errorf("expected no escalation before threshold, got %d", n)
+	}
+
+	clock = clock.Add(6 * time.Minute)
+	if n := sweep(); n != 1 {
+		t.Errorf("expected 1 escalation after threshold, got %d", n)
+	}
+
+	clock = clock.Add(30 * time.Minute)
+	if n := sweep(); n != 0 {
+		t.Errorf("expected the same outage not to escalate again, got %d", n)
+	}
+
+	// Recovery resets the escalation
+	setStatus(domain.StatusGreen)
+	if n := sweep(); n != 0 {
+		t.Errorf("expected no escalation while green, got %d", n)
+	}
+
+	setStatus(domain.StatusRed)
+	clock = clock.Add(9 * time.Minute)
+	if n := sweep(); n != 0 {
+		t.Errorf("expected new outage to wait for threshold, got %d", n)
+	}
+
+	clock = clock.Add(2 * time.Minute)
+	if n := sweep(); n != 1 {
+		t.Errorf("expected new outage to escalate once, got %d", n)
+	}
+}
+
+func TestOutageEscalationService_IgnoresYellow(t *testing.T) {
+	ctx := context.Background()
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(ctx, system)
+	dep, _ := domain.NewDependency(system.ID, "Cache", "")
+	dep.Status = domain.StatusYellow
+	dep.UpdatedAt = time.Now().Add(-time.Hour)
+	depRepo.Create(ctx, dep)
+
+	service := NewOutageEscalationService(systemRepo, depRepo, NewMockStatusLogRepository(), time.Minute)
+
+	escalated, err := service.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if len(escalated) != 0 {
+		t.Errorf("expected degraded dependency not to escalate, got %d", len(escalated))
+	}
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// RetentionService deletes history older than the configured retention periods.
+// A zero retention keeps that history forever.
+type RetentionService struct {
+	logRepo          domain.StatusLogRepository
+	latencyRepo      domain.LatencyRepository
+	breachRepo       domain.SLABreachRepository
+	logRetention     time.Duration
+	latencyRetention time.Duration
+	clock            Clock
+}
+
+// NewRetentionService creates a new RetentionService.
+// Acknowledged SLA breaches follow the status log retention.
+func NewRetentionService(
+	logRepo domain.StatusLogRepository,
+	latencyRepo domain.LatencyRepository,
+	breachRepo domain.SLABreachRepository,
+	logRetention, latencyRetention time.Duration,
+) *RetentionService {
+	return &RetentionService{
+		logRepo:          logRepo,
+		latencyRepo:      latencyRepo,
+		breachRepo:       breachRepo,
+		logRetention:     logRetention,
+		latencyRetention: latencyRetention,
+		clock:            SystemClock{},
+	}
+}
+
+// SetClock sets the clock that retention cutoffs are measured from
+func (s *RetentionService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Enabled reports whether any retention period is set
+func (s *RetentionService) Enabled() bool {
+	return s.logRetention > 0 || s.latencyRetention > 0
+}
+
+// Cleanup deletes expired status logs, latency records and acknowledged SLA breaches.
+// A failure in one table doesn't stop the others from being cleaned.
+func (s *RetentionService) Cleanup(ctx context.Context) error {
+	now := s.clock.Now()
+	var errs []error
+
+	if s.logRetention > 0 {
+		cutoff := now.Add(-s.logRetention)
+		if err := s.logRepo.Cleanup(ctx, cutoff); err != nil {
+			errs = append(errs, err)
+		}
+		if err := s.breachRepo.Cleanup(ctx, cutoff); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if s.latencyRetention > 0 {
+		if err := s.latencyRepo.Cleanup(ctx, now.Add(-s.latencyRetention)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean up latency records: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func TestRetentionService_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	logRepo := NewMockStatusLogRepository()
+	systemID := int64(1)
+	oldLog := domain.NewStatusLog(&systemID, nil, domain.StatusGreen, domain.StatusRed, "old", domain.SourceManual)
+	oldLog.CreatedAt = now.AddDate(0, 0, -40)
+	newLog := domain.NewStatusLog(&systemID, nil, domain.StatusRed, domain.StatusGreen, "new", domain.SourceManual)
+	newLog.CreatedAt = now.AddDate(0, 0, -1)
+	logRepo.Logs = []*domain.StatusLog{oldLog, newLog}
+
+	breachRepo := NewMockSLABreachRepository()
+	breachRepo.Create(ctx, &domain.SLABreachEvent{SystemID: 1, DetectedAt: now.AddDate(0, 0, -40), Acknowledged: true})
+	breachRepo.Create(ctx, &domain.SLABreachEvent{SystemID: 1, DetectedAt: now.AddDate(0, 0, -40)})
+
+	latencyRepo := NewMockLatencyRepository()
+	var latencyCutoff time.Time
+	latencyRepo.CleanupFunc = func(ctx context.Context, olderThan time.Time) error {
+		latencyCutoff = olderThan
+		return nil
+	}
+
+	service := NewRetentionService(logRepo, latencyRepo, breachRepo, 30*24*time.Hour, 7*24*time.Hour)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+
+	if err := service.Cleanup(ctx); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if len(logRepo.Logs) != 1 || logRepo.Logs[0] != newLog {
+		t.Errorf("expected only the recent log to remain, got %d logs", len(logRepo.Logs))
+	}
+	if len(breachRepo.Breaches) != 1 {
+		t.Errorf("expected the unacknowledged breach to remain, got %d breaches", len(breachRepo.Breaches))
+	}
+	if want := now.AddDate(0, 0, -7); !latencyCutoff.Equal(want) {
+		t.Errorf("latency cutoff = %v, want %v", latencyCutoff, want)
+	}
+}
+
+func TestRetentionService_Cleanup_ZeroRetentionKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+
+	logRepo := NewMockStatusLogRepository()
+	systemID := int64(1)
+	old := domain.NewStatusLog(&systemID, nil, domain.StatusGreen, domain.StatusRed, "old", domain.SourceManual)
+	old.CreatedAt = time.Now().AddDate(-5, 0, 0)
+	logRepo.Logs = []*domain.StatusLog{old}
+
+	latencyRepo := NewMockLatencyRepository()
+	latencyRepo.CleanupFunc = func(ctx context.Context, olderThan time.Time) error {
+		t.Error("latency cleanup should not run without a retention period")
+		return nil
+	}
+
+	service := NewRetentionService(logRepo, latencyRepo, NewMockSLABreachRepository(), 0, 0)
+	if service.Enabled() {
+		t.Error("expected service to be disabled with zero retention")
+	}
+	if err := service.Cleanup(ctx); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if len(logRepo.Logs) != 1 {
+		t.Errorf("expected log to be kept, got %d logs", len(logRepo.Logs))
+	}
+}
+
+func TestRetentionService_Cleanup_ContinuesAfterError(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	logRepo := NewMockStatusLogRepository()
+	systemID := int64(1)
+	old := domain.NewStatusLog(&systemID, nil, domain.StatusGreen, domain.StatusRed, "old", domain.SourceManual)
+	old.CreatedAt = now.AddDate(0, 0, -40)
+	logRepo.Logs = []*domain.StatusLog{old}
+
+	latencyErr := errors.New("disk full")
+	latencyRepo := NewMockLatencyRepository()
+	latencyRepo.CleanupFunc = func(ctx context.Context, olderThan time.Time) error {
+		return latencyErr
+	}
+
+	service := NewRetentionService(logRepo, latencyRepo, NewMockSLABreachRepository(), 24*time.Hour, 24*time.Hour)
+	err := service.Cleanup(ctx)
+	if !errors.Is(err, latencyErr) {
+		t.Errorf("Cleanup() error = %v, want %v", err, latencyErr)
+	}
+	if len(logRepo.Logs) != 0 {
+		t.Errorf("expected logs to be cleaned despite latency error, got %d", len(logRepo.Logs))
+	}
+}
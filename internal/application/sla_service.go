@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"status-incident/internal/domain"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,11 @@ type SLAService struct {
 	breachRepo    domain.SLABreachRepository
 	latencyRepo   domain.LatencyRepository
 	notifService  *NotificationService
+	tagTargets    map[string]float64
+	// maintenanceRepo is set when downtime during maintenance windows
+	// should not count against uptime
+	maintenanceRepo domain.MaintenanceRepository
+	clock           Clock
 }
 
 // NewSLAService creates a new SLAService
@@ -36,9 +43,125 @@ func NewSLAService(
 		breachRepo:    breachRepo,
 		latencyRepo:   latencyRepo,
 		notifService:  notifService,
+		clock:         SystemClock{},
 	}
 }
 
+// SetClock sets the clock that SLA periods and breach checks are
+// measured against
+func (s *SLAService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// currentTime returns the time from the clock, falling back to the real time
+// for a zero SLAService
+func (s *SLAService) currentTime() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// SetTagSLATargets sets SLA targets inherited by systems with the given tags
+func (s *SLAService) SetTagSLATargets(targets map[string]float64) {
+	s.tagTargets = targets
+}
+
+// SetExcludeMaintenance makes uptime and SLA calculations ignore downtime
+// that falls within maintenance windows of the affected systems. Cancelled
+// windows still count. Pass nil to count all downtime again.
+func (s *SLAService) SetExcludeMaintenance(repo domain.MaintenanceRepository) {
+	s.maintenanceRepo = repo
+}
+
+// systemUptime returns uptime analytics for a system, with downtime covered
+// by maintenance windows removed when maintenance is excluded. The second
+// return value is the downtime that was removed.
+func (s *SLAService) systemUptime(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, time.Duration, error) {
+	analytics, err := s.analyticsRepo.GetUptimeBySystemID(ctx, systemID, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	if s.maintenanceRepo == nil {
+		return analytics, 0, nil
+	}
+
+	windows, err := s.maintenanceRepo.GetByTimeRange(ctx, start, end)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+	var affecting []*domain.Maintenance
+	for _, m := range windows {
+		if m.AffectsSystem(systemID) {
+			affecting = append(affecting, m)
+		}
+	}
+	if len(affecting) == 0 {
+		return analytics, 0, nil
+	}
+
+	incidents, err := s.analyticsRepo.GetIncidentsBySystemID(ctx, systemID, start, end)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get incidents: %w", err)
+	}
+
+	downtime, unavailable := domain.MaintenanceOverlap(incidents, affecting, start, end, s.currentTime())
+	if downtime == 0 {
+		return analytics, 0, nil
+	}
+
+	period := end.Sub(start)
+	adjusted := *analytics
+	adjusted.UptimePercent = min(100, analytics.UptimePercent+float64(downtime)/float64(period)*100)
+	adjusted.AvailabilityPercent = min(100, analytics.AvailabilityPercent+float64(unavailable)/float64(period)*100)
+	adjusted.TotalDowntime = max(0, analytics.TotalDowntime-downtime)
+	adjusted.TotalUnavailable = max(0, analytics.TotalUnavailable-unavailable)
+	return &adjusted, downtime, nil
+}
+
+// ResolveSLATarget returns the SLA target that applies to a system.
+// An explicit system target wins; otherwise the strictest target of the
+// system's tags is used, falling back to the system default.
+func (s *SLAService) ResolveSLATarget(system *domain.System) float64 {
+	if system.SLATargetExplicit {
+		return system.GetSLATarget()
+	}
+
+	var inherited float64
+	for _, tag := range system.Tags {
+		if target, ok := s.tagTargets[tag]; ok && target > inherited {
+			inherited = target
+		}
+	}
+	if inherited > 0 {
+		return inherited
+	}
+	return system.GetSLATarget()
+}
+
+// ParseTagSLATargets parses "tag=target" pairs separated by commas,
+// e.g. "production=99.95,staging=99"
+func ParseTagSLATargets(spec string) (map[string]float64, error) {
+	targets := make(map[string]float64)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tag, value, ok := strings.Cut(pair, "=")
+		tag = strings.TrimSpace(tag)
+		if !ok || tag == "" {
+			return nil, fmt.Errorf("invalid tag SLA target %q", pair)
+		}
+		target, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || target <= 0 || target > 100 {
+			return nil, fmt.Errorf("invalid SLA target for tag %q: %s", tag, value)
+		}
+		targets[tag] = target
+	}
+	return targets, nil
+}
+
 // GenerateReport creates an SLA report for the specified period
 func (s *SLAService) GenerateReport(ctx context.Context, title, period, generatedBy string) (*domain.SLAReport, error) {
 	start, end := s.parsePeriod(period)
@@ -76,12 +199,12 @@ func (s *SLAService) GenerateCustomReport(ctx context.Context, title, period str
 // generateSystemReport creates SLA metrics for a single system
 func (s *SLAService) generateSystemReport(ctx context.Context, system *domain.System, start, end time.Time) (*domain.SystemSLAReport, error) {
 	// Get uptime analytics
-	analytics, err := s.analyticsRepo.GetUptimeBySystemID(ctx, system.ID, start, end)
+	analytics, excluded, err := s.systemUptime(ctx, system.ID, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system analytics: %w", err)
 	}
 
-	slaTarget := system.GetSLATarget()
+	slaTarget := s.ResolveSLATarget(system)
 	uptimePercent := analytics.UptimePercent
 	slaMet := uptimePercent >= slaTarget
 
@@ -104,9 +227,9 @@ func (s *SLAService) generateSystemReport(ctx context.Context, system *domain.Sy
 	incidents, _ := s.analyticsRepo.GetIncidentsBySystemID(ctx, system.ID, start, end)
 	mttr := s.calculateMTTR(incidents)
 	longestOutage := s.findLongestOutage(incidents)
-	totalDowntime := s.calculateTotalDowntime(incidents)
+	totalDowntime := max(0, s.calculateTotalDowntime(incidents)-excluded)
 
-	return &domain.SystemSLAReport{
+	report := &domain.SystemSLAReport{
 		SystemID:          system.ID,
 		SystemName:        system.Name,
 		Owner:             system.Owner,
@@ -122,7 +245,11 @@ func (s *SLAService) generateSystemReport(ctx context.Context, system *domain.Sy
 		SLADelta:          uptimePercent - slaTarget,
 		StatusSummary:     domain.GetStatusSummary(uptimePercent, slaTarget),
 		DependencyReports: depReports,
-	}, nil
+		MaintenanceExcluded: excluded,
+	}
+	report.CalculateErrorBudget(end.Sub(start))
+
+	return report, nil
 }
 
 // generateDependencyReport creates SLA metrics for a dependency
@@ -136,6 +263,7 @@ func (s *SLAService) generateDependencyReport(ctx context.Context, dep *domain.D
 	report := &domain.DependencySLAReport{
 		DependencyID:       dep.ID,
 		DependencyName:     dep.Name,
+		Weight:             dep.Weight,
 		UptimePercent:      analytics.UptimePercent,
 		AvailabilityPercent: analytics.AvailabilityPercent,
 	}
@@ -239,12 +367,12 @@ func (s *SLAService) CheckForBreaches(ctx context.Context, period string) ([]*do
 	var breaches []*domain.SLABreachEvent
 
 	for _, system := range systems {
-		analytics, err := s.analyticsRepo.GetUptimeBySystemID(ctx, system.ID, start, end)
+		analytics, _, err := s.systemUptime(ctx, system.ID, start, end)
 		if err != nil {
 			continue
 		}
 
-		slaTarget := system.GetSLATarget()
+		slaTarget := s.ResolveSLATarget(system)
 
 		// Check uptime breach
 		if analytics.UptimePercent < slaTarget {
@@ -257,7 +385,7 @@ func (s *SLAService) CheckForBreaches(ctx context.Context, period string) ([]*do
 				Period:      period,
 				PeriodStart: start,
 				PeriodEnd:   end,
-				DetectedAt:  time.Now(),
+				DetectedAt:  s.currentTime(),
 			}
 
 			if err := s.breachRepo.Create(ctx, breach); err == nil {
@@ -269,11 +397,64 @@ func (s *SLAService) CheckForBreaches(ctx context.Context, period string) ([]*do
 				}
 			}
 		}
+
+		// Check response time breach
+		if system.ResponseTimeTargetMs > 0 {
+			p95, ok := s.systemP95Latency(ctx, system.ID, start, end)
+			if ok && p95 > system.ResponseTimeTargetMs {
+				breach := &domain.SLABreachEvent{
+					SystemID:    system.ID,
+					SystemName:  system.Name,
+					BreachType:  "response_time",
+					SLATarget:   float64(system.ResponseTimeTargetMs),
+					ActualValue: float64(p95),
+					Period:      period,
+					PeriodStart: start,
+					PeriodEnd:   end,
+					DetectedAt:  s.currentTime(),
+				}
+
+				if err := s.breachRepo.Create(ctx, breach); err == nil {
+					breaches = append(breaches, breach)
+
+					if s.notifService != nil {
+						s.notifService.NotifySLABreach(ctx, breach)
+					}
+				}
+			}
+		}
 	}
 
 	return breaches, nil
 }
 
+// systemP95Latency returns the p95 latency of a system over a period, taken
+// as the slowest p95 among its dependencies. ok is false when no dependency
+// has latency data for the period.
+func (s *SLAService) systemP95Latency(ctx context.Context, systemID int64, start, end time.Time) (p95 int64, ok bool) {
+	if s.latencyRepo == nil {
+		return 0, false
+	}
+
+	deps, err := s.depRepo.GetBySystemID(ctx, systemID)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, dep := range deps {
+		stats, err := s.latencyRepo.GetStats(ctx, dep.ID, start, end)
+		if err != nil || stats == nil || stats.TotalChecks == 0 {
+			continue
+		}
+		if !ok || stats.P95LatencyMs > p95 {
+			p95 = stats.P95LatencyMs
+			ok = true
+		}
+	}
+
+	return p95, ok
+}
+
 // GetBreaches retrieves SLA breaches
 func (s *SLAService) GetBreaches(ctx context.Context, limit int) ([]*domain.SLABreachEvent, error) {
 	return s.breachRepo.GetAll(ctx, limit)
@@ -294,6 +475,48 @@ func (s *SLAService) AcknowledgeBreach(ctx context.Context, breachID int64, acke
 	return s.breachRepo.Acknowledge(ctx, breachID, ackedBy)
 }
 
+// BreachAckFailure records a breach that could not be acknowledged
+type BreachAckFailure struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error"`
+}
+
+// BreachAckSummary reports the outcome of acknowledging breaches in bulk
+type BreachAckSummary struct {
+	Succeeded    int                `json:"succeeded"`
+	Failed       int                `json:"failed"`
+	Acknowledged []int64            `json:"acknowledged"`
+	Failures     []BreachAckFailure `json:"failures"`
+}
+
+// AcknowledgeBreaches acknowledges each breach in turn. A failure does not
+// stop the rest; it is reported in the summary. Duplicate IDs are
+// acknowledged once.
+func (s *SLAService) AcknowledgeBreaches(ctx context.Context, ids []int64, ackedBy string) *BreachAckSummary {
+	summary := &BreachAckSummary{
+		Acknowledged: []int64{},
+		Failures:     []BreachAckFailure{},
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if err := s.breachRepo.Acknowledge(ctx, id, ackedBy); err != nil {
+			summary.Failures = append(summary.Failures, BreachAckFailure{ID: id, Error: err.Error()})
+			continue
+		}
+		summary.Acknowledged = append(summary.Acknowledged, id)
+	}
+
+	summary.Succeeded = len(summary.Acknowledged)
+	summary.Failed = len(summary.Failures)
+	return summary
+}
+
 // GetSystemSLAStatus returns current SLA status for a system
 func (s *SLAService) GetSystemSLAStatus(ctx context.Context, systemID int64, period string) (*domain.SystemSLAReport, error) {
 	system, err := s.systemRepo.GetByID(ctx, systemID)
@@ -305,7 +528,45 @@ func (s *SLAService) GetSystemSLAStatus(ctx context.Context, systemID int64, per
 	}
 
 	start, end := s.parsePeriod(period)
-	return s.generateSystemReport(ctx, system, start, end)
+	report, err := s.generateSystemReport(ctx, system, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report.Basis = SLABasisSystem
+	report.Period = period
+	report.PeriodStart = start
+	report.PeriodEnd = end
+	return report, nil
+}
+
+// SLA bases for GetSystemSLAStatus
+const (
+	SLABasisSystem       = "system"       // the system's own status logs
+	SLABasisDependencies = "dependencies" // weighted dependency uptimes
+)
+
+// GetWeightedSystemSLAStatus returns SLA status for a system with uptime
+// derived from its dependencies' uptimes, weighted by dependency weight.
+// Systems without weighted dependencies fall back to their own status logs.
+func (s *SLAService) GetWeightedSystemSLAStatus(ctx context.Context, systemID int64, period string) (*domain.SystemSLAReport, error) {
+	report, err := s.GetSystemSLAStatus(ctx, systemID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	uptime, availability, ok := domain.WeightedDependencyUptime(report.DependencyReports)
+	if !ok {
+		return report, nil
+	}
+
+	report.Basis = SLABasisDependencies
+	report.UptimePercent = uptime
+	report.AvailabilityPercent = availability
+	report.SLAMet = uptime >= report.SLATarget
+	report.SLADelta = uptime - report.SLATarget
+	report.StatusSummary = domain.GetStatusSummary(uptime, report.SLATarget)
+	report.CalculateErrorBudget(report.PeriodEnd.Sub(report.PeriodStart))
+	return report, nil
 }
 
 // UpdateSystemSLATarget updates the SLA target for a system
@@ -322,9 +583,24 @@ func (s *SLAService) UpdateSystemSLATarget(ctx context.Context, systemID int64,
 	return s.systemRepo.Update(ctx, system)
 }
 
+// UpdateSystemResponseTimeTarget updates the p95 latency target of a system;
+// 0 removes it
+func (s *SLAService) UpdateSystemResponseTimeTarget(ctx context.Context, systemID int64, targetMs int64) error {
+	system, err := s.systemRepo.GetByID(ctx, systemID)
+	if err != nil {
+		return fmt.Errorf("failed to get system: %w", err)
+	}
+	if system == nil {
+		return fmt.Errorf("system not found")
+	}
+
+	system.SetResponseTimeTarget(targetMs)
+	return s.systemRepo.Update(ctx, system)
+}
+
 // parsePeriod converts period string to time range
 func (s *SLAService) parsePeriod(period string) (start, end time.Time) {
-	end = time.Now()
+	end = s.currentTime()
 
 	switch period {
 	case "daily", "1d":
@@ -333,7 +609,7 @@ func (s *SLAService) parsePeriod(period string) (start, end time.Time) {
 		start = end.Add(-7 * 24 * time.Hour)
 	case "monthly", "30d":
 		start = end.Add(-30 * 24 * time.Hour)
-	case "quarterly", "90d":
+	case "quarterly", "90d", "rolling90":
 		start = end.Add(-90 * 24 * time.Hour)
 	case "yearly", "365d":
 		start = end.Add(-365 * 24 * time.Hour)
@@ -26,6 +26,7 @@ func TestSLAService_parsePeriod(t *testing.T) {
 		{"30d", "30d", 30},
 		{"quarterly", "quarterly", 90},
 		{"90d", "90d", 90},
+		{"rolling90", "rolling90", 90},
 		{"yearly", "yearly", 365},
 		{"365d", "365d", 365},
 		{"default", "unknown", 30},
@@ -53,6 +54,20 @@ func TestSLAService_parsePeriod(t *testing.T) {
 	}
 }
 
+func TestSLAService_parsePeriod_Clock(t *testing.T) {
+	now := time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)
+	s := &SLAService{}
+	s.SetClock(ClockFunc(func() time.Time { return now }))
+
+	start, end := s.parsePeriod("weekly")
+	if !end.Equal(now) {
+		t.Errorf("end = %v, want %v", end, now)
+	}
+	if want := now.Add(-7 * 24 * time.Hour); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+}
+
 func TestSLAService_calculateMTTR(t *testing.T) {
 	s := &SLAService{}
 	now := time.Now()
@@ -380,6 +395,151 @@ func TestSLAService_GenerateCustomReport(t *testing.T) {
 	}
 }
 
+func TestSLAService_GenerateCustomReport_ErrorBudget(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	service := NewSLAService(systemRepo, NewMockDependencyRepository(), analyticsRepo,
+		NewMockSLAReportRepository(), NewMockSLABreachRepository(), NewMockLatencyRepository(), nil)
+
+	halfUsed, _ := domain.NewSystem("Half used", "", "", "")
+	halfUsed.SetSLATarget(99.9)
+	systemRepo.Create(ctx, halfUsed)
+
+	blown, _ := domain.NewSystem("Blown", "", "", "")
+	blown.SetSLATarget(99.9)
+	systemRepo.Create(ctx, blown)
+
+	uptimes := map[int64]float64{halfUsed.ID: 99.95, blown.ID: 99.7}
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: uptimes[systemID], AvailabilityPercent: 100}, nil
+	}
+
+	end := time.Now()
+	start := end.Add(-30 * 24 * time.Hour)
+	report, err := service.GenerateCustomReport(ctx, "Budget", "custom", start, end, "admin")
+	if err != nil {
+		t.Fatalf("GenerateCustomReport() error = %v", err)
+	}
+
+	byName := make(map[string]domain.SystemSLAReport)
+	for _, sr := range report.SystemReports {
+		byName[sr.SystemName] = sr
+	}
+
+	// 0.1% of 30 days is 43m12s
+	allowed := 43*time.Minute + 12*time.Second
+	for name, want := range map[string]struct {
+		consumed time.Duration
+		percent  float64
+	}{
+		"Half used": {allowed / 2, 50},
+		"Blown":     {3 * allowed, -200},
+	} {
+		sr := byName[name]
+		if d := sr.AllowedDowntime - allowed; d < -time.Millisecond || d > time.Millisecond {
+			t.Errorf("%s: AllowedDowntime = %v, want %v", name, sr.AllowedDowntime, allowed)
+		}
+		if d := sr.ConsumedDowntime - want.consumed; d < -time.Millisecond || d > time.Millisecond {
+			t.Errorf("%s: ConsumedDowntime = %v, want %v", name, sr.ConsumedDowntime, want.consumed)
+		}
+		if diff := sr.ErrorBudgetRemainingPercent - want.percent; diff < -0.01 || diff > 0.01 {
+			t.Errorf("%s: ErrorBudgetRemainingPercent = %.2f, want %.2f", name, sr.ErrorBudgetRemainingPercent, want.percent)
+		}
+	}
+	if byName["Blown"].ErrorBudgetRemaining >= 0 {
+		t.Errorf("expected negative remaining budget for blown system, got %v", byName["Blown"].ErrorBudgetRemaining)
+	}
+}
+
+func TestSLAService_ExcludeMaintenance(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	maintenanceRepo := NewMockMaintenanceRepository()
+	breachRepo := NewMockSLABreachRepository()
+	service := NewSLAService(systemRepo, NewMockDependencyRepository(), analyticsRepo,
+		NewMockSLAReportRepository(), breachRepo, NewMockLatencyRepository(), nil)
+	service.SetExcludeMaintenance(maintenanceRepo)
+
+	planned, _ := domain.NewSystem("Planned", "", "", "")
+	planned.SetSLATarget(99.9)
+	systemRepo.Create(ctx, planned)
+
+	cancelled, _ := domain.NewSystem("Cancelled", "", "", "")
+	cancelled.SetSLATarget(99.9)
+	systemRepo.Create(ctx, cancelled)
+
+	// Both systems were red for the same two hours, about 0.28% of 30 days
+	outageStart := time.Now().Add(-3 * 24 * time.Hour)
+	outageEnd := outageStart.Add(2 * time.Hour)
+	outageUptime := 100 - float64(2*time.Hour)/float64(30*24*time.Hour)*100
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{
+			UptimePercent:       outageUptime,
+			AvailabilityPercent: outageUptime,
+			TotalIncidents:      1,
+			ResolvedIncidents:   1,
+			TotalDowntime:       2 * time.Hour,
+			TotalUnavailable:    2 * time.Hour,
+		}, nil
+	}
+	analyticsRepo.GetIncidentsBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) ([]domain.IncidentPeriod, error) {
+		ended := outageEnd
+		return []domain.IncidentPeriod{{
+			StartedAt:   outageStart,
+			EndedAt:     &ended,
+			Duration:    2 * time.Hour,
+			MaxSeverity: domain.StatusRed,
+		}}, nil
+	}
+
+	// The planned system's outage is covered by its maintenance window; the
+	// other system's window was cancelled and must not be excluded
+	m1, _ := domain.NewMaintenance("Upgrade", "", outageStart.Add(-time.Hour), outageEnd.Add(time.Hour))
+	m1.SetSystemIDs([]int64{planned.ID})
+	maintenanceRepo.Create(ctx, m1)
+	m2, _ := domain.NewMaintenance("Called off", "", outageStart.Add(-time.Hour), outageEnd.Add(time.Hour))
+	m2.SetSystemIDs([]int64{cancelled.ID})
+	m2.Cancel()
+	maintenanceRepo.Create(ctx, m2)
+
+	status, err := service.GetSystemSLAStatus(ctx, planned.ID, "monthly")
+	if err != nil {
+		t.Fatalf("GetSystemSLAStatus() error = %v", err)
+	}
+	if status.UptimePercent != 100 || status.AvailabilityPercent != 100 {
+		t.Errorf("expected 100%% uptime and availability, got %.4f and %.4f", status.UptimePercent, status.AvailabilityPercent)
+	}
+	if status.MaintenanceExcluded != 2*time.Hour {
+		t.Errorf("MaintenanceExcluded = %v, want 2h", status.MaintenanceExcluded)
+	}
+	if status.TotalDowntime != 0 {
+		t.Errorf("TotalDowntime = %v, want 0", status.TotalDowntime)
+	}
+	if !status.SLAMet {
+		t.Error("expected SLA to be met when the outage was planned maintenance")
+	}
+
+	status, err = service.GetSystemSLAStatus(ctx, cancelled.ID, "monthly")
+	if err != nil {
+		t.Fatalf("GetSystemSLAStatus() error = %v", err)
+	}
+	if status.UptimePercent != outageUptime || status.MaintenanceExcluded != 0 {
+		t.Errorf("expected cancelled maintenance to count, got uptime %.4f excluded %v", status.UptimePercent, status.MaintenanceExcluded)
+	}
+
+	breaches, err := service.CheckForBreaches(ctx, "monthly")
+	if err != nil {
+		t.Fatalf("CheckForBreaches() error = %v", err)
+	}
+	if len(breaches) != 1 || breaches[0].SystemID != cancelled.ID {
+		t.Errorf("expected a single breach for the cancelled-maintenance system, got %+v", breaches)
+	}
+}
+
 func TestSLAService_GetReport(t *testing.T) {
 	ctx := context.Background()
 
@@ -505,6 +665,108 @@ func TestSLAService_CheckForBreaches(t *testing.T) {
 	}
 }
 
+func TestSLAService_CheckForBreaches_ResponseTime(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	latencyRepo := NewMockLatencyRepository()
+	breachRepo := NewMockSLABreachRepository()
+
+	service := NewSLAService(
+		systemRepo,
+		depRepo,
+		analyticsRepo,
+		nil,
+		breachRepo,
+		latencyRepo,
+		nil,
+	)
+
+	// Uptime within target so only the latency check can fire
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: 100, AvailabilityPercent: 100}, nil
+	}
+
+	slow, _ := domain.NewSystem("Slow API", "", "", "")
+	slow.SetResponseTimeTarget(200)
+	systemRepo.Create(ctx, slow)
+
+	fast, _ := domain.NewSystem("Fast API", "", "", "")
+	fast.SetResponseTimeTarget(500)
+	systemRepo.Create(ctx, fast)
+
+	untracked, _ := domain.NewSystem("No target", "", "", "")
+	systemRepo.Create(ctx, untracked)
+
+	p95s := make(map[int64]int64)
+	for _, d := range []struct {
+		system *domain.System
+		p95    int64
+	}{{slow, 120}, {slow, 350}, {fast, 350}, {untracked, 900}} {
+		dep, _ := domain.NewDependency(d.system.ID, "dep", "")
+		depRepo.Create(ctx, dep)
+		p95s[dep.ID] = d.p95
+	}
+
+	latencyRepo.GetStatsFunc = func(ctx context.Context, dependencyID int64, start, end time.Time) (*domain.LatencyStats, error) {
+		return &domain.LatencyStats{DependencyID: dependencyID, P95LatencyMs: p95s[dependencyID], TotalChecks: 100}, nil
+	}
+
+	breaches, err := service.CheckForBreaches(ctx, "monthly")
+	if err != nil {
+		t.Fatalf("CheckForBreaches() error = %v", err)
+	}
+
+	if len(breaches) != 1 {
+		t.Fatalf("expected 1 breach, got %d", len(breaches))
+	}
+
+	breach := breaches[0]
+	if breach.SystemID != slow.ID {
+		t.Errorf("SystemID = %d, want %d", breach.SystemID, slow.ID)
+	}
+	if breach.BreachType != "response_time" {
+		t.Errorf("BreachType = %s, want response_time", breach.BreachType)
+	}
+	if breach.SLATarget != 200 {
+		t.Errorf("SLATarget = %f, want 200", breach.SLATarget)
+	}
+	if breach.ActualValue != 350 {
+		t.Errorf("ActualValue = %f, want 350 (slowest dependency p95)", breach.ActualValue)
+	}
+}
+
+func TestSLAService_CheckForBreaches_ResponseTimeNoData(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	latencyRepo := NewMockLatencyRepository()
+	service := NewSLAService(systemRepo, depRepo, NewMockAnalyticsRepository(), nil,
+		NewMockSLABreachRepository(), latencyRepo, nil)
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.SetSLATarget(99)
+	system.SetResponseTimeTarget(100)
+	systemRepo.Create(ctx, system)
+	dep, _ := domain.NewDependency(system.ID, "DB", "")
+	depRepo.Create(ctx, dep)
+
+	latencyRepo.GetStatsFunc = func(ctx context.Context, dependencyID int64, start, end time.Time) (*domain.LatencyStats, error) {
+		return &domain.LatencyStats{DependencyID: dependencyID}, nil
+	}
+
+	breaches, err := service.CheckForBreaches(ctx, "monthly")
+	if err != nil {
+		t.Fatalf("CheckForBreaches() error = %v", err)
+	}
+	if len(breaches) != 0 {
+		t.Errorf("expected no breach without latency data, got %+v", breaches)
+	}
+}
+
 func TestSLAService_CheckForBreaches_NoBreaches(t *testing.T) {
 	ctx := context.Background()
 
@@ -731,6 +993,127 @@ func TestSLAService_GetSystemSLAStatus(t *testing.T) {
 	}
 }
 
+func TestSLAService_GetSystemSLAStatus_Rolling90(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	service := NewSLAService(systemRepo, NewMockDependencyRepository(), analyticsRepo, nil, nil, NewMockLatencyRepository(), nil)
+
+	system, _ := domain.NewSystem("API Gateway", "", "", "")
+	systemRepo.Create(ctx, system)
+
+	var gotStart, gotEnd time.Time
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		gotStart, gotEnd = start, end
+		return &domain.Analytics{UptimePercent: 99.95}, nil
+	}
+
+	status, err := service.GetSystemSLAStatus(ctx, system.ID, "rolling90")
+	if err != nil {
+		t.Fatalf("GetSystemSLAStatus() error = %v", err)
+	}
+
+	window := gotEnd.Sub(gotStart)
+	if window != 90*24*time.Hour {
+		t.Errorf("expected uptime over trailing 90 days, got %v", window)
+	}
+	if status.Period != "rolling90" {
+		t.Errorf("Period = %q, want rolling90", status.Period)
+	}
+	if !status.PeriodStart.Equal(gotStart) || !status.PeriodEnd.Equal(gotEnd) {
+		t.Errorf("expected status window %v - %v, got %v - %v", gotStart, gotEnd, status.PeriodStart, status.PeriodEnd)
+	}
+}
+
+func TestSLAService_GetWeightedSystemSLAStatus(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	service := NewSLAService(systemRepo, depRepo, analyticsRepo, nil, nil, NewMockLatencyRepository(), nil)
+
+	system, _ := domain.NewSystem("Checkout", "", "", "")
+	system.SetSLATarget(99.9)
+	systemRepo.Create(ctx, system)
+
+	// Critical database (weight 3) was down the whole period, cache (weight 1) was up
+	database, _ := domain.NewDependency(system.ID, "Database", "")
+	database.SetWeight(3)
+	depRepo.Create(ctx, database)
+	cache, _ := domain.NewDependency(system.ID, "Cache", "")
+	depRepo.Create(ctx, cache)
+
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: 100, AvailabilityPercent: 100}, nil
+	}
+	analyticsRepo.GetUptimeByDependencyIDFunc = func(ctx context.Context, dependencyID int64, start, end time.Time) (*domain.Analytics, error) {
+		if dependencyID == database.ID {
+			return &domain.Analytics{UptimePercent: 0, AvailabilityPercent: 0}, nil
+		}
+		return &domain.Analytics{UptimePercent: 100, AvailabilityPercent: 100}, nil
+	}
+
+	own, err := service.GetSystemSLAStatus(ctx, system.ID, "monthly")
+	if err != nil {
+		t.Fatalf("GetSystemSLAStatus() error = %v", err)
+	}
+	if own.UptimePercent != 100 || own.Basis != SLABasisSystem {
+		t.Fatalf("expected own status logs to show 100%%, got %.2f (basis %q)", own.UptimePercent, own.Basis)
+	}
+
+	weighted, err := service.GetWeightedSystemSLAStatus(ctx, system.ID, "monthly")
+	if err != nil {
+		t.Fatalf("GetWeightedSystemSLAStatus() error = %v", err)
+	}
+	if weighted.Basis != SLABasisDependencies {
+		t.Errorf("Basis = %q, want %q", weighted.Basis, SLABasisDependencies)
+	}
+	// 3/4 of the weight was down
+	if weighted.UptimePercent != 25 || weighted.AvailabilityPercent != 25 {
+		t.Errorf("expected weighted uptime 25%%, got uptime %.2f availability %.2f", weighted.UptimePercent, weighted.AvailabilityPercent)
+	}
+	if weighted.SLAMet {
+		t.Error("expected SLA to be breached by the down critical dependency")
+	}
+
+	// Lowering the database weight lessens its pull on the system SLA
+	database.SetWeight(1)
+	depRepo.Update(ctx, database)
+	weighted, _ = service.GetWeightedSystemSLAStatus(ctx, system.ID, "monthly")
+	if weighted.UptimePercent != 50 {
+		t.Errorf("expected weighted uptime 50%% with equal weights, got %.2f", weighted.UptimePercent)
+	}
+}
+
+func TestSLAService_GetWeightedSystemSLAStatus_NoWeightedDependencies(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	service := NewSLAService(systemRepo, depRepo, analyticsRepo, nil, nil, NewMockLatencyRepository(), nil)
+
+	system, _ := domain.NewSystem("Docs", "", "", "")
+	systemRepo.Create(ctx, system)
+	dep, _ := domain.NewDependency(system.ID, "CDN", "")
+	dep.SetWeight(0)
+	depRepo.Create(ctx, dep)
+
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: 99.5}, nil
+	}
+
+	status, err := service.GetWeightedSystemSLAStatus(ctx, system.ID, "monthly")
+	if err != nil {
+		t.Fatalf("GetWeightedSystemSLAStatus() error = %v", err)
+	}
+	if status.Basis != SLABasisSystem || status.UptimePercent != 99.5 {
+		t.Errorf("expected fallback to system status logs, got %.2f (basis %q)", status.UptimePercent, status.Basis)
+	}
+}
+
 func TestSLAService_GetSystemSLAStatus_NotFound(t *testing.T) {
 	ctx := context.Background()
 
@@ -894,6 +1277,15 @@ func (m *MockSLABreachRepository) GetByPeriod(ctx context.Context, start, end ti
 	return result, nil
 }
 
+func (m *MockSLABreachRepository) Cleanup(ctx context.Context, olderThan time.Time) error {
+	for id, b := range m.Breaches {
+		if b.Acknowledged && b.DetectedAt.Before(olderThan) {
+			delete(m.Breaches, id)
+		}
+	}
+	return nil
+}
+
 // ============= Float Comparison and Content Validation Tests =============
 
 func TestSLAService_CheckForBreaches_FloatComparison(t *testing.T) {
@@ -1183,3 +1575,90 @@ func TestSLAService_calculateMTTR_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestSLAService_ResolveSLATarget_TagInheritance(t *testing.T) {
+	service := NewSLAService(nil, nil, nil, nil, nil, nil, nil)
+	service.SetTagSLATargets(map[string]float64{
+		"production": 99.95,
+		"staging":    99.0,
+	})
+
+	inherited, _ := domain.NewSystem("API", "", "", "")
+	inherited.SetTags([]string{"production"})
+	if got := service.ResolveSLATarget(inherited); got != 99.95 {
+		t.Errorf("inherited target = %v, want 99.95", got)
+	}
+
+	explicit, _ := domain.NewSystem("Billing", "", "", "")
+	explicit.SetTags([]string{"production"})
+	explicit.SetSLATarget(99.5)
+	if got := service.ResolveSLATarget(explicit); got != 99.5 {
+		t.Errorf("explicit target = %v, want 99.5", got)
+	}
+
+	// Several matching tags: the strictest target applies
+	multi, _ := domain.NewSystem("Search", "", "", "")
+	multi.SetTags([]string{"staging", "production"})
+	if got := service.ResolveSLATarget(multi); got != 99.95 {
+		t.Errorf("multi-tag target = %v, want 99.95", got)
+	}
+
+	untagged, _ := domain.NewSystem("Docs", "", "", "")
+	if got := service.ResolveSLATarget(untagged); got != domain.DefaultSLATarget {
+		t.Errorf("untagged target = %v, want %v", got, domain.DefaultSLATarget)
+	}
+}
+
+func TestSLAService_CheckForBreaches_TagTarget(t *testing.T) {
+	ctx := context.Background()
+
+	systemRepo := NewMockSystemRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	breachRepo := NewMockSLABreachRepository()
+
+	service := NewSLAService(systemRepo, nil, analyticsRepo, nil, breachRepo, nil, nil)
+	service.SetTagSLATargets(map[string]float64{"staging": 98.0})
+
+	// 98.5% uptime breaches the default 99.9% but meets the staging target
+	system, _ := domain.NewSystem("Staging API", "", "", "")
+	system.SetTags([]string{"staging"})
+	systemRepo.Create(ctx, system)
+
+	analyticsRepo.GetUptimeBySystemIDFunc = func(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+		return &domain.Analytics{UptimePercent: 98.5}, nil
+	}
+
+	breaches, err := service.CheckForBreaches(ctx, "monthly")
+	if err != nil {
+		t.Fatalf("CheckForBreaches() error = %v", err)
+	}
+	if len(breaches) != 0 {
+		t.Errorf("expected tag target to apply, got %d breaches", len(breaches))
+	}
+
+	// An explicit target overrides the tag
+	system.SetSLATarget(99.0)
+	breaches, err = service.CheckForBreaches(ctx, "monthly")
+	if err != nil {
+		t.Fatalf("CheckForBreaches() error = %v", err)
+	}
+	if len(breaches) != 1 || breaches[0].SLATarget != 99.0 {
+		t.Errorf("expected 1 breach against explicit 99.0 target, got %+v", breaches)
+	}
+}
+
+func TestParseTagSLATargets(t *testing.T) {
+	targets, err := ParseTagSLATargets("production=99.95, staging = 99")
+	if err != nil {
+		t.Fatalf("ParseTagSLATargets() error = %v", err)
+	}
+	if targets["production"] != 99.95 || targets["staging"] != 99 {
+		t.Errorf("unexpected targets: %v", targets)
+	}
+
+	for _, spec := range []string{"production", "=99", "production=abc", "production=101"} {
+		if _, err := ParseTagSLATargets(spec); err == nil {
+			t.Errorf("ParseTagSLATargets(%q) expected error", spec)
+		}
+	}
+}
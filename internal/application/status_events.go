@@ -0,0 +1,173 @@
+package application
+
+import (
+	"sync"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// statusEventBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it
+const statusEventBuffer = 32
+
+// Live event types
+const (
+	LiveEventStatusChange = "status_change"
+	LiveEventIncident     = "incident"
+)
+
+// LiveEvent is a change pushed to live subscribers
+type LiveEvent struct {
+	Type string
+	// SystemIDs are the systems the event concerns; empty means all systems
+	SystemIDs []int64
+	// Data is the event payload, a StatusEvent or an IncidentEvent
+	Data interface{}
+}
+
+// Concerns reports whether the event is about one of the given systems.
+// An empty filter matches every event, and events without systems match
+// every filter.
+func (e LiveEvent) Concerns(systemIDs map[int64]bool) bool {
+	if len(systemIDs) == 0 || len(e.SystemIDs) == 0 {
+		return true
+	}
+	for _, id := range e.SystemIDs {
+		if systemIDs[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusEvent is the payload of a status change event
+type StatusEvent struct {
+	SystemID     int64     `json:"system_id"`
+	DependencyID *int64    `json:"dependency_id,omitempty"`
+	OldStatus    string    `json:"old_status"`
+	NewStatus    string    `json:"new_status"`
+	Message      string    `json:"message"`
+	Source       string    `json:"source"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// IncidentEvent is the payload of an incident event
+type IncidentEvent struct {
+	Event      string    `json:"event"` // incident_start, incident_updated or incident_end
+	IncidentID int64     `json:"incident_id"`
+	Title      string    `json:"title"`
+	Status     string    `json:"status"`
+	Severity   string    `json:"severity"`
+	SystemIDs  []int64   `json:"system_ids,omitempty"`
+	Message    string    `json:"message,omitempty"` // latest timeline entry
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// StatusEventBroker fans status changes and incident events out to
+// in-process subscribers such as the live event stream. Publishing never blocks: a subscriber that is
+// not keeping up misses events rather than stalling status updates.
+type StatusEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan LiveEvent]struct{}
+	closed      bool
+}
+
+// NewStatusEventBroker creates a new StatusEventBroker
+func NewStatusEventBroker() *StatusEventBroker {
+	return &StatusEventBroker{
+		subscribers: make(map[chan LiveEvent]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber. The channel is closed when the returned
+// function is called or the broker is closed; the function is safe to call
+// more than once.
+func (b *StatusEventBroker) Subscribe() (<-chan LiveEvent, func()) {
+	ch := make(chan LiveEvent, statusEventBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close ends every subscription so long-lived streams finish, e.g. on
+// shutdown. Later subscriptions are closed immediately.
+func (b *StatusEventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *StatusEventBroker) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Publish sends an event to every subscriber
+func (b *StatusEventBroker) Publish(event LiveEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// PublishStatusChange publishes a status log entry of a system, or of a
+// dependency belonging to systemID
+func (b *StatusEventBroker) PublishStatusChange(log *domain.StatusLog, systemID int64) {
+	b.Publish(LiveEvent{
+		Type:      LiveEventStatusChange,
+		SystemIDs: []int64{systemID},
+		Data: StatusEvent{
+			SystemID:     systemID,
+			DependencyID: log.DependencyID,
+			OldStatus:    log.OldStatus.String(),
+			NewStatus:    log.NewStatus.String(),
+			Message:      log.Message,
+			Source:       string(log.Source),
+			Timestamp:    log.CreatedAt,
+		},
+	})
+}
+
+// PublishIncident publishes an incident event with its latest timeline
+// entry, which may be nil
+func (b *StatusEventBroker) PublishIncident(incident *domain.Incident, update *domain.IncidentUpdate, event domain.WebhookEvent) {
+	payload := IncidentEvent{
+		Event:      string(event),
+		IncidentID: incident.ID,
+		Title:      incident.Title,
+		Status:     string(incident.Status),
+		Severity:   string(incident.Severity),
+		SystemIDs:  incident.SystemIDs,
+		Timestamp:  incident.UpdatedAt,
+	}
+	if update != nil {
+		payload.Message = update.Message
+		payload.Timestamp = update.CreatedAt
+	}
+	b.Publish(LiveEvent{Type: LiveEventIncident, SystemIDs: incident.SystemIDs, Data: payload})
+}
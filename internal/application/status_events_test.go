@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func TestStatusEventBroker_PublishAndUnsubscribe(t *testing.T) {
+	broker := NewStatusEventBroker()
+	events, unsubscribe := broker.Subscribe()
+
+	depID := int64(7)
+	broker.PublishStatusChange(domain.NewStatusLog(nil, &depID, domain.StatusGreen, domain.StatusRed, "down", domain.SourceHeartbeat), 3)
+
+	live := <-events
+	event, ok := live.Data.(StatusEvent)
+	if live.Type != LiveEventStatusChange || !ok {
+		t.Fatalf("unexpected event %+v", live)
+	}
+	if event.SystemID != 3 || event.DependencyID == nil || *event.DependencyID != 7 {
+		t.Errorf("unexpected event target %+v", event)
+	}
+	if event.OldStatus != "green" || event.NewStatus != "red" || event.Source != "heartbeat" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("expected the channel to be closed after unsubscribing")
+	}
+	if n := broker.SubscriberCount(); n != 0 {
+		t.Errorf("expected no subscribers, got %d", n)
+	}
+}
+
+func TestStatusEventBroker_SlowSubscriberDoesNotBlock(t *testing.T) {
+	broker := NewStatusEventBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < statusEventBuffer*2; i++ {
+		broker.Publish(LiveEvent{Type: LiveEventStatusChange})
+	}
+	if len(events) != statusEventBuffer {
+		t.Errorf("expected %d buffered events, got %d", statusEventBuffer, len(events))
+	}
+}
+
+func TestStatusEventBroker_Close(t *testing.T) {
+	broker := NewStatusEventBroker()
+	events, unsubscribe := broker.Subscribe()
+	broker.Close()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Error("expected Close to end the subscription")
+	}
+	late, _ := broker.Subscribe()
+	if _, ok := <-late; ok {
+		t.Error("expected subscriptions after Close to be closed")
+	}
+}
+
+func TestSystemService_PublishesStatusEvents(t *testing.T) {
+	ctx := context.Background()
+	systemRepo := NewMockSystemRepository()
+	service := NewSystemService(systemRepo, NewMockStatusLogRepository())
+	broker := NewStatusEventBroker()
+	service.SetEventBroker(broker)
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	system, _ := service.CreateSystem(ctx, "API", "", "", "")
+	if _, err := service.UpdateSystemStatus(ctx, system.ID, "yellow", "slow"); err != nil {
+		t.Fatalf("UpdateSystemStatus() error = %v", err)
+	}
+	// Unchanged status publishes nothing
+	service.UpdateSystemStatus(ctx, system.ID, "yellow", "still slow")
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if event := (<-events).Data.(StatusEvent); event.SystemID != system.ID || event.NewStatus != "yellow" || event.Message != "slow" {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
+
+func TestIncidentService_PublishesIncidentEvents(t *testing.T) {
+	ctx := context.Background()
+	service := NewIncidentService(NewMockIncidentRepository())
+	broker := NewStatusEventBroker()
+	service.SetEventBroker(broker)
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	incident, err := service.CreateIncident(ctx, "Checkout down", "Payments failing", domain.SeverityCritical, []int64{2})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	service.AddIncidentUpdate(ctx, incident.ID, "Typo fix", "alice", false)
+	service.ResolveIncident(ctx, incident.ID, "", "alice")
+
+	want := []string{"incident_start", "incident_updated", "incident_end"}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for _, name := range want {
+		live := <-events
+		event := live.Data.(IncidentEvent)
+		if live.Type != LiveEventIncident || event.Event != name || event.IncidentID != incident.ID {
+			t.Errorf("expected %s for incident %d, got %+v", name, incident.ID, event)
+		}
+		if !live.Concerns(map[int64]bool{2: true}) || live.Concerns(map[int64]bool{3: true}) {
+			t.Errorf("expected %s to concern only system 2", name)
+		}
+	}
+}
+
+func TestLiveEvent_Concerns(t *testing.T) {
+	tests := []struct {
+		name    string
+		systems []int64
+		filter  map[int64]bool
+		want    bool
+	}{
+		{"no filter", []int64{1}, nil, true},
+		{"matching system", []int64{1, 2}, map[int64]bool{2: true}, true},
+		{"other system", []int64{1}, map[int64]bool{2: true}, false},
+		{"event for all systems", nil, map[int64]bool{2: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (LiveEvent{SystemIDs: tt.systems}).Concerns(tt.filter); got != tt.want {
+				t.Errorf("Concerns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 )
 
 // StatusPropagationService handles propagating dependency status changes to parent systems
+// and from upstream systems to the systems that depend on them
 type StatusPropagationService struct {
 	systemRepo          domain.SystemRepository
 	depRepo             domain.DependencyRepository
+	systemDepRepo       domain.SystemDependencyRepository
 	logRepo             domain.StatusLogRepository
 	notificationService *NotificationService
+	eventBroker         *StatusEventBroker
 }
 
 // NewStatusPropagationService creates a new StatusPropagationService
@@ -32,7 +35,19 @@ func (s *StatusPropagationService) SetNotificationService(ns *NotificationServic
 	s.notificationService = ns
 }
 
-// PropagateStatusToSystem updates a system's status based on its dependencies' statuses.
+// SetEventBroker publishes status changes to live subscribers
+func (s *StatusPropagationService) SetEventBroker(b *StatusEventBroker) {
+	s.eventBroker = b
+}
+
+// SetSystemDependencyRepo enables propagation between systems along the system graph
+func (s *StatusPropagationService) SetSystemDependencyRepo(repo domain.SystemDependencyRepository) {
+	s.systemDepRepo = repo
+}
+
+// PropagateStatusToSystem updates a system's status based on its dependencies' statuses,
+// weighted by their criticality (see domain.PropagatedStatus), and on the systems it
+// depends on. A change is passed on to dependent systems in turn.
 // Returns true if the system status was changed, false otherwise.
 func (s *StatusPropagationService) PropagateStatusToSystem(ctx context.Context, systemID int64) (bool, error) {
 	// Get the system
@@ -50,19 +65,18 @@ func (s *StatusPropagationService) PropagateStatusToSystem(ctx context.Context,
 		return false, fmt.Errorf("failed to get dependencies: %w", err)
 	}
 
-	// If no dependencies, nothing to propagate
-	if len(deps) == 0 {
-		return false, nil
+	upstream, err := s.upstreamStatuses(ctx, systemID)
+	if err != nil {
+		return false, err
 	}
 
-	// Collect all dependency statuses
-	statuses := make([]domain.Status, len(deps))
-	for i, dep := range deps {
-		statuses[i] = dep.Status
+	// If no dependencies, nothing to propagate
+	if len(deps) == 0 && len(upstream) == 0 {
+		return false, nil
 	}
 
-	// Calculate aggregate status (worst-case)
-	aggregateStatus := domain.MaxSeverityStatus(statuses)
+	// Calculate aggregate status, weighted by dependency criticality
+	aggregateStatus := domain.MaxSeverityStatus(append(upstream, domain.SystemStatusFromDependencies(deps)))
 
 	// Check if status changed
 	oldStatus := system.Status
@@ -80,7 +94,10 @@ func (s *StatusPropagationService) PropagateStatusToSystem(ctx context.Context,
 	}
 
 	// Create status log
-	message := fmt.Sprintf("Status propagated from dependencies (worst-case: %s)", aggregateStatus)
+	message := fmt.Sprintf("Status propagated from dependencies (weighted by criticality: %s)", aggregateStatus)
+	if len(upstream) > 0 {
+		message = fmt.Sprintf("Status propagated from dependencies and upstream systems (weighted by criticality: %s)", aggregateStatus)
+	}
 	statusLog := domain.NewStatusLog(&systemID, nil, oldStatus, aggregateStatus, message, domain.SourcePropagation)
 	if err := s.logRepo.Create(ctx, statusLog); err != nil {
 		fmt.Printf("failed to log propagated status change: %v\n", err)
@@ -90,6 +107,57 @@ func (s *StatusPropagationService) PropagateStatusToSystem(ctx context.Context,
 	if s.notificationService != nil {
 		go s.notificationService.NotifyStatusChange(ctx, statusLog)
 	}
+	if s.eventBroker != nil {
+		s.eventBroker.PublishStatusChange(statusLog, systemID)
+	}
+
+	s.PropagateToDependents(ctx, systemID)
 
 	return true, nil
 }
+
+// PropagateToDependents re-evaluates every system that depends on systemID.
+// The graph is kept acyclic, so the recursion always terminates.
+func (s *StatusPropagationService) PropagateToDependents(ctx context.Context, systemID int64) {
+	if s.systemDepRepo == nil {
+		return
+	}
+
+	dependents, err := s.systemDepRepo.GetDependents(ctx, systemID)
+	if err != nil {
+		fmt.Printf("failed to get dependents of system %d: %v\n", systemID, err)
+		return
+	}
+
+	for _, edge := range dependents {
+		if _, err := s.PropagateStatusToSystem(ctx, edge.SystemID); err != nil {
+			fmt.Printf("failed to propagate status to system %d: %v\n", edge.SystemID, err)
+		}
+	}
+}
+
+// upstreamStatuses returns the status each system that systemID depends on
+// passes on to it (see domain.SystemDependencyCriticality)
+func (s *StatusPropagationService) upstreamStatuses(ctx context.Context, systemID int64) ([]domain.Status, error) {
+	if s.systemDepRepo == nil {
+		return nil, nil
+	}
+
+	edges, err := s.systemDepRepo.GetBySystemID(ctx, systemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system dependencies: %w", err)
+	}
+
+	var statuses []domain.Status
+	for _, edge := range edges {
+		upstream, err := s.systemRepo.GetByID(ctx, edge.DependsOnID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system: %w", err)
+		}
+		if upstream == nil {
+			continue
+		}
+		statuses = append(statuses, domain.PropagatedStatus(upstream.Status, domain.SystemDependencyCriticality))
+	}
+	return statuses, nil
+}
@@ -156,6 +156,43 @@ func TestStatusPropagationService_PropagateStatusToSystem_OneRed(t *testing.T) {
 	}
 }
 
+func TestStatusPropagationService_PropagateStatusToSystem_WeightedByCriticality(t *testing.T) {
+	systemRepo := NewMockSystemRepository()
+	system, _ := domain.NewSystem("API", "API Service", "https://api.example.com", "team@example.com")
+	system.ID = 1
+	system.Status = domain.StatusGreen
+	systemRepo.Systems[1] = system
+
+	depRepo := NewMockDependencyRepository()
+	dep1, _ := domain.NewDependency(1, "Analytics", "Tracking")
+	dep1.ID = 1
+	dep1.Status = domain.StatusRed
+	dep1.SetCriticality(domain.CriticalityMinor)
+	dep2, _ := domain.NewDependency(1, "PostgreSQL", "Database")
+	dep2.ID = 2
+	dep2.Status = domain.StatusGreen
+	depRepo.Dependencies[1] = dep1
+	depRepo.Dependencies[2] = dep2
+
+	logRepo := NewMockStatusLogRepository()
+	service := NewStatusPropagationService(systemRepo, depRepo, logRepo)
+
+	if _, err := service.PropagateStatusToSystem(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if system.Status != domain.StatusYellow {
+		t.Errorf("expected minor red dependency to degrade system to yellow, got %q", system.Status)
+	}
+
+	dep2.Status = domain.StatusRed
+	if _, err := service.PropagateStatusToSystem(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if system.Status != domain.StatusRed {
+		t.Errorf("expected critical red dependency to turn system red, got %q", system.Status)
+	}
+}
+
 func TestStatusPropagationService_PropagateStatusToSystem_RedOverridesYellow(t *testing.T) {
 	systemRepo := NewMockSystemRepository()
 	system, _ := domain.NewSystem("API", "API Service", "https://api.example.com", "team@example.com")
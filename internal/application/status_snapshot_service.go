@@ -0,0 +1,149 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// PublicStatusSummary is the public status page in machine-readable form
+type PublicStatusSummary struct {
+	Systems   []PublicSystemStatus `json:"systems"`
+	UpdatedAt string               `json:"updated_at"`
+}
+
+// PublicSystemStatus is a system as shown on the public status page
+type PublicSystemStatus struct {
+	ID           int64                    `json:"id"`
+	Name         string                   `json:"name"`
+	Description  string                   `json:"description,omitempty"`
+	Status       string                   `json:"status"`
+	Dependencies []PublicDependencyStatus `json:"dependencies"`
+}
+
+// PublicDependencyStatus is a dependency as shown on the public status page
+type PublicDependencyStatus struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// NewPublicStatusSummary builds the public summary for systems already in
+// display order; deps maps system IDs to their dependencies
+func NewPublicStatusSummary(systems []*domain.System, deps map[int64][]*domain.Dependency, updatedAt time.Time) *PublicStatusSummary {
+	summary := &PublicStatusSummary{
+		Systems:   make([]PublicSystemStatus, 0, len(systems)),
+		UpdatedAt: updatedAt.Format(time.RFC3339),
+	}
+	for _, sys := range systems {
+		sysDeps := make([]PublicDependencyStatus, 0, len(deps[sys.ID]))
+		for _, dep := range deps[sys.ID] {
+			sysDeps = append(sysDeps, PublicDependencyStatus{
+				ID:     dep.ID,
+				Name:   dep.Name,
+				Status: dep.Status.String(),
+			})
+		}
+		summary.Systems = append(summary.Systems, PublicSystemStatus{
+			ID:           sys.ID,
+			Name:         sys.Name,
+			Description:  sys.Description,
+			Status:       sys.Status.String(),
+			Dependencies: sysDeps,
+		})
+	}
+	return summary
+}
+
+// StatusSnapshotService writes the public status summary to a static file,
+// e.g. for CDN-fronted status pages
+type StatusSnapshotService struct {
+	systemRepo domain.SystemRepository
+	depRepo    domain.DependencyRepository
+	path       string
+	clock      Clock
+
+	lastSystems []byte // systems JSON of the last written snapshot
+}
+
+// NewStatusSnapshotService creates a new StatusSnapshotService
+func NewStatusSnapshotService(systemRepo domain.SystemRepository, depRepo domain.DependencyRepository, path string) *StatusSnapshotService {
+	return &StatusSnapshotService{
+		systemRepo: systemRepo,
+		depRepo:    depRepo,
+		path:       path,
+		clock:      SystemClock{},
+	}
+}
+
+// SetClock sets the clock that stamps status snapshots
+func (s *StatusSnapshotService) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// WriteIfChanged writes the snapshot when the public status differs from the
+// last written one. It returns true if the file was written.
+func (s *StatusSnapshotService) WriteIfChanged(ctx context.Context) (bool, error) {
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get systems: %w", err)
+	}
+	domain.SortForDisplay(systems)
+
+	deps := make(map[int64][]*domain.Dependency, len(systems))
+	for _, sys := range systems {
+		sysDeps, err := s.depRepo.GetBySystemID(ctx, sys.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get dependencies: %w", err)
+		}
+		deps[sys.ID] = sysDeps
+	}
+
+	summary := NewPublicStatusSummary(systems, deps, s.clock.Now())
+
+	// Compare without the timestamp, which changes on every run
+	systemsJSON, err := json.Marshal(summary.Systems)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if s.lastSystems != nil && bytes.Equal(systemsJSON, s.lastSystems) {
+		return false, nil
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	// Write to a temp file and rename so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return false, fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return false, fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+
+	s.lastSystems = systemsJSON
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+func readSnapshot(t *testing.T, path string) PublicStatusSummary {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	var summary PublicStatusSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	return summary
+}
+
+func TestStatusSnapshotService_WriteIfChanged(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "status.json")
+
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+
+	system, _ := domain.NewSystem("API", "Public API", "", "")
+	systemRepo.Create(ctx, system)
+	dep, _ := domain.NewDependency(system.ID, "Database", "")
+	depRepo.Create(ctx, dep)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewStatusSnapshotService(systemRepo, depRepo, path)
+	service.SetClock(ClockFunc(func() time.Time { return now }))
+
+	written, err := service.WriteIfChanged(ctx)
+	if err != nil {
+		t.Fatalf("WriteIfChanged() error = %v", err)
+	}
+	if !written {
+		t.Fatal("expected initial snapshot to be written")
+	}
+	summary := readSnapshot(t, path)
+	if len(summary.Systems) != 1 || summary.Systems[0].Status != "green" {
+		t.Fatalf("unexpected initial snapshot: %+v", summary)
+	}
+
+	// Nothing changed: the file is left alone
+	now = now.Add(time.Minute)
+	written, err = service.WriteIfChanged(ctx)
+	if err != nil {
+		t.Fatalf("WriteIfChanged() error = %v", err)
+	}
+	if written {
+		t.Error("expected no write without a status change")
+	}
+
+	// A status change updates the file
+	system.UpdateStatus(domain.StatusRed)
+	dep.UpdateStatus(domain.StatusYellow)
+	now = now.Add(time.Minute)
+	written, err = service.WriteIfChanged(ctx)
+	if err != nil {
+		t.Fatalf("WriteIfChanged() error = %v", err)
+	}
+	if !written {
+		t.Fatal("expected snapshot to be rewritten after a status change")
+	}
+
+	summary = readSnapshot(t, path)
+	if summary.Systems[0].Status != "red" {
+		t.Errorf("system status = %s, want red", summary.Systems[0].Status)
+	}
+	if len(summary.Systems[0].Dependencies) != 1 || summary.Systems[0].Dependencies[0].Status != "yellow" {
+		t.Errorf("unexpected dependencies: %+v", summary.Systems[0].Dependencies)
+	}
+	if summary.UpdatedAt != now.Format(time.RFC3339) {
+		t.Errorf("updated_at = %s, want %s", summary.UpdatedAt, now.Format(time.RFC3339))
+	}
+}
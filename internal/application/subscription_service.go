@@ -0,0 +1,227 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"net/url"
+	"strings"
+
+	"status-incident/internal/domain"
+)
+
+var ErrSubscriberNotFound = errors.New("subscription not found")
+
+// SubscriptionService manages end-user email subscriptions to incidents.
+// Subscriptions are double opt-in: only confirmed addresses receive mail.
+type SubscriptionService struct {
+	subscriberRepo domain.SubscriberRepository
+	systemRepo     domain.SystemRepository
+	smtpConfig     *domain.EmailConfig
+	publicURL      string
+	sendMail       MailSender
+}
+
+// NewSubscriptionService creates a new SubscriptionService that sends mail
+// through smtpConfig and links back to publicURL
+func NewSubscriptionService(
+	subscriberRepo domain.SubscriberRepository,
+	systemRepo domain.SystemRepository,
+	smtpConfig *domain.EmailConfig,
+	publicURL string,
+) *SubscriptionService {
+	return &SubscriptionService{
+		subscriberRepo: subscriberRepo,
+		systemRepo:     systemRepo,
+		smtpConfig:     smtpConfig,
+		publicURL:      strings.TrimRight(publicURL, "/"),
+		sendMail:       smtp.SendMail,
+	}
+}
+
+// SetMailSender replaces the SMTP client used for subscriber mail
+func (s *SubscriptionService) SetMailSender(sender MailSender) {
+	s.sendMail = sender
+}
+
+// Subscribe registers an address and mails it a confirmation link. Asking
+// again before confirming updates the system filter and resends the link;
+// confirmed subscriptions are left unchanged.
+func (s *SubscriptionService) Subscribe(ctx context.Context, email string, systemIDs []int64) (*domain.Subscriber, error) {
+	subscriber, err := domain.NewSubscriber(email, systemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range systemIDs {
+		system, err := s.systemRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system: %w", err)
+		}
+		if system == nil {
+			return nil, fmt.Errorf("system not found: %d", id)
+		}
+	}
+
+	existing, err := s.subscriberRepo.GetByEmail(ctx, subscriber.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriber: %w", err)
+	}
+
+	switch {
+	case existing != nil && existing.Confirmed:
+		return existing, nil
+	case existing != nil:
+		existing.SystemIDs = systemIDs
+		if err := s.subscriberRepo.Update(ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to update subscriber: %w", err)
+		}
+		subscriber = existing
+	default:
+		if err := s.subscriberRepo.Create(ctx, subscriber); err != nil {
+			return nil, fmt.Errorf("failed to create subscriber: %w", err)
+		}
+	}
+
+	title := "Confirm your status updates subscription"
+	text, htmlBody := formatEmailBodies(title, cardColorBlue, []emailField{
+		{"Confirm", s.link("/api/subscribe/confirm", subscriber.Token)},
+		{"Note", "You will not receive any updates until you confirm. If you did not ask for this, ignore this email."},
+	})
+	if err := s.send(subscriber.Email, title, text, htmlBody); err != nil {
+		return nil, fmt.Errorf("failed to send confirmation: %w", err)
+	}
+
+	return subscriber, nil
+}
+
+// Confirm verifies the address behind token
+func (s *SubscriptionService) Confirm(ctx context.Context, token string) (*domain.Subscriber, error) {
+	subscriber, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscriber.Confirmed {
+		subscriber.Confirm()
+		if err := s.subscriberRepo.Update(ctx, subscriber); err != nil {
+			return nil, fmt.Errorf("failed to update subscriber: %w", err)
+		}
+	}
+
+	return subscriber, nil
+}
+
+// Unsubscribe removes the subscription behind token
+func (s *SubscriptionService) Unsubscribe(ctx context.Context, token string) error {
+	subscriber, err := s.getByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.subscriberRepo.Delete(ctx, subscriber.ID); err != nil {
+		return fmt.Errorf("failed to delete subscriber: %w", err)
+	}
+	return nil
+}
+
+// NotifyIncident emails an opened (EventIncidentStart) or resolved
+// (EventIncidentEnd) incident to confirmed subscribers of the affected systems
+func (s *SubscriptionService) NotifyIncident(ctx context.Context, incident *domain.Incident, event domain.WebhookEvent) {
+	subscribers, err := s.subscriberRepo.GetConfirmed(ctx)
+	if err != nil {
+		slog.Error("failed to get subscribers", "error", err)
+		return
+	}
+
+	var recipients []*domain.Subscriber
+	for _, subscriber := range subscribers {
+		if subscriber.WantsSystems(incident.SystemIDs) {
+			recipients = append(recipients, subscriber)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	title := "Incident: " + incident.Title
+	color := incidentCardColor(incident)
+	message := incident.Message
+	if event == domain.EventIncidentEnd {
+		title = "Resolved: " + incident.Title
+		color = cardColorGreen
+		message = incident.Postmortem
+	}
+
+	fields := []emailField{
+		{"Severity", string(incident.Severity)},
+		{"Status", string(incident.Status)},
+		{"Affected", strings.Join(s.systemNames(ctx, incident.SystemIDs), ", ")},
+		{"Message", message},
+	}
+	if s.publicURL != "" {
+		fields = append(fields, emailField{"Status page", s.publicURL + "/status"})
+	}
+
+	go func() {
+		for _, subscriber := range recipients {
+			text, htmlBody := formatEmailBodies(title, color, append(fields,
+				emailField{"Unsubscribe", s.link("/api/subscribe/unsubscribe", subscriber.Token)}))
+			if err := s.send(subscriber.Email, title, text, htmlBody); err != nil {
+				slog.Error("failed to email subscriber", "subscriber_id", subscriber.ID, "error", err)
+			}
+		}
+	}()
+}
+
+// getByToken returns the subscriber behind token or ErrSubscriberNotFound
+func (s *SubscriptionService) getByToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	if token == "" {
+		return nil, ErrSubscriberNotFound
+	}
+
+	subscriber, err := s.subscriberRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriber: %w", err)
+	}
+	if subscriber == nil {
+		return nil, ErrSubscriberNotFound
+	}
+	return subscriber, nil
+}
+
+// systemNames resolves system IDs to names; no IDs means all systems
+func (s *SubscriptionService) systemNames(ctx context.Context, ids []int64) []string {
+	var names []string
+	for _, id := range ids {
+		if system, err := s.systemRepo.GetByID(ctx, id); err == nil && system != nil {
+			names = append(names, system.Name)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"all systems"}
+	}
+	return names
+}
+
+// link builds a public URL carrying a subscriber token
+func (s *SubscriptionService) link(path, token string) string {
+	return s.publicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// send mails one message to a single subscriber
+func (s *SubscriptionService) send(to, subject, text, htmlBody string) error {
+	msg, err := composeEmail(s.smtpConfig.From, []string{to}, subject, text, htmlBody)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", s.smtpConfig.Username, s.smtpConfig.Password, s.smtpConfig.Host)
+	}
+
+	return s.sendMail(s.smtpConfig.Addr(), auth, s.smtpConfig.From, []string{to}, msg)
+}
@@ -2,15 +2,28 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"status-incident/internal/domain"
 )
 
+// ErrSlugTaken is returned when another system already uses a slug
+var ErrSlugTaken = errors.New("slug is already used by another system")
+
 // SystemService handles system-related use cases
 type SystemService struct {
 	systemRepo          domain.SystemRepository
 	logRepo             domain.StatusLogRepository
+	systemDepRepo       domain.SystemDependencyRepository
 	notificationService *NotificationService
+	propagationService  *StatusPropagationService
+	eventBroker         *StatusEventBroker
+}
+
+// SystemGraph is every system together with the dependencies between them
+type SystemGraph struct {
+	Systems []*domain.System
+	Edges   []*domain.SystemDependency
 }
 
 // NewSystemService creates a new SystemService
@@ -26,8 +39,26 @@ func (s *SystemService) SetNotificationService(ns *NotificationService) {
 	s.notificationService = ns
 }
 
+// SetEventBroker publishes status changes to live subscribers
+func (s *SystemService) SetEventBroker(b *StatusEventBroker) {
+	s.eventBroker = b
+}
+
+// SetSystemDependencyRepo enables dependencies between systems
+func (s *SystemService) SetSystemDependencyRepo(repo domain.SystemDependencyRepository) {
+	s.systemDepRepo = repo
+}
+
+// SetPropagationService sets the propagation service for propagating status to dependent systems
+func (s *SystemService) SetPropagationService(ps *StatusPropagationService) {
+	s.propagationService = ps
+}
+
 // CreateSystem creates a new system
 func (s *SystemService) CreateSystem(ctx context.Context, name, description, url, owner string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.CreateSystem")
+	defer span.End()
+
 	system, err := domain.NewSystem(name, description, url, owner)
 	if err != nil {
 		return nil, fmt.Errorf("invalid system data: %w", err)
@@ -42,6 +73,9 @@ func (s *SystemService) CreateSystem(ctx context.Context, name, description, url
 
 // GetSystem retrieves a system by ID
 func (s *SystemService) GetSystem(ctx context.Context, id int64) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.GetSystem")
+	defer span.End()
+
 	system, err := s.systemRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system: %w", err)
@@ -51,6 +85,9 @@ func (s *SystemService) GetSystem(ctx context.Context, id int64) (*domain.System
 
 // GetAllSystems retrieves all systems
 func (s *SystemService) GetAllSystems(ctx context.Context) ([]*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.GetAllSystems")
+	defer span.End()
+
 	systems, err := s.systemRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get systems: %w", err)
@@ -60,6 +97,9 @@ func (s *SystemService) GetAllSystems(ctx context.Context) ([]*domain.System, er
 
 // UpdateSystem updates system name, description, url and owner
 func (s *SystemService) UpdateSystem(ctx context.Context, id int64, name, description, url, owner string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.UpdateSystem")
+	defer span.End()
+
 	system, err := s.systemRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system: %w", err)
@@ -79,8 +119,117 @@ func (s *SystemService) UpdateSystem(ctx context.Context, id int64, name, descri
 	return system, nil
 }
 
+// GetSystemBySlug retrieves the system with the given public page slug
+func (s *SystemService) GetSystemBySlug(ctx context.Context, slug string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.GetSystemBySlug")
+	defer span.End()
+
+	system, err := s.systemRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+	return system, nil
+}
+
+// CheckSystemSlug returns domain.ErrInvalidSlug or ErrSlugTaken if the
+// system with the given ID (0 for a new one) can't use slug
+func (s *SystemService) CheckSystemSlug(ctx context.Context, id int64, slug string) error {
+	var probe domain.System
+	if err := probe.SetSlug(slug); err != nil {
+		return err
+	}
+	if probe.Slug == "" {
+		return nil
+	}
+
+	existing, err := s.systemRepo.GetBySlug(ctx, probe.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to get system: %w", err)
+	}
+	if existing != nil && existing.ID != id {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// UpdateSystemSlug sets the public page slug of a system; an empty slug
+// removes the page
+func (s *SystemService) UpdateSystemSlug(ctx context.Context, id int64, slug string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.UpdateSystemSlug")
+	defer span.End()
+
+	if err := s.CheckSystemSlug(ctx, id, slug); err != nil {
+		return nil, err
+	}
+
+	system, err := s.systemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+	if system == nil {
+		return nil, fmt.Errorf("system not found: %d", id)
+	}
+
+	if err := system.SetSlug(slug); err != nil {
+		return nil, err
+	}
+
+	if err := s.systemRepo.Update(ctx, system); err != nil {
+		return nil, fmt.Errorf("failed to update system: %w", err)
+	}
+
+	return system, nil
+}
+
+// UpdateSystemDisplayOrder sets the position of a system on the public page
+func (s *SystemService) UpdateSystemDisplayOrder(ctx context.Context, id int64, order int) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.UpdateSystemDisplayOrder")
+	defer span.End()
+
+	system, err := s.systemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+	if system == nil {
+		return nil, fmt.Errorf("system not found: %d", id)
+	}
+
+	system.SetDisplayOrder(order)
+
+	if err := s.systemRepo.Update(ctx, system); err != nil {
+		return nil, fmt.Errorf("failed to update system: %w", err)
+	}
+
+	return system, nil
+}
+
+// UpdateSystemTags replaces the tags of a system
+func (s *SystemService) UpdateSystemTags(ctx context.Context, id int64, tags []string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.UpdateSystemTags")
+	defer span.End()
+
+	system, err := s.systemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+	if system == nil {
+		return nil, fmt.Errorf("system not found: %d", id)
+	}
+
+	system.SetTags(tags)
+
+	if err := s.systemRepo.Update(ctx, system); err != nil {
+		return nil, fmt.Errorf("failed to update system: %w", err)
+	}
+
+	return system, nil
+}
+
 // UpdateSystemStatus changes system status with logging
 func (s *SystemService) UpdateSystemStatus(ctx context.Context, id int64, statusStr, message string) (*domain.System, error) {
+	ctx, span := startSpan(ctx, "SystemService.UpdateSystemStatus")
+	defer span.End()
+
 	system, err := s.systemRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system: %w", err)
@@ -106,6 +255,7 @@ func (s *SystemService) UpdateSystemStatus(ctx context.Context, id int64, status
 
 	// Log the status change
 	statusLog := domain.NewStatusLog(&id, nil, oldStatus, newStatus, message, domain.SourceManual)
+	statusLog.Actor = domain.ActorFromContext(ctx)
 	if err := s.logRepo.Create(ctx, statusLog); err != nil {
 		// Log error but don't fail the operation
 		fmt.Printf("failed to log status change: %v\n", err)
@@ -115,12 +265,126 @@ func (s *SystemService) UpdateSystemStatus(ctx context.Context, id int64, status
 	if s.notificationService != nil && oldStatus != newStatus {
 		go s.notificationService.NotifyStatusChange(ctx, statusLog)
 	}
+	if s.eventBroker != nil && oldStatus != newStatus {
+		s.eventBroker.PublishStatusChange(statusLog, id)
+	}
+
+	// Propagate status change to dependent systems
+	if s.propagationService != nil && oldStatus != newStatus {
+		s.propagationService.PropagateToDependents(ctx, id)
+	}
 
 	return system, nil
 }
 
+// AddSystemDependency records that systemID depends on dependsOnID. Edges that
+// would create a cycle are rejected with domain.ErrDependencyCycle; adding an
+// existing edge returns it unchanged.
+func (s *SystemService) AddSystemDependency(ctx context.Context, systemID, dependsOnID int64) (*domain.SystemDependency, error) {
+	ctx, span := startSpan(ctx, "SystemService.AddSystemDependency")
+	defer span.End()
+
+	if s.systemDepRepo == nil {
+		return nil, fmt.Errorf("system dependencies are not configured")
+	}
+
+	edge, err := domain.NewSystemDependency(systemID, dependsOnID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range []int64{systemID, dependsOnID} {
+		system, err := s.systemRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system: %w", err)
+		}
+		if system == nil {
+			return nil, fmt.Errorf("system not found: %d", id)
+		}
+	}
+
+	edges, err := s.systemDepRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system dependencies: %w", err)
+	}
+	for _, e := range edges {
+		if e.SystemID == systemID && e.DependsOnID == dependsOnID {
+			return e, nil
+		}
+	}
+	if domain.CreatesCycle(edges, systemID, dependsOnID) {
+		return nil, domain.ErrDependencyCycle
+	}
+
+	if err := s.systemDepRepo.Create(ctx, edge); err != nil {
+		return nil, fmt.Errorf("failed to create system dependency: %w", err)
+	}
+
+	s.propagate(ctx, systemID)
+
+	return edge, nil
+}
+
+// RemoveSystemDependency removes the edge from systemID to dependsOnID
+func (s *SystemService) RemoveSystemDependency(ctx context.Context, systemID, dependsOnID int64) error {
+	ctx, span := startSpan(ctx, "SystemService.RemoveSystemDependency")
+	defer span.End()
+
+	if s.systemDepRepo == nil {
+		return fmt.Errorf("system dependencies are not configured")
+	}
+
+	if err := s.systemDepRepo.Delete(ctx, systemID, dependsOnID); err != nil {
+		return fmt.Errorf("failed to delete system dependency: %w", err)
+	}
+
+	// The system may recover now that the upstream system no longer counts
+	s.propagate(ctx, systemID)
+
+	return nil
+}
+
+// GetSystemGraph retrieves all systems and the dependencies between them
+func (s *SystemService) GetSystemGraph(ctx context.Context) (*SystemGraph, error) {
+	ctx, span := startSpan(ctx, "SystemService.GetSystemGraph")
+	defer span.End()
+
+	systems, err := s.systemRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+
+	graph := &SystemGraph{Systems: systems, Edges: []*domain.SystemDependency{}}
+	if s.systemDepRepo == nil {
+		return graph, nil
+	}
+
+	edges, err := s.systemDepRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system dependencies: %w", err)
+	}
+	if edges != nil {
+		graph.Edges = edges
+	}
+
+	return graph, nil
+}
+
+// propagate re-evaluates systemID after its upstream systems changed
+func (s *SystemService) propagate(ctx context.Context, systemID int64) {
+	if s.propagationService == nil {
+		return
+	}
+	if _, err := s.propagationService.PropagateStatusToSystem(ctx, systemID); err != nil {
+		fmt.Printf("failed to propagate status to system %d: %v\n", systemID, err)
+	}
+}
+
 // DeleteSystem removes a system
 func (s *SystemService) DeleteSystem(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "SystemService.DeleteSystem")
+	defer span.End()
+
 	if err := s.systemRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete system: %w", err)
 	}
@@ -129,6 +393,9 @@ func (s *SystemService) DeleteSystem(ctx context.Context, id int64) error {
 
 // GetSystemLogs retrieves status logs for a system
 func (s *SystemService) GetSystemLogs(ctx context.Context, id int64, limit int) ([]*domain.StatusLog, error) {
+	ctx, span := startSpan(ctx, "SystemService.GetSystemLogs")
+	defer span.End()
+
 	logs, err := s.logRepo.GetBySystemID(ctx, id, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logs: %w", err)
@@ -283,3 +283,145 @@ func TestSystemService_SetNotificationService(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+// setupSystemGraph creates systems 1..n wired for system dependencies
+func setupSystemGraph(t *testing.T, n int) (*SystemService, *MockSystemRepository) {
+	t.Helper()
+
+	systemRepo := NewMockSystemRepository()
+	logRepo := NewMockStatusLogRepository()
+	for i := 1; i <= n; i++ {
+		system, _ := domain.NewSystem(string(rune('A'+i-1)), "", "", "")
+		systemRepo.Create(context.Background(), system)
+	}
+
+	systemDepRepo := NewMockSystemDependencyRepository()
+	propagation := NewStatusPropagationService(systemRepo, NewMockDependencyRepository(), logRepo)
+	propagation.SetSystemDependencyRepo(systemDepRepo)
+
+	service := NewSystemService(systemRepo, logRepo)
+	service.SetSystemDependencyRepo(systemDepRepo)
+	service.SetPropagationService(propagation)
+
+	return service, systemRepo
+}
+
+func TestSystemService_AddSystemDependency_RejectsCycles(t *testing.T) {
+	service, _ := setupSystemGraph(t, 3)
+	ctx := context.Background()
+
+	// 1 -> 2 -> 3
+	if _, err := service.AddSystemDependency(ctx, 1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.AddSystemDependency(ctx, 2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := service.AddSystemDependency(ctx, 3, 1); !errors.Is(err, domain.ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle for 3 -> 1, got %v", err)
+	}
+	if _, err := service.AddSystemDependency(ctx, 2, 1); !errors.Is(err, domain.ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle for 2 -> 1, got %v", err)
+	}
+	if _, err := service.AddSystemDependency(ctx, 2, 2); !errors.Is(err, domain.ErrSelfDependency) {
+		t.Errorf("expected ErrSelfDependency, got %v", err)
+	}
+	if _, err := service.AddSystemDependency(ctx, 1, 99); err == nil {
+		t.Error("expected error for unknown system")
+	}
+
+	// A shortcut that keeps the graph acyclic is fine, and repeating an edge is a no-op
+	if _, err := service.AddSystemDependency(ctx, 1, 3); err != nil {
+		t.Errorf("unexpected error for 1 -> 3: %v", err)
+	}
+	if _, err := service.AddSystemDependency(ctx, 1, 2); err != nil {
+		t.Errorf("unexpected error for existing edge: %v", err)
+	}
+
+	graph, err := service.GetSystemGraph(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(graph.Systems) != 3 || len(graph.Edges) != 3 {
+		t.Errorf("expected 3 systems and 3 edges, got %d and %d", len(graph.Systems), len(graph.Edges))
+	}
+}
+
+func TestSystemService_SystemDependency_TransitivePropagation(t *testing.T) {
+	service, systemRepo := setupSystemGraph(t, 3)
+	ctx := context.Background()
+
+	// 3 depends on 2, which depends on 1
+	service.AddSystemDependency(ctx, 2, 1)
+	service.AddSystemDependency(ctx, 3, 2)
+
+	if _, err := service.UpdateSystemStatus(ctx, 1, "red", "Outage"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := systemRepo.Systems[2].Status; got != domain.StatusYellow {
+		t.Errorf("expected direct dependent to be degraded, got %q", got)
+	}
+	if got := systemRepo.Systems[3].Status; got != domain.StatusYellow {
+		t.Errorf("expected transitive dependent to be degraded, got %q", got)
+	}
+
+	if _, err := service.UpdateSystemStatus(ctx, 1, "green", "Recovered"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := systemRepo.Systems[3].Status; got != domain.StatusGreen {
+		t.Errorf("expected transitive dependent to recover, got %q", got)
+	}
+
+	// Removing the edge stops propagation
+	if err := service.RemoveSystemDependency(ctx, 3, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service.UpdateSystemStatus(ctx, 2, "red", "Outage")
+	if got := systemRepo.Systems[3].Status; got != domain.StatusGreen {
+		t.Errorf("expected detached system to stay green, got %q", got)
+	}
+}
+
+func TestSystemService_UpdateSystemSlug(t *testing.T) {
+	ctx := context.Background()
+	service := NewSystemService(NewMockSystemRepository(), NewMockStatusLogRepository())
+
+	checkout, _ := service.CreateSystem(ctx, "Checkout", "", "", "")
+	search, _ := service.CreateSystem(ctx, "Search", "", "", "")
+
+	updated, err := service.UpdateSystemSlug(ctx, checkout.ID, "Checkout")
+	if err != nil {
+		t.Fatalf("UpdateSystemSlug() error = %v", err)
+	}
+	if updated.Slug != "checkout" {
+		t.Errorf("Slug = %q, want checkout", updated.Slug)
+	}
+
+	found, _ := service.GetSystemBySlug(ctx, "checkout")
+	if found == nil || found.ID != checkout.ID {
+		t.Errorf("expected to find Checkout by slug, got %v", found)
+	}
+
+	// Keeping its own slug is fine, taking another system's is not
+	if _, err := service.UpdateSystemSlug(ctx, checkout.ID, "checkout"); err != nil {
+		t.Errorf("expected a system to keep its own slug, got %v", err)
+	}
+	if _, err := service.UpdateSystemSlug(ctx, search.ID, "checkout"); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected ErrSlugTaken, got %v", err)
+	}
+	if _, err := service.UpdateSystemSlug(ctx, search.ID, "not a slug"); !errors.Is(err, domain.ErrInvalidSlug) {
+		t.Errorf("expected ErrInvalidSlug, got %v", err)
+	}
+	if err := service.CheckSystemSlug(ctx, 0, "checkout"); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected a new system not to take a used slug, got %v", err)
+	}
+
+	// Clearing frees the slug
+	if _, err := service.UpdateSystemSlug(ctx, checkout.ID, ""); err != nil {
+		t.Fatalf("UpdateSystemSlug() error = %v", err)
+	}
+	if _, err := service.UpdateSystemSlug(ctx, search.ID, "checkout"); err != nil {
+		t.Errorf("expected a cleared slug to be reusable, got %v", err)
+	}
+}
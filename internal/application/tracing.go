@@ -0,0 +1,17 @@
+package application
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of service spans
+const tracerName = "status-incident/internal/application"
+
+// startSpan starts a span for a service call, e.g. "SystemService.GetAllSystems".
+// It is a no-op unless tracing is configured.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
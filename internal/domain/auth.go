@@ -22,17 +22,38 @@ type APIKey struct {
 	Name      string     // descriptive name like "CI/CD Pipeline"
 	Key       string     // the actual key (stored hashed)
 	KeyHash   string     // hash of the key for comparison
-	Scopes    []string   // allowed scopes: "read", "write", "admin"
+	Scopes    []string   // allowed scopes: ScopeRead, ScopeWrite, ScopeAdmin
 	CreatedAt time.Time
 	LastUsed  *time.Time
 	ExpiresAt *time.Time
 	Enabled   bool
 }
 
+// API key scopes, from least to most privileged.
+// Each scope implies the ones before it.
+const (
+	ScopeRead  = "read"  // GET requests only
+	ScopeWrite = "write" // create, update and delete resources
+	ScopeAdmin = "admin" // everything, including API key management
+)
+
+// IsValidScope checks if scope is one of the known scopes
+func IsValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeWrite || scope == ScopeAdmin
+}
+
 // HasScope checks if API key has required scope
 func (k *APIKey) HasScope(scope string) bool {
-	for _, s := range k.Scopes {
-		if s == scope || s == "admin" {
+	return scopesAllow(k.Scopes, scope)
+}
+
+// scopesAllow checks if any of granted implies required
+func scopesAllow(granted []string, required string) bool {
+	for _, s := range granted {
+		switch {
+		case s == required, s == ScopeAdmin:
+			return true
+		case s == ScopeWrite && required == ScopeRead:
 			return true
 		}
 	}
@@ -83,6 +104,11 @@ type User struct {
 	Scopes   []string
 }
 
+// HasScope checks if user has required scope
+func (u *User) HasScope(scope string) bool {
+	return scopesAllow(u.Scopes, scope)
+}
+
 // UserContextKey is the context key for user
 type userContextKey struct{}
 
@@ -94,6 +120,15 @@ func UserFromContext(ctx context.Context) *User {
 	return nil
 }
 
+// ActorFromContext returns the name of the authenticated user or API key
+// acting in ctx, or an empty string if the request is unauthenticated
+func ActorFromContext(ctx context.Context) string {
+	if user := UserFromContext(ctx); user != nil {
+		return user.Username
+	}
+	return ""
+}
+
 // ContextWithUser adds user to context
 func ContextWithUser(ctx context.Context, user *User) context.Context {
 	return context.WithValue(ctx, userContextKey{}, user)
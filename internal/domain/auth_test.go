@@ -19,6 +19,9 @@ func TestAPIKey_HasScope(t *testing.T) {
 		{"missing scope", []string{"read"}, "write", false},
 		{"admin has all", []string{"admin"}, "read", true},
 		{"admin has write", []string{"admin"}, "write", true},
+		{"write implies read", []string{"write"}, "read", true},
+		{"write lacks admin", []string{"write"}, "admin", false},
+		{"read lacks admin", []string{"read"}, "admin", false},
 		{"empty scopes", []string{}, "read", false},
 		{"nil scopes", nil, "read", false},
 	}
@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,19 +17,162 @@ var (
 	ErrInvalidHeartbeatInterval = errors.New("heartbeat interval must be positive")
 	ErrInvalidHeartbeatMethod   = errors.New("invalid HTTP method")
 	ErrInvalidExpectStatus      = errors.New("invalid expected status code format")
+	ErrInvalidMinTLSVersion     = errors.New("invalid minimum TLS version")
+	ErrInvalidRetries           = errors.New("retries must be between 0 and 5")
+	ErrInvalidWeight            = errors.New("weight must be between 0 and 100")
+	ErrInvalidCheckType         = errors.New("invalid check type")
+	ErrInvalidCertExpiryWarning = errors.New("cert expiry warning days must not be negative")
+	ErrInvalidCriticality       = errors.New("criticality must be critical, major or minor")
+	ErrInvalidMaxRedirects      = errors.New("max redirects must be between 0 and 20")
+	ErrInvalidHeartbeatTimeout  = errors.New("timeout must be between 1000 and 60000 ms")
+	ErrInvalidDNSRecordType     = errors.New("DNS record type must be A, AAAA or CNAME")
 )
 
+// Criticality is how strongly a dependency's health affects its system's status
+type Criticality string
+
+const (
+	CriticalityCritical Criticality = "critical"
+	CriticalityMajor    Criticality = "major"
+	CriticalityMinor    Criticality = "minor"
+)
+
+// IsValid checks if the criticality is one of the known levels
+func (c Criticality) IsValid() bool {
+	switch c {
+	case CriticalityCritical, CriticalityMajor, CriticalityMinor:
+		return true
+	}
+	return false
+}
+
 // HeartbeatConfig contains all configuration for health checks
 type HeartbeatConfig struct {
+	// CheckType selects the probe: "http" (default), "tcp", "grpc" or "dns".
+	// For TCP checks URL is a host:port address, optionally prefixed with
+	// tcp://; for gRPC checks it is host:port, grpc://host:port or
+	// grpcs://host:port (TLS); for DNS checks it is a hostname, optionally
+	// prefixed with dns://
+	CheckType    string            `json:"check_type,omitempty"`
 	URL          string            `json:"url"`
-	Interval     int               `json:"interval"` // seconds
+	Interval     int               `json:"interval"`         // seconds
 	Method       string            `json:"method,omitempty"` // GET, POST, PUT, HEAD
 	Headers      map[string]string `json:"headers,omitempty"`
 	Body         string            `json:"body,omitempty"`
 	ExpectStatus string            `json:"expect_status,omitempty"` // "200", "200,201", "2xx"
 	ExpectBody   string            `json:"expect_body,omitempty"`   // regex pattern
+	// ExpectBodySubstring must appear verbatim in the response body. A
+	// matching status with a missing substring counts as degraded
+	ExpectBodySubstring string `json:"expect_body_substring,omitempty"`
+	// MinTLSVersion is the lowest acceptable negotiated TLS version ("1.0".."1.3");
+	// checks below it are treated as failures
+	MinTLSVersion string `json:"min_tls_version,omitempty"`
+	// Retries is how many times a failed probe is retried immediately
+	// within a single check before it counts as a failure
+	Retries int `json:"retries,omitempty"`
+	// GRPCService is the service name sent in grpc.health.v1 Check requests;
+	// empty asks for the overall server health
+	GRPCService string `json:"grpc_service,omitempty"`
+	// CertExpiryWarningDays marks HTTPS checks degraded when the leaf
+	// certificate expires within this many days and failed once it has
+	// expired; 0 disables the check
+	CertExpiryWarningDays int `json:"cert_expiry_warning_days,omitempty"`
+	// FollowRedirects controls whether HTTP checks follow 3xx responses;
+	// nil means true. When disabled the redirect itself is the result
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+	// MaxRedirects caps how many redirects are followed before the last
+	// response is evaluated; 0 means DefaultMaxRedirects
+	MaxRedirects int `json:"max_redirects,omitempty"`
+	// TimeoutMs bounds a single probe in milliseconds; 0 uses the checker's
+	// default timeout
+	TimeoutMs int `json:"timeout_ms,omitempty"`
+	// DNSRecordType is the record a DNS check looks up: "A" (default),
+	// "AAAA" or "CNAME"
+	DNSRecordType string `json:"dns_record_type,omitempty"`
+	// DNSExpect is a value the DNS answer must contain, an IP address or a
+	// canonical name. A resolving host without it counts as degraded
+	DNSExpect string `json:"dns_expect,omitempty"`
+}
+
+// Timeout returns the probe timeout, or 0 to use the checker's default
+func (c HeartbeatConfig) Timeout() time.Duration {
+	return time.Duration(c.TimeoutMs) * time.Millisecond
+}
+
+// CheckBudget returns how long a whole check may take: one probe timeout
+// for the first attempt and each retry, plus a grace period so a probe's own
+// timeout reports the failure before the check is cancelled
+func (c HeartbeatConfig) CheckBudget() time.Duration {
+	timeout := c.Timeout()
+	if timeout <= 0 {
+		timeout = DefaultHeartbeatTimeout
+	}
+	return timeout*time.Duration(c.Retries+1) + heartbeatBudgetGrace
+}
+
+// RedirectLimit returns how many redirects an HTTP check may follow
+func (c HeartbeatConfig) RedirectLimit() int {
+	if c.FollowRedirects != nil && !*c.FollowRedirects {
+		return 0
+	}
+	if c.MaxRedirects <= 0 {
+		return DefaultMaxRedirects
+	}
+	return c.MaxRedirects
+}
+
+// Health check types
+const (
+	CheckTypeHTTP = "http"
+	CheckTypeTCP  = "tcp"
+	CheckTypeGRPC = "grpc"
+	CheckTypeDNS  = "dns"
+)
+
+// ValidCheckTypes lists accepted values for HeartbeatConfig.CheckType
+var ValidCheckTypes = map[string]bool{
+	CheckTypeHTTP: true,
+	CheckTypeTCP:  true,
+	CheckTypeGRPC: true,
+	CheckTypeDNS:  true,
+}
+
+// DNS record types a DNS check can look up
+const (
+	DNSRecordA     = "A"
+	DNSRecordAAAA  = "AAAA"
+	DNSRecordCNAME = "CNAME"
+)
+
+// ValidDNSRecordTypes lists accepted values for HeartbeatConfig.DNSRecordType
+var ValidDNSRecordTypes = map[string]bool{
+	DNSRecordA:     true,
+	DNSRecordAAAA:  true,
+	DNSRecordCNAME: true,
 }
 
+// MaxHeartbeatRetries caps in-check retries so a check stays bounded
+const MaxHeartbeatRetries = 5
+
+// Redirect limits for HTTP checks
+const (
+	DefaultMaxRedirects = 10
+	MaxRedirectsLimit   = 20
+)
+
+// Bounds for per-dependency check timeouts
+const (
+	MinHeartbeatTimeoutMs = 1000
+	MaxHeartbeatTimeoutMs = 60000
+)
+
+// DefaultHeartbeatTimeout is the probe timeout for dependencies without
+// their own timeout_ms
+const DefaultHeartbeatTimeout = 10 * time.Second
+
+// heartbeatBudgetGrace is added to a check's budget on top of its probes
+const heartbeatBudgetGrace = time.Second
+
 // ValidHTTPMethods lists allowed HTTP methods for health checks
 var ValidHTTPMethods = map[string]bool{
 	"GET":  true,
@@ -34,26 +181,50 @@ var ValidHTTPMethods = map[string]bool{
 	"HEAD": true,
 }
 
+// ValidTLSVersions lists accepted values for HeartbeatConfig.MinTLSVersion
+var ValidTLSVersions = map[string]bool{
+	"1.0": true,
+	"1.1": true,
+	"1.2": true,
+	"1.3": true,
+}
+
 // Dependency is an entity representing a subsystem/component of a System
 type Dependency struct {
-	ID                  int64
-	SystemID            int64
-	Name                string
-	Description         string
-	Status              Status
-	HeartbeatURL        string
-	HeartbeatInterval   int   // seconds
-	HeartbeatMethod     string // GET, POST, PUT, HEAD (default: GET)
-	HeartbeatHeaders    map[string]string // custom headers (e.g., Authorization)
-	HeartbeatBody       string // request body for POST/PUT
-	HeartbeatExpectStatus string // expected status codes: "200", "200,201", "2xx" (default: 2xx)
-	HeartbeatExpectBody string // regex pattern to match in response body
-	LastCheck           time.Time
-	LastLatency         int64 // milliseconds
-	LastStatusCode      int   // last HTTP status code received
-	ConsecutiveFailures int
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	ID                             int64
+	SystemID                       int64
+	Name                           string
+	Description                    string
+	Status                         Status
+	HeartbeatCheckType             string // "http", "tcp", "grpc" or "dns" (empty = http)
+	HeartbeatURL                   string
+	HeartbeatInterval              int               // seconds
+	HeartbeatMethod                string            // GET, POST, PUT, HEAD (default: GET)
+	HeartbeatHeaders               map[string]string // custom headers (e.g., Authorization)
+	HeartbeatBody                  string            // request body for POST/PUT
+	HeartbeatExpectStatus          string            // expected status codes: "200", "200,201", "2xx" (default: 2xx)
+	HeartbeatExpectBody            string            // regex pattern to match in response body
+	HeartbeatExpectBodySubstring   string            // literal text expected in response body (mismatch = degraded)
+	HeartbeatMinTLSVersion         string            // minimum negotiated TLS version, e.g. "1.2" (empty = any)
+	HeartbeatRetries               int               // immediate retries within a single check
+	HeartbeatGRPCService           string            // service name for gRPC health checks (empty = whole server)
+	HeartbeatCertExpiryWarningDays int               // days before cert expiry that checks turn degraded (0 = off)
+	HeartbeatFollowRedirects       bool              // follow 3xx responses in HTTP checks (default true)
+	HeartbeatMaxRedirects          int               // redirects followed before giving up (0 = default 10)
+	HeartbeatTimeoutMs             int               // per-check timeout in milliseconds (0 = checker default)
+	HeartbeatDNSRecordType         string            // record looked up by DNS checks: A, AAAA or CNAME (empty = A)
+	HeartbeatDNSExpect             string            // IP or name the DNS answer must contain (mismatch = degraded)
+	LastCheck                      time.Time
+	LastLatency                    int64     // milliseconds
+	LastStatusCode                 int       // last HTTP status code received
+	CertExpiresAt                  time.Time // leaf certificate expiry seen by the last TLS check (zero = unknown)
+	ConsecutiveFailures            int
+	ConsecutiveSuccesses           int         // successful checks in a row, counted toward recovery
+	RecordLatency                  bool        // persist a latency record per check (default true)
+	Weight                         float64     // share in the weighted system SLA (default 1, 0 excludes)
+	Criticality                    Criticality // how the status propagates to the system (default critical)
+	CreatedAt                      time.Time
+	UpdatedAt                      time.Time
 }
 
 // NewDependency creates a new Dependency with validation
@@ -69,16 +240,20 @@ func NewDependency(systemID int64, name, description string) (*Dependency, error
 
 	now := time.Now()
 	return &Dependency{
-		ID:                  0,
-		SystemID:            systemID,
-		Name:                name,
-		Description:         strings.TrimSpace(description),
-		Status:              StatusGreen,
-		HeartbeatURL:        "",
-		HeartbeatInterval:   0,
-		ConsecutiveFailures: 0,
-		CreatedAt:           now,
-		UpdatedAt:           now,
+		ID:                       0,
+		SystemID:                 systemID,
+		Name:                     name,
+		Description:              strings.TrimSpace(description),
+		Status:                   StatusGreen,
+		HeartbeatURL:             "",
+		HeartbeatInterval:        0,
+		ConsecutiveFailures:      0,
+		RecordLatency:            true,
+		HeartbeatFollowRedirects: true,
+		Weight:                   1,
+		Criticality:              CriticalityCritical,
+		CreatedAt:                now,
+		UpdatedAt:                now,
 	}, nil
 }
 
@@ -94,13 +269,36 @@ func (d *Dependency) SetHeartbeat(heartbeatURL string, intervalSeconds int) erro
 func (d *Dependency) SetHeartbeatConfig(config HeartbeatConfig) error {
 	config.URL = strings.TrimSpace(config.URL)
 
-	// Validate URL
-	parsed, err := url.Parse(config.URL)
-	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
-		return ErrInvalidHeartbeatURL
+	checkType := strings.ToLower(strings.TrimSpace(config.CheckType))
+	if checkType == "" {
+		checkType = CheckTypeHTTP
 	}
-	if parsed.Scheme != "http" && parsed.Scheme != "https" {
-		return ErrInvalidHeartbeatURL
+	if !ValidCheckTypes[checkType] {
+		return ErrInvalidCheckType
+	}
+
+	// Validate URL
+	switch checkType {
+	case CheckTypeTCP:
+		if _, _, err := SplitTCPAddress(config.URL); err != nil {
+			return ErrInvalidHeartbeatURL
+		}
+	case CheckTypeGRPC:
+		if _, _, err := SplitGRPCAddress(config.URL); err != nil {
+			return ErrInvalidHeartbeatURL
+		}
+	case CheckTypeDNS:
+		if _, err := SplitDNSHost(config.URL); err != nil {
+			return ErrInvalidHeartbeatURL
+		}
+	default:
+		parsed, err := url.Parse(config.URL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return ErrInvalidHeartbeatURL
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return ErrInvalidHeartbeatURL
+		}
 	}
 
 	if config.Interval <= 0 {
@@ -123,13 +321,60 @@ func (d *Dependency) SetHeartbeatConfig(config HeartbeatConfig) error {
 		}
 	}
 
+	config.MinTLSVersion = strings.TrimSpace(config.MinTLSVersion)
+	if config.MinTLSVersion != "" && !ValidTLSVersions[config.MinTLSVersion] {
+		return ErrInvalidMinTLSVersion
+	}
+
+	if config.Retries < 0 || config.Retries > MaxHeartbeatRetries {
+		return ErrInvalidRetries
+	}
+
+	if config.CertExpiryWarningDays < 0 {
+		return ErrInvalidCertExpiryWarning
+	}
+
+	if config.MaxRedirects < 0 || config.MaxRedirects > MaxRedirectsLimit {
+		return ErrInvalidMaxRedirects
+	}
+
+	if config.TimeoutMs != 0 && (config.TimeoutMs < MinHeartbeatTimeoutMs || config.TimeoutMs > MaxHeartbeatTimeoutMs) {
+		return ErrInvalidHeartbeatTimeout
+	}
+
+	dnsRecordType := strings.ToUpper(strings.TrimSpace(config.DNSRecordType))
+	if checkType != CheckTypeDNS {
+		dnsRecordType, config.DNSExpect = "", ""
+	} else if dnsRecordType == "" {
+		dnsRecordType = DNSRecordA
+	}
+	if dnsRecordType != "" && !ValidDNSRecordTypes[dnsRecordType] {
+		return ErrInvalidDNSRecordType
+	}
+
+	// The recorded certificate belongs to the old target
+	if config.URL != d.HeartbeatURL {
+		d.CertExpiresAt = time.Time{}
+	}
+
+	d.HeartbeatCheckType = checkType
 	d.HeartbeatURL = config.URL
 	d.HeartbeatInterval = config.Interval
 	d.HeartbeatMethod = method
-	d.HeartbeatHeaders = config.Headers
+	d.HeartbeatHeaders = keepRedactedHeaders(config.Headers, d.HeartbeatHeaders)
 	d.HeartbeatBody = config.Body
 	d.HeartbeatExpectStatus = config.ExpectStatus
 	d.HeartbeatExpectBody = config.ExpectBody
+	d.HeartbeatExpectBodySubstring = config.ExpectBodySubstring
+	d.HeartbeatMinTLSVersion = config.MinTLSVersion
+	d.HeartbeatRetries = config.Retries
+	d.HeartbeatGRPCService = strings.TrimSpace(config.GRPCService)
+	d.HeartbeatCertExpiryWarningDays = config.CertExpiryWarningDays
+	d.HeartbeatFollowRedirects = config.FollowRedirects == nil || *config.FollowRedirects
+	d.HeartbeatMaxRedirects = config.MaxRedirects
+	d.HeartbeatTimeoutMs = config.TimeoutMs
+	d.HeartbeatDNSRecordType = dnsRecordType
+	d.HeartbeatDNSExpect = strings.TrimSpace(config.DNSExpect)
 	d.UpdatedAt = time.Now()
 	return nil
 }
@@ -160,25 +405,161 @@ func isValidExpectStatus(s string) bool {
 	return true
 }
 
+// SplitTCPAddress parses a TCP check target ("host:port" or "tcp://host:port")
+func SplitTCPAddress(address string) (host, port string, err error) {
+	address = strings.TrimPrefix(strings.TrimSpace(address), "tcp://")
+	host, port, err = net.SplitHostPort(address)
+	if err != nil {
+		return "", "", err
+	}
+	if host == "" || port == "" {
+		return "", "", ErrInvalidHeartbeatURL
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", "", ErrInvalidHeartbeatURL
+	}
+	return host, port, nil
+}
+
+// SplitGRPCAddress parses a gRPC check target ("host:port", "grpc://host:port"
+// or "grpcs://host:port") into a host:port address and whether to use TLS
+func SplitGRPCAddress(address string) (hostPort string, useTLS bool, err error) {
+	address = strings.TrimSpace(address)
+	switch {
+	case strings.HasPrefix(address, "grpcs://"):
+		address, useTLS = strings.TrimPrefix(address, "grpcs://"), true
+	case strings.HasPrefix(address, "grpc://"):
+		address = strings.TrimPrefix(address, "grpc://")
+	}
+	host, port, err := SplitTCPAddress(address)
+	if err != nil {
+		return "", false, err
+	}
+	return net.JoinHostPort(host, port), useTLS, nil
+}
+
+// SplitDNSHost parses a DNS check target ("example.com" or
+// "dns://example.com") into the hostname to resolve
+func SplitDNSHost(target string) (string, error) {
+	host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(target), "dns://"), ".")
+	if host == "" || len(host) > 253 || strings.ContainsAny(host, "/:@ ") {
+		return "", ErrInvalidHeartbeatURL
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 {
+			return "", ErrInvalidHeartbeatURL
+		}
+	}
+	return host, nil
+}
+
 // GetHeartbeatConfig returns the current heartbeat configuration
 func (d *Dependency) GetHeartbeatConfig() HeartbeatConfig {
 	method := d.HeartbeatMethod
 	if method == "" {
 		method = "GET"
 	}
+	checkType := d.HeartbeatCheckType
+	if checkType == "" {
+		checkType = CheckTypeHTTP
+	}
+	followRedirects := d.HeartbeatFollowRedirects
 	return HeartbeatConfig{
-		URL:          d.HeartbeatURL,
-		Interval:     d.HeartbeatInterval,
-		Method:       method,
-		Headers:      d.HeartbeatHeaders,
-		Body:         d.HeartbeatBody,
-		ExpectStatus: d.HeartbeatExpectStatus,
-		ExpectBody:   d.HeartbeatExpectBody,
+		CheckType:             checkType,
+		URL:                   d.HeartbeatURL,
+		Interval:              d.HeartbeatInterval,
+		Method:                method,
+		Headers:               d.HeartbeatHeaders,
+		Body:                  d.HeartbeatBody,
+		ExpectStatus:          d.HeartbeatExpectStatus,
+		ExpectBody:            d.HeartbeatExpectBody,
+		ExpectBodySubstring:   d.HeartbeatExpectBodySubstring,
+		MinTLSVersion:         d.HeartbeatMinTLSVersion,
+		Retries:               d.HeartbeatRetries,
+		GRPCService:           d.HeartbeatGRPCService,
+		CertExpiryWarningDays: d.HeartbeatCertExpiryWarningDays,
+		FollowRedirects:       &followRedirects,
+		MaxRedirects:          d.HeartbeatMaxRedirects,
+		TimeoutMs:             d.HeartbeatTimeoutMs,
+		DNSRecordType:         d.HeartbeatDNSRecordType,
+		DNSExpect:             d.HeartbeatDNSExpect,
 	}
 }
 
+// CertDaysRemaining returns the whole days until a certificate expiring at
+// expiresAt expires; negative once it has expired
+func CertDaysRemaining(expiresAt, now time.Time) int {
+	return int(math.Floor(expiresAt.Sub(now).Hours() / 24))
+}
+
+// SetRecordLatency toggles persisting latency history for each check.
+// Status is still updated when recording is off.
+func (d *Dependency) SetRecordLatency(record bool) {
+	d.RecordLatency = record
+	d.UpdatedAt = time.Now()
+}
+
+// SetWeight sets how much the dependency counts towards its system's
+// weighted SLA. Critical dependencies get a higher weight; 0 excludes it.
+func (d *Dependency) SetWeight(weight float64) error {
+	if weight < 0 || weight > 100 {
+		return ErrInvalidWeight
+	}
+	d.Weight = weight
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCriticality sets how the dependency's status propagates to its system
+func (d *Dependency) SetCriticality(criticality Criticality) error {
+	if !criticality.IsValid() {
+		return ErrInvalidCriticality
+	}
+	d.Criticality = criticality
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// PropagatedStatus returns the status a dependency passes on to its system:
+//
+//	criticality  dependency yellow  dependency partial  dependency red
+//	critical     yellow             partial             red
+//	major        yellow             yellow              yellow
+//	minor        green              yellow              yellow
+//
+// Green dependencies always pass on green. Unknown criticality counts as
+// critical, so dependencies stored before criticality existed keep the
+// worst-case behaviour.
+func PropagatedStatus(status Status, criticality Criticality) Status {
+	switch criticality {
+	case CriticalityMajor:
+		if status == StatusRed || status == StatusPartial {
+			return StatusYellow
+		}
+	case CriticalityMinor:
+		switch status {
+		case StatusRed, StatusPartial:
+			return StatusYellow
+		case StatusYellow:
+			return StatusGreen
+		}
+	}
+	return status
+}
+
+// SystemStatusFromDependencies returns the worst status propagated by deps,
+// green when there are none
+func SystemStatusFromDependencies(deps []*Dependency) Status {
+	statuses := make([]Status, len(deps))
+	for i, dep := range deps {
+		statuses[i] = PropagatedStatus(dep.Status, dep.Criticality)
+	}
+	return MaxSeverityStatus(statuses)
+}
+
 // ClearHeartbeat removes heartbeat configuration
 func (d *Dependency) ClearHeartbeat() {
+	d.HeartbeatCheckType = ""
 	d.HeartbeatURL = ""
 	d.HeartbeatInterval = 0
 	d.HeartbeatMethod = ""
@@ -186,24 +567,114 @@ func (d *Dependency) ClearHeartbeat() {
 	d.HeartbeatBody = ""
 	d.HeartbeatExpectStatus = ""
 	d.HeartbeatExpectBody = ""
+	d.HeartbeatExpectBodySubstring = ""
+	d.HeartbeatMinTLSVersion = ""
+	d.HeartbeatRetries = 0
+	d.HeartbeatGRPCService = ""
+	d.HeartbeatCertExpiryWarningDays = 0
+	d.HeartbeatFollowRedirects = true
+	d.HeartbeatMaxRedirects = 0
+	d.HeartbeatTimeoutMs = 0
+	d.HeartbeatDNSRecordType = ""
+	d.HeartbeatDNSExpect = ""
+	d.CertExpiresAt = time.Time{}
 	d.UpdatedAt = time.Now()
 }
 
+// CloneTo returns a new dependency for systemID with this dependency's
+// definition: name, description, heartbeat configuration, latency recording,
+// weight and criticality. Runtime state such as status, check results and
+// failure counts starts fresh.
+func (d *Dependency) CloneTo(systemID int64) (*Dependency, error) {
+	clone, err := NewDependency(systemID, d.Name, d.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.HeartbeatCheckType = d.HeartbeatCheckType
+	clone.HeartbeatURL = d.HeartbeatURL
+	clone.HeartbeatInterval = d.HeartbeatInterval
+	clone.HeartbeatMethod = d.HeartbeatMethod
+	if d.HeartbeatHeaders != nil {
+		clone.HeartbeatHeaders = make(map[string]string, len(d.HeartbeatHeaders))
+		for name, value := range d.HeartbeatHeaders {
+			clone.HeartbeatHeaders[name] = value
+		}
+	}
+	clone.HeartbeatBody = d.HeartbeatBody
+	clone.HeartbeatExpectStatus = d.HeartbeatExpectStatus
+	clone.HeartbeatExpectBody = d.HeartbeatExpectBody
+	clone.HeartbeatExpectBodySubstring = d.HeartbeatExpectBodySubstring
+	clone.HeartbeatMinTLSVersion = d.HeartbeatMinTLSVersion
+	clone.HeartbeatRetries = d.HeartbeatRetries
+	clone.HeartbeatGRPCService = d.HeartbeatGRPCService
+	clone.HeartbeatCertExpiryWarningDays = d.HeartbeatCertExpiryWarningDays
+	clone.HeartbeatFollowRedirects = d.HeartbeatFollowRedirects
+	clone.HeartbeatMaxRedirects = d.HeartbeatMaxRedirects
+	clone.HeartbeatTimeoutMs = d.HeartbeatTimeoutMs
+	clone.HeartbeatDNSRecordType = d.HeartbeatDNSRecordType
+	clone.HeartbeatDNSExpect = d.HeartbeatDNSExpect
+	clone.RecordLatency = d.RecordLatency
+	clone.Weight = d.Weight
+	clone.Criticality = d.Criticality
+	return clone, nil
+}
+
 // HasHeartbeat returns true if heartbeat URL is configured
 func (d *Dependency) HasHeartbeat() bool {
 	return d.HeartbeatURL != ""
 }
 
+// Default check thresholds: 3 failures in a row turn a dependency red, a
+// single success turns it green again
+const (
+	DefaultFailureThreshold  = 3
+	DefaultRecoveryThreshold = 1
+)
+
 // RecordCheckSuccess records a successful health check with latency
 // Returns true if status changed
 func (d *Dependency) RecordCheckSuccess(latencyMs int64) bool {
-	d.LastCheck = time.Now()
+	return d.RecordCheckSuccessWithThreshold(latencyMs, DefaultRecoveryThreshold, time.Now())
+}
+
+// RecordCheckSuccessWithThreshold records a successful health check made at
+// now. The dependency turns green after recoveryThreshold successes in a
+// row; until then an unhealthy dependency stays yellow. Returns true if
+// status changed
+func (d *Dependency) RecordCheckSuccessWithThreshold(latencyMs int64, recoveryThreshold int, now time.Time) bool {
+	d.LastCheck = now
 	d.LastLatency = latencyMs
 	d.ConsecutiveFailures = 0
+	d.ConsecutiveSuccesses++
 
-	if d.Status != StatusGreen {
+	oldStatus := d.Status
+	if d.ConsecutiveSuccesses >= recoveryThreshold {
 		d.Status = StatusGreen
-		d.UpdatedAt = time.Now()
+	} else if d.Status != StatusGreen {
+		d.Status = StatusYellow
+	}
+
+	if d.Status != oldStatus {
+		d.UpdatedAt = now
+		return true
+	}
+	return false
+}
+
+// RecordCheckDegraded records a check made at now where the endpoint
+// answered with the expected status but an unexpected body or a
+// soon-expiring certificate. The dependency is marked yellow
+// without escalating to red. Returns true if status changed
+func (d *Dependency) RecordCheckDegraded(latencyMs int64, now time.Time) bool {
+	d.LastCheck = now
+	d.LastLatency = latencyMs
+	d.ConsecutiveFailures = 0
+	d.ConsecutiveSuccesses = 0
+
+	if d.Status != StatusYellow {
+		d.Status = StatusYellow
+		d.UpdatedAt = now
 		return true
 	}
 	return false
@@ -213,20 +684,28 @@ func (d *Dependency) RecordCheckSuccess(latencyMs int64) bool {
 // Returns true if status changed
 // Logic: 1 failure = yellow, 3+ failures = red
 func (d *Dependency) RecordCheckFailure(latencyMs int64) bool {
-	d.LastCheck = time.Now()
+	return d.RecordCheckFailureWithThreshold(latencyMs, DefaultFailureThreshold, time.Now())
+}
+
+// RecordCheckFailureWithThreshold records a failed health check made at now.
+// The dependency turns red after failureThreshold failures in a row and is
+// yellow before that. Returns true if status changed
+func (d *Dependency) RecordCheckFailureWithThreshold(latencyMs int64, failureThreshold int, now time.Time) bool {
+	d.LastCheck = now
 	d.LastLatency = latencyMs
 	d.ConsecutiveFailures++
+	d.ConsecutiveSuccesses = 0
 
 	oldStatus := d.Status
 
-	if d.ConsecutiveFailures >= 3 {
+	if d.ConsecutiveFailures >= failureThreshold {
 		d.Status = StatusRed
 	} else if d.ConsecutiveFailures >= 1 {
 		d.Status = StatusYellow
 	}
 
 	if d.Status != oldStatus {
-		d.UpdatedAt = time.Now()
+		d.UpdatedAt = now
 		return true
 	}
 	return false
@@ -238,12 +717,25 @@ func (d *Dependency) NeedsCheck() bool {
 		return false
 	}
 
-	if d.LastCheck.IsZero() {
-		return true
+	return !d.NextCheckAt(0).After(time.Now())
+}
+
+// CheckInterval returns the dependency's heartbeat interval, or fallback
+// when it has none of its own
+func (d *Dependency) CheckInterval(fallback time.Duration) time.Duration {
+	if d.HeartbeatInterval > 0 {
+		return time.Duration(d.HeartbeatInterval) * time.Second
 	}
+	return fallback
+}
 
-	nextCheck := d.LastCheck.Add(time.Duration(d.HeartbeatInterval) * time.Second)
-	return time.Now().After(nextCheck)
+// NextCheckAt returns when the dependency is next due for a health check.
+// A zero time means it has never been checked and is due now.
+func (d *Dependency) NextCheckAt(fallback time.Duration) time.Time {
+	if d.LastCheck.IsZero() {
+		return time.Time{}
+	}
+	return d.LastCheck.Add(d.CheckInterval(fallback))
 }
 
 // UpdateStatus manually updates dependency status
@@ -253,8 +745,9 @@ func (d *Dependency) UpdateStatus(status Status) error {
 	}
 	d.Status = status
 	d.UpdatedAt = time.Now()
-	// Reset consecutive failures on manual update
+	// Reset consecutive check counts on manual update
 	d.ConsecutiveFailures = 0
+	d.ConsecutiveSuccesses = 0
 	return nil
 }
 
@@ -269,3 +762,61 @@ func (d *Dependency) Update(name, description string) error {
 	d.UpdatedAt = time.Now()
 	return nil
 }
+
+// RedactedHeaderValue replaces sensitive heartbeat header values in API
+// responses. Sending it back keeps the stored value.
+const RedactedHeaderValue = "[REDACTED]"
+
+// sensitiveHeaderParts mark header names whose values may hold credentials
+var sensitiveHeaderParts = []string{"auth", "cookie", "token", "secret", "key", "password", "signature"}
+
+// IsSensitiveHeader reports whether a header value may hold credentials,
+// e.g. Authorization, Cookie or X-API-Key
+func IsSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeaders returns a copy of headers with sensitive values replaced by
+// RedactedHeaderValue
+func RedactHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if IsSensitiveHeader(name) {
+			value = RedactedHeaderValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// keepRedactedHeaders restores stored values for headers sent back redacted,
+// so a config read from the API can be saved again without losing secrets
+func keepRedactedHeaders(headers, stored map[string]string) map[string]string {
+	for name, value := range headers {
+		if value != RedactedHeaderValue {
+			continue
+		}
+		if old, ok := stored[name]; ok {
+			headers[name] = old
+		}
+	}
+	return headers
+}
+
+// MarshalJSON serializes the dependency with sensitive heartbeat header
+// values redacted; they are only needed by the checker
+func (d Dependency) MarshalJSON() ([]byte, error) {
+	type plain Dependency
+	p := plain(d)
+	p.HeartbeatHeaders = RedactHeaders(d.HeartbeatHeaders)
+	return json.Marshal(p)
+}
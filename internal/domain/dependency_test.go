@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -300,6 +302,115 @@ func TestDependency_SetHeartbeatConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid tcp check",
+			config: HeartbeatConfig{
+				CheckType: "tcp",
+				URL:       "db.internal:5432",
+				Interval:  60,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid tcp check with scheme",
+			config: HeartbeatConfig{
+				CheckType: "tcp",
+				URL:       "tcp://broker:5672",
+				Interval:  60,
+			},
+			wantErr: false,
+		},
+		{
+			name: "tcp check without port",
+			config: HeartbeatConfig{
+				CheckType: "tcp",
+				URL:       "db.internal",
+				Interval:  60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid grpc check",
+			config: HeartbeatConfig{
+				CheckType:   "grpc",
+				URL:         "orders.internal:50051",
+				Interval:    60,
+				GRPCService: "orders.v1.Orders",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid grpc check with tls scheme",
+			config: HeartbeatConfig{
+				CheckType: "grpc",
+				URL:       "grpcs://orders.internal:443",
+				Interval:  60,
+			},
+			wantErr: false,
+		},
+		{
+			name: "grpc check with http url",
+			config: HeartbeatConfig{
+				CheckType: "grpc",
+				URL:       "https://orders.internal/health",
+				Interval:  60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cert expiry warning",
+			config: HeartbeatConfig{
+				URL:                   "https://api.example.com/health",
+				Interval:              60,
+				CertExpiryWarningDays: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid check type",
+			config: HeartbeatConfig{
+				CheckType: "icmp",
+				URL:       "https://api.example.com/health",
+				Interval:  60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid min TLS version",
+			config: HeartbeatConfig{
+				URL:           "https://api.example.com/health",
+				Interval:      60,
+				MinTLSVersion: "1.2",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid min TLS version",
+			config: HeartbeatConfig{
+				URL:           "https://api.example.com/health",
+				Interval:      60,
+				MinTLSVersion: "2.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid retries",
+			config: HeartbeatConfig{
+				URL:      "https://api.example.com/health",
+				Interval: 60,
+				Retries:  2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "too many retries",
+			config: HeartbeatConfig{
+				URL:      "https://api.example.com/health",
+				Interval: 60,
+				Retries:  MaxHeartbeatRetries + 1,
+			},
+			wantErr: true,
+		},
 		{
 			name: "ftp scheme not allowed",
 			config: HeartbeatConfig{
@@ -399,6 +510,9 @@ func TestDependency_GetHeartbeatConfig(t *testing.T) {
 	if config.ExpectBody != `"status":\s*"ok"` {
 		t.Errorf("expected body pattern, got %q", config.ExpectBody)
 	}
+	if config.CheckType != CheckTypeHTTP {
+		t.Errorf("expected check type to default to http, got %q", config.CheckType)
+	}
 }
 
 func TestDependency_GetHeartbeatConfig_DefaultMethod(t *testing.T) {
@@ -489,3 +603,457 @@ func TestDependency_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestDependency_SetWeight(t *testing.T) {
+	dep, _ := NewDependency(1, "Database", "")
+	if dep.Weight != 1 {
+		t.Errorf("default Weight = %v, want 1", dep.Weight)
+	}
+
+	tests := []struct {
+		weight  float64
+		wantErr bool
+	}{
+		{0, false},
+		{3, false},
+		{100, false},
+		{-1, true},
+		{101, true},
+	}
+	for _, tt := range tests {
+		err := dep.SetWeight(tt.weight)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SetWeight(%v) error = %v, wantErr %v", tt.weight, err, tt.wantErr)
+		}
+		if err == nil && dep.Weight != tt.weight {
+			t.Errorf("Weight = %v, want %v", dep.Weight, tt.weight)
+		}
+	}
+}
+
+func TestDependency_RecordCheckDegraded(t *testing.T) {
+	dep, _ := NewDependency(1, "Search", "")
+	dep.ConsecutiveFailures = 2
+
+	if changed := dep.RecordCheckDegraded(120, time.Now()); !changed {
+		t.Error("expected status change from green to yellow")
+	}
+	if dep.Status != StatusYellow {
+		t.Errorf("expected yellow, got %s", dep.Status)
+	}
+	if dep.ConsecutiveFailures != 0 {
+		t.Errorf("expected failures to reset, got %d", dep.ConsecutiveFailures)
+	}
+	if dep.LastLatency != 120 {
+		t.Errorf("expected latency 120, got %d", dep.LastLatency)
+	}
+	if changed := dep.RecordCheckDegraded(90, time.Now()); changed {
+		t.Error("expected no status change while already yellow")
+	}
+}
+
+func TestDependency_RecordCheckAtGivenTime(t *testing.T) {
+	dep, _ := NewDependency(1, "Queue", "")
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	dep.RecordCheckFailureWithThreshold(300, 1, at)
+	if !dep.LastCheck.Equal(at) || !dep.UpdatedAt.Equal(at) {
+		t.Errorf("expected failure recorded at %v, got last check %v and updated %v", at, dep.LastCheck, dep.UpdatedAt)
+	}
+
+	at = at.Add(time.Minute)
+	dep.RecordCheckDegraded(120, at)
+	if !dep.LastCheck.Equal(at) || !dep.UpdatedAt.Equal(at) {
+		t.Errorf("expected degraded check recorded at %v, got last check %v and updated %v", at, dep.LastCheck, dep.UpdatedAt)
+	}
+
+	at = at.Add(time.Minute)
+	dep.RecordCheckSuccessWithThreshold(50, 1, at)
+	if !dep.LastCheck.Equal(at) || !dep.UpdatedAt.Equal(at) {
+		t.Errorf("expected success recorded at %v, got last check %v and updated %v", at, dep.LastCheck, dep.UpdatedAt)
+	}
+}
+
+func TestDependency_NextCheckAt(t *testing.T) {
+	dep, _ := NewDependency(1, "CDN", "")
+
+	if got := dep.CheckInterval(time.Minute); got != time.Minute {
+		t.Errorf("expected fallback interval without own interval, got %v", got)
+	}
+
+	dep.SetHeartbeat("https://cdn.example.com/health", 10)
+	if got := dep.CheckInterval(time.Minute); got != 10*time.Second {
+		t.Errorf("expected own 10s interval, got %v", got)
+	}
+
+	if !dep.NextCheckAt(time.Minute).IsZero() {
+		t.Error("expected never-checked dependency to be due now")
+	}
+
+	lastCheck := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	dep.LastCheck = lastCheck
+	if got := dep.NextCheckAt(time.Minute); !got.Equal(lastCheck.Add(10 * time.Second)) {
+		t.Errorf("expected next check 10s after last, got %v", got)
+	}
+}
+
+func TestSplitGRPCAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		hostPort string
+		useTLS   bool
+		wantErr  bool
+	}{
+		{"orders:50051", "orders:50051", false, false},
+		{"grpc://orders:50051", "orders:50051", false, false},
+		{"grpcs://orders:443", "orders:443", true, false},
+		{"[::1]:50051", "[::1]:50051", false, false},
+		{"orders", "", false, true},
+		{"grpcs://orders:0", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			hostPort, useTLS, err := SplitGRPCAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitGRPCAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if hostPort != tt.hostPort || useTLS != tt.useTLS {
+				t.Errorf("SplitGRPCAddress() = (%q, %v), want (%q, %v)", hostPort, useTLS, tt.hostPort, tt.useTLS)
+			}
+		})
+	}
+}
+
+func TestCertDaysRemaining(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      int
+	}{
+		{"30 days", now.Add(30 * 24 * time.Hour), 30},
+		{"partial day rounds down", now.Add(36 * time.Hour), 1},
+		{"expires today", now.Add(time.Hour), 0},
+		{"expired", now.Add(-36 * time.Hour), -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CertDaysRemaining(tt.expiresAt, now); got != tt.want {
+				t.Errorf("CertDaysRemaining() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependency_SetHeartbeatConfig_ClearsCertExpiryOnURLChange(t *testing.T) {
+	dep, _ := NewDependency(1, "Gateway", "")
+	dep.SetHeartbeat("https://gw.example.com/health", 60)
+	dep.CertExpiresAt = time.Now().Add(24 * time.Hour)
+
+	dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw.example.com/health", Interval: 30})
+	if dep.CertExpiresAt.IsZero() {
+		t.Error("expected cert expiry kept when the URL is unchanged")
+	}
+
+	dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw2.example.com/health", Interval: 30})
+	if !dep.CertExpiresAt.IsZero() {
+		t.Error("expected cert expiry cleared when the URL changes")
+	}
+}
+
+func TestDependency_SetHeartbeatConfig_Redirects(t *testing.T) {
+	dep, _ := NewDependency(1, "Gateway", "")
+	if !dep.HeartbeatFollowRedirects {
+		t.Error("expected redirects followed by default")
+	}
+
+	disabled := false
+	if err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw.example.com/", Interval: 60, FollowRedirects: &disabled}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := dep.GetHeartbeatConfig()
+	if config.FollowRedirects == nil || *config.FollowRedirects {
+		t.Error("expected follow_redirects false")
+	}
+	if config.RedirectLimit() != 0 {
+		t.Errorf("expected redirect limit 0, got %d", config.RedirectLimit())
+	}
+
+	if err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw.example.com/", Interval: 60, MaxRedirects: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dep.HeartbeatFollowRedirects {
+		t.Error("expected omitted follow_redirects to mean true")
+	}
+	if got := dep.GetHeartbeatConfig().RedirectLimit(); got != 3 {
+		t.Errorf("expected redirect limit 3, got %d", got)
+	}
+
+	for _, max := range []int{-1, MaxRedirectsLimit + 1} {
+		err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw.example.com/", Interval: 60, MaxRedirects: max})
+		if err != ErrInvalidMaxRedirects {
+			t.Errorf("max_redirects %d: expected ErrInvalidMaxRedirects, got %v", max, err)
+		}
+	}
+
+	dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://gw.example.com/", Interval: 60, FollowRedirects: &disabled})
+	dep.ClearHeartbeat()
+	if !dep.HeartbeatFollowRedirects || dep.HeartbeatMaxRedirects != 0 {
+		t.Error("expected ClearHeartbeat to restore redirect defaults")
+	}
+	if got := (HeartbeatConfig{}).RedirectLimit(); got != DefaultMaxRedirects {
+		t.Errorf("expected default redirect limit %d, got %d", DefaultMaxRedirects, got)
+	}
+}
+
+func TestDependency_SetHeartbeatConfig_Timeout(t *testing.T) {
+	dep, _ := NewDependency(1, "Reports", "")
+
+	if err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://reports.example.com/health", Interval: 60, TimeoutMs: 20000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := dep.GetHeartbeatConfig()
+	if config.TimeoutMs != 20000 || config.Timeout() != 20*time.Second {
+		t.Errorf("expected timeout 20s, got %d ms (%v)", config.TimeoutMs, config.Timeout())
+	}
+
+	for _, ms := range []int{MinHeartbeatTimeoutMs, MaxHeartbeatTimeoutMs, 0} {
+		if err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://reports.example.com/health", Interval: 60, TimeoutMs: ms}); err != nil {
+			t.Errorf("timeout_ms %d: unexpected error: %v", ms, err)
+		}
+	}
+	for _, ms := range []int{-1, 500, MaxHeartbeatTimeoutMs + 1} {
+		err := dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://reports.example.com/health", Interval: 60, TimeoutMs: ms})
+		if err != ErrInvalidHeartbeatTimeout {
+			t.Errorf("timeout_ms %d: expected ErrInvalidHeartbeatTimeout, got %v", ms, err)
+		}
+	}
+
+	dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://reports.example.com/health", Interval: 60, TimeoutMs: 3000})
+	dep.ClearHeartbeat()
+	if dep.HeartbeatTimeoutMs != 0 {
+		t.Errorf("expected ClearHeartbeat to reset the timeout, got %d", dep.HeartbeatTimeoutMs)
+	}
+}
+
+func TestHeartbeatConfig_CheckBudget(t *testing.T) {
+	tests := []struct {
+		config HeartbeatConfig
+		want   time.Duration
+	}{
+		{HeartbeatConfig{}, DefaultHeartbeatTimeout + heartbeatBudgetGrace},
+		{HeartbeatConfig{TimeoutMs: 3000}, 3*time.Second + heartbeatBudgetGrace},
+		{HeartbeatConfig{TimeoutMs: MaxHeartbeatTimeoutMs, Retries: MaxHeartbeatRetries}, 6*time.Minute + heartbeatBudgetGrace},
+	}
+	for _, tt := range tests {
+		if got := tt.config.CheckBudget(); got != tt.want {
+			t.Errorf("CheckBudget() for timeout %d ms and %d retries = %v, want %v", tt.config.TimeoutMs, tt.config.Retries, got, tt.want)
+		}
+	}
+}
+
+func TestDependency_SetHeartbeatConfig_DNS(t *testing.T) {
+	dep, _ := NewDependency(1, "CDN", "")
+
+	err := dep.SetHeartbeatConfig(HeartbeatConfig{CheckType: "dns", URL: "dns://cdn.example.com", Interval: 60, DNSRecordType: "cname", DNSExpect: "edge.example.net"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := dep.GetHeartbeatConfig()
+	if config.CheckType != CheckTypeDNS || config.DNSRecordType != DNSRecordCNAME || config.DNSExpect != "edge.example.net" {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	// The record type defaults to A
+	dep.SetHeartbeatConfig(HeartbeatConfig{CheckType: "dns", URL: "mail.example.com", Interval: 60})
+	if dep.HeartbeatDNSRecordType != DNSRecordA {
+		t.Errorf("expected default record type A, got %q", dep.HeartbeatDNSRecordType)
+	}
+
+	if err := dep.SetHeartbeatConfig(HeartbeatConfig{CheckType: "dns", URL: "mail.example.com", Interval: 60, DNSRecordType: "MX"}); err != ErrInvalidDNSRecordType {
+		t.Errorf("expected ErrInvalidDNSRecordType, got %v", err)
+	}
+	for _, target := range []string{"", "https://example.com", "example.com:53", "bad..example.com"} {
+		if err := dep.SetHeartbeatConfig(HeartbeatConfig{CheckType: "dns", URL: target, Interval: 60}); err != ErrInvalidHeartbeatURL {
+			t.Errorf("target %q: expected ErrInvalidHeartbeatURL, got %v", target, err)
+		}
+	}
+
+	// DNS options are dropped for other check types
+	dep.SetHeartbeatConfig(HeartbeatConfig{URL: "https://example.com", Interval: 60, DNSRecordType: "AAAA", DNSExpect: "::1"})
+	if dep.HeartbeatDNSRecordType != "" || dep.HeartbeatDNSExpect != "" {
+		t.Errorf("expected DNS options to be cleared, got %q/%q", dep.HeartbeatDNSRecordType, dep.HeartbeatDNSExpect)
+	}
+}
+
+func TestDependency_SetCriticality(t *testing.T) {
+	dep, _ := NewDependency(1, "Redis", "Cache")
+	if dep.Criticality != CriticalityCritical {
+		t.Errorf("expected default criticality critical, got %q", dep.Criticality)
+	}
+
+	if err := dep.SetCriticality(CriticalityMinor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dep.Criticality != CriticalityMinor {
+		t.Errorf("expected criticality minor, got %q", dep.Criticality)
+	}
+
+	if err := dep.SetCriticality("optional"); err != ErrInvalidCriticality {
+		t.Errorf("expected ErrInvalidCriticality, got %v", err)
+	}
+	if dep.Criticality != CriticalityMinor {
+		t.Errorf("expected criticality unchanged, got %q", dep.Criticality)
+	}
+}
+
+func TestPropagatedStatus(t *testing.T) {
+	tests := []struct {
+		status      Status
+		criticality Criticality
+		expected    Status
+	}{
+		{StatusGreen, CriticalityCritical, StatusGreen},
+		{StatusYellow, CriticalityCritical, StatusYellow},
+		{StatusPartial, CriticalityCritical, StatusPartial},
+		{StatusRed, CriticalityCritical, StatusRed},
+		{StatusGreen, CriticalityMajor, StatusGreen},
+		{StatusYellow, CriticalityMajor, StatusYellow},
+		{StatusPartial, CriticalityMajor, StatusYellow},
+		{StatusRed, CriticalityMajor, StatusYellow},
+		{StatusGreen, CriticalityMinor, StatusGreen},
+		{StatusYellow, CriticalityMinor, StatusGreen},
+		{StatusPartial, CriticalityMinor, StatusYellow},
+		{StatusRed, CriticalityMinor, StatusYellow},
+		{StatusRed, "", StatusRed},
+	}
+
+	for _, tt := range tests {
+		if got := PropagatedStatus(tt.status, tt.criticality); got != tt.expected {
+			t.Errorf("PropagatedStatus(%q, %q) = %q, expected %q", tt.status, tt.criticality, got, tt.expected)
+		}
+	}
+}
+
+func TestSystemStatusFromDependencies(t *testing.T) {
+	newDep := func(status Status, criticality Criticality) *Dependency {
+		dep, _ := NewDependency(1, "dep", "")
+		dep.Status = status
+		dep.Criticality = criticality
+		return dep
+	}
+
+	if got := SystemStatusFromDependencies(nil); got != StatusGreen {
+		t.Errorf("expected green without dependencies, got %q", got)
+	}
+
+	deps := []*Dependency{
+		newDep(StatusRed, CriticalityMinor),
+		newDep(StatusYellow, CriticalityMinor),
+		newDep(StatusGreen, CriticalityCritical),
+	}
+	if got := SystemStatusFromDependencies(deps); got != StatusYellow {
+		t.Errorf("expected yellow, got %q", got)
+	}
+
+	deps = append(deps, newDep(StatusRed, CriticalityCritical))
+	if got := SystemStatusFromDependencies(deps); got != StatusRed {
+		t.Errorf("expected red, got %q", got)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Authorization":  "Bearer secret",
+		"X-API-Key":      "k-123",
+		"Cookie":         "session=abc",
+		"X-Auth-Token":   "t-456",
+		"Accept":         "application/json",
+		"X-Request-From": "status",
+	}
+
+	redacted := RedactHeaders(headers)
+
+	for _, name := range []string{"Authorization", "X-API-Key", "Cookie", "X-Auth-Token"} {
+		if redacted[name] != RedactedHeaderValue {
+			t.Errorf("expected %s to be redacted, got %q", name, redacted[name])
+		}
+	}
+	for _, name := range []string{"Accept", "X-Request-From"} {
+		if redacted[name] != headers[name] {
+			t.Errorf("expected %s to be kept, got %q", name, redacted[name])
+		}
+	}
+	if headers["Authorization"] != "Bearer secret" {
+		t.Error("expected the original headers to be left untouched")
+	}
+	if RedactHeaders(nil) != nil {
+		t.Error("expected nil headers to stay nil")
+	}
+}
+
+func TestDependency_MarshalJSON_RedactsHeaders(t *testing.T) {
+	dep, _ := NewDependency(1, "Billing API", "")
+	if err := dep.SetHeartbeatConfig(HeartbeatConfig{
+		URL:      "https://billing.example.com/health",
+		Interval: 60,
+		Method:   "HEAD",
+		Headers:  map[string]string{"Authorization": "Bearer secret", "Accept": "text/plain"},
+	}); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+
+	data, err := json.Marshal(dep)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "Bearer secret") {
+		t.Errorf("expected the Authorization value to be redacted, got %s", data)
+	}
+
+	var decoded struct {
+		Name             string
+		HeartbeatMethod  string
+		HeartbeatHeaders map[string]string
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.Name != "Billing API" || decoded.HeartbeatMethod != "HEAD" {
+		t.Errorf("expected other fields to be serialized as before, got %+v", decoded)
+	}
+	if decoded.HeartbeatHeaders["Authorization"] != RedactedHeaderValue || decoded.HeartbeatHeaders["Accept"] != "text/plain" {
+		t.Errorf("unexpected headers %v", decoded.HeartbeatHeaders)
+	}
+	if dep.HeartbeatHeaders["Authorization"] != "Bearer secret" {
+		t.Error("expected the stored header to be kept for the checker")
+	}
+}
+
+func TestDependency_SetHeartbeatConfig_KeepsRedactedHeaders(t *testing.T) {
+	dep, _ := NewDependency(1, "Billing API", "")
+	config := HeartbeatConfig{
+		URL:      "https://billing.example.com/health",
+		Interval: 60,
+		Headers:  map[string]string{"Authorization": "Bearer secret"},
+	}
+	if err := dep.SetHeartbeatConfig(config); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+
+	// Saving the config as returned by the API keeps the secret
+	config.Interval = 30
+	config.Headers = map[string]string{"Authorization": RedactedHeaderValue, "X-API-Key": "new-key"}
+	if err := dep.SetHeartbeatConfig(config); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+	if dep.HeartbeatHeaders["Authorization"] != "Bearer secret" {
+		t.Errorf("expected stored Authorization to be kept, got %q", dep.HeartbeatHeaders["Authorization"])
+	}
+	if dep.HeartbeatHeaders["X-API-Key"] != "new-key" {
+		t.Errorf("expected new header to be set, got %q", dep.HeartbeatHeaders["X-API-Key"])
+	}
+}
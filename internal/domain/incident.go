@@ -2,9 +2,14 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
+// ErrIncidentNotAcknowledged is returned when an unacknowledged incident is
+// moved forward while acknowledgement is required
+var ErrIncidentNotAcknowledged = errors.New("incident must be acknowledged before moving to identified or monitoring")
+
 // IncidentStatus represents the current status of an incident
 type IncidentStatus string
 
@@ -13,6 +18,7 @@ const (
 	IncidentIdentified    IncidentStatus = "identified"
 	IncidentMonitoring    IncidentStatus = "monitoring"
 	IncidentResolved      IncidentStatus = "resolved"
+	IncidentScheduled     IncidentStatus = "scheduled" // announced, starts at ScheduledFor
 )
 
 // IncidentSeverity represents the severity level of an incident
@@ -31,15 +37,28 @@ type Incident struct {
 	Status      IncidentStatus
 	Severity    IncidentSeverity
 	SystemIDs   []int64 // Affected systems (nil = all)
+	Components  []AffectedComponent // Customer-facing components hit, e.g. "Checkout"
 	Message     string  // Initial message
 	Postmortem  string  // Post-incident summary
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	ResolvedAt  *time.Time
+	ScheduledFor *time.Time // Planned start of a scheduled incident
 	AcknowledgedAt *time.Time
 	AcknowledgedBy string
 }
 
+// maxComponentNameLength caps affected component labels
+const maxComponentNameLength = 100
+
+// AffectedComponent is a customer-facing part of the service hit by an
+// incident. It is a free-form label, optionally linked to the dependency
+// it corresponds to.
+type AffectedComponent struct {
+	Name         string `json:"name"`
+	DependencyID *int64 `json:"dependency_id,omitempty"`
+}
+
 // IncidentUpdate represents a timeline entry for an incident
 type IncidentUpdate struct {
 	ID         int64
@@ -78,12 +97,132 @@ func NewIncident(title, message string, severity IncidentSeverity) (*Incident, e
 	}, nil
 }
 
+// NewHistoricalIncident creates an incident that happened in the past,
+// keeping its original timestamps (used when importing from other tools)
+func NewHistoricalIncident(title, message string, severity IncidentSeverity, status IncidentStatus, createdAt time.Time, resolvedAt *time.Time) (*Incident, error) {
+	incident, err := NewIncident(title, message, severity)
+	if err != nil {
+		return nil, err
+	}
+	if createdAt.IsZero() {
+		return nil, errors.New("created_at is required")
+	}
+
+	switch status {
+	case "":
+		status = IncidentInvestigating
+		if resolvedAt != nil {
+			status = IncidentResolved
+		}
+	case IncidentInvestigating, IncidentIdentified, IncidentMonitoring, IncidentResolved:
+		// valid
+	default:
+		return nil, errors.New("invalid status")
+	}
+
+	if status == IncidentResolved && resolvedAt == nil {
+		return nil, errors.New("resolved_at is required for resolved incidents")
+	}
+	if status != IncidentResolved && resolvedAt != nil {
+		return nil, errors.New("resolved_at is only allowed for resolved incidents")
+	}
+	if resolvedAt != nil && resolvedAt.Before(createdAt) {
+		return nil, errors.New("resolved_at must not be before created_at")
+	}
+
+	incident.Status = status
+	incident.CreatedAt = createdAt
+	incident.UpdatedAt = createdAt
+	incident.ResolvedAt = resolvedAt
+	if resolvedAt != nil {
+		incident.UpdatedAt = *resolvedAt
+	}
+	return incident, nil
+}
+
 // SetSystemIDs sets the affected systems
 func (i *Incident) SetSystemIDs(ids []int64) {
 	i.SystemIDs = ids
 	i.UpdatedAt = time.Now()
 }
 
+// SetComponents replaces the affected components. Names are trimmed and
+// must be unique (case-insensitively); nil or empty clears the list.
+func (i *Incident) SetComponents(components []AffectedComponent) error {
+	seen := make(map[string]bool, len(components))
+	cleaned := make([]AffectedComponent, 0, len(components))
+	for _, c := range components {
+		c.Name = strings.TrimSpace(c.Name)
+		if c.Name == "" {
+			return errors.New("component name is required")
+		}
+		if len(c.Name) > maxComponentNameLength {
+			return errors.New("component name is too long")
+		}
+		if c.DependencyID != nil && *c.DependencyID <= 0 {
+			return errors.New("component dependency_id must be positive")
+		}
+		key := strings.ToLower(c.Name)
+		if seen[key] {
+			return errors.New("duplicate component: " + c.Name)
+		}
+		seen[key] = true
+		cleaned = append(cleaned, c)
+	}
+
+	if len(cleaned) == 0 {
+		cleaned = nil
+	}
+	i.Components = cleaned
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// ComponentNames returns the labels of the affected components
+func (i *Incident) ComponentNames() []string {
+	names := make([]string, len(i.Components))
+	for idx, c := range i.Components {
+		names[idx] = c.Name
+	}
+	return names
+}
+
+// Schedule turns the incident into an announcement of a planned degradation
+// that starts at the given time. Checking that the time lies in the future is
+// up to the caller.
+func (i *Incident) Schedule(at time.Time) error {
+	if i.Status != IncidentInvestigating && i.Status != IncidentScheduled {
+		return errors.New("only new incidents can be scheduled")
+	}
+	if at.IsZero() {
+		return errors.New("scheduled time is required")
+	}
+	i.Status = IncidentScheduled
+	i.ScheduledFor = &at
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsScheduled returns true if the incident is announced but has not started yet
+func (i *Incident) IsScheduled() bool {
+	return i.Status == IncidentScheduled
+}
+
+// IsDue returns true if a scheduled incident should have started by now
+func (i *Incident) IsDue(now time.Time) bool {
+	return i.IsScheduled() && i.ScheduledFor != nil && !now.Before(*i.ScheduledFor)
+}
+
+// Start moves a scheduled incident to investigating
+func (i *Incident) Start() error {
+	if !i.IsScheduled() {
+		return errors.New("incident is not scheduled")
+	}
+	i.Status = IncidentInvestigating
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
 // Acknowledge marks the incident as acknowledged
 func (i *Incident) Acknowledge(by string) error {
 	if i.AcknowledgedAt != nil {
@@ -96,6 +235,11 @@ func (i *Incident) Acknowledge(by string) error {
 	return nil
 }
 
+// IsAcknowledged returns true if someone has acknowledged the incident
+func (i *Incident) IsAcknowledged() bool {
+	return i.AcknowledgedAt != nil
+}
+
 // UpdateStatus updates the incident status
 func (i *Incident) UpdateStatus(status IncidentStatus) error {
 	if i.Status == IncidentResolved {
@@ -139,17 +283,35 @@ func (i *Incident) IsResolved() bool {
 	return i.Status == IncidentResolved
 }
 
-// IsActive returns true if the incident is not resolved
+// IsActive returns true if the incident has started and is not resolved
 func (i *Incident) IsActive() bool {
-	return i.Status != IncidentResolved
+	return i.Status != IncidentResolved && i.Status != IncidentScheduled
 }
 
-// Duration returns the duration of the incident
+// Duration returns the duration of the incident. Scheduled incidents count
+// from their planned start; one that has not started yet lasts zero.
 func (i *Incident) Duration() time.Duration {
+	return i.DurationAt(time.Now())
+}
+
+// DurationAt returns the duration of the incident as of now; resolved
+// incidents have a fixed duration
+func (i *Incident) DurationAt(now time.Time) time.Duration {
+	if i.IsScheduled() {
+		return 0
+	}
+	start := i.CreatedAt
+	if i.ScheduledFor != nil {
+		start = *i.ScheduledFor
+	}
+	end := now
 	if i.ResolvedAt != nil {
-		return i.ResolvedAt.Sub(i.CreatedAt)
+		end = *i.ResolvedAt
 	}
-	return time.Since(i.CreatedAt)
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
 }
 
 // AffectsSystem returns true if this incident affects the given system
@@ -180,6 +342,19 @@ func NewIncidentUpdate(incidentID int64, status IncidentStatus, message, created
 	}, nil
 }
 
+// Level returns the numeric severity level (1=minor, 2=major, 3=critical)
+func (s IncidentSeverity) Level() int {
+	switch s {
+	case SeverityMinor:
+		return 1
+	case SeverityMajor:
+		return 2
+	case SeverityCritical:
+		return 3
+	}
+	return 0
+}
+
 // SeverityEmoji returns an emoji for the severity
 func SeverityEmoji(s IncidentSeverity) string {
 	switch s {
@@ -205,6 +380,8 @@ func IncidentStatusEmoji(s IncidentStatus) string {
 		return "👀"
 	case IncidentResolved:
 		return "✅"
+	case IncidentScheduled:
+		return "🗓️"
 	default:
 		return "❓"
 	}
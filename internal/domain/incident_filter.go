@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidIncidentStatus   = errors.New("status must be investigating, identified, monitoring or resolved")
+	ErrInvalidIncidentSeverity = errors.New("severity must be minor, major or critical")
+	ErrInvalidIncidentSort     = errors.New("sort must be created_at, updated_at, resolved_at or severity, optionally prefixed with - for descending order")
+)
+
+// IncidentSortField is a column incident listings can be ordered by
+type IncidentSortField string
+
+const (
+	IncidentSortCreatedAt  IncidentSortField = "created_at"
+	IncidentSortUpdatedAt  IncidentSortField = "updated_at"
+	IncidentSortResolvedAt IncidentSortField = "resolved_at"
+	IncidentSortSeverity   IncidentSortField = "severity" // minor < major < critical
+)
+
+// IncidentFilter narrows and orders an incident listing. The zero value
+// matches every incident, newest first.
+type IncidentFilter struct {
+	Status    IncidentStatus    // empty matches any status
+	Severity  IncidentSeverity  // empty matches any severity
+	SystemID  int64             // 0 matches any; otherwise incidents affecting the system (see AffectsSystem)
+	SortBy    IncidentSortField // default created_at
+	Ascending bool              // default descending
+}
+
+// ParseIncidentStatus validates an incident status
+func ParseIncidentStatus(s string) (IncidentStatus, error) {
+	switch status := IncidentStatus(s); status {
+	case IncidentInvestigating, IncidentIdentified, IncidentMonitoring, IncidentResolved, IncidentScheduled:
+		return status, nil
+	}
+	return "", ErrInvalidIncidentStatus
+}
+
+// ParseIncidentSeverity validates an incident severity
+func ParseIncidentSeverity(s string) (IncidentSeverity, error) {
+	switch severity := IncidentSeverity(s); severity {
+	case SeverityMinor, SeverityMajor, SeverityCritical:
+		return severity, nil
+	}
+	return "", ErrInvalidIncidentSeverity
+}
+
+// ParseIncidentSort parses a sort key such as "created_at" (ascending) or
+// "-created_at" (descending)
+func ParseIncidentSort(s string) (field IncidentSortField, ascending bool, err error) {
+	ascending = !strings.HasPrefix(s, "-")
+	switch field = IncidentSortField(strings.TrimPrefix(s, "-")); field {
+	case IncidentSortCreatedAt, IncidentSortUpdatedAt, IncidentSortResolvedAt, IncidentSortSeverity:
+		return field, ascending, nil
+	}
+	return "", false, ErrInvalidIncidentSort
+}
+
+// Matches reports whether incident passes the filter's conditions
+func (f IncidentFilter) Matches(incident *Incident) bool {
+	if f.Status != "" && incident.Status != f.Status {
+		return false
+	}
+	if f.Severity != "" && incident.Severity != f.Severity {
+		return false
+	}
+	if f.SystemID != 0 && !incident.AffectsSystem(f.SystemID) {
+		return false
+	}
+	return true
+}
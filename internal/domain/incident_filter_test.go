@@ -0,0 +1,70 @@
+package domain
+
+import "testing"
+
+func TestParseIncidentSort(t *testing.T) {
+	tests := []struct {
+		input     string
+		field     IncidentSortField
+		ascending bool
+		wantErr   bool
+	}{
+		{"created_at", IncidentSortCreatedAt, true, false},
+		{"-created_at", IncidentSortCreatedAt, false, false},
+		{"-severity", IncidentSortSeverity, false, false},
+		{"resolved_at", IncidentSortResolvedAt, true, false},
+		{"title", "", false, true},
+		{"--created_at", "", false, true},
+	}
+
+	for _, tt := range tests {
+		field, ascending, err := ParseIncidentSort(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIncidentSort(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if field != tt.field || ascending != tt.ascending {
+			t.Errorf("ParseIncidentSort(%q) = %q, %v, expected %q, %v", tt.input, field, ascending, tt.field, tt.ascending)
+		}
+	}
+}
+
+func TestParseIncidentStatusAndSeverity(t *testing.T) {
+	if status, err := ParseIncidentStatus("monitoring"); err != nil || status != IncidentMonitoring {
+		t.Errorf("expected monitoring, got %q, %v", status, err)
+	}
+	if _, err := ParseIncidentStatus("closed"); err != ErrInvalidIncidentStatus {
+		t.Errorf("expected ErrInvalidIncidentStatus, got %v", err)
+	}
+
+	if severity, err := ParseIncidentSeverity("critical"); err != nil || severity != SeverityCritical {
+		t.Errorf("expected critical, got %q, %v", severity, err)
+	}
+	if _, err := ParseIncidentSeverity("high"); err != ErrInvalidIncidentSeverity {
+		t.Errorf("expected ErrInvalidIncidentSeverity, got %v", err)
+	}
+}
+
+func TestIncidentFilter_Matches(t *testing.T) {
+	incident, _ := NewIncident("DB down", "Message", SeverityCritical)
+	incident.SetSystemIDs([]int64{4})
+
+	tests := []struct {
+		name     string
+		filter   IncidentFilter
+		expected bool
+	}{
+		{"zero filter", IncidentFilter{}, true},
+		{"matching status", IncidentFilter{Status: IncidentInvestigating}, true},
+		{"other status", IncidentFilter{Status: IncidentResolved}, false},
+		{"other severity", IncidentFilter{Severity: SeverityMinor}, false},
+		{"affected system", IncidentFilter{SystemID: 4}, true},
+		{"unaffected system", IncidentFilter{SystemID: 5}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Matches(incident); got != tt.expected {
+			t.Errorf("%s: Matches() = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// IncidentTemplate pre-fills incidents of a recurring kind. The title pattern
+// may contain {name} placeholders that are filled in when the incident is
+// created from the template.
+type IncidentTemplate struct {
+	ID               int64
+	Name             string
+	TitlePattern     string
+	DefaultSeverity  IncidentSeverity
+	DefaultMessage   string
+	DefaultSystemIDs []int64 // nil = all systems
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewIncidentTemplate creates a new incident template. An empty severity
+// defaults to minor; an unknown one is rejected.
+func NewIncidentTemplate(name, titlePattern string, severity IncidentSeverity, message string, systemIDs []int64) (*IncidentTemplate, error) {
+	now := time.Now()
+	t := &IncidentTemplate{CreatedAt: now}
+	if err := t.Update(name, titlePattern, severity, message, systemIDs); err != nil {
+		return nil, err
+	}
+	t.UpdatedAt = now
+	return t, nil
+}
+
+// Update replaces the template fields, validating them like NewIncidentTemplate
+func (t *IncidentTemplate) Update(name, titlePattern string, severity IncidentSeverity, message string, systemIDs []int64) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	titlePattern = strings.TrimSpace(titlePattern)
+	if titlePattern == "" {
+		return errors.New("title pattern is required")
+	}
+	if severity == "" {
+		severity = SeverityMinor
+	}
+	if _, err := ParseIncidentSeverity(string(severity)); err != nil {
+		return err
+	}
+
+	t.Name = name
+	t.TitlePattern = titlePattern
+	t.DefaultSeverity = severity
+	t.DefaultMessage = strings.TrimSpace(message)
+	t.DefaultSystemIDs = systemIDs
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// RenderTitle fills the {name} placeholders of the title pattern from vars.
+// Placeholders without a value are left as they are.
+func (t *IncidentTemplate) RenderTitle(vars map[string]string) string {
+	if len(vars) == 0 {
+		return t.TitlePattern
+	}
+	pairs := make([]string, 0, len(vars)*2)
+	for name, value := range vars {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(t.TitlePattern)
+}
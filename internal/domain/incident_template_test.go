@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestNewIncidentTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		tmplName     string
+		titlePattern string
+		severity     IncidentSeverity
+		wantErr      bool
+		wantSeverity IncidentSeverity
+	}{
+		{"valid", "DB failover", "Database failover on {cluster}", SeverityMajor, false, SeverityMajor},
+		{"empty severity defaults to minor", "CDN", "CDN degradation", "", false, SeverityMinor},
+		{"invalid severity", "CDN", "CDN degradation", IncidentSeverity("catastrophic"), true, ""},
+		{"missing name", "  ", "Title", SeverityMinor, true, ""},
+		{"missing title pattern", "Name", "", SeverityMinor, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := NewIncidentTemplate(tt.tmplName, tt.titlePattern, tt.severity, "msg", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewIncidentTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tmpl.DefaultSeverity != tt.wantSeverity {
+				t.Errorf("expected severity %s, got %s", tt.wantSeverity, tmpl.DefaultSeverity)
+			}
+		})
+	}
+}
+
+func TestIncidentTemplate_RenderTitle(t *testing.T) {
+	tmpl, _ := NewIncidentTemplate("Latency", "High latency on {service} in {region}", SeverityMinor, "", nil)
+
+	if got := tmpl.RenderTitle(map[string]string{"service": "checkout", "region": "eu-west"}); got != "High latency on checkout in eu-west" {
+		t.Errorf("unexpected title: %q", got)
+	}
+	if got := tmpl.RenderTitle(map[string]string{"service": "checkout"}); got != "High latency on checkout in {region}" {
+		t.Errorf("expected unknown placeholders to be kept, got %q", got)
+	}
+	if got := tmpl.RenderTitle(nil); got != tmpl.TitlePattern {
+		t.Errorf("expected the pattern without variables, got %q", got)
+	}
+}
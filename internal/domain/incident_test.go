@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -111,6 +112,47 @@ func TestIncident_SetSystemIDs(t *testing.T) {
 	}
 }
 
+func TestIncident_SetComponents(t *testing.T) {
+	incident, _ := NewIncident("Test", "Test message", SeverityMinor)
+	depID := int64(7)
+
+	err := incident.SetComponents([]AffectedComponent{
+		{Name: "  Checkout "},
+		{Name: "Search", DependencyID: &depID},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := incident.ComponentNames(); len(names) != 2 || names[0] != "Checkout" || names[1] != "Search" {
+		t.Errorf("expected [Checkout Search], got %v", names)
+	}
+	if incident.Components[1].DependencyID == nil || *incident.Components[1].DependencyID != 7 {
+		t.Errorf("expected Search to link dependency 7, got %v", incident.Components[1].DependencyID)
+	}
+
+	invalid := map[string][]AffectedComponent{
+		"empty name":     {{Name: " "}},
+		"too long":       {{Name: strings.Repeat("x", maxComponentNameLength+1)}},
+		"duplicate":      {{Name: "Search"}, {Name: "search"}},
+		"bad dependency": {{Name: "API", DependencyID: new(int64)}},
+	}
+	for name, components := range invalid {
+		if err := incident.SetComponents(components); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if len(incident.Components) != 2 {
+		t.Errorf("expected a failed update to keep the components, got %v", incident.Components)
+	}
+
+	if err := incident.SetComponents([]AffectedComponent{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incident.Components != nil {
+		t.Errorf("expected an empty list to clear the components, got %v", incident.Components)
+	}
+}
+
 func TestIncident_Acknowledge(t *testing.T) {
 	incident, _ := NewIncident("Test", "Test message", SeverityMinor)
 
@@ -236,6 +278,73 @@ func TestIncident_IsActive(t *testing.T) {
 	}
 }
 
+func TestIncident_DurationAt(t *testing.T) {
+	incident, _ := NewIncident("Test", "Test message", SeverityMinor)
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	incident.CreatedAt = start
+
+	if got := incident.DurationAt(start.Add(90 * time.Minute)); got != 90*time.Minute {
+		t.Errorf("DurationAt() = %v, want 1h30m", got)
+	}
+
+	resolvedAt := start.Add(time.Hour)
+	incident.Resolve("")
+	incident.ResolvedAt = &resolvedAt
+	if got := incident.DurationAt(start.Add(5 * time.Hour)); got != time.Hour {
+		t.Errorf("DurationAt() for resolved incident = %v, want 1h", got)
+	}
+}
+
+func TestIncident_Schedule(t *testing.T) {
+	incident, _ := NewIncident("Database migration", "Writes will be slow", SeverityMinor)
+	at := time.Now().Add(time.Hour)
+
+	if err := incident.Schedule(time.Time{}); err == nil {
+		t.Error("expected error for a zero scheduled time")
+	}
+	if err := incident.Schedule(at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !incident.IsScheduled() || incident.ScheduledFor == nil || !incident.ScheduledFor.Equal(at) {
+		t.Errorf("expected incident scheduled for %v, got status %q at %v", at, incident.Status, incident.ScheduledFor)
+	}
+	if incident.IsActive() {
+		t.Error("expected a scheduled incident not to be active")
+	}
+	if incident.Duration() != 0 {
+		t.Errorf("expected zero duration before the start, got %v", incident.Duration())
+	}
+
+	if incident.IsDue(at.Add(-time.Second)) {
+		t.Error("expected incident not to be due before its time")
+	}
+	if !incident.IsDue(at) {
+		t.Error("expected incident to be due at its time")
+	}
+
+	if err := incident.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incident.Status != IncidentInvestigating || !incident.IsActive() {
+		t.Errorf("expected started incident to be investigating and active, got %q", incident.Status)
+	}
+	if incident.IsDue(at) {
+		t.Error("expected a started incident not to be due")
+	}
+	if err := incident.Start(); err == nil {
+		t.Error("expected error when starting an incident that is not scheduled")
+	}
+	if err := incident.Schedule(at); err != nil {
+		t.Errorf("expected an investigating incident to be reschedulable, got %v", err)
+	}
+
+	incident.Resolve("")
+	if err := incident.Schedule(at); err == nil {
+		t.Error("expected error when scheduling a resolved incident")
+	}
+}
+
 func TestIncident_Duration(t *testing.T) {
 	incident, _ := NewIncident("Test", "Test message", SeverityMinor)
 
@@ -380,3 +489,51 @@ func TestIncidentStatusEmoji(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHistoricalIncident(t *testing.T) {
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	resolved := created.Add(90 * time.Minute)
+	beforeCreated := created.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		status     IncidentStatus
+		createdAt  time.Time
+		resolvedAt *time.Time
+		wantStatus IncidentStatus
+		wantErr    bool
+	}{
+		{"resolved inferred from resolved_at", "", created, &resolved, IncidentResolved, false},
+		{"open defaults to investigating", "", created, nil, IncidentInvestigating, false},
+		{"explicit monitoring", IncidentMonitoring, created, nil, IncidentMonitoring, false},
+		{"missing created_at", "", time.Time{}, nil, "", true},
+		{"resolved without resolved_at", IncidentResolved, created, nil, "", true},
+		{"open with resolved_at", IncidentIdentified, created, &resolved, "", true},
+		{"resolved before created", "", created, &beforeCreated, "", true},
+		{"invalid status", IncidentStatus("bogus"), created, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident, err := NewHistoricalIncident("Outage", "Down", SeverityMajor, tt.status, tt.createdAt, tt.resolvedAt)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if incident.Status != tt.wantStatus {
+				t.Errorf("expected status %q, got %q", tt.wantStatus, incident.Status)
+			}
+			if !incident.CreatedAt.Equal(tt.createdAt) {
+				t.Errorf("expected created_at %v, got %v", tt.createdAt, incident.CreatedAt)
+			}
+			if tt.resolvedAt != nil && incident.Duration() != tt.resolvedAt.Sub(tt.createdAt) {
+				t.Errorf("expected duration %v, got %v", tt.resolvedAt.Sub(tt.createdAt), incident.Duration())
+			}
+		})
+	}
+}
@@ -15,12 +15,17 @@ type LatencyRecord struct {
 	CreatedAt    time.Time
 }
 
-// LatencyPoint represents aggregated latency data for charting
+// LatencyPoint represents aggregated latency data for charting. Percentiles
+// cover the successful checks of the bucket; a bucket without any checks
+// has a zero Count.
 type LatencyPoint struct {
 	Timestamp time.Time `json:"timestamp"`
 	AvgMs     float64   `json:"avg_ms"`
 	MinMs     int64     `json:"min_ms"`
 	MaxMs     int64     `json:"max_ms"`
+	P50Ms     int64     `json:"p50_ms"`
+	P95Ms     int64     `json:"p95_ms"`
+	P99Ms     int64     `json:"p99_ms"`
 	Count     int       `json:"count"`
 	Failures  int       `json:"failures"`
 }
@@ -36,20 +41,58 @@ type UptimePoint struct {
 
 // LatencyStats holds statistics for a dependency
 type LatencyStats struct {
-	DependencyID   int64         `json:"dependency_id"`
-	DependencyName string        `json:"dependency_name"`
-	Period         string        `json:"period"`
-	AvgLatencyMs   float64       `json:"avg_latency_ms"`
-	MinLatencyMs   int64         `json:"min_latency_ms"`
-	MaxLatencyMs   int64         `json:"max_latency_ms"`
-	P50LatencyMs   int64         `json:"p50_latency_ms"`
-	P95LatencyMs   int64         `json:"p95_latency_ms"`
-	P99LatencyMs   int64         `json:"p99_latency_ms"`
-	TotalChecks    int           `json:"total_checks"`
-	FailedChecks   int           `json:"failed_checks"`
-	UptimePercent  float64       `json:"uptime_percent"`
-	DataPoints     []LatencyPoint `json:"data_points,omitempty"`
-	UptimeHeatmap  []UptimePoint  `json:"uptime_heatmap,omitempty"`
+	DependencyID   int64             `json:"dependency_id"`
+	DependencyName string            `json:"dependency_name"`
+	Period         string            `json:"period"`
+	AvgLatencyMs   float64           `json:"avg_latency_ms"`
+	MinLatencyMs   int64             `json:"min_latency_ms"`
+	MaxLatencyMs   int64             `json:"max_latency_ms"`
+	P50LatencyMs   int64             `json:"p50_latency_ms"`
+	P95LatencyMs   int64             `json:"p95_latency_ms"`
+	P99LatencyMs   int64             `json:"p99_latency_ms"`
+	TotalChecks    int               `json:"total_checks"`
+	FailedChecks   int               `json:"failed_checks"`
+	UptimePercent  float64           `json:"uptime_percent"`
+	DataPoints     []LatencyPoint    `json:"data_points,omitempty"`
+	UptimeHeatmap  []UptimePoint     `json:"uptime_heatmap,omitempty"`
+	Incidents      []LatencyIncident `json:"incidents,omitempty"`
+}
+
+// LatencyIncident is an incident period of the dependency within the window
+// of a latency chart, for shading the chart
+type LatencyIncident struct {
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // nil if still ongoing
+	Severity  Status     `json:"severity"`           // worst status during the incident
+}
+
+// LatencyHistogramBounds are the upper bounds in milliseconds of LatencyHistogram buckets
+var LatencyHistogramBounds = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// LatencyHistogram counts latencies into cumulative buckets, Prometheus style
+type LatencyHistogram struct {
+	Buckets []int64 // Buckets[i] counts latencies <= LatencyHistogramBounds[i]
+	Count   int64   // all latencies, i.e. the +Inf bucket
+	SumMs   int64
+}
+
+// NewLatencyHistogram builds a histogram of the successful checks in records.
+// Failed checks are left out since their latency is a timeout or zero.
+func NewLatencyHistogram(records []*LatencyRecord) LatencyHistogram {
+	h := LatencyHistogram{Buckets: make([]int64, len(LatencyHistogramBounds))}
+	for _, r := range records {
+		if !r.Success {
+			continue
+		}
+		for i, bound := range LatencyHistogramBounds {
+			if r.LatencyMs <= bound {
+				h.Buckets[i]++
+			}
+		}
+		h.Count++
+		h.SumMs += r.LatencyMs
+	}
+	return h
 }
 
 // LatencyRepository defines operations for latency data persistence
@@ -30,3 +30,37 @@ func TestGetUptimeStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestNewLatencyHistogram(t *testing.T) {
+	var records []*LatencyRecord
+	for _, ms := range []int64{5, 10, 11, 80, 100, 400, 1500, 7000} {
+		records = append(records, &LatencyRecord{LatencyMs: ms, Success: true})
+	}
+	records = append(records, &LatencyRecord{LatencyMs: 30000, Success: false})
+
+	h := NewLatencyHistogram(records)
+
+	// Bounds:     10  25  50 100 250 500 1000 2500 5000
+	want := []int64{2, 3, 3, 5, 5, 6, 6, 7, 7}
+	for i, bound := range LatencyHistogramBounds {
+		if h.Buckets[i] != want[i] {
+			t.Errorf("bucket le=%d = %d, want %d", bound, h.Buckets[i], want[i])
+		}
+		if i > 0 && h.Buckets[i] < h.Buckets[i-1] {
+			t.Errorf("bucket le=%d is not cumulative", bound)
+		}
+	}
+	if h.Count != 8 {
+		t.Errorf("Count = %d, want 8 (failed check excluded)", h.Count)
+	}
+	if h.SumMs != 9106 {
+		t.Errorf("SumMs = %d, want 9106", h.SumMs)
+	}
+}
+
+func TestNewLatencyHistogram_Empty(t *testing.T) {
+	h := NewLatencyHistogram(nil)
+	if h.Count != 0 || h.SumMs != 0 || len(h.Buckets) != len(LatencyHistogramBounds) {
+		t.Errorf("unexpected empty histogram %+v", h)
+	}
+}
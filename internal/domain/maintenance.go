@@ -2,9 +2,15 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
+// ErrInvalidTimezone is returned for time zones that are not IANA names
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
 // MaintenanceStatus represents the status of a maintenance window
 type MaintenanceStatus string
 
@@ -15,6 +21,80 @@ const (
 	MaintenanceCancelled  MaintenanceStatus = "cancelled"
 )
 
+// MaintenanceReminder is a stage of a maintenance window that webhooks are
+// reminded about
+type MaintenanceReminder string
+
+const (
+	ReminderNone     MaintenanceReminder = ""
+	ReminderUpcoming MaintenanceReminder = "upcoming" // the lead time before the start
+	ReminderStarted  MaintenanceReminder = "started"
+	ReminderEnded    MaintenanceReminder = "ended"
+)
+
+// rank orders reminder stages; each stage is sent at most once and never
+// after a later one
+func (r MaintenanceReminder) rank() int {
+	switch r {
+	case ReminderUpcoming:
+		return 1
+	case ReminderStarted:
+		return 2
+	case ReminderEnded:
+		return 3
+	}
+	return 0
+}
+
+// RecurrenceType is how often a maintenance window repeats
+type RecurrenceType string
+
+const (
+	RecurrenceNone    RecurrenceType = "none"
+	RecurrenceDaily   RecurrenceType = "daily"
+	RecurrenceWeekly  RecurrenceType = "weekly"
+	RecurrenceMonthly RecurrenceType = "monthly"
+)
+
+// Recurrence describes how a maintenance window repeats
+type Recurrence struct {
+	Type     RecurrenceType
+	Interval int        // every Interval days, weeks or months
+	Until    *time.Time // no occurrence starts after this (nil = forever)
+}
+
+// IsRecurring returns true if the recurrence repeats the window
+func (r Recurrence) IsRecurring() bool {
+	return r.Type != "" && r.Type != RecurrenceNone
+}
+
+// Validate checks the recurrence type and interval
+func (r Recurrence) Validate() error {
+	switch r.Type {
+	case "", RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	default:
+		return errors.New("recurrence type must be none, daily, weekly or monthly")
+	}
+	if r.Interval < 0 {
+		return errors.New("recurrence interval cannot be negative")
+	}
+	return nil
+}
+
+// advance moves t forward by one recurrence step
+func (r Recurrence) advance(t time.Time) time.Time {
+	n := max(1, r.Interval)
+	switch r.Type {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, n)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7*n)
+	case RecurrenceMonthly:
+		return t.AddDate(0, n, 0)
+	}
+	return t
+}
+
 // Maintenance represents a scheduled maintenance window
 type Maintenance struct {
 	ID          int64
@@ -22,8 +102,21 @@ type Maintenance struct {
 	Description string
 	StartTime   time.Time
 	EndTime     time.Time
-	SystemIDs   []int64           // nil = all systems
+	SystemIDs   []int64 // nil = all systems
 	Status      MaintenanceStatus
+	// Timezone is the IANA zone the window is shown in, e.g. "Europe/Riga";
+	// recurring windows repeat at the same local time in it
+	Timezone string
+	// NotifySubscribers sends webhook notifications when the window is
+	// scheduled and when it starts
+	NotifySubscribers bool
+	StartNotified     bool // start notification already sent
+	// LastReminder is the latest reminder stage sent for the window
+	LastReminder MaintenanceReminder
+	// Recurrence repeats the window; the next occurrence is created once
+	// this one ends, and NextCreated records that it has been
+	Recurrence  Recurrence
+	NextCreated bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -44,22 +137,18 @@ func NewMaintenance(title, description string, startTime, endTime time.Time) (*M
 	}
 
 	now := time.Now()
-	status := MaintenanceScheduled
-	if now.After(startTime) && now.Before(endTime) {
-		status = MaintenanceInProgress
-	} else if now.After(endTime) {
-		status = MaintenanceCompleted
-	}
-
-	return &Maintenance{
+	m := &Maintenance{
 		Title:       title,
 		Description: description,
 		StartTime:   startTime,
 		EndTime:     endTime,
-		Status:      status,
+		Status:      MaintenanceScheduled,
+		Timezone:    "UTC",
 		CreatedAt:   now,
 		UpdatedAt:   now,
-	}, nil
+	}
+	m.RefreshStatusAt(now)
+	return m, nil
 }
 
 // Update updates the maintenance window details
@@ -86,13 +175,152 @@ func (m *Maintenance) SetSystemIDs(ids []int64) {
 	m.UpdatedAt = time.Now()
 }
 
+// SetTimezone sets the IANA time zone the window is shown in; empty means
+// UTC. "Local" is rejected as it depends on the server.
+func (m *Maintenance) SetTimezone(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "UTC"
+	}
+	if name == "Local" {
+		return fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+
+	m.Timezone = name
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// Location returns the time zone of the window, UTC when unset or unknown
+func (m *Maintenance) Location() *time.Location {
+	if m.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// SetRecurrence sets how the window repeats. A zero interval means every
+// day, week or month; Until must not be before the window starts.
+func (m *Maintenance) SetRecurrence(r Recurrence) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if !r.IsRecurring() {
+		r = Recurrence{Type: RecurrenceNone}
+	} else {
+		r.Interval = max(1, r.Interval)
+		if r.Until != nil && r.Until.Before(m.StartTime) {
+			return errors.New("recurrence end date must be after start time")
+		}
+	}
+
+	m.Recurrence = r
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// NextOccurrence returns the first occurrence of a recurring window that has
+// not ended by now, as a new unsaved maintenance. Occurrences missed while
+// nothing was running are skipped. Returns nil when the window does not
+// recur or the series ends before then.
+func (m *Maintenance) NextOccurrence(now time.Time) *Maintenance {
+	if !m.Recurrence.IsRecurring() {
+		return nil
+	}
+
+	duration := m.EndTime.Sub(m.StartTime)
+	start := m.Recurrence.advance(m.StartTime.In(m.Location()))
+	for !start.Add(duration).After(now) {
+		start = m.Recurrence.advance(start)
+	}
+	if m.Recurrence.Until != nil && start.After(*m.Recurrence.Until) {
+		return nil
+	}
+
+	next := &Maintenance{
+		Title:             m.Title,
+		Description:       m.Description,
+		StartTime:         start,
+		EndTime:           start.Add(duration),
+		SystemIDs:         m.SystemIDs,
+		Timezone:          m.Timezone,
+		NotifySubscribers: m.NotifySubscribers,
+		Recurrence:        m.Recurrence,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	next.RefreshStatusAt(now)
+	return next
+}
+
+// NeedsNextOccurrence returns true if the window recurs, has ended and its
+// next occurrence has not been created yet. Cancelling a single occurrence
+// does not stop the series.
+func (m *Maintenance) NeedsNextOccurrence(now time.Time) bool {
+	return m.Recurrence.IsRecurring() && !m.NextCreated && !now.Before(m.EndTime)
+}
+
+// SetNotifySubscribers toggles subscriber notifications for this window
+func (m *Maintenance) SetNotifySubscribers(notify bool) {
+	m.NotifySubscribers = notify
+	m.UpdatedAt = time.Now()
+}
+
+// NeedsStartNotification returns true if the window has started and
+// subscribers have not been told yet
+func (m *Maintenance) NeedsStartNotification() bool {
+	return m.NotifySubscribers && !m.StartNotified && m.Status == MaintenanceInProgress
+}
+
+// DueReminder returns the reminder stage to send as of now, or ReminderNone.
+// Windows are reminded about lead before they start, when they start and
+// when they end; stages missed in between are skipped, and the end is only
+// announced after an earlier reminder. A zero lead disables reminders.
+func (m *Maintenance) DueReminder(now time.Time, lead time.Duration) MaintenanceReminder {
+	if lead <= 0 || m.Status == MaintenanceCancelled {
+		return ReminderNone
+	}
+
+	var due MaintenanceReminder
+	switch {
+	case now.After(m.EndTime):
+		if m.LastReminder == ReminderNone {
+			return ReminderNone
+		}
+		due = ReminderEnded
+	case !now.Before(m.StartTime):
+		due = ReminderStarted
+	case !now.Before(m.StartTime.Add(-lead)):
+		due = ReminderUpcoming
+	default:
+		return ReminderNone
+	}
+
+	if due.rank() <= m.LastReminder.rank() {
+		return ReminderNone
+	}
+	return due
+}
+
 // RefreshStatus updates the status based on current time
 func (m *Maintenance) RefreshStatus() {
+	m.RefreshStatusAt(time.Now())
+}
+
+// RefreshStatusAt updates the status as of now. A window is in progress from
+// its start time up to and including its end time.
+func (m *Maintenance) RefreshStatusAt(now time.Time) {
 	if m.Status == MaintenanceCancelled {
 		return // Don't change cancelled status
 	}
 
-	now := time.Now()
 	if now.Before(m.StartTime) {
 		m.Status = MaintenanceScheduled
 	} else if now.After(m.EndTime) {
@@ -110,7 +338,12 @@ func (m *Maintenance) Cancel() {
 
 // IsActive returns true if maintenance is currently in progress
 func (m *Maintenance) IsActive() bool {
-	m.RefreshStatus()
+	return m.IsActiveAt(time.Now())
+}
+
+// IsActiveAt returns true if maintenance is in progress at the given time
+func (m *Maintenance) IsActiveAt(now time.Time) bool {
+	m.RefreshStatusAt(now)
 	return m.Status == MaintenanceInProgress
 }
 
@@ -127,9 +360,35 @@ func (m *Maintenance) AffectsSystem(systemID int64) bool {
 	return false
 }
 
+// ConflictsWith returns true if both windows cover a shared system at the same
+// time. Windows that only touch (one ends when the other starts) do not
+// conflict, and cancelled windows never do.
+func (m *Maintenance) ConflictsWith(other *Maintenance) bool {
+	if m.Status == MaintenanceCancelled || other.Status == MaintenanceCancelled {
+		return false
+	}
+	if !m.StartTime.Before(other.EndTime) || !other.StartTime.Before(m.EndTime) {
+		return false
+	}
+	if len(m.SystemIDs) == 0 || len(other.SystemIDs) == 0 {
+		return true // one of them covers all systems
+	}
+	for _, id := range m.SystemIDs {
+		if other.AffectsSystem(id) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsUpcoming returns true if maintenance is scheduled for the future
 func (m *Maintenance) IsUpcoming() bool {
-	return m.Status == MaintenanceScheduled && time.Now().Before(m.StartTime)
+	return m.IsUpcomingAt(time.Now())
+}
+
+// IsUpcomingAt returns true if maintenance is scheduled to start after the given time
+func (m *Maintenance) IsUpcomingAt(now time.Time) bool {
+	return m.Status == MaintenanceScheduled && now.Before(m.StartTime)
 }
 
 // TimeUntilStart returns duration until maintenance starts (negative if already started)
@@ -141,3 +400,73 @@ func (m *Maintenance) TimeUntilStart() time.Duration {
 func (m *Maintenance) TimeUntilEnd() time.Duration {
 	return m.EndTime.Sub(time.Now())
 }
+
+// MaintenanceOverlap returns how much incident time within [start, end] is
+// covered by maintenance windows: downtime overlaps any incident, unavailable
+// only red ones. Ongoing incidents run until now. Cancelled windows are
+// ignored, and overlapping windows are counted once.
+func MaintenanceOverlap(incidents []IncidentPeriod, windows []*Maintenance, start, end, now time.Time) (downtime, unavailable time.Duration) {
+	type interval struct{ from, to time.Time }
+
+	var covered []interval
+	for _, m := range windows {
+		if m.Status == MaintenanceCancelled {
+			continue
+		}
+		from, to := maxTime(m.StartTime, start), minTime(m.EndTime, end)
+		if to.After(from) {
+			covered = append(covered, interval{from, to})
+		}
+	}
+	if len(covered) == 0 {
+		return 0, 0
+	}
+
+	// Merge windows so shared time is not subtracted twice
+	sort.Slice(covered, func(i, j int) bool { return covered[i].from.Before(covered[j].from) })
+	merged := covered[:1]
+	for _, c := range covered[1:] {
+		last := &merged[len(merged)-1]
+		if !c.from.After(last.to) {
+			last.to = maxTime(last.to, c.to)
+			continue
+		}
+		merged = append(merged, c)
+	}
+
+	for _, inc := range incidents {
+		incEnd := now
+		if inc.EndedAt != nil {
+			incEnd = *inc.EndedAt
+		}
+		from, to := maxTime(inc.StartedAt, start), minTime(incEnd, end)
+
+		var overlap time.Duration
+		for _, c := range merged {
+			if o := minTime(to, c.to).Sub(maxTime(from, c.from)); o > 0 {
+				overlap += o
+			}
+		}
+
+		downtime += overlap
+		if inc.MaxSeverity == StatusRed {
+			unavailable += overlap
+		}
+	}
+
+	return downtime, unavailable
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
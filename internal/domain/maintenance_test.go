@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -254,6 +255,39 @@ func TestMaintenance_RefreshStatus(t *testing.T) {
 	}
 }
 
+func TestMaintenance_RefreshStatusAt_Boundaries(t *testing.T) {
+	start := time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	m, _ := NewMaintenance("Upgrade", "", start, end)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want MaintenanceStatus
+	}{
+		{"just before start", start.Add(-time.Nanosecond), MaintenanceScheduled},
+		{"exactly at start", start, MaintenanceInProgress},
+		{"exactly at end", end, MaintenanceInProgress},
+		{"just after end", end.Add(time.Nanosecond), MaintenanceCompleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.RefreshStatusAt(tt.now)
+			if m.Status != tt.want {
+				t.Errorf("status at %v = %q, want %q", tt.now, m.Status, tt.want)
+			}
+		})
+	}
+
+	m.RefreshStatusAt(start.Add(-time.Second))
+	if !m.IsUpcomingAt(start.Add(-time.Second)) || m.IsUpcomingAt(start) {
+		t.Error("expected the window to be upcoming only before its start")
+	}
+	if !m.IsActiveAt(start) {
+		t.Error("expected the window to be active exactly at its start")
+	}
+}
+
 func TestMaintenance_Cancel(t *testing.T) {
 	now := time.Now()
 	m, _ := NewMaintenance("Test", "Desc", now.Add(1*time.Hour), now.Add(2*time.Hour))
@@ -358,3 +392,306 @@ func TestMaintenance_TimeUntilEnd(t *testing.T) {
 		t.Errorf("expected ~2 hours until end, got %v", duration)
 	}
 }
+
+func TestMaintenanceOverlap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	now := end.Add(time.Hour)
+	at := func(h float64) time.Time { return start.Add(time.Duration(h * float64(time.Hour))) }
+	incident := func(from, to float64, severity Status) IncidentPeriod {
+		ended := at(to)
+		return IncidentPeriod{StartedAt: at(from), EndedAt: &ended, MaxSeverity: severity}
+	}
+	window := func(from, to float64, status MaintenanceStatus) *Maintenance {
+		return &Maintenance{StartTime: at(from), EndTime: at(to), Status: status}
+	}
+
+	tests := []struct {
+		name            string
+		incidents       []IncidentPeriod
+		windows         []*Maintenance
+		wantDowntime    time.Duration
+		wantUnavailable time.Duration
+	}{
+		{
+			name:            "outage fully inside window",
+			incidents:       []IncidentPeriod{incident(2, 3, StatusRed)},
+			windows:         []*Maintenance{window(1, 4, MaintenanceCompleted)},
+			wantDowntime:    time.Hour,
+			wantUnavailable: time.Hour,
+		},
+		{
+			name:         "partial overlap",
+			incidents:    []IncidentPeriod{incident(2, 6, StatusYellow)},
+			windows:      []*Maintenance{window(5, 8, MaintenanceCompleted)},
+			wantDowntime: time.Hour,
+		},
+		{
+			name:      "cancelled window still counts",
+			incidents: []IncidentPeriod{incident(2, 3, StatusRed)},
+			windows:   []*Maintenance{window(1, 4, MaintenanceCancelled)},
+		},
+		{
+			name:            "overlapping windows counted once",
+			incidents:       []IncidentPeriod{incident(2, 6, StatusRed)},
+			windows:         []*Maintenance{window(1, 4, MaintenanceCompleted), window(3, 5, MaintenanceCompleted)},
+			wantDowntime:    3 * time.Hour,
+			wantUnavailable: 3 * time.Hour,
+		},
+		{
+			name:         "window clipped to report period",
+			incidents:    []IncidentPeriod{incident(-2, 1, StatusYellow)},
+			windows:      []*Maintenance{window(-3, 2, MaintenanceCompleted)},
+			wantDowntime: time.Hour,
+		},
+		{
+			name:            "ongoing incident runs until period end",
+			incidents:       []IncidentPeriod{{StartedAt: at(22), MaxSeverity: StatusRed}},
+			windows:         []*Maintenance{window(21, 30, MaintenanceInProgress)},
+			wantDowntime:    2 * time.Hour,
+			wantUnavailable: 2 * time.Hour,
+		},
+		{
+			name:      "no overlap",
+			incidents: []IncidentPeriod{incident(2, 3, StatusRed)},
+			windows:   []*Maintenance{window(5, 6, MaintenanceCompleted)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downtime, unavailable := MaintenanceOverlap(tt.incidents, tt.windows, start, end, now)
+			if downtime != tt.wantDowntime {
+				t.Errorf("downtime = %v, want %v", downtime, tt.wantDowntime)
+			}
+			if unavailable != tt.wantUnavailable {
+				t.Errorf("unavailable = %v, want %v", unavailable, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestMaintenance_SetRecurrence(t *testing.T) {
+	start := time.Date(2024, 3, 3, 2, 0, 0, 0, time.UTC)
+	m, _ := NewMaintenance("DB maintenance", "", start, start.Add(2*time.Hour))
+
+	if err := m.SetRecurrence(Recurrence{Type: RecurrenceWeekly}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Recurrence.Interval != 1 {
+		t.Errorf("expected interval to default to 1, got %d", m.Recurrence.Interval)
+	}
+
+	before := start.Add(-time.Hour)
+	invalid := []Recurrence{
+		{Type: "hourly"},
+		{Type: RecurrenceDaily, Interval: -1},
+		{Type: RecurrenceDaily, Until: &before},
+	}
+	for _, r := range invalid {
+		if err := m.SetRecurrence(r); err == nil {
+			t.Errorf("expected error for %+v", r)
+		}
+	}
+
+	if err := m.SetRecurrence(Recurrence{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Recurrence.IsRecurring() || m.Recurrence.Type != RecurrenceNone {
+		t.Errorf("expected empty recurrence to be none, got %+v", m.Recurrence)
+	}
+}
+
+func TestMaintenance_NextOccurrence_Weekly(t *testing.T) {
+	// Sunday 02:00-04:00
+	start := time.Date(2024, 3, 3, 2, 0, 0, 0, time.UTC)
+	m, _ := NewMaintenance("DB maintenance", "Weekly vacuum", start, start.Add(2*time.Hour))
+	m.SetSystemIDs([]int64{4})
+	m.SetNotifySubscribers(true)
+	m.SetRecurrence(Recurrence{Type: RecurrenceWeekly})
+
+	now := start.Add(time.Hour)
+	if m.NeedsNextOccurrence(now) {
+		t.Error("expected no next occurrence while the window is running")
+	}
+
+	now = start.Add(5 * time.Hour)
+	if !m.NeedsNextOccurrence(now) {
+		t.Error("expected next occurrence once the window has ended")
+	}
+
+	next := m.NextOccurrence(now)
+	if next == nil {
+		t.Fatal("expected next occurrence")
+	}
+	wantStart := time.Date(2024, 3, 10, 2, 0, 0, 0, time.UTC)
+	if !next.StartTime.Equal(wantStart) || !next.EndTime.Equal(wantStart.Add(2*time.Hour)) {
+		t.Errorf("expected next window %v-%v, got %v-%v", wantStart, wantStart.Add(2*time.Hour), next.StartTime, next.EndTime)
+	}
+	if next.ID != 0 || next.Title != m.Title || len(next.SystemIDs) != 1 || !next.NotifySubscribers {
+		t.Errorf("expected unsaved copy of the window, got %+v", next)
+	}
+	if next.Recurrence.Type != RecurrenceWeekly {
+		t.Errorf("expected next occurrence to keep recurring, got %+v", next.Recurrence)
+	}
+
+	// Occurrences missed while nothing was running are skipped
+	now = time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	next = m.NextOccurrence(now)
+	if want := time.Date(2024, 3, 24, 2, 0, 0, 0, time.UTC); next == nil || !next.StartTime.Equal(want) {
+		t.Errorf("expected next occurrence on %v, got %+v", want, next)
+	}
+
+	m.NextCreated = true
+	if m.NeedsNextOccurrence(now) {
+		t.Error("expected no next occurrence once created")
+	}
+}
+
+func TestMaintenance_NextOccurrence_Intervals(t *testing.T) {
+	start := time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		recurrence Recurrence
+		want       time.Time
+	}{
+		{Recurrence{Type: RecurrenceDaily, Interval: 3}, time.Date(2024, 1, 18, 2, 0, 0, 0, time.UTC)},
+		{Recurrence{Type: RecurrenceWeekly, Interval: 2}, time.Date(2024, 1, 29, 2, 0, 0, 0, time.UTC)},
+		{Recurrence{Type: RecurrenceMonthly, Interval: 1}, time.Date(2024, 2, 15, 2, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.recurrence.Type), func(t *testing.T) {
+			m, _ := NewMaintenance("Test", "", start, start.Add(time.Hour))
+			m.SetRecurrence(tt.recurrence)
+
+			next := m.NextOccurrence(start.Add(2 * time.Hour))
+			if next == nil || !next.StartTime.Equal(tt.want) {
+				t.Errorf("expected next occurrence at %v, got %+v", tt.want, next)
+			}
+		})
+	}
+}
+
+func TestMaintenance_NextOccurrence_StopsAfterUntil(t *testing.T) {
+	start := time.Date(2024, 3, 3, 2, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+	m, _ := NewMaintenance("DB maintenance", "", start, start.Add(2*time.Hour))
+	m.SetRecurrence(Recurrence{Type: RecurrenceWeekly, Until: &until})
+
+	next := m.NextOccurrence(start.Add(5 * time.Hour))
+	if next == nil {
+		t.Fatal("expected occurrence before the end date")
+	}
+
+	if last := next.NextOccurrence(next.EndTime.Add(time.Hour)); last != nil {
+		t.Errorf("expected no occurrence after the end date, got %v", last.StartTime)
+	}
+
+	once, _ := NewMaintenance("One-off", "", start, start.Add(time.Hour))
+	if once.NextOccurrence(start.Add(2*time.Hour)) != nil || once.NeedsNextOccurrence(start.Add(2*time.Hour)) {
+		t.Error("expected no next occurrence for a non-recurring window")
+	}
+}
+
+func TestMaintenance_SetTimezone(t *testing.T) {
+	now := time.Now()
+	m, _ := NewMaintenance("Test", "", now.Add(time.Hour), now.Add(2*time.Hour))
+	if m.Timezone != "UTC" || m.Location() != time.UTC {
+		t.Errorf("expected new maintenance in UTC, got %q", m.Timezone)
+	}
+
+	if err := m.SetTimezone("Europe/Riga"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Timezone != "Europe/Riga" || m.Location().String() != "Europe/Riga" {
+		t.Errorf("expected Europe/Riga, got %q", m.Timezone)
+	}
+
+	if err := m.SetTimezone(" "); err != nil || m.Timezone != "UTC" {
+		t.Errorf("expected blank timezone to mean UTC, got %q (err %v)", m.Timezone, err)
+	}
+
+	for _, name := range []string{"Mars/Olympus_Mons", "Local", "UTC+3", "EST5EDT ; drop", "../../etc/passwd", "europe/riga"} {
+		m.SetTimezone("Asia/Tokyo")
+		err := m.SetTimezone(name)
+		if !errors.Is(err, ErrInvalidTimezone) {
+			t.Errorf("SetTimezone(%q) error = %v, want ErrInvalidTimezone", name, err)
+		}
+		if m.Timezone != "Asia/Tokyo" {
+			t.Errorf("SetTimezone(%q) changed timezone to %q", name, m.Timezone)
+		}
+	}
+
+	unknown := &Maintenance{Timezone: "Nowhere/Special"}
+	if unknown.Location() != time.UTC {
+		t.Error("expected unknown stored timezone to fall back to UTC")
+	}
+}
+
+func TestMaintenance_NextOccurrence_KeepsLocalTimeAcrossDST(t *testing.T) {
+	riga, err := time.LoadLocation("Europe/Riga")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Monday 10:00 EET, a week before clocks go forward to EEST
+	start := time.Date(2024, 3, 25, 10, 0, 0, 0, riga).UTC()
+	m, _ := NewMaintenance("Weekly", "", start, start.Add(time.Hour))
+	m.SetTimezone("Europe/Riga")
+	m.SetRecurrence(Recurrence{Type: RecurrenceWeekly})
+
+	next := m.NextOccurrence(start.Add(2 * time.Hour))
+	if next == nil {
+		t.Fatal("expected next occurrence")
+	}
+	if local := next.StartTime.In(riga); local.Hour() != 10 || local.Day() != 1 {
+		t.Errorf("expected Apr 1 10:00 local, got %v", local)
+	}
+	if next.StartTime.Sub(start) != 7*24*time.Hour-time.Hour {
+		t.Errorf("expected the UTC start to move an hour earlier, got %v later", next.StartTime.Sub(start))
+	}
+	if next.Timezone != "Europe/Riga" {
+		t.Errorf("expected next occurrence to keep the timezone, got %q", next.Timezone)
+	}
+}
+
+func TestMaintenance_DueReminder(t *testing.T) {
+	start := time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	lead := 30 * time.Minute
+
+	tests := []struct {
+		name string
+		now  time.Time
+		last MaintenanceReminder
+		want MaintenanceReminder
+	}{
+		{"before lead time", start.Add(-time.Hour), ReminderNone, ReminderNone},
+		{"within lead time", start.Add(-10 * time.Minute), ReminderNone, ReminderUpcoming},
+		{"upcoming already sent", start.Add(-5 * time.Minute), ReminderUpcoming, ReminderNone},
+		{"at start", start, ReminderUpcoming, ReminderStarted},
+		{"start after missed upcoming", start.Add(time.Minute), ReminderNone, ReminderStarted},
+		{"started already sent", start.Add(time.Minute), ReminderStarted, ReminderNone},
+		{"after end", end.Add(time.Minute), ReminderStarted, ReminderEnded},
+		{"ended already sent", end.Add(time.Minute), ReminderEnded, ReminderNone},
+		{"end without earlier reminder", end.Add(time.Minute), ReminderNone, ReminderNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Maintenance{StartTime: start, EndTime: end, Status: MaintenanceScheduled, LastReminder: tt.last}
+			if got := m.DueReminder(tt.now, lead); got != tt.want {
+				t.Errorf("DueReminder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	m := &Maintenance{StartTime: start, EndTime: end, Status: MaintenanceCancelled}
+	if got := m.DueReminder(start.Add(-time.Minute), lead); got != ReminderNone {
+		t.Errorf("expected no reminder for a cancelled window, got %q", got)
+	}
+	m.Status = MaintenanceScheduled
+	if got := m.DueReminder(start.Add(-time.Minute), 0); got != ReminderNone {
+		t.Errorf("expected no reminder with a zero lead, got %q", got)
+	}
+}
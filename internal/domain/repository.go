@@ -13,6 +13,9 @@ type SystemRepository interface {
 	// GetByID retrieves a system by ID
 	GetByID(ctx context.Context, id int64) (*System, error)
 
+	// GetBySlug retrieves a system by its public page slug (nil if none)
+	GetBySlug(ctx context.Context, slug string) (*System, error)
+
 	// GetAll retrieves all systems
 	GetAll(ctx context.Context) ([]*System, error)
 
@@ -58,6 +61,12 @@ type StatusLogRepository interface {
 	// GetAll retrieves all logs with optional limit
 	GetAll(ctx context.Context, limit int) ([]*StatusLog, error)
 
+	// GetPage retrieves up to limit logs, newest first, skipping the first offset
+	GetPage(ctx context.Context, limit, offset int) ([]*StatusLog, error)
+
+	// Count returns the total number of logs
+	Count(ctx context.Context) (int, error)
+
 	// GetByTimeRange retrieves logs within a time range
 	GetByTimeRange(ctx context.Context, start, end time.Time) ([]*StatusLog, error)
 
@@ -66,6 +75,9 @@ type StatusLogRepository interface {
 
 	// GetDependencyLogsByTimeRange retrieves dependency logs within time range
 	GetDependencyLogsByTimeRange(ctx context.Context, dependencyID int64, start, end time.Time) ([]*StatusLog, error)
+
+	// Cleanup removes logs created before olderThan
+	Cleanup(ctx context.Context, olderThan time.Time) error
 }
 
 // AnalyticsRepository defines operations for analytics queries
@@ -84,6 +96,9 @@ type AnalyticsRepository interface {
 
 	// GetOverallAnalytics returns aggregate analytics for all systems
 	GetOverallAnalytics(ctx context.Context, start, end time.Time) (*Analytics, error)
+
+	// RollupDailyUptime precomputes uptime totals of every system for the day containing day
+	RollupDailyUptime(ctx context.Context, day time.Time) error
 }
 
 // HealthCheckResult contains the result of a health check
@@ -92,6 +107,21 @@ type HealthCheckResult struct {
 	LatencyMs  int64
 	StatusCode int
 	Error      error
+	// Degraded is set when the status matched but the body did not, or
+	// the TLS certificate expires within the configured warning window
+	Degraded bool
+	// BodySnippet holds the start of the response body when a body
+	// expectation failed, for debugging mismatches, or the failure reason
+	// of checks without a body (e.g. a gRPC error or serving status)
+	BodySnippet string
+	// CertExpiresAt is the leaf certificate expiry of an HTTPS check and
+	// CertDaysRemaining the whole days left (negative once expired); zero
+	// when no certificate was seen
+	CertExpiresAt     time.Time
+	CertDaysRemaining int
+	// CertExpiring is set when the check is degraded because the
+	// certificate expires within the warning window
+	CertExpiring bool
 }
 
 // HealthChecker defines interface for checking endpoint health
@@ -121,6 +151,20 @@ type WebhookRepository interface {
 
 	// Delete removes a webhook by ID
 	Delete(ctx context.Context, id int64) error
+
+	// RecordDelivery stores the outcome of the latest delivery, resetting or
+	// incrementing the consecutive failure count, and returns the new count
+	RecordDelivery(ctx context.Context, delivery *WebhookDelivery) (int, error)
+}
+
+// WebhookDeliveryRepository defines operations for the webhook delivery log
+type WebhookDeliveryRepository interface {
+	// Create persists a delivery and sets its ID, keeping only the most recent
+	// WebhookDeliveryHistoryLimit deliveries of the webhook
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+
+	// GetByWebhook retrieves the most recent deliveries of a webhook, newest first
+	GetByWebhook(ctx context.Context, webhookID int64, limit int) ([]*WebhookDelivery, error)
 }
 
 // MaintenanceRepository defines operations for Maintenance persistence
@@ -134,11 +178,11 @@ type MaintenanceRepository interface {
 	// GetAll retrieves all maintenance windows
 	GetAll(ctx context.Context) ([]*Maintenance, error)
 
-	// GetActive retrieves currently active maintenance windows
-	GetActive(ctx context.Context) ([]*Maintenance, error)
+	// GetActive retrieves maintenance windows active at now
+	GetActive(ctx context.Context, now time.Time) ([]*Maintenance, error)
 
-	// GetUpcoming retrieves scheduled maintenance windows
-	GetUpcoming(ctx context.Context) ([]*Maintenance, error)
+	// GetUpcoming retrieves maintenance windows scheduled to start after now
+	GetUpcoming(ctx context.Context, now time.Time) ([]*Maintenance, error)
 
 	// GetByTimeRange retrieves maintenance windows overlapping with time range
 	GetByTimeRange(ctx context.Context, start, end time.Time) ([]*Maintenance, error)
@@ -161,9 +205,19 @@ type IncidentRepository interface {
 	// GetAll retrieves all incidents with optional limit
 	GetAll(ctx context.Context, limit int) ([]*Incident, error)
 
-	// GetActive retrieves all unresolved incidents
+	// GetPage retrieves up to limit incidents matching filter, in the filter's
+	// order, skipping the first offset
+	GetPage(ctx context.Context, filter IncidentFilter, limit, offset int) ([]*Incident, error)
+
+	// Count returns the number of incidents matching filter
+	Count(ctx context.Context, filter IncidentFilter) (int, error)
+
+	// GetActive retrieves all started, unresolved incidents
 	GetActive(ctx context.Context) ([]*Incident, error)
 
+	// GetScheduled retrieves incidents that have not started yet, soonest first
+	GetScheduled(ctx context.Context) ([]*Incident, error)
+
 	// GetRecent retrieves recent incidents (resolved in last N days)
 	GetRecent(ctx context.Context, days int) ([]*Incident, error)
 
@@ -178,6 +232,42 @@ type IncidentRepository interface {
 
 	// GetUpdates retrieves all updates for an incident
 	GetUpdates(ctx context.Context, incidentID int64) ([]*IncidentUpdate, error)
+
+	// GetByIdempotencyKey retrieves the incident created with an idempotency
+	// key in scope that has not expired at now, or nil, also while the key
+	// is reserved but its incident not yet recorded
+	GetByIdempotencyKey(ctx context.Context, scope, key string, now time.Time) (*Incident, error)
+
+	// ReserveIdempotencyKey claims an idempotency key in scope until
+	// expiresAt, dropping keys expired at now. It reports false when the key
+	// is already claimed; storage uniqueness decides between concurrent
+	// callers, so this holds across instances
+	ReserveIdempotencyKey(ctx context.Context, scope, key string, now, expiresAt time.Time) (bool, error)
+
+	// SetIdempotencyKeyIncident maps a reserved key to the incident created
+	// for it until expiresAt
+	SetIdempotencyKeyIncident(ctx context.Context, scope, key string, incidentID int64, expiresAt time.Time) error
+
+	// ReleaseIdempotencyKey drops a reserved key that never got an incident
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+}
+
+// IncidentTemplateRepository defines operations for IncidentTemplate persistence
+type IncidentTemplateRepository interface {
+	// Create persists a new template and sets its ID
+	Create(ctx context.Context, t *IncidentTemplate) error
+
+	// GetByID retrieves a template by ID
+	GetByID(ctx context.Context, id int64) (*IncidentTemplate, error)
+
+	// GetAll retrieves all templates ordered by name
+	GetAll(ctx context.Context) ([]*IncidentTemplate, error)
+
+	// Update saves changes to an existing template
+	Update(ctx context.Context, t *IncidentTemplate) error
+
+	// Delete removes a template by ID
+	Delete(ctx context.Context, id int64) error
 }
 
 // SLAReportRepository defines operations for SLA Report persistence
@@ -220,4 +310,46 @@ type SLABreachRepository interface {
 
 	// GetByPeriod retrieves breaches within a time range
 	GetByPeriod(ctx context.Context, start, end time.Time) ([]*SLABreachEvent, error)
+
+	// Cleanup removes acknowledged breaches detected before olderThan
+	Cleanup(ctx context.Context, olderThan time.Time) error
+}
+
+// SubscriberRepository defines operations for Subscriber persistence
+type SubscriberRepository interface {
+	// Create persists a new subscriber and sets its ID
+	Create(ctx context.Context, subscriber *Subscriber) error
+
+	// GetByEmail retrieves a subscriber by email address
+	GetByEmail(ctx context.Context, email string) (*Subscriber, error)
+
+	// GetByToken retrieves a subscriber by confirmation token
+	GetByToken(ctx context.Context, token string) (*Subscriber, error)
+
+	// GetConfirmed retrieves all confirmed subscribers
+	GetConfirmed(ctx context.Context) ([]*Subscriber, error)
+
+	// Update saves changes to an existing subscriber
+	Update(ctx context.Context, subscriber *Subscriber) error
+
+	// Delete removes a subscriber by ID
+	Delete(ctx context.Context, id int64) error
+}
+
+// SystemDependencyRepository defines the interface for system graph persistence
+type SystemDependencyRepository interface {
+	// Create persists a new edge and sets its ID
+	Create(ctx context.Context, dep *SystemDependency) error
+
+	// GetAll retrieves every edge in the graph
+	GetAll(ctx context.Context) ([]*SystemDependency, error)
+
+	// GetBySystemID retrieves the edges to systems that systemID depends on
+	GetBySystemID(ctx context.Context, systemID int64) ([]*SystemDependency, error)
+
+	// GetDependents retrieves the edges from systems that depend on systemID
+	GetDependents(ctx context.Context, systemID int64) ([]*SystemDependency, error)
+
+	// Delete removes the edge from systemID to dependsOnID
+	Delete(ctx context.Context, systemID, dependsOnID int64) error
 }
@@ -42,20 +42,40 @@ type SystemSLAReport struct {
 	TotalDowntime      time.Duration
 	LongestOutage      time.Duration
 	MTTR               time.Duration // Mean Time To Recovery
+	// MaintenanceExcluded is downtime not counted against uptime because it
+	// fell within a maintenance window
+	MaintenanceExcluded time.Duration
 
 	// Status
 	SLAMet        bool
 	SLADelta      float64 // positive = above target, negative = below
 	StatusSummary string  // "Excellent", "Good", "At Risk", "Breached"
 
+	// Error budget: downtime the SLA target allows over the period, the
+	// downtime used so far, and what is left (negative once blown)
+	AllowedDowntime             time.Duration
+	ConsumedDowntime            time.Duration
+	ErrorBudgetRemaining        time.Duration
+	ErrorBudgetRemainingPercent float64
+
 	// Dependencies
 	DependencyReports []DependencySLAReport
+
+	// Basis is "dependencies" when uptime was derived from weighted
+	// dependency uptimes rather than the system's own status logs
+	Basis string
+
+	// Window the status was computed over (set for live SLA status)
+	Period      string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
 }
 
 // DependencySLAReport represents SLA metrics for a dependency
 type DependencySLAReport struct {
 	DependencyID   int64
 	DependencyName string
+	Weight         float64
 
 	UptimePercent      float64
 	AvailabilityPercent float64
@@ -66,6 +86,24 @@ type DependencySLAReport struct {
 	P99LatencyMs       int64
 }
 
+// WeightedDependencyUptime returns the weight-averaged uptime and availability
+// of the dependency reports. ok is false when no dependency has a positive weight.
+func WeightedDependencyUptime(reports []DependencySLAReport) (uptime, availability float64, ok bool) {
+	var totalWeight float64
+	for _, r := range reports {
+		if r.Weight <= 0 {
+			continue
+		}
+		totalWeight += r.Weight
+		uptime += r.Weight * r.UptimePercent
+		availability += r.Weight * r.AvailabilityPercent
+	}
+	if totalWeight == 0 {
+		return 0, 0, false
+	}
+	return uptime / totalWeight, availability / totalWeight, true
+}
+
 // SLABreachEvent represents an SLA breach that occurred
 type SLABreachEvent struct {
 	ID           int64
@@ -125,6 +163,27 @@ func (r *SLAReport) CalculateOverall() {
 	r.OverallAvailability = totalAvailability / float64(len(r.SystemReports))
 }
 
+// CalculateErrorBudget derives the error budget for a period of the given
+// length from the SLA target and uptime. A 100% target has no budget, so any
+// downtime reports -100% remaining.
+func (r *SystemSLAReport) CalculateErrorBudget(period time.Duration) {
+	r.AllowedDowntime = time.Duration(float64(period) * (100 - r.SLATarget) / 100)
+	r.ConsumedDowntime = time.Duration(float64(period) * (100 - r.UptimePercent) / 100)
+	if r.ConsumedDowntime < 0 {
+		r.ConsumedDowntime = 0
+	}
+	r.ErrorBudgetRemaining = r.AllowedDowntime - r.ConsumedDowntime
+
+	switch {
+	case r.AllowedDowntime > 0:
+		r.ErrorBudgetRemainingPercent = float64(r.ErrorBudgetRemaining) / float64(r.AllowedDowntime) * 100
+	case r.ConsumedDowntime > 0:
+		r.ErrorBudgetRemainingPercent = -100
+	default:
+		r.ErrorBudgetRemainingPercent = 100
+	}
+}
+
 // GetStatusSummary returns a human-readable status based on SLA compliance
 func GetStatusSummary(uptimePercent, slaTarget float64) string {
 	delta := uptimePercent - slaTarget
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -135,6 +136,51 @@ func TestGetStatusSummary(t *testing.T) {
 	}
 }
 
+func TestSystemSLAReport_CalculateErrorBudget(t *testing.T) {
+	period := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name          string
+		target        float64
+		uptime        float64
+		wantAllowed   time.Duration
+		wantConsumed  time.Duration
+		wantRemaining time.Duration
+		wantPercent   float64
+	}{
+		{"half consumed", 99.9, 99.95, 43*time.Minute + 12*time.Second, 21*time.Minute + 36*time.Second, 21*time.Minute + 36*time.Second, 50},
+		{"blown budget", 99.9, 99.8, 43*time.Minute + 12*time.Second, 86*time.Minute + 24*time.Second, -(43*time.Minute + 12*time.Second), -100},
+		{"untouched budget", 99.0, 100, 432 * time.Minute, 0, 432 * time.Minute, 100},
+		{"no budget, no downtime", 100, 100, 0, 0, 0, 100},
+		{"no budget, downtime", 100, 99.99, 0, 4*time.Minute + 19*time.Second + 200*time.Millisecond, -(4*time.Minute + 19*time.Second + 200*time.Millisecond), -100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := SystemSLAReport{SLATarget: tt.target, UptimePercent: tt.uptime}
+			r.CalculateErrorBudget(period)
+
+			// Float arithmetic on percentages leaves sub-millisecond noise
+			near := func(got, want time.Duration) bool {
+				d := got - want
+				return d > -time.Millisecond && d < time.Millisecond
+			}
+			if !near(r.AllowedDowntime, tt.wantAllowed) {
+				t.Errorf("AllowedDowntime = %v, want %v", r.AllowedDowntime, tt.wantAllowed)
+			}
+			if !near(r.ConsumedDowntime, tt.wantConsumed) {
+				t.Errorf("ConsumedDowntime = %v, want %v", r.ConsumedDowntime, tt.wantConsumed)
+			}
+			if !near(r.ErrorBudgetRemaining, tt.wantRemaining) {
+				t.Errorf("ErrorBudgetRemaining = %v, want %v", r.ErrorBudgetRemaining, tt.wantRemaining)
+			}
+			if math.Abs(r.ErrorBudgetRemainingPercent-tt.wantPercent) > 0.01 {
+				t.Errorf("ErrorBudgetRemainingPercent = %.4f, want %.2f", r.ErrorBudgetRemainingPercent, tt.wantPercent)
+			}
+		})
+	}
+}
+
 func TestFormatUptime(t *testing.T) {
 	tests := []struct {
 		name     string
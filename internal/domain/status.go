@@ -9,12 +9,31 @@ import (
 type Status string
 
 const (
-	StatusGreen  Status = "green"
-	StatusYellow Status = "yellow"
-	StatusRed    Status = "red"
+	StatusGreen   Status = "green"
+	StatusYellow  Status = "yellow"
+	StatusPartial Status = "partial"
+	StatusRed     Status = "red"
 )
 
-var ErrInvalidStatus = errors.New("invalid status: must be green, yellow, or red")
+// StatusPartial sits between yellow and red: part of a system or dependency
+// is down (e.g. some instances of a pool) while the rest still serves.
+// Clients that only know green/yellow/red can treat it as red.
+
+// StatusMaintenance is shown instead of the real status of systems inside an
+// active maintenance window. It is display-only and never stored, so
+// analytics keep the real status, which is shown again when the window ends.
+const StatusMaintenance Status = "maintenance"
+
+// DisplayStatus returns the status to show: StatusMaintenance while under
+// maintenance, otherwise the real status
+func DisplayStatus(status Status, underMaintenance bool) Status {
+	if underMaintenance {
+		return StatusMaintenance
+	}
+	return status
+}
+
+var ErrInvalidStatus = errors.New("invalid status: must be green, yellow, partial, or red")
 
 // NewStatus creates a Status from string with validation
 func NewStatus(s string) (Status, error) {
@@ -33,7 +52,7 @@ func (s Status) String() string {
 // IsValid checks if status is one of allowed values
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusGreen, StatusYellow, StatusRed:
+	case StatusGreen, StatusYellow, StatusPartial, StatusRed:
 		return true
 	}
 	return false
@@ -44,15 +63,25 @@ func (s Status) IsOperational() bool {
 	return s == StatusGreen
 }
 
-// Severity returns numeric severity level (0=green, 1=yellow, 2=red)
+// IsUnavailable reports whether time spent in this status counts as
+// unavailable in availability and SLA figures. Only red does: a partial
+// outage still serves part of the traffic, so like yellow it counts against
+// uptime but not against availability.
+func (s Status) IsUnavailable() bool {
+	return s == StatusRed
+}
+
+// Severity returns numeric severity level (0=green, 1=yellow, 2=partial, 3=red)
 func (s Status) Severity() int {
 	switch s {
 	case StatusGreen:
 		return 0
 	case StatusYellow:
 		return 1
-	case StatusRed:
+	case StatusPartial:
 		return 2
+	case StatusRed:
+		return 3
 	}
 	return -1
 }
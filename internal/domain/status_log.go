@@ -22,6 +22,7 @@ type StatusLog struct {
 	NewStatus    Status
 	Message      string       // user comment or auto-generated message
 	Source       ChangeSource // manual or heartbeat
+	Actor        string       // authenticated user or API key name; empty for automatic changes
 	CreatedAt    time.Time
 }
 
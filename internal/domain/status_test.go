@@ -11,6 +11,7 @@ func TestNewStatus_ValidStatuses(t *testing.T) {
 	}{
 		{"green", StatusGreen},
 		{"yellow", StatusYellow},
+		{"partial", StatusPartial},
 		{"red", StatusRed},
 		{"GREEN", StatusGreen},
 		{"Yellow", StatusYellow},
@@ -91,12 +92,29 @@ func TestStatus_IsOperational(t *testing.T) {
 	}
 }
 
+func TestStatus_IsUnavailable(t *testing.T) {
+	tests := map[Status]bool{
+		StatusGreen:   false,
+		StatusYellow:  false,
+		StatusPartial: false,
+		StatusRed:     true,
+	}
+	for status, want := range tests {
+		if got := status.IsUnavailable(); got != want {
+			t.Errorf("%s.IsUnavailable() = %v, want %v", status, got, want)
+		}
+	}
+}
+
 func TestStatus_Severity(t *testing.T) {
 	if StatusGreen.Severity() >= StatusYellow.Severity() {
 		t.Error("Green should have lower severity than Yellow")
 	}
-	if StatusYellow.Severity() >= StatusRed.Severity() {
-		t.Error("Yellow should have lower severity than Red")
+	if StatusYellow.Severity() >= StatusPartial.Severity() {
+		t.Error("Yellow should have lower severity than Partial")
+	}
+	if StatusPartial.Severity() >= StatusRed.Severity() {
+		t.Error("Partial should have lower severity than Red")
 	}
 
 	// Test exact values
@@ -106,7 +124,8 @@ func TestStatus_Severity(t *testing.T) {
 	}{
 		{StatusGreen, 0},
 		{StatusYellow, 1},
-		{StatusRed, 2},
+		{StatusPartial, 2},
+		{StatusRed, 3},
 		{Status("invalid"), -1},
 		{Status(""), -1},
 	}
@@ -188,3 +207,15 @@ func TestMaxSeverityStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayStatus(t *testing.T) {
+	if got := DisplayStatus(StatusRed, true); got != StatusMaintenance {
+		t.Errorf("DisplayStatus(red, true) = %q, want %q", got, StatusMaintenance)
+	}
+	if got := DisplayStatus(StatusRed, false); got != StatusRed {
+		t.Errorf("DisplayStatus(red, false) = %q, want %q", got, StatusRed)
+	}
+	if StatusMaintenance.IsValid() {
+		t.Error("StatusMaintenance is display-only and should not be a valid stored status")
+	}
+}
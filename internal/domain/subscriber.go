@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// Subscriber is an end user who receives incident emails. Mail is only sent
+// after the address has been confirmed through the emailed token.
+type Subscriber struct {
+	ID          int64
+	Email       string
+	SystemIDs   []int64 // Systems to hear about (nil = all)
+	Token       string  // Confirmation and unsubscribe token
+	Confirmed   bool
+	CreatedAt   time.Time
+	ConfirmedAt *time.Time
+}
+
+// NewSubscriber creates an unconfirmed subscriber with a fresh token
+func NewSubscriber(email string, systemIDs []int64) (*Subscriber, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+
+	token, err := GenerateSubscriberToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscriber{
+		Email:     strings.ToLower(addr.Address),
+		SystemIDs: systemIDs,
+		Token:     token,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// GenerateSubscriberToken creates a random confirmation token
+func GenerateSubscriberToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// Confirm marks the address as verified
+func (s *Subscriber) Confirm() {
+	if s.Confirmed {
+		return
+	}
+	now := time.Now()
+	s.Confirmed = true
+	s.ConfirmedAt = &now
+}
+
+// WantsSystems reports whether an event affecting systemIDs (nil = all)
+// should reach this subscriber
+func (s *Subscriber) WantsSystems(systemIDs []int64) bool {
+	if len(s.SystemIDs) == 0 || len(systemIDs) == 0 {
+		return true
+	}
+	for _, want := range s.SystemIDs {
+		for _, id := range systemIDs {
+			if want == id {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestNewSubscriber(t *testing.T) {
+	s, err := NewSubscriber("  Jane <Jane@Example.com> ", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Email != "jane@example.com" {
+		t.Errorf("expected normalized email, got %q", s.Email)
+	}
+	if s.Token == "" {
+		t.Error("expected confirmation token")
+	}
+	if s.Confirmed {
+		t.Error("expected new subscriber to be unconfirmed")
+	}
+
+	if _, err := NewSubscriber("not-an-email", nil); err != ErrInvalidEmail {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+}
+
+func TestSubscriber_Confirm(t *testing.T) {
+	s, _ := NewSubscriber("user@example.com", nil)
+	s.Confirm()
+
+	if !s.Confirmed || s.ConfirmedAt == nil {
+		t.Fatal("expected subscriber to be confirmed")
+	}
+
+	first := *s.ConfirmedAt
+	s.Confirm()
+	if !s.ConfirmedAt.Equal(first) {
+		t.Error("expected confirming twice to keep the original time")
+	}
+}
+
+func TestSubscriber_WantsSystems(t *testing.T) {
+	all, _ := NewSubscriber("all@example.com", nil)
+	some, _ := NewSubscriber("some@example.com", []int64{1, 2})
+
+	tests := []struct {
+		name       string
+		subscriber *Subscriber
+		systemIDs  []int64
+		want       bool
+	}{
+		{"all systems, any event", all, []int64{5}, true},
+		{"filtered, matching system", some, []int64{2, 9}, true},
+		{"filtered, other system", some, []int64{3}, false},
+		{"filtered, event affecting all systems", some, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subscriber.WantsSystems(tt.systemIDs); got != tt.want {
+				t.Errorf("WantsSystems(%v) = %v, want %v", tt.systemIDs, got, tt.want)
+			}
+		})
+	}
+}
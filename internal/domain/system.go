@@ -2,23 +2,43 @@ package domain
 
 import (
 	"errors"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
 var ErrEmptyName = errors.New("name cannot be empty")
 
+// ErrInvalidSlug is returned for slugs that are not lowercase letters and
+// digits separated by single hyphens
+var ErrInvalidSlug = errors.New("slug must be 1-64 lowercase letters, digits or single hyphens")
+
+// maxSlugLength caps system slugs
+const maxSlugLength = 64
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 // System is an entity representing a monitored system/project
 type System struct {
 	ID          int64
 	Name        string
 	Description string
-	URL         string  // link to the system
-	Owner       string  // responsible person/team
+	URL         string // link to the system
+	Owner       string // responsible person/team
 	Status      Status
 	SLATarget   float64 // SLA target percentage (e.g., 99.9)
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// SLATargetExplicit is set when SLATarget was configured for this system;
+	// otherwise the target may be inherited from a tag
+	SLATargetExplicit bool
+	// ResponseTimeTargetMs is the p95 latency target in milliseconds for
+	// SLA breach detection; 0 means no response-time target
+	ResponseTimeTargetMs int64
+	Tags                 []string // e.g. environment tags like "production"
+	DisplayOrder         int      // position on the public page (lower first, ties by name)
+	Slug                 string   // unique name of the system's own public page at /status/{slug} (empty = none)
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 // DefaultSLATarget is the default SLA target if not specified
@@ -57,12 +77,80 @@ func (s *System) GetSLATarget() float64 {
 func (s *System) SetSLATarget(target float64) {
 	if target <= 0 || target > 100 {
 		s.SLATarget = DefaultSLATarget
+		s.SLATargetExplicit = false
 	} else {
 		s.SLATarget = target
+		s.SLATargetExplicit = true
 	}
 	s.UpdatedAt = time.Now()
 }
 
+// SetResponseTimeTarget sets the p95 latency target in milliseconds.
+// Zero or negative values remove the target.
+func (s *System) SetResponseTimeTarget(ms int64) {
+	s.ResponseTimeTargetMs = max(0, ms)
+	s.UpdatedAt = time.Now()
+}
+
+// SetTags replaces the system tags, dropping blanks and duplicates
+func (s *System) SetTags(tags []string) {
+	seen := make(map[string]bool)
+	var cleaned []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+	}
+	s.Tags = cleaned
+	s.UpdatedAt = time.Now()
+}
+
+// HasTag reports whether the system carries the given tag
+func (s *System) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDisplayOrder sets the position of the system on the public page
+func (s *System) SetDisplayOrder(order int) {
+	s.DisplayOrder = order
+	s.UpdatedAt = time.Now()
+}
+
+// SetSlug sets the public page slug; it is lowercased and an empty slug
+// removes the page. Uniqueness is up to the caller.
+func (s *System) SetSlug(slug string) error {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug != "" && !IsValidSlug(slug) {
+		return ErrInvalidSlug
+	}
+	s.Slug = slug
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsValidSlug reports whether slug can be used in a public page URL
+func IsValidSlug(slug string) bool {
+	return len(slug) <= maxSlugLength && slugPattern.MatchString(slug)
+}
+
+// SortForDisplay orders systems by DisplayOrder, then by name
+func SortForDisplay(systems []*System) {
+	sort.SliceStable(systems, func(i, j int) bool {
+		if systems[i].DisplayOrder != systems[j].DisplayOrder {
+			return systems[i].DisplayOrder < systems[j].DisplayOrder
+		}
+		return systems[i].Name < systems[j].Name
+	})
+}
+
 // IsSLAMet checks if the given uptime meets the SLA target
 func (s *System) IsSLAMet(uptimePercent float64) bool {
 	return uptimePercent >= s.GetSLATarget()
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSelfDependency  = errors.New("system cannot depend on itself")
+	ErrDependencyCycle = errors.New("system dependency would create a cycle")
+)
+
+// SystemDependencyCriticality is how an upstream system's status propagates
+// to the systems that depend on it: any degradation or outage upstream
+// marks the dependent system degraded
+const SystemDependencyCriticality = CriticalityMajor
+
+// SystemDependency is an edge in the system graph: SystemID depends on
+// DependsOnID, so problems in DependsOnID propagate to SystemID
+type SystemDependency struct {
+	ID          int64
+	SystemID    int64
+	DependsOnID int64
+	CreatedAt   time.Time
+}
+
+// NewSystemDependency creates a new edge from systemID to dependsOnID
+func NewSystemDependency(systemID, dependsOnID int64) (*SystemDependency, error) {
+	if systemID == dependsOnID {
+		return nil, ErrSelfDependency
+	}
+
+	return &SystemDependency{
+		SystemID:    systemID,
+		DependsOnID: dependsOnID,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// CreatesCycle reports whether adding the edge to edges would create a cycle,
+// i.e. whether systemID is already reachable from dependsOnID
+func CreatesCycle(edges []*SystemDependency, systemID, dependsOnID int64) bool {
+	if systemID == dependsOnID {
+		return true
+	}
+
+	upstream := make(map[int64][]int64)
+	for _, e := range edges {
+		upstream[e.SystemID] = append(upstream[e.SystemID], e.DependsOnID)
+	}
+
+	visited := map[int64]bool{dependsOnID: true}
+	stack := []int64{dependsOnID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range upstream[id] {
+			if next == systemID {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestNewSystemDependency(t *testing.T) {
+	dep, err := NewSystemDependency(1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dep.SystemID != 1 || dep.DependsOnID != 2 {
+		t.Errorf("expected edge 1 -> 2, got %d -> %d", dep.SystemID, dep.DependsOnID)
+	}
+	if dep.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+
+	if _, err := NewSystemDependency(3, 3); err != ErrSelfDependency {
+		t.Errorf("expected ErrSelfDependency, got %v", err)
+	}
+}
+
+func TestCreatesCycle(t *testing.T) {
+	// 1 -> 2 -> 3, 4 -> 3
+	edges := []*SystemDependency{
+		{SystemID: 1, DependsOnID: 2},
+		{SystemID: 2, DependsOnID: 3},
+		{SystemID: 4, DependsOnID: 3},
+	}
+
+	tests := []struct {
+		name        string
+		systemID    int64
+		dependsOnID int64
+		expected    bool
+	}{
+		{"direct cycle", 2, 1, true},
+		{"transitive cycle", 3, 1, true},
+		{"self", 1, 1, true},
+		{"shortcut", 1, 3, false},
+		{"sibling", 4, 2, false},
+		{"new root", 5, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreatesCycle(edges, tt.systemID, tt.dependsOnID); got != tt.expected {
+				t.Errorf("CreatesCycle(%d -> %d) = %v, expected %v", tt.systemID, tt.dependsOnID, got, tt.expected)
+			}
+		})
+	}
+}
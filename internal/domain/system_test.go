@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -190,6 +192,23 @@ func TestSystem_SetSLATarget(t *testing.T) {
 	}
 }
 
+func TestSystem_SetResponseTimeTarget(t *testing.T) {
+	system, _ := NewSystem("Test", "", "", "")
+	if system.ResponseTimeTargetMs != 0 {
+		t.Errorf("expected no response time target by default, got %d", system.ResponseTimeTargetMs)
+	}
+
+	system.SetResponseTimeTarget(300)
+	if system.ResponseTimeTargetMs != 300 {
+		t.Errorf("expected 300, got %d", system.ResponseTimeTargetMs)
+	}
+
+	system.SetResponseTimeTarget(-1)
+	if system.ResponseTimeTargetMs != 0 {
+		t.Errorf("expected negative target to clear it, got %d", system.ResponseTimeTargetMs)
+	}
+}
+
 func TestSystem_IsSLAMet(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -214,3 +233,60 @@ func TestSystem_IsSLAMet(t *testing.T) {
 		})
 	}
 }
+
+func TestSortForDisplay(t *testing.T) {
+	systems := []*System{
+		{Name: "Search", DisplayOrder: 2},
+		{Name: "API", DisplayOrder: 2},
+		{Name: "Web", DisplayOrder: 1},
+		{Name: "Billing"},
+	}
+
+	SortForDisplay(systems)
+
+	want := []string{"Billing", "Web", "API", "Search"}
+	for i, name := range want {
+		if systems[i].Name != name {
+			t.Errorf("systems[%d] = %s, want %s", i, systems[i].Name, name)
+		}
+	}
+}
+
+func TestSystem_SetSlug(t *testing.T) {
+	tests := []struct {
+		slug    string
+		want    string
+		wantErr bool
+	}{
+		{"checkout", "checkout", false},
+		{"  Payments-EU ", "payments-eu", false},
+		{"api-v2", "api-v2", false},
+		{"", "", false},
+		{"has space", "", true},
+		{"double--hyphen", "", true},
+		{"-leading", "", true},
+		{"trailing-", "", true},
+		{"under_score", "", true},
+		{"slash/path", "", true},
+		{strings.Repeat("a", 65), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			system, _ := NewSystem("API", "", "", "")
+			err := system.SetSlug(tt.slug)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSlug) {
+					t.Errorf("SetSlug(%q) error = %v, want ErrInvalidSlug", tt.slug, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetSlug(%q) error = %v", tt.slug, err)
+			}
+			if system.Slug != tt.want {
+				t.Errorf("Slug = %q, want %q", system.Slug, tt.want)
+			}
+		})
+	}
+}
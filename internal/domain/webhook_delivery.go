@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// DeliveryStatus is the outcome of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSuccess DeliveryStatus = "success"
+	DeliveryStatusFailure DeliveryStatus = "failure"
+)
+
+// WebhookDeliveryHistoryLimit is how many recent deliveries are kept per webhook
+const WebhookDeliveryHistoryLimit = 50
+
+// maxDeliveryErrorLength caps the stored error message
+const maxDeliveryErrorLength = 500
+
+// WebhookDelivery records one send to a webhook, including all its retries
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  int64
+	Event      WebhookEvent
+	Status     DeliveryStatus
+	StatusCode int // HTTP status of the last attempt (0 = no response or not HTTP)
+	Attempts   int
+	Error      string
+	CreatedAt  time.Time
+}
+
+// NewWebhookDelivery creates a delivery record; a nil deliveryErr means success
+func NewWebhookDelivery(webhookID int64, event WebhookEvent, statusCode, attempts int, deliveryErr error) *WebhookDelivery {
+	d := &WebhookDelivery{
+		WebhookID:  webhookID,
+		Event:      event,
+		Status:     DeliveryStatusSuccess,
+		StatusCode: statusCode,
+		Attempts:   attempts,
+		CreatedAt:  time.Now(),
+	}
+	if deliveryErr != nil {
+		d.Status = DeliveryStatusFailure
+		d.Error = deliveryErr.Error()
+		if len(d.Error) > maxDeliveryErrorLength {
+			d.Error = d.Error[:maxDeliveryErrorLength]
+		}
+	}
+	return d
+}
+
+// Succeeded reports whether the delivery was accepted by the receiver
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Status == DeliveryStatusSuccess
+}
@@ -2,7 +2,11 @@ package http_checker
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -14,22 +18,33 @@ import (
 
 // Checker implements domain.HealthChecker
 type Checker struct {
-	client *http.Client
+	client     *http.Client
+	grpcClient *http.Client
+	dialer     *net.Dialer
+	resolver   resolver
 }
 
-// New creates a new HTTP health checker
+// New creates a new health checker for HTTP, TCP, gRPC and DNS probes
 func New(timeout time.Duration) *Checker {
 	return &Checker{
 		client: &http.Client{
-			Timeout: timeout,
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				// Follow up to 10 redirects
-				if len(via) >= 10 {
-					return http.ErrUseLastResponse
-				}
-				return nil
-			},
+			Timeout:       timeout,
+			CheckRedirect: redirectPolicy(domain.DefaultMaxRedirects),
 		},
+		grpcClient: newGRPCClient(timeout),
+		dialer:     &net.Dialer{Timeout: timeout},
+		resolver:   net.DefaultResolver,
+	}
+}
+
+// redirectPolicy follows up to limit redirects and then stops, so the last
+// 3xx response is evaluated like any other status
+func redirectPolicy(limit int) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > limit {
+			return http.ErrUseLastResponse
+		}
+		return nil
 	}
 }
 
@@ -40,8 +55,31 @@ func (c *Checker) Check(ctx context.Context, url string) (bool, int64, error) {
 	return result.Healthy, result.LatencyMs, result.Error
 }
 
-// CheckWithConfig performs HTTP health check with advanced configuration
+// CheckWithConfig performs HTTP health check with advanced configuration.
+// A failed probe is retried immediately up to config.Retries times; the first
+// healthy result wins, otherwise the last attempt is reported.
 func (c *Checker) CheckWithConfig(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	result := c.checkOnce(ctx, config)
+	for attempt := 0; attempt < config.Retries && !result.Healthy && result.Error == nil; attempt++ {
+		if ctx.Err() != nil {
+			break
+		}
+		result = c.checkOnce(ctx, config)
+	}
+	return result
+}
+
+// checkOnce performs a single probe
+func (c *Checker) checkOnce(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	switch config.CheckType {
+	case domain.CheckTypeTCP:
+		return c.checkTCP(ctx, config)
+	case domain.CheckTypeGRPC:
+		return c.checkGRPC(ctx, config)
+	case domain.CheckTypeDNS:
+		return c.checkDNS(ctx, config)
+	}
+
 	method := config.Method
 	if method == "" {
 		method = "GET"
@@ -72,34 +110,125 @@ func (c *Checker) CheckWithConfig(ctx context.Context, config domain.HeartbeatCo
 		}
 	}
 
+	// Clients are cheap to copy and share the transport's connection pool
+	client := *c.client
+	client.CheckRedirect = redirectPolicy(config.RedirectLimit())
+	if timeout := config.Timeout(); timeout > 0 {
+		client.Timeout = timeout
+	}
+
 	start := time.Now()
-	resp, err := c.client.Do(req)
+	resp, err := client.Do(req)
 	latencyMs := time.Since(start).Milliseconds()
 
 	if err != nil {
-		return domain.HealthCheckResult{Healthy: false, LatencyMs: latencyMs}
+		result := domain.HealthCheckResult{Healthy: false, LatencyMs: latencyMs}
+		// An expired certificate fails verification; still report its expiry
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) && len(certErr.UnverifiedCertificates) > 0 {
+			setCertExpiry(&result, certErr.UnverifiedCertificates[0])
+		}
+		return result
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	statusOK := c.checkStatusCode(resp.StatusCode, config.ExpectStatus)
 
-	// Check response body regex if configured
+	// Check negotiated TLS version if a minimum is configured
+	if !c.checkTLSVersion(resp.TLS, config.MinTLSVersion) {
+		statusOK = false
+	}
+
+	result := domain.HealthCheckResult{
+		LatencyMs:  latencyMs,
+		StatusCode: resp.StatusCode,
+	}
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		setCertExpiry(&result, resp.TLS.PeerCertificates[0])
+	}
+
+	// Check response body regex and substring if configured
 	bodyOK := true
-	if config.ExpectBody != "" && statusOK {
-		bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024)) // Limit to 1MB
-		if err == nil {
-			bodyOK = c.checkBodyRegex(string(bodyBytes), config.ExpectBody)
-		} else {
+	if statusOK && (config.ExpectBody != "" || config.ExpectBodySubstring != "") {
+		bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		body := string(bodyBytes)
+		if err != nil {
 			bodyOK = false
+		} else {
+			bodyOK = c.checkBodyRegex(body, config.ExpectBody) &&
+				strings.Contains(body, config.ExpectBodySubstring)
+		}
+		if !bodyOK {
+			// The endpoint answered as expected but reported something else
+			result.Degraded = true
+			result.BodySnippet = bodySnippet(body)
 		}
 	}
 
-	return domain.HealthCheckResult{
-		Healthy:    statusOK && bodyOK,
-		LatencyMs:  latencyMs,
-		StatusCode: resp.StatusCode,
+	result.Healthy = statusOK && bodyOK
+
+	// Check certificate expiry if a warning window is configured
+	if config.CertExpiryWarningDays > 0 && !result.CertExpiresAt.IsZero() {
+		now := time.Now()
+		switch {
+		case !result.CertExpiresAt.After(now):
+			result.Healthy = false
+			result.Degraded = false
+		case result.Healthy && result.CertExpiresAt.Before(now.AddDate(0, 0, config.CertExpiryWarningDays)):
+			result.Healthy = false
+			result.Degraded = true
+			result.CertExpiring = true
+		}
 	}
+
+	return result
+}
+
+// setCertExpiry records the leaf certificate expiry on the result
+func setCertExpiry(result *domain.HealthCheckResult, leaf *x509.Certificate) {
+	result.CertExpiresAt = leaf.NotAfter
+	result.CertDaysRemaining = domain.CertDaysRemaining(leaf.NotAfter, time.Now())
+}
+
+// maxBodyBytes caps how much of a response body is read for body matching
+const maxBodyBytes = 64 * 1024
+
+// maxSnippetBytes caps the body excerpt kept for debugging mismatches
+const maxSnippetBytes = 256
+
+// bodySnippet returns the start of body, trimmed for logging
+func bodySnippet(body string) string {
+	body = strings.TrimSpace(body)
+	if len(body) > maxSnippetBytes {
+		body = strings.ToValidUTF8(body[:maxSnippetBytes], "") + "..."
+	}
+	return body
+}
+
+// checkTCP dials the address and reports the connect time as latency.
+// A completed handshake within the timeout is healthy; StatusCode stays 0.
+func (c *Checker) checkTCP(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	host, port, err := domain.SplitTCPAddress(config.URL)
+	if err != nil {
+		return domain.HealthCheckResult{Healthy: false, Error: err}
+	}
+
+	dialer := *c.dialer
+	if timeout := config.Timeout(); timeout > 0 {
+		dialer.Timeout = timeout
+	}
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	latencyMs := time.Since(start).Milliseconds()
+
+	if err != nil {
+		return domain.HealthCheckResult{Healthy: false, LatencyMs: latencyMs}
+	}
+	conn.Close()
+
+	return domain.HealthCheckResult{Healthy: true, LatencyMs: latencyMs}
 }
 
 // checkStatusCode checks if the status code matches the expected pattern
@@ -137,6 +266,31 @@ func (c *Checker) checkStatusCode(statusCode int, expectStatus string) bool {
 	return false
 }
 
+// tlsVersions maps HeartbeatConfig.MinTLSVersion values to crypto/tls constants
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// checkTLSVersion checks if the negotiated TLS version meets the minimum.
+// A plain HTTP response never meets a configured minimum.
+func (c *Checker) checkTLSVersion(state *tls.ConnectionState, minVersion string) bool {
+	if minVersion == "" {
+		return true
+	}
+	required, ok := tlsVersions[minVersion]
+	if !ok {
+		// Unknown version - treat as failure
+		return false
+	}
+	if state == nil {
+		return false
+	}
+	return state.Version >= required
+}
+
 // checkBodyRegex checks if the response body matches the regex pattern
 func (c *Checker) checkBodyRegex(body, pattern string) bool {
 	if pattern == "" {
@@ -2,8 +2,21 @@ package http_checker
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -99,6 +112,37 @@ func TestCheckWithConfig_Methods(t *testing.T) {
 	}
 }
 
+func TestCheckWithConfig_HeadWithAuthorization(t *testing.T) {
+	var receivedMethod, receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedMethod = r.Method
+		receivedAuth = r.Header.Get("Authorization")
+		if receivedAuth != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checker := New(5 * time.Second)
+	result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:     server.URL,
+		Method:  "HEAD",
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	})
+
+	if !result.Healthy {
+		t.Errorf("expected healthy=true, got status %d", result.StatusCode)
+	}
+	if receivedMethod != http.MethodHead {
+		t.Errorf("expected method HEAD, got %s", receivedMethod)
+	}
+	if receivedAuth != "Bearer secret" {
+		t.Errorf("expected Authorization header, got %q", receivedAuth)
+	}
+}
+
 func TestCheckWithConfig_CustomHeaders(t *testing.T) {
 	var receivedHeaders http.Header
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -451,3 +495,406 @@ func TestCheckWithConfig_FullIntegration(t *testing.T) {
 		t.Errorf("expected statusCode=200, got %d", result.StatusCode)
 	}
 }
+
+func TestCheckWithConfig_MinTLSVersion(t *testing.T) {
+	newTLSServer := func(maxVersion uint16) *httptest.Server {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{MaxVersion: maxVersion}
+		server.StartTLS()
+		return server
+	}
+
+	tls12 := newTLSServer(tls.VersionTLS12)
+	defer tls12.Close()
+	tls13 := newTLSServer(tls.VersionTLS13)
+	defer tls13.Close()
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plain.Close()
+
+	tests := []struct {
+		name          string
+		server        *httptest.Server
+		minTLSVersion string
+		expected      bool
+	}{
+		{"no minimum", tls12, "", true},
+		{"TLS 1.2 meets 1.2", tls12, "1.2", true},
+		{"TLS 1.2 below 1.3", tls12, "1.3", false},
+		{"TLS 1.3 meets 1.3", tls13, "1.3", true},
+		{"TLS 1.3 above 1.2", tls13, "1.2", true},
+		{"plain HTTP with minimum", plain, "1.2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := New(5 * time.Second)
+			// Trust the test server certificate
+			checker.client.Transport = tt.server.Client().Transport
+
+			result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+				URL:           tt.server.URL,
+				MinTLSVersion: tt.minTLSVersion,
+			})
+
+			if result.Healthy != tt.expected {
+				t.Errorf("expected healthy=%v, got %v", tt.expected, result.Healthy)
+			}
+			if result.StatusCode != http.StatusOK {
+				t.Errorf("expected statusCode=200, got %d", result.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		retries       int
+		expected      bool
+		expectedCalls int32
+	}{
+		{"no retries, transient failure", 1, 0, false, 1},
+		{"recovers within retry budget", 1, 2, true, 2},
+		{"fails beyond retry budget", 3, 2, false, 3},
+		{"healthy first time", 0, 2, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(atomic.AddInt32(&calls, 1)) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			checker := New(5 * time.Second)
+			result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+				URL:     server.URL,
+				Retries: tt.retries,
+			})
+
+			if result.Healthy != tt.expected {
+				t.Errorf("expected healthy=%v, got %v", tt.expected, result.Healthy)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.expectedCalls {
+				t.Errorf("expected %d requests, got %d", tt.expectedCalls, got)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_Redirects(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name           string
+		hops           int
+		config         domain.HeartbeatConfig
+		expected       bool
+		expectedStatus int
+	}{
+		{"follows by default", 3, domain.HeartbeatConfig{}, true, http.StatusOK},
+		{"disabled reports the redirect", 1, domain.HeartbeatConfig{FollowRedirects: &disabled}, false, http.StatusFound},
+		{"disabled with expected 302", 1, domain.HeartbeatConfig{FollowRedirects: &disabled, ExpectStatus: "302"}, true, http.StatusFound},
+		{"within max redirects", 2, domain.HeartbeatConfig{MaxRedirects: 2}, true, http.StatusOK},
+		{"beyond max redirects", 3, domain.HeartbeatConfig{MaxRedirects: 2}, false, http.StatusFound},
+		{"beyond default limit", 15, domain.HeartbeatConfig{}, false, http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// /hop/N redirects to /hop/N-1; /hop/0 is the final page
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var n int
+				fmt.Sscanf(r.URL.Path, "/hop/%d", &n)
+				if n > 0 {
+					http.Redirect(w, r, fmt.Sprintf("/hop/%d", n-1), http.StatusFound)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			config := tt.config
+			config.URL = fmt.Sprintf("%s/hop/%d", server.URL, tt.hops)
+			checker := New(5 * time.Second)
+			result := checker.CheckWithConfig(context.Background(), config)
+
+			if result.Healthy != tt.expected {
+				t.Errorf("expected healthy=%v, got %v", tt.expected, result.Healthy)
+			}
+			if result.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, result.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(1500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		global   time.Duration
+		timeout  int
+		expected bool
+	}{
+		{"short timeout fails", 10 * time.Second, 1000, false},
+		{"long timeout passes", 10 * time.Second, 3000, true},
+		{"long timeout overrides short default", 500 * time.Millisecond, 3000, true},
+		{"unset falls back to default", 500 * time.Millisecond, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := New(tt.global)
+			result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{URL: server.URL, TimeoutMs: tt.timeout})
+			if result.Healthy != tt.expected {
+				t.Errorf("expected healthy=%v, got %v (latency %dms)", tt.expected, result.Healthy, result.LatencyMs)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	addr := listener.Addr().String()
+
+	checker := New(2 * time.Second)
+
+	for _, target := range []string{addr, "tcp://" + addr} {
+		result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+			CheckType: domain.CheckTypeTCP,
+			URL:       target,
+		})
+		if !result.Healthy {
+			t.Errorf("expected healthy TCP check for %s, got %+v", target, result)
+		}
+		if result.StatusCode != 0 {
+			t.Errorf("expected status code 0 for TCP check, got %d", result.StatusCode)
+		}
+	}
+
+	// Closed port: connection refused
+	listener.Close()
+	result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeTCP,
+		URL:       addr,
+	})
+	if result.Healthy {
+		t.Error("expected unhealthy TCP check for closed port")
+	}
+
+	result = checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeTCP,
+		URL:       "no-port",
+	})
+	if result.Healthy || result.Error == nil {
+		t.Errorf("expected error for invalid TCP address, got %+v", result)
+	}
+}
+
+func TestCheckWithConfig_ExpectBodySubstring(t *testing.T) {
+	body := `{"status":"degraded","components":{"db":"slow"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	checker := New(5 * time.Second)
+
+	result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:                 server.URL,
+		ExpectBodySubstring: `"status":"degraded"`,
+	})
+	if !result.Healthy || result.Degraded {
+		t.Errorf("expected healthy result when substring is present, got %+v", result)
+	}
+
+	result = checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:                 server.URL,
+		ExpectBodySubstring: `"status":"ok"`,
+	})
+	if result.Healthy {
+		t.Error("expected unhealthy result when substring is missing")
+	}
+	if !result.Degraded {
+		t.Error("expected degraded result when status matches but body does not")
+	}
+	if result.BodySnippet != body {
+		t.Errorf("expected body snippet %q, got %q", body, result.BodySnippet)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", result.StatusCode)
+	}
+
+	// A status mismatch is a plain failure, not degraded
+	result = checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:                 server.URL,
+		ExpectStatus:        "204",
+		ExpectBodySubstring: `"status":"ok"`,
+	})
+	if result.Healthy || result.Degraded {
+		t.Errorf("expected plain failure on status mismatch, got %+v", result)
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	long := strings.Repeat("a", maxSnippetBytes+100)
+	if got := bodySnippet(long); len(got) != maxSnippetBytes+3 || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected truncated snippet, got %d bytes", len(got))
+	}
+	if got := bodySnippet("  short\n"); got != "short" {
+		t.Errorf("expected trimmed snippet, got %q", got)
+	}
+}
+
+// newCertServer starts an HTTPS server with a self-signed certificate valid
+// until notAfter, and returns it with a transport that trusts the certificate
+func newCertServer(t *testing.T, notAfter time.Time) (*httptest.Server, http.RoundTripper) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             notAfter.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	// Clients reject expired certificates; keep the handshake errors quiet
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return server, &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+}
+
+func TestCheckWithConfig_CertExpiry(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiresIn    time.Duration
+		warningDays  int
+		wantHealthy  bool
+		wantDegraded bool
+		wantDays     int
+	}{
+		{"valid beyond window", 90 * 24 * time.Hour, 14, true, false, 89},
+		{"expires within window", 5*24*time.Hour + time.Hour, 14, false, true, 5},
+		{"within window but check disabled", 5*24*time.Hour + time.Hour, 0, true, false, 5},
+		{"expired", -2*24*time.Hour + time.Hour, 14, false, false, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, transport := newCertServer(t, time.Now().Add(tt.expiresIn))
+			defer server.Close()
+
+			checker := New(5 * time.Second)
+			checker.client.Transport = transport
+
+			result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+				URL:                   server.URL,
+				CertExpiryWarningDays: tt.warningDays,
+			})
+
+			if result.Healthy != tt.wantHealthy {
+				t.Errorf("expected healthy=%v, got %v", tt.wantHealthy, result.Healthy)
+			}
+			if result.Degraded != tt.wantDegraded || result.CertExpiring != tt.wantDegraded {
+				t.Errorf("expected degraded=%v, got degraded=%v cert_expiring=%v", tt.wantDegraded, result.Degraded, result.CertExpiring)
+			}
+			if result.CertExpiresAt.IsZero() {
+				t.Fatal("expected certificate expiry to be reported")
+			}
+			if result.CertDaysRemaining != tt.wantDays {
+				t.Errorf("expected %d days remaining, got %d", tt.wantDays, result.CertDaysRemaining)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_CertExpiry_TLSServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checker := New(5 * time.Second)
+	checker.client.Transport = server.Client().Transport
+
+	result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:                   server.URL,
+		CertExpiryWarningDays: 30,
+	})
+
+	if !result.Healthy {
+		t.Errorf("expected healthy check for long-lived test certificate, got %+v", result)
+	}
+	if !result.CertExpiresAt.Equal(server.Certificate().NotAfter) {
+		t.Errorf("expected expiry %v, got %v", server.Certificate().NotAfter, result.CertExpiresAt)
+	}
+	if result.CertDaysRemaining <= 30 {
+		t.Errorf("expected more than 30 days remaining, got %d", result.CertDaysRemaining)
+	}
+
+	// Plain HTTP reports no certificate
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plain.Close()
+
+	result = checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		URL:                   plain.URL,
+		CertExpiryWarningDays: 30,
+	})
+	if !result.Healthy || !result.CertExpiresAt.IsZero() {
+		t.Errorf("expected healthy plain HTTP check without certificate, got %+v", result)
+	}
+}
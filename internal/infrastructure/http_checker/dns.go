@@ -0,0 +1,107 @@
+package http_checker
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// resolver is the subset of net.Resolver used by DNS checks
+type resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// checkDNS resolves the configured hostname and reports the resolution time
+// as latency. A failed lookup (including NXDOMAIN) or an empty answer is
+// unhealthy; an answer without the expected value is degraded.
+func (c *Checker) checkDNS(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	host, err := domain.SplitDNSHost(config.URL)
+	if err != nil {
+		return domain.HealthCheckResult{Healthy: false, Error: err}
+	}
+
+	timeout := config.Timeout()
+	if timeout <= 0 {
+		timeout = c.dialer.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	answers, err := c.lookup(ctx, host, config.DNSRecordType)
+	latencyMs := time.Since(start).Milliseconds()
+
+	if err != nil || len(answers) == 0 {
+		return domain.HealthCheckResult{Healthy: false, LatencyMs: latencyMs}
+	}
+
+	if config.DNSExpect != "" && !containsDNSAnswer(answers, config.DNSExpect) {
+		return domain.HealthCheckResult{
+			Healthy:     false,
+			Degraded:    true,
+			LatencyMs:   latencyMs,
+			BodySnippet: bodySnippet(strings.Join(answers, ", ")),
+		}
+	}
+
+	return domain.HealthCheckResult{Healthy: true, LatencyMs: latencyMs}
+}
+
+// lookup returns the answers for host of the given record type
+func (c *Checker) lookup(ctx context.Context, host, recordType string) ([]string, error) {
+	if recordType == domain.DNSRecordCNAME {
+		cname, err := c.resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		// Hosts without a CNAME record resolve to themselves
+		if normalizeDNSName(cname) == normalizeDNSName(host) {
+			return nil, nil
+		}
+		return []string{normalizeDNSName(cname)}, nil
+	}
+
+	addrs, err := c.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	wantIPv6 := recordType == domain.DNSRecordAAAA
+	var answers []string
+	for _, addr := range addrs {
+		if isIPv6 := addr.IP.To4() == nil; isIPv6 == wantIPv6 {
+			answers = append(answers, addr.IP.String())
+		}
+	}
+	return answers, nil
+}
+
+// containsDNSAnswer reports whether expect matches one of the answers,
+// comparing IPs by value and names case-insensitively
+func containsDNSAnswer(answers []string, expect string) bool {
+	expect = strings.TrimSpace(expect)
+	expectIP := net.ParseIP(expect)
+	for _, answer := range answers {
+		if expectIP != nil {
+			if expectIP.Equal(net.ParseIP(answer)) {
+				return true
+			}
+			continue
+		}
+		if normalizeDNSName(answer) == normalizeDNSName(expect) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDNSName lowercases a name and drops the trailing root dot
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
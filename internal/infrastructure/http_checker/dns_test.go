@@ -0,0 +1,84 @@
+package http_checker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// stubResolver answers lookups from fixed records
+type stubResolver struct {
+	addrs map[string][]string
+	cname map[string]string
+}
+
+func (r *stubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r.addrs[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	addrs := make([]net.IPAddr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.IPAddr{IP: net.ParseIP(ip)}
+	}
+	return addrs, nil
+}
+
+func (r *stubResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if cname, ok := r.cname[host]; ok {
+		return cname, nil
+	}
+	if _, ok := r.addrs[host]; ok {
+		return host + ".", nil
+	}
+	return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestCheckWithConfig_DNS(t *testing.T) {
+	checker := New(5 * time.Second)
+	checker.resolver = &stubResolver{
+		addrs: map[string][]string{
+			"mail.example.com": {"192.0.2.10", "2001:db8::10"},
+			"v4.example.com":   {"192.0.2.20"},
+		},
+		cname: map[string]string{"cdn.example.com": "Edge.Example.net."},
+	}
+
+	tests := []struct {
+		name       string
+		config     domain.HeartbeatConfig
+		healthy    bool
+		degraded   bool
+		wantAnswer string
+	}{
+		{"resolves", domain.HeartbeatConfig{URL: "mail.example.com"}, true, false, ""},
+		{"dns prefix", domain.HeartbeatConfig{URL: "dns://mail.example.com"}, true, false, ""},
+		{"nxdomain", domain.HeartbeatConfig{URL: "missing.example.com"}, false, false, ""},
+		{"expected A", domain.HeartbeatConfig{URL: "mail.example.com", DNSRecordType: "A", DNSExpect: "192.0.2.10"}, true, false, ""},
+		{"unexpected A", domain.HeartbeatConfig{URL: "mail.example.com", DNSRecordType: "A", DNSExpect: "192.0.2.99"}, false, true, "192.0.2.10"},
+		{"expected AAAA", domain.HeartbeatConfig{URL: "mail.example.com", DNSRecordType: "AAAA", DNSExpect: "2001:db8:0::10"}, true, false, ""},
+		{"no AAAA records", domain.HeartbeatConfig{URL: "v4.example.com", DNSRecordType: "AAAA"}, false, false, ""},
+		{"expected CNAME", domain.HeartbeatConfig{URL: "cdn.example.com", DNSRecordType: "CNAME", DNSExpect: "edge.example.net."}, true, false, ""},
+		{"unexpected CNAME", domain.HeartbeatConfig{URL: "cdn.example.com", DNSRecordType: "CNAME", DNSExpect: "other.example.net"}, false, true, "edge.example.net"},
+		{"no CNAME record", domain.HeartbeatConfig{URL: "v4.example.com", DNSRecordType: "CNAME"}, false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.CheckType = domain.CheckTypeDNS
+			result := checker.CheckWithConfig(context.Background(), tt.config)
+			if result.Error != nil {
+				t.Fatalf("unexpected error: %v", result.Error)
+			}
+			if result.Healthy != tt.healthy || result.Degraded != tt.degraded {
+				t.Errorf("expected healthy=%v degraded=%v, got healthy=%v degraded=%v", tt.healthy, tt.degraded, result.Healthy, result.Degraded)
+			}
+			if tt.wantAnswer != "" && result.BodySnippet != tt.wantAnswer {
+				t.Errorf("expected answer %q, got %q", tt.wantAnswer, result.BodySnippet)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package http_checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// grpcHealthCheckPath is the method path of grpc.health.v1.Health/Check
+const grpcHealthCheckPath = "/grpc.health.v1.Health/Check"
+
+// grpc.health.v1.HealthCheckResponse.ServingStatus values
+const (
+	grpcStatusUnknown        = 0
+	grpcStatusServing        = 1
+	grpcStatusNotServing     = 2
+	grpcStatusServiceUnknown = 3
+)
+
+// grpcServingStatusNames maps ServingStatus values to their proto names
+var grpcServingStatusNames = map[uint64]string{
+	grpcStatusUnknown:        "UNKNOWN",
+	grpcStatusServing:        "SERVING",
+	grpcStatusNotServing:     "NOT_SERVING",
+	grpcStatusServiceUnknown: "SERVICE_UNKNOWN",
+}
+
+var errInvalidGRPCMessage = errors.New("invalid gRPC health response")
+
+// newGRPCClient returns an HTTP/2-only client for gRPC calls. Plaintext
+// targets use HTTP/2 without TLS (h2c), as gRPC servers expect.
+func newGRPCClient(timeout time.Duration) *http.Client {
+	var protocols http.Protocols
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Protocols:           &protocols,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
+
+// checkGRPC calls grpc.health.v1.Health/Check and reports the RPC round trip
+// as latency. SERVING is healthy; any other status or a failed call is not.
+// StatusCode stays 0 as there is no HTTP status to report; the reason for a
+// failure (transport error, grpc-status or serving status) goes into
+// BodySnippet instead.
+func (c *Checker) checkGRPC(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	hostPort, useTLS, err := domain.SplitGRPCAddress(config.URL)
+	if err != nil {
+		return domain.HealthCheckResult{Healthy: false, Error: err}
+	}
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+"://"+hostPort+grpcHealthCheckPath,
+		bytes.NewReader(encodeGRPCHealthRequest(config.GRPCService)))
+	if err != nil {
+		return domain.HealthCheckResult{Healthy: false, Error: err}
+	}
+
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	req.Header.Set("User-Agent", "StatusIncident-HealthChecker/1.0")
+
+	// Custom headers are sent as gRPC metadata (e.g. authorization)
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	// Copies share the HTTP/2 transport and its connections
+	client := *c.grpcClient
+	if timeout := config.Timeout(); timeout > 0 {
+		client.Timeout = timeout
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return grpcFailure(time.Since(start).Milliseconds(), err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	latencyMs := time.Since(start).Milliseconds()
+	if err != nil {
+		return grpcFailure(latencyMs, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return grpcFailure(latencyMs, fmt.Sprintf("HTTP status %d", resp.StatusCode))
+	}
+
+	// grpc-status arrives in the trailers, or in the headers of a
+	// trailers-only response (e.g. NOT_FOUND for an unknown service)
+	grpcStatus := resp.Trailer.Get("Grpc-Status")
+	if grpcStatus == "" {
+		grpcStatus = resp.Header.Get("Grpc-Status")
+	}
+	if grpcStatus != "0" {
+		reason := "missing grpc-status"
+		if grpcStatus != "" {
+			reason = "grpc-status " + grpcStatus
+		}
+		message := resp.Trailer.Get("Grpc-Message")
+		if message == "" {
+			message = resp.Header.Get("Grpc-Message")
+		}
+		if message != "" {
+			reason += ": " + message
+		}
+		return grpcFailure(latencyMs, reason)
+	}
+
+	status, err := decodeGRPCHealthResponse(body)
+	if err != nil {
+		return grpcFailure(latencyMs, err.Error())
+	}
+	if status != grpcStatusServing {
+		name, ok := grpcServingStatusNames[status]
+		if !ok {
+			name = fmt.Sprintf("serving status %d", status)
+		}
+		return grpcFailure(latencyMs, name)
+	}
+
+	return domain.HealthCheckResult{Healthy: true, LatencyMs: latencyMs}
+}
+
+// grpcFailure returns an unhealthy result carrying reason as its snippet
+func grpcFailure(latencyMs int64, reason string) domain.HealthCheckResult {
+	return domain.HealthCheckResult{Healthy: false, LatencyMs: latencyMs, BodySnippet: bodySnippet(reason)}
+}
+
+// encodeGRPCHealthRequest encodes a HealthCheckRequest{service} as a
+// length-prefixed gRPC message
+func encodeGRPCHealthRequest(service string) []byte {
+	var msg []byte
+	if service != "" {
+		// Field 1, wire type 2 (length-delimited)
+		msg = append(msg, 0x0a)
+		msg = binary.AppendUvarint(msg, uint64(len(service)))
+		msg = append(msg, service...)
+	}
+
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+// decodeGRPCHealthResponse extracts the serving status from a length-prefixed
+// HealthCheckResponse. Unknown fields are skipped.
+func decodeGRPCHealthResponse(data []byte) (uint64, error) {
+	if len(data) < 5 || data[0] != 0 {
+		// Too short, or compressed, which we never ask for
+		return 0, errInvalidGRPCMessage
+	}
+	size := binary.BigEndian.Uint32(data[1:5])
+	msg := data[5:]
+	if uint64(len(msg)) < uint64(size) {
+		return 0, errInvalidGRPCMessage
+	}
+	msg = msg[:size]
+
+	status := uint64(grpcStatusUnknown)
+	for len(msg) > 0 {
+		tag, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return 0, errInvalidGRPCMessage
+		}
+		msg = msg[n:]
+
+		switch tag & 7 {
+		case 0: // varint
+			v, n := binary.Uvarint(msg)
+			if n <= 0 {
+				return 0, errInvalidGRPCMessage
+			}
+			msg = msg[n:]
+			if tag>>3 == 1 {
+				status = v
+			}
+		case 1: // 64-bit
+			if len(msg) < 8 {
+				return 0, errInvalidGRPCMessage
+			}
+			msg = msg[8:]
+		case 2: // length-delimited
+			l, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < l {
+				return 0, errInvalidGRPCMessage
+			}
+			msg = msg[n+int(l):]
+		case 5: // 32-bit
+			if len(msg) < 4 {
+				return 0, errInvalidGRPCMessage
+			}
+			msg = msg[4:]
+		default:
+			return 0, errInvalidGRPCMessage
+		}
+	}
+
+	return status, nil
+}
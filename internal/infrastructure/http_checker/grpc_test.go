@@ -0,0 +1,164 @@
+package http_checker
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// newGRPCHealthServer starts a plaintext HTTP/2 server implementing
+// grpc.health.v1.Health/Check with the given status per service name.
+// Services missing from the map get a trailers-only NOT_FOUND response.
+func newGRPCHealthServer(t *testing.T, statuses map[string]byte) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.URL.Path != grpcHealthCheckPath || r.Header.Get("Content-Type") != "application/grpc" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		service := ""
+		if len(data) > 5 && data[5] == 0x0a {
+			l, n := binary.Uvarint(data[6:])
+			service = string(data[6+n : 6+n+int(l)])
+		}
+
+		w.Header().Set("Content-Type", "application/grpc")
+		status, ok := statuses[service]
+		if !ok {
+			w.Header().Set("Grpc-Status", "5")
+			w.Header().Set("Grpc-Message", "unknown service")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte{0, 0, 0, 0, 2, 0x08, status})
+		w.Header().Set("Grpc-Status", "0")
+	}))
+
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv.Config.Protocols = &protocols
+	srv.Start()
+
+	return srv
+}
+
+func TestCheckWithConfig_GRPC(t *testing.T) {
+	srv := newGRPCHealthServer(t, map[string]byte{
+		"":             grpcStatusServing,
+		"orders.v1":    grpcStatusServing,
+		"payments.v1":  grpcStatusNotServing,
+		"inventory.v1": grpcStatusUnknown,
+	})
+	defer srv.Close()
+
+	addr := srv.Listener.Addr().String()
+	checker := New(2 * time.Second)
+
+	tests := []struct {
+		name    string
+		url     string
+		service string
+		healthy bool
+		reason  string
+	}{
+		{"server health", addr, "", true, ""},
+		{"serving service", "grpc://" + addr, "orders.v1", true, ""},
+		{"not serving service", addr, "payments.v1", false, "NOT_SERVING"},
+		{"unknown status", addr, "inventory.v1", false, "UNKNOWN"},
+		{"unregistered service", addr, "missing.v1", false, "grpc-status 5: unknown service"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+				CheckType:   domain.CheckTypeGRPC,
+				URL:         tt.url,
+				GRPCService: tt.service,
+			})
+			if result.Error != nil {
+				t.Fatalf("unexpected error: %v", result.Error)
+			}
+			if result.Healthy != tt.healthy {
+				t.Errorf("Healthy = %v, want %v", result.Healthy, tt.healthy)
+			}
+			if result.StatusCode != 0 {
+				t.Errorf("expected status code 0 for gRPC check, got %d", result.StatusCode)
+			}
+			if result.BodySnippet != tt.reason {
+				t.Errorf("BodySnippet = %q, want %q", result.BodySnippet, tt.reason)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_GRPC_Unreachable(t *testing.T) {
+	srv := newGRPCHealthServer(t, map[string]byte{"": grpcStatusServing})
+	addr := srv.Listener.Addr().String()
+	srv.Close()
+
+	checker := New(2 * time.Second)
+
+	result := checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeGRPC,
+		URL:       addr,
+	})
+	if result.Healthy {
+		t.Error("expected unhealthy gRPC check for closed port")
+	}
+	if result.Error != nil {
+		t.Errorf("expected a failed check rather than an error, got %v", result.Error)
+	}
+	if !strings.Contains(result.BodySnippet, "connection refused") {
+		t.Errorf("expected transport error in BodySnippet, got %q", result.BodySnippet)
+	}
+
+	result = checker.CheckWithConfig(context.Background(), domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeGRPC,
+		URL:       "no-port",
+	})
+	if result.Healthy || result.Error == nil {
+		t.Errorf("expected error for invalid gRPC address, got %+v", result)
+	}
+}
+
+func TestDecodeGRPCHealthResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    uint64
+		wantErr bool
+	}{
+		{"serving", []byte{0, 0, 0, 0, 2, 0x08, 1}, grpcStatusServing, false},
+		{"empty message is unknown", []byte{0, 0, 0, 0, 0}, grpcStatusUnknown, false},
+		{"skips unknown field", []byte{0, 0, 0, 0, 5, 0x12, 1, 'x', 0x08, 2}, grpcStatusNotServing, false},
+		{"too short", []byte{0, 0}, 0, true},
+		{"compressed", []byte{1, 0, 0, 0, 2, 0x08, 1}, 0, true},
+		{"truncated", []byte{0, 0, 0, 0, 4, 0x08, 1}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeGRPCHealthResponse(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
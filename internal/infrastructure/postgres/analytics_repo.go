@@ -0,0 +1,590 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"status-incident/internal/domain"
+	"time"
+)
+
+// rollupMinRange is the shortest period for which analytics are served from
+// daily uptime rollups instead of recomputing from raw status logs
+const rollupMinRange = 7 * 24 * time.Hour
+
+// AnalyticsRepo implements domain.AnalyticsRepository
+type AnalyticsRepo struct {
+	db      *DB
+	logRepo *LogRepo
+}
+
+// NewAnalyticsRepo creates a new AnalyticsRepo
+func NewAnalyticsRepo(db *DB) *AnalyticsRepo {
+	return &AnalyticsRepo{
+		db:      db,
+		logRepo: NewLogRepo(db),
+	}
+}
+
+// GetIncidentsBySystemID calculates incident periods for a system within time range
+func (r *AnalyticsRepo) GetIncidentsBySystemID(ctx context.Context, systemID int64, start, end time.Time) ([]domain.IncidentPeriod, error) {
+	logs, err := r.logRepo.GetSystemLogsByTimeRange(ctx, systemID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.calculateIncidents(logs, &systemID, nil), nil
+}
+
+// GetIncidentsByDependencyID calculates incident periods for a dependency within time range
+func (r *AnalyticsRepo) GetIncidentsByDependencyID(ctx context.Context, dependencyID int64, start, end time.Time) ([]domain.IncidentPeriod, error) {
+	logs, err := r.logRepo.GetDependencyLogsByTimeRange(ctx, dependencyID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.calculateIncidents(logs, nil, &dependencyID), nil
+}
+
+// GetUptimeBySystemID calculates uptime metrics for a system.
+// Long periods are served from daily rollups when every full day in range has been rolled up.
+func (r *AnalyticsRepo) GetUptimeBySystemID(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+	// Get system info
+	var name string
+	err := r.db.QueryRowContext(ctx, "SELECT name FROM systems WHERE id = ?", systemID).Scan(&name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+
+	if end.Sub(start) >= rollupMinRange {
+		analytics, err := r.getUptimeFromRollups(ctx, systemID, name, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if analytics != nil {
+			return analytics, nil
+		}
+	}
+
+	return r.getUptimeFromLogs(ctx, systemID, name, start, end)
+}
+
+// getUptimeFromLogs calculates uptime metrics for a system from raw status logs
+func (r *AnalyticsRepo) getUptimeFromLogs(ctx context.Context, systemID int64, name string, start, end time.Time) (*domain.Analytics, error) {
+	logs, err := r.logRepo.GetSystemLogsByTimeRange(ctx, systemID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := r.calculateIncidents(logs, &systemID, nil)
+
+	return r.buildAnalytics(systemID, "system", name, start, end, logs, incidents), nil
+}
+
+// GetUptimeByDependencyID calculates uptime metrics for a dependency
+func (r *AnalyticsRepo) GetUptimeByDependencyID(ctx context.Context, dependencyID int64, start, end time.Time) (*domain.Analytics, error) {
+	// Get dependency info
+	var name string
+	err := r.db.QueryRowContext(ctx, "SELECT name FROM dependencies WHERE id = ?", dependencyID).Scan(&name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency: %w", err)
+	}
+
+	logs, err := r.logRepo.GetDependencyLogsByTimeRange(ctx, dependencyID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := r.calculateIncidents(logs, nil, &dependencyID)
+
+	return r.buildAnalytics(dependencyID, "dependency", name, start, end, logs, incidents), nil
+}
+
+// GetOverallAnalytics returns aggregate analytics for all systems.
+// Uptime and availability are the average of per-system values over the same
+// window, so they equal the time-weighted average; incident counts and
+// durations are summed per system, so overlapping incidents count once each.
+func (r *AnalyticsRepo) GetOverallAnalytics(ctx context.Context, start, end time.Time) (*domain.Analytics, error) {
+	// Get all system IDs
+	systemIDs, err := r.getSystemIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(systemIDs) == 0 {
+		// No systems, return 100% uptime
+		return &domain.Analytics{
+			EntityID:            0,
+			EntityType:          "overall",
+			EntityName:          "All Systems",
+			PeriodStart:         start,
+			PeriodEnd:           end,
+			UptimePercent:       100,
+			AvailabilityPercent: 100,
+		}, nil
+	}
+
+	// Calculate metrics for each system and aggregate
+	var totalUptime, totalAvailability float64
+	var totalIncidents, resolvedIncidents, ongoingIncidents int
+	var totalDowntime, totalUnavailable, longestIncident time.Duration
+	var counted int
+	var lastErr error
+
+	for _, sysID := range systemIDs {
+		analytics, err := r.GetUptimeBySystemID(ctx, sysID, start, end)
+		if err != nil {
+			// Leave the system out of both the sums and the average
+			log.Printf("overall analytics: skipping system %d: %v", sysID, err)
+			lastErr = err
+			continue
+		}
+		counted++
+
+		totalUptime += analytics.UptimePercent
+		totalAvailability += analytics.AvailabilityPercent
+		totalIncidents += analytics.TotalIncidents
+		resolvedIncidents += analytics.ResolvedIncidents
+		ongoingIncidents += analytics.OngoingIncidents
+		totalDowntime += analytics.TotalDowntime
+		totalUnavailable += analytics.TotalUnavailable
+		if analytics.LongestIncident > longestIncident {
+			longestIncident = analytics.LongestIncident
+		}
+	}
+
+	if counted == 0 {
+		return nil, fmt.Errorf("failed to get analytics for any system: %w", lastErr)
+	}
+
+	n := float64(counted)
+	avgUptime := totalUptime / n
+	avgAvailability := totalAvailability / n
+
+	period := periodLabel(end.Sub(start))
+	if end.Sub(start).Hours() <= 1 {
+		period = "1h"
+	}
+
+	return &domain.Analytics{
+		EntityID:            0,
+		EntityType:          "overall",
+		EntityName:          "All Systems",
+		Period:              period,
+		PeriodStart:         start,
+		PeriodEnd:           end,
+		TotalIncidents:      totalIncidents,
+		ResolvedIncidents:   resolvedIncidents,
+		OngoingIncidents:    ongoingIncidents,
+		TotalDowntime:       totalDowntime,
+		TotalUnavailable:    totalUnavailable,
+		LongestIncident:     longestIncident,
+		UptimePercent:       avgUptime,
+		AvailabilityPercent: avgAvailability,
+	}, nil
+}
+
+func (r *AnalyticsRepo) calculateIncidents(logs []*domain.StatusLog, systemID, dependencyID *int64) []domain.IncidentPeriod {
+	var incidents []domain.IncidentPeriod
+	var currentIncident *domain.IncidentPeriod
+
+	for _, log := range logs {
+		if log.IsIncidentStart() {
+			// Start new incident period
+			currentIncident = &domain.IncidentPeriod{
+				SystemID:     systemID,
+				DependencyID: dependencyID,
+				StartedAt:    log.CreatedAt,
+				MaxSeverity:  log.NewStatus,
+				LogCount:     1,
+			}
+		} else if currentIncident != nil {
+			currentIncident.LogCount++
+
+			// Update max severity
+			if log.NewStatus.Severity() > currentIncident.MaxSeverity.Severity() {
+				currentIncident.MaxSeverity = log.NewStatus
+			}
+
+			if log.IsIncidentEnd() {
+				// End incident period
+				endTime := log.CreatedAt
+				currentIncident.EndedAt = &endTime
+				currentIncident.Duration = endTime.Sub(currentIncident.StartedAt)
+				incidents = append(incidents, *currentIncident)
+				currentIncident = nil
+			}
+		}
+	}
+
+	// Add ongoing incident period if exists
+	if currentIncident != nil {
+		incidents = append(incidents, *currentIncident)
+	}
+
+	return incidents
+}
+
+func (r *AnalyticsRepo) buildAnalytics(entityID int64, entityType, entityName string, start, end time.Time, logs []*domain.StatusLog, incidents []domain.IncidentPeriod) *domain.Analytics {
+	totalDuration := end.Sub(start)
+
+	var totalDowntime, totalUnavailable, longestIncident time.Duration
+	var resolvedIncidents, ongoingIncidents int
+
+	for _, inc := range incidents {
+		duration := inc.GetDuration()
+
+		// Cap duration at period bounds
+		if inc.StartedAt.Before(start) {
+			duration -= start.Sub(inc.StartedAt)
+		}
+		if inc.EndedAt != nil && inc.EndedAt.After(end) {
+			duration -= inc.EndedAt.Sub(end)
+		} else if inc.EndedAt == nil && time.Now().After(end) {
+			duration = end.Sub(inc.StartedAt)
+			if inc.StartedAt.Before(start) {
+				duration = totalDuration
+			}
+		}
+
+		totalDowntime += duration
+
+		if inc.MaxSeverity.IsUnavailable() {
+			totalUnavailable += duration
+		}
+
+		if duration > longestIncident {
+			longestIncident = duration
+		}
+
+		if inc.IsResolved() {
+			resolvedIncidents++
+		} else {
+			ongoingIncidents++
+		}
+	}
+
+	greenDuration := totalDuration - totalDowntime
+	if greenDuration < 0 {
+		greenDuration = 0
+	}
+
+	availableDuration := totalDuration - totalUnavailable
+
+	analytics := &domain.Analytics{
+		EntityID:            entityID,
+		EntityType:          entityType,
+		EntityName:          entityName,
+		PeriodStart:         start,
+		PeriodEnd:           end,
+		TotalIncidents:      len(incidents),
+		ResolvedIncidents:   resolvedIncidents,
+		OngoingIncidents:    ongoingIncidents,
+		TotalDowntime:       totalDowntime,
+		TotalUnavailable:    totalUnavailable,
+		MTTR:                domain.CalculateMTTR(incidents),
+		LongestIncident:     longestIncident,
+		UptimePercent:       domain.CalculateUptime(greenDuration, totalDuration),
+		AvailabilityPercent: domain.CalculateUptime(availableDuration, totalDuration),
+		Period:              periodLabel(totalDuration),
+	}
+
+	return analytics
+}
+
+// periodLabel returns a human-readable label for an analytics period
+func periodLabel(totalDuration time.Duration) string {
+	hours := totalDuration.Hours()
+	switch {
+	case hours <= 24:
+		return "24h"
+	case hours <= 24*7:
+		return "7d"
+	case hours <= 24*30:
+		return "30d"
+	default:
+		return fmt.Sprintf("%.0fd", hours/24)
+	}
+}
+
+// getSystemIDs returns the IDs of all systems
+func (r *AnalyticsRepo) getSystemIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM systems")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	defer rows.Close()
+
+	var systemIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan system id: %w", err)
+		}
+		systemIDs = append(systemIDs, id)
+	}
+
+	return systemIDs, rows.Err()
+}
+
+// uptimeTotals accumulates downtime and incident figures over a time window
+type uptimeTotals struct {
+	downtime    time.Duration
+	unavailable time.Duration
+	recovery    time.Duration
+	longest     time.Duration
+	incidents   int
+	resolved    int
+	ongoing     bool
+}
+
+func (t *uptimeTotals) addSegment(status domain.Status, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	if status != domain.StatusGreen {
+		t.downtime += d
+	}
+	if status.IsUnavailable() {
+		t.unavailable += d
+	}
+}
+
+func (t *uptimeTotals) resolve(d time.Duration) {
+	t.resolved++
+	t.recovery += d
+	if d > t.longest {
+		t.longest = d
+	}
+}
+
+func (t *uptimeTotals) add(other uptimeTotals) {
+	t.downtime += other.downtime
+	t.unavailable += other.unavailable
+	t.recovery += other.recovery
+	t.incidents += other.incidents
+	t.resolved += other.resolved
+	if other.longest > t.longest {
+		t.longest = other.longest
+	}
+}
+
+// startOfDay returns midnight of the day containing t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// computeWindow walks a system's status timeline over [from, to).
+// The status at the window start is taken from the last log before it, so
+// incidents spanning midnight are split correctly between days.
+// Unavailability counts only time actually spent red.
+func (r *AnalyticsRepo) computeWindow(ctx context.Context, systemID int64, from, to time.Time) (uptimeTotals, error) {
+	var totals uptimeTotals
+	if !from.Before(to) {
+		return totals, nil
+	}
+
+	status := domain.StatusGreen
+	streakStart := from
+
+	var lastStatus string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT new_status FROM status_log
+		WHERE system_id = ? AND created_at < ?
+		ORDER BY created_at DESC LIMIT 1
+	`, systemID, from).Scan(&lastStatus)
+	if err != nil && err != sql.ErrNoRows {
+		return totals, fmt.Errorf("failed to get status before window: %w", err)
+	}
+	if err == nil {
+		status, _ = domain.NewStatus(lastStatus)
+	}
+
+	if status != domain.StatusGreen {
+		var startedAt time.Time
+		err := r.db.QueryRowContext(ctx, `
+			SELECT created_at FROM status_log
+			WHERE system_id = ? AND created_at < ? AND old_status = 'green' AND new_status != 'green'
+			ORDER BY created_at DESC LIMIT 1
+		`, systemID, from).Scan(&startedAt)
+		if err != nil && err != sql.ErrNoRows {
+			return totals, fmt.Errorf("failed to get incident start: %w", err)
+		}
+		if err == nil {
+			streakStart = startedAt
+		}
+	}
+
+	logs, err := r.logRepo.GetSystemLogsByTimeRange(ctx, systemID, from, to)
+	if err != nil {
+		return totals, err
+	}
+
+	cursor := from
+	for _, log := range logs {
+		if !log.CreatedAt.Before(to) {
+			break
+		}
+
+		totals.addSegment(status, log.CreatedAt.Sub(cursor))
+		if log.IsIncidentStart() {
+			totals.incidents++
+			streakStart = log.CreatedAt
+		} else if log.IsIncidentEnd() {
+			totals.resolve(log.CreatedAt.Sub(streakStart))
+		}
+
+		status = log.NewStatus
+		cursor = log.CreatedAt
+	}
+	totals.addSegment(status, to.Sub(cursor))
+	totals.ongoing = status != domain.StatusGreen
+
+	return totals, nil
+}
+
+// RollupDailyUptime precomputes uptime totals of every system for the day containing day
+func (r *AnalyticsRepo) RollupDailyUptime(ctx context.Context, day time.Time) error {
+	dayStart := startOfDay(day)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	systemIDs, err := r.getSystemIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, systemID := range systemIDs {
+		totals, err := r.computeWindow(ctx, systemID, dayStart, dayEnd)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.ExecContext(ctx, `
+			INSERT INTO uptime_rollups
+				(system_id, day, downtime_seconds, unavailable_seconds, incidents, resolved, recovery_seconds, longest_seconds, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (system_id, day) DO UPDATE SET
+				downtime_seconds = EXCLUDED.downtime_seconds,
+				unavailable_seconds = EXCLUDED.unavailable_seconds,
+				incidents = EXCLUDED.incidents,
+				resolved = EXCLUDED.resolved,
+				recovery_seconds = EXCLUDED.recovery_seconds,
+				longest_seconds = EXCLUDED.longest_seconds,
+				updated_at = EXCLUDED.updated_at
+		`,
+			systemID,
+			dayStart.Format("2006-01-02"),
+			totals.downtime.Seconds(),
+			totals.unavailable.Seconds(),
+			totals.incidents,
+			totals.resolved,
+			totals.recovery.Seconds(),
+			totals.longest.Seconds(),
+			time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save uptime rollup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getUptimeFromRollups calculates uptime metrics using daily rollups for full days
+// and raw logs for the partial days at both ends of the range.
+// Returns nil if any full day in range has not been rolled up yet.
+func (r *AnalyticsRepo) getUptimeFromRollups(ctx context.Context, systemID int64, name string, start, end time.Time) (*domain.Analytics, error) {
+	firstDay := startOfDay(start)
+	if firstDay.Before(start) {
+		firstDay = firstDay.AddDate(0, 0, 1)
+	}
+	today := startOfDay(end)
+	if !firstDay.Before(today) {
+		return nil, nil
+	}
+
+	expectedDays := 0
+	for d := firstDay; d.Before(today); d = d.AddDate(0, 0, 1) {
+		expectedDays++
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT downtime_seconds, unavailable_seconds, incidents, resolved, recovery_seconds, longest_seconds
+		FROM uptime_rollups
+		WHERE system_id = ? AND day >= ? AND day < ?
+	`, systemID, firstDay.Format("2006-01-02"), today.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query uptime rollups: %w", err)
+	}
+	defer rows.Close()
+
+	var totals uptimeTotals
+	days := 0
+	for rows.Next() {
+		var downtime, unavailable, recovery, longest float64
+		var incidents, resolved int
+		if err := rows.Scan(&downtime, &unavailable, &incidents, &resolved, &recovery, &longest); err != nil {
+			return nil, fmt.Errorf("failed to scan uptime rollup: %w", err)
+		}
+		totals.add(uptimeTotals{
+			downtime:    secondsToDuration(downtime),
+			unavailable: secondsToDuration(unavailable),
+			recovery:    secondsToDuration(recovery),
+			longest:     secondsToDuration(longest),
+			incidents:   incidents,
+			resolved:    resolved,
+		})
+		days++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating uptime rollups: %w", err)
+	}
+
+	if days != expectedDays {
+		return nil, nil
+	}
+
+	head, err := r.computeWindow(ctx, systemID, start, firstDay)
+	if err != nil {
+		return nil, err
+	}
+	tail, err := r.computeWindow(ctx, systemID, today, end)
+	if err != nil {
+		return nil, err
+	}
+	totals.add(head)
+	totals.add(tail)
+
+	totalDuration := end.Sub(start)
+	var mttr time.Duration
+	if totals.resolved > 0 {
+		mttr = totals.recovery / time.Duration(totals.resolved)
+	}
+	var ongoing int
+	if tail.ongoing {
+		ongoing = 1
+	}
+
+	return &domain.Analytics{
+		EntityID:            systemID,
+		EntityType:          "system",
+		EntityName:          name,
+		Period:              periodLabel(totalDuration),
+		PeriodStart:         start,
+		PeriodEnd:           end,
+		TotalIncidents:      totals.incidents,
+		ResolvedIncidents:   totals.resolved,
+		OngoingIncidents:    ongoing,
+		TotalDowntime:       totals.downtime,
+		TotalUnavailable:    totals.unavailable,
+		MTTR:                mttr,
+		LongestIncident:     totals.longest,
+		UptimePercent:       domain.CalculateUptime(totalDuration-totals.downtime, totalDuration),
+		AvailabilityPercent: domain.CalculateUptime(totalDuration-totals.unavailable, totalDuration),
+	}, nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
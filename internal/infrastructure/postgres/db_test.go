@@ -0,0 +1,24 @@
+package postgres
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT id FROM systems", "SELECT id FROM systems"},
+		{"SELECT name FROM systems WHERE id = ?", "SELECT name FROM systems WHERE id = $1"},
+		{
+			"SELECT * FROM status_log WHERE system_id = ? AND created_at BETWEEN ? AND ? LIMIT ? OFFSET ?",
+			"SELECT * FROM status_log WHERE system_id = $1 AND created_at BETWEEN $2 AND $3 LIMIT $4 OFFSET $5",
+		},
+		{"floor(x / (?::int * 60)) * (?::int * 60)", "floor(x / ($1::int * 60)) * ($2::int * 60)"},
+	}
+
+	for _, tt := range tests {
+		if got := rebind(tt.query); got != tt.want {
+			t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,475 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// setupTestDB connects to the database in POSTGRES_TEST_DSN, migrates it and
+// empties every table. Tests are skipped when the variable is not set.
+func setupTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+
+	db, err := New(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		db.Close()
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	_, err = db.Exec(`
+		TRUNCATE systems, dependencies, status_log, webhooks, maintenances, incidents, incident_updates,
+			api_keys, latency_history, sla_reports, sla_breaches, uptime_rollups, subscribers, system_dependencies
+		RESTART IDENTITY CASCADE
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+
+	return db
+}
+
+func createTestSystem(t *testing.T, db *DB, name string) *domain.System {
+	t.Helper()
+
+	system, err := domain.NewSystem(name, "", "", "")
+	if err != nil {
+		t.Fatalf("failed to create test system: %v", err)
+	}
+	if err := NewSystemRepo(db).Create(context.Background(), system); err != nil {
+		t.Fatalf("failed to persist test system: %v", err)
+	}
+	return system
+}
+
+func createTestDependency(t *testing.T, db *DB) *domain.Dependency {
+	t.Helper()
+	system := createTestSystem(t, db, "Latency")
+
+	dep, err := domain.NewDependency(system.ID, "Database", "")
+	if err != nil {
+		t.Fatalf("failed to create test dependency: %v", err)
+	}
+	dep.HeartbeatMethod = "GET" // Required by schema
+	if err := NewDependencyRepo(db).Create(context.Background(), dep); err != nil {
+		t.Fatalf("failed to persist test dependency: %v", err)
+	}
+	return dep
+}
+
+func TestDB_MigrateIsIdempotent(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+	if got, want := db.SchemaVersion(), migrations[len(migrations)-1].Version; got != want {
+		t.Errorf("SchemaVersion() = %d, want %d", got, want)
+	}
+}
+
+func TestSystemRepo_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSystemRepo(db)
+	ctx := context.Background()
+
+	system, _ := domain.NewSystem("API", "Public API", "https://api.example.com", "platform")
+	system.Tags = []string{"production"}
+	system.SetSLATarget(99.5)
+	if err := repo.Create(ctx, system); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if system.ID == 0 {
+		t.Fatal("expected ID to be set after Create()")
+	}
+
+	got, err := repo.GetByID(ctx, system.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetByID() = %v, %v", got, err)
+	}
+	if got.Name != "API" || !got.SLATargetExplicit || got.SLATarget != 99.5 {
+		t.Errorf("GetByID() = %+v, want persisted fields", got)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "production" {
+		t.Errorf("Tags = %v, want [production]", got.Tags)
+	}
+
+	missing, err := repo.GetByID(ctx, 99999)
+	if err != nil || missing != nil {
+		t.Errorf("GetByID(missing) = %v, %v, want nil, nil", missing, err)
+	}
+
+	if err := repo.Delete(ctx, system.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(ctx, system.ID); err == nil {
+		t.Error("expected error deleting a missing system")
+	}
+}
+
+func TestDependencyRepo_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db, "API")
+	repo := NewDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewDependency(system.ID, "Database", "")
+	dep.HeartbeatURL = "https://db.example.com/health"
+	dep.HeartbeatMethod = "GET"
+	dep.HeartbeatInterval = 30
+	dep.HeartbeatHeaders = map[string]string{"Authorization": "Bearer token"}
+	dep.RecordLatency = false
+	dep.Criticality = domain.CriticalityMinor
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	deps, err := repo.GetAllWithHeartbeat(ctx)
+	if err != nil {
+		t.Fatalf("GetAllWithHeartbeat() error = %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("GetAllWithHeartbeat() returned %d dependencies, want 1", len(deps))
+	}
+	got := deps[0]
+	if got.RecordLatency || got.Criticality != domain.CriticalityMinor {
+		t.Errorf("RecordLatency = %v, Criticality = %s, want false, minor", got.RecordLatency, got.Criticality)
+	}
+	if got.HeartbeatHeaders["Authorization"] != "Bearer token" {
+		t.Errorf("HeartbeatHeaders = %v", got.HeartbeatHeaders)
+	}
+	if !got.LastCheck.IsZero() {
+		t.Errorf("LastCheck = %v, want zero", got.LastCheck)
+	}
+
+	got.LastCheck = time.Now()
+	got.LastLatency = 42
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	updated, _ := repo.GetByID(ctx, dep.ID)
+	if updated.LastLatency != 42 || updated.LastCheck.IsZero() {
+		t.Errorf("Update() not persisted: %+v", updated)
+	}
+}
+
+func TestLogRepo_GetPageAndCount(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db, "API")
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	for _, source := range []domain.ChangeSource{domain.SourceManual, domain.SourceHeartbeat, domain.SourcePropagation} {
+		log := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusRed, "down", source)
+		if err := repo.Create(ctx, log); err != nil {
+			t.Fatalf("Create(%s) error = %v", source, err)
+		}
+	}
+
+	count, err := repo.Count(ctx)
+	if err != nil || count != 3 {
+		t.Fatalf("Count() = %d, %v, want 3", count, err)
+	}
+	page, err := repo.GetPage(ctx, 2, 2)
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	if len(page) != 1 {
+		t.Errorf("GetPage(2, 2) returned %d logs, want 1", len(page))
+	}
+}
+
+func TestIncidentRepo_GetPage_SystemFilter(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	api := createTestSystem(t, db, "API")
+	web := createTestSystem(t, db, "Web")
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	for _, systemIDs := range [][]int64{{api.ID}, {web.ID}, nil, {api.ID, web.ID}} {
+		incident, _ := domain.NewIncident("Outage", "", domain.SeverityMajor)
+		incident.SystemIDs = systemIDs
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	filter := domain.IncidentFilter{SystemID: api.ID, SortBy: domain.IncidentSortCreatedAt}
+	count, err := repo.Count(ctx, filter)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Count() = %d, want 3 (own, all-systems and shared incident)", count)
+	}
+
+	page, err := repo.GetPage(ctx, filter, 10, 0)
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	if len(page) != 3 {
+		t.Errorf("GetPage() returned %d incidents, want 3", len(page))
+	}
+}
+
+func TestLatencyRepo_Aggregation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	dep := createTestDependency(t, db)
+	repo := NewLatencyRepo(db)
+	ctx := context.Background()
+
+	for _, r := range []struct {
+		ms      int64
+		success bool
+	}{{100, true}, {200, true}, {3000, false}} {
+		record := &domain.LatencyRecord{DependencyID: dep.ID, LatencyMs: r.ms, Success: r.success, StatusCode: 200}
+		if err := repo.Record(ctx, record); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	start, end := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	points, err := repo.GetAggregated(ctx, dep.ID, start, end, 5)
+	if err != nil {
+		t.Fatalf("GetAggregated() error = %v", err)
+	}
+	var total, failures int
+	for _, p := range points {
+		if p.Timestamp.IsZero() || p.Timestamp.Minute()%5 != 0 || p.Timestamp.Second() != 0 {
+			t.Errorf("Timestamp = %v, want start of a 5 minute bucket", p.Timestamp)
+		}
+		total += p.Count
+		failures += p.Failures
+	}
+	if total != 3 || failures != 1 {
+		t.Errorf("GetAggregated() counted %d checks, %d failures, want 3, 1", total, failures)
+	}
+
+	days, err := repo.GetDailyUptime(ctx, dep.ID, 7)
+	if err != nil {
+		t.Fatalf("GetDailyUptime() error = %v", err)
+	}
+	if len(days) != 8 {
+		t.Fatalf("GetDailyUptime() returned %d days, want 8", len(days))
+	}
+	if today := days[len(days)-1]; today.TotalChecks != 3 || today.FailedChecks != 1 {
+		t.Errorf("today = %+v, want 3 checks with 1 failure", today)
+	}
+
+	stats, err := repo.GetStats(ctx, dep.ID, start, end)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if stats.TotalChecks != 3 || stats.FailedChecks != 1 || stats.AvgLatencyMs != 1100 {
+		t.Errorf("GetStats() = %+v", stats)
+	}
+
+	empty, err := repo.GetStats(ctx, dep.ID, end, end.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetStats(empty) error = %v", err)
+	}
+	if empty.TotalChecks != 0 || empty.UptimePercent != 100 {
+		t.Errorf("GetStats(empty) = %+v", empty)
+	}
+}
+
+func TestAnalyticsRepo_RollupDailyUptime_Upserts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db, "API")
+	repo := NewAnalyticsRepo(db)
+	ctx := context.Background()
+	day := time.Now().AddDate(0, 0, -1)
+
+	for i := 0; i < 2; i++ {
+		if err := repo.RollupDailyUptime(ctx, day); err != nil {
+			t.Fatalf("RollupDailyUptime() run %d error = %v", i+1, err)
+		}
+	}
+
+	var rows int
+	if err := db.QueryRow("SELECT COUNT(*) FROM uptime_rollups WHERE system_id = $1", system.ID).Scan(&rows); err != nil {
+		t.Fatalf("failed to count rollups: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("uptime_rollups has %d rows, want 1", rows)
+	}
+}
+
+func TestWebhookRepo_GetEnabled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	enabled, _ := domain.NewWebhook("Slack", "https://hooks.example.com/a", domain.WebhookTypeSlack)
+	disabled, _ := domain.NewWebhook("Generic", "https://hooks.example.com/b", domain.WebhookTypeGeneric)
+	disabled.Enabled = false
+	for _, w := range []*domain.Webhook{enabled, disabled} {
+		if err := repo.Create(ctx, w); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	webhooks, err := repo.GetEnabled(ctx)
+	if err != nil {
+		t.Fatalf("GetEnabled() error = %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].ID != enabled.ID {
+		t.Errorf("GetEnabled() = %v, want only the enabled webhook", webhooks)
+	}
+}
+
+func TestSLABreachRepo_Acknowledge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db, "API")
+	repo := NewSLABreachRepo(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	breach := &domain.SLABreachEvent{
+		SystemID:    system.ID,
+		BreachType:  "uptime",
+		SLATarget:   99.9,
+		ActualValue: 98.5,
+		Period:      "daily",
+		PeriodStart: now.AddDate(0, 0, -1),
+		PeriodEnd:   now,
+		DetectedAt:  now,
+	}
+	if err := repo.Create(ctx, breach); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	unacked, err := repo.GetUnacknowledged(ctx)
+	if err != nil || len(unacked) != 1 {
+		t.Fatalf("GetUnacknowledged() = %d breaches, %v, want 1", len(unacked), err)
+	}
+	if unacked[0].SystemName != "API" {
+		t.Errorf("SystemName = %q, want API", unacked[0].SystemName)
+	}
+
+	if err := repo.Acknowledge(ctx, breach.ID, "admin"); err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	unacked, err = repo.GetUnacknowledged(ctx)
+	if err != nil || len(unacked) != 0 {
+		t.Errorf("GetUnacknowledged() after ack = %d breaches, %v, want 0", len(unacked), err)
+	}
+}
+
+func TestSubscriberRepo_GetConfirmed(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSubscriberRepo(db)
+	ctx := context.Background()
+
+	confirmed, _ := domain.NewSubscriber("ops@example.com", nil)
+	confirmed.Confirm()
+	pending, _ := domain.NewSubscriber("dev@example.com", []int64{1})
+	for _, s := range []*domain.Subscriber{confirmed, pending} {
+		if err := repo.Create(ctx, s); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	subscribers, err := repo.GetConfirmed(ctx)
+	if err != nil {
+		t.Fatalf("GetConfirmed() error = %v", err)
+	}
+	if len(subscribers) != 1 || subscribers[0].Email != "ops@example.com" {
+		t.Errorf("GetConfirmed() = %v, want only ops@example.com", subscribers)
+	}
+}
+
+func TestSystemDependencyRepo_CreateAndGetDependents(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	api := createTestSystem(t, db, "API")
+	auth := createTestSystem(t, db, "Auth")
+	repo := NewSystemDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewSystemDependency(api.ID, auth.ID)
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	duplicate, _ := domain.NewSystemDependency(api.ID, auth.ID)
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Error("expected unique constraint error for duplicate edge")
+	}
+
+	dependents, err := repo.GetDependents(ctx, auth.ID)
+	if err != nil {
+		t.Fatalf("GetDependents() error = %v", err)
+	}
+	if len(dependents) != 1 || dependents[0].SystemID != api.ID {
+		t.Errorf("GetDependents() = %v, want API", dependents)
+	}
+
+	if err := repo.Delete(ctx, api.ID, auth.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+}
+
+func TestLogRepo_Cleanup(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db, "API")
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	old := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusRed, "old", domain.SourceManual)
+	old.CreatedAt = now.AddDate(0, 0, -40)
+	recent := domain.NewStatusLog(&system.ID, nil, domain.StatusRed, domain.StatusGreen, "recent", domain.SourceManual)
+	for _, log := range []*domain.StatusLog{old, recent} {
+		if err := repo.Create(ctx, log); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := repo.Cleanup(ctx, now.AddDate(0, 0, -30)); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	logs, err := repo.GetAll(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ID != recent.ID {
+		t.Errorf("expected only the recent log to remain, got %d logs", len(logs))
+	}
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"status-incident/internal/domain"
+	"time"
+)
+
+// LogRepo implements domain.StatusLogRepository
+type LogRepo struct {
+	db *DB
+}
+
+// NewLogRepo creates a new LogRepo
+func NewLogRepo(db *DB) *LogRepo {
+	return &LogRepo{db: db}
+}
+
+// Create persists a new status log entry
+func (r *LogRepo) Create(ctx context.Context, log *domain.StatusLog) error {
+	query := `
+		INSERT INTO status_log (system_id, dependency_id, old_status, new_status, message, source, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		log.SystemID,
+		log.DependencyID,
+		log.OldStatus.String(),
+		log.NewStatus.String(),
+		log.Message,
+		string(log.Source),
+		log.Actor,
+		log.CreatedAt,
+	).Scan(&log.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create status log: %w", err)
+	}
+
+	return nil
+}
+
+// GetBySystemID retrieves logs for a system
+func (r *LogRepo) GetBySystemID(ctx context.Context, systemID int64, limit int) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		WHERE system_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, systemID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// GetByDependencyID retrieves logs for a dependency
+func (r *LogRepo) GetByDependencyID(ctx context.Context, dependencyID int64, limit int) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		WHERE dependency_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, dependencyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// GetAll retrieves all logs with optional limit
+func (r *LogRepo) GetAll(ctx context.Context, limit int) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// GetPage retrieves up to limit logs, newest first, skipping the first offset
+func (r *LogRepo) GetPage(ctx context.Context, limit, offset int) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// Count returns the total number of logs
+func (r *LogRepo) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM status_log").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count logs: %w", err)
+	}
+	return count, nil
+}
+
+// GetByTimeRange retrieves logs within a time range
+func (r *LogRepo) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		WHERE created_at >= ? AND created_at <= ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs by time range: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// GetSystemLogsByTimeRange retrieves system logs within time range
+func (r *LogRepo) GetSystemLogsByTimeRange(ctx context.Context, systemID int64, start, end time.Time) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		WHERE system_id = ? AND created_at >= ? AND created_at <= ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, systemID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query system logs by time range: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// GetDependencyLogsByTimeRange retrieves dependency logs within time range
+func (r *LogRepo) GetDependencyLogsByTimeRange(ctx context.Context, dependencyID int64, start, end time.Time) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		WHERE dependency_id = ? AND created_at >= ? AND created_at <= ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, dependencyID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependency logs by time range: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// Cleanup removes logs created before olderThan
+func (r *LogRepo) Cleanup(ctx context.Context, olderThan time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM status_log WHERE created_at < ?`, olderThan); err != nil {
+		return fmt.Errorf("failed to clean up status logs: %w", err)
+	}
+	return nil
+}
+
+func (r *LogRepo) scanLogs(rows *sql.Rows) ([]*domain.StatusLog, error) {
+	var logs []*domain.StatusLog
+
+	for rows.Next() {
+		var log domain.StatusLog
+		var systemID, dependencyID sql.NullInt64
+		var oldStatusStr, newStatusStr, sourceStr string
+
+		if err := rows.Scan(
+			&log.ID,
+			&systemID,
+			&dependencyID,
+			&oldStatusStr,
+			&newStatusStr,
+			&log.Message,
+			&sourceStr,
+			&log.Actor,
+			&log.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan log: %w", err)
+		}
+
+		if systemID.Valid {
+			log.SystemID = &systemID.Int64
+		}
+		if dependencyID.Valid {
+			log.DependencyID = &dependencyID.Int64
+		}
+
+		oldStatus, _ := domain.NewStatus(oldStatusStr)
+		newStatus, _ := domain.NewStatus(newStatusStr)
+		log.OldStatus = oldStatus
+		log.NewStatus = newStatus
+		log.Source = domain.ChangeSource(sourceStr)
+
+		logs = append(logs, &log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating logs: %w", err)
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"status-incident/internal/domain"
+)
+
+// SubscriberRepo implements SubscriberRepository for PostgreSQL
+type SubscriberRepo struct {
+	db *DB
+}
+
+// NewSubscriberRepo creates a new SubscriberRepo
+func NewSubscriberRepo(db *DB) *SubscriberRepo {
+	return &SubscriberRepo{db: db}
+}
+
+// Create persists a new subscriber
+func (r *SubscriberRepo) Create(ctx context.Context, s *domain.Subscriber) error {
+	systemIDsJSON, _ := json.Marshal(s.SystemIDs)
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO subscribers (email, system_ids, token, confirmed, created_at, confirmed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, s.Email, string(systemIDsJSON), s.Token, s.Confirmed, s.CreatedAt, s.ConfirmedAt).Scan(&s.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetByEmail retrieves a subscriber by email address
+func (r *SubscriberRepo) GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT id, email, system_ids, token, confirmed, created_at, confirmed_at
+		FROM subscribers WHERE email = ?
+	`, email)
+
+	return r.scanSubscriber(row)
+}
+
+// GetByToken retrieves a subscriber by confirmation token
+func (r *SubscriberRepo) GetByToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT id, email, system_ids, token, confirmed, created_at, confirmed_at
+		FROM subscribers WHERE token = ?
+	`, token)
+
+	return r.scanSubscriber(row)
+}
+
+// GetConfirmed retrieves all confirmed subscribers
+func (r *SubscriberRepo) GetConfirmed(ctx context.Context) ([]*domain.Subscriber, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, email, system_ids, token, confirmed, created_at, confirmed_at
+		FROM subscribers WHERE confirmed = TRUE
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscribers []*domain.Subscriber
+	for rows.Next() {
+		s, err := r.scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, s)
+	}
+
+	return subscribers, rows.Err()
+}
+
+// Update saves changes to an existing subscriber
+func (r *SubscriberRepo) Update(ctx context.Context, s *domain.Subscriber) error {
+	systemIDsJSON, _ := json.Marshal(s.SystemIDs)
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE subscribers
+		SET email = ?, system_ids = ?, token = ?, confirmed = ?, confirmed_at = ?
+		WHERE id = ?
+	`, s.Email, string(systemIDsJSON), s.Token, s.Confirmed, s.ConfirmedAt, s.ID)
+
+	return err
+}
+
+// Delete removes a subscriber by ID
+func (r *SubscriberRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM subscribers WHERE id = ?", id)
+	return err
+}
+
+func (r *SubscriberRepo) scanSubscriber(row *sql.Row) (*domain.Subscriber, error) {
+	s, err := r.scanRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return s, err
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRow reads a subscriber from a single result row
+func (r *SubscriberRepo) scanRow(row rowScanner) (*domain.Subscriber, error) {
+	var s domain.Subscriber
+	var systemIDsJSON sql.NullString
+	var confirmedAt sql.NullTime
+
+	err := row.Scan(&s.ID, &s.Email, &systemIDsJSON, &s.Token, &s.Confirmed, &s.CreatedAt, &confirmedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if systemIDsJSON.Valid && systemIDsJSON.String != "" && systemIDsJSON.String != "null" {
+		json.Unmarshal([]byte(systemIDsJSON.String), &s.SystemIDs)
+	}
+	if confirmedAt.Valid {
+		s.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return &s, nil
+}
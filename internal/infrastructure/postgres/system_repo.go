@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"status-incident/internal/domain"
+)
+
+// SystemRepo implements domain.SystemRepository
+type SystemRepo struct {
+	db *DB
+}
+
+// NewSystemRepo creates a new SystemRepo
+func NewSystemRepo(db *DB) *SystemRepo {
+	return &SystemRepo{db: db}
+}
+
+// Create persists a new system and sets its ID
+func (r *SystemRepo) Create(ctx context.Context, system *domain.System) error {
+	query := `
+		INSERT INTO systems (name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		system.Name,
+		system.Description,
+		system.URL,
+		system.Owner,
+		system.Status.String(),
+		system.GetSLATarget(),
+		system.SLATargetExplicit,
+		system.ResponseTimeTargetMs,
+		tagsJSON(system.Tags),
+		system.DisplayOrder,
+		system.Slug,
+		system.CreatedAt,
+		system.UpdatedAt,
+	).Scan(&system.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create system: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a system by ID
+func (r *SystemRepo) GetByID(ctx context.Context, id int64) (*domain.System, error) {
+	return r.getOne(ctx, "id = ?", id)
+}
+
+// GetBySlug retrieves a system by its public page slug
+func (r *SystemRepo) GetBySlug(ctx context.Context, slug string) (*domain.System, error) {
+	if slug == "" {
+		return nil, nil
+	}
+	return r.getOne(ctx, "slug = ?", slug)
+}
+
+// getOne retrieves the system matching the where clause, or nil
+func (r *SystemRepo) getOne(ctx context.Context, where string, arg interface{}) (*domain.System, error) {
+	query := `
+		SELECT id, name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at
+		FROM systems
+		WHERE ` + where
+
+	var system domain.System
+	var statusStr, tags string
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&system.ID,
+		&system.Name,
+		&system.Description,
+		&system.URL,
+		&system.Owner,
+		&statusStr,
+		&system.SLATarget,
+		&system.SLATargetExplicit,
+		&system.ResponseTimeTargetMs,
+		&tags,
+		&system.DisplayOrder,
+		&system.Slug,
+		&system.CreatedAt,
+		&system.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system: %w", err)
+	}
+
+	status, _ := domain.NewStatus(statusStr)
+	system.Status = status
+	system.Tags = parseTagsJSON(tags)
+
+	return &system, nil
+}
+
+// GetAll retrieves all systems
+func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
+	query := `
+		SELECT id, name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at
+		FROM systems
+		ORDER BY display_order ASC, name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query systems: %w", err)
+	}
+	defer rows.Close()
+
+	var systems []*domain.System
+	for rows.Next() {
+		var system domain.System
+		var statusStr, tags string
+
+		if err := rows.Scan(
+			&system.ID,
+			&system.Name,
+			&system.Description,
+			&system.URL,
+			&system.Owner,
+			&statusStr,
+			&system.SLATarget,
+			&system.SLATargetExplicit,
+			&system.ResponseTimeTargetMs,
+			&tags,
+			&system.DisplayOrder,
+			&system.Slug,
+			&system.CreatedAt,
+			&system.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan system: %w", err)
+		}
+
+		status, _ := domain.NewStatus(statusStr)
+		system.Status = status
+		system.Tags = parseTagsJSON(tags)
+		systems = append(systems, &system)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating systems: %w", err)
+	}
+
+	return systems, nil
+}
+
+// Update saves changes to an existing system
+func (r *SystemRepo) Update(ctx context.Context, system *domain.System) error {
+	query := `
+		UPDATE systems
+		SET name = ?, description = ?, url = ?, owner = ?, status = ?, sla_target = ?, sla_target_explicit = ?, response_time_target_ms = ?, tags = ?, display_order = ?, slug = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		system.Name,
+		system.Description,
+		system.URL,
+		system.Owner,
+		system.Status.String(),
+		system.GetSLATarget(),
+		system.SLATargetExplicit,
+		system.ResponseTimeTargetMs,
+		tagsJSON(system.Tags),
+		system.DisplayOrder,
+		system.Slug,
+		system.UpdatedAt,
+		system.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update system: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("system not found: %d", system.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a system by ID
+func (r *SystemRepo) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM systems WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete system: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("system not found: %d", id)
+	}
+
+	return nil
+}
+
+func tagsJSON(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(tags)
+	return string(data)
+}
+
+func parseTagsJSON(data string) []string {
+	var tags []string
+	if err := json.Unmarshal([]byte(data), &tags); err != nil {
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"status-incident/internal/domain"
+)
+
+// WebhookDeliveryRepo implements domain.WebhookDeliveryRepository
+type WebhookDeliveryRepo struct {
+	db *DB
+}
+
+// NewWebhookDeliveryRepo creates a new WebhookDeliveryRepo
+func NewWebhookDeliveryRepo(db *DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{db: db}
+}
+
+// Create persists a delivery and prunes the webhook's log to the most recent
+// domain.WebhookDeliveryHistoryLimit entries
+func (r *WebhookDeliveryRepo) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, status, status_code, attempts, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.WebhookID,
+		delivery.Event,
+		delivery.Status,
+		delivery.StatusCode,
+		delivery.Attempts,
+		delivery.Error,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	prune := `
+		DELETE FROM webhook_deliveries
+		WHERE webhook_id = ? AND id NOT IN (
+			SELECT id FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ?
+		)
+	`
+	if _, err := r.db.ExecContext(ctx, prune, delivery.WebhookID, delivery.WebhookID, domain.WebhookDeliveryHistoryLimit); err != nil {
+		return fmt.Errorf("failed to prune webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// GetByWebhook retrieves the most recent deliveries of a webhook, newest first
+func (r *WebhookDeliveryRepo) GetByWebhook(ctx context.Context, webhookID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event, status, status_code, attempts, error, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d := &domain.WebhookDelivery{}
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Status, &d.StatusCode, &d.Attempts, &d.Error, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
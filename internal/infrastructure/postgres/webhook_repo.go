@@ -0,0 +1,265 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"status-incident/internal/domain"
+)
+
+// WebhookRepo implements domain.WebhookRepository
+type WebhookRepo struct {
+	db *DB
+}
+
+// NewWebhookRepo creates a new WebhookRepo
+func NewWebhookRepo(db *DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+// Create persists a new webhook
+func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		webhook.Name,
+		webhook.URL,
+		webhook.Type,
+		webhook.EventsJSON(),
+		webhook.SystemIDsJSON(),
+		webhook.PayloadVersion,
+		webhook.IncludeEntity,
+		webhook.SlackBlocks,
+		webhook.PayloadTemplate,
+		webhook.Secret,
+		webhook.MinSeverity,
+		webhook.TriggerStatusesJSON(),
+		webhook.Enabled,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	).Scan(&webhook.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a webhook by ID
+func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
+	query := `
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
+		FROM webhooks
+		WHERE id = ?
+	`
+
+	webhook := &domain.Webhook{}
+	var eventsJSON string
+	var systemIDsJSON, triggerStatusesJSON sql.NullString
+	var lastDeliveryAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.Name,
+		&webhook.URL,
+		&webhook.Type,
+		&eventsJSON,
+		&systemIDsJSON,
+		&webhook.PayloadVersion,
+		&webhook.IncludeEntity,
+		&webhook.SlackBlocks,
+		&webhook.PayloadTemplate,
+		&webhook.Secret,
+		&webhook.MinSeverity,
+		&triggerStatusesJSON,
+		&webhook.Enabled,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+		&lastDeliveryAt,
+		&webhook.LastDeliveryStatus,
+		&webhook.LastDeliveryCode,
+		&webhook.ConsecutiveFailures,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	webhook.Events = domain.ParseEventsJSON(eventsJSON)
+	if systemIDsJSON.Valid {
+		webhook.SystemIDs = domain.ParseSystemIDsJSON(&systemIDsJSON.String)
+	}
+	if triggerStatusesJSON.Valid {
+		webhook.TriggerStatuses = domain.ParseTriggerStatusesJSON(&triggerStatusesJSON.String)
+	}
+	if lastDeliveryAt.Valid {
+		webhook.LastDeliveryAt = &lastDeliveryAt.Time
+	}
+
+	return webhook, nil
+}
+
+// GetAll retrieves all webhooks
+func (r *WebhookRepo) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	query := `
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
+		FROM webhooks
+		ORDER BY created_at DESC
+	`
+
+	return r.queryWebhooks(ctx, query)
+}
+
+// GetEnabled retrieves all enabled webhooks
+func (r *WebhookRepo) GetEnabled(ctx context.Context) ([]*domain.Webhook, error) {
+	query := `
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
+		FROM webhooks
+		WHERE enabled = TRUE
+		ORDER BY created_at DESC
+	`
+
+	return r.queryWebhooks(ctx, query)
+}
+
+func (r *WebhookRepo) queryWebhooks(ctx context.Context, query string, args ...interface{}) ([]*domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*domain.Webhook
+	for rows.Next() {
+		webhook := &domain.Webhook{}
+		var eventsJSON string
+		var systemIDsJSON, triggerStatusesJSON sql.NullString
+		var lastDeliveryAt sql.NullTime
+
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.Name,
+			&webhook.URL,
+			&webhook.Type,
+			&eventsJSON,
+			&systemIDsJSON,
+			&webhook.PayloadVersion,
+			&webhook.IncludeEntity,
+			&webhook.SlackBlocks,
+			&webhook.PayloadTemplate,
+			&webhook.Secret,
+			&webhook.MinSeverity,
+			&triggerStatusesJSON,
+			&webhook.Enabled,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+			&lastDeliveryAt,
+			&webhook.LastDeliveryStatus,
+			&webhook.LastDeliveryCode,
+			&webhook.ConsecutiveFailures,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+
+		webhook.Events = domain.ParseEventsJSON(eventsJSON)
+		if systemIDsJSON.Valid {
+			webhook.SystemIDs = domain.ParseSystemIDsJSON(&systemIDsJSON.String)
+		}
+		if triggerStatusesJSON.Valid {
+			webhook.TriggerStatuses = domain.ParseTriggerStatusesJSON(&triggerStatusesJSON.String)
+		}
+		if lastDeliveryAt.Valid {
+			webhook.LastDeliveryAt = &lastDeliveryAt.Time
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+// Update saves changes to an existing webhook
+func (r *WebhookRepo) Update(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET name = ?, url = ?, type = ?, events = ?, system_ids = ?, payload_version = ?, include_entity = ?, slack_blocks = ?, payload_template = ?, secret = ?, min_severity = ?, trigger_statuses = ?, enabled = ?, consecutive_failures = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		webhook.Name,
+		webhook.URL,
+		webhook.Type,
+		webhook.EventsJSON(),
+		webhook.SystemIDsJSON(),
+		webhook.PayloadVersion,
+		webhook.IncludeEntity,
+		webhook.SlackBlocks,
+		webhook.PayloadTemplate,
+		webhook.Secret,
+		webhook.MinSeverity,
+		webhook.TriggerStatusesJSON(),
+		webhook.Enabled,
+		webhook.ConsecutiveFailures,
+		webhook.UpdatedAt,
+		webhook.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a webhook by ID
+func (r *WebhookRepo) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM webhooks WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// RecordDelivery stores the outcome of the latest delivery and returns the
+// webhook's consecutive failure count. The count is updated in SQL so
+// concurrent sends don't lose increments.
+func (r *WebhookRepo) RecordDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (int, error) {
+	query := `
+		UPDATE webhooks
+		SET last_delivery_at = ?, last_delivery_status = ?, last_delivery_code = ?,
+		    consecutive_failures = CASE WHEN ? THEN 0 ELSE consecutive_failures + 1 END
+		WHERE id = ?
+		RETURNING consecutive_failures
+	`
+
+	var failures int
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.CreatedAt,
+		delivery.Status,
+		delivery.StatusCode,
+		delivery.Succeeded(),
+		delivery.WebhookID,
+	).Scan(&failures)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return failures, nil
+}
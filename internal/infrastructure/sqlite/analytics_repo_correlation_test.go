@@ -329,6 +329,49 @@ func TestOverallCorrelation_NoIncidents(t *testing.T) {
 	}
 }
 
+// TestOverallCorrelation_FailingSystemExcluded verifies that a system whose
+// analytics fail is left out of the average instead of failing the whole
+// overall analytics.
+func TestOverallCorrelation_FailingSystemExcluded(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	db.ExecContext(ctx, "INSERT INTO systems (id, name, description, url, status) VALUES (1, 'System A', 'Test A', 'http://a.test', 'green')")
+	db.ExecContext(ctx, "INSERT INTO systems (id, name, description, url, status) VALUES (2, 'System B', 'Test B', 'http://b.test', 'green')")
+
+	now := time.Now()
+	periodStart := now.Add(-10 * 24 * time.Hour)
+	periodEnd := now
+
+	// A corrupt rollup makes System B's analytics fail
+	_, err := db.ExecContext(ctx, "INSERT INTO uptime_rollups (system_id, day, downtime_seconds) VALUES (2, ?, 'corrupt')",
+		startOfDay(now).AddDate(0, 0, -3).Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("failed to insert rollup: %v", err)
+	}
+
+	analyticsRepo := NewAnalyticsRepo(db)
+	if _, err := analyticsRepo.GetUptimeBySystemID(ctx, 2, periodStart, periodEnd); err == nil {
+		t.Fatal("expected System B analytics to fail")
+	}
+
+	overall, err := analyticsRepo.GetOverallAnalytics(ctx, periodStart, periodEnd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if overall.UptimePercent != 100 {
+		t.Errorf("Overall uptime should only average System A (100%%), got %.2f%%", overall.UptimePercent)
+	}
+
+	// With every system failing there is nothing to report
+	db.ExecContext(ctx, "DELETE FROM systems WHERE id = 1")
+	if _, err := analyticsRepo.GetOverallAnalytics(ctx, periodStart, periodEnd); err == nil {
+		t.Error("expected an error when no system could be analysed")
+	}
+}
+
 // TestOverallCorrelation_NoSystems verifies behavior with no systems.
 func TestOverallCorrelation_NoSystems(t *testing.T) {
 	db := setupTestDB(t)
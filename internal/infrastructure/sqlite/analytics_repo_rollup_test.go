@@ -0,0 +1,274 @@
+package sqlite
+
+import (
+	"context"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"testing"
+	"time"
+)
+
+// seedRollupDataset creates a system with incidents spread over the last ten days,
+// including one that spans midnight
+func seedRollupDataset(t *testing.T, db *DB) (int64, time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, "INSERT INTO systems (id, name, status) VALUES (1, 'Rollup System', 'green')")
+	if err != nil {
+		t.Fatalf("failed to create system: %v", err)
+	}
+
+	today := startOfDay(time.Now())
+	sysID := int64(1)
+	logRepo := NewLogRepo(db)
+
+	transitions := []struct {
+		at        time.Time
+		old, next domain.Status
+	}{
+		// 2h red outage
+		{today.AddDate(0, 0, -8).Add(2 * time.Hour), domain.StatusGreen, domain.StatusRed},
+		{today.AddDate(0, 0, -8).Add(4 * time.Hour), domain.StatusRed, domain.StatusGreen},
+		// 2h yellow degradation spanning midnight
+		{today.AddDate(0, 0, -5).Add(23 * time.Hour), domain.StatusGreen, domain.StatusYellow},
+		{today.AddDate(0, 0, -4).Add(1 * time.Hour), domain.StatusYellow, domain.StatusGreen},
+		// 30m red outage
+		{today.AddDate(0, 0, -2).Add(10 * time.Hour), domain.StatusGreen, domain.StatusRed},
+		{today.AddDate(0, 0, -2).Add(10*time.Hour + 30*time.Minute), domain.StatusRed, domain.StatusGreen},
+	}
+
+	for _, tr := range transitions {
+		err := logRepo.Create(ctx, &domain.StatusLog{
+			SystemID:  &sysID,
+			OldStatus: tr.old,
+			NewStatus: tr.next,
+			Source:    domain.SourceManual,
+			CreatedAt: tr.at,
+		})
+		if err != nil {
+			t.Fatalf("failed to create log: %v", err)
+		}
+	}
+
+	return sysID, today
+}
+
+func TestAnalyticsRepo_RollupMatchesRawComputation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAnalyticsRepo(db)
+	sysID, today := seedRollupDataset(t, db)
+
+	for i := 10; i >= 1; i-- {
+		if err := repo.RollupDailyUptime(ctx, today.AddDate(0, 0, -i)); err != nil {
+			t.Fatalf("RollupDailyUptime() error = %v", err)
+		}
+	}
+
+	// Start mid-day so partial days at the range edges are computed from raw logs
+	start := today.AddDate(0, 0, -10).Add(6 * time.Hour)
+	end := time.Now()
+
+	raw, err := repo.getUptimeFromLogs(ctx, sysID, "Rollup System", start, end)
+	if err != nil {
+		t.Fatalf("getUptimeFromLogs() error = %v", err)
+	}
+	rolled, err := repo.getUptimeFromRollups(ctx, sysID, "Rollup System", start, end)
+	if err != nil {
+		t.Fatalf("getUptimeFromRollups() error = %v", err)
+	}
+	if rolled == nil {
+		t.Fatal("expected rollups to cover the range")
+	}
+
+	if abs(rolled.UptimePercent-raw.UptimePercent) > 0.001 {
+		t.Errorf("UptimePercent = %.4f, raw = %.4f", rolled.UptimePercent, raw.UptimePercent)
+	}
+	if abs(rolled.AvailabilityPercent-raw.AvailabilityPercent) > 0.001 {
+		t.Errorf("AvailabilityPercent = %.4f, raw = %.4f", rolled.AvailabilityPercent, raw.AvailabilityPercent)
+	}
+	if rolled.TotalDowntime != raw.TotalDowntime {
+		t.Errorf("TotalDowntime = %v, raw = %v", rolled.TotalDowntime, raw.TotalDowntime)
+	}
+	if rolled.TotalUnavailable != raw.TotalUnavailable {
+		t.Errorf("TotalUnavailable = %v, raw = %v", rolled.TotalUnavailable, raw.TotalUnavailable)
+	}
+	if rolled.TotalIncidents != raw.TotalIncidents {
+		t.Errorf("TotalIncidents = %d, raw = %d", rolled.TotalIncidents, raw.TotalIncidents)
+	}
+	if rolled.ResolvedIncidents != raw.ResolvedIncidents {
+		t.Errorf("ResolvedIncidents = %d, raw = %d", rolled.ResolvedIncidents, raw.ResolvedIncidents)
+	}
+	if rolled.MTTR != raw.MTTR {
+		t.Errorf("MTTR = %v, raw = %v", rolled.MTTR, raw.MTTR)
+	}
+	if rolled.LongestIncident != raw.LongestIncident {
+		t.Errorf("LongestIncident = %v, raw = %v", rolled.LongestIncident, raw.LongestIncident)
+	}
+}
+
+func TestAnalyticsRepo_GetUptimeBySystemID_UsesRollups(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAnalyticsRepo(db)
+	sysID, today := seedRollupDataset(t, db)
+
+	for i := 10; i >= 1; i-- {
+		if err := repo.RollupDailyUptime(ctx, today.AddDate(0, 0, -i)); err != nil {
+			t.Fatalf("RollupDailyUptime() error = %v", err)
+		}
+	}
+
+	// Drop raw logs; only rollups can still report the downtime
+	if _, err := db.ExecContext(ctx, "DELETE FROM status_log"); err != nil {
+		t.Fatalf("failed to delete logs: %v", err)
+	}
+
+	analytics, err := repo.GetUptimeBySystemID(ctx, sysID, today.AddDate(0, 0, -10), time.Now())
+	if err != nil {
+		t.Fatalf("GetUptimeBySystemID() error = %v", err)
+	}
+
+	want := 4*time.Hour + 30*time.Minute
+	if analytics.TotalDowntime != want {
+		t.Errorf("TotalDowntime = %v, want %v", analytics.TotalDowntime, want)
+	}
+	if analytics.TotalIncidents != 3 {
+		t.Errorf("TotalIncidents = %d, want 3", analytics.TotalIncidents)
+	}
+}
+
+func TestAnalyticsRepo_GetUptimeBySystemID_MissingRollupsFallsBack(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAnalyticsRepo(db)
+	sysID, today := seedRollupDataset(t, db)
+
+	// Only part of the range is rolled up
+	if err := repo.RollupDailyUptime(ctx, today.AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("RollupDailyUptime() error = %v", err)
+	}
+
+	start := today.AddDate(0, 0, -10)
+	end := time.Now()
+
+	rolled, err := repo.getUptimeFromRollups(ctx, sysID, "Rollup System", start, end)
+	if err != nil {
+		t.Fatalf("getUptimeFromRollups() error = %v", err)
+	}
+	if rolled != nil {
+		t.Fatal("expected nil analytics when rollups are incomplete")
+	}
+
+	analytics, err := repo.GetUptimeBySystemID(ctx, sysID, start, end)
+	if err != nil {
+		t.Fatalf("GetUptimeBySystemID() error = %v", err)
+	}
+	if analytics.TotalIncidents != 3 {
+		t.Errorf("TotalIncidents = %d, want 3", analytics.TotalIncidents)
+	}
+}
+
+func TestAnalyticsService_RollupAfterRetentionKeepsPurgedDays(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAnalyticsRepo(db)
+	logRepo := NewLogRepo(db)
+	sysID, today := seedRollupDataset(t, db)
+
+	if err := application.NewAnalyticsService(repo, logRepo).RollupUptime(ctx, 10); err != nil {
+		t.Fatalf("RollupUptime() error = %v", err)
+	}
+
+	// Purge the logs of the outage eight days ago
+	retention := 6 * 24 * time.Hour
+	retentionService := application.NewRetentionService(logRepo, NewLatencyRepo(db), NewSLABreachRepo(db), retention, 0)
+	if err := retentionService.Cleanup(ctx); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	// A restart backfills again; purged days must keep their rollups
+	restarted := application.NewAnalyticsService(repo, logRepo)
+	restarted.SetLogRetention(retention)
+	if err := restarted.RollupUptime(ctx, 90); err != nil {
+		t.Fatalf("RollupUptime() error = %v", err)
+	}
+
+	analytics, err := repo.GetUptimeBySystemID(ctx, sysID, today.AddDate(0, 0, -10), time.Now())
+	if err != nil {
+		t.Fatalf("GetUptimeBySystemID() error = %v", err)
+	}
+	want := 4*time.Hour + 30*time.Minute
+	if analytics.TotalDowntime != want {
+		t.Errorf("TotalDowntime = %v, want %v", analytics.TotalDowntime, want)
+	}
+}
+
+func TestAnalyticsRepo_PartialOutageCountsAgainstUptimeNotAvailability(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "INSERT INTO systems (id, name, status) VALUES (1, 'Partial System', 'green')"); err != nil {
+		t.Fatalf("failed to create system: %v", err)
+	}
+
+	day := startOfDay(time.Now()).AddDate(0, 0, -3)
+	sysID := int64(1)
+	logRepo := NewLogRepo(db)
+	transitions := []struct {
+		at        time.Time
+		old, next domain.Status
+	}{
+		// 2h partial outage, then a 1h full outage
+		{day.Add(2 * time.Hour), domain.StatusGreen, domain.StatusPartial},
+		{day.Add(4 * time.Hour), domain.StatusPartial, domain.StatusGreen},
+		{day.Add(10 * time.Hour), domain.StatusGreen, domain.StatusRed},
+		{day.Add(11 * time.Hour), domain.StatusRed, domain.StatusGreen},
+	}
+	for _, tr := range transitions {
+		err := logRepo.Create(ctx, &domain.StatusLog{
+			SystemID:  &sysID,
+			OldStatus: tr.old,
+			NewStatus: tr.next,
+			Source:    domain.SourceManual,
+			CreatedAt: tr.at,
+		})
+		if err != nil {
+			t.Fatalf("failed to create log: %v", err)
+		}
+	}
+
+	repo := NewAnalyticsRepo(db)
+	if err := repo.RollupDailyUptime(ctx, day); err != nil {
+		t.Fatalf("RollupDailyUptime() error = %v", err)
+	}
+
+	end := day.Add(24 * time.Hour)
+	raw, err := repo.getUptimeFromLogs(ctx, sysID, "Partial System", day, end)
+	if err != nil {
+		t.Fatalf("getUptimeFromLogs() error = %v", err)
+	}
+	rolled, err := repo.getUptimeFromRollups(ctx, sysID, "Partial System", day, end)
+	if err != nil {
+		t.Fatalf("getUptimeFromRollups() error = %v", err)
+	}
+
+	for name, analytics := range map[string]*domain.Analytics{"raw": raw, "rollup": rolled} {
+		if analytics.TotalDowntime != 3*time.Hour {
+			t.Errorf("%s: TotalDowntime = %v, want 3h (partial and red)", name, analytics.TotalDowntime)
+		}
+		if analytics.TotalUnavailable != time.Hour {
+			t.Errorf("%s: TotalUnavailable = %v, want 1h (red only)", name, analytics.TotalUnavailable)
+		}
+	}
+}
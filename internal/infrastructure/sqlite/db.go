@@ -1,12 +1,14 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"status-incident/internal/infrastructure/tracing"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -245,6 +247,499 @@ ALTER TABLE dependencies ADD COLUMN heartbeat_body TEXT NOT NULL DEFAULT '';
 ALTER TABLE dependencies ADD COLUMN heartbeat_expect_status TEXT NOT NULL DEFAULT '';
 ALTER TABLE dependencies ADD COLUMN heartbeat_expect_body TEXT NOT NULL DEFAULT '';
 ALTER TABLE dependencies ADD COLUMN last_status_code INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 9,
+		Name:    "add_uptime_rollups",
+		SQL: `
+CREATE TABLE IF NOT EXISTS uptime_rollups (
+    system_id INTEGER NOT NULL,
+    day TEXT NOT NULL,
+    downtime_seconds REAL NOT NULL DEFAULT 0,
+    unavailable_seconds REAL NOT NULL DEFAULT 0,
+    incidents INTEGER NOT NULL DEFAULT 0,
+    resolved INTEGER NOT NULL DEFAULT 0,
+    recovery_seconds REAL NOT NULL DEFAULT 0,
+    longest_seconds REAL NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (system_id, day),
+    FOREIGN KEY (system_id) REFERENCES systems(id) ON DELETE CASCADE
+);
+`,
+	},
+	{
+		Version: 10,
+		Name:    "add_webhook_payload_version",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN payload_version INTEGER NOT NULL DEFAULT 0;
+
+-- Existing receivers keep the payload shape they were built against
+UPDATE webhooks SET payload_version = 1;
+`,
+	},
+	{
+		Version: 11,
+		Name:    "add_system_display_order",
+		SQL: `
+ALTER TABLE systems ADD COLUMN display_order INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 12,
+		Name:    "add_system_tags_and_explicit_sla",
+		SQL: `
+ALTER TABLE systems ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE systems ADD COLUMN sla_target_explicit INTEGER NOT NULL DEFAULT 0;
+
+-- Targets that differ from the default were set on purpose
+UPDATE systems SET sla_target_explicit = 1 WHERE sla_target != 99.9;
+`,
+	},
+	{
+		Version: 13,
+		Name:    "add_maintenance_notify_subscribers",
+		SQL: `
+ALTER TABLE maintenances ADD COLUMN notify_subscribers INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE maintenances ADD COLUMN start_notified INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 14,
+		Name:    "add_heartbeat_min_tls_version",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_min_tls_version TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 15,
+		Name:    "add_dependency_record_latency",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN record_latency INTEGER NOT NULL DEFAULT 1;
+`,
+	},
+	{
+		Version: 16,
+		Name:    "add_heartbeat_retries",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_retries INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 17,
+		Name:    "add_webhook_include_entity",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN include_entity INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 18,
+		Name:    "add_status_log_actor",
+		SQL: `
+ALTER TABLE status_log ADD COLUMN actor TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 19,
+		Name:    "add_dependency_weight",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN weight REAL NOT NULL DEFAULT 1;
+`,
+	},
+	{
+		Version: 20,
+		Name:    "add_heartbeat_check_type",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_check_type TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 21,
+		Name:    "add_heartbeat_expect_body_substring",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_expect_body_substring TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 22,
+		Name:    "add_webhook_secret",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN secret TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 23,
+		Name:    "add_heartbeat_grpc_service",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_grpc_service TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 24,
+		Name:    "add_dependency_cert_expiry",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_cert_expiry_warning_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE dependencies ADD COLUMN cert_expires_at DATETIME;
+`,
+	},
+	{
+		Version: 25,
+		Name:    "add_system_response_time_target",
+		SQL: `
+ALTER TABLE systems ADD COLUMN response_time_target_ms INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 26,
+		Name:    "add_maintenance_recurrence",
+		SQL: `
+ALTER TABLE maintenances ADD COLUMN recurrence_type TEXT NOT NULL DEFAULT 'none';
+ALTER TABLE maintenances ADD COLUMN recurrence_interval INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE maintenances ADD COLUMN recurrence_until DATETIME;
+ALTER TABLE maintenances ADD COLUMN next_created INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 27,
+		Name:    "add_maintenance_timezone",
+		SQL: `
+ALTER TABLE maintenances ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+`,
+	},
+	{
+		Version: 28,
+		Name:    "create_subscribers",
+		SQL: `
+CREATE TABLE IF NOT EXISTS subscribers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE,
+    system_ids TEXT,
+    token TEXT NOT NULL UNIQUE,
+    confirmed BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscribers_confirmed ON subscribers(confirmed);
+`,
+	},
+	{
+		Version: 29,
+		Name:    "add_dependency_criticality",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN criticality TEXT NOT NULL DEFAULT 'critical';
+`,
+	},
+	{
+		Version: 30,
+		Name:    "create_system_dependencies",
+		SQL: `
+CREATE TABLE IF NOT EXISTS system_dependencies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    system_id INTEGER NOT NULL REFERENCES systems(id) ON DELETE CASCADE,
+    depends_on_id INTEGER NOT NULL REFERENCES systems(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(system_id, depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_system_dependencies_depends_on ON system_dependencies(depends_on_id);
+`,
+	},
+	{
+		Version: 31,
+		Name:    "add_webhook_delivery_tracking",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN last_delivery_at DATETIME;
+ALTER TABLE webhooks ADD COLUMN last_delivery_status TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN last_delivery_code INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhooks ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    status TEXT NOT NULL CHECK(status IN ('success', 'failure')),
+    status_code INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id);
+`,
+	},
+	{
+		Version: 32,
+		Name:    "create_incident_templates",
+		SQL: `
+CREATE TABLE IF NOT EXISTS incident_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    title_pattern TEXT NOT NULL,
+    default_severity TEXT NOT NULL DEFAULT 'minor' CHECK(default_severity IN ('minor', 'major', 'critical')),
+    default_message TEXT NOT NULL DEFAULT '',
+    default_system_ids TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	},
+	{
+		Version: 33,
+		Name:    "add_incident_components",
+		SQL: `
+ALTER TABLE incidents ADD COLUMN components TEXT NOT NULL DEFAULT '[]';
+`,
+	},
+	{
+		// SQLite cannot change a CHECK constraint, so the table is rebuilt to
+		// allow the scheduled status. Foreign keys are off while the old table
+		// is dropped so incident_updates rows are not cascaded away.
+		Version: 34,
+		Name:    "add_incident_scheduling",
+		SQL: `
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE incidents_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'investigating' CHECK(status IN ('scheduled', 'investigating', 'identified', 'monitoring', 'resolved')),
+    severity TEXT NOT NULL DEFAULT 'minor' CHECK(severity IN ('minor', 'major', 'critical')),
+    system_ids TEXT,
+    message TEXT NOT NULL DEFAULT '',
+    postmortem TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at DATETIME,
+    acknowledged_at DATETIME,
+    acknowledged_by TEXT NOT NULL DEFAULT '',
+    components TEXT NOT NULL DEFAULT '[]',
+    scheduled_for DATETIME
+);
+
+INSERT INTO incidents_new (id, title, status, severity, system_ids, message, postmortem,
+    created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by, components)
+SELECT id, title, status, severity, system_ids, message, postmortem,
+    created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by, components
+FROM incidents;
+
+DROP TABLE incidents;
+ALTER TABLE incidents_new RENAME TO incidents;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_status ON incidents(status);
+CREATE INDEX IF NOT EXISTS idx_incidents_created_at ON incidents(created_at);
+
+PRAGMA foreign_keys = ON;
+`,
+	},
+	{
+		Version: 35,
+		Name:    "add_heartbeat_redirect_options",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_follow_redirects INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE dependencies ADD COLUMN heartbeat_max_redirects INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 36,
+		Name:    "add_dependency_consecutive_successes",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN consecutive_successes INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 37,
+		Name:    "add_webhook_filters",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN min_severity TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN trigger_statuses TEXT;
+`,
+	},
+	{
+		Version: 38,
+		Name:    "add_maintenance_last_reminder",
+		SQL: `
+ALTER TABLE maintenances ADD COLUMN last_reminder TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 39,
+		Name:    "add_webhook_slack_blocks",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN slack_blocks INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 40,
+		Name:    "add_system_slug",
+		SQL: `
+ALTER TABLE systems ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_systems_slug ON systems(slug) WHERE slug != '';
+`,
+	},
+	{
+		Version: 41,
+		Name:    "add_webhook_payload_template",
+		SQL: `
+ALTER TABLE webhooks ADD COLUMN payload_template TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 42,
+		Name:    "add_incident_idempotency_keys",
+		SQL: `
+CREATE TABLE IF NOT EXISTS incident_idempotency_keys (
+    idempotency_key TEXT PRIMARY KEY,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_idempotency_keys_expires_at ON incident_idempotency_keys(expires_at);
+`,
+	},
+	{
+		Version: 43,
+		Name:    "add_dependency_heartbeat_timeout",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_timeout_ms INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version: 44,
+		Name:    "add_dependency_dns_check",
+		SQL: `
+ALTER TABLE dependencies ADD COLUMN heartbeat_dns_record_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE dependencies ADD COLUMN heartbeat_dns_expect TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 45,
+		Name:    "allow_partial_status",
+		SQL: `
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE systems_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    owner TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'green' CHECK(status IN ('green', 'yellow', 'partial', 'red')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sla_target REAL NOT NULL DEFAULT 99.9,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    tags TEXT NOT NULL DEFAULT '[]',
+    sla_target_explicit INTEGER NOT NULL DEFAULT 0,
+    response_time_target_ms INTEGER NOT NULL DEFAULT 0,
+    slug TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO systems_new (id, name, description, url, owner, status, created_at, updated_at,
+    sla_target, display_order, tags, sla_target_explicit, response_time_target_ms, slug)
+SELECT id, name, description, url, owner, status, created_at, updated_at,
+    sla_target, display_order, tags, sla_target_explicit, response_time_target_ms, slug
+FROM systems;
+
+DROP TABLE systems;
+ALTER TABLE systems_new RENAME TO systems;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_systems_slug ON systems(slug) WHERE slug != '';
+
+CREATE TABLE dependencies_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    system_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'green' CHECK(status IN ('green', 'yellow', 'partial', 'red')),
+    heartbeat_url TEXT,
+    heartbeat_interval INTEGER NOT NULL DEFAULT 0,
+    last_check DATETIME,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_latency INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    heartbeat_method TEXT NOT NULL DEFAULT 'GET',
+    heartbeat_headers TEXT,
+    heartbeat_body TEXT NOT NULL DEFAULT '',
+    heartbeat_expect_status TEXT NOT NULL DEFAULT '',
+    heartbeat_expect_body TEXT NOT NULL DEFAULT '',
+    last_status_code INTEGER NOT NULL DEFAULT 0,
+    heartbeat_min_tls_version TEXT NOT NULL DEFAULT '',
+    record_latency INTEGER NOT NULL DEFAULT 1,
+    heartbeat_retries INTEGER NOT NULL DEFAULT 0,
+    weight REAL NOT NULL DEFAULT 1,
+    heartbeat_check_type TEXT NOT NULL DEFAULT '',
+    heartbeat_expect_body_substring TEXT NOT NULL DEFAULT '',
+    heartbeat_grpc_service TEXT NOT NULL DEFAULT '',
+    heartbeat_cert_expiry_warning_days INTEGER NOT NULL DEFAULT 0,
+    cert_expires_at DATETIME,
+    criticality TEXT NOT NULL DEFAULT 'critical',
+    heartbeat_follow_redirects INTEGER NOT NULL DEFAULT 1,
+    heartbeat_max_redirects INTEGER NOT NULL DEFAULT 0,
+    consecutive_successes INTEGER NOT NULL DEFAULT 0,
+    heartbeat_timeout_ms INTEGER NOT NULL DEFAULT 0,
+    heartbeat_dns_record_type TEXT NOT NULL DEFAULT '',
+    heartbeat_dns_expect TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (system_id) REFERENCES systems(id) ON DELETE CASCADE
+);
+
+INSERT INTO dependencies_new SELECT * FROM dependencies;
+
+DROP TABLE dependencies;
+ALTER TABLE dependencies_new RENAME TO dependencies;
+
+CREATE INDEX IF NOT EXISTS idx_dependencies_system_id ON dependencies(system_id);
+CREATE INDEX IF NOT EXISTS idx_dependencies_heartbeat ON dependencies(heartbeat_url) WHERE heartbeat_url IS NOT NULL AND heartbeat_url != '';
+
+CREATE TABLE status_log_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    system_id INTEGER,
+    dependency_id INTEGER,
+    old_status TEXT NOT NULL CHECK(old_status IN ('green', 'yellow', 'partial', 'red')),
+    new_status TEXT NOT NULL CHECK(new_status IN ('green', 'yellow', 'partial', 'red')),
+    message TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT 'manual' CHECK(source IN ('manual', 'heartbeat', 'propagation')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    actor TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (system_id) REFERENCES systems(id) ON DELETE SET NULL,
+    FOREIGN KEY (dependency_id) REFERENCES dependencies(id) ON DELETE SET NULL
+);
+
+INSERT INTO status_log_new (id, system_id, dependency_id, old_status, new_status, message, source, created_at, actor)
+SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at, actor
+FROM status_log;
+
+DROP TABLE status_log;
+ALTER TABLE status_log_new RENAME TO status_log;
+
+CREATE INDEX IF NOT EXISTS idx_status_log_system_id ON status_log(system_id);
+CREATE INDEX IF NOT EXISTS idx_status_log_dependency_id ON status_log(dependency_id);
+CREATE INDEX IF NOT EXISTS idx_status_log_created_at ON status_log(created_at);
+
+PRAGMA foreign_keys = ON;
+`,
+	},
+	{
+		Version: 46,
+		Name:    "scope_incident_idempotency_keys",
+		SQL: `
+CREATE TABLE incident_idempotency_keys_new (
+    scope TEXT NOT NULL DEFAULT '',
+    idempotency_key TEXT NOT NULL,
+    incident_id INTEGER REFERENCES incidents(id) ON DELETE CASCADE,
+    expires_at DATETIME NOT NULL,
+    PRIMARY KEY (scope, idempotency_key)
+);
+
+INSERT INTO incident_idempotency_keys_new (idempotency_key, incident_id, expires_at)
+SELECT idempotency_key, incident_id, expires_at
+FROM incident_idempotency_keys;
+
+DROP TABLE incident_idempotency_keys;
+ALTER TABLE incident_idempotency_keys_new RENAME TO incident_idempotency_keys;
+
+CREATE INDEX IF NOT EXISTS idx_incident_idempotency_keys_expires_at ON incident_idempotency_keys(expires_at);
 `,
 	},
 }
@@ -264,6 +759,30 @@ func New(dbPath string) (*DB, error) {
 	return &DB{DB: db, path: dbPath}, nil
 }
 
+// ExecContext executes a query inside a tracing span
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := tracing.StartQuery(ctx, "sqlite", query)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	tracing.EndQuery(span, err)
+	return result, err
+}
+
+// QueryContext runs a query inside a tracing span
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, "sqlite", query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	tracing.EndQuery(span, err)
+	return rows, err
+}
+
+// QueryRowContext runs a single-row query inside a tracing span
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := tracing.StartQuery(ctx, "sqlite", query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	tracing.EndQuery(span, row.Err())
+	return row
+}
+
 // Migrate runs database migrations with version tracking and automatic backup
 func (db *DB) Migrate() error {
 	// Create schema_migrations table if not exists
@@ -21,17 +21,21 @@ func NewDependencyRepo(db *DB) *DependencyRepo {
 // Create persists a new dependency and sets its ID
 func (r *DependencyRepo) Create(ctx context.Context, dep *domain.Dependency) error {
 	query := `
-		INSERT INTO dependencies (system_id, name, description, status, heartbeat_url,
+		INSERT INTO dependencies (system_id, name, description, status, heartbeat_check_type, heartbeat_url,
 			heartbeat_interval, heartbeat_method, heartbeat_headers, heartbeat_body,
-			heartbeat_expect_status, heartbeat_expect_body, last_check, last_latency,
-			last_status_code, consecutive_failures, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			heartbeat_expect_status, heartbeat_expect_body, heartbeat_expect_body_substring, heartbeat_min_tls_version, heartbeat_retries, heartbeat_grpc_service, heartbeat_cert_expiry_warning_days, heartbeat_follow_redirects, heartbeat_max_redirects, heartbeat_timeout_ms, heartbeat_dns_record_type, heartbeat_dns_expect, last_check, last_latency,
+			last_status_code, cert_expires_at, consecutive_failures, consecutive_successes, record_latency, weight, criticality, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var lastCheck interface{}
 	if !dep.LastCheck.IsZero() {
 		lastCheck = dep.LastCheck
 	}
+	var certExpiresAt interface{}
+	if !dep.CertExpiresAt.IsZero() {
+		certExpiresAt = dep.CertExpiresAt
+	}
 
 	headersJSON := encodeHeaders(dep.HeartbeatHeaders)
 
@@ -40,6 +44,7 @@ func (r *DependencyRepo) Create(ctx context.Context, dep *domain.Dependency) err
 		dep.Name,
 		dep.Description,
 		dep.Status.String(),
+		dep.HeartbeatCheckType,
 		nullString(dep.HeartbeatURL),
 		dep.HeartbeatInterval,
 		nullString(dep.HeartbeatMethod),
@@ -47,10 +52,25 @@ func (r *DependencyRepo) Create(ctx context.Context, dep *domain.Dependency) err
 		dep.HeartbeatBody,
 		dep.HeartbeatExpectStatus,
 		dep.HeartbeatExpectBody,
+		dep.HeartbeatExpectBodySubstring,
+		dep.HeartbeatMinTLSVersion,
+		dep.HeartbeatRetries,
+		dep.HeartbeatGRPCService,
+		dep.HeartbeatCertExpiryWarningDays,
+		dep.HeartbeatFollowRedirects,
+		dep.HeartbeatMaxRedirects,
+		dep.HeartbeatTimeoutMs,
+		dep.HeartbeatDNSRecordType,
+		dep.HeartbeatDNSExpect,
 		lastCheck,
 		dep.LastLatency,
 		dep.LastStatusCode,
+		certExpiresAt,
 		dep.ConsecutiveFailures,
+		dep.ConsecutiveSuccesses,
+		dep.RecordLatency,
+		dep.Weight,
+		string(dep.Criticality),
 		dep.CreatedAt,
 		dep.UpdatedAt,
 	)
@@ -70,10 +90,10 @@ func (r *DependencyRepo) Create(ctx context.Context, dep *domain.Dependency) err
 // GetByID retrieves a dependency by ID
 func (r *DependencyRepo) GetByID(ctx context.Context, id int64) (*domain.Dependency, error) {
 	query := `
-		SELECT id, system_id, name, description, status, heartbeat_url,
+		SELECT id, system_id, name, description, status, heartbeat_check_type, heartbeat_url,
 			heartbeat_interval, heartbeat_method, heartbeat_headers, heartbeat_body,
-			heartbeat_expect_status, heartbeat_expect_body, last_check, last_latency,
-			last_status_code, consecutive_failures, created_at, updated_at
+			heartbeat_expect_status, heartbeat_expect_body, heartbeat_expect_body_substring, heartbeat_min_tls_version, heartbeat_retries, heartbeat_grpc_service, heartbeat_cert_expiry_warning_days, heartbeat_follow_redirects, heartbeat_max_redirects, heartbeat_timeout_ms, heartbeat_dns_record_type, heartbeat_dns_expect, last_check, last_latency,
+			last_status_code, cert_expires_at, consecutive_failures, consecutive_successes, record_latency, weight, criticality, created_at, updated_at
 		FROM dependencies
 		WHERE id = ?
 	`
@@ -92,10 +112,10 @@ func (r *DependencyRepo) GetByID(ctx context.Context, id int64) (*domain.Depende
 // GetBySystemID retrieves all dependencies for a system
 func (r *DependencyRepo) GetBySystemID(ctx context.Context, systemID int64) ([]*domain.Dependency, error) {
 	query := `
-		SELECT id, system_id, name, description, status, heartbeat_url,
+		SELECT id, system_id, name, description, status, heartbeat_check_type, heartbeat_url,
 			heartbeat_interval, heartbeat_method, heartbeat_headers, heartbeat_body,
-			heartbeat_expect_status, heartbeat_expect_body, last_check, last_latency,
-			last_status_code, consecutive_failures, created_at, updated_at
+			heartbeat_expect_status, heartbeat_expect_body, heartbeat_expect_body_substring, heartbeat_min_tls_version, heartbeat_retries, heartbeat_grpc_service, heartbeat_cert_expiry_warning_days, heartbeat_follow_redirects, heartbeat_max_redirects, heartbeat_timeout_ms, heartbeat_dns_record_type, heartbeat_dns_expect, last_check, last_latency,
+			last_status_code, cert_expires_at, consecutive_failures, consecutive_successes, record_latency, weight, criticality, created_at, updated_at
 		FROM dependencies
 		WHERE system_id = ?
 		ORDER BY name ASC
@@ -113,10 +133,10 @@ func (r *DependencyRepo) GetBySystemID(ctx context.Context, systemID int64) ([]*
 // GetAllWithHeartbeat retrieves all dependencies with heartbeat configured
 func (r *DependencyRepo) GetAllWithHeartbeat(ctx context.Context) ([]*domain.Dependency, error) {
 	query := `
-		SELECT id, system_id, name, description, status, heartbeat_url,
+		SELECT id, system_id, name, description, status, heartbeat_check_type, heartbeat_url,
 			heartbeat_interval, heartbeat_method, heartbeat_headers, heartbeat_body,
-			heartbeat_expect_status, heartbeat_expect_body, last_check, last_latency,
-			last_status_code, consecutive_failures, created_at, updated_at
+			heartbeat_expect_status, heartbeat_expect_body, heartbeat_expect_body_substring, heartbeat_min_tls_version, heartbeat_retries, heartbeat_grpc_service, heartbeat_cert_expiry_warning_days, heartbeat_follow_redirects, heartbeat_max_redirects, heartbeat_timeout_ms, heartbeat_dns_record_type, heartbeat_dns_expect, last_check, last_latency,
+			last_status_code, cert_expires_at, consecutive_failures, consecutive_successes, record_latency, weight, criticality, created_at, updated_at
 		FROM dependencies
 		WHERE heartbeat_url IS NOT NULL AND heartbeat_url != ''
 	`
@@ -134,10 +154,10 @@ func (r *DependencyRepo) GetAllWithHeartbeat(ctx context.Context) ([]*domain.Dep
 func (r *DependencyRepo) Update(ctx context.Context, dep *domain.Dependency) error {
 	query := `
 		UPDATE dependencies
-		SET name = ?, description = ?, status = ?, heartbeat_url = ?,
+		SET name = ?, description = ?, status = ?, heartbeat_check_type = ?, heartbeat_url = ?,
 			heartbeat_interval = ?, heartbeat_method = ?, heartbeat_headers = ?,
-			heartbeat_body = ?, heartbeat_expect_status = ?, heartbeat_expect_body = ?,
-			last_check = ?, last_latency = ?, last_status_code = ?, consecutive_failures = ?, updated_at = ?
+			heartbeat_body = ?, heartbeat_expect_status = ?, heartbeat_expect_body = ?, heartbeat_expect_body_substring = ?, heartbeat_min_tls_version = ?, heartbeat_retries = ?, heartbeat_grpc_service = ?, heartbeat_cert_expiry_warning_days = ?, heartbeat_follow_redirects = ?, heartbeat_max_redirects = ?, heartbeat_timeout_ms = ?, heartbeat_dns_record_type = ?, heartbeat_dns_expect = ?,
+			last_check = ?, last_latency = ?, last_status_code = ?, cert_expires_at = ?, consecutive_failures = ?, consecutive_successes = ?, record_latency = ?, weight = ?, criticality = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -145,6 +165,10 @@ func (r *DependencyRepo) Update(ctx context.Context, dep *domain.Dependency) err
 	if !dep.LastCheck.IsZero() {
 		lastCheck = dep.LastCheck
 	}
+	var certExpiresAt interface{}
+	if !dep.CertExpiresAt.IsZero() {
+		certExpiresAt = dep.CertExpiresAt
+	}
 
 	headersJSON := encodeHeaders(dep.HeartbeatHeaders)
 
@@ -152,6 +176,7 @@ func (r *DependencyRepo) Update(ctx context.Context, dep *domain.Dependency) err
 		dep.Name,
 		dep.Description,
 		dep.Status.String(),
+		dep.HeartbeatCheckType,
 		nullString(dep.HeartbeatURL),
 		dep.HeartbeatInterval,
 		nullString(dep.HeartbeatMethod),
@@ -159,10 +184,25 @@ func (r *DependencyRepo) Update(ctx context.Context, dep *domain.Dependency) err
 		dep.HeartbeatBody,
 		dep.HeartbeatExpectStatus,
 		dep.HeartbeatExpectBody,
+		dep.HeartbeatExpectBodySubstring,
+		dep.HeartbeatMinTLSVersion,
+		dep.HeartbeatRetries,
+		dep.HeartbeatGRPCService,
+		dep.HeartbeatCertExpiryWarningDays,
+		dep.HeartbeatFollowRedirects,
+		dep.HeartbeatMaxRedirects,
+		dep.HeartbeatTimeoutMs,
+		dep.HeartbeatDNSRecordType,
+		dep.HeartbeatDNSExpect,
 		lastCheck,
 		dep.LastLatency,
 		dep.LastStatusCode,
+		certExpiresAt,
 		dep.ConsecutiveFailures,
+		dep.ConsecutiveSuccesses,
+		dep.RecordLatency,
+		dep.Weight,
+		string(dep.Criticality),
 		dep.UpdatedAt,
 		dep.ID,
 	)
@@ -207,7 +247,7 @@ func (r *DependencyRepo) scanDependency(row *sql.Row) (*domain.Dependency, error
 	var dep domain.Dependency
 	var statusStr string
 	var heartbeatURL, heartbeatMethod, heartbeatHeaders sql.NullString
-	var lastCheck sql.NullTime
+	var lastCheck, certExpiresAt sql.NullTime
 
 	err := row.Scan(
 		&dep.ID,
@@ -215,6 +255,7 @@ func (r *DependencyRepo) scanDependency(row *sql.Row) (*domain.Dependency, error
 		&dep.Name,
 		&dep.Description,
 		&statusStr,
+		&dep.HeartbeatCheckType,
 		&heartbeatURL,
 		&dep.HeartbeatInterval,
 		&heartbeatMethod,
@@ -222,10 +263,25 @@ func (r *DependencyRepo) scanDependency(row *sql.Row) (*domain.Dependency, error
 		&dep.HeartbeatBody,
 		&dep.HeartbeatExpectStatus,
 		&dep.HeartbeatExpectBody,
+		&dep.HeartbeatExpectBodySubstring,
+		&dep.HeartbeatMinTLSVersion,
+		&dep.HeartbeatRetries,
+		&dep.HeartbeatGRPCService,
+		&dep.HeartbeatCertExpiryWarningDays,
+		&dep.HeartbeatFollowRedirects,
+		&dep.HeartbeatMaxRedirects,
+		&dep.HeartbeatTimeoutMs,
+		&dep.HeartbeatDNSRecordType,
+		&dep.HeartbeatDNSExpect,
 		&lastCheck,
 		&dep.LastLatency,
 		&dep.LastStatusCode,
+		&certExpiresAt,
 		&dep.ConsecutiveFailures,
+		&dep.ConsecutiveSuccesses,
+		&dep.RecordLatency,
+		&dep.Weight,
+		&dep.Criticality,
 		&dep.CreatedAt,
 		&dep.UpdatedAt,
 	)
@@ -248,6 +304,9 @@ func (r *DependencyRepo) scanDependency(row *sql.Row) (*domain.Dependency, error
 	if lastCheck.Valid {
 		dep.LastCheck = lastCheck.Time
 	}
+	if certExpiresAt.Valid {
+		dep.CertExpiresAt = certExpiresAt.Time
+	}
 
 	return &dep, nil
 }
@@ -259,7 +318,7 @@ func (r *DependencyRepo) scanDependencies(rows *sql.Rows) ([]*domain.Dependency,
 		var dep domain.Dependency
 		var statusStr string
 		var heartbeatURL, heartbeatMethod, heartbeatHeaders sql.NullString
-		var lastCheck sql.NullTime
+		var lastCheck, certExpiresAt sql.NullTime
 
 		if err := rows.Scan(
 			&dep.ID,
@@ -267,6 +326,7 @@ func (r *DependencyRepo) scanDependencies(rows *sql.Rows) ([]*domain.Dependency,
 			&dep.Name,
 			&dep.Description,
 			&statusStr,
+			&dep.HeartbeatCheckType,
 			&heartbeatURL,
 			&dep.HeartbeatInterval,
 			&heartbeatMethod,
@@ -274,10 +334,25 @@ func (r *DependencyRepo) scanDependencies(rows *sql.Rows) ([]*domain.Dependency,
 			&dep.HeartbeatBody,
 			&dep.HeartbeatExpectStatus,
 			&dep.HeartbeatExpectBody,
+			&dep.HeartbeatExpectBodySubstring,
+			&dep.HeartbeatMinTLSVersion,
+		&dep.HeartbeatRetries,
+			&dep.HeartbeatGRPCService,
+			&dep.HeartbeatCertExpiryWarningDays,
+			&dep.HeartbeatFollowRedirects,
+			&dep.HeartbeatMaxRedirects,
+			&dep.HeartbeatTimeoutMs,
+			&dep.HeartbeatDNSRecordType,
+			&dep.HeartbeatDNSExpect,
 			&lastCheck,
 			&dep.LastLatency,
 			&dep.LastStatusCode,
+			&certExpiresAt,
 			&dep.ConsecutiveFailures,
+			&dep.ConsecutiveSuccesses,
+			&dep.RecordLatency,
+			&dep.Weight,
+			&dep.Criticality,
 			&dep.CreatedAt,
 			&dep.UpdatedAt,
 		); err != nil {
@@ -299,6 +374,9 @@ func (r *DependencyRepo) scanDependencies(rows *sql.Rows) ([]*domain.Dependency,
 		if lastCheck.Valid {
 			dep.LastCheck = lastCheck.Time
 		}
+		if certExpiresAt.Valid {
+			dep.CertExpiresAt = certExpiresAt.Time
+		}
 
 		deps = append(deps, &dep)
 	}
@@ -99,6 +99,167 @@ func TestDependencyRepo_Create_WithHeartbeat(t *testing.T) {
 	}
 }
 
+func TestDependencyRepo_Create_WithTCPHeartbeat(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewDependency(system.ID, "Postgres", "")
+	if err := dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		CheckType: domain.CheckTypeTCP,
+		URL:       "db.internal:5432",
+		Interval:  30,
+	}); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, dep.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	config := retrieved.GetHeartbeatConfig()
+	if config.CheckType != domain.CheckTypeTCP {
+		t.Errorf("CheckType = %s, want tcp", config.CheckType)
+	}
+	if config.URL != "db.internal:5432" {
+		t.Errorf("URL = %s, want db.internal:5432", config.URL)
+	}
+}
+
+func TestDependencyRepo_Create_WithGRPCHeartbeat(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewDependency(system.ID, "Orders", "")
+	if err := dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		CheckType:   domain.CheckTypeGRPC,
+		URL:         "orders.internal:50051",
+		Interval:    30,
+		GRPCService: "orders.v1.Orders",
+	}); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, dep.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	config := retrieved.GetHeartbeatConfig()
+	if config.CheckType != domain.CheckTypeGRPC {
+		t.Errorf("CheckType = %s, want grpc", config.CheckType)
+	}
+	if config.GRPCService != "orders.v1.Orders" {
+		t.Errorf("GRPCService = %s, want orders.v1.Orders", config.GRPCService)
+	}
+}
+
+func TestDependencyRepo_RedirectOptions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewDependency(system.ID, "Legacy", "")
+	disabled := false
+	if err := dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:             "https://legacy.example.com/",
+		Interval:        60,
+		FollowRedirects: &disabled,
+	}); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, dep.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.HeartbeatFollowRedirects {
+		t.Error("HeartbeatFollowRedirects = true, want false")
+	}
+
+	if err := retrieved.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:          "https://legacy.example.com/",
+		Interval:     60,
+		MaxRedirects: 5,
+	}); err != nil {
+		t.Fatalf("SetHeartbeatConfig() error = %v", err)
+	}
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, dep.ID)
+	if !updated.HeartbeatFollowRedirects {
+		t.Error("HeartbeatFollowRedirects = false, want true")
+	}
+	if updated.HeartbeatMaxRedirects != 5 {
+		t.Errorf("HeartbeatMaxRedirects = %d, want 5", updated.HeartbeatMaxRedirects)
+	}
+}
+
+func TestDependencyRepo_Update_CertExpiry(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewDependencyRepo(db)
+	ctx := context.Background()
+
+	dep, _ := domain.NewDependency(system.ID, "Gateway", "")
+	dep.SetHeartbeatConfig(domain.HeartbeatConfig{
+		URL:                   "https://gw.example.com/health",
+		Interval:              60,
+		CertExpiryWarningDays: 14,
+	})
+	if err := repo.Create(ctx, dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, _ := repo.GetByID(ctx, dep.ID)
+	if !retrieved.CertExpiresAt.IsZero() {
+		t.Errorf("expected no cert expiry before the first check, got %v", retrieved.CertExpiresAt)
+	}
+
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	dep.CertExpiresAt = expiresAt
+	if err := repo.Update(ctx, dep); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, dep.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !retrieved.CertExpiresAt.Equal(expiresAt) {
+		t.Errorf("CertExpiresAt = %v, want %v", retrieved.CertExpiresAt, expiresAt)
+	}
+	if retrieved.GetHeartbeatConfig().CertExpiryWarningDays != 14 {
+		t.Errorf("CertExpiryWarningDays = %d, want 14", retrieved.GetHeartbeatConfig().CertExpiryWarningDays)
+	}
+}
+
 func TestDependencyRepo_GetByID(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -283,6 +444,9 @@ func TestDependencyRepo_Update(t *testing.T) {
 	dep.LastLatency = 150
 	dep.LastStatusCode = 200
 	dep.ConsecutiveFailures = 2
+	dep.RecordLatency = false
+	dep.Weight = 2.5
+	dep.Criticality = domain.CriticalityMinor
 	dep.UpdatedAt = time.Now()
 
 	if err := repo.Update(ctx, dep); err != nil {
@@ -313,6 +477,15 @@ func TestDependencyRepo_Update(t *testing.T) {
 	if retrieved.ConsecutiveFailures != 2 {
 		t.Errorf("ConsecutiveFailures = %d, want 2", retrieved.ConsecutiveFailures)
 	}
+	if retrieved.RecordLatency {
+		t.Error("RecordLatency = true, want false")
+	}
+	if retrieved.Weight != 2.5 {
+		t.Errorf("Weight = %v, want 2.5", retrieved.Weight)
+	}
+	if retrieved.Criticality != domain.CriticalityMinor {
+		t.Errorf("Criticality = %q, want minor", retrieved.Criticality)
+	}
 }
 
 func TestDependencyRepo_Update_NotFound(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"status-incident/internal/domain"
@@ -24,11 +25,11 @@ func (r *IncidentRepo) Create(ctx context.Context, i *domain.Incident) error {
 	systemIDsJSON, _ := json.Marshal(i.SystemIDs)
 
 	result, err := r.db.ExecContext(ctx, `
-		INSERT INTO incidents (title, status, severity, system_ids, message, postmortem,
-			created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, i.Title, string(i.Status), string(i.Severity), string(systemIDsJSON), i.Message, i.Postmortem,
-		i.CreatedAt, i.UpdatedAt, i.ResolvedAt, i.AcknowledgedAt, i.AcknowledgedBy)
+		INSERT INTO incidents (title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, i.Title, string(i.Status), string(i.Severity), string(systemIDsJSON), incidentComponentsJSON(i), i.Message, i.Postmortem,
+		i.CreatedAt, i.UpdatedAt, i.ResolvedAt, i.ScheduledFor, i.AcknowledgedAt, i.AcknowledgedBy)
 
 	if err != nil {
 		return err
@@ -45,8 +46,8 @@ func (r *IncidentRepo) Create(ctx context.Context, i *domain.Incident) error {
 // GetByID retrieves an incident by ID
 func (r *IncidentRepo) GetByID(ctx context.Context, id int64) (*domain.Incident, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, title, status, severity, system_ids, message, postmortem,
-			created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
 		FROM incidents WHERE id = ?
 	`, id)
 
@@ -56,8 +57,8 @@ func (r *IncidentRepo) GetByID(ctx context.Context, id int64) (*domain.Incident,
 // GetAll retrieves all incidents with optional limit
 func (r *IncidentRepo) GetAll(ctx context.Context, limit int) ([]*domain.Incident, error) {
 	query := `
-		SELECT id, title, status, severity, system_ids, message, postmortem,
-			created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
 		FROM incidents ORDER BY created_at DESC
 	`
 	if limit > 0 {
@@ -79,13 +80,90 @@ func (r *IncidentRepo) GetAll(ctx context.Context, limit int) ([]*domain.Inciden
 	return r.scanIncidents(rows)
 }
 
-// GetActive retrieves all unresolved incidents
+// GetPage retrieves up to limit incidents matching filter, in the filter's
+// order, skipping the first offset
+func (r *IncidentRepo) GetPage(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	where, args := incidentFilterWhere(filter)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
+		FROM incidents`+where+`
+		ORDER BY `+incidentFilterOrder(filter)+`
+		LIMIT ? OFFSET ?
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanIncidents(rows)
+}
+
+// Count returns the number of incidents matching filter
+func (r *IncidentRepo) Count(ctx context.Context, filter domain.IncidentFilter) (int, error) {
+	where, args := incidentFilterWhere(filter)
+
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM incidents"+where, args...).Scan(&count)
+	return count, err
+}
+
+// incidentFilterWhere builds the WHERE clause for filter. Incidents without
+// system IDs affect all systems, so they match any system filter.
+func incidentFilterWhere(filter domain.IncidentFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if filter.Severity != "" {
+		conditions = append(conditions, "severity = ?")
+		args = append(args, string(filter.Severity))
+	}
+	if filter.SystemID != 0 {
+		conditions = append(conditions, `(system_ids IS NULL OR system_ids IN ('', 'null', '[]')
+			OR EXISTS (SELECT 1 FROM json_each(incidents.system_ids) WHERE value = ?))`)
+		args = append(args, filter.SystemID)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// incidentFilterOrder builds the ORDER BY clause for filter from a fixed set
+// of columns, breaking ties by ID in the same direction
+func incidentFilterOrder(filter domain.IncidentFilter) string {
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+
+	column := "created_at"
+	switch filter.SortBy {
+	case domain.IncidentSortUpdatedAt:
+		column = "updated_at"
+	case domain.IncidentSortResolvedAt:
+		column = "resolved_at"
+	case domain.IncidentSortSeverity:
+		column = "CASE severity WHEN 'critical' THEN 3 WHEN 'major' THEN 2 ELSE 1 END"
+	}
+
+	return column + " " + direction + ", id " + direction
+}
+
+// GetActive retrieves all started, unresolved incidents
 func (r *IncidentRepo) GetActive(ctx context.Context) ([]*domain.Incident, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, status, severity, system_ids, message, postmortem,
-			created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
 		FROM incidents
-		WHERE status != 'resolved'
+		WHERE status NOT IN ('resolved', 'scheduled')
 		ORDER BY
 			CASE severity
 				WHEN 'critical' THEN 1
@@ -102,12 +180,29 @@ func (r *IncidentRepo) GetActive(ctx context.Context) ([]*domain.Incident, error
 	return r.scanIncidents(rows)
 }
 
+// GetScheduled retrieves incidents that have not started yet, soonest first
+func (r *IncidentRepo) GetScheduled(ctx context.Context) ([]*domain.Incident, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
+		FROM incidents
+		WHERE status = 'scheduled'
+		ORDER BY scheduled_for ASC, id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanIncidents(rows)
+}
+
 // GetRecent retrieves incidents resolved in last N days
 func (r *IncidentRepo) GetRecent(ctx context.Context, days int) ([]*domain.Incident, error) {
 	cutoff := time.Now().AddDate(0, 0, -days)
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, status, severity, system_ids, message, postmortem,
-			created_at, updated_at, resolved_at, acknowledged_at, acknowledged_by
+		SELECT id, title, status, severity, system_ids, components, message, postmortem,
+			created_at, updated_at, resolved_at, scheduled_for, acknowledged_at, acknowledged_by
 		FROM incidents
 		WHERE status = 'resolved' AND resolved_at >= ?
 		ORDER BY resolved_at DESC
@@ -126,11 +221,11 @@ func (r *IncidentRepo) Update(ctx context.Context, i *domain.Incident) error {
 
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE incidents
-		SET title = ?, status = ?, severity = ?, system_ids = ?, message = ?, postmortem = ?,
-			updated_at = ?, resolved_at = ?, acknowledged_at = ?, acknowledged_by = ?
+		SET title = ?, status = ?, severity = ?, system_ids = ?, components = ?, message = ?, postmortem = ?,
+			updated_at = ?, resolved_at = ?, scheduled_for = ?, acknowledged_at = ?, acknowledged_by = ?
 		WHERE id = ?
-	`, i.Title, string(i.Status), string(i.Severity), string(systemIDsJSON), i.Message, i.Postmortem,
-		i.UpdatedAt, i.ResolvedAt, i.AcknowledgedAt, i.AcknowledgedBy, i.ID)
+	`, i.Title, string(i.Status), string(i.Severity), string(systemIDsJSON), incidentComponentsJSON(i), i.Message, i.Postmortem,
+		i.UpdatedAt, i.ResolvedAt, i.ScheduledFor, i.AcknowledgedAt, i.AcknowledgedBy, i.ID)
 
 	return err
 }
@@ -141,6 +236,70 @@ func (r *IncidentRepo) Delete(ctx context.Context, id int64) error {
 	return err
 }
 
+// GetByIdempotencyKey retrieves the incident mapped to an idempotency key
+// in scope that has not expired at now, or nil. A key that is reserved but
+// has no incident yet also returns nil.
+func (r *IncidentRepo) GetByIdempotencyKey(ctx context.Context, scope, key string, now time.Time) (*domain.Incident, error) {
+	var incidentID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT incident_id FROM incident_idempotency_keys
+		WHERE scope = ? AND idempotency_key = ? AND expires_at > ?
+	`, scope, key, now).Scan(&incidentID)
+	if err == sql.ErrNoRows || (err == nil && !incidentID.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, incidentID.Int64)
+}
+
+// ReserveIdempotencyKey claims an idempotency key in scope until expiresAt,
+// before its incident exists. The primary key decides between concurrent
+// callers, also across instances; the loser gets false. Expired keys are
+// dropped first so they can be claimed again.
+func (r *IncidentRepo) ReserveIdempotencyKey(ctx context.Context, scope, key string, now, expiresAt time.Time) (bool, error) {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM incident_idempotency_keys WHERE expires_at <= ?", now); err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_idempotency_keys (scope, idempotency_key, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (scope, idempotency_key) DO NOTHING
+	`, scope, key, expiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return inserted == 1, nil
+}
+
+// SetIdempotencyKeyIncident maps a reserved idempotency key to the incident
+// created for it and keeps the mapping until expiresAt
+func (r *IncidentRepo) SetIdempotencyKeyIncident(ctx context.Context, scope, key string, incidentID int64, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE incident_idempotency_keys SET incident_id = ?, expires_at = ?
+		WHERE scope = ? AND idempotency_key = ?
+	`, incidentID, expiresAt, scope, key)
+	return err
+}
+
+// ReleaseIdempotencyKey drops a reserved idempotency key that never got an
+// incident, so a retry can claim it
+func (r *IncidentRepo) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM incident_idempotency_keys
+		WHERE scope = ? AND idempotency_key = ? AND incident_id IS NULL
+	`, scope, key)
+	return err
+}
+
 // CreateUpdate adds a timeline entry to an incident
 func (r *IncidentRepo) CreateUpdate(ctx context.Context, u *domain.IncidentUpdate) error {
 	result, err := r.db.ExecContext(ctx, `
@@ -190,12 +349,12 @@ func (r *IncidentRepo) GetUpdates(ctx context.Context, incidentID int64) ([]*dom
 
 func (r *IncidentRepo) scanIncident(row *sql.Row) (*domain.Incident, error) {
 	var i domain.Incident
-	var systemIDsJSON string
+	var systemIDsJSON, componentsJSON string
 	var status, severity string
 
 	err := row.Scan(
-		&i.ID, &i.Title, &status, &severity, &systemIDsJSON, &i.Message, &i.Postmortem,
-		&i.CreatedAt, &i.UpdatedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.AcknowledgedBy,
+		&i.ID, &i.Title, &status, &severity, &systemIDsJSON, &componentsJSON, &i.Message, &i.Postmortem,
+		&i.CreatedAt, &i.UpdatedAt, &i.ResolvedAt, &i.ScheduledFor, &i.AcknowledgedAt, &i.AcknowledgedBy,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -207,6 +366,7 @@ func (r *IncidentRepo) scanIncident(row *sql.Row) (*domain.Incident, error) {
 	if systemIDsJSON != "" && systemIDsJSON != "null" {
 		json.Unmarshal([]byte(systemIDsJSON), &i.SystemIDs)
 	}
+	json.Unmarshal([]byte(componentsJSON), &i.Components)
 	i.Status = domain.IncidentStatus(status)
 	i.Severity = domain.IncidentSeverity(severity)
 
@@ -218,12 +378,12 @@ func (r *IncidentRepo) scanIncidents(rows *sql.Rows) ([]*domain.Incident, error)
 
 	for rows.Next() {
 		var i domain.Incident
-		var systemIDsJSON string
+		var systemIDsJSON, componentsJSON string
 		var status, severity string
 
 		err := rows.Scan(
-			&i.ID, &i.Title, &status, &severity, &systemIDsJSON, &i.Message, &i.Postmortem,
-			&i.CreatedAt, &i.UpdatedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.AcknowledgedBy,
+			&i.ID, &i.Title, &status, &severity, &systemIDsJSON, &componentsJSON, &i.Message, &i.Postmortem,
+			&i.CreatedAt, &i.UpdatedAt, &i.ResolvedAt, &i.ScheduledFor, &i.AcknowledgedAt, &i.AcknowledgedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -232,6 +392,7 @@ func (r *IncidentRepo) scanIncidents(rows *sql.Rows) ([]*domain.Incident, error)
 		if systemIDsJSON != "" && systemIDsJSON != "null" {
 			json.Unmarshal([]byte(systemIDsJSON), &i.SystemIDs)
 		}
+		json.Unmarshal([]byte(componentsJSON), &i.Components)
 		i.Status = domain.IncidentStatus(status)
 		i.Severity = domain.IncidentSeverity(severity)
 
@@ -240,3 +401,12 @@ func (r *IncidentRepo) scanIncidents(rows *sql.Rows) ([]*domain.Incident, error)
 
 	return incidents, nil
 }
+
+// incidentComponentsJSON encodes the affected components, using [] for none
+func incidentComponentsJSON(i *domain.Incident) string {
+	if len(i.Components) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(i.Components)
+	return string(data)
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,6 +62,53 @@ func TestIncidentRepo_Create_WithSystemIDs(t *testing.T) {
 	}
 }
 
+func TestIncidentRepo_Components(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	depID := int64(12)
+	incident, _ := domain.NewIncident("Checkout errors", "Payments failing", domain.SeverityMajor)
+	incident.SetComponents([]domain.AffectedComponent{
+		{Name: "Checkout"},
+		{Name: "Search", DependencyID: &depID},
+	})
+	if err := repo.Create(ctx, incident); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if len(retrieved.Components) != 2 {
+		t.Fatalf("Components count = %d, want 2", len(retrieved.Components))
+	}
+	if retrieved.Components[0].Name != "Checkout" || retrieved.Components[0].DependencyID != nil {
+		t.Errorf("Components[0] = %+v, want free-form Checkout", retrieved.Components[0])
+	}
+	if retrieved.Components[1].Name != "Search" || retrieved.Components[1].DependencyID == nil || *retrieved.Components[1].DependencyID != depID {
+		t.Errorf("Components[1] = %+v, want Search linked to dependency %d", retrieved.Components[1], depID)
+	}
+
+	active, _ := repo.GetActive(ctx)
+	if len(active) != 1 || len(active[0].Components) != 2 {
+		t.Errorf("expected GetActive to load components, got %+v", active)
+	}
+
+	// Clearing the list round-trips as no components
+	retrieved.SetComponents(nil)
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	cleared, _ := repo.GetByID(ctx, incident.ID)
+	if len(cleared.Components) != 0 {
+		t.Errorf("expected no components after clearing, got %+v", cleared.Components)
+	}
+}
+
 func TestIncidentRepo_GetByID(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -168,6 +216,121 @@ func TestIncidentRepo_GetAll_WithLimit(t *testing.T) {
 	}
 }
 
+func TestIncidentRepo_GetPageAndCount(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		incident, _ := domain.NewIncident("Incident "+string(rune('A'+i)), "Message", domain.SeverityMinor)
+		repo.Create(ctx, incident)
+		time.Sleep(time.Millisecond)
+	}
+
+	page, err := repo.GetPage(ctx, domain.IncidentFilter{}, 2, 1)
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("GetPage(2, 1) returned %d incidents, want 2", len(page))
+	}
+	if page[0].Title != "Incident D" || page[1].Title != "Incident C" {
+		t.Errorf("expected Incident D and C, got %q and %q", page[0].Title, page[1].Title)
+	}
+
+	last, _ := repo.GetPage(ctx, domain.IncidentFilter{}, 2, 4)
+	if len(last) != 1 || last[0].Title != "Incident A" {
+		t.Errorf("expected only Incident A on the last page, got %d incidents", len(last))
+	}
+
+	total, err := repo.Count(ctx, domain.IncidentFilter{})
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if total != 5 {
+		t.Errorf("Count() = %d, want 5", total)
+	}
+}
+
+func TestIncidentRepo_GetPage_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	seed := []struct {
+		title     string
+		severity  domain.IncidentSeverity
+		status    domain.IncidentStatus
+		systemIDs []int64
+	}{
+		{"DB down", domain.SeverityCritical, domain.IncidentInvestigating, []int64{4}},
+		{"Slow API", domain.SeverityMinor, domain.IncidentInvestigating, []int64{2, 4}},
+		{"Auth errors", domain.SeverityCritical, domain.IncidentIdentified, []int64{2}},
+		{"Network blip", domain.SeverityMajor, domain.IncidentResolved, nil},
+		{"Cache miss storm", domain.SeverityMajor, domain.IncidentInvestigating, []int64{14}},
+	}
+	for _, s := range seed {
+		incident, _ := domain.NewIncident(s.title, "Message", s.severity)
+		incident.Status = s.status
+		incident.SetSystemIDs(s.systemIDs)
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	tests := []struct {
+		name     string
+		filter   domain.IncidentFilter
+		expected []string
+	}{
+		{"no filter, newest first", domain.IncidentFilter{},
+			[]string{"Cache miss storm", "Network blip", "Auth errors", "Slow API", "DB down"}},
+		{"status", domain.IncidentFilter{Status: domain.IncidentInvestigating},
+			[]string{"Cache miss storm", "Slow API", "DB down"}},
+		{"severity", domain.IncidentFilter{Severity: domain.SeverityCritical},
+			[]string{"Auth errors", "DB down"}},
+		// Incidents without systems affect all of them; system 14 must not match 4
+		{"system", domain.IncidentFilter{SystemID: 4},
+			[]string{"Network blip", "Slow API", "DB down"}},
+		{"combined", domain.IncidentFilter{Status: domain.IncidentInvestigating, Severity: domain.SeverityCritical, SystemID: 4},
+			[]string{"DB down"}},
+		{"oldest first", domain.IncidentFilter{SortBy: domain.IncidentSortCreatedAt, Ascending: true},
+			[]string{"DB down", "Slow API", "Auth errors", "Network blip", "Cache miss storm"}},
+		{"most severe first", domain.IncidentFilter{SortBy: domain.IncidentSortSeverity, SystemID: 2},
+			[]string{"Auth errors", "Network blip", "Slow API"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents, err := repo.GetPage(ctx, tt.filter, 100, 0)
+			if err != nil {
+				t.Fatalf("GetPage() error = %v", err)
+			}
+
+			var titles []string
+			for _, i := range incidents {
+				titles = append(titles, i.Title)
+			}
+			if strings.Join(titles, ", ") != strings.Join(tt.expected, ", ") {
+				t.Errorf("expected %v, got %v", tt.expected, titles)
+			}
+
+			count, err := repo.Count(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+			if count != len(tt.expected) {
+				t.Errorf("Count() = %d, want %d", count, len(tt.expected))
+			}
+		})
+	}
+}
+
 func TestIncidentRepo_GetActive(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -212,6 +375,60 @@ func TestIncidentRepo_GetActive(t *testing.T) {
 	}
 }
 
+func TestIncidentRepo_GetScheduled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	active, _ := domain.NewIncident("API Outage", "Down", domain.SeverityCritical)
+	later, _ := domain.NewIncident("Storage migration", "Uploads paused", domain.SeverityMinor)
+	later.Schedule(time.Now().Add(48 * time.Hour).Truncate(time.Second))
+	sooner, _ := domain.NewIncident("Database upgrade", "Read-only mode", domain.SeverityMajor)
+	sooner.Schedule(time.Now().Add(2 * time.Hour).Truncate(time.Second))
+
+	for _, inc := range []*domain.Incident{active, later, sooner} {
+		if err := repo.Create(ctx, inc); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	scheduled, err := repo.GetScheduled(ctx)
+	if err != nil {
+		t.Fatalf("GetScheduled() error = %v", err)
+	}
+	if len(scheduled) != 2 || scheduled[0].ID != sooner.ID || scheduled[1].ID != later.ID {
+		t.Fatalf("GetScheduled() = %v, want the two scheduled incidents soonest first", scheduled)
+	}
+	if scheduled[0].ScheduledFor == nil || !scheduled[0].ScheduledFor.Equal(*sooner.ScheduledFor) {
+		t.Errorf("ScheduledFor = %v, want %v", scheduled[0].ScheduledFor, sooner.ScheduledFor)
+	}
+
+	activeOnly, _ := repo.GetActive(ctx)
+	if len(activeOnly) != 1 || activeOnly[0].ID != active.ID {
+		t.Errorf("GetActive() = %v, want only the started incident", activeOnly)
+	}
+
+	// Once started it moves from scheduled to active
+	sooner.Start()
+	if err := repo.Update(ctx, sooner); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	scheduled, _ = repo.GetScheduled(ctx)
+	if len(scheduled) != 1 || scheduled[0].ID != later.ID {
+		t.Errorf("GetScheduled() after start = %v, want only the later incident", scheduled)
+	}
+	activeOnly, _ = repo.GetActive(ctx)
+	if len(activeOnly) != 2 {
+		t.Errorf("GetActive() after start returned %d incidents, want 2", len(activeOnly))
+	}
+	started, _ := repo.GetByID(ctx, sooner.ID)
+	if started.ScheduledFor == nil {
+		t.Error("expected ScheduledFor to be kept after the start")
+	}
+}
+
 func TestIncidentRepo_GetRecent(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -515,3 +732,73 @@ func TestIncidentRepo_SeverityTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestIncidentRepo_IdempotencyKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentRepo(db)
+	ctx := context.Background()
+
+	incident, _ := domain.NewIncident("Checkout down", "Alert fired", domain.SeverityMajor)
+	if err := repo.Create(ctx, incident); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	now := time.Now().UTC()
+	reserved, err := repo.ReserveIdempotencyKey(ctx, "api_key:1", "alert-1", now, now.Add(time.Minute))
+	if err != nil || !reserved {
+		t.Fatalf("ReserveIdempotencyKey() = %v, %v, want true", reserved, err)
+	}
+
+	// The unique key turns away a second claim, as it would one from
+	// another instance
+	if reserved, err := repo.ReserveIdempotencyKey(ctx, "api_key:1", "alert-1", now, now.Add(time.Minute)); err != nil || reserved {
+		t.Errorf("expected a claimed key not to be reserved again, got %v, %v", reserved, err)
+	}
+
+	// Reserved but not yet mapped
+	if found, err := repo.GetByIdempotencyKey(ctx, "api_key:1", "alert-1", now); err != nil || found != nil {
+		t.Errorf("expected no incident for a pending key, got %+v, %v", found, err)
+	}
+
+	if err := repo.SetIdempotencyKeyIncident(ctx, "api_key:1", "alert-1", incident.ID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("SetIdempotencyKeyIncident() error = %v", err)
+	}
+	found, err := repo.GetByIdempotencyKey(ctx, "api_key:1", "alert-1", now)
+	if err != nil {
+		t.Fatalf("GetByIdempotencyKey() error = %v", err)
+	}
+	if found == nil || found.ID != incident.ID {
+		t.Fatalf("expected incident %d, got %+v", incident.ID, found)
+	}
+
+	// The same key string of another caller is a different key
+	if found, _ := repo.GetByIdempotencyKey(ctx, "api_key:2", "alert-1", now); found != nil {
+		t.Errorf("expected no incident for another scope, got %d", found.ID)
+	}
+	if reserved, _ := repo.ReserveIdempotencyKey(ctx, "api_key:2", "alert-1", now, now.Add(time.Minute)); !reserved {
+		t.Error("expected the same key to be reservable in another scope")
+	}
+
+	// Releasing drops only keys without an incident
+	if err := repo.ReleaseIdempotencyKey(ctx, "api_key:2", "alert-1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey() error = %v", err)
+	}
+	if reserved, _ := repo.ReserveIdempotencyKey(ctx, "api_key:2", "alert-1", now, now.Add(time.Minute)); !reserved {
+		t.Error("expected a released key to be reservable again")
+	}
+	repo.ReleaseIdempotencyKey(ctx, "api_key:1", "alert-1")
+	if found, _ := repo.GetByIdempotencyKey(ctx, "api_key:1", "alert-1", now); found == nil {
+		t.Error("expected release to keep a key mapped to an incident")
+	}
+
+	// Expired keys are ignored and can be claimed again
+	later := now.Add(2 * time.Hour)
+	if found, _ := repo.GetByIdempotencyKey(ctx, "api_key:1", "alert-1", later); found != nil {
+		t.Errorf("expected expired key to be ignored, got %d", found.ID)
+	}
+	if reserved, err := repo.ReserveIdempotencyKey(ctx, "api_key:1", "alert-1", later, later.Add(time.Minute)); err != nil || !reserved {
+		t.Errorf("expected expired key to be reservable, got %v, %v", reserved, err)
+	}
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"status-incident/internal/domain"
+)
+
+// IncidentTemplateRepo implements IncidentTemplateRepository for SQLite
+type IncidentTemplateRepo struct {
+	db *DB
+}
+
+// NewIncidentTemplateRepo creates a new IncidentTemplateRepo
+func NewIncidentTemplateRepo(db *DB) *IncidentTemplateRepo {
+	return &IncidentTemplateRepo{db: db}
+}
+
+// Create persists a new incident template
+func (r *IncidentTemplateRepo) Create(ctx context.Context, t *domain.IncidentTemplate) error {
+	systemIDsJSON, _ := json.Marshal(t.DefaultSystemIDs)
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_templates (name, title_pattern, default_severity, default_message,
+			default_system_ids, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, t.Name, t.TitlePattern, string(t.DefaultSeverity), t.DefaultMessage,
+		string(systemIDsJSON), t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = id
+	return nil
+}
+
+// GetByID retrieves an incident template by ID
+func (r *IncidentTemplateRepo) GetByID(ctx context.Context, id int64) (*domain.IncidentTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, title_pattern, default_severity, default_message, default_system_ids,
+			created_at, updated_at
+		FROM incident_templates WHERE id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates, err := r.scanTemplates(rows)
+	if err != nil || len(templates) == 0 {
+		return nil, err
+	}
+	return templates[0], nil
+}
+
+// GetAll retrieves all incident templates ordered by name
+func (r *IncidentTemplateRepo) GetAll(ctx context.Context) ([]*domain.IncidentTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, title_pattern, default_severity, default_message, default_system_ids,
+			created_at, updated_at
+		FROM incident_templates ORDER BY name, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTemplates(rows)
+}
+
+// Update saves changes to an incident template
+func (r *IncidentTemplateRepo) Update(ctx context.Context, t *domain.IncidentTemplate) error {
+	systemIDsJSON, _ := json.Marshal(t.DefaultSystemIDs)
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE incident_templates
+		SET name = ?, title_pattern = ?, default_severity = ?, default_message = ?,
+			default_system_ids = ?, updated_at = ?
+		WHERE id = ?
+	`, t.Name, t.TitlePattern, string(t.DefaultSeverity), t.DefaultMessage,
+		string(systemIDsJSON), t.UpdatedAt, t.ID)
+
+	return err
+}
+
+// Delete removes an incident template by ID
+func (r *IncidentTemplateRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM incident_templates WHERE id = ?", id)
+	return err
+}
+
+func (r *IncidentTemplateRepo) scanTemplates(rows *sql.Rows) ([]*domain.IncidentTemplate, error) {
+	var templates []*domain.IncidentTemplate
+
+	for rows.Next() {
+		var t domain.IncidentTemplate
+		var systemIDsJSON sql.NullString
+		var severity string
+
+		err := rows.Scan(
+			&t.ID, &t.Name, &t.TitlePattern, &severity, &t.DefaultMessage, &systemIDsJSON,
+			&t.CreatedAt, &t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if systemIDsJSON.Valid && systemIDsJSON.String != "" && systemIDsJSON.String != "null" {
+			json.Unmarshal([]byte(systemIDsJSON.String), &t.DefaultSystemIDs)
+		}
+		t.DefaultSeverity = domain.IncidentSeverity(severity)
+
+		templates = append(templates, &t)
+	}
+
+	return templates, rows.Err()
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func TestIncidentTemplateRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewIncidentTemplateRepo(db)
+	ctx := context.Background()
+
+	tmpl, _ := domain.NewIncidentTemplate("DB failover", "Database failover on {cluster}", domain.SeverityMajor, "Failing over.", []int64{3, 4})
+	if err := repo.Create(ctx, tmpl); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tmpl.ID == 0 {
+		t.Fatal("expected template ID to be set after Create()")
+	}
+	other, _ := domain.NewIncidentTemplate("CDN", "CDN degradation", domain.SeverityMinor, "", nil)
+	repo.Create(ctx, other)
+
+	got, err := repo.GetByID(ctx, tmpl.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Name != "DB failover" || got.TitlePattern != "Database failover on {cluster}" ||
+		got.DefaultSeverity != domain.SeverityMajor || got.DefaultMessage != "Failing over." {
+		t.Errorf("unexpected template: %+v", got)
+	}
+	if len(got.DefaultSystemIDs) != 2 || got.DefaultSystemIDs[0] != 3 {
+		t.Errorf("DefaultSystemIDs = %v, want [3 4]", got.DefaultSystemIDs)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 2 || all[0].Name != "CDN" {
+		t.Errorf("expected 2 templates ordered by name, got %d", len(all))
+	}
+	if all[0].DefaultSystemIDs != nil {
+		t.Errorf("expected nil system IDs, got %v", all[0].DefaultSystemIDs)
+	}
+
+	got.Update("DB failover", "Database failover", domain.SeverityCritical, "Primary down.", nil)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, _ = repo.GetByID(ctx, tmpl.ID)
+	if got.TitlePattern != "Database failover" || got.DefaultSeverity != domain.SeverityCritical || got.DefaultSystemIDs != nil {
+		t.Errorf("unexpected template after Update(): %+v", got)
+	}
+
+	if err := repo.Delete(ctx, tmpl.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, tmpl.ID); got != nil {
+		t.Error("expected template to be deleted")
+	}
+}
@@ -61,21 +61,53 @@ func (r *LatencyRepo) GetByDependency(ctx context.Context, dependencyID int64, s
 	return records, rows.Err()
 }
 
-// GetAggregated retrieves aggregated latency data for charting
+// GetAggregated retrieves aggregated latency data for charting. Buckets
+// start on multiples of the interval since the Unix epoch; buckets without
+// checks are left out.
 func (r *LatencyRepo) GetAggregated(ctx context.Context, dependencyID int64, start, end time.Time, intervalMinutes int) ([]domain.LatencyPoint, error) {
-	// Group by time intervals
+	// Percentiles are nearest-rank over the successful checks of a bucket,
+	// matching GetStats
 	rows, err := r.db.QueryContext(ctx, `
+		WITH samples AS (
+			SELECT
+				(CAST(strftime('%s', created_at) AS INTEGER) / (?*60)) * (?*60) AS bucket,
+				latency_ms,
+				success
+			FROM latency_history
+			WHERE dependency_id = ? AND created_at BETWEEN ? AND ?
+		),
+		ranked AS (
+			SELECT
+				bucket,
+				latency_ms,
+				ROW_NUMBER() OVER (PARTITION BY bucket ORDER BY latency_ms) AS rn,
+				COUNT(*) OVER (PARTITION BY bucket) AS n
+			FROM samples
+			WHERE success = 1
+		),
+		percentiles AS (
+			SELECT
+				bucket,
+				MAX(CASE WHEN rn <= n*50/100 + 1 THEN latency_ms END) AS p50,
+				MAX(CASE WHEN rn <= n*95/100 + 1 THEN latency_ms END) AS p95,
+				MAX(CASE WHEN rn <= n*99/100 + 1 THEN latency_ms END) AS p99
+			FROM ranked
+			GROUP BY bucket
+		)
 		SELECT
-			datetime((strftime('%s', created_at) / (?*60)) * (?*60), 'unixepoch') as interval_start,
-			AVG(latency_ms) as avg_ms,
-			MIN(latency_ms) as min_ms,
-			MAX(latency_ms) as max_ms,
+			s.bucket,
+			AVG(s.latency_ms) as avg_ms,
+			MIN(s.latency_ms) as min_ms,
+			MAX(s.latency_ms) as max_ms,
+			COALESCE(p.p50, 0),
+			COALESCE(p.p95, 0),
+			COALESCE(p.p99, 0),
 			COUNT(*) as total,
-			SUM(CASE WHEN success = 0 THEN 1 ELSE 0 END) as failures
-		FROM latency_history
-		WHERE dependency_id = ? AND created_at BETWEEN ? AND ?
-		GROUP BY interval_start
-		ORDER BY interval_start
+			SUM(CASE WHEN s.success = 0 THEN 1 ELSE 0 END) as failures
+		FROM samples s
+		LEFT JOIN percentiles p ON p.bucket = s.bucket
+		GROUP BY s.bucket, p.p50, p.p95, p.p99
+		ORDER BY s.bucket
 	`, intervalMinutes, intervalMinutes, dependencyID, start, end)
 	if err != nil {
 		return nil, err
@@ -85,11 +117,11 @@ func (r *LatencyRepo) GetAggregated(ctx context.Context, dependencyID int64, sta
 	var points []domain.LatencyPoint
 	for rows.Next() {
 		var p domain.LatencyPoint
-		var timestampStr string
-		if err := rows.Scan(&timestampStr, &p.AvgMs, &p.MinMs, &p.MaxMs, &p.Count, &p.Failures); err != nil {
+		var bucket int64
+		if err := rows.Scan(&bucket, &p.AvgMs, &p.MinMs, &p.MaxMs, &p.P50Ms, &p.P95Ms, &p.P99Ms, &p.Count, &p.Failures); err != nil {
 			return nil, err
 		}
-		p.Timestamp, _ = time.Parse("2006-01-02 15:04:05", timestampStr)
+		p.Timestamp = time.Unix(bucket, 0).UTC()
 		points = append(points, p)
 	}
 	return points, rows.Err()
@@ -596,3 +596,68 @@ func TestLatencyRepo_MultipleDependencies(t *testing.T) {
 		}
 	}
 }
+
+func TestLatencyRepo_GetAggregated_Percentiles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	dep := createTestDependency(t, db)
+	repo := NewLatencyRepo(db)
+	ctx := context.Background()
+
+	base := time.Now().UTC().Truncate(15 * time.Minute).Add(-time.Hour)
+	seed := func(at time.Time, latencyMs int64, success bool) {
+		t.Helper()
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO latency_history (dependency_id, latency_ms, success, status_code, created_at)
+			VALUES (?, ?, ?, 200, ?)
+		`, dep.ID, latencyMs, success, at)
+		if err != nil {
+			t.Fatalf("failed to seed latency: %v", err)
+		}
+	}
+
+	// First bucket: 10..100ms plus a failed check, which counts towards the
+	// average but not the percentiles
+	for i := int64(1); i <= 10; i++ {
+		seed(base.Add(time.Duration(i)*time.Minute), i*10, true)
+	}
+	seed(base.Add(12*time.Minute), 5000, false)
+	// Second bucket is empty, third has one check, fourth only a failure
+	seed(base.Add(31*time.Minute), 200, true)
+	seed(base.Add(50*time.Minute), 3000, false)
+
+	points, err := repo.GetAggregated(ctx, dep.ID, base, base.Add(time.Hour), 15)
+	if err != nil {
+		t.Fatalf("GetAggregated() error = %v", err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("GetAggregated() returned %d points, want 3: %+v", len(points), points)
+	}
+
+	tests := []struct {
+		offset                time.Duration
+		count, failures       int
+		avg                   float64
+		p50, p95, p99, maxLat int64
+	}{
+		{0, 11, 1, 5550.0 / 11, 60, 100, 100, 5000},
+		{30 * time.Minute, 1, 0, 200, 200, 200, 200, 200},
+		{45 * time.Minute, 1, 1, 3000, 0, 0, 0, 3000},
+	}
+	for i, tt := range tests {
+		p := points[i]
+		if !p.Timestamp.Equal(base.Add(tt.offset)) {
+			t.Errorf("point %d: Timestamp = %v, want %v", i, p.Timestamp, base.Add(tt.offset))
+		}
+		if p.Count != tt.count || p.Failures != tt.failures {
+			t.Errorf("point %d: Count = %d, Failures = %d, want %d, %d", i, p.Count, p.Failures, tt.count, tt.failures)
+		}
+		if p.AvgMs != tt.avg || p.MaxMs != tt.maxLat {
+			t.Errorf("point %d: AvgMs = %v, MaxMs = %d, want %v, %d", i, p.AvgMs, p.MaxMs, tt.avg, tt.maxLat)
+		}
+		if p.P50Ms != tt.p50 || p.P95Ms != tt.p95 || p.P99Ms != tt.p99 {
+			t.Errorf("point %d: percentiles = %d/%d/%d, want %d/%d/%d", i, p.P50Ms, p.P95Ms, p.P99Ms, tt.p50, tt.p95, tt.p99)
+		}
+	}
+}
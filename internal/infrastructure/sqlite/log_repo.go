@@ -21,8 +21,8 @@ func NewLogRepo(db *DB) *LogRepo {
 // Create persists a new status log entry
 func (r *LogRepo) Create(ctx context.Context, log *domain.StatusLog) error {
 	query := `
-		INSERT INTO status_log (system_id, dependency_id, old_status, new_status, message, source, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO status_log (system_id, dependency_id, old_status, new_status, message, source, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -32,6 +32,7 @@ func (r *LogRepo) Create(ctx context.Context, log *domain.StatusLog) error {
 		log.NewStatus.String(),
 		log.Message,
 		string(log.Source),
+		log.Actor,
 		log.CreatedAt,
 	)
 	if err != nil {
@@ -50,7 +51,7 @@ func (r *LogRepo) Create(ctx context.Context, log *domain.StatusLog) error {
 // GetBySystemID retrieves logs for a system
 func (r *LogRepo) GetBySystemID(ctx context.Context, systemID int64, limit int) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		WHERE system_id = ?
 		ORDER BY created_at DESC
@@ -69,7 +70,7 @@ func (r *LogRepo) GetBySystemID(ctx context.Context, systemID int64, limit int)
 // GetByDependencyID retrieves logs for a dependency
 func (r *LogRepo) GetByDependencyID(ctx context.Context, dependencyID int64, limit int) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		WHERE dependency_id = ?
 		ORDER BY created_at DESC
@@ -88,7 +89,7 @@ func (r *LogRepo) GetByDependencyID(ctx context.Context, dependencyID int64, lim
 // GetAll retrieves all logs with optional limit
 func (r *LogRepo) GetAll(ctx context.Context, limit int) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		ORDER BY created_at DESC
 		LIMIT ?
@@ -103,10 +104,37 @@ func (r *LogRepo) GetAll(ctx context.Context, limit int) ([]*domain.StatusLog, e
 	return r.scanLogs(rows)
 }
 
+// GetPage retrieves up to limit logs, newest first, skipping the first offset
+func (r *LogRepo) GetPage(ctx context.Context, limit, offset int) ([]*domain.StatusLog, error) {
+	query := `
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
+		FROM status_log
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLogs(rows)
+}
+
+// Count returns the total number of logs
+func (r *LogRepo) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM status_log").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count logs: %w", err)
+	}
+	return count, nil
+}
+
 // GetByTimeRange retrieves logs within a time range
 func (r *LogRepo) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		WHERE created_at >= ? AND created_at <= ?
 		ORDER BY created_at ASC
@@ -124,7 +152,7 @@ func (r *LogRepo) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*
 // GetSystemLogsByTimeRange retrieves system logs within time range
 func (r *LogRepo) GetSystemLogsByTimeRange(ctx context.Context, systemID int64, start, end time.Time) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		WHERE system_id = ? AND created_at >= ? AND created_at <= ?
 		ORDER BY created_at ASC
@@ -142,7 +170,7 @@ func (r *LogRepo) GetSystemLogsByTimeRange(ctx context.Context, systemID int64,
 // GetDependencyLogsByTimeRange retrieves dependency logs within time range
 func (r *LogRepo) GetDependencyLogsByTimeRange(ctx context.Context, dependencyID int64, start, end time.Time) ([]*domain.StatusLog, error) {
 	query := `
-		SELECT id, system_id, dependency_id, old_status, new_status, message, source, created_at
+		SELECT id, system_id, dependency_id, old_status, new_status, message, source, actor, created_at
 		FROM status_log
 		WHERE dependency_id = ? AND created_at >= ? AND created_at <= ?
 		ORDER BY created_at ASC
@@ -157,6 +185,14 @@ func (r *LogRepo) GetDependencyLogsByTimeRange(ctx context.Context, dependencyID
 	return r.scanLogs(rows)
 }
 
+// Cleanup removes logs created before olderThan
+func (r *LogRepo) Cleanup(ctx context.Context, olderThan time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM status_log WHERE created_at < ?`, olderThan); err != nil {
+		return fmt.Errorf("failed to clean up status logs: %w", err)
+	}
+	return nil
+}
+
 func (r *LogRepo) scanLogs(rows *sql.Rows) ([]*domain.StatusLog, error) {
 	var logs []*domain.StatusLog
 
@@ -173,6 +209,7 @@ func (r *LogRepo) scanLogs(rows *sql.Rows) ([]*domain.StatusLog, error) {
 			&newStatusStr,
 			&log.Message,
 			&sourceStr,
+			&log.Actor,
 			&log.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan log: %w", err)
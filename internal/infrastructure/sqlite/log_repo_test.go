@@ -55,6 +55,28 @@ func TestLogRepo_Create_ForDependency(t *testing.T) {
 	}
 }
 
+func TestLogRepo_Create_PartialPropagation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	log := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusPartial, "Some instances down", domain.SourcePropagation)
+	if err := repo.Create(ctx, log); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	logs, err := repo.GetBySystemID(ctx, system.ID, 10)
+	if err != nil {
+		t.Fatalf("GetBySystemID() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].NewStatus != domain.StatusPartial || logs[0].Source != domain.SourcePropagation {
+		t.Errorf("expected the partial propagation log, got %+v", logs)
+	}
+}
+
 func TestLogRepo_GetBySystemID(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -177,6 +199,48 @@ func TestLogRepo_GetAll(t *testing.T) {
 	}
 }
 
+func TestLogRepo_GetPageAndCount(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	var created []*domain.StatusLog
+	for i := 0; i < 7; i++ {
+		log := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusYellow, "Log", domain.SourceManual)
+		repo.Create(ctx, log)
+		created = append(created, log)
+		time.Sleep(time.Millisecond)
+	}
+
+	page, err := repo.GetPage(ctx, 3, 3)
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	if len(page) != 3 {
+		t.Fatalf("GetPage(3, 3) returned %d logs, want 3", len(page))
+	}
+	// Newest first: offset 3 starts at the fourth newest log
+	if page[0].ID != created[3].ID || page[2].ID != created[1].ID {
+		t.Errorf("expected logs %d..%d, got %d..%d", created[3].ID, created[1].ID, page[0].ID, page[2].ID)
+	}
+
+	beyond, _ := repo.GetPage(ctx, 3, 10)
+	if len(beyond) != 0 {
+		t.Errorf("expected no logs past the end, got %d", len(beyond))
+	}
+
+	total, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if total != 7 {
+		t.Errorf("Count() = %d, want 7", total)
+	}
+}
+
 func TestLogRepo_GetByTimeRange(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -450,3 +514,61 @@ func TestLogRepo_OrderByCreatedAt(t *testing.T) {
 		t.Errorf("expected oldest log first in time range query, got %s", logsAsc[0].Message)
 	}
 }
+
+func TestLogRepo_Actor(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	log := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusRed, "Deploy rollback", domain.SourceManual)
+	log.Actor = "deploy-pipeline"
+	if err := repo.Create(ctx, log); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	logs, err := repo.GetBySystemID(ctx, system.ID, 10)
+	if err != nil {
+		t.Fatalf("GetBySystemID() error = %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	if logs[0].Actor != "deploy-pipeline" {
+		t.Errorf("Actor = %q, want %q", logs[0].Actor, "deploy-pipeline")
+	}
+}
+
+func TestLogRepo_Cleanup(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewLogRepo(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	old := domain.NewStatusLog(&system.ID, nil, domain.StatusGreen, domain.StatusRed, "Old", domain.SourceManual)
+	old.CreatedAt = now.AddDate(0, 0, -40)
+	recent := domain.NewStatusLog(&system.ID, nil, domain.StatusRed, domain.StatusGreen, "Recent", domain.SourceManual)
+	recent.CreatedAt = now.AddDate(0, 0, -1)
+	for _, log := range []*domain.StatusLog{old, recent} {
+		if err := repo.Create(ctx, log); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := repo.Cleanup(ctx, now.AddDate(0, 0, -30)); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	logs, err := repo.GetAll(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ID != recent.ID {
+		t.Errorf("expected only the recent log to remain, got %d logs", len(logs))
+	}
+}
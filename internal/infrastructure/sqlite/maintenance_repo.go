@@ -24,9 +24,13 @@ func (r *MaintenanceRepo) Create(ctx context.Context, m *domain.Maintenance) err
 	systemIDsJSON, _ := json.Marshal(m.SystemIDs)
 
 	result, err := r.db.ExecContext(ctx, `
-		INSERT INTO maintenances (title, description, start_time, end_time, system_ids, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`, m.Title, m.Description, m.StartTime, m.EndTime, string(systemIDsJSON), string(m.Status), m.CreatedAt, m.UpdatedAt)
+		INSERT INTO maintenances (title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, m.Title, m.Description, m.StartTime, m.EndTime, string(systemIDsJSON), string(m.Status), m.Timezone,
+		m.NotifySubscribers, m.StartNotified, recurrenceType(m.Recurrence), m.Recurrence.Interval,
+		m.Recurrence.Until, m.NextCreated, string(m.LastReminder), m.CreatedAt, m.UpdatedAt)
 
 	if err != nil {
 		return err
@@ -43,7 +47,9 @@ func (r *MaintenanceRepo) Create(ctx context.Context, m *domain.Maintenance) err
 // GetByID retrieves a maintenance window by ID
 func (r *MaintenanceRepo) GetByID(ctx context.Context, id int64) (*domain.Maintenance, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, title, description, start_time, end_time, system_ids, status, created_at, updated_at
+		SELECT id, title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at
 		FROM maintenances WHERE id = ?
 	`, id)
 
@@ -53,7 +59,9 @@ func (r *MaintenanceRepo) GetByID(ctx context.Context, id int64) (*domain.Mainte
 // GetAll retrieves all maintenance windows
 func (r *MaintenanceRepo) GetAll(ctx context.Context) ([]*domain.Maintenance, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, start_time, end_time, system_ids, status, created_at, updated_at
+		SELECT id, title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at
 		FROM maintenances ORDER BY start_time DESC
 	`)
 	if err != nil {
@@ -64,11 +72,12 @@ func (r *MaintenanceRepo) GetAll(ctx context.Context) ([]*domain.Maintenance, er
 	return r.scanMaintenances(rows)
 }
 
-// GetActive retrieves currently active maintenance windows
-func (r *MaintenanceRepo) GetActive(ctx context.Context) ([]*domain.Maintenance, error) {
-	now := time.Now()
+// GetActive retrieves maintenance windows active at now
+func (r *MaintenanceRepo) GetActive(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, start_time, end_time, system_ids, status, created_at, updated_at
+		SELECT id, title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at
 		FROM maintenances
 		WHERE status != 'cancelled' AND start_time <= ? AND end_time >= ?
 		ORDER BY start_time ASC
@@ -85,17 +94,18 @@ func (r *MaintenanceRepo) GetActive(ctx context.Context) ([]*domain.Maintenance,
 
 	// Refresh statuses
 	for _, m := range maintenances {
-		m.RefreshStatus()
+		m.RefreshStatusAt(now)
 	}
 
 	return maintenances, nil
 }
 
-// GetUpcoming retrieves scheduled maintenance windows
-func (r *MaintenanceRepo) GetUpcoming(ctx context.Context) ([]*domain.Maintenance, error) {
-	now := time.Now()
+// GetUpcoming retrieves maintenance windows scheduled to start after now
+func (r *MaintenanceRepo) GetUpcoming(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, start_time, end_time, system_ids, status, created_at, updated_at
+		SELECT id, title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at
 		FROM maintenances
 		WHERE status = 'scheduled' AND start_time > ?
 		ORDER BY start_time ASC
@@ -111,7 +121,9 @@ func (r *MaintenanceRepo) GetUpcoming(ctx context.Context) ([]*domain.Maintenanc
 // GetByTimeRange retrieves maintenance windows overlapping with time range
 func (r *MaintenanceRepo) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Maintenance, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, start_time, end_time, system_ids, status, created_at, updated_at
+		SELECT id, title, description, start_time, end_time, system_ids, status, timezone,
+			notify_subscribers, start_notified, recurrence_type, recurrence_interval,
+			recurrence_until, next_created, last_reminder, created_at, updated_at
 		FROM maintenances
 		WHERE status != 'cancelled' AND start_time <= ? AND end_time >= ?
 		ORDER BY start_time ASC
@@ -131,10 +143,14 @@ func (r *MaintenanceRepo) Update(ctx context.Context, m *domain.Maintenance) err
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE maintenances
 		SET title = ?, description = ?, start_time = ?, end_time = ?,
-		    system_ids = ?, status = ?, updated_at = ?
+		    system_ids = ?, status = ?, timezone = ?, notify_subscribers = ?, start_notified = ?,
+		    recurrence_type = ?, recurrence_interval = ?, recurrence_until = ?, next_created = ?,
+		    last_reminder = ?, updated_at = ?
 		WHERE id = ?
 	`, m.Title, m.Description, m.StartTime, m.EndTime,
-		string(systemIDsJSON), string(m.Status), m.UpdatedAt, m.ID)
+		string(systemIDsJSON), string(m.Status), m.Timezone, m.NotifySubscribers, m.StartNotified,
+		recurrenceType(m.Recurrence), m.Recurrence.Interval, m.Recurrence.Until, m.NextCreated,
+		string(m.LastReminder), m.UpdatedAt, m.ID)
 
 	return err
 }
@@ -148,11 +164,13 @@ func (r *MaintenanceRepo) Delete(ctx context.Context, id int64) error {
 func (r *MaintenanceRepo) scanMaintenance(row *sql.Row) (*domain.Maintenance, error) {
 	var m domain.Maintenance
 	var systemIDsJSON string
-	var status string
+	var status, recurrence, lastReminder string
+	var until sql.NullTime
 
 	err := row.Scan(
 		&m.ID, &m.Title, &m.Description, &m.StartTime, &m.EndTime,
-		&systemIDsJSON, &status, &m.CreatedAt, &m.UpdatedAt,
+		&systemIDsJSON, &status, &m.Timezone, &m.NotifySubscribers, &m.StartNotified,
+		&recurrence, &m.Recurrence.Interval, &until, &m.NextCreated, &lastReminder, &m.CreatedAt, &m.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -165,6 +183,11 @@ func (r *MaintenanceRepo) scanMaintenance(row *sql.Row) (*domain.Maintenance, er
 		json.Unmarshal([]byte(systemIDsJSON), &m.SystemIDs)
 	}
 	m.Status = domain.MaintenanceStatus(status)
+	m.Recurrence.Type = domain.RecurrenceType(recurrence)
+	m.LastReminder = domain.MaintenanceReminder(lastReminder)
+	if until.Valid {
+		m.Recurrence.Until = &until.Time
+	}
 	m.RefreshStatus()
 
 	return &m, nil
@@ -176,11 +199,13 @@ func (r *MaintenanceRepo) scanMaintenances(rows *sql.Rows) ([]*domain.Maintenanc
 	for rows.Next() {
 		var m domain.Maintenance
 		var systemIDsJSON string
-		var status string
+		var status, recurrence, lastReminder string
+		var until sql.NullTime
 
 		err := rows.Scan(
 			&m.ID, &m.Title, &m.Description, &m.StartTime, &m.EndTime,
-			&systemIDsJSON, &status, &m.CreatedAt, &m.UpdatedAt,
+			&systemIDsJSON, &status, &m.Timezone, &m.NotifySubscribers, &m.StartNotified,
+			&recurrence, &m.Recurrence.Interval, &until, &m.NextCreated, &lastReminder, &m.CreatedAt, &m.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -190,9 +215,22 @@ func (r *MaintenanceRepo) scanMaintenances(rows *sql.Rows) ([]*domain.Maintenanc
 			json.Unmarshal([]byte(systemIDsJSON), &m.SystemIDs)
 		}
 		m.Status = domain.MaintenanceStatus(status)
+		m.Recurrence.Type = domain.RecurrenceType(recurrence)
+		m.LastReminder = domain.MaintenanceReminder(lastReminder)
+		if until.Valid {
+			m.Recurrence.Until = &until.Time
+		}
 
 		maintenances = append(maintenances, &m)
 	}
 
 	return maintenances, nil
 }
+
+// recurrenceType returns the stored recurrence type, "none" when unset
+func recurrenceType(r domain.Recurrence) string {
+	if !r.IsRecurring() {
+		return string(domain.RecurrenceNone)
+	}
+	return string(r.Type)
+}
@@ -173,7 +173,7 @@ func TestMaintenanceRepo_GetActive(t *testing.T) {
 	}
 
 	// Get active
-	actives, err := repo.GetActive(ctx)
+	actives, err := repo.GetActive(ctx, time.Now())
 	if err != nil {
 		t.Fatalf("GetActive() error = %v", err)
 	}
@@ -213,7 +213,7 @@ func TestMaintenanceRepo_GetUpcoming(t *testing.T) {
 	}
 
 	// Get upcoming
-	upcoming, err := repo.GetUpcoming(ctx)
+	upcoming, err := repo.GetUpcoming(ctx, time.Now())
 	if err != nil {
 		t.Fatalf("GetUpcoming() error = %v", err)
 	}
@@ -386,7 +386,7 @@ func TestMaintenanceRepo_CancelledNotInActive(t *testing.T) {
 	}
 
 	// GetActive should not include cancelled
-	actives, err := repo.GetActive(ctx)
+	actives, err := repo.GetActive(ctx, time.Now())
 	if err != nil {
 		t.Fatalf("GetActive() error = %v", err)
 	}
@@ -464,3 +464,102 @@ func TestMaintenanceRepo_StatusTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestMaintenanceRepo_Recurrence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewMaintenanceRepo(db)
+	ctx := context.Background()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	until := start.AddDate(0, 3, 0)
+	m, _ := domain.NewMaintenance("Weekly", "", start, start.Add(time.Hour))
+	m.SetRecurrence(domain.Recurrence{Type: domain.RecurrenceWeekly, Interval: 2, Until: &until})
+	if err := repo.Create(ctx, m); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, m.ID)
+	if got.Recurrence.Type != domain.RecurrenceWeekly || got.Recurrence.Interval != 2 {
+		t.Errorf("expected weekly every 2, got %+v", got.Recurrence)
+	}
+	if got.Recurrence.Until == nil || !got.Recurrence.Until.Equal(until) {
+		t.Errorf("expected until %v, got %v", until, got.Recurrence.Until)
+	}
+	if got.NextCreated {
+		t.Error("expected NextCreated to be false")
+	}
+
+	got.SetRecurrence(domain.Recurrence{})
+	got.NextCreated = true
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 1 || all[0].Recurrence.IsRecurring() || all[0].Recurrence.Until != nil || !all[0].NextCreated {
+		t.Errorf("expected non-recurring window with next created, got %+v", all[0])
+	}
+}
+
+func TestMaintenanceRepo_Timezone(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewMaintenanceRepo(db)
+	ctx := context.Background()
+
+	start := time.Now().Add(time.Hour)
+	m, _ := domain.NewMaintenance("Zoned", "", start, start.Add(time.Hour))
+	if err := m.SetTimezone("Asia/Tokyo"); err != nil {
+		t.Fatalf("SetTimezone() error = %v", err)
+	}
+	if err := repo.Create(ctx, m); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, m.ID)
+	if got.Timezone != "Asia/Tokyo" {
+		t.Errorf("Timezone = %q, want Asia/Tokyo", got.Timezone)
+	}
+
+	got.SetTimezone("")
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 1 || all[0].Timezone != "UTC" {
+		t.Errorf("expected UTC after update, got %+v", all)
+	}
+}
+
+func TestMaintenanceRepo_LastReminder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewMaintenanceRepo(db)
+	ctx := context.Background()
+
+	start := time.Now().Add(10 * time.Minute)
+	m, _ := domain.NewMaintenance("Reminded", "", start, start.Add(time.Hour))
+	if err := repo.Create(ctx, m); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, m.ID)
+	if got.LastReminder != domain.ReminderNone {
+		t.Errorf("LastReminder = %q, want none", got.LastReminder)
+	}
+
+	got.LastReminder = domain.ReminderUpcoming
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 1 || all[0].LastReminder != domain.ReminderUpcoming {
+		t.Errorf("expected upcoming reminder after update, got %+v", all)
+	}
+}
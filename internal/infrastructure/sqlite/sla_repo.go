@@ -406,6 +406,15 @@ func (r *SLABreachRepo) GetByPeriod(ctx context.Context, start, end time.Time) (
 	return r.scanBreaches(ctx, query, start, end)
 }
 
+// Cleanup removes acknowledged breaches detected before olderThan
+func (r *SLABreachRepo) Cleanup(ctx context.Context, olderThan time.Time) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM sla_breaches WHERE acknowledged = 1 AND detected_at < ?`, olderThan)
+	if err != nil {
+		return fmt.Errorf("failed to clean up SLA breaches: %w", err)
+	}
+	return nil
+}
+
 // scanBreaches is a helper to scan breach rows
 func (r *SLABreachRepo) scanBreaches(ctx context.Context, query string, args ...interface{}) ([]*domain.SLABreachEvent, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -697,3 +697,49 @@ func TestSLABreachRepo_BreachTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestSLABreachRepo_Cleanup(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	system := createTestSystem(t, db)
+	repo := NewSLABreachRepo(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	newBreach := func(detectedAt time.Time, acknowledged bool) *domain.SLABreachEvent {
+		breach := &domain.SLABreachEvent{
+			SystemID:     system.ID,
+			BreachType:   "uptime",
+			SLATarget:    99.9,
+			ActualValue:  98.5,
+			Period:       "daily",
+			PeriodStart:  detectedAt.AddDate(0, 0, -1),
+			PeriodEnd:    detectedAt,
+			DetectedAt:   detectedAt,
+			Acknowledged: acknowledged,
+		}
+		if err := repo.Create(ctx, breach); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return breach
+	}
+
+	oldAcked := newBreach(now.AddDate(0, 0, -40), true)
+	oldOpen := newBreach(now.AddDate(0, 0, -40), false)
+	recentAcked := newBreach(now.AddDate(0, 0, -1), true)
+
+	if err := repo.Cleanup(ctx, now.AddDate(0, 0, -30)); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if b, _ := repo.GetByID(ctx, oldAcked.ID); b != nil {
+		t.Error("expected old acknowledged breach to be deleted")
+	}
+	if b, _ := repo.GetByID(ctx, oldOpen.ID); b == nil {
+		t.Error("expected old unacknowledged breach to be kept")
+	}
+	if b, _ := repo.GetByID(ctx, recentAcked.ID); b == nil {
+		t.Error("expected recent acknowledged breach to be kept")
+	}
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func TestSubscriberRepo_CreateAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSubscriberRepo(db)
+	ctx := context.Background()
+
+	subscriber, err := domain.NewSubscriber("User@Example.com", []int64{1, 3})
+	if err != nil {
+		t.Fatalf("failed to create subscriber: %v", err)
+	}
+	if err := repo.Create(ctx, subscriber); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if subscriber.ID == 0 {
+		t.Error("expected subscriber ID to be set after Create()")
+	}
+
+	byEmail, err := repo.GetByEmail(ctx, "user@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if byEmail == nil || byEmail.ID != subscriber.ID {
+		t.Fatalf("expected subscriber %d by email, got %+v", subscriber.ID, byEmail)
+	}
+	if len(byEmail.SystemIDs) != 2 || byEmail.SystemIDs[1] != 3 {
+		t.Errorf("expected system IDs [1 3], got %v", byEmail.SystemIDs)
+	}
+	if byEmail.Confirmed || byEmail.ConfirmedAt != nil {
+		t.Error("expected new subscriber to be unconfirmed")
+	}
+
+	byToken, err := repo.GetByToken(ctx, subscriber.Token)
+	if err != nil {
+		t.Fatalf("GetByToken() error = %v", err)
+	}
+	if byToken == nil || byToken.Email != "user@example.com" {
+		t.Errorf("expected subscriber by token, got %+v", byToken)
+	}
+
+	missing, err := repo.GetByToken(ctx, "nope")
+	if err != nil {
+		t.Fatalf("GetByToken() error = %v", err)
+	}
+	if missing != nil {
+		t.Error("expected nil for unknown token")
+	}
+}
+
+func TestSubscriberRepo_GetConfirmed(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSubscriberRepo(db)
+	ctx := context.Background()
+
+	pending, _ := domain.NewSubscriber("pending@example.com", nil)
+	repo.Create(ctx, pending)
+	confirmed, _ := domain.NewSubscriber("confirmed@example.com", nil)
+	repo.Create(ctx, confirmed)
+
+	confirmed.Confirm()
+	if err := repo.Update(ctx, confirmed); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	subscribers, err := repo.GetConfirmed(ctx)
+	if err != nil {
+		t.Fatalf("GetConfirmed() error = %v", err)
+	}
+	if len(subscribers) != 1 || subscribers[0].Email != "confirmed@example.com" {
+		t.Fatalf("expected only the confirmed subscriber, got %+v", subscribers)
+	}
+	if subscribers[0].ConfirmedAt == nil {
+		t.Error("expected ConfirmedAt to be stored")
+	}
+
+	if err := repo.Delete(ctx, confirmed.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	subscribers, _ = repo.GetConfirmed(ctx)
+	if len(subscribers) != 0 {
+		t.Errorf("expected no confirmed subscribers after delete, got %d", len(subscribers))
+	}
+}
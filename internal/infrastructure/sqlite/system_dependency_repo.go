@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+
+	"status-incident/internal/domain"
+)
+
+// SystemDependencyRepo implements SystemDependencyRepository for SQLite
+type SystemDependencyRepo struct {
+	db *DB
+}
+
+// NewSystemDependencyRepo creates a new SystemDependencyRepo
+func NewSystemDependencyRepo(db *DB) *SystemDependencyRepo {
+	return &SystemDependencyRepo{db: db}
+}
+
+// Create persists a new edge
+func (r *SystemDependencyRepo) Create(ctx context.Context, dep *domain.SystemDependency) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO system_dependencies (system_id, depends_on_id, created_at)
+		VALUES (?, ?, ?)
+	`, dep.SystemID, dep.DependsOnID, dep.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	dep.ID = id
+	return nil
+}
+
+// GetAll retrieves every edge in the graph
+func (r *SystemDependencyRepo) GetAll(ctx context.Context) ([]*domain.SystemDependency, error) {
+	return r.query(ctx, `
+		SELECT id, system_id, depends_on_id, created_at
+		FROM system_dependencies ORDER BY id ASC
+	`)
+}
+
+// GetBySystemID retrieves the edges to systems that systemID depends on
+func (r *SystemDependencyRepo) GetBySystemID(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	return r.query(ctx, `
+		SELECT id, system_id, depends_on_id, created_at
+		FROM system_dependencies WHERE system_id = ? ORDER BY id ASC
+	`, systemID)
+}
+
+// GetDependents retrieves the edges from systems that depend on systemID
+func (r *SystemDependencyRepo) GetDependents(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	return r.query(ctx, `
+		SELECT id, system_id, depends_on_id, created_at
+		FROM system_dependencies WHERE depends_on_id = ? ORDER BY id ASC
+	`, systemID)
+}
+
+// Delete removes the edge from systemID to dependsOnID
+func (r *SystemDependencyRepo) Delete(ctx context.Context, systemID, dependsOnID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		"DELETE FROM system_dependencies WHERE system_id = ? AND depends_on_id = ?",
+		systemID, dependsOnID)
+	return err
+}
+
+func (r *SystemDependencyRepo) query(ctx context.Context, query string, args ...interface{}) ([]*domain.SystemDependency, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []*domain.SystemDependency
+	for rows.Next() {
+		var dep domain.SystemDependency
+		if err := rows.Scan(&dep.ID, &dep.SystemID, &dep.DependsOnID, &dep.CreatedAt); err != nil {
+			return nil, err
+		}
+		deps = append(deps, &dep)
+	}
+
+	return deps, rows.Err()
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func TestSystemDependencyRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	systemRepo := NewSystemRepo(db)
+	repo := NewSystemDependencyRepo(db)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, name := range []string{"API", "Auth", "Frontend"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		if err := systemRepo.Create(ctx, system); err != nil {
+			t.Fatalf("failed to create system: %v", err)
+		}
+		ids = append(ids, system.ID)
+	}
+	api, auth, frontend := ids[0], ids[1], ids[2]
+
+	for _, edge := range [][2]int64{{api, auth}, {frontend, api}} {
+		dep, _ := domain.NewSystemDependency(edge[0], edge[1])
+		if err := repo.Create(ctx, dep); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if dep.ID == 0 {
+			t.Error("expected ID to be set after Create()")
+		}
+	}
+
+	duplicate, _ := domain.NewSystemDependency(api, auth)
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Error("expected error for duplicate edge")
+	}
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 edges, got %d", len(all))
+	}
+
+	upstream, err := repo.GetBySystemID(ctx, api)
+	if err != nil {
+		t.Fatalf("GetBySystemID() error = %v", err)
+	}
+	if len(upstream) != 1 || upstream[0].DependsOnID != auth {
+		t.Errorf("expected API to depend on Auth, got %+v", upstream)
+	}
+
+	dependents, err := repo.GetDependents(ctx, api)
+	if err != nil {
+		t.Fatalf("GetDependents() error = %v", err)
+	}
+	if len(dependents) != 1 || dependents[0].SystemID != frontend {
+		t.Errorf("expected Frontend to depend on API, got %+v", dependents)
+	}
+
+	if err := repo.Delete(ctx, api, auth); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	upstream, _ = repo.GetBySystemID(ctx, api)
+	if len(upstream) != 0 {
+		t.Errorf("expected no edges after Delete(), got %d", len(upstream))
+	}
+
+	// Deleting a system removes its edges
+	if err := systemRepo.Delete(ctx, api); err != nil {
+		t.Fatalf("failed to delete system: %v", err)
+	}
+	all, _ = repo.GetAll(ctx)
+	if len(all) != 0 {
+		t.Errorf("expected edges to be removed with the system, got %d", len(all))
+	}
+}
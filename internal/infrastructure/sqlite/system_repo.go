@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"status-incident/internal/domain"
 )
@@ -20,8 +21,8 @@ func NewSystemRepo(db *DB) *SystemRepo {
 // Create persists a new system and sets its ID
 func (r *SystemRepo) Create(ctx context.Context, system *domain.System) error {
 	query := `
-		INSERT INTO systems (name, description, url, owner, status, sla_target, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO systems (name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -31,6 +32,11 @@ func (r *SystemRepo) Create(ctx context.Context, system *domain.System) error {
 		system.Owner,
 		system.Status.String(),
 		system.GetSLATarget(),
+		system.SLATargetExplicit,
+		system.ResponseTimeTargetMs,
+		tagsJSON(system.Tags),
+		system.DisplayOrder,
+		system.Slug,
 		system.CreatedAt,
 		system.UpdatedAt,
 	)
@@ -49,16 +55,28 @@ func (r *SystemRepo) Create(ctx context.Context, system *domain.System) error {
 
 // GetByID retrieves a system by ID
 func (r *SystemRepo) GetByID(ctx context.Context, id int64) (*domain.System, error) {
+	return r.getOne(ctx, "id = ?", id)
+}
+
+// GetBySlug retrieves a system by its public page slug
+func (r *SystemRepo) GetBySlug(ctx context.Context, slug string) (*domain.System, error) {
+	if slug == "" {
+		return nil, nil
+	}
+	return r.getOne(ctx, "slug = ?", slug)
+}
+
+// getOne retrieves the system matching the where clause, or nil
+func (r *SystemRepo) getOne(ctx context.Context, where string, arg interface{}) (*domain.System, error) {
 	query := `
-		SELECT id, name, description, url, owner, status, sla_target, created_at, updated_at
+		SELECT id, name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at
 		FROM systems
-		WHERE id = ?
-	`
+		WHERE ` + where
 
 	var system domain.System
-	var statusStr string
+	var statusStr, tags string
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
 		&system.ID,
 		&system.Name,
 		&system.Description,
@@ -66,6 +84,11 @@ func (r *SystemRepo) GetByID(ctx context.Context, id int64) (*domain.System, err
 		&system.Owner,
 		&statusStr,
 		&system.SLATarget,
+		&system.SLATargetExplicit,
+		&system.ResponseTimeTargetMs,
+		&tags,
+		&system.DisplayOrder,
+		&system.Slug,
 		&system.CreatedAt,
 		&system.UpdatedAt,
 	)
@@ -79,6 +102,7 @@ func (r *SystemRepo) GetByID(ctx context.Context, id int64) (*domain.System, err
 
 	status, _ := domain.NewStatus(statusStr)
 	system.Status = status
+	system.Tags = parseTagsJSON(tags)
 
 	return &system, nil
 }
@@ -86,9 +110,9 @@ func (r *SystemRepo) GetByID(ctx context.Context, id int64) (*domain.System, err
 // GetAll retrieves all systems
 func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
 	query := `
-		SELECT id, name, description, url, owner, status, sla_target, created_at, updated_at
+		SELECT id, name, description, url, owner, status, sla_target, sla_target_explicit, response_time_target_ms, tags, display_order, slug, created_at, updated_at
 		FROM systems
-		ORDER BY name ASC
+		ORDER BY display_order ASC, name ASC
 	`
 
 	rows, err := r.db.QueryContext(ctx, query)
@@ -100,7 +124,7 @@ func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
 	var systems []*domain.System
 	for rows.Next() {
 		var system domain.System
-		var statusStr string
+		var statusStr, tags string
 
 		if err := rows.Scan(
 			&system.ID,
@@ -110,6 +134,11 @@ func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
 			&system.Owner,
 			&statusStr,
 			&system.SLATarget,
+			&system.SLATargetExplicit,
+			&system.ResponseTimeTargetMs,
+			&tags,
+			&system.DisplayOrder,
+			&system.Slug,
 			&system.CreatedAt,
 			&system.UpdatedAt,
 		); err != nil {
@@ -118,6 +147,7 @@ func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
 
 		status, _ := domain.NewStatus(statusStr)
 		system.Status = status
+		system.Tags = parseTagsJSON(tags)
 		systems = append(systems, &system)
 	}
 
@@ -132,7 +162,7 @@ func (r *SystemRepo) GetAll(ctx context.Context) ([]*domain.System, error) {
 func (r *SystemRepo) Update(ctx context.Context, system *domain.System) error {
 	query := `
 		UPDATE systems
-		SET name = ?, description = ?, url = ?, owner = ?, status = ?, sla_target = ?, updated_at = ?
+		SET name = ?, description = ?, url = ?, owner = ?, status = ?, sla_target = ?, sla_target_explicit = ?, response_time_target_ms = ?, tags = ?, display_order = ?, slug = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -143,6 +173,11 @@ func (r *SystemRepo) Update(ctx context.Context, system *domain.System) error {
 		system.Owner,
 		system.Status.String(),
 		system.GetSLATarget(),
+		system.SLATargetExplicit,
+		system.ResponseTimeTargetMs,
+		tagsJSON(system.Tags),
+		system.DisplayOrder,
+		system.Slug,
 		system.UpdatedAt,
 		system.ID,
 	)
@@ -182,3 +217,19 @@ func (r *SystemRepo) Delete(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+func tagsJSON(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(tags)
+	return string(data)
+}
+
+func parseTagsJSON(data string) []string {
+	var tags []string
+	if err := json.Unmarshal([]byte(data), &tags); err != nil {
+		return nil
+	}
+	return tags
+}
@@ -303,7 +303,7 @@ func TestSystemRepo_StatusPersistence(t *testing.T) {
 	repo := NewSystemRepo(db)
 	ctx := context.Background()
 
-	statuses := []domain.Status{domain.StatusGreen, domain.StatusYellow, domain.StatusRed}
+	statuses := []domain.Status{domain.StatusGreen, domain.StatusYellow, domain.StatusPartial, domain.StatusRed}
 
 	for _, status := range statuses {
 		t.Run(string(status), func(t *testing.T) {
@@ -325,3 +325,147 @@ func TestSystemRepo_StatusPersistence(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemRepo_GetAll_DisplayOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSystemRepo(db)
+	ctx := context.Background()
+
+	for _, s := range []struct {
+		name  string
+		order int
+	}{
+		{"Alpha", 2},
+		{"Beta", 1},
+		{"Gamma", 1},
+	} {
+		system, _ := domain.NewSystem(s.name, "", "", "")
+		system.SetDisplayOrder(s.order)
+		if err := repo.Create(ctx, system); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	want := []string{"Beta", "Gamma", "Alpha"}
+	for i, name := range want {
+		if all[i].Name != name {
+			t.Errorf("system[%d] = %s, want %s", i, all[i].Name, name)
+		}
+	}
+	if all[2].DisplayOrder != 2 {
+		t.Errorf("DisplayOrder = %d, want 2", all[2].DisplayOrder)
+	}
+}
+
+func TestSystemRepo_TagsAndExplicitSLA(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSystemRepo(db)
+	ctx := context.Background()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.SetTags([]string{"production", "eu"})
+	if err := repo.Create(ctx, system); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, system.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if len(got.Tags) != 2 || !got.HasTag("production") || !got.HasTag("eu") {
+		t.Errorf("Tags = %v, want [production eu]", got.Tags)
+	}
+	if got.SLATargetExplicit {
+		t.Error("expected new system target not to be explicit")
+	}
+
+	got.SetSLATarget(99.5)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, system.ID)
+	if !updated.SLATargetExplicit || updated.SLATarget != 99.5 {
+		t.Errorf("expected explicit target 99.5, got %v (explicit=%v)", updated.SLATarget, updated.SLATargetExplicit)
+	}
+}
+
+func TestSystemRepo_ResponseTimeTarget(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSystemRepo(db)
+	ctx := context.Background()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.SetResponseTimeTarget(250)
+	if err := repo.Create(ctx, system); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, system.ID)
+	if got.ResponseTimeTargetMs != 250 {
+		t.Errorf("ResponseTimeTargetMs = %d, want 250", got.ResponseTimeTargetMs)
+	}
+
+	got.SetResponseTimeTarget(0)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 1 || all[0].ResponseTimeTargetMs != 0 {
+		t.Errorf("expected cleared target after update, got %+v", all)
+	}
+}
+
+func TestSystemRepo_GetBySlug(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSystemRepo(db)
+	ctx := context.Background()
+
+	checkout, _ := domain.NewSystem("Checkout", "", "", "")
+	checkout.SetSlug("checkout")
+	if err := repo.Create(ctx, checkout); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	search, _ := domain.NewSystem("Search", "", "", "")
+	if err := repo.Create(ctx, search); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := repo.GetBySlug(ctx, "checkout")
+	if err != nil {
+		t.Fatalf("GetBySlug() error = %v", err)
+	}
+	if found == nil || found.ID != checkout.ID || found.Slug != "checkout" {
+		t.Fatalf("expected checkout system, got %+v", found)
+	}
+
+	if found, _ := repo.GetBySlug(ctx, ""); found != nil {
+		t.Errorf("expected no system for an empty slug, got %q", found.Name)
+	}
+
+	// Systems without a slug do not collide on the unique index
+	other, _ := domain.NewSystem("Other", "", "", "")
+	if err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create() without slug error = %v", err)
+	}
+
+	dup, _ := domain.NewSystem("Duplicate", "", "", "")
+	dup.SetSlug("checkout")
+	if err := repo.Create(ctx, dup); err == nil {
+		t.Error("expected duplicate slug to be rejected")
+	}
+}
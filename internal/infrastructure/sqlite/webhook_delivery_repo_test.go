@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"status-incident/internal/domain"
+)
+
+func TestWebhookDeliveryRepo_CreateAndGetByWebhook(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	webhookRepo := NewWebhookRepo(db)
+	repo := NewWebhookDeliveryRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Hook", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhookRepo.Create(ctx, webhook)
+	other, _ := domain.NewWebhook("Other", "https://example.com/other", domain.WebhookTypeGeneric)
+	webhookRepo.Create(ctx, other)
+
+	first := domain.NewWebhookDelivery(webhook.ID, domain.EventIncidentStart, 200, 1, nil)
+	second := domain.NewWebhookDelivery(webhook.ID, domain.EventIncidentEnd, 503, 4, errors.New("returned status 503"))
+	for _, d := range []*domain.WebhookDelivery{first, second, domain.NewWebhookDelivery(other.ID, domain.EventStatusChange, 200, 1, nil)} {
+		if err := repo.Create(ctx, d); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if d.ID == 0 {
+			t.Error("expected delivery ID to be set after Create()")
+		}
+	}
+
+	deliveries, err := repo.GetByWebhook(ctx, webhook.ID, 10)
+	if err != nil {
+		t.Fatalf("GetByWebhook() error = %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("GetByWebhook() returned %d deliveries, want 2", len(deliveries))
+	}
+
+	got := deliveries[0]
+	if got.ID != second.ID {
+		t.Errorf("expected newest delivery first, got ID %d", got.ID)
+	}
+	if got.Event != domain.EventIncidentEnd || got.Status != domain.DeliveryStatusFailure ||
+		got.StatusCode != 503 || got.Attempts != 4 || got.Error != "returned status 503" {
+		t.Errorf("unexpected delivery: %+v", got)
+	}
+
+	limited, _ := repo.GetByWebhook(ctx, webhook.ID, 1)
+	if len(limited) != 1 {
+		t.Errorf("GetByWebhook() with limit 1 returned %d deliveries", len(limited))
+	}
+}
+
+func TestWebhookDeliveryRepo_KeepsRecentHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	webhookRepo := NewWebhookRepo(db)
+	repo := NewWebhookDeliveryRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Busy", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhookRepo.Create(ctx, webhook)
+
+	var last *domain.WebhookDelivery
+	for i := 0; i < domain.WebhookDeliveryHistoryLimit+5; i++ {
+		last = domain.NewWebhookDelivery(webhook.ID, domain.EventStatusChange, 200, 1, nil)
+		if err := repo.Create(ctx, last); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	deliveries, _ := repo.GetByWebhook(ctx, webhook.ID, 1000)
+	if len(deliveries) != domain.WebhookDeliveryHistoryLimit {
+		t.Errorf("expected history capped at %d, got %d", domain.WebhookDeliveryHistoryLimit, len(deliveries))
+	}
+	if deliveries[0].ID != last.ID {
+		t.Errorf("expected the newest delivery to be kept")
+	}
+
+	// Deliveries go away with their webhook
+	if err := webhookRepo.Delete(ctx, webhook.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deliveries, _ = repo.GetByWebhook(ctx, webhook.ID, 1000)
+	if len(deliveries) != 0 {
+		t.Errorf("expected deliveries to be deleted with the webhook, got %d", len(deliveries))
+	}
+}
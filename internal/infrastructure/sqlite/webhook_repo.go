@@ -21,8 +21,8 @@ func NewWebhookRepo(db *DB) *WebhookRepo {
 // Create persists a new webhook
 func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
 	query := `
-		INSERT INTO webhooks (name, url, type, events, system_ids, enabled, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO webhooks (name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -31,6 +31,13 @@ func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error
 		webhook.Type,
 		webhook.EventsJSON(),
 		webhook.SystemIDsJSON(),
+		webhook.PayloadVersion,
+		webhook.IncludeEntity,
+		webhook.SlackBlocks,
+		webhook.PayloadTemplate,
+		webhook.Secret,
+		webhook.MinSeverity,
+		webhook.TriggerStatusesJSON(),
 		webhook.Enabled,
 		webhook.CreatedAt,
 		webhook.UpdatedAt,
@@ -51,14 +58,16 @@ func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error
 // GetByID retrieves a webhook by ID
 func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
 	query := `
-		SELECT id, name, url, type, events, system_ids, enabled, created_at, updated_at
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
 		FROM webhooks
 		WHERE id = ?
 	`
 
 	webhook := &domain.Webhook{}
 	var eventsJSON string
-	var systemIDsJSON sql.NullString
+	var systemIDsJSON, triggerStatusesJSON sql.NullString
+	var lastDeliveryAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&webhook.ID,
@@ -67,9 +76,20 @@ func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, e
 		&webhook.Type,
 		&eventsJSON,
 		&systemIDsJSON,
+		&webhook.PayloadVersion,
+		&webhook.IncludeEntity,
+		&webhook.SlackBlocks,
+		&webhook.PayloadTemplate,
+		&webhook.Secret,
+		&webhook.MinSeverity,
+		&triggerStatusesJSON,
 		&webhook.Enabled,
 		&webhook.CreatedAt,
 		&webhook.UpdatedAt,
+		&lastDeliveryAt,
+		&webhook.LastDeliveryStatus,
+		&webhook.LastDeliveryCode,
+		&webhook.ConsecutiveFailures,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -82,6 +102,12 @@ func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, e
 	if systemIDsJSON.Valid {
 		webhook.SystemIDs = domain.ParseSystemIDsJSON(&systemIDsJSON.String)
 	}
+	if triggerStatusesJSON.Valid {
+		webhook.TriggerStatuses = domain.ParseTriggerStatusesJSON(&triggerStatusesJSON.String)
+	}
+	if lastDeliveryAt.Valid {
+		webhook.LastDeliveryAt = &lastDeliveryAt.Time
+	}
 
 	return webhook, nil
 }
@@ -89,7 +115,8 @@ func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, e
 // GetAll retrieves all webhooks
 func (r *WebhookRepo) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
 	query := `
-		SELECT id, name, url, type, events, system_ids, enabled, created_at, updated_at
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
 		FROM webhooks
 		ORDER BY created_at DESC
 	`
@@ -100,7 +127,8 @@ func (r *WebhookRepo) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
 // GetEnabled retrieves all enabled webhooks
 func (r *WebhookRepo) GetEnabled(ctx context.Context) ([]*domain.Webhook, error) {
 	query := `
-		SELECT id, name, url, type, events, system_ids, enabled, created_at, updated_at
+		SELECT id, name, url, type, events, system_ids, payload_version, include_entity, slack_blocks, payload_template, secret, min_severity, trigger_statuses, enabled, created_at, updated_at,
+		       last_delivery_at, last_delivery_status, last_delivery_code, consecutive_failures
 		FROM webhooks
 		WHERE enabled = 1
 		ORDER BY created_at DESC
@@ -120,7 +148,8 @@ func (r *WebhookRepo) queryWebhooks(ctx context.Context, query string, args ...i
 	for rows.Next() {
 		webhook := &domain.Webhook{}
 		var eventsJSON string
-		var systemIDsJSON sql.NullString
+		var systemIDsJSON, triggerStatusesJSON sql.NullString
+		var lastDeliveryAt sql.NullTime
 
 		err := rows.Scan(
 			&webhook.ID,
@@ -129,9 +158,20 @@ func (r *WebhookRepo) queryWebhooks(ctx context.Context, query string, args ...i
 			&webhook.Type,
 			&eventsJSON,
 			&systemIDsJSON,
+			&webhook.PayloadVersion,
+			&webhook.IncludeEntity,
+			&webhook.SlackBlocks,
+			&webhook.PayloadTemplate,
+			&webhook.Secret,
+			&webhook.MinSeverity,
+			&triggerStatusesJSON,
 			&webhook.Enabled,
 			&webhook.CreatedAt,
 			&webhook.UpdatedAt,
+			&lastDeliveryAt,
+			&webhook.LastDeliveryStatus,
+			&webhook.LastDeliveryCode,
+			&webhook.ConsecutiveFailures,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan webhook: %w", err)
@@ -141,6 +181,12 @@ func (r *WebhookRepo) queryWebhooks(ctx context.Context, query string, args ...i
 		if systemIDsJSON.Valid {
 			webhook.SystemIDs = domain.ParseSystemIDsJSON(&systemIDsJSON.String)
 		}
+		if triggerStatusesJSON.Valid {
+			webhook.TriggerStatuses = domain.ParseTriggerStatusesJSON(&triggerStatusesJSON.String)
+		}
+		if lastDeliveryAt.Valid {
+			webhook.LastDeliveryAt = &lastDeliveryAt.Time
+		}
 
 		webhooks = append(webhooks, webhook)
 	}
@@ -152,7 +198,7 @@ func (r *WebhookRepo) queryWebhooks(ctx context.Context, query string, args ...i
 func (r *WebhookRepo) Update(ctx context.Context, webhook *domain.Webhook) error {
 	query := `
 		UPDATE webhooks
-		SET name = ?, url = ?, type = ?, events = ?, system_ids = ?, enabled = ?, updated_at = ?
+		SET name = ?, url = ?, type = ?, events = ?, system_ids = ?, payload_version = ?, include_entity = ?, slack_blocks = ?, payload_template = ?, secret = ?, min_severity = ?, trigger_statuses = ?, enabled = ?, consecutive_failures = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -162,7 +208,15 @@ func (r *WebhookRepo) Update(ctx context.Context, webhook *domain.Webhook) error
 		webhook.Type,
 		webhook.EventsJSON(),
 		webhook.SystemIDsJSON(),
+		webhook.PayloadVersion,
+		webhook.IncludeEntity,
+		webhook.SlackBlocks,
+		webhook.PayloadTemplate,
+		webhook.Secret,
+		webhook.MinSeverity,
+		webhook.TriggerStatusesJSON(),
 		webhook.Enabled,
+		webhook.ConsecutiveFailures,
 		webhook.UpdatedAt,
 		webhook.ID,
 	)
@@ -184,3 +238,33 @@ func (r *WebhookRepo) Delete(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// RecordDelivery stores the outcome of the latest delivery and returns the
+// webhook's consecutive failure count. The count is updated in SQL so
+// concurrent sends don't lose increments.
+func (r *WebhookRepo) RecordDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (int, error) {
+	query := `
+		UPDATE webhooks
+		SET last_delivery_at = ?, last_delivery_status = ?, last_delivery_code = ?,
+		    consecutive_failures = CASE WHEN ? THEN 0 ELSE consecutive_failures + 1 END
+		WHERE id = ?
+		RETURNING consecutive_failures
+	`
+
+	var failures int
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.CreatedAt,
+		delivery.Status,
+		delivery.StatusCode,
+		delivery.Succeeded(),
+		delivery.WebhookID,
+	).Scan(&failures)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return failures, nil
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"status-incident/internal/domain"
@@ -421,3 +422,259 @@ func TestWebhookRepo_EnableDisable(t *testing.T) {
 		t.Error("expected enabled after Enable()")
 	}
 }
+
+func TestWebhookRepo_PayloadVersion(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Pinned", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhook.SetPayloadVersion(domain.PayloadVersionV1)
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.PayloadVersion != domain.PayloadVersionV1 {
+		t.Errorf("PayloadVersion = %d, want %d", retrieved.PayloadVersion, domain.PayloadVersionV1)
+	}
+
+	retrieved.SetPayloadVersion(0)
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, webhook.ID)
+	if updated.PayloadVersion != 0 {
+		t.Errorf("PayloadVersion = %d, want 0", updated.PayloadVersion)
+	}
+}
+
+func TestWebhookRepo_IncludeEntity(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Full", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhook.SetIncludeEntity(true)
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !retrieved.IncludeEntity {
+		t.Error("expected IncludeEntity to be persisted")
+	}
+
+	retrieved.SetIncludeEntity(false)
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, webhook.ID)
+	if updated.IncludeEntity {
+		t.Error("expected IncludeEntity false after update")
+	}
+}
+
+func TestWebhookRepo_SlackBlocks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Slack", "https://hooks.slack.com/services/T000/B000/XXX", domain.WebhookTypeSlack)
+	webhook.SetSlackBlocks(true)
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !retrieved.SlackBlocks {
+		t.Error("expected SlackBlocks to be persisted")
+	}
+
+	retrieved.SetSlackBlocks(false)
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, webhook.ID)
+	if updated.SlackBlocks {
+		t.Error("expected SlackBlocks false after update")
+	}
+}
+
+func TestWebhookRepo_PayloadTemplate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	tmpl := `{"text": {{json .Message}}}`
+	webhook, _ := domain.NewWebhook("Custom", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhook.SetPayloadTemplate(tmpl)
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.PayloadTemplate != tmpl {
+		t.Errorf("expected payload template %q, got %q", tmpl, retrieved.PayloadTemplate)
+	}
+
+	retrieved.SetPayloadTemplate("")
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 1 || all[0].PayloadTemplate != "" {
+		t.Error("expected payload template cleared after update")
+	}
+}
+
+func TestWebhookRepo_Secret(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Signed", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhook.SetSecret("s3cret")
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.Secret != "s3cret" {
+		t.Errorf("expected secret to be persisted, got %q", retrieved.Secret)
+	}
+
+	retrieved.SetSecret("")
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	enabled, _ := repo.GetEnabled(ctx)
+	if len(enabled) != 1 || enabled[0].Secret != "" {
+		t.Errorf("expected secret cleared after update, got %+v", enabled)
+	}
+}
+
+func TestWebhookRepo_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Filtered", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	webhook.SetMinSeverity(domain.SeverityMajor)
+	webhook.SetTriggerStatuses([]domain.Status{domain.StatusRed})
+
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if retrieved.MinSeverity != domain.SeverityMajor {
+		t.Errorf("MinSeverity = %q, want %q", retrieved.MinSeverity, domain.SeverityMajor)
+	}
+	if len(retrieved.TriggerStatuses) != 1 || retrieved.TriggerStatuses[0] != domain.StatusRed {
+		t.Errorf("TriggerStatuses = %v, want [red]", retrieved.TriggerStatuses)
+	}
+
+	retrieved.SetMinSeverity("")
+	retrieved.SetTriggerStatuses(nil)
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	enabled, _ := repo.GetEnabled(ctx)
+	if len(enabled) != 1 || enabled[0].MinSeverity != "" || enabled[0].TriggerStatuses != nil {
+		t.Errorf("expected filters cleared after update, got %+v", enabled)
+	}
+}
+
+func TestWebhookRepo_RecordDelivery(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	ctx := context.Background()
+
+	webhook, _ := domain.NewWebhook("Flaky", "https://example.com/webhook", domain.WebhookTypeGeneric)
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	fail := errors.New("returned status 502")
+	for want := 1; want <= 2; want++ {
+		failures, err := repo.RecordDelivery(ctx, domain.NewWebhookDelivery(webhook.ID, domain.EventStatusChange, 502, 1, fail))
+		if err != nil {
+			t.Fatalf("RecordDelivery() error = %v", err)
+		}
+		if failures != want {
+			t.Errorf("RecordDelivery() failures = %d, want %d", failures, want)
+		}
+	}
+
+	retrieved, _ := repo.GetByID(ctx, webhook.ID)
+	if retrieved.ConsecutiveFailures != 2 || retrieved.LastDeliveryStatus != domain.DeliveryStatusFailure || retrieved.LastDeliveryCode != 502 {
+		t.Errorf("unexpected delivery state: failures=%d status=%s code=%d",
+			retrieved.ConsecutiveFailures, retrieved.LastDeliveryStatus, retrieved.LastDeliveryCode)
+	}
+	if retrieved.LastDeliveryAt == nil {
+		t.Error("expected LastDeliveryAt to be set")
+	}
+
+	failures, err := repo.RecordDelivery(ctx, domain.NewWebhookDelivery(webhook.ID, domain.EventStatusChange, 200, 1, nil))
+	if err != nil {
+		t.Fatalf("RecordDelivery() error = %v", err)
+	}
+	if failures != 0 {
+		t.Errorf("expected success to reset failures, got %d", failures)
+	}
+
+	// Update persists the reset done by Enable
+	retrieved.ConsecutiveFailures = 5
+	retrieved.Enable()
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	retrieved, _ = repo.GetByID(ctx, webhook.ID)
+	if retrieved.ConsecutiveFailures != 0 || retrieved.LastDeliveryStatus != domain.DeliveryStatusSuccess {
+		t.Errorf("unexpected state after Update: failures=%d status=%s", retrieved.ConsecutiveFailures, retrieved.LastDeliveryStatus)
+	}
+}
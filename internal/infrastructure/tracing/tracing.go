@@ -0,0 +1,78 @@
+// Package tracing configures OpenTelemetry tracing and provides the span
+// helpers used by the database layers. Until Setup is called with an endpoint
+// the global tracer provider is a no-op, so instrumentation costs next to nothing.
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies this service in exported traces
+const ServiceName = "status-incident"
+
+// dbTracerName is the instrumentation scope of database query spans
+const dbTracerName = "status-incident/internal/infrastructure"
+
+// Setup exports spans over OTLP/HTTP to endpoint, e.g. http://localhost:4318.
+// With an empty endpoint tracing stays disabled. The returned function flushes
+// and stops the exporter.
+func Setup(ctx context.Context, endpoint, version string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartQuery starts a client span around a database query.
+// The span is named after the statement type, e.g. "sqlite SELECT".
+func StartQuery(ctx context.Context, system, query string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(dbTracerName).Start(ctx, system+".query", trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		statement := strings.TrimSpace(query)
+		operation, _, _ := strings.Cut(statement, " ")
+		span.SetName(system + " " + strings.ToUpper(operation))
+		span.SetAttributes(
+			attribute.String("db.system", system),
+			attribute.String("db.operation", strings.ToUpper(operation)),
+			attribute.String("db.statement", statement),
+		)
+	}
+	return ctx, span
+}
+
+// EndQuery records err on span, other than sql.ErrNoRows, and ends it
+func EndQuery(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
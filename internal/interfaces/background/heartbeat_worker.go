@@ -2,15 +2,21 @@ package background
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"status-incident/internal/application"
 	"time"
 )
 
-// HeartbeatWorker runs periodic health checks
+// minHeartbeatWait keeps the worker from spinning when checks are overdue
+const minHeartbeatWait = time.Second
+
+// HeartbeatWorker runs health checks, waking when the next dependency is due.
+// interval is the longest it sleeps, so new dependencies are picked up.
 type HeartbeatWorker struct {
 	service  *application.HeartbeatService
 	interval time.Duration
+	logger   application.Logger
+	clock    application.Clock
 	stop     chan struct{}
 	done     chan struct{}
 }
@@ -20,11 +26,23 @@ func NewHeartbeatWorker(service *application.HeartbeatService, interval time.Dur
 	return &HeartbeatWorker{
 		service:  service,
 		interval: interval,
+		logger:   slog.Default(),
+		clock:    application.SystemClock{},
 		stop:     make(chan struct{}),
 		done:     make(chan struct{}),
 	}
 }
 
+// SetLogger replaces the logger used for worker events
+func (w *HeartbeatWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// SetClock sets the clock used to schedule the next wake-up
+func (w *HeartbeatWorker) SetClock(clock application.Clock) {
+	w.clock = clock
+}
+
 // Start begins the heartbeat checking loop
 func (w *HeartbeatWorker) Start(ctx context.Context) {
 	go w.run(ctx)
@@ -39,31 +57,47 @@ func (w *HeartbeatWorker) Stop() {
 func (w *HeartbeatWorker) run(ctx context.Context) {
 	defer close(w.done)
 
-	ticker := time.NewTicker(w.interval)
-	defer ticker.Stop()
-
 	// Run immediately on start
-	w.check(ctx)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			w.check(ctx)
+		case <-timer.C:
+			next := w.check(ctx)
+			timer.Reset(w.wait(next, w.clock.Now()))
 		case <-w.stop:
-			log.Println("Heartbeat worker stopping...")
+			w.logger.Info("heartbeat worker stopping")
 			return
 		case <-ctx.Done():
-			log.Println("Heartbeat worker context cancelled...")
+			w.logger.Info("heartbeat worker context cancelled")
 			return
 		}
 	}
 }
 
-func (w *HeartbeatWorker) check(ctx context.Context) {
-	checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+// check runs one sweep. Each check carries its own deadline, so the sweep
+// itself is not bounded
+func (w *HeartbeatWorker) check(ctx context.Context) time.Time {
+	next, err := w.service.CheckDueDependencies(ctx)
+	if err != nil {
+		w.logger.Error("heartbeat check failed", "error", err)
+	}
+	return next
+}
 
-	if err := w.service.CheckAllDependencies(checkCtx); err != nil {
-		log.Printf("Heartbeat check error: %v", err)
+// wait returns how long to sleep until the next dependency is due, bounded
+// by minHeartbeatWait and the worker interval
+func (w *HeartbeatWorker) wait(next, now time.Time) time.Duration {
+	if next.IsZero() {
+		return w.interval
+	}
+	wait := next.Sub(now)
+	if wait < minHeartbeatWait {
+		return minHeartbeatWait
+	}
+	if wait > w.interval {
+		return w.interval
 	}
+	return wait
 }
@@ -0,0 +1,77 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// IncidentAutoCloseWorker periodically resolves stale incidents
+type IncidentAutoCloseWorker struct {
+	service  *application.IncidentAutoCloseService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewIncidentAutoCloseWorker creates a new incident auto-close worker
+func NewIncidentAutoCloseWorker(service *application.IncidentAutoCloseService, interval time.Duration) *IncidentAutoCloseWorker {
+	return &IncidentAutoCloseWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *IncidentAutoCloseWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the auto-close loop
+func (w *IncidentAutoCloseWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *IncidentAutoCloseWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *IncidentAutoCloseWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("incident auto-close worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("incident auto-close worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *IncidentAutoCloseWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	closed, err := w.service.CloseStale(sweepCtx)
+	if err != nil {
+		w.logger.Error("incident auto-close failed", "error", err)
+	}
+	for _, incident := range closed {
+		w.logger.Info("auto-closed stale incident", "incident_id", incident.ID, "title", incident.Title)
+	}
+}
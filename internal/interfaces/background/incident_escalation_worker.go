@@ -0,0 +1,77 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// IncidentEscalationWorker periodically escalates unacknowledged incidents
+type IncidentEscalationWorker struct {
+	service  *application.IncidentEscalationService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewIncidentEscalationWorker creates a new incident escalation worker
+func NewIncidentEscalationWorker(service *application.IncidentEscalationService, interval time.Duration) *IncidentEscalationWorker {
+	return &IncidentEscalationWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *IncidentEscalationWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the sweep loop
+func (w *IncidentEscalationWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *IncidentEscalationWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *IncidentEscalationWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("incident escalation worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("incident escalation worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *IncidentEscalationWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	escalated, err := w.service.Sweep(sweepCtx)
+	if err != nil {
+		w.logger.Error("incident escalation failed", "error", err)
+	}
+	for _, incident := range escalated {
+		w.logger.Info("escalated unacknowledged incident", "incident_id", incident.ID, "title", incident.Title)
+	}
+}
@@ -0,0 +1,77 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// IncidentSchedulerWorker periodically starts scheduled incidents whose time has come
+type IncidentSchedulerWorker struct {
+	service  *application.IncidentService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewIncidentSchedulerWorker creates a new scheduled incident worker
+func NewIncidentSchedulerWorker(service *application.IncidentService, interval time.Duration) *IncidentSchedulerWorker {
+	return &IncidentSchedulerWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *IncidentSchedulerWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the scheduler loop
+func (w *IncidentSchedulerWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *IncidentSchedulerWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *IncidentSchedulerWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("incident scheduler worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("incident scheduler worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *IncidentSchedulerWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	started, err := w.service.StartDueIncidents(sweepCtx)
+	if err != nil {
+		w.logger.Error("starting scheduled incidents failed", "error", err)
+	}
+	for _, incident := range started {
+		w.logger.Info("started scheduled incident", "incident_id", incident.ID, "title", incident.Title)
+	}
+}
@@ -0,0 +1,95 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// MaintenanceWorker periodically announces maintenance windows that have
+// started, sends maintenance reminders and schedules the next occurrence of
+// recurring ones
+type MaintenanceWorker struct {
+	service  *application.MaintenanceService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewMaintenanceWorker creates a new maintenance worker
+func NewMaintenanceWorker(service *application.MaintenanceService, interval time.Duration) *MaintenanceWorker {
+	return &MaintenanceWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *MaintenanceWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the maintenance loop
+func (w *MaintenanceWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *MaintenanceWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *MaintenanceWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("maintenance worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("maintenance worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *MaintenanceWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	started, err := w.service.NotifyStartedMaintenances(sweepCtx)
+	if err != nil {
+		w.logger.Error("maintenance start notification failed", "error", err)
+	}
+	for _, m := range started {
+		w.logger.Info("announced start of maintenance", "maintenance_id", m.ID, "title", m.Title)
+	}
+
+	reminded, err := w.service.SendReminders(sweepCtx)
+	if err != nil {
+		w.logger.Error("maintenance reminder failed", "error", err)
+	}
+	for _, m := range reminded {
+		w.logger.Info("sent maintenance reminder", "maintenance_id", m.ID, "title", m.Title, "reminder", m.LastReminder)
+	}
+
+	created, err := w.service.CreateNextOccurrences(sweepCtx)
+	if err != nil {
+		w.logger.Error("scheduling recurring maintenance failed", "error", err)
+	}
+	for _, m := range created {
+		w.logger.Info("scheduled next maintenance occurrence", "maintenance_id", m.ID, "title", m.Title, "start_time", m.StartTime.Format(time.RFC3339))
+	}
+}
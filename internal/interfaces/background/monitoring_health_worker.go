@@ -0,0 +1,74 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// MonitoringHealthWorker periodically checks that monitoring itself is healthy.
+// It runs independently of the heartbeat worker so a stalled sweep is noticed.
+type MonitoringHealthWorker struct {
+	service  *application.MonitoringHealthService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewMonitoringHealthWorker creates a new monitoring health worker
+func NewMonitoringHealthWorker(service *application.MonitoringHealthService, interval time.Duration) *MonitoringHealthWorker {
+	return &MonitoringHealthWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *MonitoringHealthWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the check loop
+func (w *MonitoringHealthWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *MonitoringHealthWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *MonitoringHealthWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.check(ctx)
+		case <-w.stop:
+			w.logger.Info("monitoring health worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("monitoring health worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *MonitoringHealthWorker) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for _, alert := range w.service.Check(checkCtx) {
+		w.logger.Warn("monitoring degraded", "alert", alert)
+	}
+}
@@ -0,0 +1,78 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// OutageEscalationWorker periodically escalates prolonged dependency outages
+type OutageEscalationWorker struct {
+	service  *application.OutageEscalationService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewOutageEscalationWorker creates a new outage escalation worker
+func NewOutageEscalationWorker(service *application.OutageEscalationService, interval time.Duration) *OutageEscalationWorker {
+	return &OutageEscalationWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *OutageEscalationWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the sweep loop
+func (w *OutageEscalationWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *OutageEscalationWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *OutageEscalationWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("outage escalation worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("outage escalation worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *OutageEscalationWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	escalated, err := w.service.Sweep(sweepCtx)
+	if err != nil {
+		w.logger.Error("outage escalation failed", "error", err)
+		return
+	}
+	for _, dep := range escalated {
+		w.logger.Info("escalated prolonged outage", "dependency_id", dep.ID, "system_id", dep.SystemID, "dependency_name", dep.Name)
+	}
+}
@@ -0,0 +1,76 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// RetentionWorker periodically deletes history past its retention period
+type RetentionWorker struct {
+	service  *application.RetentionService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewRetentionWorker creates a new retention worker
+func NewRetentionWorker(service *application.RetentionService, interval time.Duration) *RetentionWorker {
+	return &RetentionWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *RetentionWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the cleanup loop
+func (w *RetentionWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *RetentionWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *RetentionWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Clean up on start so a long-stopped instance doesn't wait an interval
+	w.cleanup(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.cleanup(ctx)
+		case <-w.stop:
+			w.logger.Info("retention worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("retention worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *RetentionWorker) cleanup(ctx context.Context) {
+	cleanupCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	if err := w.service.Cleanup(cleanupCtx); err != nil {
+		w.logger.Error("retention cleanup failed", "error", err)
+	}
+}
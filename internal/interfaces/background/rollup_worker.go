@@ -0,0 +1,79 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// rollupBackfillDays is how many past days are rolled up when the worker starts
+const rollupBackfillDays = 90
+
+// RollupWorker periodically precomputes daily uptime rollups
+type RollupWorker struct {
+	service  *application.AnalyticsService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewRollupWorker creates a new rollup worker
+func NewRollupWorker(service *application.AnalyticsService, interval time.Duration) *RollupWorker {
+	return &RollupWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *RollupWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start begins the rollup loop
+func (w *RollupWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *RollupWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *RollupWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Backfill on start, then keep the most recent days fresh
+	w.rollup(ctx, rollupBackfillDays)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.rollup(ctx, 2)
+		case <-w.stop:
+			w.logger.Info("rollup worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("rollup worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *RollupWorker) rollup(ctx context.Context, days int) {
+	rollupCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	if err := w.service.RollupUptime(rollupCtx, days); err != nil {
+		w.logger.Error("uptime rollup failed", "error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"status-incident/internal/application"
+	"time"
+)
+
+// StatusSnapshotWorker periodically refreshes the static status snapshot.
+// Changes within one interval are coalesced into a single write.
+type StatusSnapshotWorker struct {
+	service  *application.StatusSnapshotService
+	interval time.Duration
+	logger   application.Logger
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewStatusSnapshotWorker creates a new status snapshot worker
+func NewStatusSnapshotWorker(service *application.StatusSnapshotService, interval time.Duration) *StatusSnapshotWorker {
+	return &StatusSnapshotWorker{
+		service:  service,
+		interval: interval,
+		logger:   slog.Default(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger replaces the logger used for worker events
+func (w *StatusSnapshotWorker) SetLogger(logger application.Logger) {
+	w.logger = logger
+}
+
+// Start writes the initial snapshot and begins the refresh loop
+func (w *StatusSnapshotWorker) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop gracefully stops the worker
+func (w *StatusSnapshotWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *StatusSnapshotWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	w.sweep(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.sweep(ctx)
+		case <-w.stop:
+			w.logger.Info("status snapshot worker stopping")
+			return
+		case <-ctx.Done():
+			w.logger.Info("status snapshot worker context cancelled")
+			return
+		}
+	}
+}
+
+func (w *StatusSnapshotWorker) sweep(ctx context.Context) {
+	sweepCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if _, err := w.service.WriteIfChanged(sweepCtx); err != nil {
+		w.logger.Error("status snapshot failed", "error", err)
+	}
+}
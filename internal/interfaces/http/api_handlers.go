@@ -2,21 +2,28 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"status-incident/internal/application"
 	"status-incident/internal/domain"
 )
 
 // Request/Response types
 type createSystemRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	URL         string `json:"url"`
-	Owner       string `json:"owner"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	URL          string   `json:"url"`
+	Owner        string   `json:"owner"`
+	DisplayOrder *int     `json:"display_order,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Slug         *string  `json:"slug,omitempty"` // public page at /status/{slug}; "" removes it
 }
 
 type updateStatusRequest struct {
@@ -24,19 +31,71 @@ type updateStatusRequest struct {
 	Message string `json:"message"`
 }
 
+// bulkStatusItem is one status change in a bulk update
+type bulkStatusItem struct {
+	ID      int64  `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// bulkStatusResult reports the outcome of one bulk status change
+type bulkStatusResult struct {
+	ID      int64  `json:"id"`
+	Success bool   `json:"success"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// bulkStatusResponse reports the outcome of a bulk status update, with
+// results in request order
+type bulkStatusResponse struct {
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []bulkStatusResult `json:"results"`
+}
+
+// maxBulkStatusUpdates caps how many status changes one bulk request may make
+const maxBulkStatusUpdates = 500
+
+// pageResponse wraps one page of a list endpoint
+type pageResponse struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+type systemDependencyRequest struct {
+	SystemID int64 `json:"system_id"` // the upstream system
+}
+
 type createDependencyRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	RecordLatency *bool    `json:"record_latency,omitempty"` // persist latency per check (default true)
+	Weight        *float64 `json:"weight,omitempty"`         // share in weighted system SLA (default 1)
+	Criticality   string   `json:"criticality,omitempty"`    // critical (default), major or minor
 }
 
 type setHeartbeatRequest struct {
-	URL          string            `json:"url"`
-	Interval     int               `json:"interval"`
-	Method       string            `json:"method,omitempty"`       // GET, POST, PUT, HEAD
-	Headers      map[string]string `json:"headers,omitempty"`      // custom headers
-	Body         string            `json:"body,omitempty"`         // request body for POST/PUT
-	ExpectStatus string            `json:"expect_status,omitempty"` // "200", "200,201", "2xx"
-	ExpectBody   string            `json:"expect_body,omitempty"`  // regex pattern
+	CheckType             string            `json:"check_type,omitempty"` // "http" (default), "tcp", "grpc" or "dns"
+	URL                   string            `json:"url"`                  // URL, host:port for tcp/grpc, or hostname for dns
+	Interval              int               `json:"interval"`
+	Method                string            `json:"method,omitempty"`                   // GET, POST, PUT, HEAD
+	Headers               map[string]string `json:"headers,omitempty"`                  // custom headers
+	Body                  string            `json:"body,omitempty"`                     // request body for POST/PUT
+	ExpectStatus          string            `json:"expect_status,omitempty"`            // "200", "200,201", "2xx"
+	ExpectBody            string            `json:"expect_body,omitempty"`              // regex pattern
+	ExpectBodySubstring   string            `json:"expect_body_substring,omitempty"`    // literal text; mismatch marks the dependency degraded
+	MinTLSVersion         string            `json:"min_tls_version,omitempty"`          // "1.0", "1.1", "1.2", "1.3"
+	Retries               int               `json:"retries,omitempty"`                  // immediate retries per check (0-5)
+	GRPCService           string            `json:"grpc_service,omitempty"`             // service name for grpc checks
+	CertExpiryWarningDays int               `json:"cert_expiry_warning_days,omitempty"` // degrade when the TLS cert expires within this many days
+	FollowRedirects       *bool             `json:"follow_redirects,omitempty"`         // follow 3xx responses (default true)
+	MaxRedirects          int               `json:"max_redirects,omitempty"`            // redirects to follow (0 = 10, max 20)
+	TimeoutMs             int               `json:"timeout_ms,omitempty"`               // per-check timeout, 1000-60000 (0 = global default)
+	DNSRecordType         string            `json:"dns_record_type,omitempty"`          // "A" (default), "AAAA" or "CNAME" for dns checks
+	DNSExpect             string            `json:"dns_expect,omitempty"`               // IP or name the answer must contain; mismatch marks the dependency degraded
 }
 
 type errorResponse struct {
@@ -72,6 +131,21 @@ func parseID(r *http.Request, param string) (int64, error) {
 	return strconv.ParseInt(idStr, 10, 64)
 }
 
+// parseOffset reads the offset query parameter of a list endpoint. paged is
+// false when it is absent, in which case the endpoint keeps returning a bare
+// array instead of a pageResponse.
+func parseOffset(r *http.Request) (offset int, paged bool, err error) {
+	o := r.URL.Query().Get("offset")
+	if o == "" {
+		return 0, false, nil
+	}
+	offset, err = strconv.Atoi(o)
+	if err != nil || offset < 0 {
+		return 0, false, errors.New("offset must be a non-negative integer")
+	}
+	return offset, true, nil
+}
+
 // System handlers
 
 // @Summary List all systems
@@ -97,6 +171,7 @@ func (s *Server) apiGetSystems(w http.ResponseWriter, r *http.Request) {
 // @Param system body createSystemRequest true "System data"
 // @Success 201 {object} domain.System
 // @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
 // @Router /systems [post]
 func (s *Server) apiCreateSystem(w http.ResponseWriter, r *http.Request) {
 	var req createSystemRequest
@@ -105,12 +180,43 @@ func (s *Server) apiCreateSystem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Slug != nil {
+		if err := s.systemService.CheckSystemSlug(r.Context(), 0, *req.Slug); err != nil {
+			s.respondSlugError(w, err)
+			return
+		}
+	}
+
 	system, err := s.systemService.CreateSystem(r.Context(), req.Name, req.Description, req.URL, req.Owner)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if req.DisplayOrder != nil {
+		system, err = s.systemService.UpdateSystemDisplayOrder(r.Context(), system.ID, *req.DisplayOrder)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Tags != nil {
+		system, err = s.systemService.UpdateSystemTags(r.Context(), system.ID, req.Tags)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Slug != nil {
+		system, err = s.systemService.UpdateSystemSlug(r.Context(), system.ID, *req.Slug)
+		if err != nil {
+			s.respondSlugError(w, err)
+			return
+		}
+	}
+
 	s.respondJSON(w, http.StatusCreated, system)
 }
 
@@ -155,15 +261,58 @@ func (s *Server) apiUpdateSystem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Slug != nil {
+		if err := s.systemService.CheckSystemSlug(r.Context(), id, *req.Slug); err != nil {
+			s.respondSlugError(w, err)
+			return
+		}
+	}
+
 	system, err := s.systemService.UpdateSystem(r.Context(), id, req.Name, req.Description, req.URL, req.Owner)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if req.DisplayOrder != nil {
+		system, err = s.systemService.UpdateSystemDisplayOrder(r.Context(), id, *req.DisplayOrder)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Tags != nil {
+		system, err = s.systemService.UpdateSystemTags(r.Context(), id, req.Tags)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Slug != nil {
+		system, err = s.systemService.UpdateSystemSlug(r.Context(), id, *req.Slug)
+		if err != nil {
+			s.respondSlugError(w, err)
+			return
+		}
+	}
+
 	s.respondJSON(w, http.StatusOK, system)
 }
 
+// respondSlugError maps slug validation errors to 400 and 409
+func (s *Server) respondSlugError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSlug):
+		s.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, application.ErrSlugTaken):
+		s.respondError(w, http.StatusConflict, err.Error())
+	default:
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
 func (s *Server) apiDeleteSystem(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -180,7 +329,7 @@ func (s *Server) apiDeleteSystem(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Update system status
-// @Description Update the status of a system (green, yellow, red)
+// @Description Update the status of a system (green, yellow, partial, red)
 // @Tags systems
 // @Accept json
 // @Produce json
@@ -211,6 +360,144 @@ func (s *Server) apiUpdateSystemStatus(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, system)
 }
 
+// @Summary Update the status of several systems
+// @Description Each item is applied in turn; failures are reported per item and do not stop the rest
+// @Tags systems
+// @Accept json
+// @Produce json
+// @Param items body []bulkStatusItem true "Status changes"
+// @Success 200 {object} bulkStatusResponse
+// @Failure 400 {object} errorResponse
+// @Router /systems/status/bulk [post]
+func (s *Server) apiBulkUpdateSystemStatus(w http.ResponseWriter, r *http.Request) {
+	s.bulkUpdateStatus(w, r, func(item bulkStatusItem) (domain.Status, error) {
+		system, err := s.systemService.UpdateSystemStatus(r.Context(), item.ID, item.Status, item.Message)
+		if err != nil {
+			return "", err
+		}
+		return system.Status, nil
+	})
+}
+
+// bulkUpdateStatus decodes a list of status changes and applies each one
+// with update, collecting per-item results
+func (s *Server) bulkUpdateStatus(w http.ResponseWriter, r *http.Request, update func(item bulkStatusItem) (domain.Status, error)) {
+	var items []bulkStatusItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(items) == 0 {
+		s.respondError(w, http.StatusBadRequest, "request must contain at least one status change")
+		return
+	}
+	if len(items) > maxBulkStatusUpdates {
+		s.respondError(w, http.StatusBadRequest, "too many status changes (max 500)")
+		return
+	}
+
+	response := bulkStatusResponse{Results: make([]bulkStatusResult, len(items))}
+	for i, item := range items {
+		result := bulkStatusResult{ID: item.ID}
+		if status, err := update(item); err != nil {
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Success = true
+			result.Status = status.String()
+			response.Succeeded++
+		}
+		response.Results[i] = result
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+// @Summary Add a system dependency
+// @Description Make a system depend on another system, so that problems upstream degrade it
+// @Tags systems
+// @Accept json
+// @Produce json
+// @Param id path int true "System ID"
+// @Param dependency body systemDependencyRequest true "Upstream system"
+// @Success 201 {object} domain.SystemDependency
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /systems/{id}/depends-on [post]
+func (s *Server) apiAddSystemDependency(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid system ID")
+		return
+	}
+
+	var req systemDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	edge, err := s.systemService.AddSystemDependency(r.Context(), id, req.SystemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSelfDependency):
+			s.respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrDependencyCycle):
+			s.respondError(w, http.StatusConflict, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			s.respondError(w, http.StatusNotFound, err.Error())
+		default:
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, edge)
+}
+
+// @Summary Remove a system dependency
+// @Tags systems
+// @Param id path int true "System ID"
+// @Param dependsOnId path int true "Upstream system ID"
+// @Success 204
+// @Router /systems/{id}/depends-on/{dependsOnId} [delete]
+func (s *Server) apiRemoveSystemDependency(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid system ID")
+		return
+	}
+	dependsOnID, err := parseID(r, "dependsOnId")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid upstream system ID")
+		return
+	}
+
+	if err := s.systemService.RemoveSystemDependency(r.Context(), id, dependsOnID); err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get the system graph
+// @Description All systems and the dependencies between them
+// @Tags systems
+// @Produce json
+// @Success 200 {object} application.SystemGraph
+// @Router /systems/graph [get]
+func (s *Server) apiGetSystemGraph(w http.ResponseWriter, r *http.Request) {
+	graph, err := s.systemService.GetSystemGraph(r.Context())
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, graph)
+}
+
 func (s *Server) apiGetSystemLogs(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -285,15 +572,86 @@ func (s *Server) apiCreateDependency(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Criticality != "" && !domain.Criticality(req.Criticality).IsValid() {
+		s.respondError(w, http.StatusBadRequest, domain.ErrInvalidCriticality.Error())
+		return
+	}
+
 	dep, err := s.depService.CreateDependency(r.Context(), systemID, req.Name, req.Description)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if req.RecordLatency != nil {
+		dep, err = s.depService.SetRecordLatency(r.Context(), dep.ID, *req.RecordLatency)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Weight != nil {
+		dep, err = s.depService.SetWeight(r.Context(), dep.ID, *req.Weight)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if req.Criticality != "" {
+		dep, err = s.depService.SetCriticality(r.Context(), dep.ID, domain.Criticality(req.Criticality))
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	s.respondJSON(w, http.StatusCreated, dep)
 }
 
+// apiCloneDependencies copies the dependency definitions of another system
+// POST /api/systems/{systemId}/dependencies/clone-from/{sourceSystemId}
+func (s *Server) apiCloneDependencies(w http.ResponseWriter, r *http.Request) {
+	targetID, err := parseID(r, "systemId")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid system ID")
+		return
+	}
+	sourceID, err := parseID(r, "sourceSystemId")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid source system ID")
+		return
+	}
+
+	for _, check := range []struct {
+		id       int64
+		notFound string
+	}{{targetID, "system not found"}, {sourceID, "source system not found"}} {
+		system, err := s.systemService.GetSystem(r.Context(), check.id)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if system == nil {
+			s.respondError(w, http.StatusNotFound, check.notFound)
+			return
+		}
+	}
+
+	result, err := s.depService.CloneDependencies(r.Context(), targetID, sourceID)
+	if errors.Is(err, application.ErrCloneIntoSameSystem) {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, result)
+}
+
 func (s *Server) apiGetDependency(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -327,12 +685,41 @@ func (s *Server) apiUpdateDependency(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Criticality != "" && !domain.Criticality(req.Criticality).IsValid() {
+		s.respondError(w, http.StatusBadRequest, domain.ErrInvalidCriticality.Error())
+		return
+	}
+
 	dep, err := s.depService.UpdateDependency(r.Context(), id, req.Name, req.Description)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if req.RecordLatency != nil {
+		dep, err = s.depService.SetRecordLatency(r.Context(), id, *req.RecordLatency)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if req.Weight != nil {
+		dep, err = s.depService.SetWeight(r.Context(), id, *req.Weight)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if req.Criticality != "" {
+		dep, err = s.depService.SetCriticality(r.Context(), id, domain.Criticality(req.Criticality))
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	s.respondJSON(w, http.StatusOK, dep)
 }
 
@@ -373,6 +760,25 @@ func (s *Server) apiUpdateDependencyStatus(w http.ResponseWriter, r *http.Reques
 	s.respondJSON(w, http.StatusOK, dep)
 }
 
+// @Summary Update the status of several dependencies
+// @Description Each item is applied in turn; failures are reported per item and do not stop the rest
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param items body []bulkStatusItem true "Status changes"
+// @Success 200 {object} bulkStatusResponse
+// @Failure 400 {object} errorResponse
+// @Router /dependencies/status/bulk [post]
+func (s *Server) apiBulkUpdateDependencyStatus(w http.ResponseWriter, r *http.Request) {
+	s.bulkUpdateStatus(w, r, func(item bulkStatusItem) (domain.Status, error) {
+		dep, err := s.depService.UpdateDependencyStatus(r.Context(), item.ID, item.Status, item.Message)
+		if err != nil {
+			return "", err
+		}
+		return dep.Status, nil
+	})
+}
+
 func (s *Server) apiSetHeartbeat(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -387,13 +793,24 @@ func (s *Server) apiSetHeartbeat(w http.ResponseWriter, r *http.Request) {
 	}
 
 	config := domain.HeartbeatConfig{
-		URL:          req.URL,
-		Interval:     req.Interval,
-		Method:       req.Method,
-		Headers:      req.Headers,
-		Body:         req.Body,
-		ExpectStatus: req.ExpectStatus,
-		ExpectBody:   req.ExpectBody,
+		CheckType:             req.CheckType,
+		URL:                   req.URL,
+		Interval:              req.Interval,
+		Method:                req.Method,
+		Headers:               req.Headers,
+		Body:                  req.Body,
+		ExpectStatus:          req.ExpectStatus,
+		ExpectBody:            req.ExpectBody,
+		ExpectBodySubstring:   req.ExpectBodySubstring,
+		MinTLSVersion:         req.MinTLSVersion,
+		Retries:               req.Retries,
+		GRPCService:           req.GRPCService,
+		CertExpiryWarningDays: req.CertExpiryWarningDays,
+		FollowRedirects:       req.FollowRedirects,
+		MaxRedirects:          req.MaxRedirects,
+		TimeoutMs:             req.TimeoutMs,
+		DNSRecordType:         req.DNSRecordType,
+		DNSExpect:             req.DNSExpect,
 	}
 
 	dep, err := s.depService.SetHeartbeatConfig(r.Context(), id, config)
@@ -490,6 +907,24 @@ func (s *Server) apiGetAllLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	offset, paged, err := parseOffset(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if paged {
+		logs, total, err := s.analyticsService.GetLogsPage(r.Context(), limit, offset)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if logs == nil {
+			logs = []*domain.StatusLog{}
+		}
+		s.respondJSON(w, http.StatusOK, pageResponse{Items: logs, Total: total, Limit: limit, Offset: offset})
+		return
+	}
+
 	logs, err := s.analyticsService.GetAllLogs(r.Context(), limit)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, err.Error())
@@ -515,28 +950,130 @@ func (s *Server) apiGetOverallAnalytics(w http.ResponseWriter, r *http.Request)
 	s.respondJSON(w, http.StatusOK, analytics)
 }
 
+// apiGetAnalyticsUptime returns uptime of every system keyed by system ID
+// GET /api/analytics/uptime?period=24h
+func (s *Server) apiGetAnalyticsUptime(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "24h"
+	}
+
+	systems, err := s.systemService.GetAllSystems(r.Context())
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	uptimes, err := s.analyticsService.GetUptimeForSystems(r.Context(), systems, period)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, uptimes)
+}
+
+// apiGetAnalyticsLeaderboard ranks systems by uptime
+// GET /api/analytics/leaderboard?period=30d&order=worst
+func (s *Server) apiGetAnalyticsLeaderboard(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "30d"
+	}
+
+	order := r.URL.Query().Get("order")
+	if order == "" {
+		order = "worst"
+	}
+	if order != "worst" && order != "best" {
+		s.respondError(w, http.StatusBadRequest, "order must be worst or best")
+		return
+	}
+
+	systems, err := s.systemService.GetAllSystems(r.Context())
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	entries, err := s.analyticsService.GetSystemLeaderboard(r.Context(), systems, period, order == "worst")
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, entries)
+}
+
 // Maintenance handlers
 
 type maintenanceRequest struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	StartTime   string  `json:"start_time"`
-	EndTime     string  `json:"end_time"`
-	SystemIDs   []int64 `json:"system_ids"`
+	Title             string  `json:"title"`
+	Description       string  `json:"description"`
+	StartTime         string  `json:"start_time"`
+	EndTime           string  `json:"end_time"`
+	SystemIDs         []int64 `json:"system_ids"`
+	NotifySubscribers bool    `json:"notify_subscribers"`
+	// Recurrence: none (default), daily, weekly or monthly, every
+	// recurrence_interval units until the optional recurrence_until
+	RecurrenceType     string `json:"recurrence_type"`
+	RecurrenceInterval int    `json:"recurrence_interval"`
+	RecurrenceUntil    string `json:"recurrence_until"`
+	// Timezone is the IANA zone the window is shown in (default UTC)
+	Timezone string `json:"timezone"`
+	// AllowOverlap skips the check for windows covering the same systems
+	AllowOverlap bool `json:"allow_overlap"`
+}
+
+// recurrence parses the recurrence fields of the request
+func (req maintenanceRequest) recurrence() (domain.Recurrence, error) {
+	rec := domain.Recurrence{
+		Type:     domain.RecurrenceType(req.RecurrenceType),
+		Interval: req.RecurrenceInterval,
+	}
+	if req.RecurrenceUntil != "" {
+		until, err := time.Parse(time.RFC3339, req.RecurrenceUntil)
+		if err != nil {
+			return rec, errors.New("invalid recurrence_until format (use RFC3339)")
+		}
+		rec.Until = &until
+	}
+	return rec, nil
 }
 
 type maintenanceResponse struct {
-	ID          int64   `json:"id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	StartTime   string  `json:"start_time"`
-	EndTime     string  `json:"end_time"`
-	SystemIDs   []int64 `json:"system_ids,omitempty"`
-	Status      string  `json:"status"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
-}
-
+	ID                int64   `json:"id"`
+	Title             string  `json:"title"`
+	Description       string  `json:"description"`
+	StartTime         string  `json:"start_time"`
+	EndTime           string  `json:"end_time"`
+	SystemIDs         []int64 `json:"system_ids,omitempty"`
+	Status            string  `json:"status"`
+	NotifySubscribers bool    `json:"notify_subscribers"`
+	Timezone          string  `json:"timezone"`
+	// ID is 0 for a computed next occurrence of a recurring window
+	RecurrenceType     string `json:"recurrence_type"`
+	RecurrenceInterval int    `json:"recurrence_interval,omitempty"`
+	RecurrenceUntil    string `json:"recurrence_until,omitempty"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+// maintenanceErrorStatus maps a failed create or update to 409 for
+// double-booked systems and 400 otherwise
+func maintenanceErrorStatus(err error) int {
+	var conflict *application.MaintenanceConflictError
+	if errors.As(err, &conflict) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary List maintenance windows
+// @Tags maintenance
+// @Produce json
+// @Success 200 {array} maintenanceResponse
+// @Router /maintenances [get]
 func (s *Server) apiGetMaintenances(w http.ResponseWriter, r *http.Request) {
 	maintenances, err := s.maintenanceService.GetAllMaintenances(r.Context())
 	if err != nil {
@@ -552,6 +1089,11 @@ func (s *Server) apiGetMaintenances(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary List active maintenance windows
+// @Tags maintenance
+// @Produce json
+// @Success 200 {array} maintenanceResponse
+// @Router /maintenances/active [get]
 func (s *Server) apiGetActiveMaintenances(w http.ResponseWriter, r *http.Request) {
 	maintenances, err := s.maintenanceService.GetActiveMaintenances(r.Context())
 	if err != nil {
@@ -567,6 +1109,12 @@ func (s *Server) apiGetActiveMaintenances(w http.ResponseWriter, r *http.Request
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary List upcoming maintenance windows
+// @Description Scheduled windows, including the next occurrence of a running recurring window
+// @Tags maintenance
+// @Produce json
+// @Success 200 {array} maintenanceResponse
+// @Router /maintenances/upcoming [get]
 func (s *Server) apiGetUpcomingMaintenances(w http.ResponseWriter, r *http.Request) {
 	maintenances, err := s.maintenanceService.GetUpcomingMaintenances(r.Context())
 	if err != nil {
@@ -582,6 +1130,16 @@ func (s *Server) apiGetUpcomingMaintenances(w http.ResponseWriter, r *http.Reque
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary Schedule a maintenance window
+// @Description Schedule a one-off or recurring window; overlapping windows on a shared system are rejected unless allow_overlap is set
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param maintenance body maintenanceRequest true "Maintenance window"
+// @Success 201 {object} maintenanceResponse
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /maintenances [post]
 func (s *Server) apiCreateMaintenance(w http.ResponseWriter, r *http.Request) {
 	var req maintenanceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -601,15 +1159,28 @@ func (s *Server) apiCreateMaintenance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := s.maintenanceService.CreateMaintenance(r.Context(), req.Title, req.Description, startTime, endTime, req.SystemIDs)
+	recurrence, err := req.recurrence()
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	m, err := s.maintenanceService.ScheduleRecurringMaintenance(r.Context(), req.Title, req.Description, startTime, endTime, req.SystemIDs, req.NotifySubscribers, recurrence, req.Timezone, req.AllowOverlap)
+	if err != nil {
+		s.respondError(w, maintenanceErrorStatus(err), err.Error())
+		return
+	}
+
 	s.respondJSON(w, http.StatusCreated, toMaintenanceResponse(m))
 }
 
+// @Summary Get a maintenance window
+// @Tags maintenance
+// @Produce json
+// @Param id path int true "Maintenance ID"
+// @Success 200 {object} maintenanceResponse
+// @Failure 404 {object} errorResponse
+// @Router /maintenances/{id} [get]
 func (s *Server) apiGetMaintenance(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -630,6 +1201,16 @@ func (s *Server) apiGetMaintenance(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, toMaintenanceResponse(m))
 }
 
+// @Summary Update a maintenance window
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param id path int true "Maintenance ID"
+// @Param maintenance body maintenanceRequest true "Maintenance window"
+// @Success 200 {object} maintenanceResponse
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Router /maintenances/{id} [put]
 func (s *Server) apiUpdateMaintenance(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -655,15 +1236,27 @@ func (s *Server) apiUpdateMaintenance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := s.maintenanceService.UpdateMaintenance(r.Context(), id, req.Title, req.Description, startTime, endTime, req.SystemIDs)
+	recurrence, err := req.recurrence()
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	m, err := s.maintenanceService.UpdateMaintenance(r.Context(), id, req.Title, req.Description, startTime, endTime, req.SystemIDs, recurrence, req.Timezone, req.AllowOverlap)
+	if err != nil {
+		s.respondError(w, maintenanceErrorStatus(err), err.Error())
+		return
+	}
+
 	s.respondJSON(w, http.StatusOK, toMaintenanceResponse(m))
 }
 
+// @Summary Delete a maintenance window
+// @Tags maintenance
+// @Param id path int true "Maintenance ID"
+// @Success 204
+// @Failure 400 {object} errorResponse
+// @Router /maintenances/{id} [delete]
 func (s *Server) apiDeleteMaintenance(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -679,6 +1272,13 @@ func (s *Server) apiDeleteMaintenance(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// @Summary Cancel a maintenance window
+// @Tags maintenance
+// @Produce json
+// @Param id path int true "Maintenance ID"
+// @Success 200 {object} maintenanceResponse
+// @Failure 400 {object} errorResponse
+// @Router /maintenances/{id}/cancel [post]
 func (s *Server) apiCancelMaintenance(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -695,33 +1295,75 @@ func (s *Server) apiCancelMaintenance(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, toMaintenanceResponse(m))
 }
 
+// toMaintenanceResponse renders times in the window's time zone, so the
+// RFC3339 offset matches it
 func toMaintenanceResponse(m *domain.Maintenance) maintenanceResponse {
-	return maintenanceResponse{
-		ID:          m.ID,
-		Title:       m.Title,
-		Description: m.Description,
-		StartTime:   m.StartTime.Format(time.RFC3339),
-		EndTime:     m.EndTime.Format(time.RFC3339),
-		SystemIDs:   m.SystemIDs,
-		Status:      string(m.Status),
-		CreatedAt:   m.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   m.UpdatedAt.Format(time.RFC3339),
+	loc := m.Location()
+	resp := maintenanceResponse{
+		ID:                m.ID,
+		Title:             m.Title,
+		Description:       m.Description,
+		StartTime:         m.StartTime.In(loc).Format(time.RFC3339),
+		EndTime:           m.EndTime.In(loc).Format(time.RFC3339),
+		SystemIDs:         m.SystemIDs,
+		Status:            string(m.Status),
+		NotifySubscribers: m.NotifySubscribers,
+		Timezone:          loc.String(),
+		RecurrenceType:    string(domain.RecurrenceNone),
+		CreatedAt:         m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         m.UpdatedAt.Format(time.RFC3339),
+	}
+	if m.Recurrence.IsRecurring() {
+		resp.RecurrenceType = string(m.Recurrence.Type)
+		resp.RecurrenceInterval = m.Recurrence.Interval
+		if m.Recurrence.Until != nil {
+			resp.RecurrenceUntil = m.Recurrence.Until.In(loc).Format(time.RFC3339)
+		}
 	}
+	return resp
 }
 
 // Incident handlers
 
+// incidentRequest opens an incident; with scheduled_for it is announced now
+// and starts at that time
 type incidentRequest struct {
-	Title     string  `json:"title"`
-	Message   string  `json:"message"`
-	Severity  string  `json:"severity"`
-	SystemIDs []int64 `json:"system_ids"`
+	Title        string                     `json:"title"`
+	Message      string                     `json:"message"`
+	Severity     string                     `json:"severity"`
+	SystemIDs    []int64                    `json:"system_ids"`
+	Components   []domain.AffectedComponent `json:"components"`
+	ScheduledFor *time.Time                 `json:"scheduled_for"`
+}
+
+type incidentComponentsRequest struct {
+	Components []domain.AffectedComponent `json:"components"`
+}
+
+type incidentImportRequest struct {
+	Title      string                        `json:"title"`
+	Message    string                        `json:"message"`
+	Severity   string                        `json:"severity"`
+	Status     string                        `json:"status"`
+	SystemIDs  []int64                       `json:"system_ids"`
+	Postmortem string                        `json:"postmortem"`
+	CreatedAt  time.Time                     `json:"created_at"`
+	ResolvedAt *time.Time                    `json:"resolved_at"`
+	Updates    []incidentImportUpdateRequest `json:"updates"`
+}
+
+type incidentImportUpdateRequest struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
 }
 
 type incidentStatusRequest struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 	By      string `json:"by"`
+	Notify  *bool  `json:"notify,omitempty"` // tell webhooks about the change (default true)
 }
 
 type incidentResolveRequest struct {
@@ -736,22 +1378,59 @@ type incidentAckRequest struct {
 type incidentUpdateRequest struct {
 	Message string `json:"message"`
 	By      string `json:"by"`
+	Notify  *bool  `json:"notify,omitempty"` // false for silent updates such as typo fixes (default true)
+}
+
+// notifyOrDefault reads an optional notify flag; updates notify unless told not to
+func notifyOrDefault(notify *bool) bool {
+	return notify == nil || *notify
 }
 
 type incidentResponse struct {
-	ID             int64   `json:"id"`
-	Title          string  `json:"title"`
-	Status         string  `json:"status"`
-	Severity       string  `json:"severity"`
-	SystemIDs      []int64 `json:"system_ids,omitempty"`
-	Message        string  `json:"message"`
-	Postmortem     string  `json:"postmortem,omitempty"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
-	ResolvedAt     *string `json:"resolved_at,omitempty"`
-	AcknowledgedAt *string `json:"acknowledged_at,omitempty"`
-	AcknowledgedBy string  `json:"acknowledged_by,omitempty"`
-	Duration       string  `json:"duration"`
+	ID             int64                      `json:"id"`
+	Title          string                     `json:"title"`
+	Status         string                     `json:"status"`
+	Severity       string                     `json:"severity"`
+	SystemIDs      []int64                    `json:"system_ids,omitempty"`
+	Components     []domain.AffectedComponent `json:"components,omitempty"`
+	Message        string                     `json:"message"`
+	Postmortem     string                     `json:"postmortem,omitempty"`
+	CreatedAt      string                     `json:"created_at"`
+	UpdatedAt      string                     `json:"updated_at"`
+	ResolvedAt     *string                    `json:"resolved_at,omitempty"`
+	ScheduledFor   *string                    `json:"scheduled_for,omitempty"`
+	AcknowledgedAt *string                    `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string                     `json:"acknowledged_by,omitempty"`
+	Duration       string                     `json:"duration"`
+}
+
+type incidentTemplateRequest struct {
+	Name             string  `json:"name"`
+	TitlePattern     string  `json:"title_pattern"`
+	DefaultSeverity  string  `json:"default_severity"`
+	DefaultMessage   string  `json:"default_message"`
+	DefaultSystemIDs []int64 `json:"default_system_ids"`
+}
+
+// incidentFromTemplateRequest overrides template defaults; omitted fields
+// keep them. Variables fill the {name} placeholders of the title pattern.
+type incidentFromTemplateRequest struct {
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Severity  string            `json:"severity"`
+	SystemIDs []int64           `json:"system_ids"`
+	Variables map[string]string `json:"variables"`
+}
+
+type incidentTemplateResponse struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	TitlePattern     string  `json:"title_pattern"`
+	DefaultSeverity  string  `json:"default_severity"`
+	DefaultMessage   string  `json:"default_message"`
+	DefaultSystemIDs []int64 `json:"default_system_ids,omitempty"`
+	CreatedAt        string  `json:"created_at"`
+	UpdatedAt        string  `json:"updated_at"`
 }
 
 type incidentUpdateResponse struct {
@@ -763,6 +1442,21 @@ type incidentUpdateResponse struct {
 	CreatedBy  string `json:"created_by"`
 }
 
+// apiGetIncidents lists incidents, optionally filtered and sorted
+// GET /api/incidents?status=investigating&severity=critical&system_id=4&sort=-created_at
+// @Summary List incidents
+// @Description Newest first by default; passing offset returns a page with the total count
+// @Tags incidents
+// @Produce json
+// @Param status query string false "Filter by status (investigating, identified, monitoring, resolved)"
+// @Param severity query string false "Filter by severity (minor, major, critical)"
+// @Param system_id query int false "Filter by affected system"
+// @Param sort query string false "Sort field, prefixed with - for descending (e.g. -created_at)"
+// @Param limit query int false "Maximum number of incidents (default 50)"
+// @Param offset query int false "Offset of the page"
+// @Success 200 {array} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents [get]
 func (s *Server) apiGetIncidents(w http.ResponseWriter, r *http.Request) {
 	limit := 50
 	if l := r.URL.Query().Get("limit"); l != "" {
@@ -771,7 +1465,93 @@ func (s *Server) apiGetIncidents(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	incidents, err := s.incidentService.GetAllIncidents(r.Context(), limit)
+	offset, paged, err := parseOffset(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := parseIncidentFilter(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var incidents []*domain.Incident
+	total := 0
+	if paged || filter != (domain.IncidentFilter{}) {
+		incidents, total, err = s.incidentService.GetIncidentsPage(r.Context(), filter, limit, offset)
+	} else {
+		incidents, err = s.incidentService.GetAllIncidents(r.Context(), limit)
+	}
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := make([]incidentResponse, len(incidents))
+	for i, inc := range incidents {
+		response[i] = toIncidentResponse(inc)
+	}
+
+	if paged {
+		s.respondJSON(w, http.StatusOK, pageResponse{Items: response, Total: total, Limit: limit, Offset: offset})
+		return
+	}
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+// parseIncidentFilter reads the status, severity, system_id and sort query
+// parameters of the incidents list
+func parseIncidentFilter(r *http.Request) (domain.IncidentFilter, error) {
+	var filter domain.IncidentFilter
+	query := r.URL.Query()
+
+	if v := query.Get("status"); v != "" {
+		status, err := domain.ParseIncidentStatus(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = status
+	}
+
+	if v := query.Get("severity"); v != "" {
+		severity, err := domain.ParseIncidentSeverity(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Severity = severity
+	}
+
+	if v := query.Get("system_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, errors.New("system_id must be a positive integer")
+		}
+		filter.SystemID = id
+	}
+
+	if v := query.Get("sort"); v != "" {
+		field, ascending, err := domain.ParseIncidentSort(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.SortBy = field
+		filter.Ascending = ascending
+	}
+
+	return filter, nil
+}
+
+// apiGetScheduledIncidents lists announced incidents that have not started yet
+// GET /api/incidents/scheduled
+// @Summary List scheduled incidents
+// @Tags incidents
+// @Produce json
+// @Success 200 {array} incidentResponse
+// @Router /incidents/scheduled [get]
+func (s *Server) apiGetScheduledIncidents(w http.ResponseWriter, r *http.Request) {
+	incidents, err := s.incidentService.GetScheduledIncidents(r.Context())
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -785,6 +1565,11 @@ func (s *Server) apiGetIncidents(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary List unresolved incidents
+// @Tags incidents
+// @Produce json
+// @Success 200 {array} incidentResponse
+// @Router /incidents/active [get]
 func (s *Server) apiGetActiveIncidents(w http.ResponseWriter, r *http.Request) {
 	incidents, err := s.incidentService.GetActiveIncidents(r.Context())
 	if err != nil {
@@ -800,6 +1585,12 @@ func (s *Server) apiGetActiveIncidents(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary List recent incidents
+// @Tags incidents
+// @Produce json
+// @Param days query int false "How many days back (default 7)"
+// @Success 200 {array} incidentResponse
+// @Router /incidents/recent [get]
 func (s *Server) apiGetRecentIncidents(w http.ResponseWriter, r *http.Request) {
 	days := 7
 	if d := r.URL.Query().Get("days"); d != "" {
@@ -822,6 +1613,35 @@ func (s *Server) apiGetRecentIncidents(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// maxIdempotencyKeyLength bounds the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
+// idempotencyScope keeps the Idempotency-Keys of different callers apart, so
+// two integrations that happen to send the same key do not collide
+func idempotencyScope(r *http.Request) string {
+	user := domain.UserFromContext(r.Context())
+	switch {
+	case user == nil:
+		return ""
+	case user.IsAPIKey:
+		return "api_key:" + strconv.FormatInt(user.APIKeyID, 10)
+	default:
+		return "user:" + user.Username
+	}
+}
+
+// @Summary Open an incident
+// @Description Open an incident now, or announce it for scheduled_for. With an Idempotency-Key header, repeating the request within the idempotency window returns the incident created by the first one with 200 instead of creating another. Keys are scoped to the calling API key or user.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Deduplicates retries of the same request"
+// @Param incident body incidentRequest true "Incident data"
+// @Success 200 {object} incidentResponse "Incident already created with this Idempotency-Key"
+// @Success 201 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse "A request with the same Idempotency-Key is still in progress"
+// @Router /incidents [post]
 func (s *Server) apiCreateIncident(w http.ResponseWriter, r *http.Request) {
 	var req incidentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -829,20 +1649,111 @@ func (s *Server) apiCreateIncident(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(key) > maxIdempotencyKeyLength {
+		s.respondError(w, http.StatusBadRequest, "Idempotency-Key is too long")
+		return
+	}
+
 	severity := domain.IncidentSeverity(req.Severity)
 	if severity == "" {
 		severity = domain.SeverityMinor
 	}
 
-	incident, err := s.incidentService.CreateIncident(r.Context(), req.Title, req.Message, severity, req.SystemIDs)
+	create := func() (*domain.Incident, error) {
+		if req.ScheduledFor != nil {
+			return s.incidentService.ScheduleIncident(r.Context(), req.Title, req.Message, severity, req.SystemIDs, req.Components, *req.ScheduledFor)
+		}
+		return s.incidentService.CreateIncidentWithComponents(r.Context(), req.Title, req.Message, severity, req.SystemIDs, req.Components)
+	}
+
+	if key == "" {
+		incident, err := create()
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.respondJSON(w, http.StatusCreated, toIncidentResponse(incident))
+		return
+	}
+
+	incident, created, err := s.incidentService.CreateIncidentIdempotent(r.Context(), idempotencyScope(r), key, create)
+	if errors.Is(err, application.ErrIdempotencyKeyInUse) {
+		s.respondError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
+	if !created {
+		s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
+		return
+	}
 	s.respondJSON(w, http.StatusCreated, toIncidentResponse(incident))
 }
 
+// @Summary Import historical incidents
+// @Description Create incidents with their original timestamps and timeline, without notifications
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param incidents body []incidentImportRequest true "Incidents"
+// @Success 201 {array} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/import [post]
+func (s *Server) apiImportIncidents(w http.ResponseWriter, r *http.Request) {
+	var req []incidentImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	imports := make([]application.ImportedIncident, len(req))
+	for i, item := range req {
+		updates := make([]application.ImportedIncidentUpdate, len(item.Updates))
+		for j, u := range item.Updates {
+			updates[j] = application.ImportedIncidentUpdate{
+				Status:    domain.IncidentStatus(u.Status),
+				Message:   u.Message,
+				CreatedAt: u.CreatedAt,
+				CreatedBy: u.CreatedBy,
+			}
+		}
+		imports[i] = application.ImportedIncident{
+			Title:      item.Title,
+			Message:    item.Message,
+			Severity:   domain.IncidentSeverity(item.Severity),
+			Status:     domain.IncidentStatus(item.Status),
+			SystemIDs:  item.SystemIDs,
+			Postmortem: item.Postmortem,
+			CreatedAt:  item.CreatedAt,
+			ResolvedAt: item.ResolvedAt,
+			Updates:    updates,
+		}
+	}
+
+	incidents, err := s.incidentService.ImportIncidents(r.Context(), imports)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := make([]incidentResponse, len(incidents))
+	for i, inc := range incidents {
+		response[i] = toIncidentResponse(inc)
+	}
+
+	s.respondJSON(w, http.StatusCreated, response)
+}
+
+// @Summary Get an incident
+// @Tags incidents
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} incidentResponse
+// @Failure 404 {object} errorResponse
+// @Router /incidents/{id} [get]
 func (s *Server) apiGetIncident(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -863,6 +1774,45 @@ func (s *Server) apiGetIncident(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
 }
 
+// apiUpdateIncidentComponents replaces the components affected by an incident
+// PUT /api/incidents/{id}/components
+// @Summary Replace the affected components of an incident
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param components body incidentComponentsRequest true "Affected components"
+// @Success 200 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/components [put]
+func (s *Server) apiUpdateIncidentComponents(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid incident ID")
+		return
+	}
+
+	var req incidentComponentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	incident, err := s.incidentService.UpdateIncidentComponents(r.Context(), id, req.Components)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
+}
+
+// @Summary Delete an incident
+// @Tags incidents
+// @Param id path int true "Incident ID"
+// @Success 204
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id} [delete]
 func (s *Server) apiDeleteIncident(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -878,6 +1828,222 @@ func (s *Server) apiDeleteIncident(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Incident template handlers
+
+// @Summary List incident templates
+// @Tags incident-templates
+// @Produce json
+// @Success 200 {array} incidentTemplateResponse
+// @Router /incident-templates [get]
+func (s *Server) apiGetIncidentTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.incidentService.GetAllTemplates(r.Context())
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := make([]incidentTemplateResponse, len(templates))
+	for i, t := range templates {
+		response[i] = toIncidentTemplateResponse(t)
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+// @Summary Create an incident template
+// @Tags incident-templates
+// @Accept json
+// @Produce json
+// @Param template body incidentTemplateRequest true "Template data"
+// @Success 201 {object} incidentTemplateResponse
+// @Failure 400 {object} errorResponse
+// @Router /incident-templates [post]
+func (s *Server) apiCreateIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	var req incidentTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	t, err := s.incidentService.CreateTemplate(r.Context(), req.Name, req.TitlePattern,
+		domain.IncidentSeverity(req.DefaultSeverity), req.DefaultMessage, req.DefaultSystemIDs)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, toIncidentTemplateResponse(t))
+}
+
+// @Summary Get an incident template
+// @Tags incident-templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} incidentTemplateResponse
+// @Failure 404 {object} errorResponse
+// @Router /incident-templates/{id} [get]
+func (s *Server) apiGetIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	t, err := s.incidentService.GetTemplate(r.Context(), id)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if t == nil {
+		s.respondError(w, http.StatusNotFound, "incident template not found")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, toIncidentTemplateResponse(t))
+}
+
+// @Summary Update an incident template
+// @Tags incident-templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param template body incidentTemplateRequest true "Template data"
+// @Success 200 {object} incidentTemplateResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /incident-templates/{id} [put]
+func (s *Server) apiUpdateIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	var req incidentTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	t, err := s.incidentService.UpdateTemplate(r.Context(), id, req.Name, req.TitlePattern,
+		domain.IncidentSeverity(req.DefaultSeverity), req.DefaultMessage, req.DefaultSystemIDs)
+	if errors.Is(err, application.ErrIncidentTemplateNotFound) {
+		s.respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, toIncidentTemplateResponse(t))
+}
+
+// @Summary Delete an incident template
+// @Tags incident-templates
+// @Param id path int true "Template ID"
+// @Success 204
+// @Failure 400 {object} errorResponse
+// @Router /incident-templates/{id} [delete]
+func (s *Server) apiDeleteIncidentTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	if err := s.incidentService.DeleteTemplate(r.Context(), id); err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiCreateIncidentFromTemplate opens an incident from a template
+// POST /api/incidents/from-template/{templateId}
+// @Summary Open an incident from a template
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Param overrides body incidentFromTemplateRequest false "Overrides and title variables"
+// @Success 201 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /incidents/from-template/{templateId} [post]
+func (s *Server) apiCreateIncidentFromTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := parseID(r, "templateId")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	// The body is optional: without one the template is used as is
+	var req incidentFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		s.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	incident, err := s.incidentService.CreateIncidentFromTemplate(r.Context(), templateID, application.IncidentOverrides{
+		Title:     req.Title,
+		Message:   req.Message,
+		Severity:  domain.IncidentSeverity(req.Severity),
+		SystemIDs: req.SystemIDs,
+		Variables: req.Variables,
+	})
+	if errors.Is(err, application.ErrIncidentTemplateNotFound) {
+		s.respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, toIncidentResponse(incident))
+}
+
+func toIncidentTemplateResponse(t *domain.IncidentTemplate) incidentTemplateResponse {
+	return incidentTemplateResponse{
+		ID:               t.ID,
+		Name:             t.Name,
+		TitlePattern:     t.TitlePattern,
+		DefaultSeverity:  string(t.DefaultSeverity),
+		DefaultMessage:   t.DefaultMessage,
+		DefaultSystemIDs: t.DefaultSystemIDs,
+		CreatedAt:        t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// requestActor attributes an incident action. The claimed "by" is kept, but
+// the authenticated user or API key is recorded alongside it so the audit
+// trail shows who actually made the change.
+func requestActor(r *http.Request, by string) string {
+	actor := domain.ActorFromContext(r.Context())
+	switch {
+	case by == "" && actor == "":
+		return "unknown"
+	case by == "":
+		return actor
+	case actor == "" || actor == by:
+		return by
+	default:
+		return by + " (via " + actor + ")"
+	}
+}
+
+// @Summary Acknowledge an incident
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param ack body incidentAckRequest false "Who acknowledged it"
+// @Success 200 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/acknowledge [post]
 func (s *Server) apiAcknowledgeIncident(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -887,9 +2053,7 @@ func (s *Server) apiAcknowledgeIncident(w http.ResponseWriter, r *http.Request)
 
 	var req incidentAckRequest
 	json.NewDecoder(r.Body).Decode(&req)
-	if req.By == "" {
-		req.By = "unknown"
-	}
+	req.By = requestActor(r, req.By)
 
 	incident, err := s.incidentService.AcknowledgeIncident(r.Context(), id, req.By)
 	if err != nil {
@@ -900,6 +2064,15 @@ func (s *Server) apiAcknowledgeIncident(w http.ResponseWriter, r *http.Request)
 	s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
 }
 
+// @Summary Change the status of an incident
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param status body incidentStatusRequest true "New status and timeline message"
+// @Success 200 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/status [post]
 func (s *Server) apiUpdateIncidentStatus(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -914,11 +2087,9 @@ func (s *Server) apiUpdateIncidentStatus(w http.ResponseWriter, r *http.Request)
 	}
 
 	status := domain.IncidentStatus(req.Status)
-	if req.By == "" {
-		req.By = "unknown"
-	}
+	req.By = requestActor(r, req.By)
 
-	incident, err := s.incidentService.UpdateIncidentStatus(r.Context(), id, status, req.Message, req.By)
+	incident, err := s.incidentService.UpdateIncidentStatus(r.Context(), id, status, req.Message, req.By, notifyOrDefault(req.Notify))
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -927,6 +2098,15 @@ func (s *Server) apiUpdateIncidentStatus(w http.ResponseWriter, r *http.Request)
 	s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
 }
 
+// @Summary Resolve an incident
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param resolve body incidentResolveRequest false "Optional postmortem"
+// @Success 200 {object} incidentResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/resolve [post]
 func (s *Server) apiResolveIncident(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -936,9 +2116,7 @@ func (s *Server) apiResolveIncident(w http.ResponseWriter, r *http.Request) {
 
 	var req incidentResolveRequest
 	json.NewDecoder(r.Body).Decode(&req)
-	if req.By == "" {
-		req.By = "unknown"
-	}
+	req.By = requestActor(r, req.By)
 
 	incident, err := s.incidentService.ResolveIncident(r.Context(), id, req.Postmortem, req.By)
 	if err != nil {
@@ -949,6 +2127,13 @@ func (s *Server) apiResolveIncident(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, toIncidentResponse(incident))
 }
 
+// @Summary List the timeline of an incident
+// @Tags incidents
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {array} incidentUpdateResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/updates [get]
 func (s *Server) apiGetIncidentUpdates(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -977,6 +2162,15 @@ func (s *Server) apiGetIncidentUpdates(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, response)
 }
 
+// @Summary Add a timeline update to an incident
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param update body incidentUpdateRequest true "Update message"
+// @Success 201 {object} incidentUpdateResponse
+// @Failure 400 {object} errorResponse
+// @Router /incidents/{id}/updates [post]
 func (s *Server) apiAddIncidentUpdate(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
 	if err != nil {
@@ -990,11 +2184,9 @@ func (s *Server) apiAddIncidentUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.By == "" {
-		req.By = "unknown"
-	}
+	req.By = requestActor(r, req.By)
 
-	update, err := s.incidentService.AddIncidentUpdate(r.Context(), id, req.Message, req.By)
+	update, err := s.incidentService.AddIncidentUpdate(r.Context(), id, req.Message, req.By, notifyOrDefault(req.Notify))
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -1017,6 +2209,7 @@ func toIncidentResponse(i *domain.Incident) incidentResponse {
 		Status:         string(i.Status),
 		Severity:       string(i.Severity),
 		SystemIDs:      i.SystemIDs,
+		Components:     i.Components,
 		Message:        i.Message,
 		Postmortem:     i.Postmortem,
 		CreatedAt:      i.CreatedAt.Format(time.RFC3339),
@@ -1029,6 +2222,10 @@ func toIncidentResponse(i *domain.Incident) incidentResponse {
 		t := i.ResolvedAt.Format(time.RFC3339)
 		resp.ResolvedAt = &t
 	}
+	if i.ScheduledFor != nil {
+		t := i.ScheduledFor.Format(time.RFC3339)
+		resp.ScheduledFor = &t
+	}
 	if i.AcknowledgedAt != nil {
 		t := i.AcknowledgedAt.Format(time.RFC3339)
 		resp.AcknowledgedAt = &t
@@ -1068,7 +2265,9 @@ func formatDuration(d time.Duration) string {
 // @Produce json
 // @Param id path int true "Dependency ID"
 // @Param period query string false "Time period (1h, 6h, 24h, 7d, 30d, 90d)"
+// @Param interval query string false "Chart bucket size in whole minutes, e.g. 15m or 1h (default depends on period)"
 // @Success 200 {object} domain.LatencyStats
+// @Failure 400 {object} errorResponse
 // @Router /dependencies/{id}/latency [get]
 func (s *Server) apiGetDependencyLatency(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(r, "id")
@@ -1082,12 +2281,25 @@ func (s *Server) apiGetDependencyLatency(w http.ResponseWriter, r *http.Request)
 		period = "24h"
 	}
 
+	var interval time.Duration
+	if v := r.URL.Query().Get("interval"); v != "" {
+		interval, err = time.ParseDuration(v)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "invalid interval")
+			return
+		}
+	}
+
 	if s.latencyService == nil {
 		s.respondError(w, http.StatusServiceUnavailable, "latency service not available")
 		return
 	}
 
-	stats, err := s.latencyService.GetDependencyLatencyStats(r.Context(), id, period)
+	stats, err := s.latencyService.GetDependencyLatencyStats(r.Context(), id, period, interval)
+	if errors.Is(err, application.ErrInvalidLatencyInterval) {
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -3,11 +3,15 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"status-incident/internal/application"
 	"status-incident/internal/domain"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,6 +39,15 @@ func (m *MockSystemRepository) GetByID(ctx context.Context, id int64) (*domain.S
 	return m.Systems[id], nil
 }
 
+func (m *MockSystemRepository) GetBySlug(ctx context.Context, slug string) (*domain.System, error) {
+	for _, s := range m.Systems {
+		if slug != "" && s.Slug == slug {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *MockSystemRepository) GetAll(ctx context.Context) ([]*domain.System, error) {
 	var result []*domain.System
 	for _, s := range m.Systems {
@@ -86,6 +99,21 @@ func (m *MockStatusLogRepository) GetAll(ctx context.Context, limit int) ([]*dom
 	return m.Logs, nil
 }
 
+func (m *MockStatusLogRepository) GetPage(ctx context.Context, limit, offset int) ([]*domain.StatusLog, error) {
+	if offset >= len(m.Logs) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(m.Logs) {
+		end = len(m.Logs)
+	}
+	return m.Logs[offset:end], nil
+}
+
+func (m *MockStatusLogRepository) Count(ctx context.Context) (int, error) {
+	return len(m.Logs), nil
+}
+
 func (m *MockStatusLogRepository) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.StatusLog, error) {
 	return m.Logs, nil
 }
@@ -98,6 +126,17 @@ func (m *MockStatusLogRepository) GetDependencyLogsByTimeRange(ctx context.Conte
 	return nil, nil
 }
 
+func (m *MockStatusLogRepository) Cleanup(ctx context.Context, olderThan time.Time) error {
+	kept := m.Logs[:0]
+	for _, log := range m.Logs {
+		if !log.CreatedAt.Before(olderThan) {
+			kept = append(kept, log)
+		}
+	}
+	m.Logs = kept
+	return nil
+}
+
 // MockDependencyRepository for testing
 type MockDependencyRepository struct {
 	Dependencies map[int64]*domain.Dependency
@@ -156,13 +195,18 @@ func (m *MockDependencyRepository) GetAllWithHeartbeat(ctx context.Context) ([]*
 }
 
 // MockAnalyticsRepository for testing
-type MockAnalyticsRepository struct{}
+type MockAnalyticsRepository struct {
+	SystemAnalytics *domain.Analytics
+}
 
 func NewMockAnalyticsRepository() *MockAnalyticsRepository {
 	return &MockAnalyticsRepository{}
 }
 
 func (m *MockAnalyticsRepository) GetUptimeBySystemID(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+	if m.SystemAnalytics != nil {
+		return m.SystemAnalytics, nil
+	}
 	return &domain.Analytics{
 		UptimePercent:       99.9,
 		AvailabilityPercent: 99.95,
@@ -193,6 +237,10 @@ func (m *MockAnalyticsRepository) GetIncidentsByDependencyID(ctx context.Context
 	return []domain.IncidentPeriod{}, nil
 }
 
+func (m *MockAnalyticsRepository) RollupDailyUptime(ctx context.Context, day time.Time) error {
+	return nil
+}
+
 // Helper to create test server
 func setupTestServer() (*Server, *MockSystemRepository, *MockDependencyRepository) {
 	systemRepo := NewMockSystemRepository()
@@ -275,6 +323,31 @@ func TestAPICreateSystem(t *testing.T) {
 	}
 }
 
+func TestAPICreateSystem_Slug(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	create := func(name, slug string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(createSystemRequest{Name: name, Slug: &slug})
+		req := httptest.NewRequest("POST", "/api/systems", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		server.apiCreateSystem(w, req)
+		return w
+	}
+
+	if w := create("Checkout", "checkout"); w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if w := create("Shop", "checkout"); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a used slug, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := create("Search", "not/a slug"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid slug, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(systemRepo.Systems) != 1 {
+		t.Errorf("expected rejected slugs not to create systems, got %d systems", len(systemRepo.Systems))
+	}
+}
+
 func TestAPICreateSystem_InvalidBody(t *testing.T) {
 	server, _, _ := setupTestServer()
 
@@ -500,6 +573,214 @@ func TestAPIUpdateSystemStatus_InvalidStatus(t *testing.T) {
 	}
 }
 
+// mockSystemDependencyRepository keeps system graph edges in memory
+type mockSystemDependencyRepository struct {
+	edges []*domain.SystemDependency
+}
+
+func (m *mockSystemDependencyRepository) Create(ctx context.Context, dep *domain.SystemDependency) error {
+	dep.ID = int64(len(m.edges) + 1)
+	m.edges = append(m.edges, dep)
+	return nil
+}
+
+func (m *mockSystemDependencyRepository) GetAll(ctx context.Context) ([]*domain.SystemDependency, error) {
+	return m.edges, nil
+}
+
+func (m *mockSystemDependencyRepository) GetBySystemID(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	var result []*domain.SystemDependency
+	for _, e := range m.edges {
+		if e.SystemID == systemID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSystemDependencyRepository) GetDependents(ctx context.Context, systemID int64) ([]*domain.SystemDependency, error) {
+	var result []*domain.SystemDependency
+	for _, e := range m.edges {
+		if e.DependsOnID == systemID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSystemDependencyRepository) Delete(ctx context.Context, systemID, dependsOnID int64) error {
+	for i, e := range m.edges {
+		if e.SystemID == systemID && e.DependsOnID == dependsOnID {
+			m.edges = append(m.edges[:i], m.edges[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestAPIAddSystemDependency(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.systemService.SetSystemDependencyRepo(&mockSystemDependencyRepository{})
+
+	for _, name := range []string{"Auth", "API"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		systemRepo.Create(context.Background(), system)
+	}
+
+	tests := []struct {
+		name         string
+		systemID     string
+		dependsOnID  int64
+		expectedCode int
+	}{
+		{"API depends on Auth", "2", 1, http.StatusCreated},
+		{"cycle", "1", 2, http.StatusConflict},
+		{"self", "1", 1, http.StatusBadRequest},
+		{"unknown system", "2", 99, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(systemDependencyRequest{SystemID: tt.dependsOnID})
+			req := httptest.NewRequest("POST", "/api/systems/"+tt.systemID+"/depends-on", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.systemID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			server.apiAddSystemDependency(w, req)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest("GET", "/api/systems/graph", nil)
+	w := httptest.NewRecorder()
+	server.apiGetSystemGraph(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var graph application.SystemGraph
+	if err := json.Unmarshal(w.Body.Bytes(), &graph); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(graph.Systems) != 2 || len(graph.Edges) != 1 {
+		t.Fatalf("expected 2 systems and 1 edge, got %d and %d", len(graph.Systems), len(graph.Edges))
+	}
+	if graph.Edges[0].SystemID != 2 || graph.Edges[0].DependsOnID != 1 {
+		t.Errorf("expected edge 2 -> 1, got %d -> %d", graph.Edges[0].SystemID, graph.Edges[0].DependsOnID)
+	}
+}
+
+func TestAPIBulkUpdateSystemStatus(t *testing.T) {
+	systemRepo := NewMockSystemRepository()
+	logRepo := NewMockStatusLogRepository()
+	server := NewServer(
+		application.NewSystemService(systemRepo, logRepo),
+		application.NewDependencyService(NewMockDependencyRepository(), logRepo),
+		nil,
+		application.NewAnalyticsService(NewMockAnalyticsRepository(), logRepo),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		t.TempDir(),
+	)
+
+	for _, name := range []string{"API", "Auth"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		systemRepo.Create(context.Background(), system)
+	}
+
+	body, _ := json.Marshal([]bulkStatusItem{
+		{ID: 1, Status: "red", Message: "Outage"},
+		{ID: 2, Status: "purple", Message: "Typo"},
+		{ID: 99, Status: "red", Message: "Unknown system"},
+		{ID: 2, Status: "yellow", Message: "Degraded"},
+	})
+	req := httptest.NewRequest("POST", "/api/systems/status/bulk", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp bulkStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Succeeded != 2 || resp.Failed != 2 {
+		t.Errorf("expected 2 succeeded and 2 failed, got %d and %d", resp.Succeeded, resp.Failed)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(resp.Results))
+	}
+
+	expected := []bool{true, false, false, true}
+	for i, result := range resp.Results {
+		if result.Success != expected[i] {
+			t.Errorf("result %d: expected success %v, got %+v", i, expected[i], result)
+		}
+		if !result.Success && result.Error == "" {
+			t.Errorf("result %d: expected an error message", i)
+		}
+	}
+	if !strings.Contains(resp.Results[1].Error, "invalid status") {
+		t.Errorf("expected invalid status error, got %q", resp.Results[1].Error)
+	}
+
+	if systemRepo.Systems[1].Status != domain.StatusRed || systemRepo.Systems[2].Status != domain.StatusYellow {
+		t.Errorf("expected systems red and yellow, got %q and %q", systemRepo.Systems[1].Status, systemRepo.Systems[2].Status)
+	}
+	if len(logRepo.Logs) != 2 {
+		t.Errorf("expected 2 status logs, got %d", len(logRepo.Logs))
+	}
+}
+
+func TestAPIBulkUpdateDependencyStatus(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	depRepo.Create(context.Background(), &domain.Dependency{SystemID: system.ID, Name: "PostgreSQL", Status: domain.StatusGreen})
+
+	body, _ := json.Marshal([]bulkStatusItem{
+		{ID: 1, Status: "red", Message: "Connection lost"},
+		{ID: 1, Status: "", Message: "Missing status"},
+	})
+	req := httptest.NewRequest("POST", "/api/dependencies/status/bulk", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	server.apiBulkUpdateDependencyStatus(w, req)
+
+	var resp bulkStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Errorf("expected 1 succeeded and 1 failed, got %d and %d", resp.Succeeded, resp.Failed)
+	}
+	if resp.Results[0].Status != "red" || depRepo.Dependencies[1].Status != domain.StatusRed {
+		t.Errorf("expected dependency to be red, got %+v", resp.Results[0])
+	}
+
+	for _, body := range []string{"[]", "{\"id\": 1}"} {
+		req := httptest.NewRequest("POST", "/api/dependencies/status/bulk", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.apiBulkUpdateDependencyStatus(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", body, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
 // ============= Dependency API Tests =============
 
 func TestAPIGetDependencies(t *testing.T) {
@@ -538,6 +819,82 @@ func TestAPIGetDependencies(t *testing.T) {
 	}
 }
 
+func TestAPISetHeartbeat_Timeout(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("Test System", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	dep := &domain.Dependency{SystemID: system.ID, Name: "Reports", Status: domain.StatusGreen}
+	depRepo.Create(context.Background(), dep)
+
+	setHeartbeat := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/dependencies/1/heartbeat", strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		server.apiSetHeartbeat(w, req)
+		return w
+	}
+
+	w := setHeartbeat(`{"url": "https://reports.example.com/health", "interval": 60, "timeout_ms": 20000}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	stored, _ := depRepo.GetByID(context.Background(), dep.ID)
+	if stored.HeartbeatTimeoutMs != 20000 {
+		t.Errorf("expected timeout 20000 ms, got %d", stored.HeartbeatTimeoutMs)
+	}
+
+	w = setHeartbeat(`{"url": "https://reports.example.com/health", "interval": 60, "timeout_ms": 120000}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an out of range timeout, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPISetHeartbeat_RedactsHeaders(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("Test System", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	dep := &domain.Dependency{SystemID: system.ID, Name: "Billing", Status: domain.StatusGreen}
+	depRepo.Create(context.Background(), dep)
+
+	body := `{"url": "https://billing.example.com/health", "interval": 60, "method": "HEAD",
+		"headers": {"Authorization": "Bearer secret", "Accept": "text/plain"}}`
+	req := httptest.NewRequest("POST", "/api/dependencies/1/heartbeat", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	server.apiSetHeartbeat(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "Bearer secret") {
+		t.Errorf("expected Authorization value to be redacted, got %s", w.Body.String())
+	}
+
+	var resp domain.Dependency
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.HeartbeatMethod != "HEAD" {
+		t.Errorf("expected method HEAD, got %q", resp.HeartbeatMethod)
+	}
+	if resp.HeartbeatHeaders["Authorization"] != domain.RedactedHeaderValue || resp.HeartbeatHeaders["Accept"] != "text/plain" {
+		t.Errorf("unexpected headers in response %v", resp.HeartbeatHeaders)
+	}
+
+	stored, _ := depRepo.GetByID(context.Background(), dep.ID)
+	if stored.HeartbeatHeaders["Authorization"] != "Bearer secret" {
+		t.Errorf("expected stored header to keep its value, got %q", stored.HeartbeatHeaders["Authorization"])
+	}
+}
+
 func TestAPICreateDependency(t *testing.T) {
 	server, systemRepo, _ := setupTestServer()
 
@@ -574,6 +931,50 @@ func TestAPICreateDependency(t *testing.T) {
 	}
 }
 
+func TestAPICreateDependency_Criticality(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	system, _ := domain.NewSystem("Test System", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	tests := []struct {
+		criticality  string
+		expectedCode int
+	}{
+		{"minor", http.StatusCreated},
+		{"optional", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		body, _ := json.Marshal(createDependencyRequest{Name: "Analytics", Criticality: tt.criticality})
+
+		req := httptest.NewRequest("POST", "/api/systems/1/dependencies", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("systemId", "1")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		server.apiCreateDependency(w, req)
+
+		if w.Code != tt.expectedCode {
+			t.Fatalf("criticality %q: expected status %d, got %d: %s", tt.criticality, tt.expectedCode, w.Code, w.Body.String())
+		}
+		if w.Code != http.StatusCreated {
+			continue
+		}
+
+		var dep domain.Dependency
+		if err := json.Unmarshal(w.Body.Bytes(), &dep); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if dep.Criticality != domain.CriticalityMinor {
+			t.Errorf("expected criticality minor, got %q", dep.Criticality)
+		}
+	}
+}
+
 func TestAPIGetDependency(t *testing.T) {
 	server, systemRepo, depRepo := setupTestServer()
 
@@ -677,6 +1078,82 @@ func TestAPIGetSystemAnalytics(t *testing.T) {
 	}
 }
 
+func TestAPIGetAnalyticsLeaderboard(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	system, _ := domain.NewSystem("Test System", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	req := httptest.NewRequest("GET", "/api/analytics/leaderboard?period=30d&order=worst", nil)
+	w := httptest.NewRecorder()
+
+	server.apiGetAnalyticsLeaderboard(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var entries []application.LeaderboardEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(entries) != 1 || entries[0].SystemName != "Test System" || entries[0].TotalIncidents != 1 {
+		t.Errorf("unexpected leaderboard: %+v", entries)
+	}
+}
+
+func TestAPIGetAnalyticsUptime(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	for _, name := range []string{"API", "Database", "Queue"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		systemRepo.Create(context.Background(), system)
+	}
+
+	req := httptest.NewRequest("GET", "/api/analytics/uptime?period=24h", nil)
+	w := httptest.NewRecorder()
+
+	server.apiGetAnalyticsUptime(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var uptimes map[int64]application.SystemUptime
+	if err := json.Unmarshal(w.Body.Bytes(), &uptimes); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(uptimes) != 3 {
+		t.Fatalf("expected 3 systems, got %d", len(uptimes))
+	}
+	for id, system := range systemRepo.Systems {
+		uptime, ok := uptimes[id]
+		if !ok {
+			t.Errorf("system %d missing from response", id)
+			continue
+		}
+		if uptime.SystemName != system.Name {
+			t.Errorf("system %d: expected name %q, got %q", id, system.Name, uptime.SystemName)
+		}
+		if uptime.UptimePercent != 99.9 || uptime.AvailabilityPercent != 99.95 {
+			t.Errorf("system %d: unexpected uptime %+v", id, uptime)
+		}
+	}
+}
+
+func TestAPIGetAnalyticsLeaderboard_InvalidOrder(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/analytics/leaderboard?order=sideways", nil)
+	w := httptest.NewRecorder()
+
+	server.apiGetAnalyticsLeaderboard(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 // ============= Helper Function Tests =============
 
 func TestFormatDuration(t *testing.T) {
@@ -690,6 +1167,9 @@ func TestFormatDuration(t *testing.T) {
 		{2 * time.Hour, "2h"},
 		{25 * time.Hour, "1d 1h"},
 		{48 * time.Hour, "2d"},
+		{12 * time.Minute, "12m"},
+		{14*time.Hour + 45*time.Minute, "14h 45m"},
+		{15 * 24 * time.Hour, "15d"},
 	}
 
 	for _, tt := range tests {
@@ -756,28 +1236,575 @@ func TestAPIGetAllLogs(t *testing.T) {
 	}
 }
 
-func TestAPIGetSystemLogs(t *testing.T) {
-	server, systemRepo, _ := setupTestServer()
+func TestAPIGetAllLogs_Paged(t *testing.T) {
+	server, _, _ := setupTestServer()
+	logRepo := NewMockStatusLogRepository()
+	server.analyticsService = application.NewAnalyticsService(NewMockAnalyticsRepository(), logRepo)
 
-	system, _ := domain.NewSystem("Test System", "", "", "")
-	systemRepo.Create(context.Background(), system)
+	systemID := int64(1)
+	for i := 0; i < 5; i++ {
+		logRepo.Create(context.Background(), domain.NewStatusLog(&systemID, nil, domain.StatusGreen, domain.StatusYellow, "Log", domain.SourceManual))
+	}
 
-	req := httptest.NewRequest("GET", "/api/systems/1/logs?limit=50", nil)
-	w := httptest.NewRecorder()
+	tests := []struct {
+		query       string
+		expectedIDs []int64
+	}{
+		{"?limit=2&offset=0", []int64{1, 2}},
+		{"?limit=2&offset=4", []int64{5}},
+		{"?limit=2&offset=9", []int64{}},
+	}
 
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "1")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/logs"+tt.query, nil)
+		w := httptest.NewRecorder()
 
-	server.apiGetSystemLogs(w, req)
+		server.apiGetAllLogs(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
-	}
-}
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", tt.query, http.StatusOK, w.Code)
+		}
 
-func TestAPIGetDependencyLogs(t *testing.T) {
-	server, systemRepo, depRepo := setupTestServer()
+		var page struct {
+			Items  []domain.StatusLog `json:"items"`
+			Total  int                `json:"total"`
+			Limit  int                `json:"limit"`
+			Offset int                `json:"offset"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("%s: failed to unmarshal response: %v", tt.query, err)
+		}
+		if page.Total != 5 || page.Limit != 2 {
+			t.Errorf("%s: expected total 5 and limit 2, got %d and %d", tt.query, page.Total, page.Limit)
+		}
+		if page.Items == nil {
+			t.Errorf("%s: expected items to be an array", tt.query)
+		}
+		if len(page.Items) != len(tt.expectedIDs) {
+			t.Fatalf("%s: expected %d items, got %d", tt.query, len(tt.expectedIDs), len(page.Items))
+		}
+		for i, id := range tt.expectedIDs {
+			if page.Items[i].ID != id {
+				t.Errorf("%s: expected item %d to be log %d, got %d", tt.query, i, id, page.Items[i].ID)
+			}
+		}
+	}
+
+	// Without an offset the response stays a bare array
+	req := httptest.NewRequest("GET", "/api/logs?limit=2", nil)
+	w := httptest.NewRecorder()
+	server.apiGetAllLogs(w, req)
+
+	var logs []domain.StatusLog
+	if err := json.Unmarshal(w.Body.Bytes(), &logs); err != nil {
+		t.Fatalf("expected a bare array without offset: %v", err)
+	}
+
+	req = httptest.NewRequest("GET", "/api/logs?offset=-1", nil)
+	w = httptest.NewRecorder()
+	server.apiGetAllLogs(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a negative offset, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPIGetIncidents_Paged(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		if _, err := server.incidentService.CreateIncident(context.Background(), title, "Message", domain.SeverityMinor, nil); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/incidents?limit=2&offset=1", nil)
+	w := httptest.NewRecorder()
+	server.apiGetIncidents(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var page struct {
+		Items  []incidentResponse `json:"items"`
+		Total  int                `json:"total"`
+		Offset int                `json:"offset"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if page.Total != 3 || page.Offset != 1 {
+		t.Errorf("expected total 3 and offset 1, got %d and %d", page.Total, page.Offset)
+	}
+	if len(page.Items) != 2 || page.Items[0].Title != "Second" || page.Items[1].Title != "Third" {
+		t.Errorf("expected Second and Third, got %+v", page.Items)
+	}
+
+	// Without an offset the response stays a bare array
+	req = httptest.NewRequest("GET", "/api/incidents?limit=2", nil)
+	w = httptest.NewRecorder()
+	server.apiGetIncidents(w, req)
+
+	var incidents []incidentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &incidents); err != nil {
+		t.Fatalf("expected a bare array without offset: %v", err)
+	}
+	if len(incidents) != 3 {
+		t.Errorf("expected the mock repository's 3 incidents, got %d", len(incidents))
+	}
+}
+
+func TestAPIGetIncidents_Filters(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	ctx := context.Background()
+
+	server.incidentService.CreateIncident(ctx, "DB down", "Message", domain.SeverityCritical, []int64{4})
+	server.incidentService.CreateIncident(ctx, "Slow API", "Message", domain.SeverityMinor, []int64{4})
+	server.incidentService.CreateIncident(ctx, "Auth errors", "Message", domain.SeverityCritical, []int64{2})
+
+	req := httptest.NewRequest("GET", "/api/incidents?status=investigating&severity=critical&system_id=4&sort=-created_at", nil)
+	w := httptest.NewRecorder()
+	server.apiGetIncidents(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Filters without an offset keep the bare array response
+	var incidents []incidentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &incidents); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(incidents) != 1 || incidents[0].Title != "DB down" {
+		t.Errorf("expected only 'DB down', got %+v", incidents)
+	}
+
+	for _, query := range []string{"status=closed", "severity=high", "system_id=abc", "system_id=0", "sort=title"} {
+		req := httptest.NewRequest("GET", "/api/incidents?"+query, nil)
+		w := httptest.NewRecorder()
+		server.apiGetIncidents(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+		var errResp errorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil || errResp.Error == "" {
+			t.Errorf("%s: expected an error message, got %s", query, w.Body.String())
+		}
+	}
+}
+
+// stubIncidentTemplateRepository keeps incident templates in memory
+type stubIncidentTemplateRepository struct {
+	templates []*domain.IncidentTemplate
+}
+
+func (s *stubIncidentTemplateRepository) Create(ctx context.Context, t *domain.IncidentTemplate) error {
+	t.ID = int64(len(s.templates) + 1)
+	s.templates = append(s.templates, t)
+	return nil
+}
+
+func (s *stubIncidentTemplateRepository) GetByID(ctx context.Context, id int64) (*domain.IncidentTemplate, error) {
+	for _, t := range s.templates {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubIncidentTemplateRepository) GetAll(ctx context.Context) ([]*domain.IncidentTemplate, error) {
+	return s.templates, nil
+}
+
+func (s *stubIncidentTemplateRepository) Update(ctx context.Context, t *domain.IncidentTemplate) error {
+	return nil
+}
+
+func (s *stubIncidentTemplateRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func setupIncidentTemplateServer() *Server {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.incidentService.SetTemplateRepository(&stubIncidentTemplateRepository{})
+	return server
+}
+
+func TestAPICreateIncidentTemplate(t *testing.T) {
+	server := setupIncidentTemplateServer()
+
+	body := `{"name":"DB failover","title_pattern":"Database failover on {cluster}","default_severity":"major","default_message":"Failing over.","default_system_ids":[3]}`
+	req := httptest.NewRequest("POST", "/api/incident-templates", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.apiCreateIncidentTemplate(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var resp incidentTemplateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.ID == 0 || resp.DefaultSeverity != "major" || resp.TitlePattern != "Database failover on {cluster}" {
+		t.Errorf("unexpected template response: %+v", resp)
+	}
+
+	body = `{"name":"Broken","title_pattern":"Title","default_severity":"catastrophic"}`
+	req = httptest.NewRequest("POST", "/api/incident-templates", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	server.apiCreateIncidentTemplate(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid severity, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPICreateIncidentFromTemplate(t *testing.T) {
+	server := setupIncidentTemplateServer()
+	ctx := context.Background()
+	tmpl, err := server.incidentService.CreateTemplate(ctx, "DB failover", "Database failover on {cluster}", domain.SeverityMajor, "Failing over.", []int64{3})
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+
+	post := func(templateID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/incidents/from-template/"+templateID, strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("templateId", templateID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		server.apiCreateIncidentFromTemplate(w, req)
+		return w
+	}
+
+	id := strconv.FormatInt(tmpl.ID, 10)
+
+	w := post(id, `{"variables":{"cluster":"eu-1"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var incident incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &incident)
+	if incident.Title != "Database failover on eu-1" || incident.Severity != "major" || incident.Message != "Failing over." {
+		t.Errorf("expected template defaults, got %+v", incident)
+	}
+	if len(incident.SystemIDs) != 1 || incident.SystemIDs[0] != 3 {
+		t.Errorf("expected default systems [3], got %v", incident.SystemIDs)
+	}
+
+	w = post(id, `{"title":"Emergency failover","severity":"critical","system_ids":[5,6]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	incident = incidentResponse{}
+	json.Unmarshal(w.Body.Bytes(), &incident)
+	if incident.Title != "Emergency failover" || incident.Severity != "critical" || incident.Message != "Failing over." || len(incident.SystemIDs) != 2 {
+		t.Errorf("expected overrides to apply, got %+v", incident)
+	}
+
+	// The body is optional
+	if w := post(id, ""); w.Code != http.StatusCreated {
+		t.Errorf("expected status %d without a body, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	if w := post(id, `{"severity":"apocalyptic"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid severity override, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := post("999", `{}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown template, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := post("abc", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid template ID, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPIIncidentComponents(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+
+	body := `{"title":"Checkout errors","message":"Payments failing","severity":"major","components":[{"name":"Checkout"},{"name":"Search","dependency_id":4}]}`
+	req := httptest.NewRequest("POST", "/api/incidents", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.apiCreateIncident(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if len(created.Components) != 2 || created.Components[1].DependencyID == nil || *created.Components[1].DependencyID != 4 {
+		t.Fatalf("expected Checkout and Search (dependency 4), got %+v", created.Components)
+	}
+
+	put := func(body string) *httptest.ResponseRecorder {
+		id := strconv.FormatInt(created.ID, 10)
+		req := httptest.NewRequest("PUT", "/api/incidents/"+id+"/components", strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		server.apiUpdateIncidentComponents(w, req)
+		return w
+	}
+
+	w = put(`{"components":[{"name":"Search"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var updated incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &updated)
+	if len(updated.Components) != 1 || updated.Components[0].Name != "Search" {
+		t.Errorf("expected components [Search], got %+v", updated.Components)
+	}
+
+	if w := put(`{"components":[{"name":""}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an unnamed component, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := put(`not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid body, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPIScheduledIncidents(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+
+	at := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	body := `{"title":"Database upgrade","message":"Read-only mode","scheduled_for":"` + at.Format(time.RFC3339) + `"}`
+	req := httptest.NewRequest("POST", "/api/incidents", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.apiCreateIncident(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if created.Status != "scheduled" || created.ScheduledFor == nil || *created.ScheduledFor != at.Format(time.RFC3339) {
+		t.Errorf("expected a scheduled incident at %s, got %+v", at.Format(time.RFC3339), created)
+	}
+
+	req = httptest.NewRequest("GET", "/api/incidents/scheduled", nil)
+	w = httptest.NewRecorder()
+	server.apiGetScheduledIncidents(w, req)
+
+	var scheduled []incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &scheduled)
+	if len(scheduled) != 1 || scheduled[0].ID != created.ID {
+		t.Errorf("expected the incident among scheduled ones, got %+v", scheduled)
+	}
+
+	req = httptest.NewRequest("GET", "/api/incidents/active", nil)
+	w = httptest.NewRecorder()
+	server.apiGetActiveIncidents(w, req)
+
+	var active []incidentResponse
+	json.Unmarshal(w.Body.Bytes(), &active)
+	if len(active) != 0 {
+		t.Errorf("expected no active incidents, got %+v", active)
+	}
+
+	body = `{"title":"Too late","message":"Already past","scheduled_for":"2020-01-01T00:00:00Z"}`
+	req = httptest.NewRequest("POST", "/api/incidents", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	server.apiCreateIncident(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a past start time, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAPICreateIncident_IdempotencyKey(t *testing.T) {
+	server, _, _ := setupTestServer()
+	incidentRepo := &mockIncidentRepository{}
+	server.incidentService = application.NewIncidentService(incidentRepo)
+
+	post := func(key string) (int, incidentResponse) {
+		req := httptest.NewRequest("POST", "/api/incidents", strings.NewReader(`{"title":"Checkout down","message":"Alert fired","severity":"major"}`))
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		server.apiCreateIncident(w, req)
+		var resp incidentResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	code, first := post("alert-1")
+	if code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, code)
+	}
+
+	code, dup := post("alert-1")
+	if code != http.StatusOK {
+		t.Errorf("expected status %d for a duplicate key, got %d", http.StatusOK, code)
+	}
+	if dup.ID != first.ID {
+		t.Errorf("expected duplicate key to return incident %d, got %d", first.ID, dup.ID)
+	}
+
+	code, other := post("alert-2")
+	if code != http.StatusCreated || other.ID == first.ID {
+		t.Errorf("expected a distinct key to create a new incident, got status %d and incident %d", code, other.ID)
+	}
+
+	// Without a key every request creates an incident
+	post("")
+	post("")
+	if len(incidentRepo.incidents) != 4 {
+		t.Errorf("expected 4 incidents, got %d", len(incidentRepo.incidents))
+	}
+
+	if code, _ := post(strings.Repeat("k", maxIdempotencyKeyLength+1)); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an overlong key, got %d", http.StatusBadRequest, code)
+	}
+}
+
+func TestAPICreateIncident_IdempotencyKeyScopedPerAPIKey(t *testing.T) {
+	server, _, _ := setupTestServer()
+	incidentRepo := &mockIncidentRepository{}
+	server.incidentService = application.NewIncidentService(incidentRepo)
+
+	post := func(user *domain.User) (int, incidentResponse) {
+		req := httptest.NewRequest("POST", "/api/incidents", strings.NewReader(`{"title":"Checkout down","message":"Alert fired","severity":"major"}`))
+		req.Header.Set("Idempotency-Key", "alert-1")
+		req = req.WithContext(domain.ContextWithUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		server.apiCreateIncident(w, req)
+		var resp incidentResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	pagerDuty := &domain.User{Username: "pagerduty", IsAPIKey: true, APIKeyID: 1}
+	grafana := &domain.User{Username: "grafana", IsAPIKey: true, APIKeyID: 2}
+
+	code, first := post(pagerDuty)
+	if code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, code)
+	}
+	if code, other := post(grafana); code != http.StatusCreated || other.ID == first.ID {
+		t.Errorf("expected another API key with the same key string to create its own incident, got status %d and incident %d", code, other.ID)
+	}
+	if code, dup := post(pagerDuty); code != http.StatusOK || dup.ID != first.ID {
+		t.Errorf("expected the same API key to get incident %d back, got status %d and incident %d", first.ID, code, dup.ID)
+	}
+}
+
+func TestAPIGetSystemLogs(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	system, _ := domain.NewSystem("Test System", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	req := httptest.NewRequest("GET", "/api/systems/1/logs?limit=50", nil)
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	server.apiGetSystemLogs(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestAPIExportLogsCSV(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	system, _ := domain.NewSystem("Payments", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	if _, err := server.systemService.UpdateSystemStatus(context.Background(), system.ID, "red", "Card processor down, retrying"); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/logs/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	server.apiExportLogsCSV(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("expected text/csv content type, got %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment;") {
+		t.Errorf("expected attachment disposition, got %q", cd)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+	if got := strings.Join(records[0], ","); got != "timestamp,system,dependency,old_status,new_status,source,message" {
+		t.Errorf("unexpected header row %q", got)
+	}
+	row := records[1]
+	if _, err := time.Parse(time.RFC3339, row[0]); err != nil {
+		t.Errorf("expected RFC3339 timestamp, got %q", row[0])
+	}
+	if want := []string{"Payments", "", "green", "red", "manual", "Card processor down, retrying"}; strings.Join(row[1:], "|") != strings.Join(want, "|") {
+		t.Errorf("expected row %q, got %q", want, row[1:])
+	}
+}
+
+func TestAPIExportSystemLogsCSV_TimeRange(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+
+	system, _ := domain.NewSystem("Payments", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	server.systemService.UpdateSystemStatus(context.Background(), system.ID, "red", "down")
+
+	from := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	req := httptest.NewRequest("GET", "/api/systems/1/logs/export?from="+from, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	server.apiExportSystemLogsCSV(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("expected only the header row, got %d records", len(records))
+	}
+}
+
+func TestAPIExportLogsCSV_InvalidParams(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	for _, query := range []string{"format=xlsx", "from=yesterday", "to=2024-13-01"} {
+		req := httptest.NewRequest("GET", "/api/logs/export?"+query, nil)
+		w := httptest.NewRecorder()
+
+		server.apiExportLogsCSV(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestAPIGetDependencyLogs(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
 
 	system, _ := domain.NewSystem("Test System", "", "", "")
 	systemRepo.Create(context.Background(), system)
@@ -931,6 +1958,23 @@ func (m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (m *MockWebhookRepository) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) (int, error) {
+	w, ok := m.Webhooks[d.WebhookID]
+	if !ok {
+		return 0, nil
+	}
+	at := d.CreatedAt
+	w.LastDeliveryAt = &at
+	w.LastDeliveryStatus = d.Status
+	w.LastDeliveryCode = d.StatusCode
+	if d.Succeeded() {
+		w.ConsecutiveFailures = 0
+	} else {
+		w.ConsecutiveFailures++
+	}
+	return w.ConsecutiveFailures, nil
+}
+
 func TestWebhookHandlers_ListWebhooks(t *testing.T) {
 	webhookRepo := NewMockWebhookRepository()
 	handlers := NewWebhookHandlers(webhookRepo, nil)
@@ -960,49 +2004,188 @@ func TestWebhookHandlers_ListWebhooks(t *testing.T) {
 	}
 }
 
-func TestWebhookHandlers_CreateWebhook(t *testing.T) {
+func TestWebhookHandlers_CreateWebhook(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	reqBody := webhookRequest{
+		Name:   "New Webhook",
+		URL:    "https://example.com/webhook",
+		Type:   "slack",
+		Events: []string{"status_change"},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handlers.CreateWebhook(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var response webhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Name != "New Webhook" {
+		t.Errorf("expected name 'New Webhook', got '%s'", response.Name)
+	}
+}
+
+func TestWebhookHandlers_CreateWebhook_InvalidBody(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader([]byte("invalid")))
+	w := httptest.NewRecorder()
+
+	handlers.CreateWebhook(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestWebhookHandlers_CreateWebhook_UnknownEvent(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	body, _ := json.Marshal(webhookRequest{
+		Name:   "Incidents",
+		URL:    "https://example.com/webhook",
+		Type:   "slack",
+		Events: []string{"incident_start", "incident_opened"},
+	})
+
+	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handlers.CreateWebhook(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "incident_opened") {
+		t.Errorf("expected error to name the unknown event, got %s", w.Body.String())
+	}
+}
+
+func TestWebhookHandlers_CreateWebhook_SecretNotExposed(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	body := []byte(`{"name":"Signed","url":"https://example.com/webhook","type":"generic","secret":"topsecret"}`)
+	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handlers.CreateWebhook(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "topsecret") {
+		t.Errorf("response must not contain the secret: %s", w.Body.String())
+	}
+
+	var response webhookResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if !response.HasSecret {
+		t.Error("expected has_secret to be true")
+	}
+	if webhookRepo.Webhooks[response.ID].Secret != "topsecret" {
+		t.Error("expected secret to be stored")
+	}
+}
+
+func TestWebhookHandlers_PayloadTemplate(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	body, _ := json.Marshal(map[string]string{
+		"name":             "Custom",
+		"url":              "https://example.com/webhook",
+		"type":             "generic",
+		"payload_template": `{"text": {{json .Message}}`,
+	})
+	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handlers.CreateWebhook(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response webhookResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.PayloadTemplate != `{"text": {{json .Message}}` {
+		t.Errorf("expected payload_template to be returned, got %q", response.PayloadTemplate)
+	}
+
+	// A template that does not parse is rejected on create and update
+	body, _ = json.Marshal(map[string]string{
+		"name":             "Broken",
+		"url":              "https://example.com/webhook",
+		"type":             "generic",
+		"payload_template": `{"text": {{.Message}`,
+	})
+	req = httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handlers.CreateWebhook(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d on create, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "invalid payload template") {
+		t.Errorf("expected template error, got %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("PUT", "/api/webhooks/1", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", strconv.FormatInt(response.ID, 10))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w = httptest.NewRecorder()
+	handlers.UpdateWebhook(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d on update, got %d", http.StatusBadRequest, w.Code)
+	}
+	if webhookRepo.Webhooks[response.ID].PayloadTemplate != `{"text": {{json .Message}}` {
+		t.Error("expected rejected update to keep the stored template")
+	}
+}
+
+func TestWebhookHandlers_CreateWebhook_Filters(t *testing.T) {
 	webhookRepo := NewMockWebhookRepository()
 	handlers := NewWebhookHandlers(webhookRepo, nil)
 
-	reqBody := webhookRequest{
-		Name:   "New Webhook",
-		URL:    "https://example.com/webhook",
-		Type:   "slack",
-		Events: []string{"status_change"},
-	}
-	body, _ := json.Marshal(reqBody)
-
+	body := []byte(`{"name":"Outages","url":"https://example.com/webhook","type":"generic","min_severity":"major","trigger_statuses":["red"]}`)
 	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	handlers.CreateWebhook(w, req)
 
 	if w.Code != http.StatusCreated {
-		t.Errorf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
 	}
 
 	var response webhookResponse
-	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
-		t.Fatalf("failed to unmarshal response: %v", err)
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.MinSeverity != "major" {
+		t.Errorf("expected min_severity major, got %q", response.MinSeverity)
 	}
-
-	if response.Name != "New Webhook" {
-		t.Errorf("expected name 'New Webhook', got '%s'", response.Name)
+	if len(response.TriggerStatuses) != 1 || response.TriggerStatuses[0] != "red" {
+		t.Errorf("expected trigger_statuses [red], got %v", response.TriggerStatuses)
 	}
-}
-
-func TestWebhookHandlers_CreateWebhook_InvalidBody(t *testing.T) {
-	webhookRepo := NewMockWebhookRepository()
-	handlers := NewWebhookHandlers(webhookRepo, nil)
 
-	req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader([]byte("invalid")))
-	w := httptest.NewRecorder()
+	body = []byte(`{"name":"Outages","url":"https://example.com/webhook","type":"generic","trigger_statuses":["purple"]}`)
+	req = httptest.NewRequest("POST", "/api/webhooks", bytes.NewReader(body))
+	w = httptest.NewRecorder()
 
 	handlers.CreateWebhook(w, req)
 
 	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		t.Errorf("expected status %d for unknown status, got %d", http.StatusBadRequest, w.Code)
 	}
 }
 
@@ -1045,6 +2228,153 @@ func TestWebhookHandlers_GetWebhook_NotFound(t *testing.T) {
 	}
 }
 
+// stubWebhookDeliveryRepository serves a fixed delivery log
+type stubWebhookDeliveryRepository struct {
+	deliveries []*domain.WebhookDelivery
+}
+
+func (s *stubWebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	s.deliveries = append([]*domain.WebhookDelivery{d}, s.deliveries...)
+	return nil
+}
+
+func (s *stubWebhookDeliveryRepository) GetByWebhook(ctx context.Context, webhookID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	var result []*domain.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.WebhookID == webhookID && len(result) < limit {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func TestWebhookHandlers_GetWebhookDeliveries(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	webhook, _ := domain.NewWebhook("Ops Slack", "https://hooks.slack.com/x", domain.WebhookTypeSlack)
+	webhookRepo.Create(context.Background(), webhook)
+
+	deliveryRepo := &stubWebhookDeliveryRepository{}
+	deliveryRepo.Create(context.Background(), domain.NewWebhookDelivery(webhook.ID, domain.EventIncidentStart, 200, 1, nil))
+	deliveryRepo.Create(context.Background(), domain.NewWebhookDelivery(webhook.ID, domain.EventStatusChange, 410, 1, errors.New("returned status 410")))
+	handlers.SetDeliveryRepository(deliveryRepo)
+
+	get := func(id, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/webhooks/"+id+"/deliveries"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handlers.GetWebhookDeliveries(w, req)
+		return w
+	}
+
+	w := get("1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var deliveries []webhookDeliveryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &deliveries); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", len(deliveries))
+	}
+	latest := deliveries[0]
+	if latest.Event != "status_change" || latest.Status != "failure" || latest.StatusCode != 410 || latest.Error != "returned status 410" {
+		t.Errorf("unexpected latest delivery: %+v", latest)
+	}
+
+	w = get("1", "?limit=1")
+	deliveries = nil
+	json.Unmarshal(w.Body.Bytes(), &deliveries)
+	if len(deliveries) != 1 {
+		t.Errorf("expected limit to cap deliveries at 1, got %d", len(deliveries))
+	}
+
+	if w := get("999", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown webhook, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestWebhookHandlers_GetWebhook_DeliveryStatus(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+
+	webhook, _ := domain.NewWebhook("Ops Slack", "https://hooks.slack.com/x", domain.WebhookTypeSlack)
+	webhookRepo.Create(context.Background(), webhook)
+	webhookRepo.RecordDelivery(context.Background(), domain.NewWebhookDelivery(webhook.ID, domain.EventStatusChange, 500, 3, errors.New("returned status 500")))
+
+	req := httptest.NewRequest("GET", "/api/webhooks/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handlers.GetWebhook(w, req)
+
+	var resp webhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.LastDeliveryStatus != "failure" || resp.LastDeliveryCode != 500 || resp.ConsecutiveFailures != 1 || resp.LastDeliveryAt == "" {
+		t.Errorf("unexpected delivery status in response: %+v", resp)
+	}
+}
+
+func TestWebhookHandlers_TestWebhook_SLABreach(t *testing.T) {
+	bodies := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhookRepo := NewMockWebhookRepository()
+	notifications := application.NewNotificationService(webhookRepo, NewMockSystemRepository(), NewMockDependencyRepository())
+	handlers := NewWebhookHandlers(webhookRepo, notifications)
+
+	// Not subscribed to SLA breaches; tests are sent regardless
+	webhook, _ := domain.NewWebhook("Alerts", server.URL, domain.WebhookTypeGeneric)
+	webhookRepo.Create(context.Background(), webhook)
+
+	testWebhook := func(id, event string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/webhooks/"+id+"/test?event="+event, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handlers.TestWebhook(w, req)
+		return w
+	}
+
+	w := testWebhook(strconv.FormatInt(webhook.ID, 10), "sla_breach")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	select {
+	case body := <-bodies:
+		if body["event"] != string(domain.EventSLABreach) {
+			t.Errorf("expected sla_breach event, got %v", body["event"])
+		}
+		if body["breach_type"] == nil || body["sla_target"] == nil || body["actual_value"] == nil {
+			t.Errorf("expected SLA breach fields in payload, got %v", body)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a test SLA breach to be delivered")
+	}
+
+	if w := testWebhook("999", "sla_breach"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown webhook, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := testWebhook(strconv.FormatInt(webhook.ID, 10), "incident_start"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for unsupported event, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestWebhookHandlers_DeleteWebhook(t *testing.T) {
 	webhookRepo := NewMockWebhookRepository()
 	handlers := NewWebhookHandlers(webhookRepo, nil)
@@ -1215,6 +2545,60 @@ func TestToMaintenanceResponse(t *testing.T) {
 	}
 }
 
+func TestMaintenanceRecurrenceFields(t *testing.T) {
+	req := maintenanceRequest{RecurrenceType: "weekly", RecurrenceInterval: 2, RecurrenceUntil: "2024-06-30T00:00:00Z"}
+	rec, err := req.recurrence()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Type != domain.RecurrenceWeekly || rec.Interval != 2 || rec.Until == nil {
+		t.Errorf("unexpected recurrence %+v", rec)
+	}
+
+	if _, err := (maintenanceRequest{RecurrenceType: "weekly", RecurrenceUntil: "next month"}).recurrence(); err == nil {
+		t.Error("expected error for invalid recurrence_until")
+	}
+
+	now := time.Now()
+	m := &domain.Maintenance{ID: 1, Title: "Weekly", StartTime: now, EndTime: now.Add(time.Hour), Recurrence: rec}
+	response := toMaintenanceResponse(m)
+	if response.RecurrenceType != "weekly" || response.RecurrenceInterval != 2 || response.RecurrenceUntil != "2024-06-30T00:00:00Z" {
+		t.Errorf("unexpected recurrence fields %+v", response)
+	}
+
+	response = toMaintenanceResponse(&domain.Maintenance{ID: 2, Title: "One-off"})
+	if response.RecurrenceType != "none" || response.RecurrenceInterval != 0 || response.RecurrenceUntil != "" {
+		t.Errorf("expected none recurrence for one-off window, got %+v", response)
+	}
+}
+
+func TestMaintenanceTimezoneDisplay(t *testing.T) {
+	start := time.Date(2024, 7, 14, 23, 30, 0, 0, time.UTC)
+	m := &domain.Maintenance{
+		ID:        1,
+		Title:     "Night upgrade",
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		Status:    domain.MaintenanceScheduled,
+		Timezone:  "Europe/Riga",
+	}
+
+	info := newMaintenanceInfo(m)
+	if info.StartTime != "Jul 15, 02:30 EEST" || info.EndTime != "Jul 15, 04:30 EEST" {
+		t.Errorf("expected Riga local times, got %q - %q", info.StartTime, info.EndTime)
+	}
+
+	response := toMaintenanceResponse(m)
+	if response.StartTime != "2024-07-15T02:30:00+03:00" || response.Timezone != "Europe/Riga" {
+		t.Errorf("expected RFC3339 in Riga offset, got %q (%q)", response.StartTime, response.Timezone)
+	}
+
+	m.Timezone = ""
+	if info := newMaintenanceInfo(m); info.StartTime != "Jul 14, 23:30 UTC" {
+		t.Errorf("expected UTC without a timezone, got %q", info.StartTime)
+	}
+}
+
 func TestToIncidentResponse(t *testing.T) {
 	now := time.Now()
 	resolvedAt := now.Add(time.Hour)
@@ -1259,3 +2643,335 @@ func TestToIncidentResponse(t *testing.T) {
 		t.Error("expected acknowledged_at to be set")
 	}
 }
+
+func TestWebhookHandlers_SubscribeToSystem(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	systemRepo := NewMockSystemRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+	handlers.SetSystemService(application.NewSystemService(systemRepo, NewMockStatusLogRepository()))
+
+	system, _ := domain.NewSystem("Payments", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	body, _ := json.Marshal(subscribeRequest{URL: "https://downstream.example.com/hook", Type: "slack"})
+	req := httptest.NewRequest("POST", "/api/systems/1/subscribe", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handlers.SubscribeToSystem(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var response webhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.ID == 0 {
+		t.Error("expected webhook ID in response")
+	}
+
+	webhook := webhookRepo.Webhooks[response.ID]
+	if webhook == nil {
+		t.Fatal("expected webhook to be stored")
+	}
+	if !webhook.Enabled {
+		t.Error("expected webhook to be enabled")
+	}
+	if len(webhook.SystemIDs) != 1 || webhook.SystemIDs[0] != system.ID {
+		t.Errorf("expected webhook scoped to system %d, got %v", system.ID, webhook.SystemIDs)
+	}
+	if webhook.Type != domain.WebhookTypeSlack {
+		t.Errorf("expected type slack, got %s", webhook.Type)
+	}
+}
+
+func TestWebhookHandlers_SubscribeToSystem_NotFound(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	handlers := NewWebhookHandlers(webhookRepo, nil)
+	handlers.SetSystemService(application.NewSystemService(NewMockSystemRepository(), NewMockStatusLogRepository()))
+
+	body, _ := json.Marshal(subscribeRequest{URL: "https://downstream.example.com/hook"})
+	req := httptest.NewRequest("POST", "/api/systems/99/subscribe", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "99")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handlers.SubscribeToSystem(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if len(webhookRepo.Webhooks) != 0 {
+		t.Error("expected no webhook to be created")
+	}
+}
+
+// stubAPIKeyRepository resolves a fixed set of keys for auth tests
+type stubAPIKeyRepository struct {
+	keys map[string]*domain.APIKey
+}
+
+func (m *stubAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error { return nil }
+func (m *stubAPIKeyRepository) GetByKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	return m.keys[key], nil
+}
+func (m *stubAPIKeyRepository) GetAll(ctx context.Context) ([]*domain.APIKey, error) { return nil, nil }
+func (m *stubAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error { return nil }
+func (m *stubAPIKeyRepository) Delete(ctx context.Context, id int64) error           { return nil }
+func (m *stubAPIKeyRepository) UpdateLastUsed(ctx context.Context, id int64) error   { return nil }
+
+func TestAPIUpdateSystemStatus_RecordsAPIKeyActor(t *testing.T) {
+	systemRepo := NewMockSystemRepository()
+	logRepo := NewMockStatusLogRepository()
+	server := &Server{
+		router:        chi.NewRouter(),
+		systemService: application.NewSystemService(systemRepo, logRepo),
+	}
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	keys := &stubAPIKeyRepository{keys: map[string]*domain.APIKey{
+		"sk_deploy": {ID: 7, Name: "deploy-pipeline", Scopes: []string{"write"}, Enabled: true},
+	}}
+	auth := NewAuthMiddleware(true, "admin", "secret", keys)
+
+	router := chi.NewRouter()
+	router.With(auth.RequireAPIAuth).Post("/api/systems/{id}/status", server.apiUpdateSystemStatus)
+
+	body, _ := json.Marshal(map[string]string{"status": "red", "message": "Rolling back"})
+	req := httptest.NewRequest("POST", "/api/systems/1/status", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", "sk_deploy")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(logRepo.Logs) != 1 {
+		t.Fatalf("expected 1 status log, got %d", len(logRepo.Logs))
+	}
+	if logRepo.Logs[0].Actor != "deploy-pipeline" {
+		t.Errorf("expected actor %q, got %q", "deploy-pipeline", logRepo.Logs[0].Actor)
+	}
+	if logRepo.Logs[0].Source != domain.SourceManual {
+		t.Errorf("expected source %q, got %q", domain.SourceManual, logRepo.Logs[0].Source)
+	}
+}
+
+func TestRequestActor(t *testing.T) {
+	tests := []struct {
+		name string
+		user *domain.User
+		by   string
+		want string
+	}{
+		{"anonymous without by", nil, "", "unknown"},
+		{"anonymous with by", nil, "alice", "alice"},
+		{"api key without by", &domain.User{Username: "ci", IsAPIKey: true}, "", "ci"},
+		{"api key with by", &domain.User{Username: "ci", IsAPIKey: true}, "alice", "alice (via ci)"},
+		{"same user", &domain.User{Username: "admin"}, "admin", "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			if tt.user != nil {
+				req = req.WithContext(domain.ContextWithUser(req.Context(), tt.user))
+			}
+			if got := requestActor(req, tt.by); got != tt.want {
+				t.Errorf("requestActor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPICloneDependencies(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	for _, name := range []string{"Source", "Target"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		systemRepo.Create(context.Background(), system)
+	}
+	dep, _ := domain.NewDependency(1, "Database", "")
+	depRepo.Create(context.Background(), dep)
+
+	tests := []struct {
+		name       string
+		target     string
+		source     string
+		expectCode int
+	}{
+		{"clones into target", "2", "1", http.StatusCreated},
+		{"unknown target", "99", "1", http.StatusNotFound},
+		{"unknown source", "2", "99", http.StatusNotFound},
+		{"same system", "1", "1", http.StatusBadRequest},
+		{"invalid source", "2", "abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/systems/"+tt.target+"/dependencies/clone-from/"+tt.source, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("systemId", tt.target)
+			rctx.URLParams.Add("sourceSystemId", tt.source)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			server.apiCloneDependencies(w, req)
+
+			if w.Code != tt.expectCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// The first case created the copy; cloning again skips it by name
+	req := httptest.NewRequest("POST", "/api/systems/2/dependencies/clone-from/1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("systemId", "2")
+	rctx.URLParams.Add("sourceSystemId", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	server.apiCloneDependencies(w, req)
+
+	var result application.CloneResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Skipped) != 1 || result.Skipped[0] != "Database" {
+		t.Errorf("expected Database skipped on the second clone, got %+v", result)
+	}
+}
+
+func TestAPIImportAll(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+	// Documents without maintenance windows never reach the maintenance repository
+	server.configService = application.NewConfigService(systemRepo, depRepo, NewMockWebhookRepository(), nil)
+
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		expectCode int
+	}{
+		{"invalid JSON", "", `{`, http.StatusBadRequest},
+		{"unknown mode", "?mode=overwrite", `{"systems": []}`, http.StatusBadRequest},
+		{"dangling system reference", "", `{"systems": [{"id": 1, "name": "API"}], "dependencies": [{"system_id": 7, "name": "Redis"}]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/import"+tt.query, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			server.apiImportAll(w, req)
+
+			if w.Code != tt.expectCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectCode, w.Code, w.Body.String())
+			}
+		})
+	}
+	if len(systemRepo.Systems) != 0 {
+		t.Fatalf("expected failed imports to write nothing, got %d systems", len(systemRepo.Systems))
+	}
+
+	// Version 1.0 exports still import, including their logs
+	body := `{
+		"version": "1.0",
+		"systems": [{"id": 5, "name": "API", "status": "red"}],
+		"dependencies": [{"id": 8, "system_id": 5, "name": "DB", "heartbeat_url": "https://db.example.com/health", "heartbeat_interval": 60}],
+		"logs": [
+			{"system_id": 5, "old_status": "green", "new_status": "red", "source": "manual"},
+			{"dependency_id": 8, "old_status": "green", "new_status": "yellow", "source": "heartbeat"},
+			{"system_id": 99, "old_status": "green", "new_status": "red", "source": "manual"}
+		]
+	}`
+	req := httptest.NewRequest("POST", "/api/import", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.apiImportAll(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var result struct {
+		SystemsCreated      int `json:"systems_created"`
+		DependenciesCreated int `json:"dependencies_created"`
+		LogsImported        int `json:"logs_imported"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if result.SystemsCreated != 1 || result.DependenciesCreated != 1 || result.LogsImported != 2 {
+		t.Errorf("expected 1 system, 1 dependency and 2 logs, got %+v", result)
+	}
+
+	dep := depRepo.Dependencies[1]
+	if dep == nil || dep.SystemID != 1 || dep.HeartbeatURL != "https://db.example.com/health" || dep.HeartbeatInterval != 60 {
+		t.Errorf("expected DB with its heartbeat on the new system, got %+v", dep)
+	}
+	logs, _ := server.analyticsService.GetAllLogs(context.Background(), 10)
+	if len(logs) != 2 || *logs[0].SystemID != 1 || *logs[1].DependencyID != dep.ID {
+		t.Errorf("expected logs remapped to the new IDs, got %+v", logs)
+	}
+}
+
+func TestAPIExportImport_RequireAdminScope(t *testing.T) {
+	systemRepo := NewMockSystemRepository()
+	depRepo := NewMockDependencyRepository()
+	logRepo := NewMockStatusLogRepository()
+	keys := &stubAPIKeyRepository{keys: map[string]*domain.APIKey{
+		"sk_read":  {ID: 1, Name: "dashboard", Scopes: []string{domain.ScopeRead}, Enabled: true},
+		"sk_write": {ID: 2, Name: "deploy", Scopes: []string{domain.ScopeWrite}, Enabled: true},
+		"sk_admin": {ID: 3, Name: "ops", Scopes: []string{domain.ScopeAdmin}, Enabled: true},
+	}}
+	server := NewServer(
+		application.NewSystemService(systemRepo, logRepo),
+		application.NewDependencyService(depRepo, logRepo),
+		nil,
+		application.NewAnalyticsService(NewMockAnalyticsRepository(), logRepo),
+		nil, nil, nil, nil, nil, nil, nil, nil,
+		NewAuthMiddleware(true, "admin", "secret", keys),
+		nil,
+		t.TempDir(),
+	)
+	server.SetConfigService(application.NewConfigService(systemRepo, depRepo, NewMockWebhookRepository(), nil))
+
+	tests := []struct {
+		name   string
+		key    string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"read key cannot export", "sk_read", "GET", "/api/export", "", http.StatusForbidden},
+		{"write key cannot export", "sk_write", "GET", "/api/export", "", http.StatusForbidden},
+		{"read key cannot import", "sk_read", "POST", "/api/import", `{"systems": []}`, http.StatusForbidden},
+		{"write key cannot import", "sk_write", "POST", "/api/import?mode=replace", `{"systems": []}`, http.StatusForbidden},
+		{"admin key can import", "sk_admin", "POST", "/api/import", `{"systems": []}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", tt.key)
+			w := httptest.NewRecorder()
+
+			server.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
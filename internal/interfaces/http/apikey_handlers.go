@@ -22,6 +22,7 @@ func NewAPIKeyHandlers(repo domain.APIKeyRepository) *APIKeyHandlers {
 
 type createAPIKeyRequest struct {
 	Name      string   `json:"name"`
+	Role      string   `json:"role,omitempty"` // shorthand for a single scope
 	Scopes    []string `json:"scopes"`
 	ExpiresIn *int     `json:"expires_in_days,omitempty"`
 }
@@ -74,8 +75,17 @@ func (h *APIKeyHandlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Role != "" {
+		req.Scopes = []string{req.Role}
+	}
 	if len(req.Scopes) == 0 {
-		req.Scopes = []string{"read"}
+		req.Scopes = []string{domain.ScopeRead}
+	}
+	for _, scope := range req.Scopes {
+		if !domain.IsValidScope(scope) {
+			writeError(w, http.StatusBadRequest, "invalid scope: "+scope+" (want read, write or admin)")
+			return
+		}
 	}
 
 	// Generate new key
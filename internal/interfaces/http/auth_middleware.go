@@ -1,29 +1,51 @@
 package http
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
 	"net/http"
+	"net/url"
 	"status-incident/internal/domain"
 	"strings"
+	"time"
 )
 
+// sessionCookieName is the cookie holding the admin UI session token
+const sessionCookieName = "session"
+
 // AuthMiddleware provides authentication middleware
 type AuthMiddleware struct {
 	enabled    bool
 	username   string
 	password   string
 	apiKeyRepo domain.APIKeyRepository
+	sessions   *sessionSigner
 }
 
-// NewAuthMiddleware creates a new auth middleware
+// NewAuthMiddleware creates a new auth middleware.
+// Sessions are signed with a random secret until SetSession is called,
+// so they don't survive a restart.
 func NewAuthMiddleware(enabled bool, username, password string, apiKeyRepo domain.APIKeyRepository) *AuthMiddleware {
+	secret := make([]byte, 32)
+	rand.Read(secret)
 	return &AuthMiddleware{
 		enabled:    enabled,
 		username:   username,
 		password:   password,
 		apiKeyRepo: apiKeyRepo,
+		sessions:   newSessionSigner(secret, DefaultSessionTTL),
+	}
+}
+
+// SetSession configures the secret signing admin UI session tokens and how long they last.
+// An empty secret keeps the random one.
+func (m *AuthMiddleware) SetSession(secret string, ttl time.Duration) {
+	key := m.sessions.secret
+	if secret != "" {
+		key = []byte(secret)
 	}
+	m.sessions = newSessionSigner(key, ttl)
 }
 
 // IsEnabled returns whether authentication is enabled
@@ -70,16 +92,17 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 		}
 
 		// Check session cookie for web UI
-		if cookie, err := r.Cookie("session"); err == nil && cookie.Value != "" {
-			if user := m.validateSession(cookie.Value); user != nil {
-				ctx := domain.ContextWithUser(r.Context(), user)
-				next.ServeHTTP(w, r.WithContext(ctx))
-				return
-			}
+		if user := m.sessionUser(r); user != nil {
+			ctx := domain.ContextWithUser(r.Context(), user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
 		}
 
-		// Return 401 with WWW-Authenticate header for browsers
-		w.Header().Set("WWW-Authenticate", `Basic realm="Status Incident Admin"`)
+		// Send browsers to the login form
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			http.Redirect(w, r, "/login?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+			return
+		}
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 	})
 }
@@ -125,12 +148,54 @@ func (m *AuthMiddleware) RequireAPIAuth(next http.Handler) http.Handler {
 			}
 		}
 
+		// Session cookie, for requests made by the admin UI
+		if user := m.sessionUser(r); user != nil {
+			ctx := domain.ContextWithUser(r.Context(), user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusUnauthorized)
 		w.Write([]byte(`{"error":"Authentication required"}`))
 	})
 }
 
+// RequireScope middleware rejects API requests whose user lacks scope.
+// Must run after RequireAPIAuth; basic auth and session users hold ScopeAdmin.
+func (m *AuthMiddleware) RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user := domain.UserFromContext(r.Context())
+			if user == nil || !user.HasScope(scope) {
+				writeError(w, http.StatusForbidden, "API key lacks required scope: "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireMethodScope middleware lets read-only keys through for safe methods
+// (GET, HEAD, OPTIONS) and requires ScopeWrite for everything else
+func (m *AuthMiddleware) RequireMethodScope(next http.Handler) http.Handler {
+	read := m.RequireScope(domain.ScopeRead)(next)
+	write := m.RequireScope(domain.ScopeWrite)(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			read.ServeHTTP(w, r)
+		default:
+			write.ServeHTTP(w, r)
+		}
+	})
+}
+
 // validateBasicAuth validates basic auth credentials
 func (m *AuthMiddleware) validateBasicAuth(authHeader string) *domain.User {
 	encoded := strings.TrimPrefix(authHeader, "Basic ")
@@ -154,7 +219,7 @@ func (m *AuthMiddleware) validateBasicAuth(authHeader string) *domain.User {
 		return &domain.User{
 			Username: username,
 			IsAPIKey: false,
-			Scopes:   []string{"admin"},
+			Scopes:   []string{domain.ScopeAdmin},
 		}
 	}
 
@@ -187,33 +252,32 @@ func (m *AuthMiddleware) validateAPIKey(r *http.Request, key string) *domain.Use
 	}
 }
 
-// validateSession validates a session token (simple implementation)
+// sessionUser returns the admin user if r carries a valid session cookie
+func (m *AuthMiddleware) sessionUser(r *http.Request) *domain.User {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	return m.validateSession(cookie.Value)
+}
+
+// validateSession verifies a signed session token issued by LoginHandler
 func (m *AuthMiddleware) validateSession(sessionToken string) *domain.User {
-	// For simplicity, session token is just base64(username:password)
-	// In production, use proper session management
-	decoded, err := base64.StdEncoding.DecodeString(sessionToken)
+	username, err := m.sessions.Verify(sessionToken)
 	if err != nil {
 		return nil
 	}
 
-	parts := strings.SplitN(string(decoded), ":", 2)
-	if len(parts) != 2 {
+	// Tokens issued to a previous admin username are no longer valid
+	if subtle.ConstantTimeCompare([]byte(username), []byte(m.username)) != 1 {
 		return nil
 	}
 
-	username, password := parts[0], parts[1]
-	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(m.username)) == 1
-	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(m.password)) == 1
-
-	if usernameMatch && passwordMatch {
-		return &domain.User{
-			Username: username,
-			IsAPIKey: false,
-			Scopes:   []string{"admin"},
-		}
+	return &domain.User{
+		Username: username,
+		IsAPIKey: false,
+		Scopes:   []string{domain.ScopeAdmin},
 	}
-
-	return nil
 }
 
 // LoginHandler handles login form submission
@@ -236,23 +300,22 @@ func (m *AuthMiddleware) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(m.password)) == 1
 
 	if usernameMatch && passwordMatch {
-		// Set session cookie
-		sessionToken := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		token, expiresAt, err := m.sessions.Issue(username)
+		if err != nil {
+			m.renderLoginPage(w, "Failed to start session")
+			return
+		}
 		http.SetCookie(w, &http.Cookie{
-			Name:     "session",
-			Value:    sessionToken,
+			Name:     sessionCookieName,
+			Value:    token,
 			Path:     "/",
+			Expires:  expiresAt,
 			HttpOnly: true,
+			Secure:   r.TLS != nil,
 			SameSite: http.SameSiteStrictMode,
-			MaxAge:   86400 * 7, // 7 days
 		})
 
-		// Redirect to dashboard
-		redirect := r.URL.Query().Get("redirect")
-		if redirect == "" {
-			redirect = "/"
-		}
-		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		http.Redirect(w, r, loginRedirect(r.URL.Query().Get("redirect")), http.StatusSeeOther)
 		return
 	}
 
@@ -262,7 +325,7 @@ func (m *AuthMiddleware) LoginHandler(w http.ResponseWriter, r *http.Request) {
 // LogoutHandler handles logout
 func (m *AuthMiddleware) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
+		Name:     sessionCookieName,
 		Value:    "",
 		Path:     "/",
 		HttpOnly: true,
@@ -271,6 +334,15 @@ func (m *AuthMiddleware) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/login", http.StatusSeeOther)
 }
 
+// loginRedirect returns where to send the user after login,
+// accepting only local paths so the form can't redirect off-site
+func loginRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		return "/"
+	}
+	return redirect
+}
+
 func (m *AuthMiddleware) renderLoginPage(w http.ResponseWriter, errorMsg string) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"status-incident/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newScopedTestRouter(auth *AuthMiddleware) *chi.Mux {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	router := chi.NewRouter()
+	router.Route("/api", func(r chi.Router) {
+		r.Use(auth.RequireAPIAuth)
+		r.Use(auth.RequireMethodScope)
+		r.Get("/systems", ok)
+		r.Post("/systems", ok)
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(domain.ScopeAdmin))
+			r.Get("/apikeys", ok)
+			r.Post("/apikeys", ok)
+		})
+	})
+	return router
+}
+
+func TestRequireScope(t *testing.T) {
+	keys := &stubAPIKeyRepository{keys: map[string]*domain.APIKey{
+		"sk_read":  {ID: 1, Name: "dashboard", Scopes: []string{domain.ScopeRead}, Enabled: true},
+		"sk_write": {ID: 2, Name: "deploy", Scopes: []string{domain.ScopeWrite}, Enabled: true},
+		"sk_admin": {ID: 3, Name: "ops", Scopes: []string{domain.ScopeAdmin}, Enabled: true},
+	}}
+	router := newScopedTestRouter(NewAuthMiddleware(true, "admin", "secret", keys))
+
+	tests := []struct {
+		name   string
+		key    string
+		method string
+		path   string
+		want   int
+	}{
+		{"read key can GET", "sk_read", "GET", "/api/systems", http.StatusOK},
+		{"read key cannot POST", "sk_read", "POST", "/api/systems", http.StatusForbidden},
+		{"read key cannot list API keys", "sk_read", "GET", "/api/apikeys", http.StatusForbidden},
+		{"read key cannot create API keys", "sk_read", "POST", "/api/apikeys", http.StatusForbidden},
+		{"write key can POST", "sk_write", "POST", "/api/systems", http.StatusOK},
+		{"write key cannot manage API keys", "sk_write", "POST", "/api/apikeys", http.StatusForbidden},
+		{"admin key can manage API keys", "sk_admin", "POST", "/api/apikeys", http.StatusOK},
+		{"basic auth admin can manage API keys", "", "POST", "/api/apikeys", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			} else {
+				req.SetBasicAuth("admin", "secret")
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireScope_AuthDisabled(t *testing.T) {
+	router := newScopedTestRouter(NewAuthMiddleware(false, "", "", nil))
+
+	req := httptest.NewRequest("POST", "/api/apikeys", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestCreateAPIKey_Role(t *testing.T) {
+	handlers := NewAPIKeyHandlers(&stubAPIKeyRepository{})
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		wantStatus int
+		wantScopes []string
+	}{
+		{"role sets scope", map[string]any{"name": "ci", "role": "write"}, http.StatusCreated, []string{"write"}},
+		{"defaults to read", map[string]any{"name": "ci"}, http.StatusCreated, []string{"read"}},
+		{"invalid role", map[string]any{"name": "ci", "role": "owner"}, http.StatusBadRequest, nil},
+		{"invalid scope", map[string]any{"name": "ci", "scopes": []string{"read", "superuser"}}, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/apikeys", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handlers.CreateAPIKey(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantScopes == nil {
+				return
+			}
+			var resp apiKeyResponse
+			json.NewDecoder(w.Body).Decode(&resp)
+			if len(resp.Scopes) != len(tt.wantScopes) || resp.Scopes[0] != tt.wantScopes[0] {
+				t.Errorf("expected scopes %v, got %v", tt.wantScopes, resp.Scopes)
+			}
+		})
+	}
+}
+
+func TestLoginHandler_IssuesSessionCookie(t *testing.T) {
+	auth := NewAuthMiddleware(true, "admin", "secret", nil)
+	auth.SetSession("signing-key", time.Hour)
+
+	router := chi.NewRouter()
+	router.Post("/login", auth.LoginHandler)
+	router.Get("/logout", auth.LogoutHandler)
+	router.With(auth.RequireAuth).Get("/admin", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(domain.ActorFromContext(r.Context())))
+	})
+	router.With(auth.RequireAPIAuth).Get("/api/systems", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	form := url.Values{"username": {"admin"}, "password": {"secret"}}
+	req := httptest.NewRequest("POST", "/login?redirect=/admin", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/admin" {
+		t.Fatalf("expected redirect to /admin, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != sessionCookieName {
+		t.Fatalf("expected a session cookie, got %v", cookies)
+	}
+	session := cookies[0]
+	if !session.HttpOnly {
+		t.Error("expected session cookie to be HttpOnly")
+	}
+	if strings.Contains(session.Value, base64.StdEncoding.EncodeToString([]byte("admin:secret"))) {
+		t.Error("session cookie must not contain the credentials")
+	}
+
+	for _, path := range []string{"/admin", "/api/systems"} {
+		req = httptest.NewRequest("GET", path, nil)
+		req.AddCookie(session)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d with session, got %d", path, http.StatusOK, w.Code)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/logout", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if cookies := w.Result().Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected logout to clear the session cookie, got %v", cookies)
+	}
+}
+
+func TestRequireAuth_RejectsInvalidSession(t *testing.T) {
+	auth := NewAuthMiddleware(true, "admin", "secret", nil)
+	handler := auth.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// The pre-JWT cookie format was base64(username:password)
+	legacy := base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+
+	for _, value := range []string{legacy, "a.b.c"} {
+		req := httptest.NewRequest("GET", "/systems/1", nil)
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: value})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected redirect to login, got %d", w.Code)
+		}
+		if got := w.Header().Get("Location"); got != "/login?redirect=%2Fsystems%2F1" {
+			t.Errorf("unexpected redirect %q", got)
+		}
+	}
+}
+
+func TestLoginRedirect(t *testing.T) {
+	tests := map[string]string{
+		"":                     "/",
+		"/sla":                 "/sla",
+		"https://evil.example": "/",
+		"//evil.example":       "/",
+		`/\evil.example`:       "/",
+	}
+	for in, want := range tests {
+		if got := loginRedirect(in); got != want {
+			t.Errorf("loginRedirect(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
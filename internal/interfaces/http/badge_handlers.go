@@ -0,0 +1,126 @@
+package http
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"status-incident/internal/domain"
+)
+
+// Badge colors, matching the shields.io palette
+const (
+	badgeColorGreen  = "#4c1"
+	badgeColorYellow = "#dfb317"
+	badgeColorOrange = "#fe7d37"
+	badgeColorRed    = "#e05d44"
+	badgeColorBlue   = "#007ec6"
+	badgeColorGrey   = "#9f9f9f"
+)
+
+// badgeMaxAge keeps badges fresh while sparing the database from README traffic
+const badgeMaxAge = "max-age=60"
+
+// badgePeriods are the uptime windows a badge can show
+var badgePeriods = map[string]bool{"1h": true, "24h": true, "7d": true, "30d": true, "90d": true}
+
+// badgeTemplate is a flat shields.io-style badge
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text><text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text><text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+type badgeData struct {
+	Label, Message, Color     string
+	Width, LabelWidth, LabelX int
+	MessageWidth, MessageX    int
+}
+
+// newBadgeData lays out a badge; text width is estimated since the client's
+// font is unknown
+func newBadgeData(label, message, color string) badgeData {
+	textWidth := func(s string) int { return len([]rune(s))*7 + 10 }
+	b := badgeData{
+		Label:        label,
+		Message:      message,
+		Color:        color,
+		LabelWidth:   textWidth(label),
+		MessageWidth: textWidth(message),
+	}
+	b.Width = b.LabelWidth + b.MessageWidth
+	b.LabelX = b.LabelWidth / 2
+	b.MessageX = b.LabelWidth + b.MessageWidth/2
+	return b
+}
+
+// badgeColor maps a system status to its badge color
+func badgeColor(status domain.Status) string {
+	switch status {
+	case domain.StatusGreen:
+		return badgeColorGreen
+	case domain.StatusYellow:
+		return badgeColorYellow
+	case domain.StatusPartial:
+		return badgeColorOrange
+	case domain.StatusRed:
+		return badgeColorRed
+	case domain.StatusMaintenance:
+		return badgeColorBlue
+	}
+	return badgeColorGrey
+}
+
+// respondBadge writes an SVG badge. Error badges use the HTTP status too, so
+// monitoring sees the failure while READMEs still show an image.
+func (s *Server) respondBadge(w http.ResponseWriter, status int, badge badgeData) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", badgeMaxAge)
+	w.WriteHeader(status)
+	badgeTemplate.Execute(w, badge)
+}
+
+// apiGetSystemBadge returns an uptime badge colored by the current status.
+// It is public like /status so it can be embedded in READMEs.
+// GET /api/systems/{id}/badge.svg?period=7d
+func (s *Server) apiGetSystemBadge(w http.ResponseWriter, r *http.Request) {
+	period := strings.ToLower(r.URL.Query().Get("period"))
+	if !badgePeriods[period] {
+		period = "24h"
+	}
+	label := "uptime " + period
+
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondBadge(w, http.StatusBadRequest, newBadgeData(label, "invalid system", badgeColorGrey))
+		return
+	}
+
+	system, err := s.systemService.GetSystem(r.Context(), id)
+	if err != nil {
+		s.respondBadge(w, http.StatusInternalServerError, newBadgeData(label, "unknown", badgeColorGrey))
+		return
+	}
+	if system == nil {
+		s.respondBadge(w, http.StatusNotFound, newBadgeData(label, "not found", badgeColorGrey))
+		return
+	}
+
+	systems := []*systemWithDeps{{System: system}}
+	s.markUnderMaintenance(r.Context(), systems)
+	color := badgeColor(systems[0].DisplayStatus())
+
+	analytics, err := s.analyticsService.GetSystemAnalytics(r.Context(), id, period)
+	if err != nil || analytics == nil {
+		s.respondBadge(w, http.StatusOK, newBadgeData(label, "unknown", badgeColorGrey))
+		return
+	}
+
+	s.respondBadge(w, http.StatusOK, newBadgeData(label, formatPercent(analytics.UptimePercent), color))
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+)
+
+// failingAnalyticsRepository has no system analytics
+type failingAnalyticsRepository struct {
+	*MockAnalyticsRepository
+}
+
+func (m *failingAnalyticsRepository) GetUptimeBySystemID(ctx context.Context, systemID int64, start, end time.Time) (*domain.Analytics, error) {
+	return nil, errors.New("no analytics")
+}
+
+func getBadge(server *Server, id, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/systems/"+id+"/badge.svg"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	server.apiGetSystemBadge(w, req)
+	return w
+}
+
+func TestAPIGetSystemBadge(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.UpdateStatus(domain.StatusRed)
+	systemRepo.Create(context.Background(), system)
+
+	w := getBadge(server, "1", "?period=7d")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("expected Content-Type image/svg+xml, got %q", ct)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != badgeMaxAge {
+		t.Errorf("expected Cache-Control %q, got %q", badgeMaxAge, cc)
+	}
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "<svg") {
+		t.Errorf("expected an SVG document, got %q", body)
+	}
+	if !strings.Contains(body, ">99.90%<") {
+		t.Errorf("expected uptime percentage in badge, got %q", body)
+	}
+	if !strings.Contains(body, ">uptime 7d<") {
+		t.Errorf("expected period label in badge, got %q", body)
+	}
+	if !strings.Contains(body, `fill="`+badgeColorRed+`"`) {
+		t.Errorf("expected red badge for a red system, got %q", body)
+	}
+}
+
+func TestAPIGetSystemBadge_UnknownPeriod(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	body := getBadge(server, "1", "?period=1y").Body.String()
+	if !strings.Contains(body, ">uptime 24h<") {
+		t.Errorf("expected fallback to 24h, got %q", body)
+	}
+	if !strings.Contains(body, `fill="`+badgeColorGreen+`"`) {
+		t.Errorf("expected green badge for a green system, got %q", body)
+	}
+}
+
+func TestAPIGetSystemBadge_NotFound(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	w := getBadge(server, "999", "")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("expected Content-Type image/svg+xml, got %q", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, ">not found<") {
+		t.Errorf("expected not found badge, got %q", body)
+	}
+}
+
+func TestAPIGetSystemBadge_MissingAnalytics(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.analyticsService = application.NewAnalyticsService(
+		&failingAnalyticsRepository{NewMockAnalyticsRepository()}, NewMockStatusLogRepository())
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.UpdateStatus(domain.StatusRed)
+	systemRepo.Create(context.Background(), system)
+
+	w := getBadge(server, "1", "")
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, ">unknown<") || !strings.Contains(body, `fill="`+badgeColorGrey+`"`) {
+		t.Errorf("expected grey unknown badge, got %q", body)
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+)
+
+// DefaultStatusPageTitle is used when no branding title is configured
+const DefaultStatusPageTitle = "System Status"
+
+// brandColorPattern accepts hex colors and plain CSS color names
+var brandColorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+)$`)
+
+// BrandingConfig themes the public status page. Empty fields keep the
+// default look.
+type BrandingConfig struct {
+	Title         string // page and feed title
+	LogoURL       string // shown above the title
+	PrimaryColor  string // header background, e.g. #0f766e
+	SupportURL    string // linked from the footer
+	CustomCSSPath string // stylesheet file appended to the page styles
+}
+
+// PageTitle returns the configured title or the default one
+func (b BrandingConfig) PageTitle() string {
+	if b.Title == "" {
+		return DefaultStatusPageTitle
+	}
+	return b.Title
+}
+
+// SetBranding themes the public status page. The custom stylesheet is read
+// once here, so a missing file is reported at startup.
+func (s *Server) SetBranding(b BrandingConfig) error {
+	if b.PrimaryColor != "" && !brandColorPattern.MatchString(b.PrimaryColor) {
+		return fmt.Errorf("invalid primary color %q: use a hex color or CSS color name", b.PrimaryColor)
+	}
+
+	var css template.CSS
+	if b.CustomCSSPath != "" {
+		data, err := os.ReadFile(b.CustomCSSPath)
+		if err != nil {
+			return fmt.Errorf("failed to read custom CSS: %w", err)
+		}
+		css = template.CSS(data)
+	}
+
+	s.branding = b
+	s.brandingCSS = css
+	return nil
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// eventStreamKeepAlive is how often an idle event stream sends a comment so
+// proxies do not close the connection
+const eventStreamKeepAlive = 30 * time.Second
+
+// apiStatusEvents streams status changes and incident events as Server-Sent
+// Events until the client disconnects
+// GET /api/events
+func (s *Server) apiStatusEvents(w http.ResponseWriter, r *http.Request) {
+	if s.eventBroker == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "event stream is not enabled")
+		return
+	}
+
+	events, unsubscribe := s.eventBroker.Subscribe()
+	defer unsubscribe()
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := w.Write([]byte(": keepalive\n\n")); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				continue
+			}
+			if _, err := w.Write([]byte("event: " + event.Type + "\ndata: " + string(data) + "\n\n")); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+)
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAPIStatusEvents(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	broker := application.NewStatusEventBroker()
+	server.SetEventBroker(broker)
+	server.systemService.SetEventBroker(broker)
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	ts := httptest.NewServer(http.HandlerFunc(server.apiStatusEvents))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+	waitFor(t, func() bool { return broker.SubscriberCount() == 1 })
+
+	if _, err := server.systemService.UpdateSystemStatus(context.Background(), system.ID, "red", "Outage"); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var eventName, data string
+	for data == "" {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("stream ended before an event arrived")
+			}
+			if name, found := strings.CutPrefix(line, "event: "); found {
+				eventName = name
+			}
+			if payload, found := strings.CutPrefix(line, "data: "); found {
+				data = payload
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected a status change event")
+		}
+	}
+
+	if eventName != "status_change" {
+		t.Errorf("expected event status_change, got %q", eventName)
+	}
+	var event application.StatusEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("invalid event data %q: %v", data, err)
+	}
+	if event.SystemID != system.ID || event.OldStatus != "green" || event.NewStatus != "red" || event.Message != "Outage" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// Disconnecting removes the subscriber
+	cancel()
+	waitFor(t, func() bool { return broker.SubscriberCount() == 0 })
+}
+
+func TestAPIStatusEvents_Disabled(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	w := httptest.NewRecorder()
+	server.apiStatusEvents(w, httptest.NewRequest("GET", "/api/events", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
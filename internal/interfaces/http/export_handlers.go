@@ -1,48 +1,19 @@
 package http
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	"status-incident/internal/application"
 	"status-incident/internal/domain"
 )
 
-// Export/Import data structures
-type ExportData struct {
-	ExportedAt   time.Time          `json:"exported_at"`
-	Version      string             `json:"version"`
-	Systems      []ExportSystem     `json:"systems"`
-	Dependencies []ExportDependency `json:"dependencies"`
-	Logs         []ExportLog        `json:"logs"`
-}
-
-type ExportSystem struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	URL         string    `json:"url"`
-	Owner       string    `json:"owner"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-}
-
-type ExportDependency struct {
-	ID                  int64     `json:"id"`
-	SystemID            int64     `json:"system_id"`
-	Name                string    `json:"name"`
-	Description         string    `json:"description"`
-	Status              string    `json:"status"`
-	HeartbeatURL        string    `json:"heartbeat_url,omitempty"`
-	HeartbeatInterval   int       `json:"heartbeat_interval,omitempty"`
-	ConsecutiveFailures int       `json:"consecutive_failures"`
-	LastCheck           time.Time `json:"last_check,omitempty"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
-}
-
 type ExportLog struct {
 	ID           int64     `json:"id"`
 	SystemID     *int64    `json:"system_id,omitempty"`
@@ -51,97 +22,38 @@ type ExportLog struct {
 	NewStatus    string    `json:"new_status"`
 	Message      string    `json:"message,omitempty"`
 	Source       string    `json:"source"`
+	Actor        string    `json:"actor,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// importRequest is the body of POST /api/import: a configuration export,
+// optionally with the status logs of a version 1.0 export
+type importRequest struct {
+	application.ConfigDocument
+	Logs []ExportLog `json:"logs,omitempty"`
+}
+
+// ImportResult reports what an import created and updated
 type ImportResult struct {
-	SystemsImported      int      `json:"systems_imported"`
-	DependenciesImported int      `json:"dependencies_imported"`
-	LogsImported         int      `json:"logs_imported"`
-	Errors               []string `json:"errors,omitempty"`
+	*application.ConfigImportResult
+	LogsImported int      `json:"logs_imported,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
 }
 
-// apiExportAll exports all data (systems, dependencies, logs)
+// apiExportAll exports the configuration: systems, dependencies, webhooks
+// and maintenance windows
 func (s *Server) apiExportAll(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	// Get all systems
-	systems, err := s.systemService.GetAllSystems(ctx)
-	if err != nil {
-		s.respondError(w, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	// Get all logs
-	logs, err := s.analyticsService.GetAllLogs(ctx, 10000)
+	doc, err := s.configService.Export(r.Context())
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	export := ExportData{
-		ExportedAt:   time.Now(),
-		Version:      "1.0",
-		Systems:      make([]ExportSystem, 0),
-		Dependencies: make([]ExportDependency, 0),
-		Logs:         make([]ExportLog, 0),
-	}
-
-	// Convert systems
-	for _, sys := range systems {
-		export.Systems = append(export.Systems, ExportSystem{
-			ID:          sys.ID,
-			Name:        sys.Name,
-			Description: sys.Description,
-			URL:         sys.URL,
-			Owner:       sys.Owner,
-			Status:      sys.Status.String(),
-			CreatedAt:   sys.CreatedAt,
-			UpdatedAt:   sys.UpdatedAt,
-		})
-
-		// Get dependencies for each system
-		deps, err := s.depService.GetDependenciesBySystem(ctx, sys.ID)
-		if err != nil {
-			continue
-		}
-
-		for _, dep := range deps {
-			export.Dependencies = append(export.Dependencies, ExportDependency{
-				ID:                  dep.ID,
-				SystemID:            dep.SystemID,
-				Name:                dep.Name,
-				Description:         dep.Description,
-				Status:              dep.Status.String(),
-				HeartbeatURL:        dep.HeartbeatURL,
-				HeartbeatInterval:   dep.HeartbeatInterval,
-				ConsecutiveFailures: dep.ConsecutiveFailures,
-				LastCheck:           dep.LastCheck,
-				CreatedAt:           dep.CreatedAt,
-				UpdatedAt:           dep.UpdatedAt,
-			})
-		}
-	}
-
-	// Convert logs
-	for _, log := range logs {
-		export.Logs = append(export.Logs, ExportLog{
-			ID:           log.ID,
-			SystemID:     log.SystemID,
-			DependencyID: log.DependencyID,
-			OldStatus:    log.OldStatus.String(),
-			NewStatus:    log.NewStatus.String(),
-			Message:      log.Message,
-			Source:       string(log.Source),
-			CreatedAt:    log.CreatedAt,
-		})
-	}
-
 	// Set download headers
 	filename := fmt.Sprintf("status-incident-export-%s.json", time.Now().Format("2006-01-02-150405"))
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 
-	s.respondJSON(w, http.StatusOK, export)
+	s.respondJSON(w, http.StatusOK, doc)
 }
 
 // apiExportLogs exports only logs
@@ -164,6 +76,7 @@ func (s *Server) apiExportLogs(w http.ResponseWriter, r *http.Request) {
 			NewStatus:    log.NewStatus.String(),
 			Message:      log.Message,
 			Source:       string(log.Source),
+			Actor:        log.Actor,
 			CreatedAt:    log.CreatedAt,
 		})
 	}
@@ -184,69 +97,184 @@ func (s *Server) apiExportLogs(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, export)
 }
 
-// apiImportAll imports all data (systems, dependencies, logs)
-func (s *Server) apiImportAll(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// logExportCSVHeader lists the columns of the CSV log export
+var logExportCSVHeader = []string{"timestamp", "system", "dependency", "old_status", "new_status", "source", "message"}
 
-	var data ExportData
-	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
-		s.respondError(w, http.StatusBadRequest, "invalid JSON format")
+// apiExportLogsCSV streams all status logs as a CSV download
+func (s *Server) apiExportLogsCSV(w http.ResponseWriter, r *http.Request) {
+	s.exportLogsCSV(w, r, nil)
+}
+
+// apiExportSystemLogsCSV streams the status logs of one system as a CSV download
+func (s *Server) apiExportSystemLogsCSV(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid system ID")
 		return
 	}
 
-	result := ImportResult{
-		Errors: make([]string, 0),
+	system, err := s.systemService.GetSystem(r.Context(), id)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if system == nil {
+		s.respondError(w, http.StatusNotFound, "system not found")
+		return
 	}
 
-	// Map old IDs to new IDs
-	systemIDMap := make(map[int64]int64)
-	depIDMap := make(map[int64]int64)
+	s.exportLogsCSV(w, r, &id)
+}
 
-	// Import systems
-	for _, expSys := range data.Systems {
-		sys, err := s.systemService.CreateSystem(ctx, expSys.Name, expSys.Description, expSys.URL, expSys.Owner)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("system '%s': %v", expSys.Name, err))
-			continue
+// exportLogsCSV writes the newest logs (limit, default 10000), optionally
+// narrowed to one system and to the from/to range, as CSV rows
+func (s *Server) exportLogsCSV(w http.ResponseWriter, r *http.Request, systemID *int64) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	if format := query.Get("format"); format != "" && format != "csv" {
+		s.respondError(w, http.StatusBadRequest, "unsupported format (use csv)")
+		return
+	}
+
+	limit := 10000
+	if l := query.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
 		}
+	}
 
-		// Update status if not green
-		if expSys.Status != "green" {
-			s.systemService.UpdateSystemStatus(ctx, sys.ID, expSys.Status, "Imported from backup")
+	var from, to time.Time
+	if v := query.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "invalid from format (use RFC3339)")
+			return
+		}
+		from = t
+	}
+	if v := query.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "invalid to format (use RFC3339)")
+			return
 		}
+		to = t
+	}
 
-		systemIDMap[expSys.ID] = sys.ID
-		result.SystemsImported++
+	var logs []*domain.StatusLog
+	var err error
+	if systemID != nil {
+		logs, err = s.systemService.GetSystemLogs(ctx, *systemID, limit)
+	} else {
+		logs, err = s.analyticsService.GetAllLogs(ctx, limit)
 	}
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("status-incident-logs-%s.csv", time.Now().Format("2006-01-02-150405"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	w.WriteHeader(http.StatusOK)
 
-	// Import dependencies
-	for _, expDep := range data.Dependencies {
-		newSystemID, ok := systemIDMap[expDep.SystemID]
-		if !ok {
-			result.Errors = append(result.Errors, fmt.Sprintf("dependency '%s': system not found", expDep.Name))
+	cw := csv.NewWriter(w)
+	cw.Write(logExportCSVHeader)
+
+	names := s.newLogNameResolver()
+	for _, log := range logs {
+		if !from.IsZero() && log.CreatedAt.Before(from) {
 			continue
 		}
-
-		dep, err := s.depService.CreateDependency(ctx, newSystemID, expDep.Name, expDep.Description)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("dependency '%s': %v", expDep.Name, err))
+		if !to.IsZero() && log.CreatedAt.After(to) {
 			continue
 		}
 
-		// Set heartbeat if configured
-		if expDep.HeartbeatURL != "" {
-			s.depService.SetHeartbeat(ctx, dep.ID, expDep.HeartbeatURL, expDep.HeartbeatInterval)
+		lc := names.enrich(ctx, log)
+		if err := cw.Write([]string{
+			log.CreatedAt.UTC().Format(time.RFC3339),
+			lc.SystemName,
+			lc.DependencyName,
+			log.OldStatus.String(),
+			log.NewStatus.String(),
+			string(log.Source),
+			log.Message,
+		}); err != nil {
+			return
 		}
+	}
+	cw.Flush()
+}
 
-		// Update status if not green
-		if expDep.Status != "green" {
-			s.depService.UpdateDependencyStatus(ctx, dep.ID, expDep.Status, "Imported from backup")
+// logNameResolver looks up the system and dependency names of status logs,
+// fetching each entity only once
+type logNameResolver struct {
+	server  *Server
+	systems map[int64]string
+	deps    map[int64]string
+}
+
+func (s *Server) newLogNameResolver() *logNameResolver {
+	return &logNameResolver{
+		server:  s,
+		systems: make(map[int64]string),
+		deps:    make(map[int64]string),
+	}
+}
+
+// enrich wraps a log with the names of its system and dependency
+func (n *logNameResolver) enrich(ctx context.Context, log *domain.StatusLog) *logWithContext {
+	lc := &logWithContext{StatusLog: log}
+
+	if log.SystemID != nil {
+		if name, ok := n.systems[*log.SystemID]; ok {
+			lc.SystemName = name
+		} else if sys, err := n.server.systemService.GetSystem(ctx, *log.SystemID); err == nil && sys != nil {
+			lc.SystemName = sys.Name
+			n.systems[*log.SystemID] = sys.Name
 		}
+	}
 
-		depIDMap[expDep.ID] = dep.ID
-		result.DependenciesImported++
+	if log.DependencyID != nil {
+		if name, ok := n.deps[*log.DependencyID]; ok {
+			lc.DependencyName = name
+		} else if dep, err := n.server.depService.GetDependency(ctx, *log.DependencyID); err == nil && dep != nil {
+			lc.DependencyName = dep.Name
+			n.deps[*log.DependencyID] = dep.Name
+		}
 	}
 
+	return lc
+}
+
+// apiImportAll imports a configuration export. ?mode=merge (default)
+// updates systems, dependencies and webhooks that exist by name;
+// ?mode=replace deletes the current configuration first.
+func (s *Server) apiImportAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var data importRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid JSON format")
+		return
+	}
+
+	imported, err := s.configService.Import(ctx, &data.ConfigDocument, r.URL.Query().Get("mode"))
+	if err != nil {
+		var invalid *application.ConfigValidationError
+		if errors.Is(err, application.ErrInvalidImportMode) || errors.As(err, &invalid) {
+			s.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := ImportResult{ConfigImportResult: imported}
+	systemIDMap := imported.SystemIDs
+	depIDMap := imported.DependencyIDs
+
 	// Import logs (create new log entries with mapped IDs)
 	for _, expLog := range data.Logs {
 		var systemID, depID *int64
@@ -273,6 +301,7 @@ func (s *Server) apiImportAll(w http.ResponseWriter, r *http.Request) {
 		source := domain.ChangeSource(expLog.Source)
 
 		log := domain.NewStatusLog(systemID, depID, oldStatus, newStatus, expLog.Message, source)
+		log.Actor = expLog.Actor
 		if err := s.analyticsService.CreateLog(ctx, log); err != nil {
 			result.Errors = append(result.Errors, fmt.Sprintf("log: %v", err))
 			continue
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// feedRecentDays is how far back resolved incidents are kept in the feed
+const feedRecentDays = 30
+
+// RSS 2.0 document structures
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// handleIncidentFeed renders active and recently resolved incidents as an RSS 2.0 feed
+func (s *Server) handleIncidentFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	baseURL := requestBaseURL(r)
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         s.branding.PageTitle() + " - Incidents",
+			Link:          baseURL + "/status",
+			Description:   "Incidents and their latest updates",
+			LastBuildDate: time.Now().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0),
+		},
+	}
+
+	if s.incidentService != nil {
+		incidents, err := s.feedIncidents(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, inc := range incidents {
+			updates, _ := s.incidentService.GetIncidentUpdates(ctx, inc.ID)
+			feed.Channel.Items = append(feed.Channel.Items, newRSSItem(inc, updates, baseURL))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.Encode(feed)
+}
+
+// feedIncidents returns active and recently resolved incidents, most recently updated first
+func (s *Server) feedIncidents(ctx context.Context) ([]*domain.Incident, error) {
+	active, err := s.incidentService.GetActiveIncidents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.incidentService.GetRecentIncidents(ctx, feedRecentDays)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := append(active, recent...)
+	sort.SliceStable(incidents, func(i, j int) bool {
+		return incidents[i].UpdatedAt.After(incidents[j].UpdatedAt)
+	})
+	return incidents, nil
+}
+
+// newRSSItem builds the feed entry of an incident; updates are newest first
+func newRSSItem(inc *domain.Incident, updates []*domain.IncidentUpdate, baseURL string) rssItem {
+	latest := inc.Message
+	if len(updates) > 0 {
+		latest = updates[0].Message
+	}
+
+	description := fmt.Sprintf("Status: %s. Severity: %s.", statusTitle(inc.Status), inc.Severity)
+	if latest != "" {
+		description += " " + latest
+	}
+
+	return rssItem{
+		Title:       inc.Title,
+		Link:        fmt.Sprintf("%s/status#incident-%d", baseURL, inc.ID),
+		Description: description,
+		GUID:        rssGUID{Value: fmt.Sprintf("incident-%d", inc.ID)},
+		PubDate:     inc.UpdatedAt.Format(time.RFC1123Z),
+	}
+}
+
+// statusTitle capitalises an incident status for display
+func statusTitle(status domain.IncidentStatus) string {
+	s := string(status)
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// requestBaseURL returns the scheme and host the request was addressed to
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockIncidentRepository keeps incidents and their updates in memory
+type mockIncidentRepository struct {
+	incidents       []*domain.Incident
+	updates         []*domain.IncidentUpdate
+	idempotencyKeys map[string]mockIdempotencyKey
+}
+
+type mockIdempotencyKey struct {
+	incidentID int64
+	expiresAt  time.Time
+}
+
+func (m *mockIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	incident.ID = int64(len(m.incidents) + 1)
+	m.incidents = append(m.incidents, incident)
+	return nil
+}
+
+func (m *mockIncidentRepository) GetByID(ctx context.Context, id int64) (*domain.Incident, error) {
+	for _, inc := range m.incidents {
+		if inc.ID == id {
+			return inc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockIncidentRepository) GetAll(ctx context.Context, limit int) ([]*domain.Incident, error) {
+	return m.incidents, nil
+}
+
+func (m *mockIncidentRepository) GetPage(ctx context.Context, filter domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	var matching []*domain.Incident
+	for _, inc := range m.incidents {
+		if filter.Matches(inc) {
+			matching = append(matching, inc)
+		}
+	}
+	if offset >= len(matching) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[offset:end], nil
+}
+
+func (m *mockIncidentRepository) Count(ctx context.Context, filter domain.IncidentFilter) (int, error) {
+	count := 0
+	for _, inc := range m.incidents {
+		if filter.Matches(inc) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockIncidentRepository) GetActive(ctx context.Context) ([]*domain.Incident, error) {
+	var result []*domain.Incident
+	for _, inc := range m.incidents {
+		if inc.IsActive() {
+			result = append(result, inc)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIncidentRepository) GetScheduled(ctx context.Context) ([]*domain.Incident, error) {
+	var result []*domain.Incident
+	for _, inc := range m.incidents {
+		if inc.IsScheduled() {
+			result = append(result, inc)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIncidentRepository) GetRecent(ctx context.Context, days int) ([]*domain.Incident, error) {
+	var result []*domain.Incident
+	for _, inc := range m.incidents {
+		if inc.Status == domain.IncidentResolved {
+			result = append(result, inc)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIncidentRepository) Update(ctx context.Context, incident *domain.Incident) error {
+	return nil
+}
+
+func (m *mockIncidentRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *mockIncidentRepository) CreateUpdate(ctx context.Context, update *domain.IncidentUpdate) error {
+	update.ID = int64(len(m.updates) + 1)
+	m.updates = append(m.updates, update)
+	return nil
+}
+
+func (m *mockIncidentRepository) GetUpdates(ctx context.Context, incidentID int64) ([]*domain.IncidentUpdate, error) {
+	// Newest first, like the SQLite repository
+	var result []*domain.IncidentUpdate
+	for i := len(m.updates) - 1; i >= 0; i-- {
+		if m.updates[i].IncidentID == incidentID {
+			result = append(result, m.updates[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIncidentRepository) GetByIdempotencyKey(ctx context.Context, scope, key string, now time.Time) (*domain.Incident, error) {
+	entry, ok := m.idempotencyKeys[scope+"\x00"+key]
+	if !ok || !entry.expiresAt.After(now) || entry.incidentID == 0 {
+		return nil, nil
+	}
+	return m.GetByID(ctx, entry.incidentID)
+}
+
+func (m *mockIncidentRepository) ReserveIdempotencyKey(ctx context.Context, scope, key string, now, expiresAt time.Time) (bool, error) {
+	if m.idempotencyKeys == nil {
+		m.idempotencyKeys = make(map[string]mockIdempotencyKey)
+	}
+	if entry, ok := m.idempotencyKeys[scope+"\x00"+key]; ok && entry.expiresAt.After(now) {
+		return false, nil
+	}
+	m.idempotencyKeys[scope+"\x00"+key] = mockIdempotencyKey{expiresAt: expiresAt}
+	return true, nil
+}
+
+func (m *mockIncidentRepository) SetIdempotencyKeyIncident(ctx context.Context, scope, key string, incidentID int64, expiresAt time.Time) error {
+	m.idempotencyKeys[scope+"\x00"+key] = mockIdempotencyKey{incidentID: incidentID, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *mockIncidentRepository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	delete(m.idempotencyKeys, scope+"\x00"+key)
+	return nil
+}
+
+func TestHandleIncidentFeed(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	ctx := context.Background()
+
+	resolved, err := server.incidentService.CreateIncident(ctx, "Payments outage", "Card payments failing", domain.SeverityCritical, nil)
+	if err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	if _, err := server.incidentService.ResolveIncident(ctx, resolved.ID, "", "ops"); err != nil {
+		t.Fatalf("failed to resolve incident: %v", err)
+	}
+	resolved.UpdatedAt = time.Now().Add(-time.Hour)
+
+	active, err := server.incidentService.CreateIncident(ctx, "Slow logins", "Investigating login latency", domain.SeverityMinor, nil)
+	if err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	if _, err := server.incidentService.UpdateIncidentStatus(ctx, active.ID, domain.IncidentIdentified, "Cache node overloaded", "ops", true); err != nil {
+		t.Fatalf("failed to update incident: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/feed.xml", nil)
+	w := httptest.NewRecorder()
+
+	server.handleIncidentFeed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/rss+xml") {
+		t.Errorf("expected RSS content type, got %q", ct)
+	}
+
+	var feed rssFeed
+	if err := xml.NewDecoder(w.Body).Decode(&feed); err != nil {
+		t.Fatalf("failed to parse feed: %v", err)
+	}
+	if feed.Version != "2.0" {
+		t.Errorf("expected RSS version 2.0, got %q", feed.Version)
+	}
+	if len(feed.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Channel.Items))
+	}
+
+	// Most recently updated first
+	if got := feed.Channel.Items[0].Title; got != "Slow logins" {
+		t.Errorf("expected first item 'Slow logins', got %q", got)
+	}
+	if got := feed.Channel.Items[1].Title; got != "Payments outage" {
+		t.Errorf("expected second item 'Payments outage', got %q", got)
+	}
+
+	desc := feed.Channel.Items[0].Description
+	if !strings.Contains(desc, "Identified") || !strings.Contains(desc, "Cache node overloaded") {
+		t.Errorf("expected description with status and latest update, got %q", desc)
+	}
+	if got := feed.Channel.Items[0].GUID.Value; got != "incident-2" {
+		t.Errorf("expected guid incident-2, got %q", got)
+	}
+}
+
+func TestHandleIncidentFeed_NoIncidentService(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/feed.xml", nil)
+	w := httptest.NewRecorder()
+
+	server.handleIncidentFeed(w, req)
+
+	var feed rssFeed
+	if err := xml.NewDecoder(w.Body).Decode(&feed); err != nil {
+		t.Fatalf("failed to parse feed: %v", err)
+	}
+	if len(feed.Channel.Items) != 0 {
+		t.Errorf("expected empty feed, got %d items", len(feed.Channel.Items))
+	}
+}
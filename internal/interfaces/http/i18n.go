@@ -0,0 +1,123 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultLocale is used for the admin pages and whenever the requested
+// language has no catalog
+const defaultLocale = "en"
+
+// messageCatalog maps English UI strings to their translations. English is
+// the source language, so it needs no entries; missing keys fall back to the
+// English text.
+var messageCatalog = map[string]map[string]string{
+	"de": {
+		// Page chrome
+		"System Status":                    "Systemstatus",
+		"Real-time status of our services": "Echtzeitstatus unserer Dienste",
+		"Ongoing Maintenance":              "Laufende Wartung",
+		"Scheduled Maintenance":            "Geplante Wartung",
+		"Started":                          "Begonnen",
+		"Expected end":                     "Voraussichtliches Ende",
+		"Last updated":                     "Zuletzt aktualisiert",
+		"Upcoming":                         "Bevorstehend",
+		"Affected":                         "Betroffen",
+		"Starts":                           "Beginnt",
+		"No systems configured yet.":       "Noch keine Systeme konfiguriert.",
+		"Contact support":                  "Support kontaktieren",
+		"Powered by":                       "Betrieben mit",
+		"just now":                         "gerade eben",
+
+		// Status labels
+		"Operational":             "Betriebsbereit",
+		"Degraded":                "Eingeschränkt",
+		"Outage":                  "Ausfall",
+		"Under Maintenance":       "In Wartung",
+		"Unknown":                 "Unbekannt",
+		"Major Outage":            "Schwerwiegender Ausfall",
+		"Partial Outage":          "Teilweiser Ausfall",
+		"Degraded Performance":    "Eingeschränkte Leistung",
+		"All Systems Operational": "Alle Systeme betriebsbereit",
+
+		// Incident severities and statuses
+		"minor":         "gering",
+		"major":         "erheblich",
+		"critical":      "kritisch",
+		"investigating": "wird untersucht",
+		"identified":    "identifiziert",
+		"monitoring":    "wird beobachtet",
+		"resolved":      "behoben",
+		"scheduled":     "geplant",
+	},
+}
+
+// translate returns msg in the given locale, or msg itself when there is no
+// translation
+func translate(locale, msg string) string {
+	if translated, ok := messageCatalog[locale][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// supportedLocale reduces a language tag such as "de-AT" to a locale with a
+// catalog, reporting false for anything else
+func supportedLocale(tag string) (string, bool) {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == defaultLocale {
+		return lang, true
+	}
+	if _, ok := messageCatalog[lang]; ok {
+		return lang, true
+	}
+	return "", false
+}
+
+// requestLocale picks the page language: an explicit ?lang= wins, then the
+// best supported Accept-Language entry, then English
+func requestLocale(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		if locale, ok := supportedLocale(lang); ok {
+			return locale
+		}
+		return defaultLocale
+	}
+
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+	var tags []weightedTag
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weightedTag{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	for _, t := range tags {
+		if locale, ok := supportedLocale(t.tag); ok {
+			return locale
+		}
+	}
+	return defaultLocale
+}
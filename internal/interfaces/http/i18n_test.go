@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestLocale(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		want           string
+	}{
+		{"no preference", "/status", "", "en"},
+		{"query param", "/status?lang=de", "", "de"},
+		{"query param with region", "/status?lang=de-AT", "", "de"},
+		{"unsupported query param", "/status?lang=fr", "de", "en"},
+		{"accept language", "/status", "de-CH", "de"},
+		{"accept language order", "/status", "en-US,de;q=0.5", "en"},
+		{"accept language weights", "/status", "en;q=0.3,de;q=0.7", "de"},
+		{"skips unsupported", "/status", "fr-FR,fr;q=0.9,de;q=0.8", "de"},
+		{"only unsupported", "/status", "fr,es;q=0.5", "en"},
+		{"zero weight", "/status", "de;q=0,fr", "en"},
+		{"wildcard", "/status", "*", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if got := requestLocale(req); got != tt.want {
+				t.Errorf("requestLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := translate("de", "Operational"); got != "Betriebsbereit" {
+		t.Errorf("translate(de, Operational) = %q, want Betriebsbereit", got)
+	}
+	if got := translate("de", "Acme API"); got != "Acme API" {
+		t.Errorf("expected untranslated text to pass through, got %q", got)
+	}
+	if got := translate("en", "Operational"); got != "Operational" {
+		t.Errorf("translate(en, Operational) = %q, want Operational", got)
+	}
+}
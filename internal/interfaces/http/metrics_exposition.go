@@ -0,0 +1,85 @@
+package http
+
+import (
+	"io"
+	"strings"
+)
+
+// metricFamily is one declared metric and the sample lines collected for it
+type metricFamily struct {
+	name    string
+	help    string
+	typ     string
+	samples []string
+}
+
+// metricsExposition collects samples per metric so that each family is
+// written once, contiguously, in declaration order. Families without samples
+// are left out, since some scrapers reject HELP/TYPE lines with nothing after them.
+type metricsExposition struct {
+	families []*metricFamily
+	byName   map[string]*metricFamily
+}
+
+func newMetricsExposition() *metricsExposition {
+	return &metricsExposition{byName: make(map[string]*metricFamily)}
+}
+
+// gauge declares a gauge metric
+func (e *metricsExposition) gauge(name, help string) {
+	family := &metricFamily{name: name, help: help, typ: "gauge"}
+	e.families = append(e.families, family)
+	e.byName[name] = family
+}
+
+// histogram declares a histogram metric
+func (e *metricsExposition) histogram(name, help string) {
+	family := &metricFamily{name: name, help: help, typ: "histogram"}
+	e.families = append(e.families, family)
+	e.byName[name] = family
+}
+
+// add records a sample for a declared metric
+func (e *metricsExposition) add(name string, value interface{}, labels ...string) {
+	family := e.family(name)
+	family.samples = append(family.samples, formatMetricLine(name, value, labels...))
+}
+
+// addHistogram records the _bucket, _sum and _count series of one histogram.
+// counts[i] is the cumulative count of observations <= bounds[i].
+func (e *metricsExposition) addHistogram(name string, bounds []int64, counts []int64, count int64, sum float64, labels ...string) {
+	family := e.family(name)
+	for i, bound := range bounds {
+		family.samples = append(family.samples, formatMetricLine(name+"_bucket", counts[i], append(labels, "le", intToStr(bound))...))
+	}
+	family.samples = append(family.samples,
+		formatMetricLine(name+"_bucket", count, append(labels, "le", "+Inf")...),
+		formatMetricLine(name+"_sum", sum, labels...),
+		formatMetricLine(name+"_count", count, labels...),
+	)
+}
+
+func (e *metricsExposition) family(name string) *metricFamily {
+	family, ok := e.byName[name]
+	if !ok {
+		panic("metrics: sample for undeclared metric " + name)
+	}
+	return family
+}
+
+// WriteTo writes the families that have samples in Prometheus text format
+func (e *metricsExposition) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	for _, family := range e.families {
+		if len(family.samples) == 0 {
+			continue
+		}
+		b.WriteString("# HELP " + family.name + " " + family.help + "\n")
+		b.WriteString("# TYPE " + family.name + " " + family.typ + "\n")
+		for _, sample := range family.samples {
+			b.WriteString(sample)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitEvictInterval is how often idle buckets are swept from memory
+const rateLimitEvictInterval = time.Minute
+
+// tokenBucket holds the tokens left for one client as of last
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is an in-memory token bucket limiter keyed by API key,
+// authenticated user or client IP
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens added per second
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastEvict time.Time
+	clock     application.Clock
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute on average,
+// with bursts of up to burst requests
+func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    float64(requestsPerMinute) / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		clock:   application.SystemClock{},
+	}
+}
+
+// SetClock sets the clock that refills token buckets
+func (l *RateLimiter) SetClock(clock application.Clock) {
+	l.clock = clock
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it
+// returns false and how long until the next token is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if now.Sub(l.lastEvict) >= rateLimitEvictInterval {
+		l.evict(now)
+		l.lastEvict = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.last).Seconds()
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.rate)
+		bucket.last = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	if l.rate <= 0 {
+		return false, rateLimitEvictInterval
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// evict drops buckets that have refilled completely, since a fresh
+// bucket would behave the same
+func (l *RateLimiter) evict(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware rejects requests over the limit with 429 Too Many Requests.
+// Place it after authentication so API keys get their own bucket.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := l.Allow(rateLimitKey(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitKey identifies the client: the API key or user when
+// authenticated, otherwise the remote IP
+func rateLimitKey(r *http.Request) string {
+	if user := domain.UserFromContext(r.Context()); user != nil {
+		if user.IsAPIKey {
+			return "key:" + strconv.FormatInt(user.APIKeyID, 10)
+		}
+		return "user:" + user.Username
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
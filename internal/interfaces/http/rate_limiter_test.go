@@ -0,0 +1,102 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRateLimiter_ThrottlesAPIKeyAfterBurst(t *testing.T) {
+	keys := &stubAPIKeyRepository{keys: map[string]*domain.APIKey{
+		"sk_noisy": {ID: 1, Name: "noisy", Scopes: []string{domain.ScopeRead}, Enabled: true},
+		"sk_quiet": {ID: 2, Name: "quiet", Scopes: []string{domain.ScopeRead}, Enabled: true},
+	}}
+	auth := NewAuthMiddleware(true, "admin", "secret", keys)
+	limiter := NewRateLimiter(60, 5)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter.SetClock(application.ClockFunc(func() time.Time { return now }))
+
+	router := chi.NewRouter()
+	router.With(auth.RequireAPIAuth, limiter.Middleware).Get("/api/systems", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	get := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/systems", nil)
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 5; i++ {
+		if w := get("sk_noisy"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, w.Code)
+		}
+	}
+
+	w := get("sk_noisy")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+
+	if w := get("sk_quiet"); w.Code != http.StatusOK {
+		t.Errorf("expected other key to be unaffected, got %d", w.Code)
+	}
+
+	now = now.Add(time.Second)
+	if w := get("sk_noisy"); w.Code != http.StatusOK {
+		t.Errorf("expected a token after refill, got %d", w.Code)
+	}
+}
+
+func TestRateLimiter_ThrottlesPublicRoutesByIP(t *testing.T) {
+	limiter := NewRateLimiter(1, 3)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	get := func(remoteAddr string) int {
+		req := httptest.NewRequest("GET", "/status.json", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := get("203.0.113.7:4000"); code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, code)
+		}
+	}
+	if code := get("203.0.113.7:4001"); code != http.StatusTooManyRequests {
+		t.Errorf("expected same IP on another port to be throttled, got %d", code)
+	}
+	if code := get("198.51.100.1:4000"); code != http.StatusOK {
+		t.Errorf("expected other IP to be allowed, got %d", code)
+	}
+}
+
+func TestRateLimiter_EvictsIdleBuckets(t *testing.T) {
+	limiter := NewRateLimiter(60, 2)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter.SetClock(application.ClockFunc(func() time.Time { return now }))
+
+	limiter.Allow("ip:203.0.113.7")
+	limiter.Allow("ip:198.51.100.1")
+
+	now = now.Add(rateLimitEvictInterval)
+	limiter.Allow("ip:192.0.2.1")
+
+	if len(limiter.buckets) != 1 {
+		t.Errorf("expected idle buckets to be evicted, got %d buckets", len(limiter.buckets))
+	}
+}
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// requestLatencyBuckets are the histogram upper bounds in seconds
+var requestLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// unmatchedRoute labels requests that did not match any route, so that
+// arbitrary paths do not blow up the label cardinality
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with a non-standard method, which clients
+// choose freely and would otherwise blow up the label cardinality too
+const otherMethod = "OTHER"
+
+// standardMethods are the request methods recorded under their own label
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// methodLabel returns the label value for a request method
+func methodLabel(method string) string {
+	if standardMethods[method] {
+		return method
+	}
+	return otherMethod
+}
+
+type requestKey struct {
+	method string
+	route  string
+	status int
+}
+
+type latencyKey struct {
+	method string
+	route  string
+}
+
+type latencyHistogram struct {
+	buckets []int64 // cumulative counts per requestLatencyBuckets entry
+	count   int64
+	sum     float64
+}
+
+// requestMetrics records request counts and latency by route and status
+type requestMetrics struct {
+	mu       sync.Mutex
+	requests map[requestKey]int64
+	latency  map[latencyKey]*latencyHistogram
+}
+
+func newRequestMetrics() *requestMetrics {
+	return &requestMetrics{
+		requests: make(map[requestKey]int64),
+		latency:  make(map[latencyKey]*latencyHistogram),
+	}
+}
+
+// Middleware instruments every request passing through the router
+func (m *requestMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// The route pattern is only known once chi has finished routing
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		m.observe(methodLabel(r.Method), route, status, time.Since(start))
+	})
+}
+
+func (m *requestMetrics) observe(method, route string, status int, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method: method, route: route, status: status}]++
+
+	key := latencyKey{method: method, route: route}
+	h, ok := m.latency[key]
+	if !ok {
+		h = &latencyHistogram{buckets: make([]int64, len(requestLatencyBuckets))}
+		m.latency[key] = h
+	}
+	seconds := elapsed.Seconds()
+	for i, bound := range requestLatencyBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// RequestCount returns how many requests were recorded for the given labels
+func (m *requestMetrics) RequestCount(method, route string, status int) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[requestKey{method: method, route: route, status: status}]
+}
+
+// WritePrometheus writes the recorded metrics in Prometheus text format
+func (m *requestMetrics) WritePrometheus(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Nothing observed yet; skip the headers rather than declare empty families
+	if len(m.requests) == 0 {
+		return
+	}
+
+	io.WriteString(w, "# HELP status_incident_http_requests_total Total HTTP requests by method, route and status\n")
+	io.WriteString(w, "# TYPE status_incident_http_requests_total counter\n")
+
+	reqKeys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		reqKeys = append(reqKeys, k)
+	}
+	sort.Slice(reqKeys, func(i, j int) bool {
+		if reqKeys[i].route != reqKeys[j].route {
+			return reqKeys[i].route < reqKeys[j].route
+		}
+		if reqKeys[i].method != reqKeys[j].method {
+			return reqKeys[i].method < reqKeys[j].method
+		}
+		return reqKeys[i].status < reqKeys[j].status
+	})
+	for _, k := range reqKeys {
+		io.WriteString(w, formatMetricLine("status_incident_http_requests_total", m.requests[k],
+			"method", k.method,
+			"route", k.route,
+			"status", strconv.Itoa(k.status)))
+	}
+
+	io.WriteString(w, "# HELP status_incident_http_request_duration_seconds HTTP request latency by method and route\n")
+	io.WriteString(w, "# TYPE status_incident_http_request_duration_seconds histogram\n")
+
+	latKeys := make([]latencyKey, 0, len(m.latency))
+	for k := range m.latency {
+		latKeys = append(latKeys, k)
+	}
+	sort.Slice(latKeys, func(i, j int) bool {
+		if latKeys[i].route != latKeys[j].route {
+			return latKeys[i].route < latKeys[j].route
+		}
+		return latKeys[i].method < latKeys[j].method
+	})
+	for _, k := range latKeys {
+		h := m.latency[k]
+		for i, bound := range requestLatencyBuckets {
+			io.WriteString(w, formatMetricLine("status_incident_http_request_duration_seconds_bucket", h.buckets[i],
+				"method", k.method,
+				"route", k.route,
+				"le", strconv.FormatFloat(bound, 'f', -1, 64)))
+		}
+		io.WriteString(w, formatMetricLine("status_incident_http_request_duration_seconds_bucket", h.count,
+			"method", k.method,
+			"route", k.route,
+			"le", "+Inf"))
+		io.WriteString(w, formatMetricLine("status_incident_http_request_duration_seconds_sum",
+			strconv.FormatFloat(h.sum, 'f', -1, 64),
+			"method", k.method,
+			"route", k.route))
+		io.WriteString(w, formatMetricLine("status_incident_http_request_duration_seconds_count", h.count,
+			"method", k.method,
+			"route", k.route))
+	}
+}
@@ -1,8 +1,12 @@
 package http
 
 import (
+	"html/template"
 	"net/http"
 	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -11,20 +15,30 @@ import (
 
 // Server represents the HTTP server
 type Server struct {
-	router             *chi.Mux
-	systemService      *application.SystemService
-	depService         *application.DependencyService
-	heartbeatService   *application.HeartbeatService
-	analyticsService   *application.AnalyticsService
-	maintenanceService *application.MaintenanceService
-	incidentService    *application.IncidentService
-	latencyService     *application.LatencyService
-	slaService         *application.SLAService
-	webhookHandlers    *WebhookHandlers
-	slaHandlers        *SLAHandlers
-	apiKeyHandlers     *APIKeyHandlers
-	authMiddleware     *AuthMiddleware
-	templateDir        string
+	router               *chi.Mux
+	systemService        *application.SystemService
+	depService           *application.DependencyService
+	heartbeatService     *application.HeartbeatService
+	analyticsService     *application.AnalyticsService
+	maintenanceService   *application.MaintenanceService
+	incidentService      *application.IncidentService
+	latencyService       *application.LatencyService
+	slaService           *application.SLAService
+	configService        *application.ConfigService
+	eventBroker          *application.StatusEventBroker
+	webhookHandlers      *WebhookHandlers
+	slaHandlers          *SLAHandlers
+	apiKeyHandlers       *APIKeyHandlers
+	subscriptionHandlers *SubscriptionHandlers
+	authMiddleware       *AuthMiddleware
+	rateLimiter          *RateLimiter
+	requestMetrics       *requestMetrics
+	branding             BrandingConfig
+	brandingCSS          template.CSS
+	templateDir          string
+	templates            map[string]*template.Template
+	templatesMu          sync.Mutex
+	wsPingInterval       time.Duration // 0 uses defaultWSPingInterval
 }
 
 // NewServer creates a new HTTP server
@@ -40,35 +54,54 @@ func NewServer(
 	webhookHandlers *WebhookHandlers,
 	slaHandlers *SLAHandlers,
 	apiKeyHandlers *APIKeyHandlers,
+	subscriptionHandlers *SubscriptionHandlers,
 	authMiddleware *AuthMiddleware,
+	rateLimiter *RateLimiter,
 	templateDir string,
 ) *Server {
 	s := &Server{
-		router:             chi.NewRouter(),
-		systemService:      systemService,
-		depService:         depService,
-		heartbeatService:   heartbeatService,
-		analyticsService:   analyticsService,
-		maintenanceService: maintenanceService,
-		incidentService:    incidentService,
-		latencyService:     latencyService,
-		slaService:         slaService,
-		webhookHandlers:    webhookHandlers,
-		slaHandlers:        slaHandlers,
-		apiKeyHandlers:     apiKeyHandlers,
-		authMiddleware:     authMiddleware,
-		templateDir:        templateDir,
+		router:               chi.NewRouter(),
+		systemService:        systemService,
+		depService:           depService,
+		heartbeatService:     heartbeatService,
+		analyticsService:     analyticsService,
+		maintenanceService:   maintenanceService,
+		incidentService:      incidentService,
+		latencyService:       latencyService,
+		slaService:           slaService,
+		webhookHandlers:      webhookHandlers,
+		slaHandlers:          slaHandlers,
+		apiKeyHandlers:       apiKeyHandlers,
+		subscriptionHandlers: subscriptionHandlers,
+		authMiddleware:       authMiddleware,
+		rateLimiter:          rateLimiter,
+		requestMetrics:       newRequestMetrics(),
+		templateDir:          templateDir,
 	}
 
 	s.setupRoutes()
 	return s
 }
 
+// SetConfigService enables configuration export and import
+func (s *Server) SetConfigService(cs *application.ConfigService) {
+	s.configService = cs
+}
+
+// SetEventBroker enables the live status event stream
+func (s *Server) SetEventBroker(b *application.StatusEventBroker) {
+	s.eventBroker = b
+}
+
 func (s *Server) setupRoutes() {
 	// Middleware
 	s.router.Use(middleware.Logger)
 	s.router.Use(middleware.Recoverer)
 	s.router.Use(middleware.RealIP)
+	if s.requestMetrics != nil {
+		s.router.Use(s.requestMetrics.Middleware)
+	}
+	s.router.Use(tracingMiddleware)
 
 	// Static files
 	fs := http.FileServer(http.Dir("static"))
@@ -79,10 +112,21 @@ func (s *Server) setupRoutes() {
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
-	// Public routes (no auth required)
-	s.router.Get("/status", s.handlePublicStatus)
+	// Public routes (no auth required, rate limited by IP)
+	s.router.With(s.rateLimited).Get("/status", s.handlePublicStatus)
+	s.router.With(s.rateLimited).Get("/status.json", s.handlePublicStatusJSON)
+	s.router.With(s.rateLimited).Get("/status/{slug}", s.handleSystemPublicStatus)
+	s.router.With(s.rateLimited).Get("/feed.xml", s.handleIncidentFeed)
+	s.router.With(s.rateLimited).Get("/api/systems/{id}/badge.svg", s.apiGetSystemBadge)
 	s.router.Get("/metrics", s.handleMetrics)
 
+	// Public email subscriptions (optional)
+	if s.subscriptionHandlers != nil {
+		s.router.With(jsonContentType, s.rateLimited).Post("/api/subscribe", s.subscriptionHandlers.Subscribe)
+		s.router.With(jsonContentType, s.rateLimited).Get("/api/subscribe/confirm", s.subscriptionHandlers.Confirm)
+		s.router.With(jsonContentType, s.rateLimited).Get("/api/subscribe/unsubscribe", s.subscriptionHandlers.Unsubscribe)
+	}
+
 	// Auth routes
 	if s.authMiddleware != nil && s.authMiddleware.IsEnabled() {
 		s.router.Get("/login", s.authMiddleware.LoginHandler)
@@ -101,6 +145,16 @@ func (s *Server) setupRoutes() {
 		r.Get("/logs", s.handleLogs)
 		r.Get("/analytics", s.handleAnalyticsPage)
 		r.Get("/sla", s.handleSLAPage)
+		r.Get("/incidents/{id}/acknowledge", s.handleIncidentAcknowledgePage)
+	})
+
+	// Live updates over WebSocket, authenticated like the API
+	s.router.Group(func(r chi.Router) {
+		if s.authMiddleware != nil && s.authMiddleware.IsEnabled() {
+			r.Use(s.authMiddleware.RequireAPIAuth)
+		}
+		r.Use(s.rateLimited)
+		r.Get("/ws", s.handleWebSocket)
 	})
 
 	// REST API routes
@@ -108,24 +162,34 @@ func (s *Server) setupRoutes() {
 		r.Use(jsonContentType)
 		if s.authMiddleware != nil && s.authMiddleware.IsEnabled() {
 			r.Use(s.authMiddleware.RequireAPIAuth)
+			r.Use(s.authMiddleware.RequireMethodScope)
 		}
+		// After auth, so each API key gets its own bucket
+		r.Use(s.rateLimited)
 
 		// Systems
 		r.Get("/systems", s.apiGetSystems)
 		r.Post("/systems", s.apiCreateSystem)
+		r.Get("/systems/graph", s.apiGetSystemGraph)
+		r.Post("/systems/status/bulk", s.apiBulkUpdateSystemStatus)
 		r.Get("/systems/{id}", s.apiGetSystem)
 		r.Put("/systems/{id}", s.apiUpdateSystem)
 		r.Delete("/systems/{id}", s.apiDeleteSystem)
 		r.Post("/systems/{id}/status", s.apiUpdateSystemStatus)
+		r.Post("/systems/{id}/depends-on", s.apiAddSystemDependency)
+		r.Delete("/systems/{id}/depends-on/{dependsOnId}", s.apiRemoveSystemDependency)
 		r.Get("/systems/{id}/logs", s.apiGetSystemLogs)
+		r.Get("/systems/{id}/logs/export", s.apiExportSystemLogsCSV)
 		r.Get("/systems/{id}/analytics", s.apiGetSystemAnalytics)
 
 		// Dependencies
 		r.Get("/systems/{systemId}/dependencies", s.apiGetDependencies)
 		r.Post("/systems/{systemId}/dependencies", s.apiCreateDependency)
+		r.Post("/systems/{systemId}/dependencies/clone-from/{sourceSystemId}", s.apiCloneDependencies)
 		r.Get("/dependencies/{id}", s.apiGetDependency)
 		r.Put("/dependencies/{id}", s.apiUpdateDependency)
 		r.Delete("/dependencies/{id}", s.apiDeleteDependency)
+		r.Post("/dependencies/status/bulk", s.apiBulkUpdateDependencyStatus)
 		r.Post("/dependencies/{id}/status", s.apiUpdateDependencyStatus)
 		r.Post("/dependencies/{id}/heartbeat", s.apiSetHeartbeat)
 		r.Delete("/dependencies/{id}/heartbeat", s.apiClearHeartbeat)
@@ -135,16 +199,32 @@ func (s *Server) setupRoutes() {
 		r.Get("/dependencies/{id}/latency", s.apiGetDependencyLatency)
 		r.Get("/dependencies/{id}/uptime", s.apiGetDependencyUptime)
 
+		// Status summary for external dashboards
+		r.Get("/status/summary", s.apiGetStatusSummary)
+
+		// Live status changes (Server-Sent Events)
+		r.Get("/events", s.apiStatusEvents)
+
 		// Logs
 		r.Get("/logs", s.apiGetAllLogs)
+		r.Get("/logs/export", s.apiExportLogsCSV)
 
 		// Analytics
 		r.Get("/analytics", s.apiGetOverallAnalytics)
+		r.Get("/analytics/uptime", s.apiGetAnalyticsUptime)
+		r.Get("/analytics/leaderboard", s.apiGetAnalyticsLeaderboard)
 
-		// Export/Import
-		r.Get("/export", s.apiExportAll)
+		// Export/Import. The full configuration carries webhook secrets and
+		// heartbeat credentials, and a replace import wipes everything, so
+		// both need an admin key
 		r.Get("/export/logs", s.apiExportLogs)
-		r.Post("/import", s.apiImportAll)
+		r.Group(func(r chi.Router) {
+			if s.authMiddleware != nil {
+				r.Use(s.authMiddleware.RequireScope(domain.ScopeAdmin))
+			}
+			r.Get("/export", s.apiExportAll)
+			r.Post("/import", s.apiImportAll)
+		})
 
 		// Webhooks
 		r.Get("/webhooks", s.webhookHandlers.ListWebhooks)
@@ -153,6 +233,8 @@ func (s *Server) setupRoutes() {
 		r.Put("/webhooks/{id}", s.webhookHandlers.UpdateWebhook)
 		r.Delete("/webhooks/{id}", s.webhookHandlers.DeleteWebhook)
 		r.Post("/webhooks/{id}/test", s.webhookHandlers.TestWebhook)
+		r.Get("/webhooks/{id}/deliveries", s.webhookHandlers.GetWebhookDeliveries)
+		r.Post("/systems/{id}/subscribe", s.webhookHandlers.SubscribeToSystem)
 
 		// Maintenance windows
 		r.Get("/maintenances", s.apiGetMaintenances)
@@ -168,21 +250,37 @@ func (s *Server) setupRoutes() {
 		r.Get("/incidents", s.apiGetIncidents)
 		r.Post("/incidents", s.apiCreateIncident)
 		r.Get("/incidents/active", s.apiGetActiveIncidents)
+		r.Get("/incidents/scheduled", s.apiGetScheduledIncidents)
 		r.Get("/incidents/recent", s.apiGetRecentIncidents)
+		r.Post("/incidents/import", s.apiImportIncidents)
+		r.Post("/incidents/from-template/{templateId}", s.apiCreateIncidentFromTemplate)
 		r.Get("/incidents/{id}", s.apiGetIncident)
 		r.Delete("/incidents/{id}", s.apiDeleteIncident)
+		r.Put("/incidents/{id}/components", s.apiUpdateIncidentComponents)
 		r.Post("/incidents/{id}/acknowledge", s.apiAcknowledgeIncident)
 		r.Post("/incidents/{id}/status", s.apiUpdateIncidentStatus)
 		r.Post("/incidents/{id}/resolve", s.apiResolveIncident)
 		r.Get("/incidents/{id}/updates", s.apiGetIncidentUpdates)
 		r.Post("/incidents/{id}/updates", s.apiAddIncidentUpdate)
 
+		// Incident templates
+		r.Get("/incident-templates", s.apiGetIncidentTemplates)
+		r.Post("/incident-templates", s.apiCreateIncidentTemplate)
+		r.Get("/incident-templates/{id}", s.apiGetIncidentTemplate)
+		r.Put("/incident-templates/{id}", s.apiUpdateIncidentTemplate)
+		r.Delete("/incident-templates/{id}", s.apiDeleteIncidentTemplate)
+
 		// API Keys (only if auth is enabled)
 		if s.apiKeyHandlers != nil {
-			r.Get("/apikeys", s.apiKeyHandlers.ListAPIKeys)
-			r.Post("/apikeys", s.apiKeyHandlers.CreateAPIKey)
-			r.Delete("/apikeys/{id}", s.apiKeyHandlers.DeleteAPIKey)
-			r.Put("/apikeys/{id}/toggle", s.apiKeyHandlers.ToggleAPIKey)
+			r.Group(func(r chi.Router) {
+				if s.authMiddleware != nil {
+					r.Use(s.authMiddleware.RequireScope(domain.ScopeAdmin))
+				}
+				r.Get("/apikeys", s.apiKeyHandlers.ListAPIKeys)
+				r.Post("/apikeys", s.apiKeyHandlers.CreateAPIKey)
+				r.Delete("/apikeys/{id}", s.apiKeyHandlers.DeleteAPIKey)
+				r.Put("/apikeys/{id}/toggle", s.apiKeyHandlers.ToggleAPIKey)
+			})
 		}
 
 		// SLA Reports and Breaches
@@ -193,9 +291,11 @@ func (s *Server) setupRoutes() {
 			r.Delete("/sla/reports/{id}", s.slaHandlers.DeleteReport)
 			r.Get("/sla/breaches", s.slaHandlers.GetBreaches)
 			r.Post("/sla/breaches/check", s.slaHandlers.CheckBreaches)
+			r.Post("/sla/breaches/acknowledge", s.slaHandlers.AcknowledgeBreaches)
 			r.Post("/sla/breaches/{id}/acknowledge", s.slaHandlers.AcknowledgeBreach)
 			r.Get("/systems/{id}/sla", s.slaHandlers.GetSystemSLA)
 			r.Put("/systems/{id}/sla-target", s.slaHandlers.UpdateSystemSLATarget)
+			r.Put("/systems/{id}/response-time-target", s.slaHandlers.UpdateSystemResponseTimeTarget)
 			r.Get("/systems/{id}/sla/breaches", s.slaHandlers.GetSystemBreaches)
 		}
 	})
@@ -206,6 +306,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.router.ServeHTTP(w, r)
 }
 
+// rateLimited applies the rate limiter, or passes requests through when rate limiting is off
+func (s *Server) rateLimited(next http.Handler) http.Handler {
+	if s.rateLimiter == nil {
+		return next
+	}
+	return s.rateLimiter.Middleware(next)
+}
+
 func jsonContentType(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionSigner_IssueAndVerify(t *testing.T) {
+	signer := newSessionSigner([]byte("secret"), time.Hour)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	token, expiresAt, err := signer.Issue("admin")
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if !expiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, now.Add(time.Hour))
+	}
+	if parts := strings.Split(token, "."); len(parts) != 3 {
+		t.Fatalf("expected a three-part JWT, got %q", token)
+	}
+
+	subject, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if subject != "admin" {
+		t.Errorf("subject = %q, want %q", subject, "admin")
+	}
+}
+
+func TestSessionSigner_RejectsExpired(t *testing.T) {
+	signer := newSessionSigner([]byte("secret"), time.Hour)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	token, _, _ := signer.Issue("admin")
+
+	now = now.Add(time.Hour)
+	if _, err := signer.Verify(token); !errors.Is(err, errTokenExpired) {
+		t.Errorf("Verify() error = %v, want %v", err, errTokenExpired)
+	}
+}
+
+func TestSessionSigner_RejectsTampering(t *testing.T) {
+	signer := newSessionSigner([]byte("secret"), time.Hour)
+	token, _, _ := signer.Issue("admin")
+	parts := strings.Split(token, ".")
+
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","iat":0,"exp":99999999999}`))
+	other := newSessionSigner([]byte("other-secret"), time.Hour)
+	otherToken, _, _ := other.Issue("admin")
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"modified payload", parts[0] + "." + forgedPayload + "." + parts[2], errTokenSignature},
+		{"modified signature", parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2])), errTokenSignature},
+		{"signed with another secret", otherToken, errTokenSignature},
+		{"alg none", base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + ".", errTokenMalformed},
+		{"not a JWT", "YWRtaW46c2VjcmV0", errTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
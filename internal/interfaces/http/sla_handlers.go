@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"status-incident/internal/application"
+	"status-incident/internal/domain"
 )
 
 // SLAHandlers handles SLA-related HTTP requests
@@ -22,14 +23,67 @@ func NewSLAHandlers(slaService *application.SLAService) *SLAHandlers {
 	}
 }
 
+// generateReportRequest is the body of POST /api/sla/reports; omitted
+// fields default to "SLA Report", monthly and "system"
+type generateReportRequest struct {
+	Title       string `json:"title" example:"March SLA"`
+	Period      string `json:"period" example:"monthly"` // daily, weekly, monthly, quarterly or yearly
+	GeneratedBy string `json:"generated_by" example:"ops"`
+}
+
+// acknowledgeBreachRequest names who acknowledged a breach (default "system")
+type acknowledgeBreachRequest struct {
+	AckedBy string `json:"acked_by" example:"alice"`
+}
+
+// acknowledgeBreachesRequest acknowledges up to maxBulkAcknowledge breaches
+type acknowledgeBreachesRequest struct {
+	IDs     []int64 `json:"ids" example:"1,2,3"`
+	AckedBy string  `json:"acked_by" example:"alice"`
+}
+
+// actionStatusResponse confirms an action that returns no entity
+type actionStatusResponse struct {
+	Status string `json:"status" example:"acknowledged"`
+}
+
+// breachCheckResponse lists the breaches found by a manual check
+type breachCheckResponse struct {
+	BreachesFound int                      `json:"breaches_found"`
+	Breaches      []*domain.SLABreachEvent `json:"breaches"`
+}
+
+type slaTargetRequest struct {
+	SLATarget float64 `json:"sla_target" example:"99.95"`
+}
+
+type slaTargetResponse struct {
+	Status    string  `json:"status" example:"updated"`
+	SLATarget float64 `json:"sla_target" example:"99.95"`
+}
+
+type responseTimeTargetRequest struct {
+	ResponseTimeTargetMs int64 `json:"response_time_target_ms" example:"300"`
+}
+
+type responseTimeTargetResponse struct {
+	Status               string `json:"status" example:"updated"`
+	ResponseTimeTargetMs int64  `json:"response_time_target_ms" example:"300"`
+}
+
 // GenerateReport creates a new SLA report
 // POST /api/sla/reports
+// @Summary Generate an SLA report
+// @Description Compute uptime and SLA compliance of every system for the period and store the report
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param report body generateReportRequest true "Report options"
+// @Success 200 {object} domain.SLAReport
+// @Failure 400 {object} errorResponse
+// @Router /sla/reports [post]
 func (h *SLAHandlers) GenerateReport(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Title       string `json:"title"`
-		Period      string `json:"period"`
-		GeneratedBy string `json:"generated_by"`
-	}
+	var req generateReportRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
@@ -57,6 +111,12 @@ func (h *SLAHandlers) GenerateReport(w http.ResponseWriter, r *http.Request) {
 
 // GetReports retrieves all SLA reports
 // GET /api/sla/reports
+// @Summary List SLA reports
+// @Tags sla
+// @Produce json
+// @Param limit query int false "Maximum number of reports"
+// @Success 200 {array} domain.SLAReport
+// @Router /sla/reports [get]
 func (h *SLAHandlers) GetReports(w http.ResponseWriter, r *http.Request) {
 	limit := 50
 	if l := r.URL.Query().Get("limit"); l != "" {
@@ -76,6 +136,13 @@ func (h *SLAHandlers) GetReports(w http.ResponseWriter, r *http.Request) {
 
 // GetReport retrieves a single SLA report
 // GET /api/sla/reports/{id}
+// @Summary Get an SLA report
+// @Tags sla
+// @Produce json
+// @Param id path int true "Report ID"
+// @Success 200 {object} domain.SLAReport
+// @Failure 404 {object} errorResponse
+// @Router /sla/reports/{id} [get]
 func (h *SLAHandlers) GetReport(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -99,6 +166,12 @@ func (h *SLAHandlers) GetReport(w http.ResponseWriter, r *http.Request) {
 
 // DeleteReport removes an SLA report
 // DELETE /api/sla/reports/{id}
+// @Summary Delete an SLA report
+// @Tags sla
+// @Param id path int true "Report ID"
+// @Success 204
+// @Failure 400 {object} errorResponse
+// @Router /sla/reports/{id} [delete]
 func (h *SLAHandlers) DeleteReport(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -117,6 +190,13 @@ func (h *SLAHandlers) DeleteReport(w http.ResponseWriter, r *http.Request) {
 
 // GetBreaches retrieves all SLA breaches
 // GET /api/sla/breaches
+// @Summary List SLA breaches
+// @Tags sla
+// @Produce json
+// @Param limit query int false "Maximum number of breaches"
+// @Param unacknowledged query bool false "Only breaches that were not acknowledged"
+// @Success 200 {array} domain.SLABreachEvent
+// @Router /sla/breaches [get]
 func (h *SLAHandlers) GetBreaches(w http.ResponseWriter, r *http.Request) {
 	limit := 50
 	if l := r.URL.Query().Get("limit"); l != "" {
@@ -147,6 +227,15 @@ func (h *SLAHandlers) GetBreaches(w http.ResponseWriter, r *http.Request) {
 
 // AcknowledgeBreach marks a breach as acknowledged
 // POST /api/sla/breaches/{id}/acknowledge
+// @Summary Acknowledge an SLA breach
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param id path int true "Breach ID"
+// @Param ack body acknowledgeBreachRequest false "Who acknowledged it"
+// @Success 200 {object} actionStatusResponse
+// @Failure 400 {object} errorResponse
+// @Router /sla/breaches/{id}/acknowledge [post]
 func (h *SLAHandlers) AcknowledgeBreach(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -155,10 +244,7 @@ func (h *SLAHandlers) AcknowledgeBreach(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var req struct {
-		AckedBy string `json:"acked_by"`
-	}
-
+	var req acknowledgeBreachRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		req.AckedBy = "system"
 	}
@@ -171,11 +257,55 @@ func (h *SLAHandlers) AcknowledgeBreach(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "acknowledged"})
+	writeJSON(w, http.StatusOK, actionStatusResponse{Status: "acknowledged"})
+}
+
+// maxBulkAcknowledge caps how many breaches one bulk request may acknowledge
+const maxBulkAcknowledge = 500
+
+// AcknowledgeBreaches marks several breaches as acknowledged and reports
+// which succeeded and which failed
+// POST /api/sla/breaches/acknowledge
+// @Summary Acknowledge several SLA breaches
+// @Description Acknowledge up to 500 breaches at once; the response lists which IDs succeeded and which failed
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param ack body acknowledgeBreachesRequest true "Breach IDs"
+// @Success 200 {object} application.BreachAckSummary
+// @Failure 400 {object} errorResponse
+// @Router /sla/breaches/acknowledge [post]
+func (h *SLAHandlers) AcknowledgeBreaches(w http.ResponseWriter, r *http.Request) {
+	var req acknowledgeBreachesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "ids must contain at least one breach ID")
+		return
+	}
+	if len(req.IDs) > maxBulkAcknowledge {
+		writeError(w, http.StatusBadRequest, "Too many breach IDs (max 500)")
+		return
+	}
+	if req.AckedBy == "" {
+		req.AckedBy = "system"
+	}
+
+	summary := h.slaService.AcknowledgeBreaches(r.Context(), req.IDs, req.AckedBy)
+
+	writeJSON(w, http.StatusOK, summary)
 }
 
 // CheckBreaches manually triggers breach check
 // POST /api/sla/breaches/check
+// @Summary Check for SLA breaches now
+// @Tags sla
+// @Produce json
+// @Param period query string false "Period to check (default monthly)"
+// @Success 200 {object} breachCheckResponse
+// @Router /sla/breaches/check [post]
 func (h *SLAHandlers) CheckBreaches(w http.ResponseWriter, r *http.Request) {
 	period := r.URL.Query().Get("period")
 	if period == "" {
@@ -188,14 +318,24 @@ func (h *SLAHandlers) CheckBreaches(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"breaches_found": len(breaches),
-		"breaches":       breaches,
+	writeJSON(w, http.StatusOK, breachCheckResponse{
+		BreachesFound: len(breaches),
+		Breaches:      breaches,
 	})
 }
 
 // GetSystemSLA returns SLA status for a system
-// GET /api/systems/{id}/sla
+// GET /api/systems/{id}/sla?period=monthly&basis=dependencies
+// @Summary Get the SLA status of a system
+// @Tags sla
+// @Produce json
+// @Param id path int true "System ID"
+// @Param period query string false "Period (default monthly)"
+// @Param basis query string false "system (default) or dependencies for the weighted dependency SLA"
+// @Success 200 {object} domain.SystemSLAReport
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /systems/{id}/sla [get]
 func (h *SLAHandlers) GetSystemSLA(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -209,7 +349,16 @@ func (h *SLAHandlers) GetSystemSLA(w http.ResponseWriter, r *http.Request) {
 		period = "monthly"
 	}
 
-	slaStatus, err := h.slaService.GetSystemSLAStatus(r.Context(), id, period)
+	var slaStatus *domain.SystemSLAReport
+	switch r.URL.Query().Get("basis") {
+	case "", application.SLABasisSystem:
+		slaStatus, err = h.slaService.GetSystemSLAStatus(r.Context(), id, period)
+	case application.SLABasisDependencies:
+		slaStatus, err = h.slaService.GetWeightedSystemSLAStatus(r.Context(), id, period)
+	default:
+		writeError(w, http.StatusBadRequest, "basis must be system or dependencies")
+		return
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			writeError(w, http.StatusNotFound, "System not found")
@@ -224,6 +373,16 @@ func (h *SLAHandlers) GetSystemSLA(w http.ResponseWriter, r *http.Request) {
 
 // UpdateSystemSLATarget updates the SLA target for a system
 // PUT /api/systems/{id}/sla-target
+// @Summary Set the SLA target of a system
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param id path int true "System ID"
+// @Param target body slaTargetRequest true "SLA target percentage (0-100]"
+// @Success 200 {object} slaTargetResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /systems/{id}/sla-target [put]
 func (h *SLAHandlers) UpdateSystemSLATarget(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -232,10 +391,7 @@ func (h *SLAHandlers) UpdateSystemSLATarget(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var req struct {
-		SLATarget float64 `json:"sla_target"`
-	}
-
+	var req slaTargetRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
@@ -255,14 +411,69 @@ func (h *SLAHandlers) UpdateSystemSLATarget(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"status":     "updated",
-		"sla_target": req.SLATarget,
+	writeJSON(w, http.StatusOK, slaTargetResponse{
+		Status:    "updated",
+		SLATarget: req.SLATarget,
+	})
+}
+
+// UpdateSystemResponseTimeTarget updates the p95 response time target for a system
+// PUT /api/systems/{id}/response-time-target
+// @Summary Set the response time target of a system
+// @Description Set the p95 latency target in milliseconds used for SLA breach detection; 0 removes it
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param id path int true "System ID"
+// @Param target body responseTimeTargetRequest true "Target in milliseconds"
+// @Success 200 {object} responseTimeTargetResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Router /systems/{id}/response-time-target [put]
+func (h *SLAHandlers) UpdateSystemResponseTimeTarget(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid system ID")
+		return
+	}
+
+	var req responseTimeTargetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ResponseTimeTargetMs < 0 {
+		writeError(w, http.StatusBadRequest, "Response time target cannot be negative")
+		return
+	}
+
+	if err := h.slaService.UpdateSystemResponseTimeTarget(r.Context(), id, req.ResponseTimeTargetMs); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "System not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, responseTimeTargetResponse{
+		Status:               "updated",
+		ResponseTimeTargetMs: req.ResponseTimeTargetMs,
 	})
 }
 
 // GetSystemBreaches retrieves breaches for a specific system
 // GET /api/systems/{id}/sla/breaches
+// @Summary List SLA breaches of a system
+// @Tags sla
+// @Produce json
+// @Param id path int true "System ID"
+// @Param limit query int false "Maximum number of breaches"
+// @Success 200 {array} domain.SLABreachEvent
+// @Failure 400 {object} errorResponse
+// @Router /systems/{id}/sla/breaches [get]
 func (h *SLAHandlers) GetSystemBreaches(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MockSLABreachRepository for testing
+type MockSLABreachRepository struct {
+	Breaches map[int64]*domain.SLABreachEvent
+}
+
+func NewMockSLABreachRepository() *MockSLABreachRepository {
+	return &MockSLABreachRepository{
+		Breaches: make(map[int64]*domain.SLABreachEvent),
+	}
+}
+
+func (m *MockSLABreachRepository) Create(ctx context.Context, b *domain.SLABreachEvent) error {
+	b.ID = int64(len(m.Breaches) + 1)
+	m.Breaches[b.ID] = b
+	return nil
+}
+
+func (m *MockSLABreachRepository) GetByID(ctx context.Context, id int64) (*domain.SLABreachEvent, error) {
+	return m.Breaches[id], nil
+}
+
+func (m *MockSLABreachRepository) GetAll(ctx context.Context, limit int) ([]*domain.SLABreachEvent, error) {
+	var result []*domain.SLABreachEvent
+	for _, b := range m.Breaches {
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+func (m *MockSLABreachRepository) GetUnacknowledged(ctx context.Context) ([]*domain.SLABreachEvent, error) {
+	var result []*domain.SLABreachEvent
+	for _, b := range m.Breaches {
+		if !b.Acknowledged {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockSLABreachRepository) GetBySystemID(ctx context.Context, systemID int64, limit int) ([]*domain.SLABreachEvent, error) {
+	var result []*domain.SLABreachEvent
+	for _, b := range m.Breaches {
+		if b.SystemID == systemID {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockSLABreachRepository) Acknowledge(ctx context.Context, id int64, ackedBy string) error {
+	b, ok := m.Breaches[id]
+	if !ok {
+		return fmt.Errorf("breach not found: %d", id)
+	}
+	b.Acknowledged = true
+	b.AckedBy = ackedBy
+	now := time.Now()
+	b.AckedAt = &now
+	return nil
+}
+
+func (m *MockSLABreachRepository) GetByPeriod(ctx context.Context, start, end time.Time) ([]*domain.SLABreachEvent, error) {
+	return nil, nil
+}
+
+func (m *MockSLABreachRepository) Cleanup(ctx context.Context, olderThan time.Time) error {
+	for id, b := range m.Breaches {
+		if b.Acknowledged && b.DetectedAt.Before(olderThan) {
+			delete(m.Breaches, id)
+		}
+	}
+	return nil
+}
+
+func setupSLATestRouter() (*chi.Mux, *MockSLABreachRepository) {
+	breachRepo := NewMockSLABreachRepository()
+	slaService := application.NewSLAService(
+		NewMockSystemRepository(),
+		NewMockDependencyRepository(),
+		NewMockAnalyticsRepository(),
+		nil,
+		breachRepo,
+		nil,
+		nil,
+	)
+	handlers := NewSLAHandlers(slaService)
+
+	r := chi.NewRouter()
+	r.Post("/api/sla/breaches/acknowledge", handlers.AcknowledgeBreaches)
+	return r, breachRepo
+}
+
+func TestSLAHandlers_AcknowledgeBreaches(t *testing.T) {
+	router, breachRepo := setupSLATestRouter()
+	for i := 0; i < 3; i++ {
+		breachRepo.Create(context.Background(), &domain.SLABreachEvent{SystemID: 1, Period: "monthly"})
+	}
+
+	body := `{"ids": [1, 3, 42, 3], "acked_by": "ops"}`
+	req := httptest.NewRequest("POST", "/api/sla/breaches/acknowledge", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var summary application.BreachAckSummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if summary.Succeeded != 2 || summary.Failed != 1 {
+		t.Errorf("expected 2 succeeded and 1 failed, got %d and %d", summary.Succeeded, summary.Failed)
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0].ID != 42 || summary.Failures[0].Error == "" {
+		t.Errorf("expected failure for breach 42, got %+v", summary.Failures)
+	}
+
+	for id, want := range map[int64]bool{1: true, 2: false, 3: true} {
+		b := breachRepo.Breaches[id]
+		if b.Acknowledged != want {
+			t.Errorf("breach %d: expected acknowledged=%v, got %v", id, want, b.Acknowledged)
+		}
+		if want && b.AckedBy != "ops" {
+			t.Errorf("breach %d: expected acked_by ops, got %q", id, b.AckedBy)
+		}
+	}
+}
+
+func TestSLAHandlers_AcknowledgeBreaches_DefaultAckedBy(t *testing.T) {
+	router, breachRepo := setupSLATestRouter()
+	breachRepo.Create(context.Background(), &domain.SLABreachEvent{SystemID: 1, Period: "monthly"})
+
+	req := httptest.NewRequest("POST", "/api/sla/breaches/acknowledge", bytes.NewBufferString(`{"ids": [1]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if breachRepo.Breaches[1].AckedBy != "system" {
+		t.Errorf("expected acked_by to default to system, got %q", breachRepo.Breaches[1].AckedBy)
+	}
+}
+
+func TestSLAHandlers_AcknowledgeBreaches_InvalidRequest(t *testing.T) {
+	router, _ := setupSLATestRouter()
+
+	tooMany := make([]int64, maxBulkAcknowledge+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	tooManyBody, _ := json.Marshal(map[string]interface{}{"ids": tooMany})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `{invalid`},
+		{"missing ids", `{"acked_by": "ops"}`},
+		{"empty ids", `{"ids": []}`},
+		{"too many ids", string(tooManyBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/sla/breaches/acknowledge", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
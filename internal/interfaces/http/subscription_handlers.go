@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+)
+
+// SubscriptionHandlers handles public email subscription endpoints
+type SubscriptionHandlers struct {
+	subscriptionService *application.SubscriptionService
+}
+
+// NewSubscriptionHandlers creates a new SubscriptionHandlers
+func NewSubscriptionHandlers(subscriptionService *application.SubscriptionService) *SubscriptionHandlers {
+	return &SubscriptionHandlers{subscriptionService: subscriptionService}
+}
+
+type emailSubscribeRequest struct {
+	Email     string  `json:"email"`
+	SystemIDs []int64 `json:"system_ids,omitempty"`
+}
+
+type subscriptionMessage struct {
+	Message string `json:"message"`
+}
+
+// Subscribe registers an email address and sends a confirmation link.
+// The response is the same for new and existing addresses.
+// POST /api/subscribe
+func (h *SubscriptionHandlers) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req emailSubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if _, err := h.subscriptionService.Subscribe(r.Context(), req.Email, req.SystemIDs); err != nil {
+		if errors.Is(err, domain.ErrInvalidEmail) || strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, subscriptionMessage{
+		Message: "Check your inbox to confirm the subscription",
+	})
+}
+
+// Confirm activates a subscription from the emailed link
+// GET /api/subscribe/confirm?token=...
+func (h *SubscriptionHandlers) Confirm(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.subscriptionService.Confirm(r.Context(), r.URL.Query().Get("token")); err != nil {
+		h.writeTokenError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, subscriptionMessage{Message: "Subscription confirmed"})
+}
+
+// Unsubscribe removes a subscription from the emailed link
+// GET /api/subscribe/unsubscribe?token=...
+func (h *SubscriptionHandlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if err := h.subscriptionService.Unsubscribe(r.Context(), r.URL.Query().Get("token")); err != nil {
+		h.writeTokenError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, subscriptionMessage{Message: "Unsubscribed"})
+}
+
+func (h *SubscriptionHandlers) writeTokenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, application.ErrSubscriberNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
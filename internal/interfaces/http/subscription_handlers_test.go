@@ -0,0 +1,177 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"regexp"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"testing"
+)
+
+// mockSubscriberRepository keeps subscribers in memory
+type mockSubscriberRepository struct {
+	subscribers []*domain.Subscriber
+}
+
+func (m *mockSubscriberRepository) Create(ctx context.Context, s *domain.Subscriber) error {
+	s.ID = int64(len(m.subscribers) + 1)
+	m.subscribers = append(m.subscribers, s)
+	return nil
+}
+
+func (m *mockSubscriberRepository) GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error) {
+	for _, s := range m.subscribers {
+		if s.Email == email {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriberRepository) GetByToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	for _, s := range m.subscribers {
+		if s.Token == token {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriberRepository) GetConfirmed(ctx context.Context) ([]*domain.Subscriber, error) {
+	var result []*domain.Subscriber
+	for _, s := range m.subscribers {
+		if s.Confirmed {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSubscriberRepository) Update(ctx context.Context, s *domain.Subscriber) error {
+	return nil
+}
+
+func (m *mockSubscriberRepository) Delete(ctx context.Context, id int64) error {
+	for i, s := range m.subscribers {
+		if s.ID == id {
+			m.subscribers = append(m.subscribers[:i], m.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// setupSubscriptionServer builds a server with authentication enabled and
+// email subscriptions whose outgoing mail is captured
+func setupSubscriptionServer(t *testing.T) (*Server, *mockSubscriberRepository, *[]string) {
+	t.Helper()
+
+	config, err := domain.ParseSMTPConfig("smtp://mail.example.com?from=status@example.com")
+	if err != nil {
+		t.Fatalf("failed to parse SMTP config: %v", err)
+	}
+
+	repo := &mockSubscriberRepository{}
+	subscriptions := application.NewSubscriptionService(repo, NewMockSystemRepository(), config, "https://status.example.com")
+	var sent []string
+	subscriptions.SetMailSender(func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, string(msg))
+		return nil
+	})
+
+	systemRepo := NewMockSystemRepository()
+	logRepo := NewMockStatusLogRepository()
+	server := NewServer(
+		application.NewSystemService(systemRepo, logRepo),
+		application.NewDependencyService(NewMockDependencyRepository(), logRepo),
+		nil,
+		application.NewAnalyticsService(NewMockAnalyticsRepository(), logRepo),
+		nil, nil, nil, nil, nil, nil, nil,
+		NewSubscriptionHandlers(subscriptions),
+		NewAuthMiddleware(true, "admin", "secret", &stubAPIKeyRepository{}),
+		nil,
+		t.TempDir(),
+	)
+
+	return server, repo, &sent
+}
+
+func TestSubscriptionHandlers_ConfirmFlow(t *testing.T) {
+	server, repo, sent := setupSubscriptionServer(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"email": "user@example.com"})
+	req := httptest.NewRequest("POST", "/api/subscribe", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d without credentials, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	if len(repo.subscribers) != 1 || repo.subscribers[0].Confirmed {
+		t.Fatal("expected one unconfirmed subscriber")
+	}
+	if len(*sent) != 1 {
+		t.Fatalf("expected confirmation email, got %d emails", len(*sent))
+	}
+
+	match := regexp.MustCompile(`/api/subscribe/confirm\?token=([0-9a-f]+)`).FindStringSubmatch((*sent)[0])
+	if match == nil {
+		t.Fatalf("expected confirmation link in email:\n%s", (*sent)[0])
+	}
+
+	req = httptest.NewRequest("GET", "/api/subscribe/confirm?token=bogus", nil)
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown token, got %d", http.StatusNotFound, w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/api/subscribe/confirm?token="+match[1], nil)
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !repo.subscribers[0].Confirmed {
+		t.Error("expected subscriber to be confirmed")
+	}
+
+	req = httptest.NewRequest("GET", "/api/subscribe/unsubscribe?token="+match[1], nil)
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(repo.subscribers) != 0 {
+		t.Error("expected subscriber to be removed")
+	}
+
+	// The rest of the API still requires credentials
+	req = httptest.NewRequest("GET", "/api/systems", nil)
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d for protected API, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestSubscriptionHandlers_Subscribe_InvalidEmail(t *testing.T) {
+	server, repo, sent := setupSubscriptionServer(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"email": "nope"})
+	req := httptest.NewRequest("POST", "/api/subscribe", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if len(repo.subscribers) != 0 || len(*sent) != 0 {
+		t.Error("expected nothing stored or sent for an invalid address")
+	}
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"status-incident/internal/domain"
+)
+
+// Overall status of the public systems, worst first
+const (
+	overallMajorOutage   = "major_outage"
+	overallPartialOutage = "partial_outage"
+	overallDegraded      = "degraded_performance"
+	overallOperational   = "operational"
+)
+
+// overallStatusLabels are the English labels shown on the public page
+var overallStatusLabels = map[string]string{
+	overallMajorOutage:   "Major Outage",
+	overallPartialOutage: "Partial Outage",
+	overallDegraded:      "Degraded Performance",
+	overallOperational:   "All Systems Operational",
+}
+
+// overallStatus rolls the public systems up into one status. A red system is
+// a major outage, a partial system or red dependency a partial one, and a
+// partial dependency counts as degraded. Systems under maintenance are
+// announced separately and do not count.
+func overallStatus(systems []*systemWithDeps) string {
+	partial := false
+	for _, sys := range systems {
+		if sys.UnderMaintenance {
+			continue
+		}
+		if sys.Status == domain.StatusRed {
+			return overallMajorOutage
+		}
+		if sys.Status == domain.StatusPartial {
+			partial = true
+		}
+		for _, dep := range sys.Dependencies {
+			if dep.Status == domain.StatusRed {
+				partial = true
+			}
+		}
+	}
+	if partial {
+		return overallPartialOutage
+	}
+	for _, sys := range systems {
+		if sys.UnderMaintenance {
+			continue
+		}
+		if sys.Status == domain.StatusYellow {
+			return overallDegraded
+		}
+		for _, dep := range sys.Dependencies {
+			if dep.Status == domain.StatusYellow || dep.Status == domain.StatusPartial {
+				return overallDegraded
+			}
+		}
+	}
+	return overallOperational
+}
+
+// statusSummaryResponse is the compact status document for external dashboards
+type statusSummaryResponse struct {
+	OverallStatus      string                  `json:"overall_status"`
+	OverallStatusText  string                  `json:"overall_status_text"`
+	Systems            []systemSummaryResponse `json:"systems"`
+	ActiveIncidents    int                     `json:"active_incidents"`
+	ActiveMaintenances int                     `json:"active_maintenances"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+type systemSummaryResponse struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Uptime24h float64 `json:"uptime_24h"`
+}
+
+// statusSummaryMaxAge is how long clients may cache the summary
+const statusSummaryMaxAge = "max-age=30"
+
+// apiGetStatusSummary returns the public status page data in compact form
+// GET /api/status/summary
+func (s *Server) apiGetStatusSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	systems, err := s.publicSystems(ctx)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	overall := overallStatus(systems)
+	response := statusSummaryResponse{
+		OverallStatus:     overall,
+		OverallStatusText: overallStatusLabels[overall],
+		Systems:           make([]systemSummaryResponse, 0, len(systems)),
+		UpdatedAt:         time.Now().UTC(),
+	}
+	for _, sys := range systems {
+		summary := systemSummaryResponse{
+			ID:     sys.ID,
+			Name:   sys.Name,
+			Status: sys.DisplayStatus().String(),
+		}
+		if analytics, err := s.analyticsService.GetSystemAnalytics(ctx, sys.ID, "24h"); err == nil {
+			summary.Uptime24h = analytics.UptimePercent
+		}
+		response.Systems = append(response.Systems, summary)
+	}
+
+	if s.incidentService != nil {
+		incidents, err := s.incidentService.GetActiveIncidents(ctx)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.ActiveIncidents = len(incidents)
+	}
+	if s.maintenanceService != nil {
+		maintenances, err := s.maintenanceService.GetActiveMaintenances(ctx)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.ActiveMaintenances = len(maintenances)
+	}
+
+	w.Header().Set("Cache-Control", statusSummaryMaxAge)
+	s.respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+)
+
+func getStatusSummary(t *testing.T, server *Server) statusSummaryResponse {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/api/status/summary", nil)
+	w := httptest.NewRecorder()
+	server.apiGetStatusSummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != statusSummaryMaxAge {
+		t.Errorf("expected Cache-Control %q, got %q", statusSummaryMaxAge, cc)
+	}
+	var summary statusSummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return summary
+}
+
+func TestAPIGetStatusSummary_Rollup(t *testing.T) {
+	tests := []struct {
+		name      string
+		systems   []domain.Status
+		depStatus domain.Status
+		want      string
+		wantText  string
+	}{
+		{"no systems", nil, "", overallOperational, "All Systems Operational"},
+		{"all green", []domain.Status{domain.StatusGreen, domain.StatusGreen}, "", overallOperational, "All Systems Operational"},
+		{"one yellow system", []domain.Status{domain.StatusGreen, domain.StatusYellow}, "", overallDegraded, "Degraded Performance"},
+		{"one red system", []domain.Status{domain.StatusGreen, domain.StatusRed}, "", overallMajorOutage, "Major Outage"},
+		{"red beats yellow", []domain.Status{domain.StatusYellow, domain.StatusRed}, "", overallMajorOutage, "Major Outage"},
+		{"yellow dependency", []domain.Status{domain.StatusGreen}, domain.StatusYellow, overallDegraded, "Degraded Performance"},
+		{"red dependency", []domain.Status{domain.StatusGreen}, domain.StatusRed, overallPartialOutage, "Partial Outage"},
+		{"partial system", []domain.Status{domain.StatusYellow, domain.StatusPartial}, "", overallPartialOutage, "Partial Outage"},
+		{"partial dependency", []domain.Status{domain.StatusGreen}, domain.StatusPartial, overallDegraded, "Degraded Performance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, systemRepo, depRepo := setupTestServer()
+			ctx := context.Background()
+			for i, status := range tt.systems {
+				sys, _ := domain.NewSystem("System "+string(rune('A'+i)), "", "", "")
+				sys.UpdateStatus(status)
+				systemRepo.Create(ctx, sys)
+				if tt.depStatus != "" {
+					depRepo.Create(ctx, &domain.Dependency{SystemID: sys.ID, Name: "DB", Status: tt.depStatus})
+				}
+			}
+
+			summary := getStatusSummary(t, server)
+			if summary.OverallStatus != tt.want {
+				t.Errorf("expected overall status %q, got %q", tt.want, summary.OverallStatus)
+			}
+			if summary.OverallStatusText != tt.wantText {
+				t.Errorf("expected overall status text %q, got %q", tt.wantText, summary.OverallStatusText)
+			}
+			if len(summary.Systems) != len(tt.systems) {
+				t.Errorf("expected %d systems, got %d", len(tt.systems), len(summary.Systems))
+			}
+		})
+	}
+}
+
+func TestAPIGetStatusSummary_Counts(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.maintenanceService = application.NewMaintenanceService(&mockMaintenanceRepository{})
+	ctx := context.Background()
+
+	api, _ := domain.NewSystem("API", "", "", "")
+	api.UpdateStatus(domain.StatusRed)
+	systemRepo.Create(ctx, api)
+	web, _ := domain.NewSystem("Web", "", "", "")
+	systemRepo.Create(ctx, web)
+
+	if _, err := server.incidentService.CreateIncident(ctx, "Slow pages", "Looking into it", domain.SeverityMinor, []int64{web.ID}); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	if _, err := server.maintenanceService.CreateMaintenance(ctx, "DB upgrade", "",
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour), []int64{api.ID}); err != nil {
+		t.Fatalf("failed to create maintenance: %v", err)
+	}
+
+	summary := getStatusSummary(t, server)
+	if summary.ActiveIncidents != 1 {
+		t.Errorf("expected 1 active incident, got %d", summary.ActiveIncidents)
+	}
+	if summary.ActiveMaintenances != 1 {
+		t.Errorf("expected 1 active maintenance, got %d", summary.ActiveMaintenances)
+	}
+	// The red system is under maintenance, so it does not count as an outage
+	if summary.OverallStatus != overallOperational {
+		t.Errorf("expected overall status %q, got %q", overallOperational, summary.OverallStatus)
+	}
+	statuses := map[string]string{}
+	for _, sys := range summary.Systems {
+		statuses[sys.Name] = sys.Status
+	}
+	if statuses["API"] != domain.StatusMaintenance.String() || statuses["Web"] != domain.StatusGreen.String() {
+		t.Errorf("unexpected system statuses %v", statuses)
+	}
+	if summary.UpdatedAt.IsZero() {
+		t.Error("expected updated_at to be set")
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"status-incident/internal/infrastructure/sqlite"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_GetSystemsSpanHierarchy(t *testing.T) {
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "status.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	systemRepo := sqlite.NewSystemRepo(db)
+	logRepo := sqlite.NewLogRepo(db)
+	system, _ := domain.NewSystem("API", "", "", "")
+	if err := systemRepo.Create(context.Background(), system); err != nil {
+		t.Fatalf("failed to create system: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := NewServer(
+		application.NewSystemService(systemRepo, logRepo),
+		application.NewDependencyService(sqlite.NewDependencyRepo(db), logRepo),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		t.TempDir(),
+	)
+
+	req := httptest.NewRequest("GET", "/api/systems", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	root, ok := spans["GET /api/systems"]
+	if !ok {
+		t.Fatalf("expected a request span, got %v", spanNames(recorder.Ended()))
+	}
+	if root.Parent().IsValid() {
+		t.Error("expected the request span to be the root")
+	}
+
+	service, ok := spans["SystemService.GetAllSystems"]
+	if !ok {
+		t.Fatalf("expected a service span, got %v", spanNames(recorder.Ended()))
+	}
+	if service.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Error("expected the service span to be a child of the request span")
+	}
+
+	query, ok := spans["sqlite SELECT"]
+	if !ok {
+		t.Fatalf("expected a query span, got %v", spanNames(recorder.Ended()))
+	}
+	if query.Parent().SpanID() != service.SpanContext().SpanID() {
+		t.Error("expected the query span to be a child of the service span")
+	}
+	if query.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Error("expected all spans to share one trace")
+	}
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	return names
+}
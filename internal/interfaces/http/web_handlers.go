@@ -1,11 +1,19 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"html/template"
+	"log"
+	"math"
 	"net/http"
 	"path/filepath"
+	"status-incident/internal/application"
 	"status-incident/internal/domain"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -20,6 +28,33 @@ type systemWithDeps struct {
 	*domain.System
 	Dependencies []*domain.Dependency
 	Analytics    *domain.Analytics
+	// UnderMaintenance is set while an active maintenance window covers
+	// the system; the stored status is left untouched
+	UnderMaintenance bool
+}
+
+// DisplayStatus returns the status to show for the system
+func (s *systemWithDeps) DisplayStatus() domain.Status {
+	return domain.DisplayStatus(s.Status, s.UnderMaintenance)
+}
+
+// markUnderMaintenance flags systems covered by an active maintenance window
+func (s *Server) markUnderMaintenance(ctx context.Context, systems []*systemWithDeps) {
+	if s.maintenanceService == nil || len(systems) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(systems))
+	for i, sys := range systems {
+		ids[i] = sys.ID
+	}
+	under, err := s.maintenanceService.GetSystemsUnderMaintenance(ctx, ids)
+	if err != nil {
+		return
+	}
+	for _, sys := range systems {
+		sys.UnderMaintenance = under[sys.ID]
+	}
 }
 
 type systemDetailData struct {
@@ -29,6 +64,10 @@ type systemDetailData struct {
 	Analytics    *domain.Analytics
 }
 
+type incidentAckData struct {
+	Incident *domain.Incident
+}
+
 type adminData struct {
 	Systems []*systemWithDeps
 }
@@ -60,11 +99,15 @@ type systemWithSLA struct {
 }
 
 type publicStatusData struct {
+	Lang                string
 	Title               string
+	Branding            BrandingConfig
+	CustomCSS           template.CSS
 	Systems             []*systemWithDeps
 	ActiveMaintenance   []*maintenanceInfo
 	UpcomingMaintenance []*maintenanceInfo
 	ActiveIncidents     []*incidentInfo
+	ScheduledIncidents  []*incidentInfo
 	UpdatedAt           string
 }
 
@@ -77,26 +120,67 @@ type maintenanceInfo struct {
 	Status      string
 }
 
+// newMaintenanceInfo formats a window for display in its own time zone,
+// with the zone abbreviation shown
+func newMaintenanceInfo(m *domain.Maintenance) *maintenanceInfo {
+	loc := m.Location()
+	return &maintenanceInfo{
+		ID:          m.ID,
+		Title:       m.Title,
+		Description: m.Description,
+		StartTime:   m.StartTime.In(loc).Format("Jan 2, 15:04 MST"),
+		EndTime:     m.EndTime.In(loc).Format("Jan 2, 15:04 MST"),
+		Status:      string(m.Status),
+	}
+}
+
 type incidentInfo struct {
-	ID        int64
-	Title     string
-	Status    string
-	Severity  string
-	Message   string
-	CreatedAt string
-	UpdatedAt string
+	ID           int64
+	Title        string
+	Status       string
+	Severity     string
+	Message      string
+	Components   []string
+	CreatedAt    string
+	UpdatedAt    string
+	ScheduledFor string
+}
+
+func newIncidentInfo(inc *domain.Incident) *incidentInfo {
+	info := &incidentInfo{
+		ID:         inc.ID,
+		Title:      inc.Title,
+		Status:     string(inc.Status),
+		Severity:   string(inc.Severity),
+		Message:    inc.Message,
+		Components: inc.ComponentNames(),
+		CreatedAt:  inc.CreatedAt.Format("Jan 2, 15:04"),
+		UpdatedAt:  inc.UpdatedAt.Format("Jan 2, 15:04"),
+	}
+	if inc.ScheduledFor != nil {
+		info.ScheduledFor = inc.ScheduledFor.Format("Jan 2, 15:04")
+	}
+	return info
 }
 
-func (s *Server) getTemplateFuncs() template.FuncMap {
+// getTemplateFuncs returns the template helpers with their labels translated
+// into locale
+func (s *Server) getTemplateFuncs(locale string) template.FuncMap {
+	t := func(msg string) string { return translate(locale, msg) }
 	return template.FuncMap{
+		"t": t,
 		"statusClass": func(status domain.Status) string {
 			switch status {
 			case domain.StatusGreen:
 				return "status-green"
 			case domain.StatusYellow:
 				return "status-yellow"
+			case domain.StatusPartial:
+				return "status-partial"
 			case domain.StatusRed:
 				return "status-red"
+			case domain.StatusMaintenance:
+				return "status-maintenance"
 			}
 			return ""
 		},
@@ -106,21 +190,29 @@ func (s *Server) getTemplateFuncs() template.FuncMap {
 				return "operational"
 			case domain.StatusYellow:
 				return "degraded"
+			case domain.StatusPartial:
+				return "partial"
 			case domain.StatusRed:
 				return "outage"
+			case domain.StatusMaintenance:
+				return "maintenance"
 			}
 			return ""
 		},
 		"statusText": func(status domain.Status) string {
 			switch status {
 			case domain.StatusGreen:
-				return "Operational"
+				return t("Operational")
 			case domain.StatusYellow:
-				return "Degraded"
+				return t("Degraded")
+			case domain.StatusPartial:
+				return t("Partial Outage")
 			case domain.StatusRed:
-				return "Outage"
+				return t("Outage")
+			case domain.StatusMaintenance:
+				return t("Under Maintenance")
 			}
-			return "Unknown"
+			return t("Unknown")
 		},
 		"statusTextClass": func(status domain.Status) string {
 			switch status {
@@ -128,30 +220,42 @@ func (s *Server) getTemplateFuncs() template.FuncMap {
 				return "operational"
 			case domain.StatusYellow:
 				return "degraded"
+			case domain.StatusPartial:
+				return "partial"
 			case domain.StatusRed:
 				return "outage"
+			case domain.StatusMaintenance:
+				return "maintenance"
 			}
 			return ""
 		},
+		// displayStatus shows dependencies of a system under maintenance
+		// as under maintenance too
+		"displayStatus": domain.DisplayStatus,
 		"overallStatusClass": func(systems []*systemWithDeps) string {
 			worst := domain.StatusGreen
 			for _, sys := range systems {
+				if sys.UnderMaintenance {
+					continue
+				}
 				if sys.Status == domain.StatusRed {
 					return "status-red"
 				}
-				if sys.Status == domain.StatusYellow && worst != domain.StatusRed {
-					worst = domain.StatusYellow
+				if sys.Status.Severity() > worst.Severity() {
+					worst = sys.Status
 				}
 				for _, dep := range sys.Dependencies {
 					if dep.Status == domain.StatusRed {
 						return "status-red"
 					}
-					if dep.Status == domain.StatusYellow && worst != domain.StatusRed {
-						worst = domain.StatusYellow
+					if dep.Status.Severity() > worst.Severity() {
+						worst = dep.Status
 					}
 				}
 			}
 			switch worst {
+			case domain.StatusPartial:
+				return "status-partial"
 			case domain.StatusYellow:
 				return "status-yellow"
 			default:
@@ -159,27 +263,8 @@ func (s *Server) getTemplateFuncs() template.FuncMap {
 			}
 		},
 		"overallStatusText": func(systems []*systemWithDeps) string {
-			for _, sys := range systems {
-				if sys.Status == domain.StatusRed {
-					return "Major Outage"
-				}
-				for _, dep := range sys.Dependencies {
-					if dep.Status == domain.StatusRed {
-						return "Partial Outage"
-					}
-				}
-			}
-			for _, sys := range systems {
-				if sys.Status == domain.StatusYellow {
-					return "Degraded Performance"
-				}
-				for _, dep := range sys.Dependencies {
-					if dep.Status == domain.StatusYellow {
-						return "Degraded Performance"
-					}
-				}
-			}
-			return "All Systems Operational"
+			// Planned downtime is announced separately, not as an outage
+			return t(overallStatusLabels[overallStatus(systems)])
 		},
 		"formatDuration": func(d interface{}) string {
 			switch v := d.(type) {
@@ -205,16 +290,72 @@ func (s *Server) getTemplateFuncs() template.FuncMap {
 	}
 }
 
+// layoutPages are rendered inside layout.html
+var layoutPages = []string{"dashboard", "system", "admin", "logs", "analytics", "sla", "incident_ack"}
+
+// standalonePages are rendered without the layout
+var standalonePages = []string{"public"}
+
+// PrecompileTemplates parses all page templates so that missing or malformed
+// files are reported at startup instead of on the first request
+func (s *Server) PrecompileTemplates() error {
+	for _, name := range layoutPages {
+		if _, err := s.loadTemplate(name); err != nil {
+			return err
+		}
+	}
+	for _, name := range standalonePages {
+		if _, err := s.loadStandaloneTemplate(name, defaultLocale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Server) loadTemplate(name string) (*template.Template, error) {
-	layoutPath := filepath.Join(s.templateDir, "layout.html")
-	tmplPath := filepath.Join(s.templateDir, name+".html")
+	return s.cachedTemplate(name, func() (*template.Template, error) {
+		layoutPath := filepath.Join(s.templateDir, "layout.html")
+		tmplPath := filepath.Join(s.templateDir, name+".html")
+
+		return template.New("layout.html").Funcs(s.getTemplateFuncs(defaultLocale)).ParseFiles(layoutPath, tmplPath)
+	})
+}
+
+// loadStandaloneTemplate parses a page without the layout. Each locale gets
+// its own cached copy since the helpers are bound at parse time.
+func (s *Server) loadStandaloneTemplate(name, locale string) (*template.Template, error) {
+	return s.cachedTemplate("standalone:"+name+":"+locale, func() (*template.Template, error) {
+		tmplPath := filepath.Join(s.templateDir, name+".html")
+		return template.New(name + ".html").Funcs(s.getTemplateFuncs(locale)).ParseFiles(tmplPath)
+	})
+}
+
+// cachedTemplate returns the template stored under key, parsing it on first use.
+// Failed parses are not cached so a fixed file is picked up on the next request.
+func (s *Server) cachedTemplate(key string, parse func() (*template.Template, error)) (*template.Template, error) {
+	s.templatesMu.Lock()
+	defer s.templatesMu.Unlock()
+
+	if tmpl, ok := s.templates[key]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", key, err)
+	}
 
-	return template.New("layout.html").Funcs(s.getTemplateFuncs()).ParseFiles(layoutPath, tmplPath)
+	if s.templates == nil {
+		s.templates = make(map[string]*template.Template)
+	}
+	s.templates[key] = tmpl
+	return tmpl, nil
 }
 
-func (s *Server) loadStandaloneTemplate(name string) (*template.Template, error) {
-	tmplPath := filepath.Join(s.templateDir, name+".html")
-	return template.New(name + ".html").Funcs(s.getTemplateFuncs()).ParseFiles(tmplPath)
+// templateError logs the template failure and responds without leaking file paths
+func (s *Server) templateError(w http.ResponseWriter, err error) {
+	log.Printf("template error: %v", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 }
 
 func formatDurationNs(ns int64) string {
@@ -267,14 +408,18 @@ func formatDays(d, h int64) string {
 }
 
 func formatValue(v int64, unit string) string {
-	return string(rune('0'+v%10+v/10*10)) + unit
+	return strconv.FormatInt(v, 10) + unit
 }
 
+// formatPercent renders p with two decimals, truncating rather than rounding
+// so that 99.999% never shows as 100.00%
 func formatPercent(p float64) string {
-	if p >= 99.99 {
-		return "99.99%"
+	if p >= 100 {
+		return "100.00%"
 	}
-	return string(rune(int('0'+int(p)/10))) + string(rune(int('0'+int(p)%10))) + "." + string(rune(int('0'+int(p*10)%10))) + string(rune(int('0'+int(p*100)%10))) + "%"
+	// The epsilon keeps values like 7.1 (709.999... hundredths) from losing a digit
+	truncated := math.Floor(p*100+1e-9) / 100
+	return strconv.FormatFloat(truncated, 'f', 2, 64) + "%"
 }
 
 func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
@@ -294,12 +439,13 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 			Analytics:    analytics,
 		})
 	}
+	s.markUnderMaintenance(r.Context(), systemsWithDeps)
 
 	overall, _ := s.analyticsService.GetOverallAnalytics(r.Context(), "24h")
 
 	tmpl, err := s.loadTemplate("dashboard")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -329,7 +475,7 @@ func (s *Server) handleSystemDetail(w http.ResponseWriter, r *http.Request) {
 
 	tmpl, err := s.loadTemplate("system")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -360,7 +506,7 @@ func (s *Server) handleAdmin(w http.ResponseWriter, r *http.Request) {
 
 	tmpl, err := s.loadTemplate("admin")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -376,41 +522,16 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Enrich logs with system/dependency names
-	systemCache := make(map[int64]string)
-	depCache := make(map[int64]string)
+	names := s.newLogNameResolver()
 
 	var enrichedLogs []*logWithContext
 	for _, log := range logs {
-		lc := &logWithContext{StatusLog: log}
-
-		if log.SystemID != nil {
-			if name, ok := systemCache[*log.SystemID]; ok {
-				lc.SystemName = name
-			} else {
-				if sys, err := s.systemService.GetSystem(r.Context(), *log.SystemID); err == nil && sys != nil {
-					lc.SystemName = sys.Name
-					systemCache[*log.SystemID] = sys.Name
-				}
-			}
-		}
-
-		if log.DependencyID != nil {
-			if name, ok := depCache[*log.DependencyID]; ok {
-				lc.DependencyName = name
-			} else {
-				if dep, err := s.depService.GetDependency(r.Context(), *log.DependencyID); err == nil && dep != nil {
-					lc.DependencyName = dep.Name
-					depCache[*log.DependencyID] = dep.Name
-				}
-			}
-		}
-
-		enrichedLogs = append(enrichedLogs, lc)
+		enrichedLogs = append(enrichedLogs, names.enrich(r.Context(), log))
 	}
 
 	tmpl, err := s.loadTemplate("logs")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -440,7 +561,7 @@ func (s *Server) handleAnalyticsPage(w http.ResponseWriter, r *http.Request) {
 
 	tmpl, err := s.loadTemplate("analytics")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -464,22 +585,59 @@ func parseIDFromChi(r *http.Request, param string) (int64, error) {
 	return id, nil
 }
 
-func (s *Server) handlePublicStatus(w http.ResponseWriter, r *http.Request) {
-	systems, err := s.systemService.GetAllSystems(r.Context())
+// publicSystems returns systems with their dependencies in public display order
+func (s *Server) publicSystems(ctx context.Context) ([]*systemWithDeps, error) {
+	systems, err := s.systemService.GetAllSystems(ctx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, err
 	}
+	domain.SortForDisplay(systems)
 
 	var systemsWithDeps []*systemWithDeps
 	for _, sys := range systems {
-		deps, _ := s.depService.GetDependenciesBySystem(r.Context(), sys.ID)
+		deps, _ := s.depService.GetDependenciesBySystem(ctx, sys.ID)
 		systemsWithDeps = append(systemsWithDeps, &systemWithDeps{
 			System:       sys,
 			Dependencies: deps,
 		})
 	}
+	s.markUnderMaintenance(ctx, systemsWithDeps)
+	return systemsWithDeps, nil
+}
+
+func (s *Server) handlePublicStatus(w http.ResponseWriter, r *http.Request) {
+	systemsWithDeps, err := s.publicSystems(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.renderPublicStatus(w, r, s.branding.PageTitle(), systemsWithDeps, nil)
+}
+
+// handleSystemPublicStatus renders the public page of the system with the
+// slug in the URL, with only the incidents and maintenance affecting it
+func (s *Server) handleSystemPublicStatus(w http.ResponseWriter, r *http.Request) {
+	system, err := s.systemService.GetSystemBySlug(r.Context(), strings.ToLower(chi.URLParam(r, "slug")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if system == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	deps, _ := s.depService.GetDependenciesBySystem(r.Context(), system.ID)
+	systemsWithDeps := []*systemWithDeps{{System: system, Dependencies: deps}}
+	s.markUnderMaintenance(r.Context(), systemsWithDeps)
+
+	s.renderPublicStatus(w, r, system.Name, systemsWithDeps, system)
+}
 
+// renderPublicStatus renders the public page for the given systems. With a
+// scope system, only incidents and maintenance affecting it are listed.
+func (s *Server) renderPublicStatus(w http.ResponseWriter, r *http.Request, title string, systemsWithDeps []*systemWithDeps, scope *domain.System) {
 	// Get maintenance info
 	var activeMaintenance []*maintenanceInfo
 	var upcomingMaintenance []*maintenanceInfo
@@ -487,63 +645,80 @@ func (s *Server) handlePublicStatus(w http.ResponseWriter, r *http.Request) {
 	if s.maintenanceService != nil {
 		actives, _ := s.maintenanceService.GetActiveMaintenances(r.Context())
 		for _, m := range actives {
-			activeMaintenance = append(activeMaintenance, &maintenanceInfo{
-				ID:          m.ID,
-				Title:       m.Title,
-				Description: m.Description,
-				StartTime:   m.StartTime.Format("Jan 2, 15:04"),
-				EndTime:     m.EndTime.Format("Jan 2, 15:04"),
-				Status:      string(m.Status),
-			})
+			if scope == nil || m.AffectsSystem(scope.ID) {
+				activeMaintenance = append(activeMaintenance, newMaintenanceInfo(m))
+			}
 		}
 
 		upcoming, _ := s.maintenanceService.GetUpcomingMaintenances(r.Context())
 		for _, m := range upcoming {
-			upcomingMaintenance = append(upcomingMaintenance, &maintenanceInfo{
-				ID:          m.ID,
-				Title:       m.Title,
-				Description: m.Description,
-				StartTime:   m.StartTime.Format("Jan 2, 15:04"),
-				EndTime:     m.EndTime.Format("Jan 2, 15:04"),
-				Status:      string(m.Status),
-			})
+			if scope == nil || m.AffectsSystem(scope.ID) {
+				upcomingMaintenance = append(upcomingMaintenance, newMaintenanceInfo(m))
+			}
 		}
 	}
 
-	// Get active incidents
-	var activeIncidents []*incidentInfo
+	// Get active and upcoming scheduled incidents
+	var activeIncidents, scheduledIncidents []*incidentInfo
 	if s.incidentService != nil {
 		incidents, _ := s.incidentService.GetActiveIncidents(r.Context())
 		for _, inc := range incidents {
-			activeIncidents = append(activeIncidents, &incidentInfo{
-				ID:        inc.ID,
-				Title:     inc.Title,
-				Status:    string(inc.Status),
-				Severity:  string(inc.Severity),
-				Message:   inc.Message,
-				CreatedAt: inc.CreatedAt.Format("Jan 2, 15:04"),
-				UpdatedAt: inc.UpdatedAt.Format("Jan 2, 15:04"),
-			})
+			if scope == nil || inc.AffectsSystem(scope.ID) {
+				activeIncidents = append(activeIncidents, newIncidentInfo(inc))
+			}
+		}
+
+		scheduled, _ := s.incidentService.GetScheduledIncidents(r.Context())
+		for _, inc := range scheduled {
+			if scope == nil || inc.AffectsSystem(scope.ID) {
+				scheduledIncidents = append(scheduledIncidents, newIncidentInfo(inc))
+			}
 		}
 	}
 
-	tmpl, err := s.loadStandaloneTemplate("public")
+	locale := requestLocale(r)
+	tmpl, err := s.loadStandaloneTemplate("public", locale)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Language", locale)
+	w.Header().Add("Vary", "Accept-Language")
 	tmpl.Execute(w, publicStatusData{
-		Title:               "System Status",
+		Lang:                locale,
+		Title:               title,
+		Branding:            s.branding,
+		CustomCSS:           s.brandingCSS,
 		Systems:             systemsWithDeps,
 		ActiveMaintenance:   activeMaintenance,
 		UpcomingMaintenance: upcomingMaintenance,
 		ActiveIncidents:     activeIncidents,
+		ScheduledIncidents:  scheduledIncidents,
 		UpdatedAt:           formatTimeAgo(),
 	})
 }
 
+// handlePublicStatusJSON returns the public page systems as JSON
+func (s *Server) handlePublicStatusJSON(w http.ResponseWriter, r *http.Request) {
+	systemsWithDeps, err := s.publicSystems(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	systems := make([]*domain.System, 0, len(systemsWithDeps))
+	deps := make(map[int64][]*domain.Dependency, len(systemsWithDeps))
+	for _, sys := range systemsWithDeps {
+		systems = append(systems, sys.System)
+		deps[sys.ID] = sys.Dependencies
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeJSON(w, http.StatusOK, application.NewPublicStatusSummary(systems, deps, time.Now()))
+}
+
 func formatTimeAgo() string {
 	return "just now"
 }
@@ -555,157 +730,170 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
 
-	// System status metrics
-	w.Write([]byte("# HELP status_incident_system_status System status (0=green, 1=yellow, 2=red)\n"))
-	w.Write([]byte("# TYPE status_incident_system_status gauge\n"))
+	m := newMetricsExposition()
 
-	w.Write([]byte("# HELP status_incident_system_sla_target SLA target percentage\n"))
-	w.Write([]byte("# TYPE status_incident_system_sla_target gauge\n"))
+	// System metrics
+	m.gauge("status_incident_system_status", "System status (0=green, 1=yellow, 2=red, 3=partial)")
+	m.gauge("status_incident_system_sla_target", "SLA target percentage")
+	m.gauge("status_incident_sla_error_budget_remaining", "Remaining error budget over the last 30 days in percent (negative once blown)")
+	m.gauge("status_incident_uptime_24h", "System uptime percentage over last 24 hours")
+	m.gauge("status_incident_system_mttr_seconds", "Mean time to recovery over last 30 days in seconds")
+	m.gauge("status_incident_system_incidents_total", "Number of incident periods over last 30 days")
 
 	// Dependency metrics
-	w.Write([]byte("# HELP status_incident_dependency_status Dependency status (0=green, 1=yellow, 2=red)\n"))
-	w.Write([]byte("# TYPE status_incident_dependency_status gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_dependency_latency_ms Last check latency in milliseconds\n"))
-	w.Write([]byte("# TYPE status_incident_dependency_latency_ms gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_dependency_consecutive_failures Number of consecutive check failures\n"))
-	w.Write([]byte("# TYPE status_incident_dependency_consecutive_failures gauge\n"))
-
-	// Counter metrics
-	w.Write([]byte("# HELP status_incident_systems_total Total number of systems\n"))
-	w.Write([]byte("# TYPE status_incident_systems_total gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_dependencies_total Total number of dependencies\n"))
-	w.Write([]byte("# TYPE status_incident_dependencies_total gauge\n"))
+	m.gauge("status_incident_dependency_status", "Dependency status (0=green, 1=yellow, 2=red, 3=partial)")
+	m.gauge("status_incident_dependency_last_latency_ms", "Last check latency in milliseconds")
+	m.histogram("status_incident_dependency_latency_ms", "Latency of successful checks over the last hour in milliseconds")
+	m.gauge("status_incident_dependency_consecutive_failures", "Number of consecutive check failures")
+	m.gauge("status_incident_dependency_flapping", "Whether the dependency is flapping and its notifications are held (1 = flapping)")
+	m.gauge("status_incident_dependency_cert_days_remaining", "Days until the TLS certificate seen by the last check expires")
+	m.gauge("status_incident_dependency_last_check_timestamp", "Unix time of the dependency's last check in seconds")
+
+	// Totals
+	m.gauge("status_incident_systems_total", "Total number of systems")
+	m.gauge("status_incident_dependencies_total", "Total number of dependencies")
 
 	// Incident metrics
-	w.Write([]byte("# HELP status_incident_incidents_active Number of active incidents\n"))
-	w.Write([]byte("# TYPE status_incident_incidents_active gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_incidents_total Total number of incidents\n"))
-	w.Write([]byte("# TYPE status_incident_incidents_total gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_incidents_by_severity Incidents by severity\n"))
-	w.Write([]byte("# TYPE status_incident_incidents_by_severity gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_incidents_by_status Incidents by status\n"))
-	w.Write([]byte("# TYPE status_incident_incidents_by_status gauge\n"))
+	m.gauge("status_incident_incidents_active", "Number of active incidents")
+	m.gauge("status_incident_incidents_total", "Total number of incidents")
+	m.gauge("status_incident_incidents_by_severity", "Incidents by severity")
+	m.gauge("status_incident_incidents_by_status", "Incidents by status")
+	m.gauge("status_incident_incident_active", "Active incident (1 while active)")
 
 	// Maintenance metrics
-	w.Write([]byte("# HELP status_incident_maintenances_active Number of active maintenance windows\n"))
-	w.Write([]byte("# TYPE status_incident_maintenances_active gauge\n"))
-
-	w.Write([]byte("# HELP status_incident_maintenances_scheduled Number of scheduled maintenance windows\n"))
-	w.Write([]byte("# TYPE status_incident_maintenances_scheduled gauge\n"))
+	m.gauge("status_incident_maintenances_active", "Number of active maintenance windows")
+	m.gauge("status_incident_maintenances_scheduled", "Number of scheduled maintenance windows")
+	m.gauge("status_incident_maintenance_active", "Active maintenance window (1 while active)")
 
 	// SLA metrics
-	w.Write([]byte("# HELP status_incident_sla_breaches_unacknowledged Number of unacknowledged SLA breaches\n"))
-	w.Write([]byte("# TYPE status_incident_sla_breaches_unacknowledged gauge\n"))
-
-	// Uptime metrics
-	w.Write([]byte("# HELP status_incident_uptime_24h System uptime percentage over last 24 hours\n"))
-	w.Write([]byte("# TYPE status_incident_uptime_24h gauge\n"))
+	m.gauge("status_incident_sla_breaches_unacknowledged", "Number of unacknowledged SLA breaches")
 
 	totalDeps := 0
 
 	// System and dependency metrics
 	for _, sys := range systems {
-		sysIDStr := intToStr(sys.ID)
+		sysLabels := []string{"system_id", intToStr(sys.ID), "system_name", sys.Name}
 
-		statusVal := statusToInt(sys.Status)
-		w.Write([]byte(formatMetricLine("status_incident_system_status", statusVal,
-			"system_id", sysIDStr,
-			"system_name", sys.Name)))
+		m.add("status_incident_system_status", statusToInt(sys.Status), sysLabels...)
 
-		w.Write([]byte(formatMetricLine("status_incident_system_sla_target", sys.SLATarget,
-			"system_id", sysIDStr,
-			"system_name", sys.Name)))
+		slaTarget := sys.GetSLATarget()
+		if s.slaService != nil {
+			slaTarget = s.slaService.ResolveSLATarget(sys)
+		}
+		m.add("status_incident_system_sla_target", slaTarget, sysLabels...)
+
+		if s.slaService != nil {
+			if slaStatus, err := s.slaService.GetSystemSLAStatus(ctx, sys.ID, "monthly"); err == nil {
+				m.add("status_incident_sla_error_budget_remaining", slaStatus.ErrorBudgetRemainingPercent, sysLabels...)
+			}
+		}
 
 		// Get uptime for this system
 		if analytics, err := s.analyticsService.GetSystemAnalytics(ctx, sys.ID, "24h"); err == nil {
-			w.Write([]byte(formatMetricLine("status_incident_uptime_24h", analytics.UptimePercent,
-				"system_id", sysIDStr,
-				"system_name", sys.Name)))
+			m.add("status_incident_uptime_24h", analytics.UptimePercent, sysLabels...)
+		}
+
+		// Get incident figures for this system
+		if analytics, err := s.analyticsService.GetSystemAnalytics(ctx, sys.ID, "30d"); err == nil {
+			m.add("status_incident_system_mttr_seconds", analytics.MTTR.Seconds(), sysLabels...)
+			m.add("status_incident_system_incidents_total", analytics.TotalIncidents, sysLabels...)
 		}
 
 		deps, _ := s.depService.GetDependenciesBySystem(ctx, sys.ID)
 		totalDeps += len(deps)
 
 		for _, dep := range deps {
-			depIDStr := intToStr(dep.ID)
-
-			depStatusVal := statusToInt(dep.Status)
-			w.Write([]byte(formatMetricLine("status_incident_dependency_status", depStatusVal,
-				"system_id", sysIDStr,
-				"system_name", sys.Name,
-				"dependency_id", depIDStr,
-				"dependency_name", dep.Name)))
+			depLabels := append(sysLabels[:len(sysLabels):len(sysLabels)], "dependency_id", intToStr(dep.ID), "dependency_name", dep.Name)
 
+			m.add("status_incident_dependency_status", statusToInt(dep.Status), depLabels...)
 			if dep.LastLatency > 0 {
-				w.Write([]byte(formatMetricLine("status_incident_dependency_latency_ms", dep.LastLatency,
-					"system_id", sysIDStr,
-					"system_name", sys.Name,
-					"dependency_id", depIDStr,
-					"dependency_name", dep.Name)))
+				m.add("status_incident_dependency_last_latency_ms", dep.LastLatency, depLabels...)
+			}
+			if s.latencyService != nil {
+				if h, err := s.latencyService.GetDependencyLatencyHistogram(ctx, dep.ID); err == nil && h.Count > 0 {
+					m.addHistogram("status_incident_dependency_latency_ms", domain.LatencyHistogramBounds, h.Buckets, h.Count, float64(h.SumMs), depLabels...)
+				}
+			}
+			m.add("status_incident_dependency_consecutive_failures", dep.ConsecutiveFailures, depLabels...)
+			if s.heartbeatService != nil {
+				flapping := 0
+				if s.heartbeatService.IsFlapping(dep.ID) {
+					flapping = 1
+				}
+				m.add("status_incident_dependency_flapping", flapping, depLabels...)
+			}
+			if !dep.CertExpiresAt.IsZero() {
+				m.add("status_incident_dependency_cert_days_remaining", domain.CertDaysRemaining(dep.CertExpiresAt, time.Now()), depLabels...)
+			}
+			if !dep.LastCheck.IsZero() {
+				m.add("status_incident_dependency_last_check_timestamp", dep.LastCheck.Unix(), depLabels...)
 			}
-
-			w.Write([]byte(formatMetricLine("status_incident_dependency_consecutive_failures", dep.ConsecutiveFailures,
-				"system_id", sysIDStr,
-				"system_name", sys.Name,
-				"dependency_id", depIDStr,
-				"dependency_name", dep.Name)))
 		}
 	}
 
 	// Write totals
-	w.Write([]byte(formatMetricLine("status_incident_systems_total", len(systems))))
-	w.Write([]byte(formatMetricLine("status_incident_dependencies_total", totalDeps)))
+	m.add("status_incident_systems_total", len(systems))
+	m.add("status_incident_dependencies_total", totalDeps)
 
 	// Incident metrics
 	if s.incidentService != nil {
 		activeIncidents, _ := s.incidentService.GetActiveIncidents(ctx)
-		w.Write([]byte(formatMetricLine("status_incident_incidents_active", len(activeIncidents))))
+		m.add("status_incident_incidents_active", len(activeIncidents))
+		for _, inc := range activeIncidents {
+			m.add("status_incident_incident_active", 1, "incident_id", intToStr(inc.ID), "severity", string(inc.Severity), "title", inc.Title)
+		}
 
 		allIncidents, _ := s.incidentService.GetAllIncidents(ctx, 1000)
-		w.Write([]byte(formatMetricLine("status_incident_incidents_total", len(allIncidents))))
+		m.add("status_incident_incidents_total", len(allIncidents))
 
-		// Count by severity and status
-		severityCounts := map[string]int{"minor": 0, "major": 0, "critical": 0}
-		statusCounts := map[string]int{"investigating": 0, "identified": 0, "monitoring": 0, "resolved": 0}
+		// Count by severity and status, always reporting every known value
+		severities := []domain.IncidentSeverity{domain.SeverityMinor, domain.SeverityMajor, domain.SeverityCritical}
+		statuses := []domain.IncidentStatus{domain.IncidentScheduled, domain.IncidentInvestigating, domain.IncidentIdentified, domain.IncidentMonitoring, domain.IncidentResolved}
+		severityCounts := make(map[domain.IncidentSeverity]int)
+		statusCounts := make(map[domain.IncidentStatus]int)
 
 		for _, inc := range allIncidents {
-			severityCounts[string(inc.Severity)]++
-			statusCounts[string(inc.Status)]++
+			severityCounts[inc.Severity]++
+			statusCounts[inc.Status]++
 		}
 
-		for severity, count := range severityCounts {
-			w.Write([]byte(formatMetricLine("status_incident_incidents_by_severity", count,
-				"severity", severity)))
+		for _, severity := range severities {
+			m.add("status_incident_incidents_by_severity", severityCounts[severity], "severity", string(severity))
 		}
-
-		for status, count := range statusCounts {
-			w.Write([]byte(formatMetricLine("status_incident_incidents_by_status", count,
-				"status", status)))
+		for _, status := range statuses {
+			m.add("status_incident_incidents_by_status", statusCounts[status], "status", string(status))
 		}
 	}
 
 	// Maintenance metrics
 	if s.maintenanceService != nil {
 		activeMaints, _ := s.maintenanceService.GetActiveMaintenances(ctx)
-		w.Write([]byte(formatMetricLine("status_incident_maintenances_active", len(activeMaints))))
+		m.add("status_incident_maintenances_active", len(activeMaints))
+		for _, mt := range activeMaints {
+			m.add("status_incident_maintenance_active", 1, "maintenance_id", intToStr(mt.ID), "title", mt.Title)
+		}
 
 		upcomingMaints, _ := s.maintenanceService.GetUpcomingMaintenances(ctx)
-		w.Write([]byte(formatMetricLine("status_incident_maintenances_scheduled", len(upcomingMaints))))
+		m.add("status_incident_maintenances_scheduled", len(upcomingMaints))
 	}
 
 	// SLA breach metrics
 	if s.slaService != nil {
 		breaches, _ := s.slaService.GetUnacknowledgedBreaches(ctx)
-		w.Write([]byte(formatMetricLine("status_incident_sla_breaches_unacknowledged", len(breaches))))
+		m.add("status_incident_sla_breaches_unacknowledged", len(breaches))
+	}
+
+	m.WriteTo(w)
+
+	// HTTP request metrics
+	if s.requestMetrics != nil {
+		s.requestMetrics.WritePrometheus(w)
 	}
 }
 
+// statusToInt maps a status to its metric value. Red stays 2 as in the
+// three-state model so existing "== 2" outage alerts keep working; partial
+// got the next free value, so the values are codes, not a severity order
 func statusToInt(status domain.Status) int {
 	switch status {
 	case domain.StatusGreen:
@@ -714,6 +902,8 @@ func statusToInt(status domain.Status) int {
 		return 1
 	case domain.StatusRed:
 		return 2
+	case domain.StatusPartial:
+		return 3
 	}
 	return -1
 }
@@ -749,6 +939,8 @@ func formatMetricLine(name string, value interface{}, labels ...string) string {
 		result += " " + intToStr(v)
 	case float64:
 		result += " " + formatFloat(v)
+	case string:
+		result += " " + v
 	}
 	result += "\n"
 	return result
@@ -773,21 +965,10 @@ func intToStrPlain(i int) string {
 	return string(digits)
 }
 
+// formatFloat renders f with two decimals for metric lines;
+// NaN and ±Inf come out as Prometheus expects
 func formatFloat(f float64) string {
-	// Simple float formatting
-	intPart := int64(f)
-	fracPart := int64((f - float64(intPart)) * 100)
-	if fracPart < 0 {
-		fracPart = -fracPart
-	}
-	return intToStr(intPart) + "." + padLeft(intToStrPlain(int(fracPart)), 2, '0')
-}
-
-func padLeft(s string, length int, pad byte) string {
-	for len(s) < length {
-		s = string(pad) + s
-	}
-	return s
+	return strconv.FormatFloat(f, 'f', 2, 64)
 }
 
 func escapeLabel(s string) string {
@@ -808,6 +989,32 @@ func escapeLabel(s string) string {
 	return string(result)
 }
 
+// handleIncidentAcknowledgePage shows an incident with a button that
+// acknowledges it through the API. Chat action buttons link here since a
+// browser cannot open the POST-only API endpoint directly
+func (s *Server) handleIncidentAcknowledgePage(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "id")
+	if err != nil {
+		http.Error(w, "invalid incident ID", http.StatusBadRequest)
+		return
+	}
+
+	incident, err := s.incidentService.GetIncident(r.Context(), id)
+	if err != nil || incident == nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	tmpl, err := s.loadTemplate("incident_ack")
+	if err != nil {
+		s.templateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl.Execute(w, incidentAckData{Incident: incident})
+}
+
 func (s *Server) handleSLAPage(w http.ResponseWriter, r *http.Request) {
 	if s.slaService == nil {
 		http.Error(w, "SLA service not available", http.StatusServiceUnavailable)
@@ -829,7 +1036,7 @@ func (s *Server) handleSLAPage(w http.ResponseWriter, r *http.Request) {
 
 	tmpl, err := s.loadTemplate("sla")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.templateError(w, err)
 		return
 	}
 
@@ -0,0 +1,1002 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/common/expfmt"
+)
+
+func writeTemplateFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write template %s: %v", name, err)
+	}
+}
+
+func TestLoadTemplate_ParsedOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFile(t, dir, "layout.html", `{{template "content" .}}`)
+	writeTemplateFile(t, dir, "dashboard.html", `{{define "content"}}dashboard{{end}}`)
+
+	server := &Server{templateDir: dir}
+
+	first, err := server.loadTemplate("dashboard")
+	if err != nil {
+		t.Fatalf("loadTemplate() error = %v", err)
+	}
+
+	// Changes on disk are not picked up once parsed
+	writeTemplateFile(t, dir, "dashboard.html", `{{define "content"}}changed{{end}}`)
+
+	second, err := server.loadTemplate("dashboard")
+	if err != nil {
+		t.Fatalf("loadTemplate() error = %v", err)
+	}
+	if first != second {
+		t.Error("expected cached template to be reused")
+	}
+}
+
+func TestPrecompileTemplates_MissingTemplate(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFile(t, dir, "layout.html", `{{template "content" .}}`)
+
+	server := &Server{templateDir: dir}
+
+	if err := server.PrecompileTemplates(); err == nil {
+		t.Error("expected error for missing templates")
+	}
+}
+
+func TestHandleDashboard_MissingTemplate(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.templateDir = t.TempDir()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+
+	server.handleDashboard(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	body := w.Body.String()
+	if strings.Contains(body, server.templateDir) || strings.Contains(body, ".html") {
+		t.Errorf("response leaks template path: %q", body)
+	}
+}
+
+func TestIncidentAcknowledgeLink_OpensInBrowser(t *testing.T) {
+	logRepo := NewMockStatusLogRepository()
+	incidentRepo := &mockIncidentRepository{}
+	incident, _ := domain.NewIncident("Checkout errors", "Investigating", domain.SeverityCritical)
+	incidentRepo.Create(context.Background(), incident)
+
+	server := NewServer(
+		application.NewSystemService(NewMockSystemRepository(), logRepo),
+		application.NewDependencyService(NewMockDependencyRepository(), logRepo),
+		nil,
+		application.NewAnalyticsService(NewMockAnalyticsRepository(), logRepo),
+		nil,
+		application.NewIncidentService(incidentRepo),
+		nil, nil, nil, nil, nil, nil,
+		NewAuthMiddleware(true, "admin", "secret", nil),
+		nil,
+		filepath.Join("..", "..", "..", "templates"),
+	)
+
+	// The Slack button is a plain link, so the browser sends a GET
+	link := application.IncidentAcknowledgePath(incident.ID)
+
+	req := httptest.NewRequest("GET", link, nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther || !strings.HasPrefix(w.Header().Get("Location"), "/login") {
+		t.Fatalf("expected signed-out browser to be sent to login, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+
+	req = httptest.NewRequest("GET", link, nil)
+	req.SetBasicAuth("admin", "secret")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected acknowledge page, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected HTML page, got %q", ct)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "Checkout errors") || !strings.Contains(body, "/api/incidents/${id}/acknowledge") {
+		t.Errorf("expected incident and acknowledge button on page, got %s", body)
+	}
+
+	req = httptest.NewRequest("GET", application.IncidentAcknowledgePath(99), nil)
+	req.SetBasicAuth("admin", "secret")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown incident, got %d", w.Code)
+	}
+}
+
+func TestHandleMetrics_SystemIncidentMetrics(t *testing.T) {
+	systemRepo := NewMockSystemRepository()
+	logRepo := NewMockStatusLogRepository()
+	analyticsRepo := NewMockAnalyticsRepository()
+	analyticsRepo.SystemAnalytics = &domain.Analytics{
+		UptimePercent:     99.5,
+		TotalIncidents:    1,
+		ResolvedIncidents: 1,
+		MTTR:              90 * time.Second,
+	}
+
+	server := &Server{
+		router:           chi.NewRouter(),
+		systemService:    application.NewSystemService(systemRepo, logRepo),
+		depService:       application.NewDependencyService(NewMockDependencyRepository(), logRepo),
+		analyticsService: application.NewAnalyticsService(analyticsRepo, logRepo),
+	}
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`status_incident_system_mttr_seconds{system_id="1",system_name="API"} 90.00`,
+		`status_incident_system_incidents_total{system_id="1",system_name="API"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+}
+
+func TestHandleMetrics_DependencyCertDaysRemaining(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	withCert, _ := domain.NewDependency(system.ID, "Gateway", "")
+	withCert.CertExpiresAt = time.Now().Add(20*24*time.Hour + time.Hour)
+	depRepo.Create(context.Background(), withCert)
+
+	withoutCert, _ := domain.NewDependency(system.ID, "Queue", "")
+	depRepo.Create(context.Background(), withoutCert)
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	want := `status_incident_dependency_cert_days_remaining{system_id="1",system_name="API",dependency_id="1",dependency_name="Gateway"} 20`
+	if !strings.Contains(body, want) {
+		t.Errorf("expected metrics to contain %q", want)
+	}
+	if strings.Contains(body, `status_incident_dependency_cert_days_remaining{system_id="1",system_name="API",dependency_id="2"`) {
+		t.Error("expected no cert metric for dependency without a certificate")
+	}
+}
+
+func TestHandleMetrics_StatusValues(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+	ctx := context.Background()
+
+	partial, _ := domain.NewSystem("API", "", "", "")
+	partial.Status = domain.StatusPartial
+	systemRepo.Create(ctx, partial)
+	red, _ := domain.NewSystem("Web", "", "", "")
+	red.Status = domain.StatusRed
+	systemRepo.Create(ctx, red)
+
+	dep, _ := domain.NewDependency(partial.ID, "Cache", "")
+	dep.Status = domain.StatusPartial
+	depRepo.Create(ctx, dep)
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		// Red keeps the three-state value so "== 2" outage alerts still fire
+		`status_incident_system_status{system_id="1",system_name="API"} 3`,
+		`status_incident_system_status{system_id="2",system_name="Web"} 2`,
+		`status_incident_dependency_status{system_id="1",system_name="API",dependency_id="1",dependency_name="Cache"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+}
+
+// alternatingChecker flips between healthy and failing on every check
+type alternatingChecker struct{ healthy bool }
+
+func (c *alternatingChecker) Check(ctx context.Context, url string) (bool, int64, error) {
+	result := c.CheckWithConfig(ctx, domain.HeartbeatConfig{URL: url})
+	return result.Healthy, result.LatencyMs, nil
+}
+
+func (c *alternatingChecker) CheckWithConfig(ctx context.Context, config domain.HeartbeatConfig) domain.HealthCheckResult {
+	c.healthy = !c.healthy
+	return domain.HealthCheckResult{Healthy: c.healthy, LatencyMs: 5, StatusCode: 200}
+}
+
+func TestHandleMetrics_DependencyFlapping(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	flapping, _ := domain.NewDependency(system.ID, "Gateway", "")
+	flapping.SetHeartbeat("https://gw.example.com/health", 60)
+	depRepo.Create(context.Background(), flapping)
+
+	stable, _ := domain.NewDependency(system.ID, "Queue", "")
+	stable.SetHeartbeat("https://queue.example.com/health", 60)
+	depRepo.Create(context.Background(), stable)
+
+	heartbeatService := application.NewHeartbeatService(depRepo, NewMockStatusLogRepository(), &alternatingChecker{})
+	heartbeatService.SetFailureThreshold(1)
+	heartbeatService.SetFlapDetection(time.Hour, 2)
+	server.heartbeatService = heartbeatService
+	for i := 0; i < 4; i++ {
+		heartbeatService.ForceCheck(context.Background(), flapping.ID)
+	}
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`status_incident_dependency_flapping{system_id="1",system_name="API",dependency_id="1",dependency_name="Gateway"} 1`,
+		`status_incident_dependency_flapping{system_id="1",system_name="API",dependency_id="2",dependency_name="Queue"} 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+}
+
+func TestHandleMetrics_SLAErrorBudget(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+	analyticsRepo := NewMockAnalyticsRepository()
+	analyticsRepo.SystemAnalytics = &domain.Analytics{UptimePercent: 99.95}
+	server.slaService = application.NewSLAService(systemRepo, depRepo, analyticsRepo, nil, NewMockSLABreachRepository(), nil, nil)
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	system.SetSLATarget(99.9)
+	systemRepo.Create(context.Background(), system)
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	want := `status_incident_sla_error_budget_remaining{system_id="1",system_name="API"} 50.00`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("expected metrics to contain %q", want)
+	}
+}
+
+func TestHandleMetrics_ActiveIncidentsAndMaintenances(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.maintenanceService = application.NewMaintenanceService(&mockMaintenanceRepository{})
+	ctx := context.Background()
+
+	active, err := server.incidentService.CreateIncident(ctx, `Checkout "EU" down`, "Payments failing", domain.SeverityCritical, nil)
+	if err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	resolved, _ := server.incidentService.CreateIncident(ctx, "Old outage", "Fixed", domain.SeverityMinor, nil)
+	if _, err := server.incidentService.ResolveIncident(ctx, resolved.ID, "Fixed", ""); err != nil {
+		t.Fatalf("failed to resolve incident: %v", err)
+	}
+	if _, err := server.maintenanceService.CreateMaintenance(ctx, "DB upgrade", "",
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour), nil); err != nil {
+		t.Fatalf("failed to create maintenance: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	for _, want := range []string{
+		`status_incident_incident_active{incident_id="` + intToStr(active.ID) + `",severity="critical",title="Checkout \"EU\" down"} 1`,
+		`status_incident_maintenance_active{maintenance_id="1",title="DB upgrade"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+	if strings.Contains(body, `title="Old outage"`) {
+		t.Error("expected resolved incidents to be left out")
+	}
+
+	var parser expfmt.TextParser
+	if _, err := parser.TextToMetricFamilies(strings.NewReader(body)); err != nil {
+		t.Fatalf("metrics do not parse: %v\n%s", err, body)
+	}
+}
+
+func TestHandleMetrics_ParsesAsPrometheusText(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+	server.requestMetrics = newRequestMetrics()
+	server.requestMetrics.observe("GET", "/api/systems", http.StatusOK, 20*time.Millisecond)
+
+	lastCheck := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"API", "Billing"} {
+		system, _ := domain.NewSystem(name, "", "", "")
+		systemRepo.Create(context.Background(), system)
+
+		checked, _ := domain.NewDependency(system.ID, "Database", "")
+		checked.LastCheck = lastCheck
+		checked.LastLatency = 1500
+		depRepo.Create(context.Background(), checked)
+
+		unchecked, _ := domain.NewDependency(system.ID, "Queue", "")
+		depRepo.Create(context.Background(), unchecked)
+	}
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("metrics do not parse: %v\n%s", err, body)
+	}
+
+	for name, family := range families {
+		if len(family.GetMetric()) == 0 {
+			t.Errorf("%s is declared without samples", name)
+			continue
+		}
+		labelNames := func(i int) string {
+			var names []string
+			for _, label := range family.GetMetric()[i].GetLabel() {
+				names = append(names, label.GetName())
+			}
+			return strings.Join(names, ",")
+		}
+		for i := range family.GetMetric() {
+			if labelNames(i) != labelNames(0) {
+				t.Errorf("%s has inconsistent labels: %q vs %q", name, labelNames(i), labelNames(0))
+			}
+		}
+	}
+
+	// Every family is written once, with its samples right after its headers
+	declared := make(map[string]int)
+	var current string
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			current = strings.Fields(line)[2]
+			declared[current]++
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, current) {
+			t.Errorf("sample %q is not under its TYPE line (current family %q)", line, current)
+		}
+	}
+	for name, n := range declared {
+		if n != 1 {
+			t.Errorf("%s is declared %d times", name, n)
+		}
+	}
+
+	lastChecks := families["status_incident_dependency_last_check_timestamp"].GetMetric()
+	if len(lastChecks) != 2 {
+		t.Fatalf("expected last check timestamps for the 2 checked dependencies, got %d", len(lastChecks))
+	}
+	if got := lastChecks[0].GetGauge().GetValue(); got != float64(lastCheck.Unix()) {
+		t.Errorf("last check timestamp = %v, want %v", got, lastCheck.Unix())
+	}
+	if got := families["status_incident_dependency_status"].GetMetric(); len(got) != 4 {
+		t.Errorf("expected 4 dependency status samples, got %d", len(got))
+	}
+	if _, ok := families["status_incident_incidents_active"]; ok {
+		t.Error("expected no incident metrics without an incident service")
+	}
+}
+
+// stubLatencyRepository serves fixed latency records to the metrics handler
+type stubLatencyRepository struct {
+	records []*domain.LatencyRecord
+}
+
+func (m *stubLatencyRepository) Record(ctx context.Context, record *domain.LatencyRecord) error {
+	return nil
+}
+func (m *stubLatencyRepository) GetByDependency(ctx context.Context, dependencyID int64, start, end time.Time, limit int) ([]*domain.LatencyRecord, error) {
+	var result []*domain.LatencyRecord
+	for _, r := range m.records {
+		if r.DependencyID == dependencyID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+func (m *stubLatencyRepository) GetAggregated(ctx context.Context, dependencyID int64, start, end time.Time, intervalMinutes int) ([]domain.LatencyPoint, error) {
+	return nil, nil
+}
+func (m *stubLatencyRepository) GetDailyUptime(ctx context.Context, dependencyID int64, days int) ([]domain.UptimePoint, error) {
+	return nil, nil
+}
+func (m *stubLatencyRepository) GetStats(ctx context.Context, dependencyID int64, start, end time.Time) (*domain.LatencyStats, error) {
+	return &domain.LatencyStats{}, nil
+}
+func (m *stubLatencyRepository) Cleanup(ctx context.Context, olderThan time.Time) error { return nil }
+
+func TestHandleMetrics_DependencyLatencyHistogram(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+	dep, _ := domain.NewDependency(system.ID, "Database", "")
+	dep.LastLatency = 1500
+	depRepo.Create(context.Background(), dep)
+
+	latencyRepo := &stubLatencyRepository{}
+	for _, ms := range []int64{8, 30, 30, 120, 1500, 9000} {
+		latencyRepo.records = append(latencyRepo.records, &domain.LatencyRecord{DependencyID: dep.ID, LatencyMs: ms, Success: true})
+	}
+	latencyRepo.records = append(latencyRepo.records, &domain.LatencyRecord{DependencyID: dep.ID, LatencyMs: 10000, Success: false})
+	server.latencyService = application.NewLatencyService(latencyRepo, depRepo)
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(w.Body.String()))
+	if err != nil {
+		t.Fatalf("metrics do not parse: %v", err)
+	}
+
+	family, ok := families["status_incident_dependency_latency_ms"]
+	if !ok || family.GetType().String() != "HISTOGRAM" {
+		t.Fatalf("expected a latency histogram, got %v", family)
+	}
+	h := family.GetMetric()[0].GetHistogram()
+	if h.GetSampleCount() != 6 {
+		t.Errorf("count = %d, want 6", h.GetSampleCount())
+	}
+	if h.GetSampleSum() != 10688 {
+		t.Errorf("sum = %v, want 10688", h.GetSampleSum())
+	}
+
+	want := map[float64]uint64{10: 1, 25: 1, 50: 3, 100: 3, 250: 4, 500: 4, 1000: 4, 2500: 5, 5000: 5, math.Inf(1): 6}
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if got := bucket.GetCumulativeCount(); got != want[bucket.GetUpperBound()] {
+			t.Errorf("bucket le=%v = %d, want %d", bucket.GetUpperBound(), got, want[bucket.GetUpperBound()])
+		}
+		if bucket.GetCumulativeCount() < previous {
+			t.Errorf("bucket le=%v is not cumulative", bucket.GetUpperBound())
+		}
+		previous = bucket.GetCumulativeCount()
+	}
+
+	if _, ok := families["status_incident_dependency_last_latency_ms"]; !ok {
+		t.Error("expected the last latency gauge alongside the histogram")
+	}
+}
+
+func TestRequestMetrics_CountsByRouteAndStatus(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.requestMetrics = newRequestMetrics()
+	server.router.Use(server.requestMetrics.Middleware)
+	server.router.Get("/api/systems/{id}", server.apiGetSystem)
+	server.router.Get("/metrics", server.handleMetrics)
+
+	system, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(context.Background(), system)
+
+	for _, path := range []string{"/api/systems/1", "/api/systems/1", "/api/systems/42"} {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := server.requestMetrics.RequestCount("GET", "/api/systems/{id}", http.StatusOK); got != 2 {
+		t.Errorf("expected 2 OK requests, got %d", got)
+	}
+	if got := server.requestMetrics.RequestCount("GET", "/api/systems/{id}", http.StatusNotFound); got != 1 {
+		t.Errorf("expected 1 not-found request, got %d", got)
+	}
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`status_incident_http_requests_total{method="GET",route="/api/systems/{id}",status="200"} 2`,
+		`status_incident_http_requests_total{method="GET",route="/api/systems/{id}",status="404"} 1`,
+		`status_incident_http_request_duration_seconds_bucket{method="GET",route="/api/systems/{id}",le="+Inf"} 3`,
+		`status_incident_http_request_duration_seconds_count{method="GET",route="/api/systems/{id}"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+
+	// The scrape itself is counted once it completes
+	if got := server.requestMetrics.RequestCount("GET", "/metrics", http.StatusOK); got != 1 {
+		t.Errorf("expected metrics scrape to be counted, got %d", got)
+	}
+}
+
+func TestRequestMetrics_NonStandardMethodsShareOneLabel(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.requestMetrics = newRequestMetrics()
+	server.router.Use(server.requestMetrics.Middleware)
+	server.router.Get("/metrics", server.handleMetrics)
+
+	// chi rejects methods it does not know before routing
+	for _, method := range []string{"PROPFIND", "FOO", "get"} {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(method, "/metrics", nil))
+	}
+
+	if got := server.requestMetrics.RequestCount("OTHER", unmatchedRoute, http.StatusMethodNotAllowed); got != 3 {
+		t.Errorf("expected 3 requests under OTHER, got %d", got)
+	}
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, method := range []string{"PROPFIND", "FOO", "get"} {
+		if strings.Contains(body, `method="`+method+`"`) {
+			t.Errorf("expected method %q not to be used as a label", method)
+		}
+	}
+}
+
+func setupPublicOrderServer(t *testing.T) *Server {
+	t.Helper()
+	server, systemRepo, _ := setupTestServer()
+
+	for _, s := range []struct {
+		name  string
+		order int
+	}{
+		{"Zeta", 1},
+		{"Alpha", 3},
+		{"Mid", 2},
+		{"Beta", 3},
+	} {
+		system, _ := domain.NewSystem(s.name, "", "", "")
+		system.SetDisplayOrder(s.order)
+		systemRepo.Create(context.Background(), system)
+	}
+	return server
+}
+
+func TestHandlePublicStatus_DisplayOrder(t *testing.T) {
+	server := setupPublicOrderServer(t)
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html", `{{range .Systems}}{{.Name}};{{end}}`)
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	w := httptest.NewRecorder()
+
+	server.handlePublicStatus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got, want := w.Body.String(), "Zeta;Mid;Alpha;Beta;"; got != want {
+		t.Errorf("expected systems in order %q, got %q", want, got)
+	}
+}
+
+func TestHandlePublicStatusJSON_DisplayOrder(t *testing.T) {
+	server := setupPublicOrderServer(t)
+
+	req := httptest.NewRequest("GET", "/status.json", nil)
+	w := httptest.NewRecorder()
+
+	server.handlePublicStatusJSON(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp application.PublicStatusSummary
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	want := []string{"Zeta", "Mid", "Alpha", "Beta"}
+	if len(resp.Systems) != len(want) {
+		t.Fatalf("expected %d systems, got %d", len(want), len(resp.Systems))
+	}
+	for i, name := range want {
+		if resp.Systems[i].Name != name {
+			t.Errorf("system[%d] = %s, want %s", i, resp.Systems[i].Name, name)
+		}
+	}
+}
+
+// mockMaintenanceRepository keeps maintenance windows in memory
+type mockMaintenanceRepository struct {
+	windows []*domain.Maintenance
+}
+
+func (m *mockMaintenanceRepository) Create(ctx context.Context, mt *domain.Maintenance) error {
+	mt.ID = int64(len(m.windows) + 1)
+	m.windows = append(m.windows, mt)
+	return nil
+}
+
+func (m *mockMaintenanceRepository) GetByID(ctx context.Context, id int64) (*domain.Maintenance, error) {
+	for _, mt := range m.windows {
+		if mt.ID == id {
+			return mt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockMaintenanceRepository) GetAll(ctx context.Context) ([]*domain.Maintenance, error) {
+	return m.windows, nil
+}
+
+func (m *mockMaintenanceRepository) GetActive(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
+	var result []*domain.Maintenance
+	for _, mt := range m.windows {
+		if mt.IsActiveAt(now) {
+			result = append(result, mt)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMaintenanceRepository) GetUpcoming(ctx context.Context, now time.Time) ([]*domain.Maintenance, error) {
+	var result []*domain.Maintenance
+	for _, mt := range m.windows {
+		if mt.IsUpcomingAt(now) {
+			result = append(result, mt)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMaintenanceRepository) GetByTimeRange(ctx context.Context, start, end time.Time) ([]*domain.Maintenance, error) {
+	return m.windows, nil
+}
+
+func (m *mockMaintenanceRepository) Update(ctx context.Context, mt *domain.Maintenance) error {
+	return nil
+}
+
+func (m *mockMaintenanceRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func TestHandlePublicStatus_UnderMaintenance(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.maintenanceService = application.NewMaintenanceService(&mockMaintenanceRepository{})
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`{{range .Systems}}{{.Name}}={{statusText .DisplayStatus}}/{{.Status}};{{end}}{{overallStatusText .Systems}}`)
+
+	ctx := context.Background()
+	api, _ := domain.NewSystem("API", "", "", "")
+	api.UpdateStatus(domain.StatusRed)
+	systemRepo.Create(ctx, api)
+	web, _ := domain.NewSystem("Web", "", "", "")
+	systemRepo.Create(ctx, web)
+
+	if _, err := server.maintenanceService.CreateMaintenance(ctx, "DB upgrade", "",
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour), []int64{api.ID}); err != nil {
+		t.Fatalf("failed to create maintenance: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	w := httptest.NewRecorder()
+
+	server.handlePublicStatus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "API=Under Maintenance/red;") {
+		t.Errorf("expected API shown under maintenance with real status kept, got %q", body)
+	}
+	if !strings.Contains(body, "Web=Operational/green;") {
+		t.Errorf("expected Web unaffected, got %q", body)
+	}
+	if !strings.HasSuffix(body, "All Systems Operational") {
+		t.Errorf("expected system under maintenance not to count as an outage, got %q", body)
+	}
+
+	stored, _ := systemRepo.GetByID(ctx, api.ID)
+	if stored.Status != domain.StatusRed {
+		t.Errorf("expected stored status to stay red, got %q", stored.Status)
+	}
+}
+
+func TestHandlePublicStatus_IncidentComponents(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`{{range .ActiveIncidents}}{{.Title}}:{{range .Components}}{{.}};{{end}}{{end}}`)
+
+	if _, err := server.incidentService.CreateIncidentWithComponents(context.Background(), "Checkout errors", "Payments failing",
+		domain.SeverityMajor, nil, []domain.AffectedComponent{{Name: "Checkout"}, {Name: "Search"}}); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	w := httptest.NewRecorder()
+
+	server.handlePublicStatus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got, want := w.Body.String(), "Checkout errors:Checkout;Search;"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestHandlePublicStatus_ScheduledIncidents(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`active:{{range .ActiveIncidents}}{{.Title}};{{end}} scheduled:{{range .ScheduledIncidents}}{{.Title}}@{{.ScheduledFor}};{{end}}`)
+
+	ctx := context.Background()
+	at := time.Now().Add(3 * time.Hour)
+	server.incidentService.CreateIncident(ctx, "API Outage", "Down", domain.SeverityCritical, nil)
+	if _, err := server.incidentService.ScheduleIncident(ctx, "Database upgrade", "Read-only mode",
+		domain.SeverityMinor, nil, nil, at); err != nil {
+		t.Fatalf("failed to schedule incident: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	w := httptest.NewRecorder()
+
+	server.handlePublicStatus(w, req)
+
+	want := "active:API Outage; scheduled:Database upgrade@" + at.Format("Jan 2, 15:04") + ";"
+	if got := w.Body.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestHandleSystemPublicStatus(t *testing.T) {
+	server, systemRepo, depRepo := setupTestServer()
+	server.incidentService = application.NewIncidentService(&mockIncidentRepository{})
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`{{.Title}}|{{range .Systems}}{{.Name}}:{{range .Dependencies}}{{.Name}},{{end}};{{end}}|{{range .ActiveIncidents}}{{.Title}};{{end}}`)
+
+	ctx := context.Background()
+	checkout, _ := domain.NewSystem("Checkout", "", "", "")
+	checkout.SetSlug("checkout")
+	systemRepo.Create(ctx, checkout)
+	search, _ := domain.NewSystem("Search", "", "", "")
+	search.SetSlug("search")
+	systemRepo.Create(ctx, search)
+
+	payments, _ := domain.NewDependency(checkout.ID, "Payments", "")
+	depRepo.Create(ctx, payments)
+	index, _ := domain.NewDependency(search.ID, "Index", "")
+	depRepo.Create(ctx, index)
+
+	server.incidentService.CreateIncident(ctx, "Card errors", "Declines", domain.SeverityMajor, []int64{checkout.ID})
+	server.incidentService.CreateIncident(ctx, "Slow search", "Latency", domain.SeverityMinor, []int64{search.ID})
+	server.incidentService.CreateIncident(ctx, "Network", "Everything", domain.SeverityCritical, nil)
+
+	get := func(slug string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/status/"+slug, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", slug)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		server.handleSystemPublicStatus(w, req)
+		return w
+	}
+
+	w := get("checkout")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got, want := w.Body.String(), "Checkout|Checkout:Payments,;|Card errors;Network;"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if w := get("unknown"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown slug, got %d", w.Code)
+	}
+}
+
+func TestHandlePublicStatus_Branding(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`{{.Title}}|{{.Branding.LogoURL}}|{{.Branding.PrimaryColor}}|{{.Branding.SupportURL}}|<style>{{.CustomCSS}}</style>`)
+
+	render := func() string {
+		req := httptest.NewRequest("GET", "/status", nil)
+		w := httptest.NewRecorder()
+		server.handlePublicStatus(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	if got, want := render(), "System Status||||<style></style>"; got != want {
+		t.Errorf("expected default branding %q, got %q", want, got)
+	}
+
+	cssPath := filepath.Join(t.TempDir(), "brand.css")
+	if err := os.WriteFile(cssPath, []byte("body{font-family:serif}"), 0644); err != nil {
+		t.Fatalf("failed to write CSS: %v", err)
+	}
+	err := server.SetBranding(BrandingConfig{
+		Title:         "Acme Status",
+		LogoURL:       "https://acme.example.com/logo.png",
+		PrimaryColor:  "#0f766e",
+		SupportURL:    "https://acme.example.com/support",
+		CustomCSSPath: cssPath,
+	})
+	if err != nil {
+		t.Fatalf("SetBranding() error = %v", err)
+	}
+
+	want := "Acme Status|https://acme.example.com/logo.png|#0f766e|https://acme.example.com/support|<style>body{font-family:serif}</style>"
+	if got := render(); got != want {
+		t.Errorf("expected branding %q, got %q", want, got)
+	}
+}
+
+func TestSetBranding_Invalid(t *testing.T) {
+	server, _, _ := setupTestServer()
+
+	if err := server.SetBranding(BrandingConfig{PrimaryColor: "red;}body{display:none"}); err == nil {
+		t.Error("expected error for an invalid primary color")
+	}
+	if err := server.SetBranding(BrandingConfig{CustomCSSPath: filepath.Join(t.TempDir(), "missing.css")}); err == nil {
+		t.Error("expected error for a missing stylesheet")
+	}
+	if server.branding.PageTitle() != DefaultStatusPageTitle {
+		t.Errorf("expected a failed call to keep the default title, got %q", server.branding.PageTitle())
+	}
+}
+
+func TestHandlePublicStatus_Localized(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.templateDir = t.TempDir()
+	writeTemplateFile(t, server.templateDir, "public.html",
+		`{{.Lang}}|{{t .Title}}|{{range .Systems}}{{.Name}}={{statusText .DisplayStatus}};{{end}}{{overallStatusText .Systems}}`)
+
+	ctx := context.Background()
+	api, _ := domain.NewSystem("API", "", "", "")
+	api.UpdateStatus(domain.StatusYellow)
+	systemRepo.Create(ctx, api)
+
+	render := func(target, acceptLanguage string) (string, string) {
+		req := httptest.NewRequest("GET", target, nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		server.handlePublicStatus(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		return w.Body.String(), w.Header().Get("Content-Language")
+	}
+
+	german := "de|Systemstatus|API=Eingeschränkt;Eingeschränkte Leistung"
+	english := "en|System Status|API=Degraded;Degraded Performance"
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		want           string
+		wantLang       string
+	}{
+		{"query param", "/status?lang=de", "", german, "de"},
+		{"accept language", "/status", "de-DE,de;q=0.9,en;q=0.8", german, "de"},
+		{"query param wins", "/status?lang=en", "de", english, "en"},
+		{"default", "/status", "", english, "en"},
+		{"unknown locale", "/status?lang=fr", "", english, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, lang := render(tt.target, tt.acceptLanguage)
+			if body != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, body)
+			}
+			if lang != tt.wantLang {
+				t.Errorf("expected Content-Language %q, got %q", tt.wantLang, lang)
+			}
+		})
+	}
+
+	// Custom titles have no catalog entry and are shown as configured
+	if err := server.SetBranding(BrandingConfig{Title: "Acme Status"}); err != nil {
+		t.Fatalf("SetBranding() error = %v", err)
+	}
+	if body, _ := render("/status?lang=de", ""); !strings.HasPrefix(body, "de|Acme Status|") {
+		t.Errorf("expected custom title kept, got %q", body)
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0.00%"},
+		{7, "7.00%"},
+		{7.1, "7.10%"},
+		{42.5, "42.50%"},
+		{87.456, "87.45%"},
+		{99.5, "99.50%"},
+		{99.999, "99.99%"},
+		{100, "100.00%"},
+	}
+	for _, tt := range tests {
+		if got := formatPercent(tt.in); got != tt.want {
+			t.Errorf("formatPercent(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0.00"},
+		{3, "3.00"},
+		{0.5, "0.50"},
+		{123.45, "123.45"},
+		{1500.25, "1500.25"},
+		{-2.5, "-2.50"},
+		{math.Inf(1), "+Inf"},
+		{math.NaN(), "NaN"},
+	}
+	for _, tt := range tests {
+		if got := formatFloat(tt.in); got != tt.want {
+			t.Errorf("formatFloat(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMetricLine_Float(t *testing.T) {
+	got := formatMetricLine("status_incident_dependency_latency_ms", 1500.25, "dependency_id", "3")
+	want := "status_incident_dependency_latency_ms{dependency_id=\"3\"} 1500.25\n"
+	if got != want {
+		t.Errorf("formatMetricLine() = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -15,6 +16,8 @@ import (
 type WebhookHandlers struct {
 	webhookRepo         domain.WebhookRepository
 	notificationService *application.NotificationService
+	systemService       *application.SystemService
+	deliveryRepo        domain.WebhookDeliveryRepository
 }
 
 // NewWebhookHandlers creates new WebhookHandlers
@@ -28,27 +31,80 @@ func NewWebhookHandlers(
 	}
 }
 
+// SetSystemService sets the system service used to validate subscriptions
+func (h *WebhookHandlers) SetSystemService(systemService *application.SystemService) {
+	h.systemService = systemService
+}
+
+// SetDeliveryRepository sets the delivery log served by GetWebhookDeliveries
+func (h *WebhookHandlers) SetDeliveryRepository(repo domain.WebhookDeliveryRepository) {
+	h.deliveryRepo = repo
+}
+
 // webhookRequest represents a webhook create/update request
 type webhookRequest struct {
-	Name      string   `json:"name"`
-	URL       string   `json:"url"`
-	Type      string   `json:"type"`
-	Events    []string `json:"events"`
-	SystemIDs []int64  `json:"system_ids"`
-	Enabled   *bool    `json:"enabled"`
+	Name           string   `json:"name"`
+	URL            string   `json:"url"`
+	Type           string   `json:"type"`
+	Events         []string `json:"events"`
+	SystemIDs      []int64  `json:"system_ids"`
+	PayloadVersion *int     `json:"payload_version"`
+	IncludeEntity  *bool    `json:"include_entity"`
+	SlackBlocks    *bool    `json:"slack_blocks"` // Slack only: Block Kit instead of legacy attachments
+	Secret         *string  `json:"secret"`       // signs generic payloads; "" clears it
+	Enabled        *bool    `json:"enabled"`
+
+	MinSeverity     *string  `json:"min_severity" example:"major"`   // incident events only; "" clears it
+	TriggerStatuses []string `json:"trigger_statuses" example:"red"` // status events only; empty means all
+
+	// PayloadTemplate is a Go text/template rendering generic webhook bodies
+	// from the payload; "" restores the standard JSON payload
+	PayloadTemplate *string `json:"payload_template"`
+}
+
+// subscribeRequest represents a system subscription request
+type subscribeRequest struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Type   string   `json:"type"`
+	Events []string `json:"events"`
 }
 
 // webhookResponse represents a webhook in API responses
 type webhookResponse struct {
-	ID        int64    `json:"id"`
-	Name      string   `json:"name"`
-	URL       string   `json:"url"`
-	Type      string   `json:"type"`
-	Events    []string `json:"events"`
-	SystemIDs []int64  `json:"system_ids,omitempty"`
-	Enabled   bool     `json:"enabled"`
-	CreatedAt string   `json:"created_at"`
-	UpdatedAt string   `json:"updated_at"`
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	URL            string   `json:"url"`
+	Type           string   `json:"type"`
+	Events         []string `json:"events"`
+	SystemIDs      []int64  `json:"system_ids,omitempty"`
+	PayloadVersion int      `json:"payload_version"`
+	IncludeEntity  bool     `json:"include_entity"`
+	SlackBlocks    bool     `json:"slack_blocks"`
+	HasSecret      bool     `json:"has_secret"` // the secret itself is never returned
+	Enabled        bool     `json:"enabled"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+
+	MinSeverity     string   `json:"min_severity,omitempty"`
+	TriggerStatuses []string `json:"trigger_statuses,omitempty"`
+	PayloadTemplate string   `json:"payload_template,omitempty"`
+
+	LastDeliveryAt      string `json:"last_delivery_at,omitempty"`
+	LastDeliveryStatus  string `json:"last_delivery_status,omitempty"`
+	LastDeliveryCode    int    `json:"last_delivery_code,omitempty"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+}
+
+// webhookDeliveryResponse represents a delivery log entry in API responses
+type webhookDeliveryResponse struct {
+	ID         int64  `json:"id"`
+	Event      string `json:"event"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Attempts   int    `json:"attempts"`
+	Error      string `json:"error,omitempty"`
+	CreatedAt  string `json:"created_at"`
 }
 
 func toWebhookResponse(w *domain.Webhook) webhookResponse {
@@ -57,17 +113,35 @@ func toWebhookResponse(w *domain.Webhook) webhookResponse {
 		events[i] = string(e)
 	}
 
-	return webhookResponse{
-		ID:        w.ID,
-		Name:      w.Name,
-		URL:       w.URL,
-		Type:      string(w.Type),
-		Events:    events,
-		SystemIDs: w.SystemIDs,
-		Enabled:   w.Enabled,
-		CreatedAt: w.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: w.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	resp := webhookResponse{
+		ID:             w.ID,
+		Name:           w.Name,
+		URL:            w.URL,
+		Type:           string(w.Type),
+		Events:         events,
+		SystemIDs:      w.SystemIDs,
+		PayloadVersion: w.PayloadVersion,
+		IncludeEntity:  w.IncludeEntity,
+		SlackBlocks:    w.SlackBlocks,
+		HasSecret:      w.Secret != "",
+		Enabled:        w.Enabled,
+		MinSeverity:    string(w.MinSeverity),
+		CreatedAt:      w.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      w.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+
+		PayloadTemplate: w.PayloadTemplate,
+
+		LastDeliveryStatus:  string(w.LastDeliveryStatus),
+		LastDeliveryCode:    w.LastDeliveryCode,
+		ConsecutiveFailures: w.ConsecutiveFailures,
 	}
+	for _, status := range w.TriggerStatuses {
+		resp.TriggerStatuses = append(resp.TriggerStatuses, string(status))
+	}
+	if w.LastDeliveryAt != nil {
+		resp.LastDeliveryAt = w.LastDeliveryAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
 }
 
 func jsonResponse(w http.ResponseWriter, data interface{}) {
@@ -84,7 +158,7 @@ func jsonError(w http.ResponseWriter, message string, status int) {
 // @Tags webhooks
 // @Produce json
 // @Success 200 {array} webhookResponse
-// @Router /api/webhooks [get]
+// @Router /webhooks [get]
 func (h *WebhookHandlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
 	webhooks, err := h.webhookRepo.GetAll(r.Context())
 	if err != nil {
@@ -108,7 +182,7 @@ func (h *WebhookHandlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
 // @Param webhook body webhookRequest true "Webhook data"
 // @Success 201 {object} webhookResponse
 // @Failure 400 {object} errorResponse
-// @Router /api/webhooks [post]
+// @Router /webhooks [post]
 func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
 	var req webhookRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -124,9 +198,10 @@ func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request)
 
 	// Set events
 	if len(req.Events) > 0 {
-		events := make([]domain.WebhookEvent, len(req.Events))
-		for i, e := range req.Events {
-			events[i] = domain.WebhookEvent(e)
+		events, err := parseWebhookEvents(req.Events)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 		webhook.SetEvents(events)
 	}
@@ -136,6 +211,51 @@ func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request)
 		webhook.SetSystemIDs(req.SystemIDs)
 	}
 
+	// Pin payload version
+	if req.PayloadVersion != nil {
+		if err := webhook.SetPayloadVersion(*req.PayloadVersion); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Embed full entities
+	if req.IncludeEntity != nil {
+		webhook.SetIncludeEntity(*req.IncludeEntity)
+	}
+
+	// Render Slack messages with Block Kit
+	if req.SlackBlocks != nil {
+		webhook.SetSlackBlocks(*req.SlackBlocks)
+	}
+
+	// Set payload template
+	if req.PayloadTemplate != nil {
+		if err := webhook.SetPayloadTemplate(*req.PayloadTemplate); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Sign payloads
+	if req.Secret != nil {
+		webhook.SetSecret(*req.Secret)
+	}
+
+	// Set filters
+	if req.MinSeverity != nil {
+		if err := webhook.SetMinSeverity(domain.IncidentSeverity(*req.MinSeverity)); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if len(req.TriggerStatuses) > 0 {
+		if err := webhook.SetTriggerStatuses(parseTriggerStatuses(req.TriggerStatuses)); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Set enabled
 	if req.Enabled != nil && !*req.Enabled {
 		webhook.Disable()
@@ -157,7 +277,7 @@ func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request)
 // @Param id path int true "Webhook ID"
 // @Success 200 {object} webhookResponse
 // @Failure 404 {object} errorResponse
-// @Router /api/webhooks/{id} [get]
+// @Router /webhooks/{id} [get]
 func (h *WebhookHandlers) GetWebhook(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -187,7 +307,7 @@ func (h *WebhookHandlers) GetWebhook(w http.ResponseWriter, r *http.Request) {
 // @Param webhook body webhookRequest true "Webhook data"
 // @Success 200 {object} webhookResponse
 // @Failure 400,404 {object} errorResponse
-// @Router /api/webhooks/{id} [put]
+// @Router /webhooks/{id} [put]
 func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -218,9 +338,10 @@ func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request)
 
 	// Update events
 	if len(req.Events) > 0 {
-		events := make([]domain.WebhookEvent, len(req.Events))
-		for i, e := range req.Events {
-			events[i] = domain.WebhookEvent(e)
+		events, err := parseWebhookEvents(req.Events)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 		webhook.SetEvents(events)
 	}
@@ -228,6 +349,49 @@ func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request)
 	// Update system IDs
 	webhook.SetSystemIDs(req.SystemIDs)
 
+	// Update payload version
+	if req.PayloadVersion != nil {
+		if err := webhook.SetPayloadVersion(*req.PayloadVersion); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Update entity embedding
+	if req.IncludeEntity != nil {
+		webhook.SetIncludeEntity(*req.IncludeEntity)
+	}
+
+	// Update Slack rendering
+	if req.SlackBlocks != nil {
+		webhook.SetSlackBlocks(*req.SlackBlocks)
+	}
+
+	// Update payload template
+	if req.PayloadTemplate != nil {
+		if err := webhook.SetPayloadTemplate(*req.PayloadTemplate); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Update signing secret
+	if req.Secret != nil {
+		webhook.SetSecret(*req.Secret)
+	}
+
+	// Update filters
+	if req.MinSeverity != nil {
+		if err := webhook.SetMinSeverity(domain.IncidentSeverity(*req.MinSeverity)); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := webhook.SetTriggerStatuses(parseTriggerStatuses(req.TriggerStatuses)); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Update enabled
 	if req.Enabled != nil {
 		if *req.Enabled {
@@ -251,7 +415,7 @@ func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request)
 // @Param id path int true "Webhook ID"
 // @Success 204
 // @Failure 404 {object} errorResponse
-// @Router /api/webhooks/{id} [delete]
+// @Router /webhooks/{id} [delete]
 func (h *WebhookHandlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -279,11 +443,14 @@ func (h *WebhookHandlers) DeleteWebhook(w http.ResponseWriter, r *http.Request)
 
 // TestWebhook handles POST /api/webhooks/{id}/test
 // @Summary Send a test notification to a webhook
+// @Description Sends a sample status change, or a sample SLA breach with event=sla_breach. Test notifications ignore the webhook's event subscriptions and filters.
 // @Tags webhooks
 // @Param id path int true "Webhook ID"
-// @Success 200 {object} map[string]string
-// @Failure 404 {object} errorResponse
-// @Router /api/webhooks/{id}/test [post]
+// @Param event query string false "Event format to preview" Enums(status_change, sla_breach)
+// @Produce json
+// @Success 200 {object} actionStatusResponse
+// @Failure 400,404 {object} errorResponse
+// @Router /webhooks/{id}/test [post]
 func (h *WebhookHandlers) TestWebhook(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -291,10 +458,169 @@ func (h *WebhookHandlers) TestWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.notificationService.SendTestNotification(r.Context(), id); err != nil {
+	send := h.notificationService.SendTestNotification
+	switch event := domain.WebhookEvent(r.URL.Query().Get("event")); event {
+	case "", domain.EventStatusChange:
+	case domain.EventSLABreach:
+		send = h.notificationService.SendTestSLABreach
+	default:
+		jsonError(w, fmt.Sprintf("unsupported test event: %s", event), http.StatusBadRequest)
+		return
+	}
+
+	if err := send(r.Context(), id); err != nil {
 		jsonError(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
-	jsonResponse(w, map[string]string{"status": "sent"})
+	jsonResponse(w, actionStatusResponse{Status: "sent"})
+}
+
+// GetWebhookDeliveries handles GET /api/webhooks/{id}/deliveries
+// @Summary List recent deliveries of a webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param limit query int false "Maximum number of deliveries (default 50)"
+// @Success 200 {array} webhookDeliveryResponse
+// @Failure 404 {object} errorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandlers) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		jsonError(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookRepo.GetByID(r.Context(), id)
+	if err != nil {
+		jsonError(w, "Failed to get webhook", http.StatusInternalServerError)
+		return
+	}
+	if webhook == nil {
+		jsonError(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	limit := domain.WebhookDeliveryHistoryLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed < limit {
+			limit = parsed
+		}
+	}
+
+	response := []webhookDeliveryResponse{}
+	if h.deliveryRepo != nil {
+		deliveries, err := h.deliveryRepo.GetByWebhook(r.Context(), id, limit)
+		if err != nil {
+			jsonError(w, "Failed to get webhook deliveries", http.StatusInternalServerError)
+			return
+		}
+		for _, d := range deliveries {
+			response = append(response, webhookDeliveryResponse{
+				ID:         d.ID,
+				Event:      string(d.Event),
+				Status:     string(d.Status),
+				StatusCode: d.StatusCode,
+				Attempts:   d.Attempts,
+				Error:      d.Error,
+				CreatedAt:  d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			})
+		}
+	}
+
+	jsonResponse(w, response)
+}
+
+// SubscribeToSystem handles POST /api/systems/{id}/subscribe
+// @Summary Subscribe a webhook to a system
+// @Description Register a webhook that only receives notifications for the given system
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "System ID"
+// @Param subscription body subscribeRequest true "Subscription data"
+// @Success 201 {object} webhookResponse
+// @Failure 400,404 {object} errorResponse
+// @Router /systems/{id}/subscribe [post]
+func (h *WebhookHandlers) SubscribeToSystem(w http.ResponseWriter, r *http.Request) {
+	systemID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		jsonError(w, "Invalid system ID", http.StatusBadRequest)
+		return
+	}
+
+	var req subscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	name := req.Name
+	if h.systemService != nil {
+		system, err := h.systemService.GetSystem(r.Context(), systemID)
+		if err != nil {
+			jsonError(w, "Failed to get system", http.StatusInternalServerError)
+			return
+		}
+		if system == nil {
+			jsonError(w, "System not found", http.StatusNotFound)
+			return
+		}
+		if name == "" {
+			name = system.Name + " subscription"
+		}
+	}
+	if name == "" {
+		name = "System " + strconv.FormatInt(systemID, 10) + " subscription"
+	}
+
+	webhook, err := domain.NewWebhook(name, req.URL, domain.WebhookType(req.Type))
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Events) > 0 {
+		events, err := parseWebhookEvents(req.Events)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		webhook.SetEvents(events)
+	}
+	webhook.SetSystemIDs([]int64{systemID})
+
+	if err := h.webhookRepo.Create(r.Context(), webhook); err != nil {
+		jsonError(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResponse(w, toWebhookResponse(webhook))
+}
+
+// parseWebhookEvents converts event names, rejecting unknown events so a
+// typo does not silently leave a webhook without notifications
+func parseWebhookEvents(names []string) ([]domain.WebhookEvent, error) {
+	events := make([]domain.WebhookEvent, len(names))
+	for i, name := range names {
+		event := domain.WebhookEvent(name)
+		if !domain.IsValidWebhookEvent(event) {
+			return nil, fmt.Errorf("unknown webhook event: %s", name)
+		}
+		events[i] = event
+	}
+	return events, nil
+}
+
+func parseTriggerStatuses(names []string) []domain.Status {
+	if len(names) == 0 {
+		return nil
+	}
+	statuses := make([]domain.Status, len(names))
+	for i, name := range names {
+		statuses[i] = domain.Status(name)
+	}
+	return statuses
 }
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// WebSocket timing
+const (
+	// defaultWSPingInterval is how often connections are pinged to keep
+	// proxies from closing them and to notice clients that went away
+	defaultWSPingInterval = 30 * time.Second
+	// wsWriteTimeout bounds each write so a stalled client is dropped
+	wsWriteTimeout = 10 * time.Second
+)
+
+// wsClientMessage is a message sent by a WebSocket client
+type wsClientMessage struct {
+	Type      string  `json:"type"` // "subscribe" or "ping"
+	SystemIDs []int64 `json:"system_ids,omitempty"`
+}
+
+// wsServerMessage is a message sent to WebSocket clients
+type wsServerMessage struct {
+	Type      string      `json:"type"`
+	SystemIDs []int64     `json:"system_ids,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// handleWebSocket streams status changes and incident events over a
+// WebSocket until either side closes it. Clients narrow the stream with
+// {"type": "subscribe", "system_ids": [1, 2]} (an empty list means all
+// systems) and may send {"type": "ping"} to get a pong.
+// GET /ws
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if s.eventBroker == nil {
+		w.Header().Set("Content-Type", "application/json")
+		s.respondError(w, http.StatusServiceUnavailable, "event stream is not enabled")
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkWebSocketOrigin,
+		Handler: func(ws *websocket.Conn) {
+			s.serveWebSocket(r.Context(), ws)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// checkWebSocketOrigin only lets browsers connect from pages served by this
+// host, so other sites cannot use an admin's session cookie. Clients that
+// send no Origin, such as scripts, are allowed.
+func checkWebSocketOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host != r.Host {
+		return errors.New("cross-origin WebSocket request")
+	}
+	config.Origin = u
+	return nil
+}
+
+// serveWebSocket runs one WebSocket connection. Client messages are read on
+// their own goroutine and handled here, so this is the only writer.
+func (s *Server) serveWebSocket(ctx context.Context, ws *websocket.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer ws.Close()
+
+	events, unsubscribe := s.eventBroker.Subscribe()
+	defer unsubscribe()
+
+	incoming := make(chan []byte)
+	go func() {
+		defer close(incoming)
+		for {
+			var data []byte
+			if err := websocket.Message.Receive(ws, &data); err != nil {
+				return
+			}
+			select {
+			case incoming <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	pingInterval := s.wsPingInterval
+	if pingInterval <= 0 {
+		pingInterval = defaultWSPingInterval
+	}
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	var filter map[int64]bool
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case data, ok := <-incoming:
+			if !ok {
+				return
+			}
+			var msg wsClientMessage
+			if json.Unmarshal(data, &msg) != nil {
+				err = writeWebSocketJSON(ws, wsServerMessage{Type: "error", Error: "invalid message"})
+				break
+			}
+			switch msg.Type {
+			case "subscribe":
+				filter = make(map[int64]bool, len(msg.SystemIDs))
+				for _, id := range msg.SystemIDs {
+					filter[id] = true
+				}
+				err = writeWebSocketJSON(ws, wsServerMessage{Type: "subscribed", SystemIDs: msg.SystemIDs})
+			case "ping":
+				err = writeWebSocketJSON(ws, wsServerMessage{Type: "pong"})
+			default:
+				err = writeWebSocketJSON(ws, wsServerMessage{Type: "error", Error: "unknown message type"})
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Concerns(filter) {
+				err = writeWebSocketJSON(ws, wsServerMessage{Type: event.Type, Data: event.Data})
+			}
+		case <-ping.C:
+			// The client answers with a pong, which the library consumes
+			err = writeWebSocketPing(ws)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeWebSocketJSON sends v as a text frame
+func writeWebSocketJSON(ws *websocket.Conn, v interface{}) error {
+	ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return websocket.JSON.Send(ws, v)
+}
+
+// writeWebSocketPing sends a ping control frame
+func writeWebSocketPing(ws *websocket.Conn) error {
+	ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	ws.PayloadType = websocket.PingFrame
+	defer func() { ws.PayloadType = websocket.TextFrame }()
+	_, err := ws.Write(nil)
+	return err
+}
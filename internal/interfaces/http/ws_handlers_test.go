@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"status-incident/internal/application"
+	"status-incident/internal/domain"
+)
+
+// dialTestWebSocket connects to the server's WebSocket endpoint as a page
+// served by the same host
+func dialTestWebSocket(t *testing.T, ts *httptest.Server, origin string) (*websocket.Conn, error) {
+	t.Helper()
+	if origin == "" {
+		origin = ts.URL
+	}
+	return websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), "", origin)
+}
+
+// receiveWSMessage reads the next message or fails after a timeout
+func receiveWSMessage(t *testing.T, ws *websocket.Conn) map[string]interface{} {
+	t.Helper()
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]interface{}
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		t.Fatalf("failed to receive message: %v", err)
+	}
+	return msg
+}
+
+func TestHandleWebSocket_SubscribeFiltersSystems(t *testing.T) {
+	server, systemRepo, _ := setupTestServer()
+	server.wsPingInterval = 20 * time.Millisecond
+	broker := application.NewStatusEventBroker()
+	server.SetEventBroker(broker)
+	server.systemService.SetEventBroker(broker)
+
+	ctx := context.Background()
+	api, _ := domain.NewSystem("API", "", "", "")
+	systemRepo.Create(ctx, api)
+	web, _ := domain.NewSystem("Web", "", "", "")
+	systemRepo.Create(ctx, web)
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer ts.Close()
+
+	ws, err := dialTestWebSocket(t, ts, "")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer ws.Close()
+
+	if err := websocket.JSON.Send(ws, map[string]interface{}{"type": "subscribe", "system_ids": []int64{api.ID}}); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	if msg := receiveWSMessage(t, ws); msg["type"] != "subscribed" {
+		t.Fatalf("expected subscribed, got %v", msg)
+	}
+
+	// Outlive a few keepalive pings before anything is sent
+	time.Sleep(100 * time.Millisecond)
+
+	server.systemService.UpdateSystemStatus(ctx, web.ID, "red", "Web outage")
+	server.systemService.UpdateSystemStatus(ctx, api.ID, "yellow", "API slow")
+
+	msg := receiveWSMessage(t, ws)
+	data, _ := msg["data"].(map[string]interface{})
+	if msg["type"] != "status_change" || data["system_id"] != float64(api.ID) || data["new_status"] != "yellow" {
+		t.Fatalf("expected only the API status change, got %v", msg)
+	}
+
+	websocket.JSON.Send(ws, map[string]string{"type": "ping"})
+	if msg := receiveWSMessage(t, ws); msg["type"] != "pong" {
+		t.Errorf("expected pong, got %v", msg)
+	}
+
+	// Closing the connection removes the subscriber
+	ws.Close()
+	waitFor(t, func() bool { return broker.SubscriberCount() == 0 })
+}
+
+func TestHandleWebSocket_BrokerClosed(t *testing.T) {
+	server, _, _ := setupTestServer()
+	broker := application.NewStatusEventBroker()
+	server.SetEventBroker(broker)
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer ts.Close()
+
+	ws, err := dialTestWebSocket(t, ts, "")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer ws.Close()
+	waitFor(t, func() bool { return broker.SubscriberCount() == 1 })
+
+	// Shutting down ends the connection
+	broker.Close()
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]interface{}
+	if err := websocket.JSON.Receive(ws, &msg); err == nil {
+		t.Errorf("expected the connection to be closed, got %v", msg)
+	}
+}
+
+func TestHandleWebSocket_RejectsCrossOrigin(t *testing.T) {
+	server, _, _ := setupTestServer()
+	server.SetEventBroker(application.NewStatusEventBroker())
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer ts.Close()
+
+	if ws, err := dialTestWebSocket(t, ts, "https://evil.example.com"); err == nil {
+		ws.Close()
+		t.Error("expected a cross-origin connection to be rejected")
+	}
+}
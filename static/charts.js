@@ -189,8 +189,10 @@ const Charts = {
             fetch(`/api/dependencies/${dependencyId}/latency?period=24h`)
                 .then(r => r.json())
                 .then(data => {
-                    if (data.data_points && data.data_points.length > 0) {
-                        this.createLatencyChart(containerId + '-latency', data.data_points);
+                    // Buckets without checks come back with a zero count
+                    const points = (data.data_points || []).filter(p => p.count > 0);
+                    if (points.length > 0) {
+                        this.createLatencyChart(containerId + '-latency', points);
                     } else {
                         latencyContainer.innerHTML = '<p class="chart-empty">No latency data yet. Data will appear after heartbeat checks.</p>';
                     }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+
+	"status-incident/internal/domain"
+	"status-incident/internal/infrastructure/postgres"
+	"status-incident/internal/infrastructure/sqlite"
+)
+
+// Supported -db-driver values
+const (
+	driverSQLite   = "sqlite"
+	driverPostgres = "postgres"
+)
+
+// repositories holds the repositories of the selected storage backend
+type repositories struct {
+	systems            domain.SystemRepository
+	dependencies       domain.DependencyRepository
+	logs               domain.StatusLogRepository
+	analytics          domain.AnalyticsRepository
+	webhooks           domain.WebhookRepository
+	webhookDeliveries  domain.WebhookDeliveryRepository
+	maintenances       domain.MaintenanceRepository
+	incidents          domain.IncidentRepository
+	incidentTemplates  domain.IncidentTemplateRepository
+	apiKeys            domain.APIKeyRepository
+	latency            domain.LatencyRepository
+	slaReports         domain.SLAReportRepository
+	slaBreaches        domain.SLABreachRepository
+	subscribers        domain.SubscriberRepository
+	systemDependencies domain.SystemDependencyRepository
+
+	close func() error
+}
+
+// openRepositories connects to the database for driver, runs its migrations
+// and builds the repositories. dsn is a file path for SQLite and a
+// connection string for PostgreSQL.
+func openRepositories(driver, dsn string) (*repositories, error) {
+	switch driver {
+	case driverSQLite:
+		db, err := sqlite.New(dsn)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Migrate(); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+		return &repositories{
+			systems:            sqlite.NewSystemRepo(db),
+			dependencies:       sqlite.NewDependencyRepo(db),
+			logs:               sqlite.NewLogRepo(db),
+			analytics:          sqlite.NewAnalyticsRepo(db),
+			webhooks:           sqlite.NewWebhookRepo(db),
+			webhookDeliveries:  sqlite.NewWebhookDeliveryRepo(db),
+			maintenances:       sqlite.NewMaintenanceRepo(db),
+			incidents:          sqlite.NewIncidentRepo(db),
+			incidentTemplates:  sqlite.NewIncidentTemplateRepo(db),
+			apiKeys:            sqlite.NewAPIKeyRepo(db),
+			latency:            sqlite.NewLatencyRepo(db),
+			slaReports:         sqlite.NewSLAReportRepo(db),
+			slaBreaches:        sqlite.NewSLABreachRepo(db),
+			subscribers:        sqlite.NewSubscriberRepo(db),
+			systemDependencies: sqlite.NewSystemDependencyRepo(db),
+			close:              db.Close,
+		}, nil
+
+	case driverPostgres:
+		db, err := postgres.New(dsn)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Migrate(); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+		return &repositories{
+			systems:            postgres.NewSystemRepo(db),
+			dependencies:       postgres.NewDependencyRepo(db),
+			logs:               postgres.NewLogRepo(db),
+			analytics:          postgres.NewAnalyticsRepo(db),
+			webhooks:           postgres.NewWebhookRepo(db),
+			webhookDeliveries:  postgres.NewWebhookDeliveryRepo(db),
+			maintenances:       postgres.NewMaintenanceRepo(db),
+			incidents:          postgres.NewIncidentRepo(db),
+			incidentTemplates:  postgres.NewIncidentTemplateRepo(db),
+			apiKeys:            postgres.NewAPIKeyRepo(db),
+			latency:            postgres.NewLatencyRepo(db),
+			slaReports:         postgres.NewSLAReportRepo(db),
+			slaBreaches:        postgres.NewSLABreachRepo(db),
+			subscribers:        postgres.NewSubscriberRepo(db),
+			systemDependencies: postgres.NewSystemDependencyRepo(db),
+			close:              db.Close,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown database driver %q (want %s or %s)", driver, driverSQLite, driverPostgres)
+	}
+}